@@ -0,0 +1,123 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EcosystemUpgradePhase describes the progress of an ecosystem upgrade.
+type EcosystemUpgradePhase string
+
+const (
+	// EcosystemUpgradePhaseUpgrading means that the plan has been validated and the dogus are upgraded one after another.
+	EcosystemUpgradePhaseUpgrading EcosystemUpgradePhase = "Upgrading"
+	// EcosystemUpgradePhaseSucceeded means that all dogus of the plan have been upgraded.
+	EcosystemUpgradePhaseSucceeded EcosystemUpgradePhase = "Succeeded"
+	// EcosystemUpgradePhaseFailed means that the plan is invalid or the upgrade of a dogu failed.
+	EcosystemUpgradePhaseFailed EcosystemUpgradePhase = "Failed"
+)
+
+// DoguUpgradePhase describes the progress of the upgrade of a single dogu within an ecosystem upgrade.
+type DoguUpgradePhase string
+
+const (
+	// DoguUpgradePhasePending means that the dogu waits for the upgrades of the dogus before it.
+	DoguUpgradePhasePending DoguUpgradePhase = "Pending"
+	// DoguUpgradePhaseUpgrading means that the version of the dogu resource has been changed and the dogu is upgraded.
+	DoguUpgradePhaseUpgrading DoguUpgradePhase = "Upgrading"
+	// DoguUpgradePhaseSucceeded means that the target version is installed and the dogu is healthy.
+	DoguUpgradePhaseSucceeded DoguUpgradePhase = "Succeeded"
+	// DoguUpgradePhaseSkipped means that the dogu is not upgraded, e.g. because the target version is already installed.
+	DoguUpgradePhaseSkipped DoguUpgradePhase = "Skipped"
+	// DoguUpgradePhaseFailed means that the upgrade of the dogu failed. No further dogus are upgraded.
+	DoguUpgradePhaseFailed DoguUpgradePhase = "Failed"
+)
+
+// EcosystemUpgradeSpec defines the dogus to upgrade. The spec cannot be changed after the creation of the resource.
+// +kubebuilder:validation:XValidation:rule="has(self.dogus) != (has(self.allToLatestPatch) && self.allToLatestPatch)",message="either dogus or allToLatestPatch must be set"
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+type EcosystemUpgradeSpec struct {
+	// Dogus lists the dogus to upgrade together with their target versions. The order of the list does not matter.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Dogus []DoguVersion `json:"dogus,omitempty"`
+	// AllToLatestPatch upgrades every installed dogu to its latest release if that release only differs from the
+	// installed version in the patch level, i.e. has the same major and minor version.
+	// +optional
+	AllToLatestPatch bool `json:"allToLatestPatch,omitempty"`
+}
+
+// DoguVersion names a dogu and the version it is upgraded to.
+type DoguVersion struct {
+	// Name is the simple name of the dogu, e.g. "redmine".
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Version is the target version of the dogu, e.g. "5.1.3-2".
+	// +kubebuilder:validation:MinLength=1
+	Version string `json:"version"`
+}
+
+// EcosystemUpgradeStatus shows the plan of the ecosystem upgrade and the progress of every dogu.
+type EcosystemUpgradeStatus struct {
+	// Phase is the progress of the whole ecosystem upgrade.
+	// +optional
+	Phase EcosystemUpgradePhase `json:"phase,omitempty"`
+	// Message describes why the upgrade failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Dogus contains the dogus of the plan in the order in which they are upgraded.
+	// +optional
+	Dogus []DoguUpgradeStatus `json:"dogus,omitempty"`
+	// ObservedGeneration is the generation of the spec the plan was created from.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// DoguUpgradeStatus shows the progress of the upgrade of a single dogu.
+type DoguUpgradeStatus struct {
+	// Name is the simple name of the dogu.
+	Name string `json:"name"`
+	// FromVersion is the version which was installed when the plan was created.
+	FromVersion string `json:"fromVersion"`
+	// Version is the target version of the dogu.
+	Version string `json:"version"`
+	// Phase is the progress of the upgrade of the dogu.
+	Phase DoguUpgradePhase `json:"phase"`
+	// Message describes why the dogu was skipped or its upgrade failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// StartedAt is the time at which the version of the dogu resource was changed.
+	// +optional
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+	// FinishedAt is the time at which the upgrade of the dogu succeeded or failed.
+	// +optional
+	FinishedAt *metav1.Time `json:"finishedAt,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=ecoup
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// EcosystemUpgrade upgrades several dogus of a namespace one after another in the order of their dependencies.
+type EcosystemUpgrade struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EcosystemUpgradeSpec   `json:"spec,omitempty"`
+	Status EcosystemUpgradeStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EcosystemUpgradeList contains a list of EcosystemUpgrade.
+type EcosystemUpgradeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EcosystemUpgrade `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EcosystemUpgrade{}, &EcosystemUpgradeList{})
+}
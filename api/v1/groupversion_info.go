@@ -0,0 +1,22 @@
+// Package v1 contains the API types of the k8s-dogu-operator in the API group k8s.cloudogu.com.
+// The types belong next to the Dogu resource in k8s-dogu-lib. They stay here until they are released there, since
+// k8s-dogu-lib is versioned separately; the CRD and the clients must be switched to the library together.
+// +kubebuilder:object:generate=true
+// +groupName=k8s.cloudogu.com
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+var (
+	// GroupVersion is group version used to register these objects.
+	GroupVersion = schema.GroupVersion{Group: "k8s.cloudogu.com", Version: "v1"}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme.
+	SchemeBuilder = &scheme.Builder{GroupVersion: GroupVersion}
+
+	// AddToScheme adds the types in this group-version to the given scheme.
+	AddToScheme = SchemeBuilder.AddToScheme
+)
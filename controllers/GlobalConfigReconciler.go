@@ -5,6 +5,7 @@ import (
 
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/namespaced"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -38,7 +39,9 @@ func NewGlobalConfigReconciler(
 	return r, nil
 }
 
-func (r *GlobalConfigReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+// Reconcile triggers a reconcile of all dogus in the namespace of the changed global config.
+func (r *GlobalConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx = namespaced.WithNamespace(ctx, req.Namespace)
 	doguList, err := r.doguInterface.List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return ctrl.Result{}, err
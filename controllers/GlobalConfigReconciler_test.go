@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/namespaced"
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -50,11 +51,11 @@ func TestGlobalConfigReconciler_Reconcile(t *testing.T) {
 	}{
 		{
 			name: "should fail to list dogus",
-			req:  controllerruntime.Request{NamespacedName: types.NamespacedName{Name: globalConfigMapName}},
+			req:  controllerruntime.Request{NamespacedName: types.NamespacedName{Name: globalConfigMapName, Namespace: "ecosystem"}},
 			fields: fields{
 				doguInterfaceFn: func(t *testing.T) doguInterface {
 					mck := newMockDoguInterface(t)
-					mck.EXPECT().List(testCtx, v1.ListOptions{}).Return(nil, assert.AnError)
+					mck.EXPECT().List(namespaced.WithNamespace(testCtx, "ecosystem"), v1.ListOptions{}).Return(nil, assert.AnError)
 					return mck
 				},
 				doguEvents: NewDoguEvents(),
@@ -47,6 +47,49 @@ func NewValidator(configMapGetter corev1.ConfigMapInterface, secretGatter corev1
 
 // ValidateAdditionalMounts validates the additional mounts from the dogu resource and dogu.json
 func (v *validator) ValidateAdditionalMounts(ctx context.Context, doguDescriptor *core.Dogu, doguResource *k8sv2.Dogu) error {
+	return errors.Join(validateMounts(doguDescriptor, doguResource, func(dataMount k8sv2.DataMount) error {
+		// check if the source really exists
+		err := v.validateSource(ctx, dataMount)
+		if err != nil {
+			return &requeueableValidationError{err}
+		}
+		return nil
+	})...)
+}
+
+// ValidateVolumes validates the additional mounts from the dogu resource against the volumes of the dogu.json.
+// Unlike Validator.ValidateAdditionalMounts, it does not check whether the sources of the mounts exist, as they may
+// still be created after the dogu resource, and it leaves duplicate entries to ValidateUniqueMounts.
+func ValidateVolumes(doguDescriptor *core.Dogu, doguResource *k8sv2.Dogu) error {
+	return errors.Join(validateMountVolumes(doguDescriptor, doguResource, func(k8sv2.DataMount) error { return nil })...)
+}
+
+// ValidateUniqueMounts validates that the additional mounts of the dogu resource contain no duplicate entries. Unlike
+// ValidateVolumes, it does not need the dogu.json.
+func ValidateUniqueMounts(doguResource *k8sv2.Dogu) error {
+	return errors.Join(validateUniqueMounts(doguResource)...)
+}
+
+func validateMounts(doguDescriptor *core.Dogu, doguResource *k8sv2.Dogu, validateSource func(dataMount k8sv2.DataMount) error) []error {
+	return append(validateUniqueMounts(doguResource), validateMountVolumes(doguDescriptor, doguResource, validateSource)...)
+}
+
+func validateUniqueMounts(doguResource *k8sv2.Dogu) []error {
+	var multiErr []error
+	var additionalMounts = make(map[k8sv2.DataMount]struct{})
+
+	for _, dataMount := range doguResource.Spec.AdditionalMounts {
+		if _, ok := additionalMounts[dataMount]; ok {
+			multiErr = append(multiErr, fmt.Errorf("duplicate entry %+v", dataMount))
+			continue
+		}
+		additionalMounts[dataMount] = struct{}{}
+	}
+
+	return multiErr
+}
+
+func validateMountVolumes(doguDescriptor *core.Dogu, doguResource *k8sv2.Dogu, validateSource func(dataMount k8sv2.DataMount) error) []error {
 	var multiErr []error
 	var additionalMounts = make(map[k8sv2.DataMount]struct{})
 
@@ -55,9 +98,8 @@ func (v *validator) ValidateAdditionalMounts(ctx context.Context, doguDescriptor
 	}
 
 	for _, dataMount := range doguResource.Spec.AdditionalMounts {
-		// check for duplicate entries
+		// duplicate entries are reported by validateUniqueMounts
 		if _, ok := additionalMounts[dataMount]; ok {
-			multiErr = append(multiErr, fmt.Errorf("duplicate entry %+v", dataMount))
 			continue
 		}
 		additionalMounts[dataMount] = struct{}{}
@@ -70,14 +112,13 @@ func (v *validator) ValidateAdditionalMounts(ctx context.Context, doguDescriptor
 			multiErr = append(multiErr, fmt.Errorf("volume %s with volumeclients is currently not supported for addtitionalMounts on dogu %s", dataMount.Volume, doguResource.Name))
 		}
 
-		// check if the source really exists
-		err := v.validateSource(ctx, dataMount)
+		err := validateSource(dataMount)
 		if err != nil {
-			multiErr = append(multiErr, &requeueableValidationError{err})
+			multiErr = append(multiErr, err)
 		}
 	}
 
-	return errors.Join(multiErr...)
+	return multiErr
 }
 
 func getDoguVolumeWithName(dogu *core.Dogu, volume string) *core.Volume {
@@ -336,3 +336,70 @@ func TestNewValidator(t *testing.T) {
 		assert.Equal(t, secretMapMock, sut.(*validator).secretInterface)
 	})
 }
+
+func TestValidateVolumes(t *testing.T) {
+	doguDescriptor := &core.Dogu{
+		Volumes: []core.Volume{
+			{Name: "customhtml", Path: "/var/www/customhtml"},
+			{Name: "localConfig", Path: "/var/ces/config"},
+		},
+	}
+
+	t.Run("should not check the sources of the mounts", func(t *testing.T) {
+		// given
+		doguResource := &k8sv2.Dogu{Spec: k8sv2.DoguSpec{AdditionalMounts: []k8sv2.DataMount{
+			{SourceType: k8sv2.DataSourceConfigMap, Name: "not-yet-created", Volume: "customhtml"},
+		}}}
+
+		// when
+		err := ValidateVolumes(doguDescriptor, doguResource)
+
+		// then
+		assert.NoError(t, err)
+	})
+	t.Run("should fail for unknown volumes and leave duplicates to ValidateUniqueMounts", func(t *testing.T) {
+		// given
+		doguResource := &k8sv2.Dogu{
+			ObjectMeta: v1.ObjectMeta{Name: "nginx"},
+			Spec: k8sv2.DoguSpec{AdditionalMounts: []k8sv2.DataMount{
+				{SourceType: k8sv2.DataSourceConfigMap, Name: "configmap1", Volume: "unknown"},
+				{SourceType: k8sv2.DataSourceConfigMap, Name: "configmap1", Volume: "unknown"},
+			}},
+		}
+
+		// when
+		err := ValidateVolumes(doguDescriptor, doguResource)
+
+		// then
+		assert.EqualError(t, err, "volume unknown does not exists in dogu descriptor for dogu nginx")
+	})
+}
+
+func TestValidateUniqueMounts(t *testing.T) {
+	t.Run("should accept unique mounts", func(t *testing.T) {
+		// given
+		doguResource := &k8sv2.Dogu{Spec: k8sv2.DoguSpec{AdditionalMounts: []k8sv2.DataMount{
+			{SourceType: k8sv2.DataSourceConfigMap, Name: "configmap1", Volume: "customhtml"},
+			{SourceType: k8sv2.DataSourceConfigMap, Name: "configmap2", Volume: "customhtml"},
+		}}}
+
+		// when
+		err := ValidateUniqueMounts(doguResource)
+
+		// then
+		assert.NoError(t, err)
+	})
+	t.Run("should fail for duplicate mounts", func(t *testing.T) {
+		// given
+		doguResource := &k8sv2.Dogu{Spec: k8sv2.DoguSpec{AdditionalMounts: []k8sv2.DataMount{
+			{SourceType: k8sv2.DataSourceConfigMap, Name: "configmap1", Volume: "unknown"},
+			{SourceType: k8sv2.DataSourceConfigMap, Name: "configmap1", Volume: "unknown"},
+		}}}
+
+		// when
+		err := ValidateUniqueMounts(doguResource)
+
+		// then
+		assert.ErrorContains(t, err, "duplicate entry")
+	})
+}
@@ -490,6 +490,10 @@ func (m *mockLocalDoguFetcher) FetchInstalled(ctx context.Context, name cescommo
 	return obj.(*cesappcore.Dogu), args.Error(1)
 }
 
+func (m *mockLocalDoguFetcher) FetchAllInstalled(context.Context) ([]*cesappcore.Dogu, error) {
+	panic("FetchAllInstalled should not be called in auth registration manager tests")
+}
+
 func (m *mockLocalDoguFetcher) Enabled(context.Context, cescommons.SimpleName) (bool, error) {
 	panic("Enabled should not be called in auth registration manager tests")
 }
@@ -5,14 +5,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
 
 	cescommons "github.com/cloudogu/ces-commons-lib/dogu"
 	cloudoguerrors "github.com/cloudogu/ces-commons-lib/errors"
 	"github.com/cloudogu/cesapp-lib/core"
 	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/tracing"
 	"github.com/cloudogu/retry-lib/retry"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -63,6 +67,30 @@ func (df *localDoguFetcher) FetchInstalled(ctx context.Context, doguName cescomm
 	return installedDogu, nil
 }
 
+// FetchAllInstalled fetches the descriptors of the current versions of all installed dogus from the local registry.
+// The descriptors are sorted by the simple name of the dogu.
+func (df *localDoguFetcher) FetchAllInstalled(ctx context.Context) ([]*core.Dogu, error) {
+	currentVersions, err := df.doguVersionRegistry.GetCurrentOfAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current versions of all dogus: %w", err)
+	}
+
+	descriptors, err := df.doguRepository.GetAll(ctx, currentVersions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current dogu descriptors: %w", err)
+	}
+
+	installedDogus := make([]*core.Dogu, 0, len(descriptors))
+	for _, descriptor := range descriptors {
+		installedDogus = append(installedDogus, descriptor)
+	}
+	slices.SortFunc(installedDogus, func(a, b *core.Dogu) int {
+		return strings.Compare(a.GetSimpleName(), b.GetSimpleName())
+	})
+
+	return installedDogus, nil
+}
+
 func (df *localDoguFetcher) Enabled(ctx context.Context, doguName cescommons.SimpleName) (bool, error) {
 	return checkDoguVersionEnabled(ctx, df.doguVersionRegistry, doguName)
 }
@@ -97,7 +125,13 @@ func (df *localDoguFetcher) getLocalDogu(ctx context.Context, fromDoguName cesco
 
 // FetchWithResource fetches the dogu either from the remote dogu registry or from a local development dogu map and
 // returns it with patched dogu dependencies (which otherwise might be incompatible with K8s CES).
-func (rdf *resourceDoguFetcher) FetchWithResource(ctx context.Context, doguResource *doguv2.Dogu) (*core.Dogu, *doguv2.DevelopmentDoguMap, error) {
+func (rdf *resourceDoguFetcher) FetchWithResource(ctx context.Context, doguResource *doguv2.Dogu) (_ *core.Dogu, _ *doguv2.DevelopmentDoguMap, err error) {
+	ctx, span := tracing.Start(ctx, "ResourceDoguFetcher.FetchWithResource",
+		tracing.AttributeDogu.String(doguResource.Name),
+		attribute.String("dogu.version", doguResource.Spec.Version),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	developmentDoguMap, err := rdf.getDevelopmentDoguMap(ctx, doguResource)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get development dogu map: %w", err)
@@ -113,13 +113,70 @@ func Test_localDoguFetcher_FetchInstalled(t *testing.T) {
 	})
 }
 
+func Test_localDoguFetcher_FetchAllInstalled(t *testing.T) {
+	ldapVersion := cescommons.SimpleNameVersion{Name: "ldap", Version: core.Version{Raw: "2.6.8-1", Major: 2, Minor: 6, Patch: 8, Nano: 1}}
+	redmineVersion := cescommons.SimpleNameVersion{Name: "redmine", Version: core.Version{Raw: "5.1.3-1", Major: 5, Minor: 1, Patch: 3, Nano: 1}}
+
+	t.Run("should return descriptors of all installed dogus sorted by name", func(t *testing.T) {
+		// given
+		ldap := &core.Dogu{Name: "official/ldap", Version: "2.6.8-1"}
+		redmine := &core.Dogu{Name: "official/redmine", Version: "5.1.3-1"}
+		versions := []cescommons.SimpleNameVersion{redmineVersion, ldapVersion}
+		mockDoguVersionRegistry := newMockDoguVersionRegistry(t)
+		mockDoguVersionRegistry.EXPECT().GetCurrentOfAll(testCtx).Return(versions, nil)
+		mockLocalDoguDescriptorRepository := newMockLocalDoguDescriptorRepository(t)
+		mockLocalDoguDescriptorRepository.EXPECT().GetAll(testCtx, versions).
+			Return(map[cescommons.SimpleNameVersion]*core.Dogu{redmineVersion: redmine, ldapVersion: ldap}, nil)
+
+		sut := NewLocalDoguFetcher(mockDoguVersionRegistry, mockLocalDoguDescriptorRepository)
+
+		// when
+		installedDogus, err := sut.FetchAllInstalled(testCtx)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []*core.Dogu{ldap, redmine}, installedDogus)
+	})
+	t.Run("should fail to get current versions", func(t *testing.T) {
+		// given
+		mockDoguVersionRegistry := newMockDoguVersionRegistry(t)
+		mockDoguVersionRegistry.EXPECT().GetCurrentOfAll(testCtx).Return(nil, assert.AnError)
+
+		sut := NewLocalDoguFetcher(mockDoguVersionRegistry, newMockLocalDoguDescriptorRepository(t))
+
+		// when
+		_, err := sut.FetchAllInstalled(testCtx)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get current versions of all dogus")
+	})
+	t.Run("should fail to get descriptors", func(t *testing.T) {
+		// given
+		versions := []cescommons.SimpleNameVersion{ldapVersion}
+		mockDoguVersionRegistry := newMockDoguVersionRegistry(t)
+		mockDoguVersionRegistry.EXPECT().GetCurrentOfAll(testCtx).Return(versions, nil)
+		mockLocalDoguDescriptorRepository := newMockLocalDoguDescriptorRepository(t)
+		mockLocalDoguDescriptorRepository.EXPECT().GetAll(testCtx, versions).Return(nil, assert.AnError)
+
+		sut := NewLocalDoguFetcher(mockDoguVersionRegistry, mockLocalDoguDescriptorRepository)
+
+		// when
+		_, err := sut.FetchAllInstalled(testCtx)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get current dogu descriptors")
+	})
+}
+
 func Test_resourceDoguFetcher_FetchFromResource(t *testing.T) {
 	t.Run("should fail to retrieve dogu development map", func(t *testing.T) {
 		// given
 		doguCr := readTestDataRedmineCr(t)
 		remoteDoguRepo := newMockRemoteDoguDescriptorRepository(t)
 		client := NewMockK8sClient(t)
-		client.EXPECT().Get(testCtx, doguCr.GetDevelopmentDoguMapKey(), mock.AnythingOfType("*v1.ConfigMap")).Return(assert.AnError)
+		client.EXPECT().Get(mock.Anything, doguCr.GetDevelopmentDoguMapKey(), mock.AnythingOfType("*v1.ConfigMap")).Return(assert.AnError)
 		sut := NewResourceDoguFetcher(client, remoteDoguRepo)
 
 		// when
@@ -136,10 +193,10 @@ func Test_resourceDoguFetcher_FetchFromResource(t *testing.T) {
 		resourceNotFoundErr := errors.NewNotFound(schema.GroupResource{Group: "", Resource: ""}, doguCr.GetDevelopmentDoguMapKey().Name)
 
 		client := NewMockK8sClient(t)
-		client.EXPECT().Get(testCtx, doguCr.GetDevelopmentDoguMapKey(), mock.AnythingOfType("*v1.ConfigMap")).Return(resourceNotFoundErr)
+		client.EXPECT().Get(mock.Anything, doguCr.GetDevelopmentDoguMapKey(), mock.AnythingOfType("*v1.ConfigMap")).Return(resourceNotFoundErr)
 
 		remoteDoguRepo := newMockRemoteDoguDescriptorRepository(t)
-		remoteDoguRepo.EXPECT().Get(testCtx, cescommons.QualifiedVersion{Name: cescommons.QualifiedName{SimpleName: "redmine", Namespace: "official"}, Version: core.Version{Raw: "4.2.3-10", Major: 4, Minor: 2, Patch: 3, Nano: 0, Extra: 10}}).Return(&core.Dogu{}, assert.AnError)
+		remoteDoguRepo.EXPECT().Get(mock.Anything, cescommons.QualifiedVersion{Name: cescommons.QualifiedName{SimpleName: "redmine", Namespace: "official"}, Version: core.Version{Raw: "4.2.3-10", Major: 4, Minor: 2, Patch: 3, Nano: 0, Extra: 10}}).Return(&core.Dogu{}, assert.AnError)
 
 		sut := NewResourceDoguFetcher(client, remoteDoguRepo)
 
@@ -189,7 +246,7 @@ func Test_resourceDoguFetcher_FetchFromResource(t *testing.T) {
 		testDogu := readTestDataDogu(t, redmineBytes)
 
 		remoteDoguRepo := newMockRemoteDoguDescriptorRepository(t)
-		remoteDoguRepo.EXPECT().Get(testCtx, cescommons.QualifiedVersion{Name: cescommons.QualifiedName{SimpleName: "redmine", Namespace: "official"}, Version: core.Version{Raw: "4.2.3-10", Major: 4, Minor: 2, Patch: 3, Nano: 0, Extra: 10}}).Return(testDogu, nil)
+		remoteDoguRepo.EXPECT().Get(mock.Anything, cescommons.QualifiedVersion{Name: cescommons.QualifiedName{SimpleName: "redmine", Namespace: "official"}, Version: core.Version{Raw: "4.2.3-10", Major: 4, Minor: 2, Patch: 3, Nano: 0, Extra: 10}}).Return(testDogu, nil)
 
 		client := fake.NewClientBuilder().WithScheme(getTestScheme()).WithObjects().Build()
 		sut := NewResourceDoguFetcher(client, remoteDoguRepo)
@@ -216,7 +273,7 @@ func Test_resourceDoguFetcher_FetchFromResource(t *testing.T) {
 		require.Contains(t, testDogu.Dependencies, registratorDep)
 
 		remoteDoguRepo := newMockRemoteDoguDescriptorRepository(t)
-		remoteDoguRepo.EXPECT().Get(testCtx, cescommons.QualifiedVersion{Name: cescommons.QualifiedName{SimpleName: "redmine", Namespace: "official"}, Version: core.Version{Raw: "4.2.3-10", Major: 4, Minor: 2, Patch: 3, Nano: 0, Extra: 10}}).Return(&core.Dogu{}, nil)
+		remoteDoguRepo.EXPECT().Get(mock.Anything, cescommons.QualifiedVersion{Name: cescommons.QualifiedName{SimpleName: "redmine", Namespace: "official"}, Version: core.Version{Raw: "4.2.3-10", Major: 4, Minor: 2, Patch: 3, Nano: 0, Extra: 10}}).Return(&core.Dogu{}, nil)
 
 		client := fake.NewClientBuilder().WithScheme(getTestScheme()).Build()
 		sut := NewResourceDoguFetcher(client, remoteDoguRepo)
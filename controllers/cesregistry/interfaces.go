@@ -26,6 +26,8 @@ type LocalDoguFetcher interface {
 	// FetchInstalled fetches the dogu from the local registry and returns it with patched dogu dependencies (which
 	// otherwise might be incompatible with K8s CES).
 	FetchInstalled(ctx context.Context, doguName cescommons.SimpleName) (installedDogu *cesappcore.Dogu, err error)
+	// FetchAllInstalled fetches the descriptors of the current versions of all installed dogus from the local registry.
+	FetchAllInstalled(ctx context.Context) ([]*cesappcore.Dogu, error)
 	// Enabled checks is the given dogu is enabled.
 	// Returns false (without error), when the dogu is not installed
 	Enabled(ctx context.Context, doguName cescommons.SimpleName) (bool, error)
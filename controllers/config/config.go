@@ -4,11 +4,14 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/cloudogu/cesapp-lib/core"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
@@ -19,6 +22,34 @@ const (
 )
 
 const defaultRequeueTime = time.Second * 5
+const defaultMaxRequeueTime = time.Minute * 5
+
+const defaultExecutionJournalHistoryLimit = 10
+
+const (
+	defaultHealthHistoryLimit      = 20
+	defaultHealthFlappingThreshold = 4
+	defaultHealthFlappingWindow    = time.Hour
+)
+
+const defaultMaxConcurrentReconciles = 1
+
+const (
+	defaultPreUpgradeDeadline  = 15 * time.Minute
+	defaultRolloutDeadline     = 3 * time.Hour
+	defaultPostUpgradeDeadline = 15 * time.Minute
+)
+
+const defaultUpgradeRequeueTime = 3 * time.Second
+
+const defaultVolumeSnapshotRetention = 3
+
+// defaultDataVolumeSize matches the size the dogu resource falls back to if no data volume size is set.
+var defaultDataVolumeSize = resource.MustParse("2Gi")
+
+// seccompProfileTypes contains the seccomp profile types which can be used as a cluster-wide default.
+// Localhost profiles need a path on the node and can therefore only be set per dogu.
+var seccompProfileTypes = []string{"RuntimeDefault", "Unconfined"}
 
 const cacheDir = "/tmp/dogu-registry-cache"
 
@@ -47,8 +78,30 @@ const (
 	envVarAuthRegistrationEnabled                 = "AUTH_REGISTRATION_ENABLED"
 	envVarDisablePostfixDependencyCheck           = "DISABLE_POSTFIX_DEPENDENCY_CHECK"
 	envVarRequeueTimeForDoguResourceInNanoseconds = "REQUEUE_TIME_FOR_DOGU_RESOURCE_IN_NANOSECONDS"
+	envVarExecutionJournalHistoryLimit            = "EXECUTION_JOURNAL_HISTORY_LIMIT"
+	envVarHealthHistoryLimit                      = "HEALTH_HISTORY_LIMIT"
+	envVarHealthFlappingThreshold                 = "HEALTH_FLAPPING_THRESHOLD"
+	envVarHealthFlappingWindow                    = "HEALTH_FLAPPING_WINDOW"
+	envVarPreUpgradeDeadline                      = "UPGRADE_PRE_UPGRADE_DEADLINE"
+	envVarRolloutDeadline                         = "UPGRADE_ROLLOUT_DEADLINE"
+	envVarPostUpgradeDeadline                     = "UPGRADE_POST_UPGRADE_DEADLINE"
+	envVarUpgradeRequeueTime                      = "UPGRADE_REQUEUE_TIME"
+	envVarVolumeSnapshotClass                     = "VOLUME_SNAPSHOT_CLASS"
+	envVarVolumeSnapshotRetention                 = "VOLUME_SNAPSHOT_RETENTION"
+	envVarTracingEnabled                          = "TRACING_ENABLED"
+	envVarDisabledSteps                           = "DISABLED_STEPS"
+	envVarMaxRequeueTimeForDoguResource           = "MAX_REQUEUE_TIME_FOR_DOGU_RESOURCE"
+	envVarWatchNamespaces                         = "WATCH_NAMESPACES"
+	envVarMaxConcurrentReconciles                 = "MAX_CONCURRENT_RECONCILES"
+	envVarWebhooksEnabled                         = "WEBHOOKS_ENABLED"
+	envVarDefaultDataVolumeSize                   = "DEFAULT_DATA_VOLUME_SIZE"
+	envVarDefaultStorageClass                     = "DEFAULT_STORAGE_CLASS"
+	envVarDefaultSeccompProfile                   = "DEFAULT_SECCOMP_PROFILE"
 )
 
+// allNamespacesWildcard can be used as value of WATCH_NAMESPACES to watch dogus in all namespaces.
+const allNamespacesWildcard = "*"
+
 // DoguRegistryData contains all necessary data for the dogu registry.
 type DoguRegistryData struct {
 	Endpoint  string `json:"endpoint"`
@@ -61,6 +114,9 @@ type DoguRegistryData struct {
 type OperatorConfig struct {
 	// Namespace specifies the namespace that the operator is deployed to.
 	Namespace string `json:"namespace"`
+	// WatchNamespaces contains the namespaces in which the operator reconciles dogus.
+	// It defaults to Namespace. A single empty entry (metav1.NamespaceAll) stands for all namespaces.
+	WatchNamespaces []string `json:"watch_namespaces"`
 	// DoguRegistry contains all necessary data for the dogu registry.
 	DoguRegistry DoguRegistryData `json:"dogu_registry"`
 	// Version contains the current version of the operator
@@ -75,6 +131,54 @@ type OperatorConfig struct {
 	DisablePostfixDependencyCheck bool `json:"disable_postfix_dependency_check"`
 	// RequeueTimeForDoguReconciler defines the requeue time for the dogu reconciler
 	RequeueTimeForDoguReconciler time.Duration `json:"requeue_time_for_dogu_reconciler"`
+	// MaxRequeueTimeForDoguReconciler caps the exponential backoff of dogus whose reconciles fail repeatedly.
+	MaxRequeueTimeForDoguReconciler time.Duration `json:"max_requeue_time_for_dogu_reconciler"`
+	// ExecutionJournalHistoryLimit defines how many reconcile runs are kept in the execution journal of a dogu.
+	ExecutionJournalHistoryLimit int `json:"execution_journal_history_limit"`
+	// HealthHistoryLimit defines how many health transitions are kept in the health history of a dogu.
+	HealthHistoryLimit int `json:"health_history_limit"`
+	// HealthFlappingThreshold defines how many health transitions within the HealthFlappingWindow mark a dogu as flapping.
+	HealthFlappingThreshold int `json:"health_flapping_threshold"`
+	// HealthFlappingWindow defines the time window in which health transitions are counted to detect flapping.
+	HealthFlappingWindow time.Duration `json:"health_flapping_window"`
+	// PreUpgradeDeadline defines how long an upgrade may take until the deployment is updated to the new version before
+	// the upgrade is reported as stalled and rolled back, if the dogu opted in to upgrade rollbacks. This includes the
+	// start of the exec pod and the pre-upgrade script.
+	PreUpgradeDeadline time.Duration `json:"pre_upgrade_deadline"`
+	// RolloutDeadline defines how long the new version of an upgraded dogu may take to start before the upgrade is
+	// reported as stalled and rolled back, if the dogu opted in to upgrade rollbacks. The startup probe and the progress
+	// deadline of the deployment are extended to this time during the upgrade.
+	RolloutDeadline time.Duration `json:"rollout_deadline"`
+	// PostUpgradeDeadline defines how long an upgrade may take after the start of the new version before the upgrade is
+	// reported as stalled and rolled back, if the dogu opted in to upgrade rollbacks. This includes the post-upgrade
+	// script.
+	PostUpgradeDeadline time.Duration `json:"post_upgrade_deadline"`
+	// UpgradeRequeueTime defines how long an upgrade waits for the exec pod and for the rollout of the updated
+	// deployment before the dogu is reconciled again.
+	UpgradeRequeueTime time.Duration `json:"upgrade_requeue_time"`
+	// VolumeSnapshotClass is the class of the volume snapshots taken of the data volumes of dogus.
+	// If empty, the default volume snapshot class of the cluster is used.
+	VolumeSnapshotClass string `json:"volume_snapshot_class"`
+	// VolumeSnapshotRetention defines how many volume snapshots are kept per dogu.
+	VolumeSnapshotRetention int `json:"volume_snapshot_retention"`
+	// TracingEnabled defines whether traces should be exported via OTLP.
+	// The exporter is configured with the standard OTEL_EXPORTER_OTLP_* environment variables.
+	TracingEnabled bool `json:"tracing_enabled"`
+	// DisabledSteps contains the names of the steps which are left out of the dogu install or change pipeline.
+	DisabledSteps []string `json:"disabled_steps"`
+	// MaxConcurrentReconciles defines how many dogus may be reconciled at the same time.
+	MaxConcurrentReconciles int `json:"max_concurrent_reconciles"`
+	// WebhooksEnabled defines whether the admission webhooks for dogu resources are served.
+	// The webhook server needs a serving certificate, which is provided by the helm chart.
+	WebhooksEnabled bool `json:"webhooks_enabled"`
+	// DefaultDataVolumeSize is written into new dogu resources which do not request a data volume size.
+	DefaultDataVolumeSize resource.Quantity `json:"default_data_volume_size"`
+	// DefaultStorageClass is written into new dogu resources which do not request a storage class.
+	// If empty, the default storage class of the cluster is used.
+	DefaultStorageClass string `json:"default_storage_class"`
+	// DefaultSeccompProfile is the seccomp profile type written into new dogu resources which do not request one.
+	// If empty, no seccomp profile is set and the default of the container runtime applies.
+	DefaultSeccompProfile string `json:"default_seccomp_profile"`
 }
 
 type Version string
@@ -116,13 +220,32 @@ func NewOperatorConfig(version Version) (*OperatorConfig, error) {
 	log.Info(fmt.Sprintf("Found stored dogu reconciler requeue time! Using requeue time %s", doguReconcilerRequeueTime.String()))
 
 	return &OperatorConfig{
-		Namespace:                     namespace,
-		DoguRegistry:                  doguRegistryData,
-		Version:                       &parsedVersion,
-		NetworkPoliciesEnabled:        getNetworkPoliciesEnabled(),
-		AuthRegistrationEnabled:       getAuthRegistrationEnabled(),
-		DisablePostfixDependencyCheck: getDisablePostfixDependencyCheck(),
-		RequeueTimeForDoguReconciler:  doguReconcilerRequeueTime,
+		Namespace:                       namespace,
+		WatchNamespaces:                 getWatchNamespaces(namespace),
+		DoguRegistry:                    doguRegistryData,
+		Version:                         &parsedVersion,
+		NetworkPoliciesEnabled:          getNetworkPoliciesEnabled(),
+		AuthRegistrationEnabled:         getAuthRegistrationEnabled(),
+		DisablePostfixDependencyCheck:   getDisablePostfixDependencyCheck(),
+		RequeueTimeForDoguReconciler:    doguReconcilerRequeueTime,
+		MaxRequeueTimeForDoguReconciler: getMaxDoguReconcilerRequeueTime(),
+		ExecutionJournalHistoryLimit:    getExecutionJournalHistoryLimit(),
+		HealthHistoryLimit:              getHealthHistoryLimit(),
+		HealthFlappingThreshold:         getHealthFlappingThreshold(),
+		HealthFlappingWindow:            getHealthFlappingWindow(),
+		PreUpgradeDeadline:              getUpgradePhaseDeadline(envVarPreUpgradeDeadline, defaultPreUpgradeDeadline),
+		RolloutDeadline:                 getUpgradePhaseDeadline(envVarRolloutDeadline, defaultRolloutDeadline),
+		PostUpgradeDeadline:             getUpgradePhaseDeadline(envVarPostUpgradeDeadline, defaultPostUpgradeDeadline),
+		UpgradeRequeueTime:              getUpgradeRequeueTime(),
+		VolumeSnapshotClass:             getVolumeSnapshotClass(),
+		VolumeSnapshotRetention:         getVolumeSnapshotRetention(),
+		TracingEnabled:                  getTracingEnabled(),
+		DisabledSteps:                   getDisabledSteps(),
+		MaxConcurrentReconciles:         getMaxConcurrentReconciles(),
+		WebhooksEnabled:                 getWebhooksEnabled(),
+		DefaultDataVolumeSize:           getDefaultDataVolumeSize(),
+		DefaultStorageClass:             getDefaultStorageClass(),
+		DefaultSeccompProfile:           getDefaultSeccompProfile(),
 	}, nil
 }
 
@@ -147,6 +270,22 @@ func readDoguReconcilerRequeueTime() (time.Duration, error) {
 	return time.Duration(requeueTime), nil
 }
 
+func getMaxDoguReconcilerRequeueTime() time.Duration {
+	maxRequeueTimeStr, found := os.LookupEnv(envVarMaxRequeueTimeForDoguResource)
+	if !found {
+		log.Info(fmt.Sprintf("Environment variable %s not set. Using maximum requeue time of %s by default", envVarMaxRequeueTimeForDoguResource, defaultMaxRequeueTime))
+		return defaultMaxRequeueTime
+	}
+
+	maxRequeueTime, err := time.ParseDuration(maxRequeueTimeStr)
+	if err != nil || maxRequeueTime <= 0 {
+		log.Error(fmt.Errorf("failed to parse value of environment variable %s as positive duration: %q", envVarMaxRequeueTimeForDoguResource, maxRequeueTimeStr), fmt.Sprintf("Using maximum requeue time of %s by default", defaultMaxRequeueTime))
+		return defaultMaxRequeueTime
+	}
+
+	return maxRequeueTime
+}
+
 func readDoguRegistryData() (DoguRegistryData, error) {
 	endpoint, err := getRequiredEnvVar(envVarDoguRegistryEndpoint)
 	if err != nil {
@@ -305,6 +444,264 @@ func getDisablePostfixDependencyCheck() bool {
 	return disablePostfixDependencyCheck
 }
 
+func getExecutionJournalHistoryLimit() int {
+	limitStr, found := os.LookupEnv(envVarExecutionJournalHistoryLimit)
+	if !found {
+		log.Info(fmt.Sprintf("Environment variable %s not set. Keeping %d runs in the execution journal by default", envVarExecutionJournalHistoryLimit, defaultExecutionJournalHistoryLimit))
+		return defaultExecutionJournalHistoryLimit
+	}
+
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit < 1 {
+		log.Error(fmt.Errorf("failed to parse value of environment variable %s as positive integer: %q", envVarExecutionJournalHistoryLimit, limitStr), fmt.Sprintf("Keeping %d runs in the execution journal by default", defaultExecutionJournalHistoryLimit))
+		return defaultExecutionJournalHistoryLimit
+	}
+
+	return limit
+}
+
+func getHealthHistoryLimit() int {
+	limitStr, found := os.LookupEnv(envVarHealthHistoryLimit)
+	if !found {
+		log.Info(fmt.Sprintf("Environment variable %s not set. Keeping %d transitions in the health history by default", envVarHealthHistoryLimit, defaultHealthHistoryLimit))
+		return defaultHealthHistoryLimit
+	}
+
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit < 1 {
+		log.Error(fmt.Errorf("failed to parse value of environment variable %s as positive integer: %q", envVarHealthHistoryLimit, limitStr), fmt.Sprintf("Keeping %d transitions in the health history by default", defaultHealthHistoryLimit))
+		return defaultHealthHistoryLimit
+	}
+
+	return limit
+}
+
+func getHealthFlappingThreshold() int {
+	thresholdStr, found := os.LookupEnv(envVarHealthFlappingThreshold)
+	if !found {
+		log.Info(fmt.Sprintf("Environment variable %s not set. Detecting flapping at %d health transitions by default", envVarHealthFlappingThreshold, defaultHealthFlappingThreshold))
+		return defaultHealthFlappingThreshold
+	}
+
+	threshold, err := strconv.Atoi(thresholdStr)
+	if err != nil || threshold < 2 {
+		log.Error(fmt.Errorf("failed to parse value of environment variable %s as integer greater than 1: %q", envVarHealthFlappingThreshold, thresholdStr), fmt.Sprintf("Detecting flapping at %d health transitions by default", defaultHealthFlappingThreshold))
+		return defaultHealthFlappingThreshold
+	}
+
+	return threshold
+}
+
+func getHealthFlappingWindow() time.Duration {
+	windowStr, found := os.LookupEnv(envVarHealthFlappingWindow)
+	if !found {
+		log.Info(fmt.Sprintf("Environment variable %s not set. Counting health transitions within %s by default", envVarHealthFlappingWindow, defaultHealthFlappingWindow))
+		return defaultHealthFlappingWindow
+	}
+
+	window, err := time.ParseDuration(windowStr)
+	if err != nil || window <= 0 {
+		log.Error(fmt.Errorf("failed to parse value of environment variable %s as positive duration: %q", envVarHealthFlappingWindow, windowStr), fmt.Sprintf("Counting health transitions within %s by default", defaultHealthFlappingWindow))
+		return defaultHealthFlappingWindow
+	}
+
+	return window
+}
+
+func getUpgradePhaseDeadline(envVar string, defaultDeadline time.Duration) time.Duration {
+	deadlineStr, found := os.LookupEnv(envVar)
+	if !found {
+		log.Info(fmt.Sprintf("Environment variable %s not set. Using deadline %s by default", envVar, defaultDeadline))
+		return defaultDeadline
+	}
+
+	deadline, err := time.ParseDuration(deadlineStr)
+	if err != nil || deadline <= 0 {
+		log.Error(fmt.Errorf("failed to parse value of environment variable %s as positive duration: %q", envVar, deadlineStr), fmt.Sprintf("Using deadline %s by default", defaultDeadline))
+		return defaultDeadline
+	}
+
+	return deadline
+}
+
+func getUpgradeRequeueTime() time.Duration {
+	requeueTimeStr, found := os.LookupEnv(envVarUpgradeRequeueTime)
+	if !found {
+		log.Info(fmt.Sprintf("Environment variable %s not set. Using upgrade requeue time %s by default", envVarUpgradeRequeueTime, defaultUpgradeRequeueTime))
+		return defaultUpgradeRequeueTime
+	}
+
+	requeueTime, err := time.ParseDuration(requeueTimeStr)
+	if err != nil || requeueTime <= 0 {
+		log.Error(fmt.Errorf("failed to parse value of environment variable %s as positive duration: %q", envVarUpgradeRequeueTime, requeueTimeStr), fmt.Sprintf("Using upgrade requeue time %s by default", defaultUpgradeRequeueTime))
+		return defaultUpgradeRequeueTime
+	}
+
+	return requeueTime
+}
+
+func getMaxConcurrentReconciles() int {
+	maxConcurrentReconcilesStr, found := os.LookupEnv(envVarMaxConcurrentReconciles)
+	if !found {
+		log.Info(fmt.Sprintf("Environment variable %s not set. Reconciling %d dogu(s) at the same time by default", envVarMaxConcurrentReconciles, defaultMaxConcurrentReconciles))
+		return defaultMaxConcurrentReconciles
+	}
+
+	maxConcurrentReconciles, err := strconv.Atoi(maxConcurrentReconcilesStr)
+	if err != nil || maxConcurrentReconciles < 1 {
+		log.Error(fmt.Errorf("failed to parse value of environment variable %s as positive integer: %q", envVarMaxConcurrentReconciles, maxConcurrentReconcilesStr), fmt.Sprintf("Reconciling %d dogu(s) at the same time by default", defaultMaxConcurrentReconciles))
+		return defaultMaxConcurrentReconciles
+	}
+
+	return maxConcurrentReconciles
+}
+
+func getTracingEnabled() bool {
+	tracingEnabledStr, found := os.LookupEnv(envVarTracingEnabled)
+	if !found {
+		log.Info(fmt.Sprintf("Environment variable %s not set. Disabling tracing by default", envVarTracingEnabled))
+		return false
+	}
+
+	tracingEnabled, err := strconv.ParseBool(tracingEnabledStr)
+	if err != nil {
+		log.Error(fmt.Errorf("failed to parse value of environment variable %s: %w", envVarTracingEnabled, err), "Disabling tracing by default")
+		return false
+	}
+
+	return tracingEnabled
+}
+
+func getWebhooksEnabled() bool {
+	webhooksEnabledStr, found := os.LookupEnv(envVarWebhooksEnabled)
+	if !found {
+		log.Info(fmt.Sprintf("Environment variable %s not set. Disabling admission webhooks by default", envVarWebhooksEnabled))
+		return false
+	}
+
+	webhooksEnabled, err := strconv.ParseBool(webhooksEnabledStr)
+	if err != nil {
+		log.Error(fmt.Errorf("failed to parse value of environment variable %s: %w", envVarWebhooksEnabled, err), "Disabling admission webhooks by default")
+		return false
+	}
+
+	return webhooksEnabled
+}
+
+func getDefaultDataVolumeSize() resource.Quantity {
+	sizeStr, found := os.LookupEnv(envVarDefaultDataVolumeSize)
+	if !found {
+		log.Info(fmt.Sprintf("Environment variable %s not set. Using data volume size %s by default", envVarDefaultDataVolumeSize, defaultDataVolumeSize.String()))
+		return defaultDataVolumeSize
+	}
+
+	size, err := resource.ParseQuantity(sizeStr)
+	if err != nil || size.Sign() <= 0 {
+		log.Error(fmt.Errorf("failed to parse value of environment variable %s as positive quantity: %q", envVarDefaultDataVolumeSize, sizeStr), fmt.Sprintf("Using data volume size %s by default", defaultDataVolumeSize.String()))
+		return defaultDataVolumeSize
+	}
+
+	return size
+}
+
+func getVolumeSnapshotClass() string {
+	snapshotClass, found := os.LookupEnv(envVarVolumeSnapshotClass)
+	if !found || strings.TrimSpace(snapshotClass) == "" {
+		log.Info(fmt.Sprintf("Environment variable %s not set. Using the default volume snapshot class of the cluster", envVarVolumeSnapshotClass))
+		return ""
+	}
+
+	return strings.TrimSpace(snapshotClass)
+}
+
+func getVolumeSnapshotRetention() int {
+	retentionStr, found := os.LookupEnv(envVarVolumeSnapshotRetention)
+	if !found {
+		log.Info(fmt.Sprintf("Environment variable %s not set. Keeping %d volume snapshots per dogu by default", envVarVolumeSnapshotRetention, defaultVolumeSnapshotRetention))
+		return defaultVolumeSnapshotRetention
+	}
+
+	retention, err := strconv.Atoi(retentionStr)
+	if err != nil || retention < 1 {
+		log.Error(fmt.Errorf("failed to parse value of environment variable %s as positive integer: %q", envVarVolumeSnapshotRetention, retentionStr), fmt.Sprintf("Keeping %d volume snapshots per dogu by default", defaultVolumeSnapshotRetention))
+		return defaultVolumeSnapshotRetention
+	}
+
+	return retention
+}
+
+func getDefaultStorageClass() string {
+	storageClass, found := os.LookupEnv(envVarDefaultStorageClass)
+	if !found || strings.TrimSpace(storageClass) == "" {
+		log.Info(fmt.Sprintf("Environment variable %s not set. Using the default storage class of the cluster", envVarDefaultStorageClass))
+		return ""
+	}
+
+	return strings.TrimSpace(storageClass)
+}
+
+func getDefaultSeccompProfile() string {
+	seccompProfile, found := os.LookupEnv(envVarDefaultSeccompProfile)
+	if !found || strings.TrimSpace(seccompProfile) == "" {
+		log.Info(fmt.Sprintf("Environment variable %s not set. Setting no seccomp profile by default", envVarDefaultSeccompProfile))
+		return ""
+	}
+
+	seccompProfile = strings.TrimSpace(seccompProfile)
+	if !slices.Contains(seccompProfileTypes, seccompProfile) {
+		log.Error(fmt.Errorf("invalid value of environment variable %s: %q is not one of %v", envVarDefaultSeccompProfile, seccompProfile, seccompProfileTypes), "Setting no seccomp profile by default")
+		return ""
+	}
+
+	return seccompProfile
+}
+
+func getDisabledSteps() []string {
+	disabledStepsStr, found := os.LookupEnv(envVarDisabledSteps)
+	if !found {
+		log.Info(fmt.Sprintf("Environment variable %s not set. Running all steps by default", envVarDisabledSteps))
+		return nil
+	}
+
+	var disabledSteps []string
+	for _, step := range strings.Split(disabledStepsStr, ",") {
+		step = strings.TrimSpace(step)
+		if step != "" {
+			disabledSteps = append(disabledSteps, step)
+		}
+	}
+
+	return disabledSteps
+}
+
+func getWatchNamespaces(operatorNamespace string) []string {
+	watchNamespacesStr, found := os.LookupEnv(envVarWatchNamespaces)
+	if !found || strings.TrimSpace(watchNamespacesStr) == "" {
+		log.Info(fmt.Sprintf("Environment variable %s not set. Watching namespace %s by default", envVarWatchNamespaces, operatorNamespace))
+		return []string{operatorNamespace}
+	}
+
+	var watchNamespaces []string
+	for _, namespace := range strings.Split(watchNamespacesStr, ",") {
+		namespace = strings.TrimSpace(namespace)
+		if namespace == allNamespacesWildcard {
+			log.Info("Watching dogus in all namespaces")
+			return []string{metav1.NamespaceAll}
+		}
+		if namespace != "" && !slices.Contains(watchNamespaces, namespace) {
+			watchNamespaces = append(watchNamespaces, namespace)
+		}
+	}
+	log.Info(fmt.Sprintf("Watching dogus in namespaces %v", watchNamespaces))
+
+	return watchNamespaces
+}
+
+// WatchesAllNamespaces returns true if the operator reconciles dogus in all namespaces of the cluster.
+func (o *OperatorConfig) WatchesAllNamespaces() bool {
+	return slices.Contains(o.WatchNamespaces, metav1.NamespaceAll)
+}
+
 func GetStage() (string, error) {
 	stage, err := getRequiredEnvVar(StageEnvironmentVariable)
 	if err != nil {
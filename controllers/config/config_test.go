@@ -3,11 +3,13 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/cloudogu/cesapp-lib/core"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 func TestNewOperatorConfig(t *testing.T) {
@@ -83,6 +85,7 @@ func TestNewOperatorConfig(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, operatorConfig)
 		assert.Equal(t, expectedNamespace, operatorConfig.Namespace)
+		assert.Equal(t, []string{expectedNamespace}, operatorConfig.WatchNamespaces)
 		assert.Equal(t, expectedDoguRegistryData, operatorConfig.DoguRegistry)
 		assert.Equal(t, "0.1.0", operatorConfig.Version.Raw)
 		assert.True(t, operatorConfig.AuthRegistrationEnabled)
@@ -193,3 +196,329 @@ func TestGetStage(t *testing.T) {
 	})
 
 }
+
+func Test_getExecutionJournalHistoryLimit(t *testing.T) {
+	t.Run("should return default if env var is not set", func(t *testing.T) {
+		_ = os.Unsetenv(envVarExecutionJournalHistoryLimit)
+
+		assert.Equal(t, defaultExecutionJournalHistoryLimit, getExecutionJournalHistoryLimit())
+	})
+	t.Run("should return default if env var is invalid", func(t *testing.T) {
+		t.Setenv(envVarExecutionJournalHistoryLimit, "many")
+
+		assert.Equal(t, defaultExecutionJournalHistoryLimit, getExecutionJournalHistoryLimit())
+	})
+	t.Run("should return default if env var is not positive", func(t *testing.T) {
+		t.Setenv(envVarExecutionJournalHistoryLimit, "0")
+
+		assert.Equal(t, defaultExecutionJournalHistoryLimit, getExecutionJournalHistoryLimit())
+	})
+	t.Run("should return configured limit", func(t *testing.T) {
+		t.Setenv(envVarExecutionJournalHistoryLimit, "3")
+
+		assert.Equal(t, 3, getExecutionJournalHistoryLimit())
+	})
+}
+
+func Test_getHealthHistoryLimit(t *testing.T) {
+	t.Run("should return default if env var is not set", func(t *testing.T) {
+		_ = os.Unsetenv(envVarHealthHistoryLimit)
+
+		assert.Equal(t, defaultHealthHistoryLimit, getHealthHistoryLimit())
+	})
+	t.Run("should return default if env var is not positive", func(t *testing.T) {
+		t.Setenv(envVarHealthHistoryLimit, "0")
+
+		assert.Equal(t, defaultHealthHistoryLimit, getHealthHistoryLimit())
+	})
+	t.Run("should return configured limit", func(t *testing.T) {
+		t.Setenv(envVarHealthHistoryLimit, "50")
+
+		assert.Equal(t, 50, getHealthHistoryLimit())
+	})
+}
+
+func Test_getHealthFlappingThreshold(t *testing.T) {
+	t.Run("should return default if env var is not set", func(t *testing.T) {
+		_ = os.Unsetenv(envVarHealthFlappingThreshold)
+
+		assert.Equal(t, defaultHealthFlappingThreshold, getHealthFlappingThreshold())
+	})
+	t.Run("should return default if a single transition would be flapping", func(t *testing.T) {
+		t.Setenv(envVarHealthFlappingThreshold, "1")
+
+		assert.Equal(t, defaultHealthFlappingThreshold, getHealthFlappingThreshold())
+	})
+	t.Run("should return configured threshold", func(t *testing.T) {
+		t.Setenv(envVarHealthFlappingThreshold, "6")
+
+		assert.Equal(t, 6, getHealthFlappingThreshold())
+	})
+}
+
+func Test_getHealthFlappingWindow(t *testing.T) {
+	t.Run("should return default if env var is not set", func(t *testing.T) {
+		_ = os.Unsetenv(envVarHealthFlappingWindow)
+
+		assert.Equal(t, defaultHealthFlappingWindow, getHealthFlappingWindow())
+	})
+	t.Run("should return default if env var is invalid", func(t *testing.T) {
+		t.Setenv(envVarHealthFlappingWindow, "an hour")
+
+		assert.Equal(t, defaultHealthFlappingWindow, getHealthFlappingWindow())
+	})
+	t.Run("should return configured window", func(t *testing.T) {
+		t.Setenv(envVarHealthFlappingWindow, "15m")
+
+		assert.Equal(t, 15*time.Minute, getHealthFlappingWindow())
+	})
+}
+
+func Test_getUpgradePhaseDeadline(t *testing.T) {
+	t.Run("should return default if env var is not set", func(t *testing.T) {
+		_ = os.Unsetenv(envVarRolloutDeadline)
+
+		assert.Equal(t, defaultRolloutDeadline, getUpgradePhaseDeadline(envVarRolloutDeadline, defaultRolloutDeadline))
+	})
+	t.Run("should return default if env var is invalid", func(t *testing.T) {
+		t.Setenv(envVarPreUpgradeDeadline, "soon")
+
+		assert.Equal(t, defaultPreUpgradeDeadline, getUpgradePhaseDeadline(envVarPreUpgradeDeadline, defaultPreUpgradeDeadline))
+	})
+	t.Run("should return default if env var is not positive", func(t *testing.T) {
+		t.Setenv(envVarPostUpgradeDeadline, "0s")
+
+		assert.Equal(t, defaultPostUpgradeDeadline, getUpgradePhaseDeadline(envVarPostUpgradeDeadline, defaultPostUpgradeDeadline))
+	})
+	t.Run("should return configured deadline", func(t *testing.T) {
+		t.Setenv(envVarRolloutDeadline, "45m")
+
+		assert.Equal(t, 45*time.Minute, getUpgradePhaseDeadline(envVarRolloutDeadline, defaultRolloutDeadline))
+	})
+}
+
+func Test_getUpgradeRequeueTime(t *testing.T) {
+	t.Run("should return default if env var is not set", func(t *testing.T) {
+		_ = os.Unsetenv(envVarUpgradeRequeueTime)
+
+		assert.Equal(t, defaultUpgradeRequeueTime, getUpgradeRequeueTime())
+	})
+	t.Run("should return default if env var is invalid", func(t *testing.T) {
+		t.Setenv(envVarUpgradeRequeueTime, "3")
+
+		assert.Equal(t, defaultUpgradeRequeueTime, getUpgradeRequeueTime())
+	})
+	t.Run("should return configured requeue time", func(t *testing.T) {
+		t.Setenv(envVarUpgradeRequeueTime, "10s")
+
+		assert.Equal(t, 10*time.Second, getUpgradeRequeueTime())
+	})
+}
+
+func Test_getMaxConcurrentReconciles(t *testing.T) {
+	t.Run("should return default if env var is not set", func(t *testing.T) {
+		_ = os.Unsetenv(envVarMaxConcurrentReconciles)
+
+		assert.Equal(t, defaultMaxConcurrentReconciles, getMaxConcurrentReconciles())
+	})
+	t.Run("should return default if env var is invalid", func(t *testing.T) {
+		t.Setenv(envVarMaxConcurrentReconciles, "all")
+
+		assert.Equal(t, defaultMaxConcurrentReconciles, getMaxConcurrentReconciles())
+	})
+	t.Run("should return default if env var is not positive", func(t *testing.T) {
+		t.Setenv(envVarMaxConcurrentReconciles, "0")
+
+		assert.Equal(t, defaultMaxConcurrentReconciles, getMaxConcurrentReconciles())
+	})
+	t.Run("should return configured value", func(t *testing.T) {
+		t.Setenv(envVarMaxConcurrentReconciles, "4")
+
+		assert.Equal(t, 4, getMaxConcurrentReconciles())
+	})
+}
+
+func Test_getTracingEnabled(t *testing.T) {
+	t.Run("should disable tracing if env var is not set", func(t *testing.T) {
+		_ = os.Unsetenv(envVarTracingEnabled)
+
+		assert.False(t, getTracingEnabled())
+	})
+	t.Run("should disable tracing if env var is invalid", func(t *testing.T) {
+		t.Setenv(envVarTracingEnabled, "yes please")
+
+		assert.False(t, getTracingEnabled())
+	})
+	t.Run("should enable tracing", func(t *testing.T) {
+		t.Setenv(envVarTracingEnabled, "true")
+
+		assert.True(t, getTracingEnabled())
+	})
+}
+
+func Test_getWebhooksEnabled(t *testing.T) {
+	t.Run("should disable webhooks if env var is not set", func(t *testing.T) {
+		_ = os.Unsetenv(envVarWebhooksEnabled)
+
+		assert.False(t, getWebhooksEnabled())
+	})
+	t.Run("should disable webhooks if env var is invalid", func(t *testing.T) {
+		t.Setenv(envVarWebhooksEnabled, "sure")
+
+		assert.False(t, getWebhooksEnabled())
+	})
+	t.Run("should enable webhooks", func(t *testing.T) {
+		t.Setenv(envVarWebhooksEnabled, "true")
+
+		assert.True(t, getWebhooksEnabled())
+	})
+}
+
+func Test_getDefaultDataVolumeSize(t *testing.T) {
+	t.Run("should return default if env var is not set", func(t *testing.T) {
+		_ = os.Unsetenv(envVarDefaultDataVolumeSize)
+
+		assert.Equal(t, resource.MustParse("2Gi"), getDefaultDataVolumeSize())
+	})
+	t.Run("should return default if env var is invalid", func(t *testing.T) {
+		t.Setenv(envVarDefaultDataVolumeSize, "big")
+
+		assert.Equal(t, resource.MustParse("2Gi"), getDefaultDataVolumeSize())
+	})
+	t.Run("should return default if env var is not positive", func(t *testing.T) {
+		t.Setenv(envVarDefaultDataVolumeSize, "0")
+
+		assert.Equal(t, resource.MustParse("2Gi"), getDefaultDataVolumeSize())
+	})
+	t.Run("should return size of env var", func(t *testing.T) {
+		t.Setenv(envVarDefaultDataVolumeSize, "5Gi")
+
+		assert.Equal(t, resource.MustParse("5Gi"), getDefaultDataVolumeSize())
+	})
+}
+
+func Test_getVolumeSnapshotClass(t *testing.T) {
+	t.Run("should return empty class if env var is not set", func(t *testing.T) {
+		_ = os.Unsetenv(envVarVolumeSnapshotClass)
+
+		assert.Empty(t, getVolumeSnapshotClass())
+	})
+	t.Run("should return trimmed class", func(t *testing.T) {
+		t.Setenv(envVarVolumeSnapshotClass, " longhorn-snapshot ")
+
+		assert.Equal(t, "longhorn-snapshot", getVolumeSnapshotClass())
+	})
+}
+
+func Test_getVolumeSnapshotRetention(t *testing.T) {
+	t.Run("should return default if env var is not set", func(t *testing.T) {
+		_ = os.Unsetenv(envVarVolumeSnapshotRetention)
+
+		assert.Equal(t, defaultVolumeSnapshotRetention, getVolumeSnapshotRetention())
+	})
+	t.Run("should return default if env var is not a positive integer", func(t *testing.T) {
+		t.Setenv(envVarVolumeSnapshotRetention, "0")
+
+		assert.Equal(t, defaultVolumeSnapshotRetention, getVolumeSnapshotRetention())
+	})
+	t.Run("should return configured retention", func(t *testing.T) {
+		t.Setenv(envVarVolumeSnapshotRetention, "5")
+
+		assert.Equal(t, 5, getVolumeSnapshotRetention())
+	})
+}
+
+func Test_getDefaultStorageClass(t *testing.T) {
+	t.Run("should return empty storage class if env var is not set", func(t *testing.T) {
+		_ = os.Unsetenv(envVarDefaultStorageClass)
+
+		assert.Empty(t, getDefaultStorageClass())
+	})
+	t.Run("should return trimmed storage class", func(t *testing.T) {
+		t.Setenv(envVarDefaultStorageClass, " longhorn ")
+
+		assert.Equal(t, "longhorn", getDefaultStorageClass())
+	})
+}
+
+func Test_getDefaultSeccompProfile(t *testing.T) {
+	t.Run("should return empty profile if env var is not set", func(t *testing.T) {
+		_ = os.Unsetenv(envVarDefaultSeccompProfile)
+
+		assert.Empty(t, getDefaultSeccompProfile())
+	})
+	t.Run("should return empty profile if env var is not a supported type", func(t *testing.T) {
+		t.Setenv(envVarDefaultSeccompProfile, "Localhost")
+
+		assert.Empty(t, getDefaultSeccompProfile())
+	})
+	t.Run("should return profile of env var", func(t *testing.T) {
+		t.Setenv(envVarDefaultSeccompProfile, "RuntimeDefault")
+
+		assert.Equal(t, "RuntimeDefault", getDefaultSeccompProfile())
+	})
+}
+
+func Test_getDisabledSteps(t *testing.T) {
+	t.Run("should return no steps if env var is not set", func(t *testing.T) {
+		_ = os.Unsetenv(envVarDisabledSteps)
+
+		assert.Empty(t, getDisabledSteps())
+	})
+	t.Run("should return trimmed step names", func(t *testing.T) {
+		t.Setenv(envVarDisabledSteps, " export-mode, ,support-mode ")
+
+		assert.Equal(t, []string{"export-mode", "support-mode"}, getDisabledSteps())
+	})
+}
+
+func Test_getMaxDoguReconcilerRequeueTime(t *testing.T) {
+	t.Run("should return default if env var is not set", func(t *testing.T) {
+		_ = os.Unsetenv(envVarMaxRequeueTimeForDoguResource)
+
+		assert.Equal(t, defaultMaxRequeueTime, getMaxDoguReconcilerRequeueTime())
+	})
+	t.Run("should return default if env var is invalid", func(t *testing.T) {
+		t.Setenv(envVarMaxRequeueTimeForDoguResource, "forever")
+
+		assert.Equal(t, defaultMaxRequeueTime, getMaxDoguReconcilerRequeueTime())
+	})
+	t.Run("should return default if env var is not positive", func(t *testing.T) {
+		t.Setenv(envVarMaxRequeueTimeForDoguResource, "0s")
+
+		assert.Equal(t, defaultMaxRequeueTime, getMaxDoguReconcilerRequeueTime())
+	})
+	t.Run("should return configured time", func(t *testing.T) {
+		t.Setenv(envVarMaxRequeueTimeForDoguResource, "1m")
+
+		assert.Equal(t, time.Minute, getMaxDoguReconcilerRequeueTime())
+	})
+}
+
+func Test_getWatchNamespaces(t *testing.T) {
+	t.Run("should return operator namespace if env var is not set", func(t *testing.T) {
+		_ = os.Unsetenv(envVarWatchNamespaces)
+
+		assert.Equal(t, []string{"ecosystem"}, getWatchNamespaces("ecosystem"))
+	})
+	t.Run("should return operator namespace if env var is blank", func(t *testing.T) {
+		t.Setenv(envVarWatchNamespaces, " ")
+
+		assert.Equal(t, []string{"ecosystem"}, getWatchNamespaces("ecosystem"))
+	})
+	t.Run("should return trimmed and deduplicated namespaces", func(t *testing.T) {
+		t.Setenv(envVarWatchNamespaces, " test, ,stage,test ")
+
+		assert.Equal(t, []string{"test", "stage"}, getWatchNamespaces("ecosystem"))
+	})
+	t.Run("should return all namespaces for wildcard", func(t *testing.T) {
+		t.Setenv(envVarWatchNamespaces, "test,*")
+
+		assert.Equal(t, []string{""}, getWatchNamespaces("ecosystem"))
+	})
+}
+
+func TestOperatorConfig_WatchesAllNamespaces(t *testing.T) {
+	assert.False(t, (&OperatorConfig{WatchNamespaces: []string{"test", "stage"}}).WatchesAllNamespaces())
+	assert.True(t, (&OperatorConfig{WatchNamespaces: []string{""}}).WatchesAllNamespaces())
+}
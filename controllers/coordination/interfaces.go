@@ -0,0 +1,37 @@
+package coordination
+
+import "k8s.io/apimachinery/pkg/types"
+
+// Locker coordinates concurrent reconciles of dogus.
+// Every lock is held by the reconcile of a dogu, identified by the namespaced name of the dogu resource.
+// All methods are non-blocking so that a reconcile which cannot get its locks is requeued instead of waiting while
+// holding other locks.
+// An exclusive lock which cannot be taken is queued for the requesting dogu, so that it is not starved by shared
+// holders coming and going. While it is queued, the lock is not given to anyone else.
+type Locker interface {
+	// TryLockDogu locks the dogu exclusively for its own reconcile.
+	// It fails if a dependent dogu is being reconciled or if namespace-wide work is running in the namespace of the dogu.
+	TryLockDogu(dogu types.NamespacedName) bool
+	// TryLockDependencies locks the given dependencies in the namespace of the dogu shared, so that they cannot be
+	// reconciled while the dogu uses them. Either all dependencies are locked or none of them.
+	// It returns the names of the dependencies which are currently being reconciled.
+	TryLockDependencies(dogu types.NamespacedName, dependencies []string) []string
+	// TryLockNamespace locks the whole namespace of the dogu exclusively for work which touches all dogus of the
+	// namespace. It fails as long as any other dogu of the namespace is being reconciled.
+	TryLockNamespace(dogu types.NamespacedName) bool
+	// Release releases all locks held by the reconcile of the dogu.
+	Release(dogu types.NamespacedName)
+}
+
+// WaitList tracks which dogus wait for which of their dependencies to become available, so that the waiting dogus can
+// be reconciled as soon as a dependency is available instead of waiting for their requeue timer.
+// Dependencies are identified by their name in the namespace of the waiting dogu.
+type WaitList interface {
+	// Wait records that the dogu waits for the given dependencies. It replaces dependencies recorded before.
+	Wait(dogu types.NamespacedName, dependencies []string)
+	// Done removes the dogu from the wait list.
+	Done(dogu types.NamespacedName)
+	// DependencyAvailable triggers a reconcile of all dogus waiting for the dependency and removes them from the wait
+	// list. It returns the woken dogus.
+	DependencyAvailable(dependency types.NamespacedName) []types.NamespacedName
+}
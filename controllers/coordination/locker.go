@@ -0,0 +1,218 @@
+package coordination
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// namespaceWideName is the lock name which stands for all dogus of a namespace.
+// It cannot collide with a dogu because it is no valid resource name.
+const namespaceWideName = "*"
+
+// queuedExclusiveTimeout is how long a failed exclusive lock request keeps its place in the queue. Reconciles which
+// cannot get a lock are requeued after a few seconds and renew their request. A request which is not renewed in time
+// is dropped, so that a dogu which is not reconciled anymore does not block the lock forever.
+const queuedExclusiveTimeout = 30 * time.Second
+
+type lockState struct {
+	exclusiveHolder types.NamespacedName
+	sharedHolders   map[types.NamespacedName]struct{}
+}
+
+func (ls *lockState) isFree() bool {
+	return ls.exclusiveHolder == types.NamespacedName{} && len(ls.sharedHolders) == 0
+}
+
+// exclusiveRequest is an exclusive lock request which failed because the lock was held by others.
+type exclusiveRequest struct {
+	holder      types.NamespacedName
+	lastAttempt time.Time
+}
+
+type locker struct {
+	mutex sync.Mutex
+	locks map[types.NamespacedName]*lockState
+	held  map[types.NamespacedName][]types.NamespacedName
+	// queued contains at most one exclusive request per lock. While it is queued, no one else gets the lock, neither
+	// shared nor exclusive, so that the requester does not starve while shared holders come and go.
+	queued map[types.NamespacedName]exclusiveRequest
+	now    func() time.Time
+}
+
+// NewLocker creates a Locker which keeps its locks in memory.
+// This is sufficient because only the leading instance of the operator reconciles dogus.
+func NewLocker() Locker {
+	return &locker{
+		locks:  map[types.NamespacedName]*lockState{},
+		held:   map[types.NamespacedName][]types.NamespacedName{},
+		queued: map[types.NamespacedName]exclusiveRequest{},
+		now:    time.Now,
+	}
+}
+
+func (l *locker) TryLockDogu(dogu types.NamespacedName) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	namespaceLock := namespaceWide(dogu.Namespace)
+	canLockExclusive := l.canLockExclusive(dogu, dogu)
+	if !canLockExclusive {
+		l.queueExclusive(dogu, dogu)
+	}
+	if !l.canLockShared(namespaceLock, dogu) || !canLockExclusive {
+		return false
+	}
+
+	l.lockShared(namespaceLock, dogu)
+	l.lockExclusive(dogu, dogu)
+	return true
+}
+
+func (l *locker) TryLockDependencies(dogu types.NamespacedName, dependencies []string) []string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	var locks []types.NamespacedName
+	var blocked []string
+	for _, dependency := range dependencies {
+		lock := types.NamespacedName{Namespace: dogu.Namespace, Name: dependency}
+		if lock == dogu {
+			continue
+		}
+		if !l.canLockShared(lock, dogu) {
+			blocked = append(blocked, dependency)
+			continue
+		}
+		locks = append(locks, lock)
+	}
+	if len(blocked) > 0 {
+		return blocked
+	}
+
+	for _, lock := range locks {
+		l.lockShared(lock, dogu)
+	}
+	return nil
+}
+
+func (l *locker) TryLockNamespace(dogu types.NamespacedName) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	namespaceLock := namespaceWide(dogu.Namespace)
+	if !l.canLockExclusive(namespaceLock, dogu) {
+		l.queueExclusive(namespaceLock, dogu)
+		return false
+	}
+
+	l.lockExclusive(namespaceLock, dogu)
+	return true
+}
+
+func (l *locker) Release(dogu types.NamespacedName) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	for _, lock := range l.held[dogu] {
+		state, ok := l.locks[lock]
+		if !ok {
+			continue
+		}
+		if state.exclusiveHolder == dogu {
+			state.exclusiveHolder = types.NamespacedName{}
+		}
+		delete(state.sharedHolders, dogu)
+		if state.isFree() {
+			delete(l.locks, lock)
+		}
+	}
+	delete(l.held, dogu)
+}
+
+func (l *locker) canLockShared(lock types.NamespacedName, holder types.NamespacedName) bool {
+	if l.isQueuedByOther(lock, holder) {
+		return false
+	}
+
+	state, ok := l.locks[lock]
+	if !ok {
+		return true
+	}
+
+	return state.exclusiveHolder == types.NamespacedName{} || state.exclusiveHolder == holder
+}
+
+func (l *locker) canLockExclusive(lock types.NamespacedName, holder types.NamespacedName) bool {
+	if l.isQueuedByOther(lock, holder) {
+		return false
+	}
+
+	state, ok := l.locks[lock]
+	if !ok {
+		return true
+	}
+	if state.exclusiveHolder != (types.NamespacedName{}) && state.exclusiveHolder != holder {
+		return false
+	}
+	for sharedHolder := range state.sharedHolders {
+		if sharedHolder != holder {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (l *locker) lockShared(lock types.NamespacedName, holder types.NamespacedName) {
+	l.stateOf(lock, holder).sharedHolders[holder] = struct{}{}
+}
+
+func (l *locker) lockExclusive(lock types.NamespacedName, holder types.NamespacedName) {
+	l.stateOf(lock, holder).exclusiveHolder = holder
+	delete(l.queued, lock)
+}
+
+// queueExclusive queues the exclusive request of the holder for the lock unless another request is queued.
+// A queued request of the holder itself is renewed.
+func (l *locker) queueExclusive(lock types.NamespacedName, holder types.NamespacedName) {
+	if l.isQueuedByOther(lock, holder) {
+		return
+	}
+
+	l.queued[lock] = exclusiveRequest{holder: holder, lastAttempt: l.now()}
+}
+
+// isQueuedByOther returns true if another holder than the given one has queued an exclusive request for the lock.
+// Expired requests are dropped.
+func (l *locker) isQueuedByOther(lock types.NamespacedName, holder types.NamespacedName) bool {
+	request, ok := l.queued[lock]
+	if !ok {
+		return false
+	}
+	if l.now().Sub(request.lastAttempt) > queuedExclusiveTimeout {
+		delete(l.queued, lock)
+		return false
+	}
+
+	return request.holder != holder
+}
+
+func (l *locker) stateOf(lock types.NamespacedName, holder types.NamespacedName) *lockState {
+	state, ok := l.locks[lock]
+	if !ok {
+		state = &lockState{sharedHolders: map[types.NamespacedName]struct{}{}}
+		l.locks[lock] = state
+	}
+	if !slices.Contains(l.held[holder], lock) {
+		l.held[holder] = append(l.held[holder], lock)
+	}
+
+	return state
+}
+
+func namespaceWide(namespace string) types.NamespacedName {
+	return types.NamespacedName{Namespace: namespace, Name: namespaceWideName}
+}
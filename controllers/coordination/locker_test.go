@@ -0,0 +1,244 @@
+package coordination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var (
+	cas        = types.NamespacedName{Namespace: "ecosystem", Name: "cas"}
+	redmine    = types.NamespacedName{Namespace: "ecosystem", Name: "redmine"}
+	postgresql = types.NamespacedName{Namespace: "ecosystem", Name: "postgresql"}
+	stageCas   = types.NamespacedName{Namespace: "stage", Name: "cas"}
+)
+
+func TestLocker_TryLockDogu(t *testing.T) {
+	t.Run("should lock different dogus at the same time", func(t *testing.T) {
+		// given
+		sut := NewLocker()
+
+		// when
+		casLocked := sut.TryLockDogu(cas)
+		redmineLocked := sut.TryLockDogu(redmine)
+
+		// then
+		assert.True(t, casLocked)
+		assert.True(t, redmineLocked)
+	})
+	t.Run("should not lock dogu twice until it is released", func(t *testing.T) {
+		// given
+		sut := NewLocker()
+		sut.TryLockDogu(cas)
+		sut.TryLockDependencies(cas, []string{"postgresql"})
+
+		// when
+		lockedByDependent := sut.TryLockDogu(postgresql)
+		sut.Release(cas)
+		lockedAfterRelease := sut.TryLockDogu(postgresql)
+
+		// then
+		assert.False(t, lockedByDependent)
+		assert.True(t, lockedAfterRelease)
+	})
+	t.Run("should not lock dogu while namespace is locked", func(t *testing.T) {
+		// given
+		sut := NewLocker()
+		sut.TryLockDogu(cas)
+		sut.TryLockNamespace(cas)
+
+		// when
+		redmineLocked := sut.TryLockDogu(redmine)
+		stageCasLocked := sut.TryLockDogu(stageCas)
+
+		// then
+		assert.False(t, redmineLocked)
+		assert.True(t, stageCasLocked)
+	})
+}
+
+func TestLocker_TryLockDependencies(t *testing.T) {
+	t.Run("should share dependency between dependent dogus", func(t *testing.T) {
+		// given
+		sut := NewLocker()
+		sut.TryLockDogu(cas)
+		sut.TryLockDogu(redmine)
+
+		// when
+		casBlocked := sut.TryLockDependencies(cas, []string{"postgresql"})
+		redmineBlocked := sut.TryLockDependencies(redmine, []string{"postgresql"})
+
+		// then
+		assert.Empty(t, casBlocked)
+		assert.Empty(t, redmineBlocked)
+	})
+	t.Run("should return dependencies which are being reconciled and lock none", func(t *testing.T) {
+		// given
+		sut := NewLocker()
+		sut.TryLockDogu(redmine)
+		sut.TryLockDogu(cas)
+		sut.TryLockDogu(postgresql)
+
+		// when
+		blocked := sut.TryLockDependencies(redmine, []string{"cas", "postgresql", "postfix"})
+
+		// then
+		assert.Equal(t, []string{"cas", "postgresql"}, blocked)
+		sut.Release(cas)
+		sut.Release(postgresql)
+		assert.True(t, sut.TryLockDogu(types.NamespacedName{Namespace: "ecosystem", Name: "postfix"}))
+	})
+	t.Run("should ignore dependency on itself", func(t *testing.T) {
+		// given
+		sut := NewLocker()
+		sut.TryLockDogu(cas)
+
+		// when
+		blocked := sut.TryLockDependencies(cas, []string{"cas"})
+
+		// then
+		assert.Empty(t, blocked)
+	})
+	t.Run("should not lock dependency while its own reconcile waits for it", func(t *testing.T) {
+		// given
+		sut := NewLocker()
+		sut.TryLockDogu(redmine)
+		sut.TryLockDependencies(redmine, []string{"postgresql"})
+		postgresqlLocked := sut.TryLockDogu(postgresql)
+
+		// when
+		blocked := sut.TryLockDependencies(cas, []string{"postgresql"})
+		sut.Release(redmine)
+		postgresqlLockedAfterRelease := sut.TryLockDogu(postgresql)
+
+		// then
+		assert.False(t, postgresqlLocked)
+		assert.Equal(t, []string{"postgresql"}, blocked)
+		assert.True(t, postgresqlLockedAfterRelease)
+	})
+	t.Run("should lock dependency again if the waiting reconcile does not retry", func(t *testing.T) {
+		// given
+		sut := NewLocker()
+		now := time.Now()
+		sut.(*locker).now = func() time.Time { return now }
+		sut.TryLockDogu(redmine)
+		sut.TryLockDependencies(redmine, []string{"postgresql"})
+		sut.TryLockDogu(postgresql)
+		now = now.Add(queuedExclusiveTimeout + time.Second)
+
+		// when
+		blocked := sut.TryLockDependencies(cas, []string{"postgresql"})
+
+		// then
+		assert.Empty(t, blocked)
+	})
+	t.Run("should lock dependencies in namespace of dogu", func(t *testing.T) {
+		// given
+		sut := NewLocker()
+		sut.TryLockDogu(postgresql)
+
+		// when
+		blocked := sut.TryLockDependencies(stageCas, []string{"postgresql"})
+
+		// then
+		assert.Empty(t, blocked)
+	})
+}
+
+func TestLocker_TryLockNamespace(t *testing.T) {
+	t.Run("should lock namespace if only the dogu itself is reconciled", func(t *testing.T) {
+		// given
+		sut := NewLocker()
+		sut.TryLockDogu(cas)
+		sut.TryLockDogu(stageCas)
+
+		// when
+		locked := sut.TryLockNamespace(cas)
+
+		// then
+		assert.True(t, locked)
+	})
+	t.Run("should not lock namespace while another dogu is reconciled", func(t *testing.T) {
+		// given
+		sut := NewLocker()
+		sut.TryLockDogu(cas)
+		sut.TryLockDogu(redmine)
+
+		// when
+		lockedWhileReconciling := sut.TryLockNamespace(cas)
+		sut.Release(redmine)
+		lockedAfterRelease := sut.TryLockNamespace(cas)
+
+		// then
+		assert.False(t, lockedWhileReconciling)
+		assert.True(t, lockedAfterRelease)
+	})
+	t.Run("should not lock other dogus while namespace-wide work waits", func(t *testing.T) {
+		// given
+		sut := NewLocker()
+		sut.TryLockDogu(cas)
+		sut.TryLockDogu(redmine)
+		sut.TryLockNamespace(cas)
+		sut.Release(cas)
+
+		// when
+		postgresqlLocked := sut.TryLockDogu(postgresql)
+		casLocked := sut.TryLockDogu(cas)
+		sut.Release(redmine)
+		namespaceLocked := sut.TryLockNamespace(cas)
+
+		// then
+		assert.False(t, postgresqlLocked)
+		assert.True(t, casLocked)
+		assert.True(t, namespaceLocked)
+	})
+	t.Run("should keep the place of the first waiting request", func(t *testing.T) {
+		// given
+		sut := NewLocker()
+		sut.TryLockDogu(cas)
+		sut.TryLockDogu(redmine)
+		sut.TryLockDogu(postgresql)
+		sut.TryLockNamespace(cas)
+
+		// when
+		redmineLocked := sut.TryLockNamespace(redmine)
+		sut.Release(postgresql)
+		sut.Release(redmine)
+		redmineLockedAfterRelease := sut.TryLockNamespace(redmine)
+		casLocked := sut.TryLockNamespace(cas)
+
+		// then
+		assert.False(t, redmineLocked)
+		assert.False(t, redmineLockedAfterRelease)
+		assert.True(t, casLocked)
+	})
+}
+
+func TestLocker_Release(t *testing.T) {
+	t.Run("should release all locks of the dogu", func(t *testing.T) {
+		// given
+		sut := NewLocker()
+		sut.TryLockDogu(cas)
+		sut.TryLockDependencies(cas, []string{"postgresql"})
+		sut.TryLockNamespace(cas)
+
+		// when
+		sut.Release(cas)
+
+		// then
+		assert.Empty(t, sut.(*locker).locks)
+		assert.Empty(t, sut.(*locker).held)
+	})
+	t.Run("should ignore dogu without locks", func(t *testing.T) {
+		// given
+		sut := NewLocker()
+
+		// when
+		sut.Release(cas)
+
+		// then
+		assert.Empty(t, sut.(*locker).locks)
+	})
+}
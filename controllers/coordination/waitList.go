@@ -0,0 +1,78 @@
+package coordination
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+type waitList struct {
+	mutex   sync.Mutex
+	waiting map[types.NamespacedName][]string
+	events  chan<- event.TypedGenericEvent[*doguv2.Dogu]
+}
+
+// NewWaitList creates a WaitList which keeps the waiting dogus in memory and wakes them with generic events on the
+// given channel. Like the locks, the wait list only has to live as long as the leading instance of the operator;
+// dogus which are waiting while the leader changes are reconciled again by the requeue timer.
+func NewWaitList(events chan<- event.TypedGenericEvent[*doguv2.Dogu]) WaitList {
+	return &waitList{
+		waiting: map[types.NamespacedName][]string{},
+		events:  events,
+	}
+}
+
+func (w *waitList) Wait(dogu types.NamespacedName, dependencies []string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if len(dependencies) == 0 {
+		delete(w.waiting, dogu)
+		return
+	}
+	w.waiting[dogu] = slices.Clone(dependencies)
+}
+
+func (w *waitList) Done(dogu types.NamespacedName) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	delete(w.waiting, dogu)
+}
+
+func (w *waitList) DependencyAvailable(dependency types.NamespacedName) []types.NamespacedName {
+	woken := w.removeWaitingFor(dependency)
+
+	// the events are sent without holding the mutex, because the channel blocks until the controller takes them
+	for _, dogu := range woken {
+		w.events <- event.TypedGenericEvent[*doguv2.Dogu]{Object: &doguv2.Dogu{
+			ObjectMeta: metav1.ObjectMeta{Name: dogu.Name, Namespace: dogu.Namespace},
+		}}
+	}
+
+	return woken
+}
+
+func (w *waitList) removeWaitingFor(dependency types.NamespacedName) []types.NamespacedName {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	var woken []types.NamespacedName
+	for dogu, dependencies := range w.waiting {
+		if dogu.Namespace == dependency.Namespace && slices.Contains(dependencies, dependency.Name) {
+			woken = append(woken, dogu)
+			delete(w.waiting, dogu)
+		}
+	}
+	// all woken dogus share the namespace of the dependency
+	slices.SortFunc(woken, func(a, b types.NamespacedName) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return woken
+}
@@ -0,0 +1,69 @@
+package coordination
+
+import (
+	"testing"
+
+	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+var (
+	scm      = types.NamespacedName{Namespace: "ecosystem", Name: "scm"}
+	stageRed = types.NamespacedName{Namespace: "stage", Name: "redmine"}
+)
+
+func TestWaitList_DependencyAvailable(t *testing.T) {
+	t.Run("should wake exactly the dogus waiting for the dependency", func(t *testing.T) {
+		// given
+		events := make(chan event.TypedGenericEvent[*doguv2.Dogu], 10)
+		sut := NewWaitList(events)
+		sut.Wait(redmine, []string{"postgresql", "cas"})
+		sut.Wait(scm, []string{"cas"})
+		sut.Wait(stageRed, []string{"postgresql"})
+
+		// when
+		woken := sut.DependencyAvailable(postgresql)
+
+		// then
+		assert.Equal(t, []types.NamespacedName{redmine}, woken)
+		require.Len(t, events, 1)
+		wokenDogu := (<-events).Object
+		assert.Equal(t, "redmine", wokenDogu.Name)
+		assert.Equal(t, "ecosystem", wokenDogu.Namespace)
+	})
+	t.Run("should wake dogus only once", func(t *testing.T) {
+		// given
+		events := make(chan event.TypedGenericEvent[*doguv2.Dogu], 10)
+		sut := NewWaitList(events)
+		sut.Wait(redmine, []string{"postgresql", "cas"})
+		sut.Wait(scm, []string{"cas"})
+
+		// when
+		wokenByCas := sut.DependencyAvailable(cas)
+		wokenByPostgresql := sut.DependencyAvailable(postgresql)
+
+		// then
+		assert.Equal(t, []types.NamespacedName{redmine, scm}, wokenByCas)
+		assert.Empty(t, wokenByPostgresql)
+		assert.Len(t, events, 2)
+	})
+	t.Run("should not wake dogus which are done", func(t *testing.T) {
+		// given
+		events := make(chan event.TypedGenericEvent[*doguv2.Dogu], 10)
+		sut := NewWaitList(events)
+		sut.Wait(redmine, []string{"postgresql"})
+		sut.Wait(scm, []string{"postgresql"})
+		sut.Done(redmine)
+		sut.Wait(scm, nil)
+
+		// when
+		woken := sut.DependencyAvailable(postgresql)
+
+		// then
+		assert.Empty(t, woken)
+		assert.Empty(t, events)
+	})
+}
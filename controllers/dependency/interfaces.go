@@ -17,3 +17,10 @@ type Validator interface {
 	// ValidateDependencies is used to check if dogu dependencies are installed.
 	ValidateDependencies(ctx context.Context, dogu *cesappcore.Dogu) error
 }
+
+// ReverseDependencyValidator checks if the installed dogus accept a new version of a dogu.
+type ReverseDependencyValidator interface {
+	// ValidateReverseDependencies returns an error if an installed dogu requires a version of the given dogu which
+	// does not match its version.
+	ValidateReverseDependencies(ctx context.Context, dogu *cesappcore.Dogu) error
+}
@@ -0,0 +1,35 @@
+package dependency
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// IgnoreReverseDependenciesAnnotation skips the reverse-dependency check of a dogu if set to "true".
+// The switch belongs to spec.upgradeConfig next to forceUpgrade, but the UpgradeConfig of the dogu resource is defined in
+// k8s-dogu-lib, which has no such field yet. The annotation is only read by IgnoresReverseDependencies, so that it can be
+// replaced there once the field is available.
+const IgnoreReverseDependenciesAnnotation = "k8s.cloudogu.com/ignore-reverse-dependencies"
+
+// IgnoresReverseDependencies returns true if the reverse-dependency check of the dogu is skipped.
+func IgnoresReverseDependencies(object metav1.Object) bool {
+	return object.GetAnnotations()[IgnoreReverseDependenciesAnnotation] == "true"
+}
+
+// IgnoreReverseDependenciesAnnotationChangedPredicate lets update events pass if the reverse-dependency check of the
+// dogu resource was switched on or off. Changed annotations do not increase the generation, so without this predicate
+// a blocked dogu would only be reconciled again after its requeue time.
+func IgnoreReverseDependenciesAnnotationChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return false
+			}
+			return IgnoresReverseDependencies(e.ObjectOld) != IgnoresReverseDependencies(e.ObjectNew)
+		},
+	}
+}
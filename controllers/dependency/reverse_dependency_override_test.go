@@ -0,0 +1,32 @@
+package dependency
+
+import (
+	"testing"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestIgnoreReverseDependenciesAnnotationChangedPredicate(t *testing.T) {
+	ignoring := &v2.Dogu{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{IgnoreReverseDependenciesAnnotation: "true"}}}
+	checking := &v2.Dogu{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{IgnoreReverseDependenciesAnnotation: "false"}}}
+	defaulted := &v2.Dogu{}
+
+	sut := IgnoreReverseDependenciesAnnotationChangedPredicate()
+
+	assert.True(t, sut.Update(event.UpdateEvent{ObjectOld: defaulted, ObjectNew: ignoring}))
+	assert.True(t, sut.Update(event.UpdateEvent{ObjectOld: ignoring, ObjectNew: checking}))
+	assert.False(t, sut.Update(event.UpdateEvent{ObjectOld: checking, ObjectNew: defaulted}))
+	assert.False(t, sut.Update(event.UpdateEvent{ObjectOld: ignoring, ObjectNew: ignoring}))
+	assert.False(t, sut.Create(event.CreateEvent{Object: ignoring}))
+	assert.False(t, sut.Delete(event.DeleteEvent{Object: ignoring}))
+	assert.False(t, sut.Generic(event.GenericEvent{Object: ignoring}))
+}
+
+func TestIgnoresReverseDependencies(t *testing.T) {
+	assert.True(t, IgnoresReverseDependencies(&v2.Dogu{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{IgnoreReverseDependenciesAnnotation: "true"}}}))
+	assert.False(t, IgnoresReverseDependencies(&v2.Dogu{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{IgnoreReverseDependenciesAnnotation: "false"}}}))
+	assert.False(t, IgnoresReverseDependencies(&v2.Dogu{}))
+}
@@ -0,0 +1,95 @@
+package dependency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cloudogu/cesapp-lib/core"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/cesregistry"
+)
+
+// reverseDependencyError is returned when an installed dogu does not accept the version of one of its dependencies.
+type reverseDependencyError struct {
+	dependentDogu    string
+	dependentVersion string
+	dependency       core.Dependency
+	version          string
+}
+
+// Error returns the error in string representation
+func (e *reverseDependencyError) Error() string {
+	return fmt.Sprintf("installed dogu %q %s requires version %q of dogu %q which does not allow version %s",
+		e.dependentDogu, e.dependentVersion, e.dependency.Version, e.dependency.Name, e.version)
+}
+
+// reverseDependencyValidator checks if the installed dogus accept a new version of a dogu they depend on.
+type reverseDependencyValidator struct {
+	fetcher localDoguFetcher
+}
+
+// NewReverseDependencyValidator creates a validator that checks the new version of a dogu against the version
+// requirements of all installed dogus which depend on it.
+func NewReverseDependencyValidator(doguFetcher cesregistry.LocalDoguFetcher) ReverseDependencyValidator {
+	return &reverseDependencyValidator{fetcher: doguFetcher}
+}
+
+// ValidateReverseDependencies evaluates the version requirements that the installed dogus declare for the given dogu
+// in their mandatory and optional dependencies against its version. All violations are returned joined together.
+func (rv *reverseDependencyValidator) ValidateReverseDependencies(ctx context.Context, dogu *core.Dogu) error {
+	version, err := core.ParseVersion(dogu.Version)
+	if err != nil {
+		return fmt.Errorf("failed to parse version of dogu %q: %w", dogu.GetSimpleName(), err)
+	}
+
+	installedDogus, err := rv.fetcher.FetchAllInstalled(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch installed dogus: %w", err)
+	}
+
+	var violations error
+	for _, installedDogu := range installedDogus {
+		if installedDogu.GetSimpleName() == dogu.GetSimpleName() {
+			continue
+		}
+
+		dependencies := append(installedDogu.GetDependenciesOfType(core.DependencyTypeDogu),
+			installedDogu.GetOptionalDependenciesOfType(core.DependencyTypeDogu)...)
+		for _, doguDependency := range dependencies {
+			if doguDependency.Name != dogu.GetSimpleName() || doguDependency.Version == "" {
+				continue
+			}
+
+			allowed, err := AllowsVersion(doguDependency, version)
+			if err != nil {
+				violations = errors.Join(violations, fmt.Errorf("failed to check requirement of installed dogu %q: %w", installedDogu.GetSimpleName(), err))
+				continue
+			}
+			if !allowed {
+				violations = errors.Join(violations, &reverseDependencyError{
+					dependentDogu:    installedDogu.GetSimpleName(),
+					dependentVersion: installedDogu.Version,
+					dependency:       doguDependency,
+					version:          dogu.Version,
+				})
+			}
+		}
+	}
+
+	return violations
+}
+
+// AllowsVersion returns true if the version requirement of the dependency is met by the given version.
+func AllowsVersion(doguDependency core.Dependency, version core.Version) (bool, error) {
+	comparator, err := core.ParseVersionComparator(doguDependency.Version)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse version requirement %q for dogu %q: %w", doguDependency.Version, doguDependency.Name, err)
+	}
+
+	allowed, err := comparator.Allows(version)
+	if err != nil {
+		return false, fmt.Errorf("an error occurred when comparing the versions: %w", err)
+	}
+
+	return allowed, nil
+}
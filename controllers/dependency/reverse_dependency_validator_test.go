@@ -0,0 +1,114 @@
+package dependency
+
+import (
+	"testing"
+
+	"github.com/cloudogu/cesapp-lib/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReverseDependencyValidator(t *testing.T) {
+	// when
+	validator := NewReverseDependencyValidator(newMockLocalDoguFetcher(t))
+
+	// then
+	assert.NotNil(t, validator)
+}
+
+func Test_reverseDependencyValidator_ValidateReverseDependencies(t *testing.T) {
+	postgresql := &core.Dogu{Name: "official/postgresql", Version: "14.15.0-1"}
+	installedPostgresql := &core.Dogu{Name: "official/postgresql", Version: "12.18.1-1"}
+	redmine := &core.Dogu{
+		Name:    "official/redmine",
+		Version: "5.1.3-1",
+		Dependencies: []core.Dependency{
+			{Type: core.DependencyTypeDogu, Name: "postgresql", Version: "<13.0.0-0"},
+			{Type: core.DependencyTypeClient, Name: "postgresql", Version: "<1.0.0"},
+		},
+	}
+	scm := &core.Dogu{
+		Name:                 "official/scm",
+		Version:              "3.7.1-1",
+		OptionalDependencies: []core.Dependency{{Type: core.DependencyTypeDogu, Name: "postgresql", Version: "<=12.18.1-1"}},
+	}
+	sonar := &core.Dogu{
+		Name:         "official/sonar",
+		Version:      "25.1.0-1",
+		Dependencies: []core.Dependency{{Type: core.DependencyTypeDogu, Name: "postgresql", Version: ">=12.0.0-1"}},
+	}
+	jenkins := &core.Dogu{
+		Name:         "official/jenkins",
+		Version:      "2.479.3-1",
+		Dependencies: []core.Dependency{{Type: core.DependencyTypeDogu, Name: "postgresql"}, {Type: core.DependencyTypeDogu, Name: "cas", Version: "<1.0.0"}},
+	}
+
+	t.Run("should succeed if all installed dogus accept the version", func(t *testing.T) {
+		// given
+		fetcher := newMockLocalDoguFetcher(t)
+		fetcher.EXPECT().FetchAllInstalled(testCtx).Return([]*core.Dogu{installedPostgresql, sonar, jenkins}, nil)
+		sut := NewReverseDependencyValidator(fetcher)
+
+		// when
+		err := sut.ValidateReverseDependencies(testCtx, postgresql)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should report all installed dogus which do not accept the version", func(t *testing.T) {
+		// given
+		fetcher := newMockLocalDoguFetcher(t)
+		fetcher.EXPECT().FetchAllInstalled(testCtx).Return([]*core.Dogu{installedPostgresql, redmine, scm, sonar}, nil)
+		sut := NewReverseDependencyValidator(fetcher)
+
+		// when
+		err := sut.ValidateReverseDependencies(testCtx, postgresql)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, `installed dogu "redmine" 5.1.3-1 requires version "<13.0.0-0" of dogu "postgresql" which does not allow version 14.15.0-1`)
+		assert.ErrorContains(t, err, `installed dogu "scm" 3.7.1-1 requires version "<=12.18.1-1" of dogu "postgresql" which does not allow version 14.15.0-1`)
+		assert.NotContains(t, err.Error(), "sonar")
+	})
+	t.Run("should fail for unparsable version requirement", func(t *testing.T) {
+		// given
+		broken := &core.Dogu{
+			Name:         "official/broken",
+			Version:      "1.0.0-1",
+			Dependencies: []core.Dependency{{Type: core.DependencyTypeDogu, Name: "postgresql", Version: "-1.0.0"}},
+		}
+		fetcher := newMockLocalDoguFetcher(t)
+		fetcher.EXPECT().FetchAllInstalled(testCtx).Return([]*core.Dogu{broken}, nil)
+		sut := NewReverseDependencyValidator(fetcher)
+
+		// when
+		err := sut.ValidateReverseDependencies(testCtx, postgresql)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, `failed to check requirement of installed dogu "broken"`)
+	})
+	t.Run("should fail to parse version of dogu", func(t *testing.T) {
+		// given
+		sut := NewReverseDependencyValidator(newMockLocalDoguFetcher(t))
+
+		// when
+		err := sut.ValidateReverseDependencies(testCtx, &core.Dogu{Name: "official/postgresql", Version: "abc"})
+
+		// then
+		assert.ErrorContains(t, err, `failed to parse version of dogu "postgresql"`)
+	})
+	t.Run("should fail to fetch installed dogus", func(t *testing.T) {
+		// given
+		fetcher := newMockLocalDoguFetcher(t)
+		fetcher.EXPECT().FetchAllInstalled(testCtx).Return(nil, assert.AnError)
+		sut := NewReverseDependencyValidator(fetcher)
+
+		// when
+		err := sut.ValidateReverseDependencies(testCtx, postgresql)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to fetch installed dogus")
+	})
+}
@@ -10,6 +10,15 @@ import (
 	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/coordination"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/dependency"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/drift"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/health"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/namespaced"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/plan"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/tracing"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/volumesnapshot"
 	appsv1 "k8s.io/api/apps/v1"
 	coreV1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
@@ -19,6 +28,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -30,6 +40,7 @@ import (
 const (
 	RequeueEventReason          = "Requeue"
 	ReconcileStartedEventReason = "ReconcileStarted"
+	PlanCreatedEventReason      = "PlanCreated"
 )
 
 const (
@@ -38,6 +49,10 @@ const (
 	ReasonHasToReconcile   = "HasToReconcile"
 )
 
+// requeueAfterDoguLocked is the delay after which a dogu is reconciled again if a dependent dogu or namespace-wide
+// work holds its lock.
+const requeueAfterDoguLocked = 5 * time.Second
+
 // The DoguReconciler knows where the [*doguv2.Dogu] is at all times. It knows this because it knows where it isn't.
 // By subtracting where it is from where it isn't, or where it isn't from where it is (whichever is greater), it obtains
 // a difference, or deviation. The guidance subsystem uses deviations to generate corrective commands to drive the
@@ -56,11 +71,15 @@ type DoguReconciler struct {
 	client                  client.Client
 	doguChangeHandler       DoguInstallOrChangeUseCase
 	doguDeleteHandler       DoguDeleteUseCase
+	doguPlanner             doguPlanner
 	doguInterface           doguInterface
 	requeueHandler          RequeueHandler
 	externalEvents          <-chan event.TypedGenericEvent[*doguv2.Dogu]
 	eventRecorder           eventRecorder
+	locker                  doguLocker
+	dependentDoguMapper     dependentDoguMapper
 	authRegistrationEnabled bool
+	maxConcurrentReconciles int
 }
 
 func NewDoguEvents() chan event.TypedGenericEvent[*doguv2.Dogu] {
@@ -80,22 +99,29 @@ func NewDoguReconciler(
 	k8sClient client.Client,
 	doguChangeHandler DoguInstallOrChangeUseCase,
 	doguDeleteHandler DoguDeleteUseCase,
+	doguPlanner plan.Planner,
 	doguInterface doguClient.DoguInterface,
 	requeueHandler RequeueHandler,
 	externalEvents <-chan event.TypedGenericEvent[*doguv2.Dogu],
 	recorder record.EventRecorder,
 	manager manager.Manager,
 	config *config.OperatorConfig,
+	locker coordination.Locker,
+	dependentDoguMapper health.DependentDoguMapper,
 ) (*DoguReconciler, error) {
 	r := &DoguReconciler{
 		client:                  k8sClient,
 		doguChangeHandler:       doguChangeHandler,
 		doguDeleteHandler:       doguDeleteHandler,
+		doguPlanner:             doguPlanner,
 		doguInterface:           doguInterface,
 		requeueHandler:          requeueHandler,
 		externalEvents:          externalEvents,
 		eventRecorder:           recorder,
+		locker:                  locker,
+		dependentDoguMapper:     dependentDoguMapper,
 		authRegistrationEnabled: config.AuthRegistrationEnabled,
+		maxConcurrentReconciles: config.MaxConcurrentReconciles,
 	}
 	err := r.setupWithManager(manager)
 	if err != nil {
@@ -105,14 +131,38 @@ func NewDoguReconciler(
 	return r, nil
 }
 
-func (r *DoguReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *DoguReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "DoguReconciler.Reconcile",
+		tracing.AttributeDogu.String(req.Name),
+		tracing.AttributeNamespace.String(req.Namespace),
+	)
+	defer func() { tracing.End(span, err) }()
+	ctx = tracing.WithTraceIDLogger(ctx)
+	ctx = namespaced.WithNamespace(ctx, req.Namespace)
+
 	doguResource := &doguv2.Dogu{}
-	err := r.client.Get(ctx, req.NamespacedName, doguResource)
+	err = r.client.Get(ctx, req.NamespacedName, doguResource)
 	if err != nil {
 		return r.requeueHandler.Handle(ctx, doguResource, client.IgnoreNotFound(err), 0)
 	}
 	r.eventRecorder.Event(doguResource, coreV1.EventTypeNormal, ReconcileStartedEventReason, "reconciliation started")
 
+	if doguResource.GetDeletionTimestamp().IsZero() && plan.IsDryRun(doguResource) {
+		return r.planDoguResource(ctx, doguResource)
+	}
+
+	if doguResource.GetDeletionTimestamp().IsZero() && isStalledUntilSpecChange(doguResource) {
+		log.FromContext(ctx).Info("Skipping reconcile because the dogu failed with a terminal error until its spec changes", "generation", doguResource.Generation)
+		return ctrl.Result{}, nil
+	}
+
+	// the lock is held until the reconcile ends, so that dependent dogus cannot use this dogu while it is changed
+	if !r.locker.TryLockDogu(req.NamespacedName) {
+		log.FromContext(ctx).Info("Dogu is locked by the reconcile of a dependent dogu or by namespace-wide work; requeueing", "requeueAfter", requeueAfterDoguLocked)
+		return ctrl.Result{RequeueAfter: requeueAfterDoguLocked}, nil
+	}
+	defer r.locker.Release(req.NamespacedName)
+
 	var requeueAfter time.Duration
 	var cont bool
 	if doguResource.GetDeletionTimestamp().IsZero() {
@@ -132,6 +182,8 @@ func (r *DoguReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 
 	if requeueAfter != 0 {
 		getDoguResourceErr = r.setReadyCondition(ctx, doguResource, metav1.ConditionFalse, ReasonHasToReconcile, fmt.Sprintf("The dogu resource has to be requeued after %d seconds.", requeueAfter))
+	} else if steps.IsTerminal(err) {
+		getDoguResourceErr = r.setReadyCondition(ctx, doguResource, metav1.ConditionFalse, ReasonReconcileFail, fmt.Sprintf("The dogu resource cannot be reconciled because of a terminal error: %q.", err))
 	} else if err != nil {
 		getDoguResourceErr = r.setReadyCondition(ctx, doguResource, metav1.ConditionFalse, ReasonReconcileFail, fmt.Sprintf("The dogu resource has to be requeued because of an error: %q.", err))
 	} else if !cont {
@@ -145,14 +197,28 @@ func (r *DoguReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 	return r.requeueHandler.Handle(ctx, doguResource, errs, requeueAfter)
 }
 
+// planDoguResource only writes a plan of the changes instead of applying the dogu resource.
+// The status of the dogu resource stays untouched, because nothing was applied.
+func (r *DoguReconciler) planDoguResource(ctx context.Context, doguResource *doguv2.Dogu) (ctrl.Result, error) {
+	err := r.doguPlanner.Plan(ctx, doguResource)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	r.eventRecorder.Eventf(doguResource, coreV1.EventTypeNormal, PlanCreatedEventReason, "Plan written to config map %q; remove the annotation %q to apply the changes", plan.ConfigMapName(doguResource.Name), plan.DryRunAnnotation)
+	return ctrl.Result{}, nil
+}
+
 // setupWithManager sets up the controller with the manager.
 // The dogu controller should be triggered when resources on which a dogu cr has an OwnerReference change.
 // These resource types are listed here with owns.
 // In addition, the dogu reconciler can be triggered via an events channel.
 // This is intended, for example, for the GlobalConfigReconciler to reconcile the dogus again.
+// If the health of a dogu changes, the dogus depending on it are reconciled to update their Degraded condition.
+// Several dogus may be reconciled at the same time; their dependencies are coordinated by the locker.
 func (r *DoguReconciler) setupWithManager(mgr ctrlManager) error {
 	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
-		For(&doguv2.Dogu{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		For(&doguv2.Dogu{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, plan.DryRunAnnotationChangedPredicate(), drift.PolicyAnnotationChangedPredicate(), volumesnapshot.RestoreAnnotationChangedPredicate(), dependency.IgnoreReverseDependenciesAnnotationChangedPredicate()))).
 		Owns(&coreV1.ConfigMap{}).
 		Owns(&coreV1.Secret{}).
 		Owns(&coreV1.Service{}).
@@ -160,7 +226,9 @@ func (r *DoguReconciler) setupWithManager(mgr ctrlManager) error {
 		Owns(&coreV1.PersistentVolumeClaim{}).
 		Owns(&netv1.NetworkPolicy{}).
 		Owns(&coreV1.Pod{}).
-		WatchesRawSource(source.Channel(r.externalEvents, &handler.TypedEnqueueRequestForObject[*doguv2.Dogu]{}))
+		Watches(&doguv2.Dogu{}, handler.EnqueueRequestsFromMapFunc(r.dependentDoguMapper.MapDependents), builder.WithPredicates(health.HealthChangedPredicate())).
+		WatchesRawSource(source.Channel(r.externalEvents, &handler.TypedEnqueueRequestForObject[*doguv2.Dogu]{})).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.maxConcurrentReconciles})
 	if r.authRegistrationEnabled {
 		controllerBuilder = controllerBuilder.Owns(&authRegApiV1.AuthRegistration{})
 	}
@@ -8,6 +8,8 @@ import (
 
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	opConfig "github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/coordination"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/plan"
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -33,7 +35,7 @@ func TestNewDoguReconciler(t *testing.T) {
 	managerMock.EXPECT().GetRESTMapper().Return(nil)
 
 	// when
-	reconciler, err := NewDoguReconciler(nil, nil, nil, nil, nil, nil, nil, managerMock, &opConfig.OperatorConfig{})
+	reconciler, err := NewDoguReconciler(nil, nil, nil, nil, nil, nil, nil, nil, managerMock, &opConfig.OperatorConfig{}, coordination.NewLocker(), newMockDependentDoguMapper(t))
 
 	// then
 	assert.NoError(t, err)
@@ -61,7 +63,7 @@ func TestDoguReconciler_Reconcile(t *testing.T) {
 			fields: fields{
 				clientFn: func(t *testing.T) client.Client {
 					mck := NewMockK8sClient(t)
-					mck.EXPECT().Get(testCtx, types.NamespacedName{}, &v2.Dogu{}).Return(assert.AnError)
+					mck.EXPECT().Get(mock.Anything, types.NamespacedName{}, &v2.Dogu{}).Return(assert.AnError)
 					return mck
 				},
 				doguChangeHandlerFn: func(t *testing.T) DoguUsecase {
@@ -78,7 +80,7 @@ func TestDoguReconciler_Reconcile(t *testing.T) {
 				},
 				requeueHandlerFn: func(t *testing.T) RequeueHandler {
 					mck := NewMockRequeueHandler(t)
-					mck.EXPECT().Handle(testCtx, &v2.Dogu{}, assert.AnError, time.Duration(0)).Return(controllerruntime.Result{Requeue: true, RequeueAfter: requeueTime}, nil)
+					mck.EXPECT().Handle(mock.Anything, &v2.Dogu{}, assert.AnError, time.Duration(0)).Return(controllerruntime.Result{Requeue: true, RequeueAfter: requeueTime}, nil)
 					return mck
 				},
 			},
@@ -113,7 +115,7 @@ func TestDoguReconciler_Reconcile(t *testing.T) {
 				},
 				requeueHandlerFn: func(t *testing.T) RequeueHandler {
 					mck := NewMockRequeueHandler(t)
-					mck.EXPECT().Handle(testCtx, &v2.Dogu{}, nil, time.Duration(0)).Return(controllerruntime.Result{Requeue: false, RequeueAfter: 0}, nil)
+					mck.EXPECT().Handle(mock.Anything, &v2.Dogu{}, nil, time.Duration(0)).Return(controllerruntime.Result{Requeue: false, RequeueAfter: 0}, nil)
 					return mck
 				},
 			},
@@ -138,7 +140,7 @@ func TestDoguReconciler_Reconcile(t *testing.T) {
 				},
 				doguChangeHandlerFn: func(t *testing.T) DoguUsecase {
 					mck := NewMockDoguUsecase(t)
-					mck.EXPECT().HandleUntilApplied(testCtx, mock.Anything).Return(0, false, nil)
+					mck.EXPECT().HandleUntilApplied(mock.Anything, mock.Anything).Return(0, false, nil)
 					return mck
 				},
 				doguDeleteHandlerFn: func(t *testing.T) DoguUsecase {
@@ -146,7 +148,7 @@ func TestDoguReconciler_Reconcile(t *testing.T) {
 				},
 				doguInterfaceFn: func(t *testing.T) doguInterface {
 					mck := newMockDoguInterface(t)
-					mck.EXPECT().UpdateStatusWithRetry(testCtx, mock.Anything, mock.Anything, v1.UpdateOptions{}).Return(nil, assert.AnError)
+					mck.EXPECT().UpdateStatusWithRetry(mock.Anything, mock.Anything, mock.Anything, v1.UpdateOptions{}).Return(nil, assert.AnError)
 					return mck
 				},
 				eventRecorderFn: func(t *testing.T) eventRecorder {
@@ -156,7 +158,7 @@ func TestDoguReconciler_Reconcile(t *testing.T) {
 				},
 				requeueHandlerFn: func(t *testing.T) RequeueHandler {
 					mck := NewMockRequeueHandler(t)
-					mck.EXPECT().Handle(testCtx, mock.AnythingOfType("*v2.Dogu"), errors.Join(assert.AnError), time.Duration(0)).Return(controllerruntime.Result{Requeue: true, RequeueAfter: requeueTime}, nil)
+					mck.EXPECT().Handle(mock.Anything, mock.AnythingOfType("*v2.Dogu"), errors.Join(assert.AnError), time.Duration(0)).Return(controllerruntime.Result{Requeue: true, RequeueAfter: requeueTime}, nil)
 					return mck
 				},
 			},
@@ -181,7 +183,7 @@ func TestDoguReconciler_Reconcile(t *testing.T) {
 				},
 				doguChangeHandlerFn: func(t *testing.T) DoguUsecase {
 					mck := NewMockDoguUsecase(t)
-					mck.EXPECT().HandleUntilApplied(testCtx, mock.Anything).Return(0, false, assert.AnError)
+					mck.EXPECT().HandleUntilApplied(mock.Anything, mock.Anything).Return(0, false, assert.AnError)
 					return mck
 				},
 				doguDeleteHandlerFn: func(t *testing.T) DoguUsecase {
@@ -189,7 +191,7 @@ func TestDoguReconciler_Reconcile(t *testing.T) {
 				},
 				doguInterfaceFn: func(t *testing.T) doguInterface {
 					mck := newMockDoguInterface(t)
-					mck.EXPECT().UpdateStatusWithRetry(testCtx, mock.Anything, mock.Anything, v1.UpdateOptions{}).Return(&v2.Dogu{ObjectMeta: v1.ObjectMeta{Name: testDoguName}}, nil)
+					mck.EXPECT().UpdateStatusWithRetry(mock.Anything, mock.Anything, mock.Anything, v1.UpdateOptions{}).Return(&v2.Dogu{ObjectMeta: v1.ObjectMeta{Name: testDoguName}}, nil)
 					return mck
 				},
 				eventRecorderFn: func(t *testing.T) eventRecorder {
@@ -199,7 +201,7 @@ func TestDoguReconciler_Reconcile(t *testing.T) {
 				},
 				requeueHandlerFn: func(t *testing.T) RequeueHandler {
 					mck := NewMockRequeueHandler(t)
-					mck.EXPECT().Handle(testCtx, mock.AnythingOfType("*v2.Dogu"), errors.Join(assert.AnError), time.Duration(0)).Return(controllerruntime.Result{Requeue: true, RequeueAfter: requeueTime}, nil)
+					mck.EXPECT().Handle(mock.Anything, mock.AnythingOfType("*v2.Dogu"), errors.Join(assert.AnError), time.Duration(0)).Return(controllerruntime.Result{Requeue: true, RequeueAfter: requeueTime}, nil)
 					return mck
 				},
 			},
@@ -224,7 +226,7 @@ func TestDoguReconciler_Reconcile(t *testing.T) {
 				},
 				doguChangeHandlerFn: func(t *testing.T) DoguUsecase {
 					mck := NewMockDoguUsecase(t)
-					mck.EXPECT().HandleUntilApplied(testCtx, mock.Anything).Return(0, true, nil)
+					mck.EXPECT().HandleUntilApplied(mock.Anything, mock.Anything).Return(0, true, nil)
 					return mck
 				},
 				doguDeleteHandlerFn: func(t *testing.T) DoguUsecase {
@@ -232,7 +234,7 @@ func TestDoguReconciler_Reconcile(t *testing.T) {
 				},
 				doguInterfaceFn: func(t *testing.T) doguInterface {
 					mck := newMockDoguInterface(t)
-					mck.EXPECT().UpdateStatusWithRetry(testCtx, mock.Anything, mock.Anything, v1.UpdateOptions{}).Return(&v2.Dogu{ObjectMeta: v1.ObjectMeta{Name: testDoguName}}, nil)
+					mck.EXPECT().UpdateStatusWithRetry(mock.Anything, mock.Anything, mock.Anything, v1.UpdateOptions{}).Return(&v2.Dogu{ObjectMeta: v1.ObjectMeta{Name: testDoguName}}, nil)
 					return mck
 				},
 				eventRecorderFn: func(t *testing.T) eventRecorder {
@@ -242,7 +244,7 @@ func TestDoguReconciler_Reconcile(t *testing.T) {
 				},
 				requeueHandlerFn: func(t *testing.T) RequeueHandler {
 					mck := NewMockRequeueHandler(t)
-					mck.EXPECT().Handle(testCtx, mock.AnythingOfType("*v2.Dogu"), nil, time.Duration(0)).Return(controllerruntime.Result{Requeue: false, RequeueAfter: 0}, nil)
+					mck.EXPECT().Handle(mock.Anything, mock.AnythingOfType("*v2.Dogu"), nil, time.Duration(0)).Return(controllerruntime.Result{Requeue: false, RequeueAfter: 0}, nil)
 					return mck
 				},
 			},
@@ -267,7 +269,7 @@ func TestDoguReconciler_Reconcile(t *testing.T) {
 				},
 				doguChangeHandlerFn: func(t *testing.T) DoguUsecase {
 					mck := NewMockDoguUsecase(t)
-					mck.EXPECT().HandleUntilApplied(testCtx, mock.Anything).Return(0, false, nil)
+					mck.EXPECT().HandleUntilApplied(mock.Anything, mock.Anything).Return(0, false, nil)
 					return mck
 				},
 				doguDeleteHandlerFn: func(t *testing.T) DoguUsecase {
@@ -275,7 +277,7 @@ func TestDoguReconciler_Reconcile(t *testing.T) {
 				},
 				doguInterfaceFn: func(t *testing.T) doguInterface {
 					mck := newMockDoguInterface(t)
-					mck.EXPECT().UpdateStatusWithRetry(testCtx, mock.Anything, mock.Anything, v1.UpdateOptions{}).Return(&v2.Dogu{ObjectMeta: v1.ObjectMeta{Name: testDoguName}}, nil)
+					mck.EXPECT().UpdateStatusWithRetry(mock.Anything, mock.Anything, mock.Anything, v1.UpdateOptions{}).Return(&v2.Dogu{ObjectMeta: v1.ObjectMeta{Name: testDoguName}}, nil)
 					return mck
 				},
 				eventRecorderFn: func(t *testing.T) eventRecorder {
@@ -285,7 +287,7 @@ func TestDoguReconciler_Reconcile(t *testing.T) {
 				},
 				requeueHandlerFn: func(t *testing.T) RequeueHandler {
 					mck := NewMockRequeueHandler(t)
-					mck.EXPECT().Handle(testCtx, mock.AnythingOfType("*v2.Dogu"), nil, time.Duration(0)).Return(controllerruntime.Result{Requeue: false, RequeueAfter: 0}, nil)
+					mck.EXPECT().Handle(mock.Anything, mock.AnythingOfType("*v2.Dogu"), nil, time.Duration(0)).Return(controllerruntime.Result{Requeue: false, RequeueAfter: 0}, nil)
 					return mck
 				},
 			},
@@ -303,6 +305,7 @@ func TestDoguReconciler_Reconcile(t *testing.T) {
 				doguInterface:     tt.fields.doguInterfaceFn(t),
 				requeueHandler:    tt.fields.requeueHandlerFn(t),
 				eventRecorder:     tt.fields.eventRecorderFn(t),
+				locker:            coordination.NewLocker(),
 			}
 			got, err := r.Reconcile(testCtx, tt.req)
 			if !tt.wantErr(t, err, fmt.Sprintf("Reconcile(%v, %v)", testCtx, tt.req)) {
@@ -312,3 +315,169 @@ func TestDoguReconciler_Reconcile(t *testing.T) {
 		})
 	}
 }
+
+func TestDoguReconciler_Reconcile_dryRun(t *testing.T) {
+	newClient := func(t *testing.T) client.Client {
+		scheme := runtime.NewScheme()
+		require.NoError(t, v2.AddToScheme(scheme))
+		doguResource := &v2.Dogu{ObjectMeta: v1.ObjectMeta{
+			Name:        testDoguName,
+			Annotations: map[string]string{plan.DryRunAnnotation: "true"},
+		}}
+		return fake.NewClientBuilder().WithScheme(scheme).WithObjects(doguResource).Build()
+	}
+	req := controllerruntime.Request{NamespacedName: types.NamespacedName{Name: testDoguName}}
+
+	t.Run("should only plan dogu resource", func(t *testing.T) {
+		// given
+		plannerMock := newMockDoguPlanner(t)
+		plannerMock.EXPECT().Plan(mock.Anything, mock.AnythingOfType("*v2.Dogu")).Return(nil)
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Event(mock.AnythingOfType("*v2.Dogu"), v3.EventTypeNormal, ReconcileStartedEventReason, "reconciliation started")
+		recorderMock.EXPECT().Eventf(mock.AnythingOfType("*v2.Dogu"), v3.EventTypeNormal, PlanCreatedEventReason, mock.Anything, "test-plan", plan.DryRunAnnotation)
+
+		sut := &DoguReconciler{
+			client:            newClient(t),
+			doguChangeHandler: NewMockDoguUsecase(t),
+			doguDeleteHandler: NewMockDoguUsecase(t),
+			doguPlanner:       plannerMock,
+			doguInterface:     newMockDoguInterface(t),
+			requeueHandler:    NewMockRequeueHandler(t),
+			eventRecorder:     recorderMock,
+			locker:            coordination.NewLocker(),
+		}
+
+		// when
+		got, err := sut.Reconcile(testCtx, req)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, controllerruntime.Result{}, got)
+	})
+	t.Run("should fail to plan dogu resource", func(t *testing.T) {
+		// given
+		plannerMock := newMockDoguPlanner(t)
+		plannerMock.EXPECT().Plan(mock.Anything, mock.AnythingOfType("*v2.Dogu")).Return(assert.AnError)
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Event(mock.AnythingOfType("*v2.Dogu"), v3.EventTypeNormal, ReconcileStartedEventReason, "reconciliation started")
+
+		sut := &DoguReconciler{
+			client:            newClient(t),
+			doguChangeHandler: NewMockDoguUsecase(t),
+			doguDeleteHandler: NewMockDoguUsecase(t),
+			doguPlanner:       plannerMock,
+			doguInterface:     newMockDoguInterface(t),
+			requeueHandler:    NewMockRequeueHandler(t),
+			eventRecorder:     recorderMock,
+			locker:            coordination.NewLocker(),
+		}
+
+		// when
+		_, err := sut.Reconcile(testCtx, req)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestDoguReconciler_Reconcile_stalled(t *testing.T) {
+	newClient := func(t *testing.T, observedGeneration int64) client.Client {
+		scheme := runtime.NewScheme()
+		require.NoError(t, v2.AddToScheme(scheme))
+		doguResource := &v2.Dogu{
+			ObjectMeta: v1.ObjectMeta{Name: testDoguName, Generation: 2},
+			Status: v2.DoguStatus{Conditions: []v1.Condition{{
+				Type:               ConditionStalled,
+				Status:             v1.ConditionTrue,
+				Reason:             ReasonTerminalUntilSpecChange,
+				ObservedGeneration: observedGeneration,
+			}}},
+		}
+		return fake.NewClientBuilder().WithScheme(scheme).WithObjects(doguResource).Build()
+	}
+	req := controllerruntime.Request{NamespacedName: types.NamespacedName{Name: testDoguName}}
+
+	t.Run("should skip reconcile of stalled generation", func(t *testing.T) {
+		// given
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Event(mock.AnythingOfType("*v2.Dogu"), v3.EventTypeNormal, ReconcileStartedEventReason, "reconciliation started")
+
+		sut := &DoguReconciler{
+			client:            newClient(t, 2),
+			doguChangeHandler: NewMockDoguUsecase(t),
+			doguDeleteHandler: NewMockDoguUsecase(t),
+			doguInterface:     newMockDoguInterface(t),
+			requeueHandler:    NewMockRequeueHandler(t),
+			eventRecorder:     recorderMock,
+			locker:            coordination.NewLocker(),
+		}
+
+		// when
+		got, err := sut.Reconcile(testCtx, req)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, controllerruntime.Result{}, got)
+	})
+	t.Run("should reconcile if generation changed", func(t *testing.T) {
+		// given
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Event(mock.AnythingOfType("*v2.Dogu"), v3.EventTypeNormal, ReconcileStartedEventReason, "reconciliation started")
+		changeHandlerMock := NewMockDoguUsecase(t)
+		changeHandlerMock.EXPECT().HandleUntilApplied(mock.Anything, mock.AnythingOfType("*v2.Dogu")).Return(0, true, nil)
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().UpdateStatusWithRetry(mock.Anything, mock.AnythingOfType("*v2.Dogu"), mock.Anything, v1.UpdateOptions{}).Return(&v2.Dogu{}, nil)
+		requeueHandlerMock := NewMockRequeueHandler(t)
+		requeueHandlerMock.EXPECT().Handle(mock.Anything, mock.AnythingOfType("*v2.Dogu"), nil, time.Duration(0)).Return(controllerruntime.Result{}, nil)
+
+		sut := &DoguReconciler{
+			client:            newClient(t, 1),
+			doguChangeHandler: changeHandlerMock,
+			doguDeleteHandler: NewMockDoguUsecase(t),
+			doguInterface:     doguInterfaceMock,
+			requeueHandler:    requeueHandlerMock,
+			eventRecorder:     recorderMock,
+			locker:            coordination.NewLocker(),
+		}
+
+		// when
+		_, err := sut.Reconcile(testCtx, req)
+
+		// then
+		require.NoError(t, err)
+	})
+}
+
+func TestDoguReconciler_Reconcile_locked(t *testing.T) {
+	t.Run("should requeue without running the handler if the dogu is locked by a dependent dogu", func(t *testing.T) {
+		// given
+		scheme := runtime.NewScheme()
+		require.NoError(t, v2.AddToScheme(scheme))
+		doguResource := &v2.Dogu{ObjectMeta: v1.ObjectMeta{Name: testDoguName, Namespace: "ecosystem"}}
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(doguResource).Build()
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Event(mock.AnythingOfType("*v2.Dogu"), v3.EventTypeNormal, ReconcileStartedEventReason, "reconciliation started")
+
+		dependent := types.NamespacedName{Namespace: "ecosystem", Name: "dependent"}
+		locker := coordination.NewLocker()
+		require.True(t, locker.TryLockDogu(dependent))
+		require.Empty(t, locker.TryLockDependencies(dependent, []string{testDoguName}))
+
+		sut := &DoguReconciler{
+			client:            k8sClient,
+			doguChangeHandler: NewMockDoguUsecase(t),
+			doguDeleteHandler: NewMockDoguUsecase(t),
+			doguInterface:     newMockDoguInterface(t),
+			requeueHandler:    NewMockRequeueHandler(t),
+			eventRecorder:     recorderMock,
+			locker:            locker,
+		}
+
+		// when
+		got, err := sut.Reconcile(testCtx, controllerruntime.Request{NamespacedName: types.NamespacedName{Namespace: "ecosystem", Name: testDoguName}})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, controllerruntime.Result{RequeueAfter: requeueAfterDoguLocked}, got)
+	})
+}
@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8swait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// ConditionRequeueBackoff is true while the reconciles of a dogu fail and the dogu is requeued with backoff.
+	// The DoguStatus is defined in the k8s-dogu-lib, so the attempt count and next retry time are exposed in this condition.
+	ConditionRequeueBackoff = "RequeueBackoff"
+	// ReasonBackoffReconcileFailed is the reason of the RequeueBackoff condition if the last reconcile failed.
+	ReasonBackoffReconcileFailed = "ReconcileFailed"
+	// ReasonBackoffReconcileSucceeded is the reason of the RequeueBackoff condition if the last reconcile did not fail.
+	ReasonBackoffReconcileSucceeded = "ReconcileSucceeded"
+	// ReasonBackoffReconcileTerminal is the reason of the RequeueBackoff condition if the last reconcile failed with a
+	// terminal error and is not retried.
+	ReasonBackoffReconcileTerminal = "ReconcileTerminal"
+)
+
+// requeueBackoffJitterFactor spreads the requeues of dogus which failed at the same time by up to 10 percent.
+const requeueBackoffJitterFactor = 0.1
+
+// requeueBackoff tracks the consecutive failed reconciles per dogu and calculates the requeue time with
+// capped exponential backoff and jitter.
+type requeueBackoff struct {
+	mutex     sync.Mutex
+	failures  map[types.NamespacedName]int
+	baseDelay time.Duration
+	maxDelay  time.Duration
+	jitter    func(duration time.Duration, maxFactor float64) time.Duration
+}
+
+func newRequeueBackoff(baseDelay time.Duration, maxDelay time.Duration) *requeueBackoff {
+	return &requeueBackoff{
+		failures:  map[types.NamespacedName]int{},
+		baseDelay: baseDelay,
+		maxDelay:  max(baseDelay, maxDelay),
+		jitter:    k8swait.Jitter,
+	}
+}
+
+// failed counts a failed reconcile of the dogu and returns the number of consecutive failures and the time to wait
+// until the next attempt. The delay doubles with every failure until it reaches the maximum delay.
+func (b *requeueBackoff) failed(dogu types.NamespacedName) (attempt int, delay time.Duration) {
+	b.mutex.Lock()
+	b.failures[dogu]++
+	attempt = b.failures[dogu]
+	b.mutex.Unlock()
+
+	delay = b.baseDelay
+	for i := 1; i < attempt && delay < b.maxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, b.maxDelay)
+
+	return attempt, b.jitter(delay, requeueBackoffJitterFactor)
+}
+
+// terminated counts a reconcile of the dogu which failed with a terminal error and returns the number of consecutive
+// failures. The dogu is not requeued, but the failures are kept, so a retry after a spec change continues the backoff.
+func (b *requeueBackoff) terminated(dogu types.NamespacedName) (attempt int) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.failures[dogu]++
+	return b.failures[dogu]
+}
+
+// reset forgets the failures of the dogu.
+func (b *requeueBackoff) reset(dogu types.NamespacedName) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	delete(b.failures, dogu)
+}
+
+func newRequeueBackoffCondition(attempt int, nextRetry time.Time, terminal bool) metav1.Condition {
+	if terminal {
+		return metav1.Condition{
+			Type:    ConditionRequeueBackoff,
+			Status:  metav1.ConditionFalse,
+			Reason:  ReasonBackoffReconcileTerminal,
+			Message: fmt.Sprintf("Attempt %d failed with a terminal error; no retry is scheduled", attempt),
+		}
+	}
+
+	if attempt == 0 {
+		return metav1.Condition{
+			Type:    ConditionRequeueBackoff,
+			Status:  metav1.ConditionFalse,
+			Reason:  ReasonBackoffReconcileSucceeded,
+			Message: "The last reconcile did not fail",
+		}
+	}
+
+	return metav1.Condition{
+		Type:    ConditionRequeueBackoff,
+		Status:  metav1.ConditionTrue,
+		Reason:  ReasonBackoffReconcileFailed,
+		Message: fmt.Sprintf("Attempt %d failed; next retry at %s", attempt, nextRetry.UTC().Format(time.RFC3339)),
+	}
+}
@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var testBackoffDogu = types.NamespacedName{Namespace: testNamespace, Name: testDoguName}
+
+func Test_requeueBackoff_failed(t *testing.T) {
+	t.Run("should double the delay until the maximum is reached", func(t *testing.T) {
+		// given
+		backoff := newRequeueBackoff(5*time.Second, 30*time.Second)
+		backoff.jitter = func(duration time.Duration, _ float64) time.Duration {
+			return duration
+		}
+		wantDelays := []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second, 30 * time.Second, 30 * time.Second}
+
+		for i, wantDelay := range wantDelays {
+			// when
+			attempt, delay := backoff.failed(testBackoffDogu)
+
+			// then
+			assert.Equal(t, i+1, attempt)
+			assert.Equal(t, wantDelay, delay)
+		}
+	})
+	t.Run("should not overflow after many failures", func(t *testing.T) {
+		// given
+		backoff := newRequeueBackoff(5*time.Second, 5*time.Minute)
+		backoff.failures[testBackoffDogu] = 1000
+
+		// when
+		_, delay := backoff.failed(testBackoffDogu)
+
+		// then
+		assert.GreaterOrEqual(t, delay, 5*time.Minute)
+		assert.LessOrEqual(t, delay, 5*time.Minute+30*time.Second)
+	})
+	t.Run("should add jitter to the delay", func(t *testing.T) {
+		// given
+		backoff := newRequeueBackoff(5*time.Second, time.Minute)
+		backoff.jitter = func(duration time.Duration, maxFactor float64) time.Duration {
+			assert.Equal(t, requeueBackoffJitterFactor, maxFactor)
+			return duration + time.Second
+		}
+
+		// when
+		_, delay := backoff.failed(testBackoffDogu)
+
+		// then
+		assert.Equal(t, 6*time.Second, delay)
+	})
+	t.Run("should track dogus separately", func(t *testing.T) {
+		// given
+		backoff := newRequeueBackoff(5*time.Second, time.Minute)
+		backoff.failed(testBackoffDogu)
+
+		// when
+		attempt, _ := backoff.failed(types.NamespacedName{Namespace: testNamespace, Name: "other"})
+
+		// then
+		assert.Equal(t, 1, attempt)
+	})
+	t.Run("should track dogus with the same name in different namespaces separately", func(t *testing.T) {
+		// given
+		backoff := newRequeueBackoff(5*time.Second, time.Minute)
+		backoff.failed(testBackoffDogu)
+
+		// when
+		attempt, _ := backoff.failed(types.NamespacedName{Namespace: "other", Name: testDoguName})
+
+		// then
+		assert.Equal(t, 1, attempt)
+	})
+	t.Run("should use the base delay as maximum if the maximum is lower", func(t *testing.T) {
+		// given
+		backoff := newRequeueBackoff(5*time.Second, time.Second)
+
+		// then
+		assert.Equal(t, 5*time.Second, backoff.maxDelay)
+	})
+}
+
+func Test_requeueBackoff_reset(t *testing.T) {
+	// given
+	backoff := newRequeueBackoff(5*time.Second, time.Minute)
+	backoff.failed(testBackoffDogu)
+	backoff.failed(testBackoffDogu)
+
+	// when
+	backoff.reset(testBackoffDogu)
+
+	// then
+	attempt, _ := backoff.failed(testBackoffDogu)
+	assert.Equal(t, 1, attempt)
+}
+
+func Test_requeueBackoff_terminated(t *testing.T) {
+	// given
+	backoff := newTestRequeueBackoff()
+	backoff.failed(testBackoffDogu)
+
+	// when
+	attempt := backoff.terminated(testBackoffDogu)
+
+	// then
+	assert.Equal(t, 2, attempt)
+	_, delay := backoff.failed(testBackoffDogu)
+	assert.Equal(t, 4*requeueTime, delay)
+}
+
+func Test_newRequeueBackoffCondition(t *testing.T) {
+	t.Run("should create false condition without failures", func(t *testing.T) {
+		// when
+		condition := newRequeueBackoffCondition(0, time.Time{}, false)
+
+		// then
+		assert.Equal(t, ConditionRequeueBackoff, condition.Type)
+		assert.Equal(t, v1.ConditionFalse, condition.Status)
+		assert.Equal(t, ReasonBackoffReconcileSucceeded, condition.Reason)
+	})
+	t.Run("should create true condition with attempt and next retry", func(t *testing.T) {
+		// when
+		condition := newRequeueBackoffCondition(3, time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC), false)
+
+		// then
+		assert.Equal(t, ConditionRequeueBackoff, condition.Type)
+		assert.Equal(t, v1.ConditionTrue, condition.Status)
+		assert.Equal(t, ReasonBackoffReconcileFailed, condition.Reason)
+		assert.Equal(t, "Attempt 3 failed; next retry at 2026-10-17T12:00:00Z", condition.Message)
+	})
+	t.Run("should create false condition with terminal reason on terminal error", func(t *testing.T) {
+		// when
+		condition := newRequeueBackoffCondition(2, time.Time{}, true)
+
+		// then
+		assert.Equal(t, ConditionRequeueBackoff, condition.Type)
+		assert.Equal(t, v1.ConditionFalse, condition.Status)
+		assert.Equal(t, ReasonBackoffReconcileTerminal, condition.Reason)
+		assert.Equal(t, "Attempt 2 failed with a terminal error; no retry is scheduled", condition.Message)
+	})
+}
@@ -7,11 +7,14 @@ import (
 
 	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/metrics"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -28,26 +31,43 @@ type doguRequeueHandler struct {
 	recorder      record.EventRecorder
 	doguInterface doguClient.DoguInterface
 	requeueTime   time.Duration
+	metrics       requeueRecorder
+	backoff       *requeueBackoff
 }
 
 // NewDoguRequeueHandler creates a new dogu requeue handler.
-func NewDoguRequeueHandler(doguInterface doguClient.DoguInterface, recorder record.EventRecorder, operatorConfig *config.OperatorConfig) *doguRequeueHandler {
+func NewDoguRequeueHandler(doguInterface doguClient.DoguInterface, recorder record.EventRecorder, operatorConfig *config.OperatorConfig, requeueMetrics metrics.RequeueRecorder) *doguRequeueHandler {
 	return &doguRequeueHandler{
 		doguInterface: doguInterface,
 		namespace:     operatorConfig.Namespace,
 		recorder:      recorder,
 		requeueTime:   operatorConfig.RequeueTimeForDoguReconciler,
+		metrics:       requeueMetrics,
+		backoff:       newRequeueBackoff(operatorConfig.RequeueTimeForDoguReconciler, operatorConfig.MaxRequeueTimeForDoguReconciler),
 	}
 }
 
 func (d *doguRequeueHandler) Handle(ctx context.Context, doguResource *doguv2.Dogu, reconcileError error, reqTime time.Duration) (ctrl.Result, error) {
-	result := d.handleRequeue(doguResource, reconcileError, reqTime)
+	result, attempt := d.handleRequeue(doguResource, reconcileError, reqTime)
 	d.handleRequeueEvent(doguResource, reconcileError, result.RequeueAfter)
-	d.handleRequeueTime(ctx, doguResource, &result)
+	d.handleRequeueTime(ctx, doguResource, &result, attempt, reconcileError)
+	d.handleRequeueMetrics(doguResource, result.RequeueAfter)
 	return result, nil
 }
 
-func (d *doguRequeueHandler) handleRequeueTime(ctx context.Context, doguResource *doguv2.Dogu, result *ctrl.Result) {
+func (d *doguRequeueHandler) handleRequeueMetrics(doguResource *doguv2.Dogu, requeueAfter time.Duration) {
+	emptyDogu := &doguv2.Dogu{}
+	if reflect.DeepEqual(doguResource, emptyDogu) {
+		return
+	}
+	if !doguResource.DeletionTimestamp.IsZero() {
+		d.metrics.ForgetDogu(doguResource.GetObjectKey())
+		return
+	}
+	d.metrics.SetRequeueTime(doguResource.GetObjectKey(), requeueAfter)
+}
+
+func (d *doguRequeueHandler) handleRequeueTime(ctx context.Context, doguResource *doguv2.Dogu, result *ctrl.Result, attempt int, reconcileError error) {
 	logger := log.FromContext(ctx)
 	emptyDogu := &doguv2.Dogu{}
 	if reflect.DeepEqual(doguResource, emptyDogu) || !doguResource.DeletionTimestamp.IsZero() {
@@ -57,17 +77,21 @@ func (d *doguRequeueHandler) handleRequeueTime(ctx context.Context, doguResource
 	var err error
 	doguResource, err = d.doguInterface.Get(ctx, doguResource.Name, metav1.GetOptions{})
 	if err != nil {
-		result.RequeueAfter = d.requeueTime
+		result.RequeueAfter = max(result.RequeueAfter, d.requeueTime)
 		logger.Error(err, "failed to get doguResource for setting requeue time")
 		return
 	}
 
+	backoffCondition := newRequeueBackoffCondition(attempt, time.Now().Add(result.RequeueAfter), steps.IsTerminal(reconcileError))
+	stalledCondition := newStalledCondition(reconcileError, doguResource.Generation)
 	updatedDoguResource, err := d.doguInterface.UpdateStatusWithRetry(ctx, doguResource, func(status doguv2.DoguStatus) doguv2.DoguStatus {
 		status.RequeueTime = result.RequeueAfter
+		meta.SetStatusCondition(&status.Conditions, backoffCondition)
+		meta.SetStatusCondition(&status.Conditions, stalledCondition)
 		return status
 	}, metav1.UpdateOptions{})
 	if err != nil {
-		result.RequeueAfter = d.requeueTime
+		result.RequeueAfter = max(result.RequeueAfter, d.requeueTime)
 		logger.Error(err, "failed to set requeue time")
 		return
 	}
@@ -81,6 +105,10 @@ func (d *doguRequeueHandler) handleRequeueEvent(doguResource *doguv2.Dogu, recon
 	}
 	if reconcileError == nil && reqTime == 0 {
 		d.recorder.Event(doguResource, v1.EventTypeNormal, ReasonReconcileOK, "resource synced")
+	} else if steps.ClassifyError(reconcileError) == steps.ErrorClassTerminalUntilSpecChange {
+		d.recorder.Eventf(doguResource, v1.EventTypeWarning, ReasonReconcileFail, "Not trying again until the dogu spec changes because of: %s", reconcileError.Error())
+	} else if steps.IsTerminal(reconcileError) {
+		d.recorder.Eventf(doguResource, v1.EventTypeWarning, ReasonReconcileFail, "Not trying again because of: %s", reconcileError.Error())
 	} else if reconcileError != nil {
 		d.recorder.Eventf(doguResource, v1.EventTypeWarning, ReasonReconcileFail, "Trying again in %s because of: %s", reqTime.String(), reconcileError.Error())
 	} else {
@@ -88,21 +116,34 @@ func (d *doguRequeueHandler) handleRequeueEvent(doguResource *doguv2.Dogu, recon
 	}
 }
 
-func (d *doguRequeueHandler) handleRequeue(doguResource *doguv2.Dogu, reconcileError error, reqTime time.Duration) ctrl.Result {
+// handleRequeue returns the result of the reconcile and the number of consecutive failed reconciles of the dogu.
+// Failed reconciles are requeued with exponential backoff which is reset as soon as a reconcile does not fail.
+// Reconciles failing with terminal errors are not requeued at all, but still count as failures.
+func (d *doguRequeueHandler) handleRequeue(doguResource *doguv2.Dogu, reconcileError error, reqTime time.Duration) (ctrl.Result, int) {
 	result := ctrl.Result{}
 	emptyDogu := &doguv2.Dogu{}
-	if reflect.DeepEqual(doguResource, emptyDogu) || !doguResource.DeletionTimestamp.IsZero() {
-		return result
+	if reflect.DeepEqual(doguResource, emptyDogu) {
+		return result, 0
+	}
+	if !doguResource.DeletionTimestamp.IsZero() {
+		d.backoff.reset(doguResource.GetObjectKey())
+		return result, 0
+	}
+
+	if steps.IsTerminal(reconcileError) {
+		return result, d.backoff.terminated(doguResource.GetObjectKey())
 	}
 
 	if reconcileError != nil {
-		result.RequeueAfter = d.requeueTime
-		return result
+		attempt, delay := d.backoff.failed(doguResource.GetObjectKey())
+		result.RequeueAfter = delay
+		return result, attempt
 	}
 
+	d.backoff.reset(doguResource.GetObjectKey())
 	if reqTime > 0 {
 		result.RequeueAfter = reqTime
 	}
 
-	return result
+	return result, 0
 }
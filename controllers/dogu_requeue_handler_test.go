@@ -12,9 +12,13 @@ import (
 	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	"github.com/cloudogu/k8s-dogu-lib/v2/client"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	v2 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 )
@@ -29,12 +33,15 @@ func TestNewDoguRequeueHandler(t *testing.T) {
 		eventRecorderMock := newMockEventRecorder(t)
 
 		// when
-		handler := NewDoguRequeueHandler(doguInterfaceMock, eventRecorderMock, conf)
+		metricsMock := newMockRequeueRecorder(t)
+		handler := NewDoguRequeueHandler(doguInterfaceMock, eventRecorderMock, conf, metricsMock)
 
 		// then
 		assert.Same(t, doguInterfaceMock, handler.doguInterface)
+		assert.Same(t, metricsMock, handler.metrics)
 		assert.Same(t, eventRecorderMock, handler.recorder)
 		assert.Equal(t, testNamespace, handler.namespace)
+		assert.NotNil(t, handler.backoff)
 	})
 }
 
@@ -77,6 +84,11 @@ func Test_doguRequeueHandler_Handle(t *testing.T) {
 					mck.EXPECT().UpdateStatusWithRetry(testCtx, getDogu, mock.Anything, v1.UpdateOptions{}).Run(func(ctx context.Context, dogu *doguv2.Dogu, modifyStatusFn func(doguv2.DoguStatus) doguv2.DoguStatus, opts v1.UpdateOptions) {
 						status := modifyStatusFn(dogu.Status)
 						assert.Equal(t, requeueTime, status.RequeueTime)
+						backoffCondition := meta.FindStatusCondition(status.Conditions, ConditionRequeueBackoff)
+						require.NotNil(t, backoffCondition)
+						assert.Equal(t, v1.ConditionTrue, backoffCondition.Status)
+						assert.Equal(t, ReasonBackoffReconcileFailed, backoffCondition.Reason)
+						assert.Contains(t, backoffCondition.Message, "Attempt 1 failed; next retry at ")
 					}).Return(updateDogu, nil)
 					return mck
 				},
@@ -147,6 +159,74 @@ func Test_doguRequeueHandler_Handle(t *testing.T) {
 			want:    controllerruntime.Result{RequeueAfter: requeueTime},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "should not requeue on terminal error",
+			fields: fields{
+				recorderFn: func(t *testing.T) record.EventRecorder {
+					mck := newMockEventRecorder(t)
+					mck.EXPECT().Eventf(
+						&doguv2.Dogu{ObjectMeta: v1.ObjectMeta{Name: testDoguName}},
+						v2.EventTypeWarning,
+						ReasonReconcileFail,
+						"Not trying again until the dogu spec changes because of: %s", assert.AnError.Error()).Return()
+					return mck
+				},
+				doguInterfaceFn: func(t *testing.T) client.DoguInterface {
+					mck := newMockDoguInterface(t)
+					getDogu := &doguv2.Dogu{ObjectMeta: v1.ObjectMeta{Name: testDoguName, Generation: 4}}
+					mck.EXPECT().Get(testCtx, testDoguName, v1.GetOptions{}).Return(getDogu, nil)
+					mck.EXPECT().UpdateStatusWithRetry(testCtx, getDogu, mock.Anything, v1.UpdateOptions{}).Run(func(ctx context.Context, dogu *doguv2.Dogu, modifyStatusFn func(doguv2.DoguStatus) doguv2.DoguStatus, opts v1.UpdateOptions) {
+						status := modifyStatusFn(dogu.Status)
+						assert.Equal(t, time.Duration(0), status.RequeueTime)
+						stalledCondition := meta.FindStatusCondition(status.Conditions, ConditionStalled)
+						require.NotNil(t, stalledCondition)
+						assert.Equal(t, v1.ConditionTrue, stalledCondition.Status)
+						assert.Equal(t, ReasonTerminalUntilSpecChange, stalledCondition.Reason)
+						assert.Equal(t, int64(4), stalledCondition.ObservedGeneration)
+						backoffCondition := meta.FindStatusCondition(status.Conditions, ConditionRequeueBackoff)
+						require.NotNil(t, backoffCondition)
+						assert.Equal(t, v1.ConditionFalse, backoffCondition.Status)
+						assert.Equal(t, ReasonBackoffReconcileTerminal, backoffCondition.Reason)
+					}).Return(getDogu, nil)
+					return mck
+				},
+			},
+			args: args{
+				doguResource: &doguv2.Dogu{ObjectMeta: v1.ObjectMeta{Name: testDoguName}},
+				err:          steps.NewTerminalUntilSpecChangeError(assert.AnError),
+				reqTime:      time.Duration(0),
+			},
+			want:    controllerruntime.Result{},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "should not requeue on terminal error without event about spec",
+			fields: fields{
+				recorderFn: func(t *testing.T) record.EventRecorder {
+					mck := newMockEventRecorder(t)
+					mck.EXPECT().Eventf(
+						&doguv2.Dogu{ObjectMeta: v1.ObjectMeta{Name: testDoguName}},
+						v2.EventTypeWarning,
+						ReasonReconcileFail,
+						"Not trying again because of: %s", assert.AnError.Error()).Return()
+					return mck
+				},
+				doguInterfaceFn: func(t *testing.T) client.DoguInterface {
+					mck := newMockDoguInterface(t)
+					getDogu := &doguv2.Dogu{ObjectMeta: v1.ObjectMeta{Name: testDoguName}}
+					mck.EXPECT().Get(testCtx, testDoguName, v1.GetOptions{}).Return(getDogu, nil)
+					mck.EXPECT().UpdateStatusWithRetry(testCtx, getDogu, mock.Anything, v1.UpdateOptions{}).Return(getDogu, nil)
+					return mck
+				},
+			},
+			args: args{
+				doguResource: &doguv2.Dogu{ObjectMeta: v1.ObjectMeta{Name: testDoguName}},
+				err:          steps.NewTerminalError(assert.AnError),
+				reqTime:      time.Duration(0),
+			},
+			want:    controllerruntime.Result{},
+			wantErr: assert.NoError,
+		},
 		{
 			name: "should reconcile on requeue time",
 			fields: fields{
@@ -203,6 +283,10 @@ func Test_doguRequeueHandler_Handle(t *testing.T) {
 					mck.EXPECT().UpdateStatusWithRetry(testCtx, getDogu, mock.Anything, v1.UpdateOptions{}).Run(func(ctx context.Context, dogu *doguv2.Dogu, modifyStatusFn func(doguv2.DoguStatus) doguv2.DoguStatus, opts v1.UpdateOptions) {
 						status := modifyStatusFn(dogu.Status)
 						assert.Equal(t, time.Duration(0), status.RequeueTime)
+						backoffCondition := meta.FindStatusCondition(status.Conditions, ConditionRequeueBackoff)
+						require.NotNil(t, backoffCondition)
+						assert.Equal(t, v1.ConditionFalse, backoffCondition.Status)
+						assert.Equal(t, ReasonBackoffReconcileSucceeded, backoffCondition.Reason)
 					}).Return(updateDogu, nil)
 					return mck
 				},
@@ -258,11 +342,16 @@ func Test_doguRequeueHandler_Handle(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			metricsMock := newMockRequeueRecorder(t)
+			metricsMock.EXPECT().SetRequeueTime(mock.Anything, mock.Anything).Return().Maybe()
+			metricsMock.EXPECT().ForgetDogu(mock.Anything).Return().Maybe()
 			d := &doguRequeueHandler{
 				namespace:     "ecosystem",
 				recorder:      tt.fields.recorderFn(t),
 				doguInterface: tt.fields.doguInterfaceFn(t),
 				requeueTime:   time.Second * 5,
+				metrics:       metricsMock,
+				backoff:       newTestRequeueBackoff(),
 			}
 			got, err := d.Handle(testCtx, tt.args.doguResource, tt.args.err, tt.args.reqTime)
 			if !tt.wantErr(t, err, fmt.Sprintf("Handle(%v, %v, %v, %v)", testCtx, tt.args.doguResource, tt.args.err, tt.args.reqTime)) {
@@ -272,3 +361,97 @@ func Test_doguRequeueHandler_Handle(t *testing.T) {
 		})
 	}
 }
+
+func Test_doguRequeueHandler_handleRequeueMetrics(t *testing.T) {
+	t.Run("should set requeue time", func(t *testing.T) {
+		metricsMock := newMockRequeueRecorder(t)
+		metricsMock.EXPECT().SetRequeueTime(types.NamespacedName{Name: testDoguName}, requeueTime).Return()
+		d := &doguRequeueHandler{metrics: metricsMock}
+
+		d.handleRequeueMetrics(&doguv2.Dogu{ObjectMeta: v1.ObjectMeta{Name: testDoguName}}, requeueTime)
+	})
+	t.Run("should forget deleted dogu", func(t *testing.T) {
+		metricsMock := newMockRequeueRecorder(t)
+		metricsMock.EXPECT().ForgetDogu(types.NamespacedName{Name: testDoguName}).Return()
+		d := &doguRequeueHandler{metrics: metricsMock}
+
+		d.handleRequeueMetrics(&doguv2.Dogu{ObjectMeta: v1.ObjectMeta{Name: testDoguName, DeletionTimestamp: &v1.Time{Time: time.Now()}}}, 0)
+	})
+	t.Run("should do nothing for empty dogu", func(t *testing.T) {
+		d := &doguRequeueHandler{metrics: newMockRequeueRecorder(t)}
+
+		d.handleRequeueMetrics(&doguv2.Dogu{}, requeueTime)
+	})
+}
+
+func Test_doguRequeueHandler_Handle_backoff(t *testing.T) {
+	doguResource := &doguv2.Dogu{ObjectMeta: v1.ObjectMeta{Name: testDoguName}}
+
+	newHandler := func(t *testing.T) *doguRequeueHandler {
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+		recorderMock.EXPECT().Event(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, testDoguName, v1.GetOptions{}).Return(doguResource, nil)
+		doguInterfaceMock.EXPECT().UpdateStatusWithRetry(testCtx, doguResource, mock.Anything, v1.UpdateOptions{}).Return(doguResource, nil)
+		metricsMock := newMockRequeueRecorder(t)
+		metricsMock.EXPECT().SetRequeueTime(types.NamespacedName{Name: testDoguName}, mock.Anything).Return()
+		return &doguRequeueHandler{
+			recorder:      recorderMock,
+			doguInterface: doguInterfaceMock,
+			requeueTime:   requeueTime,
+			metrics:       metricsMock,
+			backoff:       newTestRequeueBackoff(),
+		}
+	}
+
+	t.Run("should increase requeue time with every failure", func(t *testing.T) {
+		// given
+		d := newHandler(t)
+
+		// when
+		first, _ := d.Handle(testCtx, doguResource, assert.AnError, 0)
+		second, _ := d.Handle(testCtx, doguResource, assert.AnError, 0)
+		third, _ := d.Handle(testCtx, doguResource, assert.AnError, 0)
+
+		// then
+		assert.Equal(t, requeueTime, first.RequeueAfter)
+		assert.Equal(t, 2*requeueTime, second.RequeueAfter)
+		assert.Equal(t, 4*requeueTime, third.RequeueAfter)
+	})
+	t.Run("should not requeue on terminal error and keep backoff", func(t *testing.T) {
+		// given
+		d := newHandler(t)
+		_, _ = d.Handle(testCtx, doguResource, assert.AnError, 0)
+
+		// when
+		got, err := d.Handle(testCtx, doguResource, steps.NewTerminalUntilSpecChangeError(assert.AnError), 0)
+		next, _ := d.Handle(testCtx, doguResource, assert.AnError, 0)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, controllerruntime.Result{}, got)
+		assert.Equal(t, 4*requeueTime, next.RequeueAfter)
+	})
+	t.Run("should reset requeue time after reconcile without error", func(t *testing.T) {
+		// given
+		d := newHandler(t)
+		_, _ = d.Handle(testCtx, doguResource, assert.AnError, 0)
+		_, _ = d.Handle(testCtx, doguResource, assert.AnError, 0)
+
+		// when
+		_, _ = d.Handle(testCtx, doguResource, nil, 0)
+		got, _ := d.Handle(testCtx, doguResource, assert.AnError, 0)
+
+		// then
+		assert.Equal(t, requeueTime, got.RequeueAfter)
+	})
+}
+
+func newTestRequeueBackoff() *requeueBackoff {
+	backoff := newRequeueBackoff(requeueTime, time.Minute)
+	backoff.jitter = func(duration time.Duration, _ float64) time.Duration {
+		return duration
+	}
+	return backoff
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/namespaced"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -199,6 +200,7 @@ func TestNewDoguRestartReconciler(t *testing.T) {
 }
 
 func TestDoguRestartReconciler_Reconcile(t *testing.T) {
+	namespacedCtx := namespaced.WithNamespace(testCtx, "ecosystem")
 	type fields struct {
 		doguInterfaceFn        func(t *testing.T) doguInterface
 		doguRestartInterfaceFn func(t *testing.T) doguRestartInterface
@@ -218,15 +220,15 @@ func TestDoguRestartReconciler_Reconcile(t *testing.T) {
 				doguInterfaceFn: func(t *testing.T) doguInterface {
 					mck := newMockDoguInterface(t)
 					dogu := &v2.Dogu{}
-					mck.EXPECT().Get(testCtx, testCasDoguName, metav1.GetOptions{}).Return(dogu, nil)
-					mck.EXPECT().UpdateSpecWithRetry(testCtx, mock.Anything, mock.Anything, metav1.UpdateOptions{}).Return(nil, assert.AnError)
+					mck.EXPECT().Get(namespacedCtx, testCasDoguName, metav1.GetOptions{}).Return(dogu, nil)
+					mck.EXPECT().UpdateSpecWithRetry(namespacedCtx, mock.Anything, mock.Anything, metav1.UpdateOptions{}).Return(nil, assert.AnError)
 					return mck
 				},
 				doguRestartInterfaceFn: func(t *testing.T) doguRestartInterface {
 					mck := newMockDoguRestartInterface(t)
 					doguRestart := &v2.DoguRestart{Spec: v2.DoguRestartSpec{DoguName: testCasDoguName}, Status: v2.DoguRestartStatus{Phase: v2.RestartStatusPhaseStopped}}
-					mck.EXPECT().Get(testCtx, testCasRestartName, metav1.GetOptions{}).Return(doguRestart, nil)
-					mck.EXPECT().UpdateStatusWithRetry(testCtx, mock.Anything, mock.Anything, metav1.UpdateOptions{}).Return(nil, assert.AnError)
+					mck.EXPECT().Get(namespacedCtx, testCasRestartName, metav1.GetOptions{}).Return(doguRestart, nil)
+					mck.EXPECT().UpdateStatusWithRetry(namespacedCtx, mock.Anything, mock.Anything, metav1.UpdateOptions{}).Return(nil, assert.AnError)
 					return mck
 				},
 				garbageCollectorFn: func(t *testing.T) DoguRestartGarbageCollector {
@@ -238,7 +240,7 @@ func TestDoguRestartReconciler_Reconcile(t *testing.T) {
 					return mck
 				},
 			},
-			req:     reconcile.Request{NamespacedName: types.NamespacedName{Name: testCasRestartName}},
+			req:     reconcile.Request{NamespacedName: types.NamespacedName{Name: testCasRestartName, Namespace: "ecosystem"}},
 			want:    controllerruntime.Result{},
 			wantErr: assert.Error,
 		},
@@ -0,0 +1,11 @@
+package dogustore
+
+import (
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+//nolint:unused
+//goland:noinspection GoUnusedType
+type configMapInterface interface {
+	v1.ConfigMapInterface
+}
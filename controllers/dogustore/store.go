@@ -0,0 +1,127 @@
+// Package dogustore keeps operator state of a dogu in config maps that belong to the dogu.
+//
+// The config maps get a non-controlling owner reference to their dogu, so they are garbage collected together with the
+// dogu. The dogu controller only reconciles a dogu for config maps it controls, so updates of these config maps do not
+// trigger a reconcile.
+package dogustore
+
+import (
+	"context"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/resource"
+	"github.com/cloudogu/retry-lib/retry"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// ConfigMapStore reads and writes one kind of config map per dogu. The config maps are named after the dogu with a
+// suffix and marked with a label.
+type ConfigMapStore struct {
+	configMapInterface configMapInterface
+	scheme             *runtime.Scheme
+	nameSuffix         string
+	label              string
+}
+
+// NewConfigMapStore creates a ConfigMapStore for the config maps with the given name suffix and label.
+func NewConfigMapStore(configMapInterface v1.ConfigMapInterface, scheme *runtime.Scheme, nameSuffix string, label string) *ConfigMapStore {
+	return &ConfigMapStore{
+		configMapInterface: configMapInterface,
+		scheme:             scheme,
+		nameSuffix:         nameSuffix,
+		label:              label,
+	}
+}
+
+// ConfigMapName returns the name of the config map of the dogu.
+func (s *ConfigMapStore) ConfigMapName(doguName string) string {
+	return doguName + s.nameSuffix
+}
+
+// Get returns the data of the config map of the dogu or nil if the config map does not exist.
+func (s *ConfigMapStore) Get(ctx context.Context, doguResource *v2.Dogu) (map[string]string, error) {
+	cm, err := s.configMapInterface.Get(ctx, s.ConfigMapName(doguResource.Name), metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if cm.Data == nil {
+		return map[string]string{}, nil
+	}
+
+	return cm.Data, nil
+}
+
+// Update creates the config map of the dogu or updates the existing one. The given function changes the data of the
+// config map, which is empty for a new config map. The update is retried with fresh data on conflicts.
+func (s *ConfigMapStore) Update(ctx context.Context, doguResource *v2.Dogu, update func(data map[string]string) error) error {
+	return retry.OnConflict(func() error {
+		cm, err := s.configMapInterface.Get(ctx, s.ConfigMapName(doguResource.Name), metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			cm, err = s.newConfigMap(doguResource)
+			if err != nil {
+				return err
+			}
+			if err = update(cm.Data); err != nil {
+				return err
+			}
+			_, err = s.configMapInterface.Create(ctx, cm, metav1.CreateOptions{})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		if err = update(cm.Data); err != nil {
+			return err
+		}
+		_, err = s.configMapInterface.Update(ctx, cm, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// Delete deletes the config map of the dogu. A missing config map is not an error.
+func (s *ConfigMapStore) Delete(ctx context.Context, doguResource *v2.Dogu) error {
+	err := s.configMapInterface.Delete(ctx, s.ConfigMapName(doguResource.Name), metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (s *ConfigMapStore) newConfigMap(doguResource *v2.Dogu) (*corev1.ConfigMap, error) {
+	gvk, err := apiutil.GVKForObject(doguResource, s.scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.ConfigMapName(doguResource.Name),
+			Namespace: doguResource.Namespace,
+			Labels: resource.GetAppLabel().
+				Add(doguResource.GetDoguNameLabel()).
+				Add(v2.CesMatchingLabels{s.label: "true"}),
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					Name:       doguResource.Name,
+					Kind:       gvk.Kind,
+					APIVersion: gvk.GroupVersion().String(),
+					UID:        doguResource.UID,
+				},
+			},
+		},
+		Data: map[string]string{},
+	}, nil
+}
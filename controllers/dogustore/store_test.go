@@ -0,0 +1,259 @@
+package dogustore
+
+import (
+	"context"
+	"testing"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var testCtx = context.Background()
+
+const (
+	testSuffix = "-test-state"
+	testLabel  = "k8s.cloudogu.com/test-state"
+)
+
+var notFoundErr = errors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "ldap-test-state")
+
+func getTestScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypeWithName(schema.GroupVersionKind{
+		Group:   "k8s.cloudogu.com",
+		Version: "v2",
+		Kind:    "Dogu",
+	}, &v2.Dogu{})
+	return scheme
+}
+
+func getTestDogu() *v2.Dogu {
+	return &v2.Dogu{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: "ecosystem", UID: "uid"},
+	}
+}
+
+func TestConfigMapStore_ConfigMapName(t *testing.T) {
+	sut := NewConfigMapStore(newMockConfigMapInterface(t), getTestScheme(), testSuffix, testLabel)
+
+	assert.Equal(t, "ldap-test-state", sut.ConfigMapName("ldap"))
+}
+
+func TestConfigMapStore_Get(t *testing.T) {
+	t.Run("should return data of config map", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-test-state", metav1.GetOptions{}).Return(&corev1.ConfigMap{Data: map[string]string{"key": "value"}}, nil)
+		sut := NewConfigMapStore(cmMock, getTestScheme(), testSuffix, testLabel)
+
+		// when
+		data, err := sut.Get(testCtx, getTestDogu())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"key": "value"}, data)
+	})
+	t.Run("should return empty data of config map without data", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-test-state", metav1.GetOptions{}).Return(&corev1.ConfigMap{}, nil)
+		sut := NewConfigMapStore(cmMock, getTestScheme(), testSuffix, testLabel)
+
+		// when
+		data, err := sut.Get(testCtx, getTestDogu())
+
+		// then
+		require.NoError(t, err)
+		assert.NotNil(t, data)
+		assert.Empty(t, data)
+	})
+	t.Run("should return nil for missing config map", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-test-state", metav1.GetOptions{}).Return(nil, notFoundErr)
+		sut := NewConfigMapStore(cmMock, getTestScheme(), testSuffix, testLabel)
+
+		// when
+		data, err := sut.Get(testCtx, getTestDogu())
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, data)
+	})
+	t.Run("should fail to get config map", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-test-state", metav1.GetOptions{}).Return(nil, assert.AnError)
+		sut := NewConfigMapStore(cmMock, getTestScheme(), testSuffix, testLabel)
+
+		// when
+		_, err := sut.Get(testCtx, getTestDogu())
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestConfigMapStore_Update(t *testing.T) {
+	setValue := func(data map[string]string) error {
+		data["key"] = "value"
+		return nil
+	}
+
+	t.Run("should create config map with non-controlling owner reference", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-test-state", metav1.GetOptions{}).Return(nil, notFoundErr)
+		cmMock.EXPECT().Create(testCtx, mock.Anything, metav1.CreateOptions{}).
+			RunAndReturn(func(ctx context.Context, cm *corev1.ConfigMap, opts metav1.CreateOptions) (*corev1.ConfigMap, error) {
+				assert.Equal(t, "ldap-test-state", cm.Name)
+				assert.Equal(t, "ecosystem", cm.Namespace)
+				assert.Equal(t, "true", cm.Labels[testLabel])
+				assert.Equal(t, "ldap", cm.Labels["dogu.name"])
+				assert.Equal(t, "ces", cm.Labels["app"])
+				require.Len(t, cm.OwnerReferences, 1)
+				assert.Equal(t, "ldap", cm.OwnerReferences[0].Name)
+				assert.Equal(t, "Dogu", cm.OwnerReferences[0].Kind)
+				assert.Equal(t, "k8s.cloudogu.com/v2", cm.OwnerReferences[0].APIVersion)
+				assert.Nil(t, cm.OwnerReferences[0].Controller)
+				assert.Equal(t, map[string]string{"key": "value"}, cm.Data)
+				return cm, nil
+			})
+		sut := NewConfigMapStore(cmMock, getTestScheme(), testSuffix, testLabel)
+
+		// when
+		err := sut.Update(testCtx, getTestDogu(), setValue)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should update existing config map", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-test-state", metav1.GetOptions{}).Return(&corev1.ConfigMap{}, nil)
+		cmMock.EXPECT().Update(testCtx, &corev1.ConfigMap{Data: map[string]string{"key": "value"}}, metav1.UpdateOptions{}).Return(nil, nil)
+		sut := NewConfigMapStore(cmMock, getTestScheme(), testSuffix, testLabel)
+
+		// when
+		err := sut.Update(testCtx, getTestDogu(), setValue)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should retry update with fresh data on conflict", func(t *testing.T) {
+		// given
+		conflictErr := errors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "ldap-test-state", assert.AnError)
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-test-state", metav1.GetOptions{}).Return(&corev1.ConfigMap{Data: map[string]string{"count": "1"}}, nil).Once()
+		cmMock.EXPECT().Get(testCtx, "ldap-test-state", metav1.GetOptions{}).Return(&corev1.ConfigMap{Data: map[string]string{"count": "2"}}, nil).Once()
+		cmMock.EXPECT().Update(testCtx, mock.Anything, metav1.UpdateOptions{}).Return(nil, conflictErr).Once()
+		cmMock.EXPECT().Update(testCtx, &corev1.ConfigMap{Data: map[string]string{"count": "3"}}, metav1.UpdateOptions{}).Return(nil, nil).Once()
+		sut := NewConfigMapStore(cmMock, getTestScheme(), testSuffix, testLabel)
+
+		// when
+		err := sut.Update(testCtx, getTestDogu(), func(data map[string]string) error {
+			if data["count"] == "2" {
+				data["count"] = "3"
+			}
+			return nil
+		})
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should not write config map if the data cannot be changed", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-test-state", metav1.GetOptions{}).Return(&corev1.ConfigMap{}, nil)
+		sut := NewConfigMapStore(cmMock, getTestScheme(), testSuffix, testLabel)
+
+		// when
+		err := sut.Update(testCtx, getTestDogu(), func(map[string]string) error { return assert.AnError })
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("should fail to get config map", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-test-state", metav1.GetOptions{}).Return(nil, assert.AnError)
+		sut := NewConfigMapStore(cmMock, getTestScheme(), testSuffix, testLabel)
+
+		// when
+		err := sut.Update(testCtx, getTestDogu(), setValue)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("should fail to get kind of dogu", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-test-state", metav1.GetOptions{}).Return(nil, notFoundErr)
+		sut := NewConfigMapStore(cmMock, runtime.NewScheme(), testSuffix, testLabel)
+
+		// when
+		err := sut.Update(testCtx, getTestDogu(), setValue)
+
+		// then
+		require.Error(t, err)
+	})
+	t.Run("should fail to create config map", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-test-state", metav1.GetOptions{}).Return(nil, notFoundErr)
+		cmMock.EXPECT().Create(testCtx, mock.Anything, metav1.CreateOptions{}).Return(nil, assert.AnError)
+		sut := NewConfigMapStore(cmMock, getTestScheme(), testSuffix, testLabel)
+
+		// when
+		err := sut.Update(testCtx, getTestDogu(), setValue)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestConfigMapStore_Delete(t *testing.T) {
+	t.Run("should delete config map", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Delete(testCtx, "ldap-test-state", metav1.DeleteOptions{}).Return(nil)
+		sut := NewConfigMapStore(cmMock, getTestScheme(), testSuffix, testLabel)
+
+		// when
+		err := sut.Delete(testCtx, getTestDogu())
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should ignore missing config map", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Delete(testCtx, "ldap-test-state", metav1.DeleteOptions{}).Return(notFoundErr)
+		sut := NewConfigMapStore(cmMock, getTestScheme(), testSuffix, testLabel)
+
+		// when
+		err := sut.Delete(testCtx, getTestDogu())
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail to delete config map", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Delete(testCtx, "ldap-test-state", metav1.DeleteOptions{}).Return(assert.AnError)
+		sut := NewConfigMapStore(cmMock, getTestScheme(), testSuffix, testLabel)
+
+		// when
+		err := sut.Delete(testCtx, getTestDogu())
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -0,0 +1,60 @@
+package drift
+
+import (
+	"slices"
+	"strings"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ConditionDrifted is true if resources of the dogu in the cluster differ from their desired state.
+	ConditionDrifted = "Drifted"
+	// ReasonInSync is the reason of the Drifted condition if all resources of the dogu match their desired state.
+	ReasonInSync = "InSync"
+	// ReasonDriftReverted is the reason of the Drifted condition if the drifted resources were reverted.
+	ReasonDriftReverted = "DriftReverted"
+	// ReasonDriftReported is the reason of the Drifted condition if drifted resources were not reverted.
+	ReasonDriftReported = "DriftReported"
+	// ReasonGenerationChanged is the reason of the Drifted condition if the resources of a new generation of the dogu
+	// are applied before their drift is detected again.
+	ReasonGenerationChanged = "GenerationChanged"
+)
+
+const driftedMessagePrefix = "Resources drifted from their desired state: "
+
+// IsReportedOnly returns true if a resource of one of the given kinds drifted in the current generation of the dogu
+// and must not be reverted, because the dogu has the drift policy PolicyReport. Steps which apply the resources of an
+// installed dogu again skip them in this case, as applying them would revert the drift. Resources of other kinds and
+// resources of a new generation are still applied.
+func IsReportedOnly(doguResource *v2.Dogu, kinds ...string) bool {
+	if PolicyOf(doguResource) != PolicyReport {
+		return false
+	}
+
+	condition := meta.FindStatusCondition(doguResource.Status.Conditions, ConditionDrifted)
+	if condition == nil || condition.Status != metav1.ConditionTrue || condition.ObservedGeneration != doguResource.Generation {
+		return false
+	}
+
+	return slices.ContainsFunc(driftedKinds(condition.Message), func(kind string) bool {
+		return slices.Contains(kinds, kind)
+	})
+}
+
+// driftedKinds returns the kinds of the drifted resources listed in the message of the Drifted condition.
+func driftedKinds(message string) []string {
+	summary, found := strings.CutPrefix(message, driftedMessagePrefix)
+	if !found {
+		return nil
+	}
+
+	var kinds []string
+	for _, resourceDrift := range strings.Split(summary, "; ") {
+		kind, _, _ := strings.Cut(resourceDrift, " ")
+		kinds = append(kinds, kind)
+	}
+	return kinds
+}
@@ -0,0 +1,33 @@
+package drift
+
+import (
+	"testing"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIsReportedOnly(t *testing.T) {
+	report := &Report{Drifts: []ResourceDrift{
+		{Kind: KindService, Name: "ldap", Fields: []string{"spec.ports"}},
+		{Kind: KindNetworkPolicy, Name: "ldap-deny-all", Missing: true},
+	}}
+	newDoguResource := func(policy Policy, status metav1.ConditionStatus, observedGeneration int64) *v2.Dogu {
+		return &v2.Dogu{
+			ObjectMeta: metav1.ObjectMeta{Generation: 2, Annotations: map[string]string{PolicyAnnotation: string(policy)}},
+			Status: v2.DoguStatus{Conditions: []metav1.Condition{
+				{Type: ConditionDrifted, Status: status, Reason: ReasonDriftReported, Message: report.Message(), ObservedGeneration: observedGeneration},
+			}},
+		}
+	}
+
+	assert.True(t, IsReportedOnly(newDoguResource(PolicyReport, metav1.ConditionTrue, 2), KindService))
+	assert.True(t, IsReportedOnly(newDoguResource(PolicyReport, metav1.ConditionTrue, 2), KindDeployment, KindNetworkPolicy))
+	assert.False(t, IsReportedOnly(newDoguResource(PolicyReport, metav1.ConditionTrue, 2), KindDeployment))
+	assert.False(t, IsReportedOnly(newDoguResource(PolicyRepair, metav1.ConditionTrue, 2), KindService))
+	assert.False(t, IsReportedOnly(newDoguResource(PolicyReport, metav1.ConditionFalse, 2), KindService))
+	assert.False(t, IsReportedOnly(newDoguResource(PolicyReport, metav1.ConditionUnknown, 2), KindService))
+	assert.False(t, IsReportedOnly(newDoguResource(PolicyReport, metav1.ConditionTrue, 1), KindService))
+	assert.False(t, IsReportedOnly(&v2.Dogu{}, KindService))
+}
@@ -0,0 +1,200 @@
+package drift
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/cloudogu/cesapp-lib/core"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/imageregistry"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/objectdiff"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/resource"
+	imagev1 "github.com/google/go-containerregistry/pkg/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ignoredFields are changed by other parts of the dogu operator and do not count as drift.
+var ignoredFields = map[string][]string{
+	// the start stop step scales the deployment down while the dogu is stopped or its volume is resized
+	KindDeployment: {"spec.replicas"},
+	// the volume expander only grows the volume and the storage class may be chosen by the cluster
+	KindPersistentVolumeClaim: {"spec.resources", "spec.storageClassName"},
+}
+
+type detector struct {
+	client                 k8sClient
+	scheme                 *runtime.Scheme
+	generator              doguResourceGenerator
+	imageRegistry          imageRegistry
+	networkPoliciesEnabled bool
+	// imageConfigs holds the config of the last pulled image per dogu image, because drift is detected on every
+	// reconcile and the config of an image version does not change.
+	imageConfigs      map[string]cachedImageConfig
+	imageConfigsMutex sync.Mutex
+}
+
+type cachedImageConfig struct {
+	version string
+	config  *imagev1.ConfigFile
+}
+
+// NewDetector creates a Detector which compares the resources of a dogu with the output of the resource generator.
+func NewDetector(
+	k8sClient client.Client,
+	scheme *runtime.Scheme,
+	generator resource.DoguResourceGenerator,
+	registry imageregistry.ImageRegistry,
+	operatorConfig *config.OperatorConfig,
+) Detector {
+	return &detector{
+		client:                 k8sClient,
+		scheme:                 scheme,
+		generator:              generator,
+		imageRegistry:          registry,
+		networkPoliciesEnabled: operatorConfig.NetworkPoliciesEnabled,
+	}
+}
+
+// Detect returns the drift of the deployment, the service, the network policies and the volume claim of the
+// installed dogu.
+// Fields which are only set in the cluster, like defaults of the API server, are ignored.
+func (d *detector) Detect(ctx context.Context, doguResource *v2.Dogu, dogu *core.Dogu) (*Report, error) {
+	report := &Report{}
+
+	deployment, err := d.generator.CreateDoguDeployment(ctx, doguResource, dogu)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate deployment: %w", err)
+	}
+	err = d.detect(ctx, report, KindDeployment, &appsv1.Deployment{}, deployment, true)
+	if err != nil {
+		return nil, err
+	}
+
+	imageConfig, err := d.pullImageConfig(ctx, dogu)
+	if err != nil {
+		return nil, err
+	}
+	service, err := d.generator.CreateDoguService(doguResource, dogu, imageConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate service: %w", err)
+	}
+	err = d.detect(ctx, report, KindService, &corev1.Service{}, service, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.networkPoliciesEnabled {
+		netPols, err := resource.GenerateDoguNetworkPolicies(doguResource, dogu, service, d.scheme)
+		if err != nil {
+			return nil, err
+		}
+		for _, netPol := range netPols {
+			err = d.detect(ctx, report, KindNetworkPolicy, &netv1.NetworkPolicy{}, netPol, true)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if resource.NeedsPVCs(dogu) {
+		pvc, err := d.generator.CreateDoguPVC(doguResource)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate pvc: %w", err)
+		}
+		// existing volume claims are never applied again, so they do not carry the hash of the current desired state
+		err = d.detect(ctx, report, KindPersistentVolumeClaim, &corev1.PersistentVolumeClaim{}, pvc, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+// pullImageConfig returns the config of the image of the dogu. The image is only pulled if its config is not cached yet.
+// Only the config of the last version is kept for every image, so the cache does not grow with upgrades.
+func (d *detector) pullImageConfig(ctx context.Context, dogu *core.Dogu) (*imagev1.ConfigFile, error) {
+	d.imageConfigsMutex.Lock()
+	cached, found := d.imageConfigs[dogu.Image]
+	d.imageConfigsMutex.Unlock()
+	if found && cached.version == dogu.Version {
+		return cached.config, nil
+	}
+
+	// the image is pulled without holding the mutex, so that slow pulls do not block the drift detection of other dogus
+	imageConfig, err := d.imageRegistry.PullImageConfig(ctx, dogu.Image+":"+dogu.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	d.imageConfigsMutex.Lock()
+	defer d.imageConfigsMutex.Unlock()
+	if d.imageConfigs == nil {
+		d.imageConfigs = map[string]cachedImageConfig{}
+	}
+	d.imageConfigs[dogu.Image] = cachedImageConfig{version: dogu.Version, config: imageConfig}
+
+	return imageConfig, nil
+}
+
+// detect adds the drift of the live object to the report.
+// If the desired state of a reapplied resource changed since it was applied last, the resource is skipped, because
+// the differences are changes which are not applied yet.
+func (d *detector) detect(ctx context.Context, report *Report, kind string, live, desired client.Object, reapplied bool) error {
+	err := d.client.Get(ctx, client.ObjectKeyFromObject(desired), live)
+	if apierrors.IsNotFound(err) {
+		report.Drifts = append(report.Drifts, ResourceDrift{Kind: kind, Name: desired.GetName(), Missing: true})
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get %s %s: %w", kind, desired.GetName(), err)
+	}
+
+	if reapplied {
+		desiredStateHash, err := resource.DesiredStateHash(desired, d.scheme)
+		if err != nil {
+			return err
+		}
+		if live.GetAnnotations()[resource.DesiredStateHashAnnotation] != desiredStateHash {
+			return nil
+		}
+	}
+
+	fields, err := objectdiff.ChangedFields(live, desired)
+	if err != nil {
+		return err
+	}
+	fields = withoutIgnoredFields(kind, fields)
+	if len(fields) > 0 {
+		report.Drifts = append(report.Drifts, ResourceDrift{Kind: kind, Name: desired.GetName(), Fields: fields})
+	}
+
+	return nil
+}
+
+func withoutIgnoredFields(kind string, fields []string) []string {
+	var result []string
+	for _, field := range fields {
+		if !isIgnored(kind, field) {
+			result = append(result, field)
+		}
+	}
+	return result
+}
+
+func isIgnored(kind, field string) bool {
+	for _, ignored := range ignoredFields[kind] {
+		if field == ignored || strings.HasPrefix(field, ignored+".") || strings.HasPrefix(field, ignored+"[") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,236 @@
+package drift
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudogu/cesapp-lib/core"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/resource"
+	imagev1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testNamespace = "ecosystem"
+
+var testCtx = context.Background()
+
+func getTestScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v2.AddToScheme(scheme))
+	return scheme
+}
+
+func getTestDoguResource() *v2.Dogu {
+	return &v2.Dogu{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: testNamespace, UID: "uid"},
+		Spec:       v2.DoguSpec{Name: "official/ldap", Version: "2.6.8-1"},
+	}
+}
+
+func getTestDogu() *core.Dogu {
+	return &core.Dogu{
+		Name:    "official/ldap",
+		Version: "2.6.8-1",
+		Image:   "registry.cloudogu.com/official/ldap",
+		Volumes: []core.Volume{{Name: "data", NeedsBackup: true}},
+	}
+}
+
+func getTestDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: testNamespace},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptrTo(int32(1)),
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ldap", Image: "ldap:2.6.8-1"}}}},
+		},
+	}
+}
+
+func getTestService() *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: testNamespace},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Name: "ldap", Port: 389, TargetPort: intstr.FromInt32(389)}},
+		},
+	}
+}
+
+func getTestPVC(size string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: testNamespace, Labels: map[string]string{v2.DoguLabelName: "ldap"}},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: k8sresource.MustParse(size)},
+			},
+		},
+	}
+}
+
+// applied returns the object like it was applied by the dogu operator and defaulted by the API server.
+func applied[T client.Object](t *testing.T, object T) T {
+	hash, err := resource.DesiredStateHash(object, getTestScheme(t))
+	require.NoError(t, err)
+	object.SetAnnotations(map[string]string{resource.DesiredStateHashAnnotation: hash})
+	object.SetUID("uid")
+	return object
+}
+
+func ptrTo[T any](value T) *T {
+	return &value
+}
+
+func newTestDetector(t *testing.T, networkPoliciesEnabled bool, objects ...client.Object) (*detector, *mockDoguResourceGenerator) {
+	imageConfig := &imagev1.ConfigFile{}
+	registryMock := newMockImageRegistry(t)
+	registryMock.EXPECT().PullImageConfig(testCtx, "registry.cloudogu.com/official/ldap:2.6.8-1").Return(imageConfig, nil).Once()
+	generatorMock := newMockDoguResourceGenerator(t)
+	generatorMock.EXPECT().CreateDoguDeployment(testCtx, mock.Anything, mock.Anything).Return(getTestDeployment(), nil)
+	generatorMock.EXPECT().CreateDoguService(mock.Anything, mock.Anything, imageConfig).Return(getTestService(), nil)
+	generatorMock.EXPECT().CreateDoguPVC(mock.Anything).Return(getTestPVC("2Gi"), nil)
+
+	return &detector{
+		client:                 fake.NewClientBuilder().WithScheme(getTestScheme(t)).WithObjects(objects...).Build(),
+		scheme:                 getTestScheme(t),
+		generator:              generatorMock,
+		imageRegistry:          registryMock,
+		networkPoliciesEnabled: networkPoliciesEnabled,
+	}, generatorMock
+}
+
+func TestNewDetector(t *testing.T) {
+	got := NewDetector(nil, nil, nil, nil, &config.OperatorConfig{NetworkPoliciesEnabled: true})
+
+	require.NotNil(t, got)
+	assert.True(t, got.(*detector).networkPoliciesEnabled)
+}
+
+func Test_detector_Detect(t *testing.T) {
+	t.Run("should not report resources which only differ in defaulted and ignored fields", func(t *testing.T) {
+		// given
+		deployment := applied(t, getTestDeployment())
+		deployment.Spec.Replicas = ptrTo(int32(0))
+		deployment.Spec.Template.Spec.Containers[0].ImagePullPolicy = corev1.PullIfNotPresent
+		service := applied(t, getTestService())
+		service.Spec.ClusterIP = "10.0.0.1"
+		pvc := getTestPVC("5Gi")
+		pvc.Spec.VolumeName = "pv-ldap"
+		sut, _ := newTestDetector(t, false, deployment, service, pvc)
+
+		// when
+		report, err := sut.Detect(testCtx, getTestDoguResource(), getTestDogu())
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, report.HasDrift())
+	})
+	t.Run("should pull the image config of a dogu version only once", func(t *testing.T) {
+		// given
+		sut, _ := newTestDetector(t, false, applied(t, getTestDeployment()), applied(t, getTestService()), getTestPVC("2Gi"))
+
+		// when
+		_, err := sut.Detect(testCtx, getTestDoguResource(), getTestDogu())
+		require.NoError(t, err)
+		report, err := sut.Detect(testCtx, getTestDoguResource(), getTestDogu())
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, report.HasDrift())
+	})
+	t.Run("should report changed and missing resources", func(t *testing.T) {
+		// given
+		deployment := applied(t, getTestDeployment())
+		deployment.Spec.Template.Spec.Containers[0].Image = "ldap:latest"
+		pvc := getTestPVC("2Gi")
+		pvc.Labels[v2.DoguLabelName] = "cas"
+		sut, _ := newTestDetector(t, true, deployment, pvc)
+
+		// when
+		report, err := sut.Detect(testCtx, getTestDoguResource(), getTestDogu())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []ResourceDrift{
+			{Kind: KindDeployment, Name: "ldap", Fields: []string{"spec.template.spec.containers[0].image"}},
+			{Kind: KindService, Name: "ldap", Missing: true},
+			{Kind: KindNetworkPolicy, Name: "ldap-deny-all", Missing: true},
+			{Kind: KindPersistentVolumeClaim, Name: "ldap", Fields: []string{"metadata.labels.dogu.name"}},
+		}, report.Drifts)
+	})
+	t.Run("should skip resources whose desired state changed since they were applied or which have no hash", func(t *testing.T) {
+		// given
+		deployment := getTestDeployment()
+		deployment.Spec.Template.Spec.Containers[0].Image = "ldap:2.6.7-1"
+		deployment = applied(t, deployment)
+		service := getTestService()
+		service.Spec.Ports[0].Port = 636
+		// resources which were never applied with a hash cannot be told apart from resources with pending changes
+		sut, _ := newTestDetector(t, false, deployment, service, getTestPVC("2Gi"))
+
+		// when
+		report, err := sut.Detect(testCtx, getTestDoguResource(), getTestDogu())
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, report.HasDrift())
+	})
+	t.Run("should fail to generate deployment", func(t *testing.T) {
+		// given
+		generatorMock := newMockDoguResourceGenerator(t)
+		generatorMock.EXPECT().CreateDoguDeployment(testCtx, mock.Anything, mock.Anything).Return(nil, assert.AnError)
+		sut := &detector{generator: generatorMock}
+
+		// when
+		_, err := sut.Detect(testCtx, getTestDoguResource(), getTestDogu())
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to generate deployment")
+	})
+	t.Run("should fail to get deployment", func(t *testing.T) {
+		// given
+		generatorMock := newMockDoguResourceGenerator(t)
+		generatorMock.EXPECT().CreateDoguDeployment(testCtx, mock.Anything, mock.Anything).Return(getTestDeployment(), nil)
+		clientMock := newMockK8sClient(t)
+		clientMock.EXPECT().Get(testCtx, client.ObjectKey{Namespace: testNamespace, Name: "ldap"}, mock.Anything).Return(assert.AnError)
+		sut := &detector{client: clientMock, generator: generatorMock}
+
+		// when
+		_, err := sut.Detect(testCtx, getTestDoguResource(), getTestDogu())
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get Deployment ldap")
+	})
+	t.Run("should fail to pull image config", func(t *testing.T) {
+		// given
+		generatorMock := newMockDoguResourceGenerator(t)
+		generatorMock.EXPECT().CreateDoguDeployment(testCtx, mock.Anything, mock.Anything).Return(getTestDeployment(), nil)
+		registryMock := newMockImageRegistry(t)
+		registryMock.EXPECT().PullImageConfig(testCtx, "registry.cloudogu.com/official/ldap:2.6.8-1").Return(nil, assert.AnError)
+		sut := &detector{
+			client:        fake.NewClientBuilder().WithScheme(getTestScheme(t)).Build(),
+			generator:     generatorMock,
+			imageRegistry: registryMock,
+		}
+
+		// when
+		_, err := sut.Detect(testCtx, getTestDoguResource(), getTestDogu())
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -0,0 +1,30 @@
+package drift
+
+import (
+	"context"
+
+	"github.com/cloudogu/cesapp-lib/core"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/imageregistry"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Detector compares the resources of a dogu in the cluster with their desired state.
+type Detector interface {
+	// Detect returns the drift of the deployment, the service, the network policies and the volume claim of the
+	// installed dogu.
+	Detect(ctx context.Context, doguResource *v2.Dogu, dogu *core.Dogu) (*Report, error)
+}
+
+type k8sClient interface {
+	client.Client
+}
+
+type doguResourceGenerator interface {
+	resource.DoguResourceGenerator
+}
+
+type imageRegistry interface {
+	imageregistry.ImageRegistry
+}
@@ -0,0 +1,46 @@
+package drift
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// PolicyAnnotation selects how the dogu operator handles drifted resources of a dogu.
+const PolicyAnnotation = "k8s.cloudogu.com/drift-policy"
+
+// Policy defines how the dogu operator handles drifted resources of a dogu.
+type Policy string
+
+const (
+	// PolicyRepair reverts drifted resources to their desired state.
+	PolicyRepair Policy = "repair"
+	// PolicyReport only reports drifted resources in the Drifted condition and with an event. This is the default policy.
+	PolicyReport Policy = "report"
+)
+
+// PolicyOf returns the drift policy of the object. Unknown policies fall back to PolicyReport, so that drifted
+// resources are only reverted if this was chosen explicitly.
+func PolicyOf(object metav1.Object) Policy {
+	if Policy(object.GetAnnotations()[PolicyAnnotation]) == PolicyRepair {
+		return PolicyRepair
+	}
+	return PolicyReport
+}
+
+// PolicyAnnotationChangedPredicate lets update events pass if the drift policy of the dogu resource changed.
+// Changed annotations do not increase the generation, so without this predicate reported drift would not be repaired
+// after the policy was switched to repair.
+func PolicyAnnotationChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return false
+			}
+			return PolicyOf(e.ObjectOld) != PolicyOf(e.ObjectNew)
+		},
+	}
+}
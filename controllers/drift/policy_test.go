@@ -0,0 +1,33 @@
+package drift
+
+import (
+	"testing"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestPolicyAnnotationChangedPredicate(t *testing.T) {
+	reporting := &v2.Dogu{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{PolicyAnnotation: "report"}}}
+	repairing := &v2.Dogu{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{PolicyAnnotation: "repair"}}}
+	defaulted := &v2.Dogu{}
+
+	sut := PolicyAnnotationChangedPredicate()
+
+	assert.True(t, sut.Update(event.UpdateEvent{ObjectOld: reporting, ObjectNew: repairing}))
+	assert.True(t, sut.Update(event.UpdateEvent{ObjectOld: defaulted, ObjectNew: repairing}))
+	assert.False(t, sut.Update(event.UpdateEvent{ObjectOld: reporting, ObjectNew: defaulted}))
+	assert.False(t, sut.Update(event.UpdateEvent{ObjectOld: reporting, ObjectNew: reporting}))
+	assert.False(t, sut.Create(event.CreateEvent{Object: reporting}))
+	assert.False(t, sut.Delete(event.DeleteEvent{Object: reporting}))
+	assert.False(t, sut.Generic(event.GenericEvent{Object: reporting}))
+}
+
+func TestPolicyOf(t *testing.T) {
+	assert.Equal(t, PolicyReport, PolicyOf(&v2.Dogu{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{PolicyAnnotation: "report"}}}))
+	assert.Equal(t, PolicyRepair, PolicyOf(&v2.Dogu{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{PolicyAnnotation: "repair"}}}))
+	assert.Equal(t, PolicyReport, PolicyOf(&v2.Dogu{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{PolicyAnnotation: "unknown"}}}))
+	assert.Equal(t, PolicyReport, PolicyOf(&v2.Dogu{}))
+}
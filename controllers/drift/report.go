@@ -0,0 +1,84 @@
+package drift
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	KindDeployment            = "Deployment"
+	KindService               = "Service"
+	KindNetworkPolicy         = "NetworkPolicy"
+	KindPersistentVolumeClaim = "PersistentVolumeClaim"
+)
+
+// ResourceDrift describes how a resource in the cluster differs from its desired state.
+type ResourceDrift struct {
+	Kind string
+	Name string
+	// Missing is true if the resource was deleted from the cluster.
+	Missing bool
+	// Fields contains the paths of the drifted fields, e.g. spec.template.spec.containers[0].image.
+	Fields []string
+}
+
+func (rd ResourceDrift) String() string {
+	if rd.Missing {
+		return fmt.Sprintf("%s %s: missing", rd.Kind, rd.Name)
+	}
+	return fmt.Sprintf("%s %s: %s", rd.Kind, rd.Name, strings.Join(rd.Fields, ", "))
+}
+
+// Revertible returns true if the dogu operator can revert the drift. Drifted fields of an existing volume claim cannot
+// be reverted, because the dogu operator never applies existing volume claims again to keep their data.
+func (rd ResourceDrift) Revertible() bool {
+	return rd.Kind != KindPersistentVolumeClaim || rd.Missing
+}
+
+// Report contains the drifted resources of a dogu.
+type Report struct {
+	Drifts []ResourceDrift
+}
+
+// HasDrift returns true if at least one resource of the dogu drifted.
+func (r *Report) HasDrift() bool {
+	return len(r.Drifts) > 0
+}
+
+// Contains returns true if a resource of the given kind drifted.
+func (r *Report) Contains(kind string) bool {
+	for _, drift := range r.Drifts {
+		if drift.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Split splits the report into the drift which can be reverted and the drift which cannot be reverted.
+func (r *Report) Split() (revertible *Report, notRevertible *Report) {
+	revertible, notRevertible = &Report{}, &Report{}
+	for _, drift := range r.Drifts {
+		if drift.Revertible() {
+			revertible.Drifts = append(revertible.Drifts, drift)
+		} else {
+			notRevertible.Drifts = append(notRevertible.Drifts, drift)
+		}
+	}
+	return revertible, notRevertible
+}
+
+// Message describes the drift in the Drifted condition. The kinds of the drifted resources are read from it again,
+// see IsReportedOnly.
+func (r *Report) Message() string {
+	return driftedMessagePrefix + r.Summary()
+}
+
+// Summary lists the drifted fields of all drifted resources.
+func (r *Report) Summary() string {
+	summaries := make([]string, len(r.Drifts))
+	for i, drift := range r.Drifts {
+		summaries[i] = drift.String()
+	}
+	return strings.Join(summaries, "; ")
+}
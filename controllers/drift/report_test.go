@@ -0,0 +1,46 @@
+package drift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReport(t *testing.T) {
+	t.Run("should summarize drifted resources", func(t *testing.T) {
+		// given
+		sut := &Report{Drifts: []ResourceDrift{
+			{Kind: KindDeployment, Name: "ldap", Fields: []string{"metadata.labels.app", "spec.template.spec.containers[0].image"}},
+			{Kind: KindNetworkPolicy, Name: "ldap-deny-all", Missing: true},
+		}}
+
+		// then
+		assert.True(t, sut.HasDrift())
+		assert.True(t, sut.Contains(KindNetworkPolicy))
+		assert.False(t, sut.Contains(KindService))
+		assert.Equal(t, "Deployment ldap: metadata.labels.app, spec.template.spec.containers[0].image; NetworkPolicy ldap-deny-all: missing", sut.Summary())
+		assert.Equal(t, "Resources drifted from their desired state: "+sut.Summary(), sut.Message())
+	})
+	t.Run("should have no drift without drifted resources", func(t *testing.T) {
+		// given
+		sut := &Report{}
+
+		// then
+		assert.False(t, sut.HasDrift())
+		assert.Empty(t, sut.Summary())
+	})
+	t.Run("should split revertible from not revertible drift", func(t *testing.T) {
+		// given
+		deploymentDrift := ResourceDrift{Kind: KindDeployment, Name: "ldap", Fields: []string{"spec.template.spec.containers[0].image"}}
+		missingPVCDrift := ResourceDrift{Kind: KindPersistentVolumeClaim, Name: "ldap", Missing: true}
+		changedPVCDrift := ResourceDrift{Kind: KindPersistentVolumeClaim, Name: "ldap", Fields: []string{"metadata.labels.app"}}
+		sut := &Report{Drifts: []ResourceDrift{deploymentDrift, missingPVCDrift, changedPVCDrift}}
+
+		// when
+		revertible, notRevertible := sut.Split()
+
+		// then
+		assert.Equal(t, []ResourceDrift{deploymentDrift, missingPVCDrift}, revertible.Drifts)
+		assert.Equal(t, []ResourceDrift{changedPVCDrift}, notRevertible.Drifts)
+	})
+}
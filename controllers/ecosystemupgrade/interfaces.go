@@ -0,0 +1,29 @@
+package ecosystemupgrade
+
+import (
+	"context"
+
+	cescommons "github.com/cloudogu/ces-commons-lib/dogu"
+	v1 "github.com/cloudogu/k8s-dogu-operator/v3/api/v1"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/cesregistry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Planner creates the plan of an ecosystem upgrade.
+type Planner interface {
+	// Plan determines the target versions of the dogus, validates the resulting ecosystem and returns the dogus in the
+	// order in which they have to be upgraded. An *InvalidPlanError is returned if the plan cannot be executed.
+	Plan(ctx context.Context, ecosystemUpgrade *v1.EcosystemUpgrade) ([]v1.DoguUpgradeStatus, error)
+}
+
+type k8sClient interface {
+	client.Client
+}
+
+type remoteDoguDescriptorRepository interface {
+	cescommons.RemoteDoguDescriptorRepository
+}
+
+type localDoguFetcher interface {
+	cesregistry.LocalDoguFetcher
+}
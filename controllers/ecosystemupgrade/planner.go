@@ -0,0 +1,271 @@
+package ecosystemupgrade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	cescommons "github.com/cloudogu/ces-commons-lib/dogu"
+	regLibErr "github.com/cloudogu/ces-commons-lib/errors"
+	"github.com/cloudogu/cesapp-lib/core"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	v1 "github.com/cloudogu/k8s-dogu-operator/v3/api/v1"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/cesregistry"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/dependency"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// InvalidPlanError is returned if an ecosystem upgrade cannot be executed, e.g. because a dogu would be downgraded or
+// the target versions do not meet the version requirements of the dogus.
+type InvalidPlanError struct {
+	violations error
+}
+
+// Error returns the error in string representation
+func (e *InvalidPlanError) Error() string {
+	return fmt.Sprintf("invalid ecosystem upgrade: %s", e.violations)
+}
+
+// Unwrap returns the violations of the plan.
+func (e *InvalidPlanError) Unwrap() error {
+	return e.violations
+}
+
+type planner struct {
+	client  k8sClient
+	remote  remoteDoguDescriptorRepository
+	fetcher localDoguFetcher
+}
+
+// NewPlanner creates a planner which fetches the descriptors of the target versions from the remote dogu registry.
+func NewPlanner(client client.Client, remote cescommons.RemoteDoguDescriptorRepository, fetcher cesregistry.LocalDoguFetcher) Planner {
+	return &planner{client: client, remote: remote, fetcher: fetcher}
+}
+
+// Plan determines the target versions of the dogus, validates the resulting ecosystem and returns the dogus in the
+// order in which they have to be upgraded. Dogus which are not upgraded are appended as skipped.
+func (p *planner) Plan(ctx context.Context, ecosystemUpgrade *v1.EcosystemUpgrade) ([]v1.DoguUpgradeStatus, error) {
+	if (len(ecosystemUpgrade.Spec.Dogus) > 0) == ecosystemUpgrade.Spec.AllToLatestPatch {
+		return nil, &InvalidPlanError{violations: errors.New("either dogus or allToLatestPatch must be set")}
+	}
+
+	doguList := &v2.DoguList{}
+	err := p.client.List(ctx, doguList, client.InNamespace(ecosystemUpgrade.Namespace))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dogu resources: %w", err)
+	}
+	doguResources := make(map[string]*v2.Dogu, len(doguList.Items))
+	for i := range doguList.Items {
+		doguResources[doguList.Items[i].Name] = &doguList.Items[i]
+	}
+
+	installedDogus, err := p.fetcher.FetchAllInstalled(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch installed dogus: %w", err)
+	}
+	installedByName := make(map[string]*core.Dogu, len(installedDogus))
+	for _, installedDogu := range installedDogus {
+		installedByName[installedDogu.GetSimpleName()] = installedDogu
+	}
+
+	var targets []*core.Dogu
+	var skipped []v1.DoguUpgradeStatus
+	var violations error
+	if ecosystemUpgrade.Spec.AllToLatestPatch {
+		targets, skipped, violations, err = p.latestPatches(ctx, installedDogus, doguResources)
+	} else {
+		targets, skipped, violations, err = p.requestedVersions(ctx, ecosystemUpgrade.Spec.Dogus, installedByName, doguResources)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	violations = errors.Join(violations, validateVersionRequirements(installedDogus, targets))
+
+	sorted, err := core.SortDogusByDependencyWithError(targets)
+	if err != nil {
+		violations = errors.Join(violations, err)
+	}
+
+	if violations != nil {
+		return nil, &InvalidPlanError{violations: violations}
+	}
+
+	plan := make([]v1.DoguUpgradeStatus, 0, len(sorted)+len(skipped))
+	for _, target := range sorted {
+		plan = append(plan, v1.DoguUpgradeStatus{
+			Name:        target.GetSimpleName(),
+			FromVersion: installedByName[target.GetSimpleName()].Version,
+			Version:     target.Version,
+			Phase:       v1.DoguUpgradePhasePending,
+		})
+	}
+
+	return append(plan, skipped...), nil
+}
+
+func (p *planner) requestedVersions(
+	ctx context.Context,
+	requested []v1.DoguVersion,
+	installedByName map[string]*core.Dogu,
+	doguResources map[string]*v2.Dogu,
+) (targets []*core.Dogu, skipped []v1.DoguUpgradeStatus, violations error, err error) {
+	seen := make(map[string]bool, len(requested))
+	for _, doguVersion := range requested {
+		if seen[doguVersion.Name] {
+			violations = errors.Join(violations, fmt.Errorf("dogu %q is listed more than once", doguVersion.Name))
+			continue
+		}
+		seen[doguVersion.Name] = true
+
+		installedDogu, installed := installedByName[doguVersion.Name]
+		doguResource, found := doguResources[doguVersion.Name]
+		if !installed || !found {
+			violations = errors.Join(violations, fmt.Errorf("dogu %q is not installed", doguVersion.Name))
+			continue
+		}
+
+		fromVersion, err := core.ParseVersion(installedDogu.Version)
+		if err != nil {
+			violations = errors.Join(violations, fmt.Errorf("failed to parse installed version of dogu %q: %w", doguVersion.Name, err))
+			continue
+		}
+		toVersion, err := core.ParseVersion(doguVersion.Version)
+		if err != nil {
+			violations = errors.Join(violations, fmt.Errorf("failed to parse target version of dogu %q: %w", doguVersion.Name, err))
+			continue
+		}
+
+		if toVersion.IsOlderThan(fromVersion) {
+			violations = errors.Join(violations, fmt.Errorf("downgrade of dogu %q from %s to %s is not supported", doguVersion.Name, installedDogu.Version, doguVersion.Version))
+			continue
+		}
+		if toVersion.IsEqualTo(fromVersion) {
+			skipped = append(skipped, skippedDogu(installedDogu, "the version is already installed"))
+			continue
+		}
+
+		qualifiedName, err := cescommons.QualifiedNameFromString(doguResource.Spec.Name)
+		if err != nil {
+			violations = errors.Join(violations, fmt.Errorf("invalid name of dogu resource %q: %w", doguVersion.Name, err))
+			continue
+		}
+
+		target, err := p.remote.Get(ctx, cescommons.QualifiedVersion{Name: qualifiedName, Version: toVersion})
+		if err != nil {
+			if regLibErr.IsNotFoundError(err) {
+				violations = errors.Join(violations, fmt.Errorf("version %s of dogu %q does not exist in the remote dogu registry", doguVersion.Version, doguVersion.Name))
+				continue
+			}
+			return nil, nil, nil, fmt.Errorf("failed to get descriptor of dogu %q in version %s: %w", doguVersion.Name, doguVersion.Version, err)
+		}
+		targets = append(targets, target)
+	}
+
+	return targets, skipped, violations, nil
+}
+
+func (p *planner) latestPatches(
+	ctx context.Context,
+	installedDogus []*core.Dogu,
+	doguResources map[string]*v2.Dogu,
+) (targets []*core.Dogu, skipped []v1.DoguUpgradeStatus, violations error, err error) {
+	for _, installedDogu := range installedDogus {
+		doguResource, found := doguResources[installedDogu.GetSimpleName()]
+		if !found {
+			continue
+		}
+
+		qualifiedName, err := cescommons.QualifiedNameFromString(doguResource.Spec.Name)
+		if err != nil {
+			violations = errors.Join(violations, fmt.Errorf("invalid name of dogu resource %q: %w", doguResource.Name, err))
+			continue
+		}
+
+		latest, err := p.remote.GetLatest(ctx, qualifiedName)
+		if err != nil {
+			if regLibErr.IsNotFoundError(err) {
+				skipped = append(skipped, skippedDogu(installedDogu, "the dogu does not exist in the remote dogu registry"))
+				continue
+			}
+			return nil, nil, nil, fmt.Errorf("failed to get latest descriptor of dogu %q: %w", installedDogu.GetSimpleName(), err)
+		}
+
+		fromVersion, err := core.ParseVersion(installedDogu.Version)
+		if err != nil {
+			violations = errors.Join(violations, fmt.Errorf("failed to parse installed version of dogu %q: %w", installedDogu.GetSimpleName(), err))
+			continue
+		}
+		toVersion, err := core.ParseVersion(latest.Version)
+		if err != nil {
+			violations = errors.Join(violations, fmt.Errorf("failed to parse latest version of dogu %q: %w", installedDogu.GetSimpleName(), err))
+			continue
+		}
+
+		if !toVersion.IsNewerThan(fromVersion) {
+			skipped = append(skipped, skippedDogu(installedDogu, "the latest version is already installed"))
+			continue
+		}
+		if toVersion.Major != fromVersion.Major || toVersion.Minor != fromVersion.Minor {
+			skipped = append(skipped, skippedDogu(installedDogu, fmt.Sprintf("the latest version %s is no patch release of the installed version", latest.Version)))
+			continue
+		}
+		targets = append(targets, latest)
+	}
+
+	return targets, skipped, violations, nil
+}
+
+// validateVersionRequirements checks the version requirements of the mandatory and optional dogu dependencies in the
+// ecosystem after the upgrade. Only requirements from or to an upgraded dogu are checked, so that existing violations
+// do not block the upgrade. Missing dependencies are reported by the dependency validation of the upgrade of the dogu.
+func validateVersionRequirements(installedDogus []*core.Dogu, targets []*core.Dogu) error {
+	ecosystem := make(map[string]*core.Dogu, len(installedDogus))
+	for _, installedDogu := range installedDogus {
+		ecosystem[installedDogu.GetSimpleName()] = installedDogu
+	}
+	upgraded := make(map[string]bool, len(targets))
+	for _, target := range targets {
+		ecosystem[target.GetSimpleName()] = target
+		upgraded[target.GetSimpleName()] = true
+	}
+
+	var violations error
+	for _, installedDogu := range installedDogus {
+		dogu := ecosystem[installedDogu.GetSimpleName()]
+		for _, doguDependency := range dogu.GetAllDependenciesOfType(core.DependencyTypeDogu) {
+			required, ok := ecosystem[doguDependency.Name]
+			if !ok || doguDependency.Version == "" || (!upgraded[dogu.GetSimpleName()] && !upgraded[doguDependency.Name]) {
+				continue
+			}
+
+			version, err := core.ParseVersion(required.Version)
+			if err != nil {
+				violations = errors.Join(violations, fmt.Errorf("failed to parse version of dogu %q: %w", doguDependency.Name, err))
+				continue
+			}
+
+			allowed, err := dependency.AllowsVersion(doguDependency, version)
+			if err != nil {
+				violations = errors.Join(violations, fmt.Errorf("failed to check requirement of dogu %q: %w", dogu.GetSimpleName(), err))
+				continue
+			}
+			if !allowed {
+				violations = errors.Join(violations, fmt.Errorf("dogu %q %s requires version %q of dogu %q which does not allow version %s",
+					dogu.GetSimpleName(), dogu.Version, doguDependency.Version, doguDependency.Name, required.Version))
+			}
+		}
+	}
+
+	return violations
+}
+
+func skippedDogu(installedDogu *core.Dogu, message string) v1.DoguUpgradeStatus {
+	return v1.DoguUpgradeStatus{
+		Name:        installedDogu.GetSimpleName(),
+		FromVersion: installedDogu.Version,
+		Version:     installedDogu.Version,
+		Phase:       v1.DoguUpgradePhaseSkipped,
+		Message:     message,
+	}
+}
@@ -0,0 +1,277 @@
+package ecosystemupgrade
+
+import (
+	"context"
+	"testing"
+
+	cescommons "github.com/cloudogu/ces-commons-lib/dogu"
+	regLibErr "github.com/cloudogu/ces-commons-lib/errors"
+	"github.com/cloudogu/cesapp-lib/core"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	v1 "github.com/cloudogu/k8s-dogu-operator/v3/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var testCtx = context.Background()
+
+const testNamespace = "ecosystem"
+
+func newDoguResource(qualifiedName, version string) *v2.Dogu {
+	simpleName, _ := cescommons.QualifiedNameFromString(qualifiedName)
+	return &v2.Dogu{
+		ObjectMeta: metav1.ObjectMeta{Name: string(simpleName.SimpleName), Namespace: testNamespace},
+		Spec:       v2.DoguSpec{Name: qualifiedName, Version: version},
+	}
+}
+
+func newFakeClient(t *testing.T, objects ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v2.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
+
+func qualifiedVersion(t *testing.T, qualifiedName, version string) cescommons.QualifiedVersion {
+	name, err := cescommons.QualifiedNameFromString(qualifiedName)
+	require.NoError(t, err)
+	parsedVersion, err := core.ParseVersion(version)
+	require.NoError(t, err)
+	return cescommons.QualifiedVersion{Name: name, Version: parsedVersion}
+}
+
+func newEcosystemUpgrade(spec v1.EcosystemUpgradeSpec) *v1.EcosystemUpgrade {
+	return &v1.EcosystemUpgrade{
+		ObjectMeta: metav1.ObjectMeta{Name: "upgrade", Namespace: testNamespace},
+		Spec:       spec,
+	}
+}
+
+func TestNewPlanner(t *testing.T) {
+	// when
+	sut := NewPlanner(newMockK8sClient(t), newMockRemoteDoguDescriptorRepository(t), newMockLocalDoguFetcher(t))
+
+	// then
+	assert.NotNil(t, sut)
+}
+
+func Test_planner_Plan(t *testing.T) {
+	installedPostgresql := &core.Dogu{Name: "official/postgresql", Version: "14.15.0-1"}
+	installedRedmine := &core.Dogu{
+		Name:         "official/redmine",
+		Version:      "5.1.3-1",
+		Dependencies: []core.Dependency{{Type: core.DependencyTypeDogu, Name: "postgresql", Version: ">=14.0.0-1"}},
+	}
+	installedScm := &core.Dogu{Name: "official/scm", Version: "3.7.1-1"}
+	postgresql := &core.Dogu{Name: "official/postgresql", Version: "14.15.0-2"}
+	redmine := &core.Dogu{
+		Name:         "official/redmine",
+		Version:      "5.1.3-2",
+		Dependencies: []core.Dependency{{Type: core.DependencyTypeDogu, Name: "postgresql", Version: ">=14.15.0-2"}},
+	}
+	installed := []*core.Dogu{installedPostgresql, installedRedmine, installedScm}
+	doguResources := []client.Object{
+		newDoguResource("official/postgresql", "14.15.0-1"),
+		newDoguResource("official/redmine", "5.1.3-1"),
+		newDoguResource("official/scm", "3.7.1-1"),
+	}
+
+	t.Run("should order requested dogus by their dependencies", func(t *testing.T) {
+		// given
+		remote := newMockRemoteDoguDescriptorRepository(t)
+		remote.EXPECT().Get(testCtx, qualifiedVersion(t, "official/postgresql", "14.15.0-2")).Return(postgresql, nil)
+		remote.EXPECT().Get(testCtx, qualifiedVersion(t, "official/redmine", "5.1.3-2")).Return(redmine, nil)
+		fetcher := newMockLocalDoguFetcher(t)
+		fetcher.EXPECT().FetchAllInstalled(testCtx).Return(installed, nil)
+		sut := NewPlanner(newFakeClient(t, doguResources...), remote, fetcher)
+
+		// when
+		plan, err := sut.Plan(testCtx, newEcosystemUpgrade(v1.EcosystemUpgradeSpec{Dogus: []v1.DoguVersion{
+			{Name: "redmine", Version: "5.1.3-2"},
+			{Name: "scm", Version: "3.7.1-1"},
+			{Name: "postgresql", Version: "14.15.0-2"},
+		}}))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []v1.DoguUpgradeStatus{
+			{Name: "postgresql", FromVersion: "14.15.0-1", Version: "14.15.0-2", Phase: v1.DoguUpgradePhasePending},
+			{Name: "redmine", FromVersion: "5.1.3-1", Version: "5.1.3-2", Phase: v1.DoguUpgradePhasePending},
+			{Name: "scm", FromVersion: "3.7.1-1", Version: "3.7.1-1", Phase: v1.DoguUpgradePhaseSkipped, Message: "the version is already installed"},
+		}, plan)
+	})
+	t.Run("should report a dependency cycle of the requested dogus", func(t *testing.T) {
+		// given
+		cyclicPostgresql := &core.Dogu{
+			Name:         "official/postgresql",
+			Version:      "14.15.0-2",
+			Dependencies: []core.Dependency{{Type: core.DependencyTypeDogu, Name: "redmine"}},
+		}
+		remote := newMockRemoteDoguDescriptorRepository(t)
+		remote.EXPECT().Get(testCtx, qualifiedVersion(t, "official/postgresql", "14.15.0-2")).Return(cyclicPostgresql, nil)
+		remote.EXPECT().Get(testCtx, qualifiedVersion(t, "official/redmine", "5.1.3-2")).Return(redmine, nil)
+		fetcher := newMockLocalDoguFetcher(t)
+		fetcher.EXPECT().FetchAllInstalled(testCtx).Return(installed, nil)
+		sut := NewPlanner(newFakeClient(t, doguResources...), remote, fetcher)
+
+		// when
+		_, err := sut.Plan(testCtx, newEcosystemUpgrade(v1.EcosystemUpgradeSpec{Dogus: []v1.DoguVersion{
+			{Name: "redmine", Version: "5.1.3-2"},
+			{Name: "postgresql", Version: "14.15.0-2"},
+		}}))
+
+		// then
+		require.Error(t, err)
+		var invalidPlanError *InvalidPlanError
+		assert.ErrorAs(t, err, &invalidPlanError)
+		assert.ErrorContains(t, err, "error in sorting dogus by dependency")
+	})
+	t.Run("should report all violations of the requested dogus", func(t *testing.T) {
+		// given
+		remote := newMockRemoteDoguDescriptorRepository(t)
+		remote.EXPECT().Get(testCtx, qualifiedVersion(t, "official/redmine", "5.1.3-2")).Return(redmine, nil)
+		remote.EXPECT().Get(testCtx, qualifiedVersion(t, "official/scm", "3.8.0-1")).Return(nil, regLibErr.NewNotFoundError(assert.AnError))
+		fetcher := newMockLocalDoguFetcher(t)
+		fetcher.EXPECT().FetchAllInstalled(testCtx).Return(installed, nil)
+		sut := NewPlanner(newFakeClient(t, doguResources...), remote, fetcher)
+
+		// when
+		_, err := sut.Plan(testCtx, newEcosystemUpgrade(v1.EcosystemUpgradeSpec{Dogus: []v1.DoguVersion{
+			{Name: "redmine", Version: "5.1.3-2"},
+			{Name: "redmine", Version: "5.1.3-3"},
+			{Name: "postgresql", Version: "12.18.1-1"},
+			{Name: "scm", Version: "3.8.0-1"},
+			{Name: "jenkins", Version: "2.479.3-1"},
+		}}))
+
+		// then
+		require.Error(t, err)
+		var invalidPlanError *InvalidPlanError
+		assert.ErrorAs(t, err, &invalidPlanError)
+		assert.ErrorContains(t, err, `dogu "redmine" is listed more than once`)
+		assert.ErrorContains(t, err, `downgrade of dogu "postgresql" from 14.15.0-1 to 12.18.1-1 is not supported`)
+		assert.ErrorContains(t, err, `version 3.8.0-1 of dogu "scm" does not exist in the remote dogu registry`)
+		assert.ErrorContains(t, err, `dogu "jenkins" is not installed`)
+		assert.ErrorContains(t, err, `dogu "redmine" 5.1.3-2 requires version ">=14.15.0-2" of dogu "postgresql" which does not allow version 14.15.0-1`)
+	})
+	t.Run("should report installed dogus which do not accept a target version", func(t *testing.T) {
+		// given
+		strictRedmine := &core.Dogu{
+			Name:         "official/redmine",
+			Version:      "5.1.3-1",
+			Dependencies: []core.Dependency{{Type: core.DependencyTypeDogu, Name: "postgresql", Version: "<=14.15.0-1"}},
+		}
+		remote := newMockRemoteDoguDescriptorRepository(t)
+		remote.EXPECT().Get(testCtx, qualifiedVersion(t, "official/postgresql", "14.15.0-2")).Return(postgresql, nil)
+		fetcher := newMockLocalDoguFetcher(t)
+		fetcher.EXPECT().FetchAllInstalled(testCtx).Return([]*core.Dogu{installedPostgresql, strictRedmine}, nil)
+		sut := NewPlanner(newFakeClient(t, doguResources...), remote, fetcher)
+
+		// when
+		_, err := sut.Plan(testCtx, newEcosystemUpgrade(v1.EcosystemUpgradeSpec{Dogus: []v1.DoguVersion{{Name: "postgresql", Version: "14.15.0-2"}}}))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, `dogu "redmine" 5.1.3-1 requires version "<=14.15.0-1" of dogu "postgresql" which does not allow version 14.15.0-2`)
+	})
+	t.Run("should plan latest patch releases", func(t *testing.T) {
+		// given
+		remote := newMockRemoteDoguDescriptorRepository(t)
+		remote.EXPECT().GetLatest(testCtx, qualifiedVersion(t, "official/postgresql", "1.0.0").Name).Return(postgresql, nil)
+		remote.EXPECT().GetLatest(testCtx, qualifiedVersion(t, "official/redmine", "1.0.0").Name).Return(&core.Dogu{Name: "official/redmine", Version: "6.0.0-1"}, nil)
+		remote.EXPECT().GetLatest(testCtx, qualifiedVersion(t, "official/scm", "1.0.0").Name).Return(installedScm, nil)
+		fetcher := newMockLocalDoguFetcher(t)
+		fetcher.EXPECT().FetchAllInstalled(testCtx).Return(append(installed, &core.Dogu{Name: "official/jenkins", Version: "2.479.3-1"}), nil)
+		sut := NewPlanner(newFakeClient(t, doguResources...), remote, fetcher)
+
+		// when
+		plan, err := sut.Plan(testCtx, newEcosystemUpgrade(v1.EcosystemUpgradeSpec{AllToLatestPatch: true}))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []v1.DoguUpgradeStatus{
+			{Name: "postgresql", FromVersion: "14.15.0-1", Version: "14.15.0-2", Phase: v1.DoguUpgradePhasePending},
+			{Name: "redmine", FromVersion: "5.1.3-1", Version: "5.1.3-1", Phase: v1.DoguUpgradePhaseSkipped, Message: "the latest version 6.0.0-1 is no patch release of the installed version"},
+			{Name: "scm", FromVersion: "3.7.1-1", Version: "3.7.1-1", Phase: v1.DoguUpgradePhaseSkipped, Message: "the latest version is already installed"},
+		}, plan)
+	})
+	t.Run("should skip dogus which do not exist in the remote dogu registry", func(t *testing.T) {
+		// given
+		remote := newMockRemoteDoguDescriptorRepository(t)
+		remote.EXPECT().GetLatest(testCtx, mock.Anything).Return(nil, regLibErr.NewNotFoundError(assert.AnError))
+		fetcher := newMockLocalDoguFetcher(t)
+		fetcher.EXPECT().FetchAllInstalled(testCtx).Return([]*core.Dogu{installedScm}, nil)
+		sut := NewPlanner(newFakeClient(t, doguResources...), remote, fetcher)
+
+		// when
+		plan, err := sut.Plan(testCtx, newEcosystemUpgrade(v1.EcosystemUpgradeSpec{AllToLatestPatch: true}))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []v1.DoguUpgradeStatus{
+			{Name: "scm", FromVersion: "3.7.1-1", Version: "3.7.1-1", Phase: v1.DoguUpgradePhaseSkipped, Message: "the dogu does not exist in the remote dogu registry"},
+		}, plan)
+	})
+	t.Run("should fail to get latest descriptor", func(t *testing.T) {
+		// given
+		remote := newMockRemoteDoguDescriptorRepository(t)
+		remote.EXPECT().GetLatest(testCtx, mock.Anything).Return(nil, assert.AnError)
+		fetcher := newMockLocalDoguFetcher(t)
+		fetcher.EXPECT().FetchAllInstalled(testCtx).Return([]*core.Dogu{installedScm}, nil)
+		sut := NewPlanner(newFakeClient(t, doguResources...), remote, fetcher)
+
+		// when
+		_, err := sut.Plan(testCtx, newEcosystemUpgrade(v1.EcosystemUpgradeSpec{AllToLatestPatch: true}))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		var invalidPlanError *InvalidPlanError
+		assert.NotErrorAs(t, err, &invalidPlanError)
+		assert.ErrorContains(t, err, `failed to get latest descriptor of dogu "scm"`)
+	})
+	t.Run("should fail if neither dogus nor allToLatestPatch are set", func(t *testing.T) {
+		// given
+		sut := NewPlanner(newMockK8sClient(t), newMockRemoteDoguDescriptorRepository(t), newMockLocalDoguFetcher(t))
+
+		// when
+		_, err := sut.Plan(testCtx, newEcosystemUpgrade(v1.EcosystemUpgradeSpec{}))
+
+		// then
+		var invalidPlanError *InvalidPlanError
+		require.ErrorAs(t, err, &invalidPlanError)
+		assert.ErrorContains(t, err, "either dogus or allToLatestPatch must be set")
+	})
+	t.Run("should fail to list dogu resources", func(t *testing.T) {
+		// given
+		k8sClient := newMockK8sClient(t)
+		k8sClient.EXPECT().List(testCtx, mock.Anything, client.InNamespace(testNamespace)).Return(errors.NewServiceUnavailable("unavailable"))
+		sut := NewPlanner(k8sClient, newMockRemoteDoguDescriptorRepository(t), newMockLocalDoguFetcher(t))
+
+		// when
+		_, err := sut.Plan(testCtx, newEcosystemUpgrade(v1.EcosystemUpgradeSpec{AllToLatestPatch: true}))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to list dogu resources")
+	})
+	t.Run("should fail to fetch installed dogus", func(t *testing.T) {
+		// given
+		fetcher := newMockLocalDoguFetcher(t)
+		fetcher.EXPECT().FetchAllInstalled(testCtx).Return(nil, assert.AnError)
+		sut := NewPlanner(newFakeClient(t, doguResources...), newMockRemoteDoguDescriptorRepository(t), fetcher)
+
+		// when
+		_, err := sut.Plan(testCtx, newEcosystemUpgrade(v1.EcosystemUpgradeSpec{AllToLatestPatch: true}))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to fetch installed dogus")
+	})
+}
@@ -0,0 +1,292 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	v1 "github.com/cloudogu/k8s-dogu-operator/v3/api/v1"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/ecosystemupgrade"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/health"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/namespaced"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/upgrade"
+	coreV1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	DoguUpgradeStartedEventReason        = "DoguUpgradeStarted"
+	DoguUpgradeSucceededEventReason      = "DoguUpgradeSucceeded"
+	EcosystemUpgradeFailedEventReason    = "EcosystemUpgradeFailed"
+	EcosystemUpgradeSucceededEventReason = "EcosystemUpgradeSucceeded"
+)
+
+// ecosystemUpgradePollInterval is the interval in which running dogu upgrades are checked in addition to the changes
+// of the dogu resources.
+const ecosystemUpgradePollInterval = 30 * time.Second
+
+// EcosystemUpgradeReconciler upgrades the dogus of an EcosystemUpgrade one after another and waits for every dogu to
+// become healthy before the next dogu is upgraded.
+type EcosystemUpgradeReconciler struct {
+	client   K8sClient
+	planner  ecosystemUpgradePlanner
+	recorder eventRecorder
+	now      func() time.Time
+}
+
+func NewEcosystemUpgradeReconciler(
+	client client.Client,
+	planner ecosystemupgrade.Planner,
+	recorder record.EventRecorder,
+	manager manager.Manager,
+) (*EcosystemUpgradeReconciler, error) {
+	r := &EcosystemUpgradeReconciler{
+		client:   client,
+		planner:  planner,
+		recorder: recorder,
+		now:      time.Now,
+	}
+	err := r.setupWithManager(manager)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// +kubebuilder:rbac:groups=k8s.cloudogu.com,resources=ecosystemupgrades,verbs=get;list;watch
+// +kubebuilder:rbac:groups=k8s.cloudogu.com,resources=ecosystemupgrades/status,verbs=update
+
+// Reconcile plans the ecosystem upgrade once and then advances the upgrade of the first unfinished dogu of the plan.
+// The ecosystem upgrade stops on the first failed dogu upgrade. It fails right away if another ecosystem upgrade is
+// already running in the namespace.
+func (r *EcosystemUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx = namespaced.WithNamespace(ctx, req.Namespace)
+
+	ecosystemUpgrade := &v1.EcosystemUpgrade{}
+	err := r.client.Get(ctx, req.NamespacedName, ecosystemUpgrade)
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	switch ecosystemUpgrade.Status.Phase {
+	case v1.EcosystemUpgradePhaseSucceeded, v1.EcosystemUpgradePhaseFailed:
+		return ctrl.Result{}, nil
+	case "":
+		running, err := r.runningEcosystemUpgrade(ctx, ecosystemUpgrade)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if running != "" {
+			ecosystemUpgrade.Status.ObservedGeneration = ecosystemUpgrade.Generation
+			return ctrl.Result{}, r.fail(ctx, ecosystemUpgrade, fmt.Sprintf("the ecosystem upgrade %q is already running in the namespace", running))
+		}
+
+		planned, err := r.plan(ctx, ecosystemUpgrade)
+		if err != nil || !planned {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return r.upgradeNextDogu(ctx, ecosystemUpgrade)
+}
+
+// runningEcosystemUpgrade returns the name of another running ecosystem upgrade in the namespace of the given one or
+// an empty string if there is none. Two ecosystem upgrades would otherwise upgrade the same dogus concurrently.
+func (r *EcosystemUpgradeReconciler) runningEcosystemUpgrade(ctx context.Context, ecosystemUpgrade *v1.EcosystemUpgrade) (string, error) {
+	ecosystemUpgrades := &v1.EcosystemUpgradeList{}
+	err := r.client.List(ctx, ecosystemUpgrades, client.InNamespace(ecosystemUpgrade.Namespace))
+	if err != nil {
+		return "", fmt.Errorf("failed to list ecosystem upgrades in namespace %q: %w", ecosystemUpgrade.Namespace, err)
+	}
+
+	for _, other := range ecosystemUpgrades.Items {
+		if other.Name != ecosystemUpgrade.Name && other.Status.Phase == v1.EcosystemUpgradePhaseUpgrading {
+			return other.Name, nil
+		}
+	}
+
+	return "", nil
+}
+
+func (r *EcosystemUpgradeReconciler) plan(ctx context.Context, ecosystemUpgrade *v1.EcosystemUpgrade) (bool, error) {
+	plan, err := r.planner.Plan(ctx, ecosystemUpgrade)
+	var invalidPlanError *ecosystemupgrade.InvalidPlanError
+	if errors.As(err, &invalidPlanError) {
+		ecosystemUpgrade.Status.ObservedGeneration = ecosystemUpgrade.Generation
+		return false, r.fail(ctx, ecosystemUpgrade, err.Error())
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to plan ecosystem upgrade %q: %w", ecosystemUpgrade.Name, err)
+	}
+
+	ecosystemUpgrade.Status = v1.EcosystemUpgradeStatus{
+		Phase:              v1.EcosystemUpgradePhaseUpgrading,
+		Dogus:              plan,
+		ObservedGeneration: ecosystemUpgrade.Generation,
+	}
+	err = r.updateStatus(ctx, ecosystemUpgrade)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *EcosystemUpgradeReconciler) upgradeNextDogu(ctx context.Context, ecosystemUpgrade *v1.EcosystemUpgrade) (ctrl.Result, error) {
+	doguStatus := nextDogu(ecosystemUpgrade)
+	if doguStatus == nil {
+		ecosystemUpgrade.Status.Phase = v1.EcosystemUpgradePhaseSucceeded
+		ecosystemUpgrade.Status.Message = ""
+		r.recorder.Event(ecosystemUpgrade, coreV1.EventTypeNormal, EcosystemUpgradeSucceededEventReason, "All dogus have been upgraded")
+		return ctrl.Result{}, r.updateStatus(ctx, ecosystemUpgrade)
+	}
+
+	doguResource := &doguv2.Dogu{}
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: ecosystemUpgrade.Namespace, Name: doguStatus.Name}, doguResource)
+	if apierrors.IsNotFound(err) {
+		return ctrl.Result{}, r.failDogu(ctx, ecosystemUpgrade, doguStatus, "the dogu resource does not exist anymore")
+	}
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get dogu resource %q: %w", doguStatus.Name, err)
+	}
+
+	if doguStatus.Phase == v1.DoguUpgradePhasePending {
+		return r.startDoguUpgrade(ctx, ecosystemUpgrade, doguStatus, doguResource)
+	}
+
+	return r.checkDoguUpgrade(ctx, ecosystemUpgrade, doguStatus, doguResource)
+}
+
+func nextDogu(ecosystemUpgrade *v1.EcosystemUpgrade) *v1.DoguUpgradeStatus {
+	for i := range ecosystemUpgrade.Status.Dogus {
+		phase := ecosystemUpgrade.Status.Dogus[i].Phase
+		if phase == v1.DoguUpgradePhasePending || phase == v1.DoguUpgradePhaseUpgrading {
+			return &ecosystemUpgrade.Status.Dogus[i]
+		}
+	}
+
+	return nil
+}
+
+func (r *EcosystemUpgradeReconciler) startDoguUpgrade(ctx context.Context, ecosystemUpgrade *v1.EcosystemUpgrade, doguStatus *v1.DoguUpgradeStatus, doguResource *doguv2.Dogu) (ctrl.Result, error) {
+	log.FromContext(ctx).Info("Starting upgrade of dogu", "dogu", doguStatus.Name, "from", doguStatus.FromVersion, "to", doguStatus.Version)
+
+	if doguResource.Spec.Version != doguStatus.Version {
+		doguResource.Spec.Version = doguStatus.Version
+		err := r.client.Update(ctx, doguResource)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update version of dogu resource %q: %w", doguStatus.Name, err)
+		}
+	}
+
+	startedAt := metav1.NewTime(r.now())
+	doguStatus.Phase = v1.DoguUpgradePhaseUpgrading
+	doguStatus.StartedAt = &startedAt
+	r.recorder.Eventf(ecosystemUpgrade, coreV1.EventTypeNormal, DoguUpgradeStartedEventReason, "Upgrading dogu %q from %s to %s", doguStatus.Name, doguStatus.FromVersion, doguStatus.Version)
+
+	return ctrl.Result{RequeueAfter: ecosystemUpgradePollInterval}, r.updateStatus(ctx, ecosystemUpgrade)
+}
+
+func (r *EcosystemUpgradeReconciler) checkDoguUpgrade(ctx context.Context, ecosystemUpgrade *v1.EcosystemUpgrade, doguStatus *v1.DoguUpgradeStatus, doguResource *doguv2.Dogu) (ctrl.Result, error) {
+	if failure := r.doguUpgradeFailure(doguStatus, doguResource); failure != "" {
+		return ctrl.Result{}, r.failDogu(ctx, ecosystemUpgrade, doguStatus, failure)
+	}
+
+	if doguResource.Status.InstalledVersion != doguStatus.Version || !health.IsRunning(doguResource.Status.Health) {
+		return ctrl.Result{RequeueAfter: ecosystemUpgradePollInterval}, nil
+	}
+
+	finishedAt := metav1.NewTime(r.now())
+	doguStatus.Phase = v1.DoguUpgradePhaseSucceeded
+	doguStatus.FinishedAt = &finishedAt
+	r.recorder.Eventf(ecosystemUpgrade, coreV1.EventTypeNormal, DoguUpgradeSucceededEventReason, "Dogu %q has been upgraded to %s", doguStatus.Name, doguStatus.Version)
+	err := r.updateStatus(ctx, ecosystemUpgrade)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return r.upgradeNextDogu(ctx, ecosystemUpgrade)
+}
+
+// doguUpgradeFailure returns why the upgrade of the dogu failed or an empty string if it is still running or succeeded.
+func (r *EcosystemUpgradeReconciler) doguUpgradeFailure(doguStatus *v1.DoguUpgradeStatus, doguResource *doguv2.Dogu) string {
+	if doguResource.Spec.Version != doguStatus.Version {
+		return fmt.Sprintf("the version of the dogu resource was changed to %s", doguResource.Spec.Version)
+	}
+
+	for _, conditionType := range []string{upgrade.ConditionRolledBack, upgrade.ConditionUpgradeStalled, ConditionStalled} {
+		condition := meta.FindStatusCondition(doguResource.Status.Conditions, conditionType)
+		if condition != nil && condition.Status == metav1.ConditionTrue && condition.ObservedGeneration == doguResource.Generation {
+			return fmt.Sprintf("the dogu is %s: %s", conditionType, condition.Message)
+		}
+	}
+
+	return ""
+}
+
+func (r *EcosystemUpgradeReconciler) failDogu(ctx context.Context, ecosystemUpgrade *v1.EcosystemUpgrade, doguStatus *v1.DoguUpgradeStatus, message string) error {
+	finishedAt := metav1.NewTime(r.now())
+	doguStatus.Phase = v1.DoguUpgradePhaseFailed
+	doguStatus.Message = message
+	doguStatus.FinishedAt = &finishedAt
+
+	return r.fail(ctx, ecosystemUpgrade, fmt.Sprintf("upgrade of dogu %q to %s failed: %s", doguStatus.Name, doguStatus.Version, message))
+}
+
+func (r *EcosystemUpgradeReconciler) fail(ctx context.Context, ecosystemUpgrade *v1.EcosystemUpgrade, message string) error {
+	log.FromContext(ctx).Info("Ecosystem upgrade failed", "ecosystemUpgrade", ecosystemUpgrade.Name, "reason", message)
+
+	ecosystemUpgrade.Status.Phase = v1.EcosystemUpgradePhaseFailed
+	ecosystemUpgrade.Status.Message = message
+	r.recorder.Event(ecosystemUpgrade, coreV1.EventTypeWarning, EcosystemUpgradeFailedEventReason, message)
+
+	return r.updateStatus(ctx, ecosystemUpgrade)
+}
+
+func (r *EcosystemUpgradeReconciler) updateStatus(ctx context.Context, ecosystemUpgrade *v1.EcosystemUpgrade) error {
+	err := r.client.Status().Update(ctx, ecosystemUpgrade)
+	if err != nil {
+		return fmt.Errorf("failed to update status of ecosystem upgrade %q: %w", ecosystemUpgrade.Name, err)
+	}
+
+	return nil
+}
+
+// ecosystemUpgradesOfDogu enqueues the running ecosystem upgrades in the namespace of the changed dogu.
+func (r *EcosystemUpgradeReconciler) ecosystemUpgradesOfDogu(ctx context.Context, doguResource client.Object) []reconcile.Request {
+	ecosystemUpgrades := &v1.EcosystemUpgradeList{}
+	err := r.client.List(ctx, ecosystemUpgrades, client.InNamespace(doguResource.GetNamespace()))
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to list ecosystem upgrades", "namespace", doguResource.GetNamespace())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, ecosystemUpgrade := range ecosystemUpgrades.Items {
+		if ecosystemUpgrade.Status.Phase == v1.EcosystemUpgradePhaseUpgrading {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&ecosystemUpgrade)})
+		}
+	}
+
+	return requests
+}
+
+func (r *EcosystemUpgradeReconciler) setupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1.EcosystemUpgrade{}).
+		Watches(&doguv2.Dogu{}, handler.EnqueueRequestsFromMapFunc(r.ecosystemUpgradesOfDogu)).
+		Complete(r)
+}
@@ -0,0 +1,413 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	v1 "github.com/cloudogu/k8s-dogu-operator/v3/api/v1"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/ecosystemupgrade"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/health"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/namespaced"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/upgrade"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/config"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var ecosystemUpgradeRequest = ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ecosystem", Name: "upgrade"}}
+
+func newTestEcosystemUpgrade(status v1.EcosystemUpgradeStatus) *v1.EcosystemUpgrade {
+	return &v1.EcosystemUpgrade{
+		ObjectMeta: metav1.ObjectMeta{Name: "upgrade", Namespace: "ecosystem"},
+		Spec:       v1.EcosystemUpgradeSpec{AllToLatestPatch: true},
+		Status:     status,
+	}
+}
+
+func newTestUpgradeDogu(name, specVersion, installedVersion string, health doguv2.HealthStatus) *doguv2.Dogu {
+	return &doguv2.Dogu{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ecosystem"},
+		Spec:       doguv2.DoguSpec{Name: "official/" + name, Version: specVersion},
+		Status:     doguv2.DoguStatus{InstalledVersion: installedVersion, Health: health},
+	}
+}
+
+func newEcosystemUpgradeReconciler(t *testing.T, planner ecosystemUpgradePlanner, recorder eventRecorder, now time.Time, objects ...client.Object) (*EcosystemUpgradeReconciler, client.Client) {
+	scheme := getTestScheme()
+	require.NoError(t, v1.AddToScheme(scheme))
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithStatusSubresource(&v1.EcosystemUpgrade{}, &doguv2.Dogu{}).
+		Build()
+
+	return &EcosystemUpgradeReconciler{
+		client:   k8sClient,
+		planner:  planner,
+		recorder: recorder,
+		now:      func() time.Time { return now },
+	}, k8sClient
+}
+
+func getEcosystemUpgrade(t *testing.T, k8sClient client.Client) *v1.EcosystemUpgrade {
+	ecosystemUpgrade := &v1.EcosystemUpgrade{}
+	require.NoError(t, k8sClient.Get(testCtx, ecosystemUpgradeRequest.NamespacedName, ecosystemUpgrade))
+	return ecosystemUpgrade
+}
+
+func getUpgradeDogu(t *testing.T, k8sClient client.Client, name string) *doguv2.Dogu {
+	doguResource := &doguv2.Dogu{}
+	require.NoError(t, k8sClient.Get(testCtx, types.NamespacedName{Namespace: "ecosystem", Name: name}, doguResource))
+	return doguResource
+}
+
+func TestNewEcosystemUpgradeReconciler(t *testing.T) {
+	t.Run("should fail to create ecosystem upgrade reconciler", func(t *testing.T) {
+		// given
+		managerMock := newMockCtrlManager(t)
+		managerMock.EXPECT().GetControllerOptions().Return(config.Controller{})
+		managerMock.EXPECT().GetScheme().Return(getTestScheme())
+
+		// when
+		r, err := NewEcosystemUpgradeReconciler(
+			NewMockK8sClient(t),
+			newMockEcosystemUpgradePlanner(t),
+			newMockEventRecorder(t),
+			managerMock,
+		)
+
+		// then
+		assert.Empty(t, r)
+		assert.Error(t, err)
+	})
+}
+
+func TestEcosystemUpgradeReconciler_Reconcile(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	startedAt := metav1.NewTime(now.Add(-10 * time.Minute))
+	plannedCtx := namespaced.WithNamespace(testCtx, "ecosystem")
+
+	t.Run("should ignore missing ecosystem upgrade", func(t *testing.T) {
+		// given
+		sut, _ := newEcosystemUpgradeReconciler(t, newMockEcosystemUpgradePlanner(t), newMockEventRecorder(t), now)
+
+		// when
+		result, err := sut.Reconcile(testCtx, ecosystemUpgradeRequest)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+	})
+	t.Run("should ignore finished ecosystem upgrade", func(t *testing.T) {
+		// given
+		sut, _ := newEcosystemUpgradeReconciler(t, newMockEcosystemUpgradePlanner(t), newMockEventRecorder(t), now,
+			newTestEcosystemUpgrade(v1.EcosystemUpgradeStatus{Phase: v1.EcosystemUpgradePhaseFailed}))
+
+		// when
+		result, err := sut.Reconcile(testCtx, ecosystemUpgradeRequest)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+	})
+	t.Run("should plan and start the upgrade of the first dogu", func(t *testing.T) {
+		// given
+		plan := []v1.DoguUpgradeStatus{
+			{Name: "postgresql", FromVersion: "14.15.0-1", Version: "14.15.0-2", Phase: v1.DoguUpgradePhasePending},
+			{Name: "redmine", FromVersion: "5.1.3-1", Version: "5.1.3-2", Phase: v1.DoguUpgradePhasePending},
+		}
+		planner := newMockEcosystemUpgradePlanner(t)
+		planner.EXPECT().Plan(plannedCtx, mock.AnythingOfType("*v1.EcosystemUpgrade")).Return(plan, nil)
+		recorder := newMockEventRecorder(t)
+		recorder.EXPECT().Eventf(mock.AnythingOfType("*v1.EcosystemUpgrade"), coreV1.EventTypeNormal, DoguUpgradeStartedEventReason,
+			"Upgrading dogu %q from %s to %s", "postgresql", "14.15.0-1", "14.15.0-2")
+		sut, k8sClient := newEcosystemUpgradeReconciler(t, planner, recorder, now,
+			newTestEcosystemUpgrade(v1.EcosystemUpgradeStatus{}),
+			newTestUpgradeDogu("postgresql", "14.15.0-1", "14.15.0-1", doguv2.AvailableHealthStatus),
+			newTestUpgradeDogu("redmine", "5.1.3-1", "5.1.3-1", doguv2.AvailableHealthStatus),
+		)
+
+		// when
+		result, err := sut.Reconcile(testCtx, ecosystemUpgradeRequest)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: ecosystemUpgradePollInterval}, result)
+		status := getEcosystemUpgrade(t, k8sClient).Status
+		assert.Equal(t, v1.EcosystemUpgradePhaseUpgrading, status.Phase)
+		require.Len(t, status.Dogus, 2)
+		assert.Equal(t, v1.DoguUpgradePhaseUpgrading, status.Dogus[0].Phase)
+		assert.Equal(t, now.Unix(), status.Dogus[0].StartedAt.Unix())
+		assert.Equal(t, v1.DoguUpgradePhasePending, status.Dogus[1].Phase)
+		assert.Equal(t, "14.15.0-2", getUpgradeDogu(t, k8sClient, "postgresql").Spec.Version)
+		assert.Equal(t, "5.1.3-1", getUpgradeDogu(t, k8sClient, "redmine").Spec.Version)
+	})
+	t.Run("should fail if another ecosystem upgrade is running in the namespace", func(t *testing.T) {
+		// given
+		running := newTestEcosystemUpgrade(v1.EcosystemUpgradeStatus{Phase: v1.EcosystemUpgradePhaseUpgrading})
+		running.Name = "running"
+		otherNamespace := newTestEcosystemUpgrade(v1.EcosystemUpgradeStatus{Phase: v1.EcosystemUpgradePhaseUpgrading})
+		otherNamespace.Name = "running"
+		otherNamespace.Namespace = "other"
+		recorder := newMockEventRecorder(t)
+		recorder.EXPECT().Event(mock.AnythingOfType("*v1.EcosystemUpgrade"), coreV1.EventTypeWarning, EcosystemUpgradeFailedEventReason, `the ecosystem upgrade "running" is already running in the namespace`)
+		sut, k8sClient := newEcosystemUpgradeReconciler(t, newMockEcosystemUpgradePlanner(t), recorder, now, newTestEcosystemUpgrade(v1.EcosystemUpgradeStatus{}), running, otherNamespace)
+
+		// when
+		result, err := sut.Reconcile(testCtx, ecosystemUpgradeRequest)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+		status := getEcosystemUpgrade(t, k8sClient).Status
+		assert.Equal(t, v1.EcosystemUpgradePhaseFailed, status.Phase)
+		assert.Empty(t, status.Dogus)
+	})
+	t.Run("should plan if other ecosystem upgrades in the namespace are finished", func(t *testing.T) {
+		// given
+		finished := newTestEcosystemUpgrade(v1.EcosystemUpgradeStatus{Phase: v1.EcosystemUpgradePhaseFailed})
+		finished.Name = "finished"
+		planner := newMockEcosystemUpgradePlanner(t)
+		planner.EXPECT().Plan(plannedCtx, mock.AnythingOfType("*v1.EcosystemUpgrade")).Return(nil, assert.AnError)
+		sut, _ := newEcosystemUpgradeReconciler(t, planner, newMockEventRecorder(t), now, newTestEcosystemUpgrade(v1.EcosystemUpgradeStatus{}), finished)
+
+		// when
+		_, err := sut.Reconcile(testCtx, ecosystemUpgradeRequest)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("should fail on invalid plan", func(t *testing.T) {
+		// given
+		planner := newMockEcosystemUpgradePlanner(t)
+		planner.EXPECT().Plan(plannedCtx, mock.AnythingOfType("*v1.EcosystemUpgrade")).Return(nil, &ecosystemupgrade.InvalidPlanError{})
+		recorder := newMockEventRecorder(t)
+		recorder.EXPECT().Event(mock.AnythingOfType("*v1.EcosystemUpgrade"), coreV1.EventTypeWarning, EcosystemUpgradeFailedEventReason, mock.AnythingOfType("string"))
+		sut, k8sClient := newEcosystemUpgradeReconciler(t, planner, recorder, now, newTestEcosystemUpgrade(v1.EcosystemUpgradeStatus{}))
+
+		// when
+		result, err := sut.Reconcile(testCtx, ecosystemUpgradeRequest)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+		status := getEcosystemUpgrade(t, k8sClient).Status
+		assert.Equal(t, v1.EcosystemUpgradePhaseFailed, status.Phase)
+		assert.Contains(t, status.Message, "invalid ecosystem upgrade")
+	})
+	t.Run("should fail to plan", func(t *testing.T) {
+		// given
+		planner := newMockEcosystemUpgradePlanner(t)
+		planner.EXPECT().Plan(plannedCtx, mock.AnythingOfType("*v1.EcosystemUpgrade")).Return(nil, assert.AnError)
+		sut, k8sClient := newEcosystemUpgradeReconciler(t, planner, newMockEventRecorder(t), now, newTestEcosystemUpgrade(v1.EcosystemUpgradeStatus{}))
+
+		// when
+		_, err := sut.Reconcile(testCtx, ecosystemUpgradeRequest)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Empty(t, getEcosystemUpgrade(t, k8sClient).Status.Phase)
+	})
+	t.Run("should wait for running dogu upgrade", func(t *testing.T) {
+		// given
+		sut, k8sClient := newEcosystemUpgradeReconciler(t, newMockEcosystemUpgradePlanner(t), newMockEventRecorder(t), now,
+			newTestEcosystemUpgrade(v1.EcosystemUpgradeStatus{Phase: v1.EcosystemUpgradePhaseUpgrading, Dogus: []v1.DoguUpgradeStatus{
+				{Name: "postgresql", FromVersion: "14.15.0-1", Version: "14.15.0-2", Phase: v1.DoguUpgradePhaseUpgrading, StartedAt: &startedAt},
+			}}),
+			newTestUpgradeDogu("postgresql", "14.15.0-2", "14.15.0-2", doguv2.UnavailableHealthStatus),
+		)
+
+		// when
+		result, err := sut.Reconcile(testCtx, ecosystemUpgradeRequest)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: ecosystemUpgradePollInterval}, result)
+		assert.Equal(t, v1.DoguUpgradePhaseUpgrading, getEcosystemUpgrade(t, k8sClient).Status.Dogus[0].Phase)
+	})
+	t.Run("should start next dogu after healthy upgrade", func(t *testing.T) {
+		// given
+		recorder := newMockEventRecorder(t)
+		recorder.EXPECT().Eventf(mock.AnythingOfType("*v1.EcosystemUpgrade"), coreV1.EventTypeNormal, DoguUpgradeSucceededEventReason,
+			"Dogu %q has been upgraded to %s", "postgresql", "14.15.0-2")
+		recorder.EXPECT().Eventf(mock.AnythingOfType("*v1.EcosystemUpgrade"), coreV1.EventTypeNormal, DoguUpgradeStartedEventReason,
+			"Upgrading dogu %q from %s to %s", "redmine", "5.1.3-1", "5.1.3-2")
+		sut, k8sClient := newEcosystemUpgradeReconciler(t, newMockEcosystemUpgradePlanner(t), recorder, now,
+			newTestEcosystemUpgrade(v1.EcosystemUpgradeStatus{Phase: v1.EcosystemUpgradePhaseUpgrading, Dogus: []v1.DoguUpgradeStatus{
+				{Name: "postgresql", FromVersion: "14.15.0-1", Version: "14.15.0-2", Phase: v1.DoguUpgradePhaseUpgrading, StartedAt: &startedAt},
+				{Name: "redmine", FromVersion: "5.1.3-1", Version: "5.1.3-2", Phase: v1.DoguUpgradePhasePending},
+			}}),
+			newTestUpgradeDogu("postgresql", "14.15.0-2", "14.15.0-2", doguv2.AvailableHealthStatus),
+			newTestUpgradeDogu("redmine", "5.1.3-1", "5.1.3-1", doguv2.AvailableHealthStatus),
+		)
+
+		// when
+		result, err := sut.Reconcile(testCtx, ecosystemUpgradeRequest)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: ecosystemUpgradePollInterval}, result)
+		status := getEcosystemUpgrade(t, k8sClient).Status
+		assert.Equal(t, v1.DoguUpgradePhaseSucceeded, status.Dogus[0].Phase)
+		assert.NotNil(t, status.Dogus[0].FinishedAt)
+		assert.Equal(t, v1.DoguUpgradePhaseUpgrading, status.Dogus[1].Phase)
+		assert.Equal(t, "5.1.3-2", getUpgradeDogu(t, k8sClient, "redmine").Spec.Version)
+	})
+	t.Run("should count degraded dogu as upgraded", func(t *testing.T) {
+		// given
+		recorder := newMockEventRecorder(t)
+		recorder.EXPECT().Eventf(mock.AnythingOfType("*v1.EcosystemUpgrade"), coreV1.EventTypeNormal, DoguUpgradeSucceededEventReason,
+			"Dogu %q has been upgraded to %s", "redmine", "5.1.3-2")
+		recorder.EXPECT().Event(mock.AnythingOfType("*v1.EcosystemUpgrade"), coreV1.EventTypeNormal, EcosystemUpgradeSucceededEventReason, "All dogus have been upgraded")
+		sut, k8sClient := newEcosystemUpgradeReconciler(t, newMockEcosystemUpgradePlanner(t), recorder, now,
+			newTestEcosystemUpgrade(v1.EcosystemUpgradeStatus{Phase: v1.EcosystemUpgradePhaseUpgrading, Dogus: []v1.DoguUpgradeStatus{
+				{Name: "redmine", FromVersion: "5.1.3-1", Version: "5.1.3-2", Phase: v1.DoguUpgradePhaseUpgrading, StartedAt: &startedAt},
+			}}),
+			newTestUpgradeDogu("redmine", "5.1.3-2", "5.1.3-2", health.DegradedHealthStatus),
+		)
+
+		// when
+		result, err := sut.Reconcile(testCtx, ecosystemUpgradeRequest)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+		assert.Equal(t, v1.DoguUpgradePhaseSucceeded, getEcosystemUpgrade(t, k8sClient).Status.Dogus[0].Phase)
+	})
+	t.Run("should succeed after the last dogu", func(t *testing.T) {
+		// given
+		recorder := newMockEventRecorder(t)
+		recorder.EXPECT().Eventf(mock.AnythingOfType("*v1.EcosystemUpgrade"), coreV1.EventTypeNormal, DoguUpgradeSucceededEventReason,
+			"Dogu %q has been upgraded to %s", "postgresql", "14.15.0-2")
+		recorder.EXPECT().Event(mock.AnythingOfType("*v1.EcosystemUpgrade"), coreV1.EventTypeNormal, EcosystemUpgradeSucceededEventReason, "All dogus have been upgraded")
+		sut, k8sClient := newEcosystemUpgradeReconciler(t, newMockEcosystemUpgradePlanner(t), recorder, now,
+			newTestEcosystemUpgrade(v1.EcosystemUpgradeStatus{Phase: v1.EcosystemUpgradePhaseUpgrading, Dogus: []v1.DoguUpgradeStatus{
+				{Name: "postgresql", FromVersion: "14.15.0-1", Version: "14.15.0-2", Phase: v1.DoguUpgradePhaseUpgrading, StartedAt: &startedAt},
+				{Name: "scm", FromVersion: "3.7.1-1", Version: "3.7.1-1", Phase: v1.DoguUpgradePhaseSkipped},
+			}}),
+			newTestUpgradeDogu("postgresql", "14.15.0-2", "14.15.0-2", doguv2.AvailableHealthStatus),
+		)
+
+		// when
+		result, err := sut.Reconcile(testCtx, ecosystemUpgradeRequest)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+		assert.Equal(t, v1.EcosystemUpgradePhaseSucceeded, getEcosystemUpgrade(t, k8sClient).Status.Phase)
+	})
+	t.Run("should stop on rolled back dogu", func(t *testing.T) {
+		// given
+		doguResource := newTestUpgradeDogu("postgresql", "14.15.0-2", "14.15.0-1", doguv2.AvailableHealthStatus)
+		doguResource.Status.Conditions = []metav1.Condition{{Type: upgrade.ConditionRolledBack, Status: metav1.ConditionTrue, Reason: upgrade.ReasonStartupFailed, Message: "container did not start"}}
+		recorder := newMockEventRecorder(t)
+		recorder.EXPECT().Event(mock.AnythingOfType("*v1.EcosystemUpgrade"), coreV1.EventTypeWarning, EcosystemUpgradeFailedEventReason,
+			`upgrade of dogu "postgresql" to 14.15.0-2 failed: the dogu is RolledBack: container did not start`)
+		sut, k8sClient := newEcosystemUpgradeReconciler(t, newMockEcosystemUpgradePlanner(t), recorder, now,
+			newTestEcosystemUpgrade(v1.EcosystemUpgradeStatus{Phase: v1.EcosystemUpgradePhaseUpgrading, Dogus: []v1.DoguUpgradeStatus{
+				{Name: "postgresql", FromVersion: "14.15.0-1", Version: "14.15.0-2", Phase: v1.DoguUpgradePhaseUpgrading, StartedAt: &startedAt},
+				{Name: "redmine", FromVersion: "5.1.3-1", Version: "5.1.3-2", Phase: v1.DoguUpgradePhasePending},
+			}}),
+			doguResource,
+		)
+
+		// when
+		result, err := sut.Reconcile(testCtx, ecosystemUpgradeRequest)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+		status := getEcosystemUpgrade(t, k8sClient).Status
+		assert.Equal(t, v1.EcosystemUpgradePhaseFailed, status.Phase)
+		assert.Equal(t, v1.DoguUpgradePhaseFailed, status.Dogus[0].Phase)
+		assert.Equal(t, "the dogu is RolledBack: container did not start", status.Dogus[0].Message)
+		assert.Equal(t, v1.DoguUpgradePhasePending, status.Dogus[1].Phase)
+	})
+	t.Run("should stop if the upgrade of the dogu stalled", func(t *testing.T) {
+		// given
+		doguResource := newTestUpgradeDogu("postgresql", "14.15.0-2", "14.15.0-1", doguv2.UnavailableHealthStatus)
+		doguResource.Status.Conditions = []metav1.Condition{{Type: upgrade.ConditionUpgradeStalled, Status: metav1.ConditionTrue, Reason: "RolloutStalled", Message: "the rollout took too long"}}
+		recorder := newMockEventRecorder(t)
+		recorder.EXPECT().Event(mock.AnythingOfType("*v1.EcosystemUpgrade"), coreV1.EventTypeWarning, EcosystemUpgradeFailedEventReason,
+			`upgrade of dogu "postgresql" to 14.15.0-2 failed: the dogu is UpgradeStalled: the rollout took too long`)
+		sut, k8sClient := newEcosystemUpgradeReconciler(t, newMockEcosystemUpgradePlanner(t), recorder, now,
+			newTestEcosystemUpgrade(v1.EcosystemUpgradeStatus{Phase: v1.EcosystemUpgradePhaseUpgrading, Dogus: []v1.DoguUpgradeStatus{
+				{Name: "postgresql", FromVersion: "14.15.0-1", Version: "14.15.0-2", Phase: v1.DoguUpgradePhaseUpgrading, StartedAt: &startedAt},
+			}}),
+			doguResource,
+		)
+
+		// when
+		_, err := sut.Reconcile(testCtx, ecosystemUpgradeRequest)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, v1.EcosystemUpgradePhaseFailed, getEcosystemUpgrade(t, k8sClient).Status.Phase)
+	})
+	t.Run("should stop if the version of the dogu resource was changed", func(t *testing.T) {
+		// given
+		recorder := newMockEventRecorder(t)
+		recorder.EXPECT().Event(mock.AnythingOfType("*v1.EcosystemUpgrade"), coreV1.EventTypeWarning, EcosystemUpgradeFailedEventReason,
+			`upgrade of dogu "postgresql" to 14.15.0-2 failed: the version of the dogu resource was changed to 14.15.0-3`)
+		sut, k8sClient := newEcosystemUpgradeReconciler(t, newMockEcosystemUpgradePlanner(t), recorder, now,
+			newTestEcosystemUpgrade(v1.EcosystemUpgradeStatus{Phase: v1.EcosystemUpgradePhaseUpgrading, Dogus: []v1.DoguUpgradeStatus{
+				{Name: "postgresql", FromVersion: "14.15.0-1", Version: "14.15.0-2", Phase: v1.DoguUpgradePhaseUpgrading, StartedAt: &startedAt},
+			}}),
+			newTestUpgradeDogu("postgresql", "14.15.0-3", "14.15.0-1", doguv2.AvailableHealthStatus),
+		)
+
+		// when
+		_, err := sut.Reconcile(testCtx, ecosystemUpgradeRequest)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, v1.EcosystemUpgradePhaseFailed, getEcosystemUpgrade(t, k8sClient).Status.Phase)
+	})
+	t.Run("should stop if the dogu resource does not exist", func(t *testing.T) {
+		// given
+		recorder := newMockEventRecorder(t)
+		recorder.EXPECT().Event(mock.AnythingOfType("*v1.EcosystemUpgrade"), coreV1.EventTypeWarning, EcosystemUpgradeFailedEventReason,
+			`upgrade of dogu "postgresql" to 14.15.0-2 failed: the dogu resource does not exist anymore`)
+		sut, k8sClient := newEcosystemUpgradeReconciler(t, newMockEcosystemUpgradePlanner(t), recorder, now,
+			newTestEcosystemUpgrade(v1.EcosystemUpgradeStatus{Phase: v1.EcosystemUpgradePhaseUpgrading, Dogus: []v1.DoguUpgradeStatus{
+				{Name: "postgresql", FromVersion: "14.15.0-1", Version: "14.15.0-2", Phase: v1.DoguUpgradePhasePending},
+			}}),
+		)
+
+		// when
+		_, err := sut.Reconcile(testCtx, ecosystemUpgradeRequest)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, v1.DoguUpgradePhaseFailed, getEcosystemUpgrade(t, k8sClient).Status.Dogus[0].Phase)
+	})
+}
+
+func TestEcosystemUpgradeReconciler_ecosystemUpgradesOfDogu(t *testing.T) {
+	t.Run("should enqueue running ecosystem upgrades in the namespace of the dogu", func(t *testing.T) {
+		// given
+		running := newTestEcosystemUpgrade(v1.EcosystemUpgradeStatus{Phase: v1.EcosystemUpgradePhaseUpgrading})
+		finished := newTestEcosystemUpgrade(v1.EcosystemUpgradeStatus{Phase: v1.EcosystemUpgradePhaseSucceeded})
+		finished.Name = "finished"
+		otherNamespace := newTestEcosystemUpgrade(v1.EcosystemUpgradeStatus{Phase: v1.EcosystemUpgradePhaseUpgrading})
+		otherNamespace.Namespace = "other"
+		sut, _ := newEcosystemUpgradeReconciler(t, newMockEcosystemUpgradePlanner(t), newMockEventRecorder(t), time.Now(), running, finished, otherNamespace)
+
+		// when
+		requests := sut.ecosystemUpgradesOfDogu(testCtx, newTestUpgradeDogu("postgresql", "14.15.0-1", "14.15.0-1", doguv2.AvailableHealthStatus))
+
+		// then
+		assert.Equal(t, []reconcile.Request{ecosystemUpgradeRequest}, requests)
+	})
+}
@@ -9,6 +9,8 @@ import (
 	"strings"
 
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/tracing"
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 
@@ -113,7 +115,13 @@ func (ce *defaultCommandExecutor) ExecCommandForDogu(ctx context.Context, resour
 
 // ExecCommandForPod execs a command in a given pod. This method executes a command on an arbitrary pod that can be
 // identified by its pod name.
-func (ce *defaultCommandExecutor) ExecCommandForPod(ctx context.Context, pod *corev1.Pod, command ShellCommand) (*bytes.Buffer, error) {
+func (ce *defaultCommandExecutor) ExecCommandForPod(ctx context.Context, pod *corev1.Pod, command ShellCommand) (_ *bytes.Buffer, err error) {
+	ctx, span := tracing.Start(ctx, "CommandExecutor.ExecCommandForPod",
+		attribute.String("pod.name", pod.Name),
+		attribute.String("command", commandName(command)),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	req := ce.getCreateExecRequest(pod, command)
 	exec, err := ce.commandExecutorCreator(ce.restConfig, "POST", req.URL())
 	if err != nil {
@@ -169,6 +177,15 @@ func (ce *defaultCommandExecutor) getCreateExecRequest(pod *corev1.Pod, command
 		}, scheme.ParameterCodec)
 }
 
+// commandName returns only the executable of the command because the arguments may contain sensitive data.
+func commandName(command ShellCommand) string {
+	commandWithArgs := command.CommandWithArgs()
+	if len(commandWithArgs) == 0 {
+		return ""
+	}
+	return commandWithArgs[0]
+}
+
 func (ce *defaultCommandExecutor) getDefaultContainer(pod *corev1.Pod) string {
 	if container, ok := pod.Annotations["kubectl.kubernetes.io/default-container"]; ok {
 		return container
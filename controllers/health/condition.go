@@ -0,0 +1,50 @@
+package health
+
+import (
+	k8sv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+)
+
+const (
+	// ConditionDegraded is true if the dogu itself runs but at least one of its mandatory dependencies is not healthy.
+	ConditionDegraded = "Degraded"
+	// ReasonDependenciesHealthy is the reason of the Degraded condition if all mandatory dependencies are healthy.
+	ReasonDependenciesHealthy = "DependenciesHealthy"
+	// ReasonDependencyUnhealthy is the reason of the Degraded condition if a mandatory dependency is not healthy.
+	ReasonDependencyUnhealthy = "DependencyUnhealthy"
+)
+
+const (
+	// ConditionFlapping is true if the health of the dogu changed too often within the flapping window.
+	ConditionFlapping = "Flapping"
+	// ReasonHealthStable is the reason of the Flapping condition if the health of the dogu is stable.
+	ReasonHealthStable = "HealthStable"
+	// ReasonHealthFlapping is the reason of the Flapping condition and of the warning event if the health of the dogu
+	// is flapping.
+	ReasonHealthFlapping = "HealthFlapping"
+)
+
+// DegradedHealthStatus is the health status of a dogu whose own replicas are available and ready while at least one
+// of its mandatory dependencies is not healthy.
+const DegradedHealthStatus k8sv2.HealthStatus = "degraded"
+
+// IsRunning returns true if the dogu itself is healthy. A degraded dogu is running, only its mandatory dependencies
+// are not healthy.
+func IsRunning(healthStatus k8sv2.HealthStatus) bool {
+	return healthStatus == k8sv2.AvailableHealthStatus || healthStatus == DegradedHealthStatus
+}
+
+const (
+	// ConditionContainersFailing is true if a container of the dogu crash-loops or has been terminated abnormally.
+	ConditionContainersFailing = "ContainersFailing"
+	// ReasonContainersRunning is the reason of the ContainersFailing condition if no container of the dogu fails.
+	ReasonContainersRunning = "ContainersRunning"
+	// ReasonOOMKilled is the reason of the ContainersFailing condition and of the warning event if a container of the
+	// dogu has been killed because it exceeded its memory limit.
+	ReasonOOMKilled = "OOMKilled"
+	// ReasonCrashLoopBackOff is the reason of the ContainersFailing condition and of the warning event if a container
+	// of the dogu is restarted with back-off after crashing repeatedly.
+	ReasonCrashLoopBackOff = "CrashLoopBackOff"
+	// ReasonContainerTerminated is the reason of the ContainersFailing condition and of the warning event if a
+	// container of the dogu has been terminated with a non-zero exit code.
+	ReasonContainerTerminated = "ContainerTerminated"
+)
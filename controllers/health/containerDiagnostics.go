@@ -0,0 +1,157 @@
+package health
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	terminationReasonOOMKilled = "OOMKilled"
+	waitingReasonCrashLoop     = "CrashLoopBackOff"
+	// maxTerminationMessageLength limits the excerpt of the termination message so that the condition stays readable.
+	maxTerminationMessageLength = 200
+	// maxReportedContainerFailures limits the number of failures described in the condition message.
+	maxReportedContainerFailures = 3
+)
+
+// OOMKilledHint explains how to raise the memory limit of a dogu whose container has been killed because of it.
+const OOMKilledHint = "The container exceeded its memory limit; raise it with the dogu config key container_config/memory_limit."
+
+// ContainerFailure describes a container of a dogu pod which crash-loops or has been terminated abnormally.
+type ContainerFailure struct {
+	// Pod is the name of the pod of the container.
+	Pod string
+	// Container is the name of the container.
+	Container string
+	// Reason is one of ReasonOOMKilled, ReasonCrashLoopBackOff and ReasonContainerTerminated.
+	Reason string
+	// TerminationReason is the reason of the last termination of the container as reported by the kubelet, e.g. "Error".
+	TerminationReason string
+	// ExitCode is the exit code of the last termination of the container.
+	ExitCode int32
+	// RestartCount is the number of restarts of the container.
+	RestartCount int32
+	// Message is an excerpt of the termination message of the container.
+	Message string
+}
+
+// String describes the failure in a single line.
+func (f ContainerFailure) String() string {
+	description := fmt.Sprintf("container %s of pod %s: %s (exit code %d, %d restarts)", f.Container, f.Pod, f.Reason, f.ExitCode, f.RestartCount)
+	if f.TerminationReason != "" && f.TerminationReason != f.Reason {
+		description = fmt.Sprintf("%s, last termination: %s", description, f.TerminationReason)
+	}
+	if f.Message != "" {
+		description = fmt.Sprintf("%s: %s", description, f.Message)
+	}
+	return description
+}
+
+// FindContainerFailures inspects the container statuses of the pods and returns the containers which crash-loop or
+// have been terminated abnormally. Init containers are included, because a failing init container blocks the pod as
+// well. Terminating pods are skipped.
+func FindContainerFailures(pods []corev1.Pod) []ContainerFailure {
+	var failures []ContainerFailure
+	for _, pod := range pods {
+		if !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		for _, status := range pod.Status.InitContainerStatuses {
+			if failure, failing := diagnoseContainer(pod.Name, status, true); failing {
+				failures = append(failures, failure)
+			}
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if failure, failing := diagnoseContainer(pod.Name, status, false); failing {
+				failures = append(failures, failure)
+			}
+		}
+	}
+
+	return failures
+}
+
+// DescribeContainerFailures returns the reason and message for the ContainersFailing condition.
+// OOM kills take precedence over crash loops and other terminations, because they have a clear remedy.
+func DescribeContainerFailures(failures []ContainerFailure) (reason string, message string) {
+	if len(failures) == 0 {
+		return ReasonContainersRunning, "No container is crash-looping or terminated abnormally"
+	}
+
+	reason = ReasonContainerTerminated
+	var descriptions []string
+	for i, failure := range failures {
+		if failure.Reason == ReasonOOMKilled || (failure.Reason == ReasonCrashLoopBackOff && reason != ReasonOOMKilled) {
+			reason = failure.Reason
+		}
+		if i < maxReportedContainerFailures {
+			descriptions = append(descriptions, failure.String())
+		}
+	}
+	if len(failures) > maxReportedContainerFailures {
+		descriptions = append(descriptions, fmt.Sprintf("and %d more", len(failures)-maxReportedContainerFailures))
+	}
+
+	message = strings.Join(descriptions, "; ")
+	if reason == ReasonOOMKilled {
+		message = fmt.Sprintf("%s. %s", message, OOMKilledHint)
+	}
+
+	return reason, message
+}
+
+func diagnoseContainer(podName string, status corev1.ContainerStatus, initContainer bool) (ContainerFailure, bool) {
+	failure := ContainerFailure{
+		Pod:          podName,
+		Container:    status.Name,
+		RestartCount: status.RestartCount,
+	}
+
+	// a running container is the result of a restart, so its last termination describes why it was restarted
+	termination := status.LastTerminationState.Terminated
+	switch {
+	case status.State.Waiting != nil && status.State.Waiting.Reason == waitingReasonCrashLoop:
+		failure.Reason = ReasonCrashLoopBackOff
+	case status.State.Terminated != nil && isAbnormalTermination(status.State.Terminated):
+		failure.Reason = ReasonContainerTerminated
+		termination = status.State.Terminated
+	case !initContainer && status.State.Running != nil && !status.Ready && termination != nil && termination.Reason == terminationReasonOOMKilled:
+		failure.Reason = ReasonOOMKilled
+	default:
+		return ContainerFailure{}, false
+	}
+
+	if termination != nil {
+		failure.TerminationReason = termination.Reason
+		failure.ExitCode = termination.ExitCode
+		failure.Message = excerpt(termination.Message)
+		if termination.Reason == terminationReasonOOMKilled {
+			failure.Reason = ReasonOOMKilled
+		}
+	}
+
+	return failure, true
+}
+
+func isAbnormalTermination(terminated *corev1.ContainerStateTerminated) bool {
+	return terminated.ExitCode != 0 || terminated.Reason == terminationReasonOOMKilled
+}
+
+// excerpt returns the last line of the message, limited to maxTerminationMessageLength characters.
+// Termination messages taken from the container log end with the error, so the last line is the most telling one.
+func excerpt(message string) string {
+	lines := strings.Split(strings.TrimSpace(message), "\n")
+	lastLine := strings.TrimSpace(lines[len(lines)-1])
+	if len(lines) > 1 {
+		lastLine = "... " + lastLine
+	}
+
+	runes := []rune(lastLine)
+	if len(runes) > maxTerminationMessageLength {
+		return string(runes[:maxTerminationMessageLength]) + "..."
+	}
+	return lastLine
+}
@@ -0,0 +1,111 @@
+package health
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFindContainerFailures(t *testing.T) {
+	oomKilled := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}}
+	crashed := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1, Message: "starting ldap\nslapd: cannot open database"}}
+	running := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	crashLoop := corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}
+	newPod := func(name string, initStatuses []corev1.ContainerStatus, statuses ...corev1.ContainerStatus) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     corev1.PodStatus{InitContainerStatuses: initStatuses, ContainerStatuses: statuses},
+		}
+	}
+
+	t.Run("should find crash-looping, oom killed and terminated containers", func(t *testing.T) {
+		// given
+		pods := []corev1.Pod{
+			newPod("ldap-1", nil,
+				corev1.ContainerStatus{Name: "ldap", State: crashLoop, LastTerminationState: crashed, RestartCount: 5},
+				corev1.ContainerStatus{Name: "exporter", State: running, Ready: true, LastTerminationState: oomKilled, RestartCount: 1},
+			),
+			newPod("ldap-2",
+				[]corev1.ContainerStatus{{Name: "chown", State: crashed}},
+				corev1.ContainerStatus{Name: "ldap", State: running, LastTerminationState: oomKilled, RestartCount: 2},
+			),
+		}
+
+		// when
+		failures := FindContainerFailures(pods)
+
+		// then
+		assert.Equal(t, []ContainerFailure{
+			{Pod: "ldap-1", Container: "ldap", Reason: ReasonCrashLoopBackOff, TerminationReason: "Error", ExitCode: 1, RestartCount: 5, Message: "... slapd: cannot open database"},
+			{Pod: "ldap-2", Container: "chown", Reason: ReasonContainerTerminated, TerminationReason: "Error", ExitCode: 1, Message: "... slapd: cannot open database"},
+			{Pod: "ldap-2", Container: "ldap", Reason: ReasonOOMKilled, TerminationReason: "OOMKilled", ExitCode: 137, RestartCount: 2},
+		}, failures)
+	})
+	t.Run("should report crash loop after oom kill as oom kill", func(t *testing.T) {
+		// given
+		pods := []corev1.Pod{newPod("ldap-1", nil, corev1.ContainerStatus{Name: "ldap", State: crashLoop, LastTerminationState: oomKilled, RestartCount: 3})}
+
+		// when
+		failures := FindContainerFailures(pods)
+
+		// then
+		assert.Equal(t, []ContainerFailure{{Pod: "ldap-1", Container: "ldap", Reason: ReasonOOMKilled, TerminationReason: "OOMKilled", ExitCode: 137, RestartCount: 3}}, failures)
+	})
+	t.Run("should ignore healthy, completed and terminating containers", func(t *testing.T) {
+		// given
+		terminatingPod := newPod("ldap-0", nil, corev1.ContainerStatus{Name: "ldap", State: crashLoop})
+		deletionTimestamp := metav1.Now()
+		terminatingPod.DeletionTimestamp = &deletionTimestamp
+		pods := []corev1.Pod{
+			terminatingPod,
+			newPod("ldap-1",
+				[]corev1.ContainerStatus{{Name: "chown", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Completed"}}}},
+				corev1.ContainerStatus{Name: "ldap", State: running, Ready: true},
+			),
+		}
+
+		// when
+		failures := FindContainerFailures(pods)
+
+		// then
+		assert.Empty(t, failures)
+	})
+}
+
+func TestDescribeContainerFailures(t *testing.T) {
+	crashLoop := ContainerFailure{Pod: "ldap-1", Container: "ldap", Reason: ReasonCrashLoopBackOff, TerminationReason: "Error", ExitCode: 1, RestartCount: 5, Message: "cannot open database"}
+	oomKilled := ContainerFailure{Pod: "ldap-2", Container: "ldap", Reason: ReasonOOMKilled, TerminationReason: "OOMKilled", ExitCode: 137, RestartCount: 2}
+	terminated := ContainerFailure{Pod: "ldap-3", Container: "chown", Reason: ReasonContainerTerminated, ExitCode: 1}
+
+	t.Run("should describe running containers", func(t *testing.T) {
+		reason, message := DescribeContainerFailures(nil)
+
+		assert.Equal(t, ReasonContainersRunning, reason)
+		assert.Equal(t, "No container is crash-looping or terminated abnormally", message)
+	})
+	t.Run("should prefer crash loop over termination", func(t *testing.T) {
+		reason, message := DescribeContainerFailures([]ContainerFailure{terminated, crashLoop})
+
+		assert.Equal(t, ReasonCrashLoopBackOff, reason)
+		assert.Equal(t, "container chown of pod ldap-3: ContainerTerminated (exit code 1, 0 restarts); "+
+			"container ldap of pod ldap-1: CrashLoopBackOff (exit code 1, 5 restarts), last termination: Error: cannot open database", message)
+	})
+	t.Run("should prefer oom kill and add memory limit hint", func(t *testing.T) {
+		reason, message := DescribeContainerFailures([]ContainerFailure{crashLoop, oomKilled, terminated, terminated, terminated})
+
+		assert.Equal(t, ReasonOOMKilled, reason)
+		assert.Contains(t, message, "container ldap of pod ldap-2: OOMKilled (exit code 137, 2 restarts)")
+		assert.Contains(t, message, "; and 2 more. ")
+		assert.True(t, strings.HasSuffix(message, "container_config/memory_limit."))
+	})
+}
+
+func Test_excerpt(t *testing.T) {
+	assert.Equal(t, "", excerpt(""))
+	assert.Equal(t, "panic", excerpt(" panic\n"))
+	assert.Equal(t, "... fatal error", excerpt("starting\nfatal error"))
+	assert.Equal(t, strings.Repeat("ä", maxTerminationMessageLength)+"...", excerpt(strings.Repeat("ä", 300)))
+}
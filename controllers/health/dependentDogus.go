@@ -0,0 +1,96 @@
+package health
+
+import (
+	"context"
+
+	cescommons "github.com/cloudogu/ces-commons-lib/dogu"
+	"github.com/cloudogu/cesapp-lib/core"
+	k8sv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/cesregistry"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/namespaced"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// HealthChangedPredicate lets update events pass if the health status of the dogu resource changed.
+// Status updates do not increase the generation, so without this predicate dependent dogus would not notice that a
+// dependency became unhealthy or recovered.
+func HealthChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldDogu, oldOk := e.ObjectOld.(*k8sv2.Dogu)
+			newDogu, newOk := e.ObjectNew.(*k8sv2.Dogu)
+			if !oldOk || !newOk {
+				return false
+			}
+			return oldDogu.Status.Health != newDogu.Status.Health
+		},
+	}
+}
+
+// NewDependentDoguMapper creates a mapper which finds the dogus depending on a dogu.
+func NewDependentDoguMapper(ecosystemClient doguClient.EcoSystemV2Interface, localFetcher cesregistry.LocalDoguFetcher) DependentDoguMapper {
+	return &dependentDoguMapper{
+		ecosystemClient:   ecosystemClient,
+		doguLocalRegistry: localFetcher,
+	}
+}
+
+type dependentDoguMapper struct {
+	ecosystemClient   doguClient.EcoSystemV2Interface
+	doguLocalRegistry localDoguFetcher
+}
+
+// MapDependents returns reconcile requests for all installed dogus depending on the given dogu, so that they update
+// their Degraded condition. Dogus which are not installed yet are woken by the wait list instead.
+// Dogus whose descriptor cannot be fetched are skipped, because their dependencies are unknown.
+func (m *dependentDoguMapper) MapDependents(ctx context.Context, obj client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+	namespace := obj.GetNamespace()
+	ctx = namespaced.WithNamespace(ctx, namespace)
+
+	doguList, err := m.ecosystemClient.Dogus(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logger.Error(err, "failed to list dogus to find dependents", "dogu", obj.GetName())
+		return nil
+	}
+
+	dependents := map[string][]string{}
+	for _, doguResource := range doguList.Items {
+		dogu, err := m.doguLocalRegistry.FetchInstalled(ctx, cescommons.SimpleName(doguResource.Name))
+		if err != nil {
+			logger.Info("Skipping dogu while searching dependents", "dogu", doguResource.Name, "reason", err.Error())
+			continue
+		}
+
+		for _, dependency := range dogu.GetDependenciesOfType(core.DependencyTypeDogu) {
+			dependents[dependency.Name] = append(dependents[dependency.Name], doguResource.Name)
+		}
+	}
+
+	var requests []reconcile.Request
+	visited := map[string]bool{obj.GetName(): true}
+	queue := []string{obj.GetName()}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, dependent := range dependents[current] {
+			if visited[dependent] {
+				continue
+			}
+			visited[dependent] = true
+			queue = append(queue, dependent)
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKey{Namespace: namespace, Name: dependent}})
+		}
+	}
+
+	return requests
+}
@@ -0,0 +1,87 @@
+package health
+
+import (
+	"testing"
+
+	cescommons "github.com/cloudogu/ces-commons-lib/dogu"
+	"github.com/cloudogu/cesapp-lib/core"
+	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/namespaced"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestHealthChangedPredicate(t *testing.T) {
+	sut := HealthChangedPredicate()
+	available := &doguv2.Dogu{Status: doguv2.DoguStatus{Health: doguv2.AvailableHealthStatus}}
+	unavailable := &doguv2.Dogu{Status: doguv2.DoguStatus{Health: doguv2.UnavailableHealthStatus}}
+
+	assert.True(t, sut.Update(event.UpdateEvent{ObjectOld: available, ObjectNew: unavailable}))
+	assert.False(t, sut.Update(event.UpdateEvent{ObjectOld: available, ObjectNew: available.DeepCopy()}))
+	assert.False(t, sut.Update(event.UpdateEvent{ObjectOld: nil, ObjectNew: available}))
+	assert.False(t, sut.Create(event.CreateEvent{Object: available}))
+	assert.False(t, sut.Delete(event.DeleteEvent{Object: available}))
+}
+
+func Test_dependentDoguMapper_MapDependents(t *testing.T) {
+	ctx := namespaced.WithNamespace(testCtx, testNamespace)
+	newDogu := func(name string) doguv2.Dogu {
+		return doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace}}
+	}
+	newDescriptor := func(name string, mandatory []string, optional []string) *core.Dogu {
+		descriptor := &core.Dogu{Name: "official/" + name}
+		for _, dependency := range mandatory {
+			descriptor.Dependencies = append(descriptor.Dependencies, core.Dependency{Type: core.DependencyTypeDogu, Name: dependency})
+		}
+		for _, dependency := range optional {
+			descriptor.OptionalDependencies = append(descriptor.OptionalDependencies, core.Dependency{Type: core.DependencyTypeDogu, Name: dependency})
+		}
+		return descriptor
+	}
+	postgresql := newDogu("postgresql")
+
+	t.Run("should return direct and transitive mandatory dependents", func(t *testing.T) {
+		// given
+		doguClientMock := newMockDoguInterface(t)
+		doguClientMock.EXPECT().List(ctx, metav1.ListOptions{}).Return(&doguv2.DoguList{Items: []doguv2.Dogu{
+			postgresql, newDogu("redmine"), newDogu("scm"), newDogu("smeagol"), newDogu("jenkins"),
+		}}, nil)
+		ecosystemClientMock := newMockEcosystemInterface(t)
+		ecosystemClientMock.EXPECT().Dogus(testNamespace).Return(doguClientMock)
+		localFetcher := newMockLocalDoguFetcher(t)
+		localFetcher.EXPECT().FetchInstalled(ctx, cescommons.SimpleName("postgresql")).Return(newDescriptor("postgresql", nil, nil), nil)
+		localFetcher.EXPECT().FetchInstalled(ctx, cescommons.SimpleName("redmine")).Return(newDescriptor("redmine", []string{"postgresql"}, nil), nil)
+		localFetcher.EXPECT().FetchInstalled(ctx, cescommons.SimpleName("scm")).Return(newDescriptor("scm", nil, []string{"redmine"}), nil)
+		localFetcher.EXPECT().FetchInstalled(ctx, cescommons.SimpleName("smeagol")).Return(newDescriptor("smeagol", []string{"redmine"}, nil), nil)
+		localFetcher.EXPECT().FetchInstalled(ctx, cescommons.SimpleName("jenkins")).Return(nil, assert.AnError)
+
+		sut := NewDependentDoguMapper(ecosystemClientMock, localFetcher)
+
+		// when
+		requests := sut.MapDependents(testCtx, &postgresql)
+
+		// then
+		assert.Equal(t, []reconcile.Request{
+			{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: "redmine"}},
+			{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: "smeagol"}},
+		}, requests)
+	})
+	t.Run("should return no requests if dogus cannot be listed", func(t *testing.T) {
+		// given
+		doguClientMock := newMockDoguInterface(t)
+		doguClientMock.EXPECT().List(ctx, metav1.ListOptions{}).Return(nil, assert.AnError)
+		ecosystemClientMock := newMockEcosystemInterface(t)
+		ecosystemClientMock.EXPECT().Dogus(testNamespace).Return(doguClientMock)
+
+		sut := NewDependentDoguMapper(ecosystemClientMock, newMockLocalDoguFetcher(t))
+
+		// when
+		requests := sut.MapDependents(testCtx, &postgresql)
+
+		// then
+		assert.Empty(t, requests)
+	})
+}
@@ -8,7 +8,6 @@ import (
 	cescommons "github.com/cloudogu/ces-commons-lib/dogu"
 	regLibErr "github.com/cloudogu/ces-commons-lib/errors"
 	"github.com/cloudogu/cesapp-lib/core"
-	k8sv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/cesregistry"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
@@ -55,7 +54,8 @@ type doguChecker struct {
 	disablePostfixDependencyCheck bool
 }
 
-// CheckByName returns nil if the dogu resource's health status says it's available.
+// CheckByName returns nil if the dogu resource's health status is available or degraded. A degraded dogu is running
+// itself, so it does not block its dependents; the unhealthy dependency causing the degradation is reported on its own.
 // If the dogu is unhealthy, an error of type *health.DoguHealthError is returned:
 //
 //	var doguHealthError *health.DoguHealthError
@@ -68,7 +68,7 @@ func (dc *doguChecker) CheckByName(ctx context.Context, doguName types.Namespace
 		return fmt.Errorf("failed to get dogu resource %q: %w", doguName, err)
 	}
 
-	if doguResource.Status.Health != k8sv2.AvailableHealthStatus {
+	if !IsRunning(doguResource.Status.Health) {
 		return NewDoguHealthError(fmt.Errorf("dogu %q appears unhealthy",
 			doguResource.Name))
 	}
@@ -81,7 +81,7 @@ func (dc *doguChecker) CheckByName(ctx context.Context, doguName types.Namespace
 func (dc *doguChecker) CheckDependenciesRecursive(ctx context.Context, localDoguRoot *core.Dogu, namespace string) error {
 	var errs []error
 
-	err := dc.checkMandatoryRecursive(ctx, localDoguRoot, namespace)
+	err := dc.checkMandatoryRecursive(ctx, localDoguRoot, namespace, true)
 	if err != nil {
 		errs = append(errs, err)
 	}
@@ -94,7 +94,13 @@ func (dc *doguChecker) CheckDependenciesRecursive(ctx context.Context, localDogu
 	return errors.Join(errs...)
 }
 
-func (dc *doguChecker) checkMandatoryRecursive(ctx context.Context, localDogu *core.Dogu, namespace string) error {
+// CheckMandatoryDependenciesRecursive checks only the mandatory dogu dependencies and their mandatory dependencies
+// for health and returns an error if at least one dogu is not healthy.
+func (dc *doguChecker) CheckMandatoryDependenciesRecursive(ctx context.Context, localDoguRoot *core.Dogu, namespace string) error {
+	return dc.checkMandatoryRecursive(ctx, localDoguRoot, namespace, false)
+}
+
+func (dc *doguChecker) checkMandatoryRecursive(ctx context.Context, localDogu *core.Dogu, namespace string, includeOptional bool) error {
 	var errs []error
 
 	for _, dependency := range localDogu.GetDependenciesOfType(core.DependencyTypeDogu) {
@@ -124,7 +130,11 @@ func (dc *doguChecker) checkMandatoryRecursive(ctx context.Context, localDogu *c
 			errs = append(errs, err)
 		}
 
-		err = dc.CheckDependenciesRecursive(ctx, dependencyDogu, namespace)
+		if includeOptional {
+			err = dc.CheckDependenciesRecursive(ctx, dependencyDogu, namespace)
+		} else {
+			err = dc.checkMandatoryRecursive(ctx, dependencyDogu, namespace, false)
+		}
 		if err != nil {
 			errs = append(errs, err)
 		}
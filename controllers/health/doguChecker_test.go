@@ -980,6 +980,60 @@ func Test_doguChecker_checkDependencyDogusHealthy(t *testing.T) {
 	})
 }
 
+func Test_doguChecker_CheckMandatoryDependenciesRecursive(t *testing.T) {
+	t.Run("should ignore optional dependencies", func(t *testing.T) {
+		/*
+			redmine
+			+-m-> ☑️postgresql
+			+-m-> ☑️mandatory1
+			+-o-> ❌️optional1
+		*/
+		localFetcher := newMockLocalDoguFetcher(t)
+		localFetcher.EXPECT().FetchInstalled(testCtx, cescommons.SimpleName("postgresql")).Return(readTestDataDogu(t, postgresqlBytes), nil)
+		localFetcher.EXPECT().FetchInstalled(testCtx, cescommons.SimpleName("mandatory1")).Return(readTestDataDogu(t, mandatory1Bytes), nil)
+
+		doguClientMock := newMockDoguInterface(t)
+		doguClientMock.EXPECT().Get(testCtx, "postgresql", metav1.GetOptions{}).Return(&doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "postgresql"}, Status: doguv2.DoguStatus{Health: "available"}}, nil)
+		doguClientMock.EXPECT().Get(testCtx, "mandatory1", metav1.GetOptions{}).Return(&doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "mandatory1"}, Status: doguv2.DoguStatus{Health: "degraded"}}, nil)
+		ecosystemClient := newMockEcosystemInterface(t)
+		ecosystemClient.EXPECT().Dogus(testNamespace).Return(doguClientMock)
+
+		sut := NewDoguChecker(&config.OperatorConfig{}, ecosystemClient, localFetcher)
+
+		// when
+		err := sut.CheckMandatoryDependenciesRecursive(testCtx, readTestDataDogu(t, redmineBytes), testNamespace)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail if a mandatory dependency is not healthy", func(t *testing.T) {
+		/*
+			redmine
+			+-m-> ❌️postgresql
+			+-m-> ☑️mandatory1
+		*/
+		localFetcher := newMockLocalDoguFetcher(t)
+		localFetcher.EXPECT().FetchInstalled(testCtx, cescommons.SimpleName("postgresql")).Return(readTestDataDogu(t, postgresqlBytes), nil)
+		localFetcher.EXPECT().FetchInstalled(testCtx, cescommons.SimpleName("mandatory1")).Return(readTestDataDogu(t, mandatory1Bytes), nil)
+
+		doguClientMock := newMockDoguInterface(t)
+		doguClientMock.EXPECT().Get(testCtx, "postgresql", metav1.GetOptions{}).Return(&doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "postgresql"}, Status: doguv2.DoguStatus{Health: "unavailable"}}, nil)
+		doguClientMock.EXPECT().Get(testCtx, "mandatory1", metav1.GetOptions{}).Return(&doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "mandatory1"}, Status: doguv2.DoguStatus{Health: "available"}}, nil)
+		ecosystemClient := newMockEcosystemInterface(t)
+		ecosystemClient.EXPECT().Dogus(testNamespace).Return(doguClientMock)
+
+		sut := NewDoguChecker(&config.OperatorConfig{}, ecosystemClient, localFetcher)
+
+		// when
+		err := sut.CheckMandatoryDependenciesRecursive(testCtx, readTestDataDogu(t, redmineBytes), testNamespace)
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, 1, countMultiErrors(err))
+		assert.ErrorContains(t, err, "dogu \"postgresql\" appears unhealthy")
+	})
+}
+
 func createTestRestConfig() (*rest.Config, error) {
 	return &rest.Config{}, nil
 }
@@ -2,36 +2,56 @@ package health
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"github.com/cloudogu/retry-lib/retry"
+	"maps"
+	"slices"
 
 	cesappcore "github.com/cloudogu/cesapp-lib/core"
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/dogustore"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/resource"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
 	metav1api "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	appsv1client "k8s.io/client-go/kubernetes/typed/apps/v1"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/record"
 )
 
-const healthConfigMapName = "k8s-dogu-operator-dogu-health"
+// HealthStateLabel marks config maps that contain the health state of a dogu.
+const HealthStateLabel = "k8s.cloudogu.com/health-state"
 
 type DoguStatusUpdater struct {
-	recorder           record.EventRecorder
-	configMapInterface configMapInterface
-	podInterface       podInterface
+	recorder record.EventRecorder
+	// store contains the health config maps of the dogus. The config map of a dogu contains a single key, the name of
+	// the dogu, so that it appears as /etc/ces/health/<dogu> in the pods of the dogu and of its dependents.
+	store        *dogustore.ConfigMapStore
+	podInterface podInterface
+	legacy       *legacyHealthConfigMap
 }
 
-func NewDoguStatusUpdater(recorder record.EventRecorder, configMapInterface corev1.ConfigMapInterface, podInterface corev1.PodInterface) *DoguStatusUpdater {
+func NewDoguStatusUpdater(
+	recorder record.EventRecorder,
+	configMapInterface corev1.ConfigMapInterface,
+	podInterface corev1.PodInterface,
+	deploymentInterface appsv1client.DeploymentInterface,
+	scheme *runtime.Scheme,
+) *DoguStatusUpdater {
 	return &DoguStatusUpdater{
-		recorder:           recorder,
-		configMapInterface: configMapInterface,
-		podInterface:       podInterface,
+		recorder:     recorder,
+		store:        dogustore.NewConfigMapStore(configMapInterface, scheme, resource.HealthConfigMapNameSuffix, HealthStateLabel),
+		podInterface: podInterface,
+		legacy:       newLegacyHealthConfigMap(configMapInterface, podInterface, deploymentInterface),
 	}
 }
 
-func (dsw *DoguStatusUpdater) UpdateHealthConfigMap(ctx context.Context, doguDeployment *appsv1.Deployment, doguJson *cesappcore.Dogu) error {
+// UpdateHealthConfigMap writes the health state of the dogu into its own health config map. The state is set if the
+// dogu has a health check of type state and one of its pods has started; otherwise it is empty.
+// Only the reconcile of the dogu writes its health config map, so updates of different dogus never conflict.
+// As long as the dogu still mounts the legacy health config map, the state is written there as well.
+func (dsw *DoguStatusUpdater) UpdateHealthConfigMap(ctx context.Context, doguResource *v2.Dogu, doguDeployment *appsv1.Deployment, doguJson *cesappcore.Dogu) error {
 	// Get all pods to deployment
 	pods, err := dsw.podInterface.List(ctx, metav1api.ListOptions{
 		LabelSelector: metav1api.FormatLabelSelector(doguDeployment.Spec.Selector),
@@ -40,77 +60,81 @@ func (dsw *DoguStatusUpdater) UpdateHealthConfigMap(ctx context.Context, doguDep
 		return fmt.Errorf("failed to get all pods for the deployment %v: %w", doguDeployment, err)
 	}
 
+	healthState := ""
 	isState, state := hasHealthCheckofTypeState(doguJson)
-	var stateConfigMap *v1.ConfigMap
-	for _, pod := range pods.Items {
-		retryErr := retry.OnConflict(func() error {
-			stateConfigMap, err = dsw.configMapInterface.Get(ctx, healthConfigMapName, metav1api.GetOptions{})
-			if err != nil {
-				return fmt.Errorf("failed to update health state in health configMap: %w", err)
-			}
-			initHealthConfigMap(stateConfigMap, doguDeployment)
-
-			if isState {
-				setHealthConfigMapStateWhenStarted(stateConfigMap, pod, doguDeployment, state)
-			}
-
-			_, err = dsw.configMapInterface.Update(ctx, stateConfigMap, metav1api.UpdateOptions{})
+	if isState && hasStartedPod(pods.Items) {
+		healthState = state
+	}
 
-			return err
+	desiredData := map[string]string{doguResource.Name: healthState}
+	currentData, err := dsw.store.Get(ctx, doguResource)
+	if err != nil {
+		return fmt.Errorf("failed to get health configMap: %w", err)
+	}
+	if currentData == nil || !maps.Equal(currentData, desiredData) {
+		err = dsw.store.Update(ctx, doguResource, func(data map[string]string) error {
+			clear(data)
+			maps.Copy(data, desiredData)
+			return nil
 		})
-		if retryErr != nil {
-			return fmt.Errorf("failed to update health state in health configMap: %w", retryErr)
-		}
-
-		if stateConfigMap != nil && stateConfigMap.Data[doguDeployment.Name] != "" {
-			break
+		if err != nil {
+			return fmt.Errorf("failed to update health state in health configMap: %w", err)
 		}
 	}
 
-	return nil
+	stillMounted := mountsLegacyHealthConfigMap(doguDeployment.Spec.Template.Spec.Volumes) || slices.ContainsFunc(pods.Items, func(pod v1.Pod) bool {
+		return mountsLegacyHealthConfigMap(pod.Spec.Volumes)
+	})
+	return dsw.legacy.update(ctx, doguResource.Namespace, doguResource.Name, &healthState, stillMounted)
 }
 
+// DeleteDoguOutOfHealthConfigMap deletes the health config map of the dogu and its entry in the legacy health config
+// map, if the legacy health config map is still in use.
 func (dsw *DoguStatusUpdater) DeleteDoguOutOfHealthConfigMap(ctx context.Context, dogu *v2.Dogu) error {
-	stateConfigMap, err := dsw.getOrCreateHealthConfigMap(ctx)
+	err := dsw.store.Delete(ctx, dogu)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to delete health configMap of dogu %q: %w", dogu.Name, err)
 	}
 
-	newData := stateConfigMap.Data
-	if newData == nil {
-		newData = make(map[string]string)
-	}
-	delete(newData, dogu.Name)
+	return dsw.legacy.update(ctx, dogu.Namespace, dogu.Name, nil, false)
+}
 
-	stateConfigMap.Data = newData
+// MigrateLegacyHealthConfigMap copies the health states of the given dogus from the legacy health config map into
+// their own health config maps, unless they already have one. It also determines which dogus of the namespace still
+// mount the legacy health config map. It is called once per namespace when the operator starts.
+func (dsw *DoguStatusUpdater) MigrateLegacyHealthConfigMap(ctx context.Context, namespace string, dogus []v2.Dogu) error {
+	legacyData, err := dsw.legacy.migrate(ctx, namespace)
+	if err != nil {
+		return err
+	}
 
-	// Update the ConfigMap
-	_, err = dsw.configMapInterface.Update(ctx, stateConfigMap, metav1api.UpdateOptions{})
-	return err
-}
+	var errs []error
+	for _, dogu := range dogus {
+		state, found := legacyData[dogu.Name]
+		if !found {
+			continue
+		}
 
-func (dsw *DoguStatusUpdater) getOrCreateHealthConfigMap(ctx context.Context) (*v1.ConfigMap, error) {
-	cm, err := dsw.configMapInterface.Get(ctx, healthConfigMapName, metav1api.GetOptions{})
-	if err != nil && errors.IsNotFound(err) {
-		cm = &v1.ConfigMap{
-			ObjectMeta: metav1api.ObjectMeta{Name: healthConfigMapName},
-			Data:       map[string]string{},
+		data, err := dsw.store.Get(ctx, &dogu)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get health configMap of dogu %q: %w", dogu.Name, err))
+			continue
+		}
+		if data != nil {
+			// the health state was already written by a reconcile of the dogu
+			continue
 		}
-		cm, err = dsw.configMapInterface.Create(ctx, cm, metav1api.CreateOptions{})
+
+		err = dsw.store.Update(ctx, &dogu, func(data map[string]string) error {
+			data[dogu.Name] = state
+			return nil
+		})
 		if err != nil {
-			return nil, err
+			errs = append(errs, fmt.Errorf("failed to migrate health state of dogu %q: %w", dogu.Name, err))
 		}
-	} else if err != nil {
-		return nil, err
 	}
-	return cm, nil
-}
 
-func initHealthConfigMap(stateConfigMap *v1.ConfigMap, doguDeployment *appsv1.Deployment) {
-	if stateConfigMap.Data == nil {
-		stateConfigMap.Data = make(map[string]string)
-	}
-	stateConfigMap.Data[doguDeployment.Name] = ""
+	return errors.Join(errs...)
 }
 
 func hasHealthCheckofTypeState(doguJson *cesappcore.Dogu) (bool, string) {
@@ -128,11 +152,14 @@ func hasHealthCheckofTypeState(doguJson *cesappcore.Dogu) (bool, string) {
 	return isState, state
 }
 
-func setHealthConfigMapStateWhenStarted(stateConfigMap *v1.ConfigMap, pod v1.Pod, doguDeployment *appsv1.Deployment, state string) {
-	for _, status := range pod.Status.ContainerStatuses {
-		if *status.Started {
-			stateConfigMap.Data[doguDeployment.Name] = state
-			break
+func hasStartedPod(pods []v1.Pod) bool {
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Started != nil && *status.Started {
+				return true
+			}
 		}
 	}
+
+	return false
 }
@@ -1,16 +1,19 @@
 package health
 
 import (
-	"fmt"
+	"context"
 	"testing"
 
 	"github.com/cloudogu/cesapp-lib/core"
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/dogustore"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/resource"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	errors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/client-go/tools/record"
+	"k8s.io/apimachinery/pkg/types"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -22,17 +25,38 @@ func TestNewDoguStatusUpdater(t *testing.T) {
 	recorderMock := newMockEventRecorder(t)
 	configMapInterfaceMock := newMockConfigMapInterface(t)
 	podInterfaceMock := newMockPodInterface(t)
+	deploymentInterfaceMock := newMockDeploymentInterface(t)
 
 	// when
-	actual := NewDoguStatusUpdater(recorderMock, configMapInterfaceMock, podInterfaceMock)
+	actual := NewDoguStatusUpdater(recorderMock, configMapInterfaceMock, podInterfaceMock, deploymentInterfaceMock, getTestScheme())
 
 	// then
 	assert.Same(t, podInterfaceMock, actual.podInterface)
 	assert.Same(t, recorderMock, actual.recorder)
-	assert.Same(t, configMapInterfaceMock, actual.configMapInterface)
+	assert.Equal(t, "ldap-health", actual.store.ConfigMapName("ldap"))
+	assert.Same(t, configMapInterfaceMock, actual.legacy.configMapInterface)
+	assert.Same(t, podInterfaceMock, actual.legacy.podInterface)
+	assert.Same(t, deploymentInterfaceMock, actual.legacy.deploymentInterface)
+}
+
+// newTestDoguStatusUpdater creates a DoguStatusUpdater whose dogus mounting the legacy health config map in the test
+// namespace are already determined.
+func newTestDoguStatusUpdater(cmClientMock configMapInterface, podClientMock podInterface, mountingDogus ...string) *DoguStatusUpdater {
+	legacy := newLegacyHealthConfigMap(cmClientMock, podClientMock, nil)
+	legacy.mountingDogus[testNamespace] = map[string]bool{}
+	for _, doguName := range mountingDogus {
+		legacy.mountingDogus[testNamespace][doguName] = true
+	}
+
+	return &DoguStatusUpdater{
+		store:        dogustore.NewConfigMapStore(cmClientMock, getTestScheme(), resource.HealthConfigMapNameSuffix, HealthStateLabel),
+		podInterface: podClientMock,
+		legacy:       legacy,
+	}
 }
 
 func TestDoguStatusUpdater_UpdateHealthConfigMap(t *testing.T) {
+	doguResource := &v2.Dogu{ObjectMeta: metav1api.ObjectMeta{Name: "ldap", Namespace: testNamespace, UID: "ldap-uid"}}
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1api.ObjectMeta{
 			Name:      "ldap",
@@ -44,150 +68,232 @@ func TestDoguStatusUpdater_UpdateHealthConfigMap(t *testing.T) {
 			},
 		},
 	}
-	testCM := &corev1.ConfigMap{}
-	started := true
-	podList := &corev1.PodList{
-		Items: []corev1.Pod{
-			{
-				Status: corev1.PodStatus{
-					ContainerStatuses: []corev1.ContainerStatus{{
-						Started: &started,
-					}},
-				},
-			},
-		},
+	newPodList := func(started bool) *corev1.PodList {
+		return &corev1.PodList{Items: []corev1.Pod{{
+			Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{Started: &started}}},
+		}}}
+	}
+	newHealthCM := func(state string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1api.ObjectMeta{Name: "ldap-health", Labels: map[string]string{"dogu.name": "ldap"}},
+			Data:       map[string]string{"ldap": state},
+		}
 	}
+	listOptions := metav1api.ListOptions{LabelSelector: metav1api.FormatLabelSelector(deployment.Spec.Selector)}
+	stateDogu := &core.Dogu{HealthChecks: []core.HealthCheck{{Type: "state"}}}
+	notFoundErr := errors.NewNotFound(schema.GroupResource{}, "")
 
 	t.Run("should succeed to update health config map", func(t *testing.T) {
 		// given
 		podClientMock := newMockPodInterface(t)
+		podClientMock.EXPECT().List(testCtx, listOptions).Return(newPodList(true), nil)
 		cmClientMock := newMockConfigMapInterface(t)
-
-		cmClientMock.EXPECT().Get(testCtx, healthConfigMapName, metav1api.GetOptions{}).Return(testCM, nil)
-		cmClientMock.EXPECT().Update(testCtx, testCM, metav1api.UpdateOptions{}).Return(testCM, nil)
-
-		podClientMock.EXPECT().List(testCtx, metav1api.ListOptions{
-			LabelSelector: metav1api.FormatLabelSelector(deployment.Spec.Selector),
-		}).Return(podList, nil)
-
-		doguJson := &core.Dogu{
-			HealthChecks: []core.HealthCheck{{
-				Type: "state",
-			}},
-		}
-		sut := &DoguStatusUpdater{podInterface: podClientMock, configMapInterface: cmClientMock}
+		cmClientMock.EXPECT().Get(testCtx, "ldap-health", metav1api.GetOptions{}).Return(newHealthCM(""), nil)
+		cmClientMock.EXPECT().Update(testCtx, newHealthCM("ready"), metav1api.UpdateOptions{}).Return(newHealthCM("ready"), nil)
+		sut := newTestDoguStatusUpdater(cmClientMock, podClientMock)
 
 		// when
-		err := sut.UpdateHealthConfigMap(testCtx, deployment, doguJson)
+		err := sut.UpdateHealthConfigMap(testCtx, doguResource, deployment, stateDogu)
 
 		// then
 		require.NoError(t, err)
-		assert.Equal(t, "ready", testCM.Data["ldap"])
 	})
 	t.Run("should succeed to update health config map with custom state", func(t *testing.T) {
 		// given
-		testCM.Data = make(map[string]string)
-		testCM.Data["ldap"] = "ready"
-
 		podClientMock := newMockPodInterface(t)
+		podClientMock.EXPECT().List(testCtx, listOptions).Return(newPodList(true), nil)
 		cmClientMock := newMockConfigMapInterface(t)
+		cmClientMock.EXPECT().Get(testCtx, "ldap-health", metav1api.GetOptions{}).Return(newHealthCM("ready"), nil)
+		cmClientMock.EXPECT().Update(testCtx, newHealthCM("customReady123"), metav1api.UpdateOptions{}).Return(newHealthCM("customReady123"), nil)
+		doguJson := &core.Dogu{HealthChecks: []core.HealthCheck{{Type: "state", State: "customReady123"}}}
+		sut := newTestDoguStatusUpdater(cmClientMock, podClientMock)
 
-		cmClientMock.EXPECT().Get(testCtx, healthConfigMapName, metav1api.GetOptions{}).Return(testCM, nil)
-		cmClientMock.EXPECT().Update(testCtx, testCM, metav1api.UpdateOptions{}).Return(testCM, nil)
-
-		podClientMock.EXPECT().List(testCtx, metav1api.ListOptions{
-			LabelSelector: metav1api.FormatLabelSelector(deployment.Spec.Selector),
-		}).Return(podList, nil)
+		// when
+		err := sut.UpdateHealthConfigMap(testCtx, doguResource, deployment, doguJson)
 
-		doguJson := &core.Dogu{
-			HealthChecks: []core.HealthCheck{{
-				Type:  "state",
-				State: "customReady123",
-			}},
-		}
-		sut := &DoguStatusUpdater{podInterface: podClientMock, configMapInterface: cmClientMock}
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should not update health config map if state did not change", func(t *testing.T) {
+		// given
+		podClientMock := newMockPodInterface(t)
+		podClientMock.EXPECT().List(testCtx, listOptions).Return(newPodList(true), nil)
+		cmClientMock := newMockConfigMapInterface(t)
+		cmClientMock.EXPECT().Get(testCtx, "ldap-health", metav1api.GetOptions{}).Return(newHealthCM("ready"), nil)
+		sut := newTestDoguStatusUpdater(cmClientMock, podClientMock)
 
 		// when
-		err := sut.UpdateHealthConfigMap(testCtx, deployment, doguJson)
+		err := sut.UpdateHealthConfigMap(testCtx, doguResource, deployment, stateDogu)
 
 		// then
 		require.NoError(t, err)
-		assert.Equal(t, "customReady123", testCM.Data["ldap"])
 	})
 	t.Run("should remove health state from config map if not started", func(t *testing.T) {
 		// given
-		testCM.Data = make(map[string]string)
-		testCM.Data["ldap"] = "ready"
-		started = false
-		podList.Items[0].Status.ContainerStatuses[0].Started = &started
-
 		podClientMock := newMockPodInterface(t)
+		podClientMock.EXPECT().List(testCtx, listOptions).Return(newPodList(false), nil)
 		cmClientMock := newMockConfigMapInterface(t)
+		cmClientMock.EXPECT().Get(testCtx, "ldap-health", metav1api.GetOptions{}).Return(newHealthCM("ready"), nil)
+		cmClientMock.EXPECT().Update(testCtx, newHealthCM(""), metav1api.UpdateOptions{}).Return(newHealthCM(""), nil)
+		sut := newTestDoguStatusUpdater(cmClientMock, podClientMock)
 
-		cmClientMock.EXPECT().Get(testCtx, healthConfigMapName, metav1api.GetOptions{}).Return(testCM, nil)
-		cmClientMock.EXPECT().Update(testCtx, testCM, metav1api.UpdateOptions{}).Return(testCM, nil)
+		// when
+		err := sut.UpdateHealthConfigMap(testCtx, doguResource, deployment, stateDogu)
 
-		podClientMock.EXPECT().List(testCtx, metav1api.ListOptions{
-			LabelSelector: metav1api.FormatLabelSelector(deployment.Spec.Selector),
-		}).Return(podList, nil)
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should remove existing state if no healthcheck of type state", func(t *testing.T) {
+		// given
+		podClientMock := newMockPodInterface(t)
+		podClientMock.EXPECT().List(testCtx, listOptions).Return(newPodList(true), nil)
+		cmClientMock := newMockConfigMapInterface(t)
+		cmClientMock.EXPECT().Get(testCtx, "ldap-health", metav1api.GetOptions{}).Return(newHealthCM("ready"), nil)
+		cmClientMock.EXPECT().Update(testCtx, newHealthCM(""), metav1api.UpdateOptions{}).Return(newHealthCM(""), nil)
+		doguJson := &core.Dogu{HealthChecks: []core.HealthCheck{{Type: "tcp"}}}
+		sut := newTestDoguStatusUpdater(cmClientMock, podClientMock)
 
-		doguJson := &core.Dogu{
-			HealthChecks: []core.HealthCheck{{
-				Type: "state",
-			}},
-		}
-		sut := &DoguStatusUpdater{podInterface: podClientMock, configMapInterface: cmClientMock}
+		// when
+		err := sut.UpdateHealthConfigMap(testCtx, doguResource, deployment, doguJson)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should create missing health config map with owner reference to the dogu", func(t *testing.T) {
+		// given
+		podClientMock := newMockPodInterface(t)
+		podClientMock.EXPECT().List(testCtx, listOptions).Return(newPodList(true), nil)
+		cmClientMock := newMockConfigMapInterface(t)
+		cmClientMock.EXPECT().Get(testCtx, "ldap-health", metav1api.GetOptions{}).Return(nil, notFoundErr)
+		cmClientMock.EXPECT().Create(testCtx, mock.Anything, metav1api.CreateOptions{}).Run(func(ctx context.Context, cm *corev1.ConfigMap, opts metav1api.CreateOptions) {
+			assert.Equal(t, "ldap-health", cm.Name)
+			assert.Equal(t, map[string]string{"ldap": "ready"}, cm.Data)
+			assert.Equal(t, "true", cm.Labels[HealthStateLabel])
+			assert.Equal(t, "ldap", cm.Labels["dogu.name"])
+			require.Len(t, cm.OwnerReferences, 1)
+			assert.Equal(t, "ldap", cm.OwnerReferences[0].Name)
+			assert.Equal(t, types.UID("ldap-uid"), cm.OwnerReferences[0].UID)
+		}).Return(newHealthCM("ready"), nil)
+		sut := newTestDoguStatusUpdater(cmClientMock, podClientMock)
 
 		// when
-		err := sut.UpdateHealthConfigMap(testCtx, deployment, doguJson)
+		err := sut.UpdateHealthConfigMap(testCtx, doguResource, deployment, stateDogu)
 
 		// then
 		require.NoError(t, err)
-		assert.Empty(t, testCM.Data["ldap"])
 	})
-	t.Run("should do remove existing state if no healthcheck of type state", func(t *testing.T) {
+	t.Run("should fail to create missing health config map", func(t *testing.T) {
 		// given
-		testCM.Data = make(map[string]string)
-		testCM.Data["ldap"] = "ready"
+		podClientMock := newMockPodInterface(t)
+		podClientMock.EXPECT().List(testCtx, listOptions).Return(newPodList(true), nil)
+		cmClientMock := newMockConfigMapInterface(t)
+		cmClientMock.EXPECT().Get(testCtx, "ldap-health", metav1api.GetOptions{}).Return(nil, notFoundErr)
+		cmClientMock.EXPECT().Create(testCtx, mock.Anything, metav1api.CreateOptions{}).Return(nil, assert.AnError)
+		sut := newTestDoguStatusUpdater(cmClientMock, podClientMock)
 
+		// when
+		err := sut.UpdateHealthConfigMap(testCtx, doguResource, deployment, stateDogu)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to update health state in health configMap")
+	})
+	t.Run("should fail to get health config map", func(t *testing.T) {
+		// given
 		podClientMock := newMockPodInterface(t)
+		podClientMock.EXPECT().List(testCtx, listOptions).Return(newPodList(true), nil)
 		cmClientMock := newMockConfigMapInterface(t)
+		cmClientMock.EXPECT().Get(testCtx, "ldap-health", metav1api.GetOptions{}).Return(nil, assert.AnError)
+		sut := newTestDoguStatusUpdater(cmClientMock, podClientMock)
+
+		// when
+		err := sut.UpdateHealthConfigMap(testCtx, doguResource, deployment, stateDogu)
 
-		cmClientMock.EXPECT().Get(testCtx, healthConfigMapName, metav1api.GetOptions{}).Return(testCM, nil)
-		cmClientMock.EXPECT().Update(testCtx, testCM, metav1api.UpdateOptions{}).Return(testCM, nil)
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get health configMap")
+	})
+	t.Run("should write state into legacy health config map while the dogu mounts it", func(t *testing.T) {
+		// given
+		mountingDeployment := deployment.DeepCopy()
+		mountingDeployment.Spec.Template.Spec.Volumes = legacyHealthVolumes
+		podClientMock := newMockPodInterface(t)
+		podClientMock.EXPECT().List(testCtx, listOptions).Return(newPodList(true), nil)
+		cmClientMock := newMockConfigMapInterface(t)
+		cmClientMock.EXPECT().Get(testCtx, "ldap-health", metav1api.GetOptions{}).Return(newHealthCM("ready"), nil)
+		cmClientMock.EXPECT().Get(testCtx, legacyHealthConfigMapName, metav1api.GetOptions{}).
+			Return(newLegacyHealthCM(map[string]string{"cas": "ready"}), nil)
+		cmClientMock.EXPECT().Update(testCtx, newLegacyHealthCM(map[string]string{"cas": "ready", "ldap": "ready"}), metav1api.UpdateOptions{}).
+			Return(nil, nil)
+		sut := newTestDoguStatusUpdater(cmClientMock, podClientMock, "ldap")
 
-		podClientMock.EXPECT().List(testCtx, metav1api.ListOptions{
-			LabelSelector: metav1api.FormatLabelSelector(deployment.Spec.Selector),
-		}).Return(podList, nil)
+		// when
+		err := sut.UpdateHealthConfigMap(testCtx, doguResource, mountingDeployment, stateDogu)
 
-		doguJson := &core.Dogu{
-			HealthChecks: []core.HealthCheck{{
-				Type: "tcp",
-			}},
-		}
-		sut := &DoguStatusUpdater{podInterface: podClientMock, configMapInterface: cmClientMock}
+		// then
+		require.NoError(t, err)
+		assert.True(t, sut.legacy.mountingDogus[testNamespace]["ldap"])
+	})
+	t.Run("should keep writing the legacy health config map while pods of the dogu mount it", func(t *testing.T) {
+		// given
+		pods := newPodList(true)
+		pods.Items[0].Spec.Volumes = legacyHealthVolumes
+		podClientMock := newMockPodInterface(t)
+		podClientMock.EXPECT().List(testCtx, listOptions).Return(pods, nil)
+		cmClientMock := newMockConfigMapInterface(t)
+		cmClientMock.EXPECT().Get(testCtx, "ldap-health", metav1api.GetOptions{}).Return(newHealthCM("ready"), nil)
+		cmClientMock.EXPECT().Get(testCtx, legacyHealthConfigMapName, metav1api.GetOptions{}).
+			Return(newLegacyHealthCM(map[string]string{"ldap": "ready"}), nil)
+		sut := newTestDoguStatusUpdater(cmClientMock, podClientMock, "ldap")
 
 		// when
-		err := sut.UpdateHealthConfigMap(testCtx, deployment, doguJson)
+		err := sut.UpdateHealthConfigMap(testCtx, doguResource, deployment, stateDogu)
 
 		// then
 		require.NoError(t, err)
-		assert.Empty(t, testCM.Data["ldap"])
+		assert.True(t, sut.legacy.mountingDogus[testNamespace]["ldap"])
 	})
+	t.Run("should delete legacy health config map once the last dogu does not mount it anymore", func(t *testing.T) {
+		// given
+		podClientMock := newMockPodInterface(t)
+		podClientMock.EXPECT().List(testCtx, listOptions).Return(newPodList(true), nil)
+		cmClientMock := newMockConfigMapInterface(t)
+		cmClientMock.EXPECT().Get(testCtx, "ldap-health", metav1api.GetOptions{}).Return(newHealthCM("ready"), nil)
+		cmClientMock.EXPECT().Delete(testCtx, legacyHealthConfigMapName, metav1api.DeleteOptions{}).Return(nil)
+		sut := newTestDoguStatusUpdater(cmClientMock, podClientMock, "ldap")
 
-	t.Run("should throw error if not able to get pod list of deployment", func(t *testing.T) {
+		// when
+		err := sut.UpdateHealthConfigMap(testCtx, doguResource, deployment, stateDogu)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, sut.legacy.mountingDogus[testNamespace])
+	})
+	t.Run("should throw error if not able to get legacy health config map", func(t *testing.T) {
 		// given
 		podClientMock := newMockPodInterface(t)
+		podClientMock.EXPECT().List(testCtx, listOptions).Return(newPodList(true), nil)
 		cmClientMock := newMockConfigMapInterface(t)
+		cmClientMock.EXPECT().Get(testCtx, "ldap-health", metav1api.GetOptions{}).Return(newHealthCM("ready"), nil)
+		cmClientMock.EXPECT().Get(testCtx, legacyHealthConfigMapName, metav1api.GetOptions{}).Return(nil, assert.AnError)
+		sut := newTestDoguStatusUpdater(cmClientMock, podClientMock, "ldap", "cas")
 
-		podClientMock.EXPECT().List(testCtx, metav1api.ListOptions{
-			LabelSelector: metav1api.FormatLabelSelector(deployment.Spec.Selector),
-		}).Return(nil, assert.AnError)
+		// when
+		err := sut.UpdateHealthConfigMap(testCtx, doguResource, deployment, stateDogu)
 
-		sut := &DoguStatusUpdater{podInterface: podClientMock, configMapInterface: cmClientMock}
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to update dogu \"ldap\" in legacy health configMap")
+	})
+	t.Run("should throw error if not able to get pod list of deployment", func(t *testing.T) {
+		// given
+		podClientMock := newMockPodInterface(t)
+		podClientMock.EXPECT().List(testCtx, listOptions).Return(nil, assert.AnError)
+		sut := newTestDoguStatusUpdater(newMockConfigMapInterface(t), podClientMock)
 
 		// when
-		err := sut.UpdateHealthConfigMap(testCtx, deployment, &core.Dogu{})
+		err := sut.UpdateHealthConfigMap(testCtx, doguResource, deployment, &core.Dogu{})
 
 		// then
 		require.Error(t, err)
@@ -197,24 +303,14 @@ func TestDoguStatusUpdater_UpdateHealthConfigMap(t *testing.T) {
 	t.Run("should throw error if not able to update configmap", func(t *testing.T) {
 		// given
 		podClientMock := newMockPodInterface(t)
+		podClientMock.EXPECT().List(testCtx, listOptions).Return(newPodList(true), nil)
 		cmClientMock := newMockConfigMapInterface(t)
-
-		cmClientMock.EXPECT().Get(testCtx, healthConfigMapName, metav1api.GetOptions{}).Return(testCM, nil)
-		cmClientMock.EXPECT().Update(testCtx, testCM, metav1api.UpdateOptions{}).Return(nil, assert.AnError)
-
-		podClientMock.EXPECT().List(testCtx, metav1api.ListOptions{
-			LabelSelector: metav1api.FormatLabelSelector(deployment.Spec.Selector),
-		}).Return(podList, nil)
-
-		doguJson := &core.Dogu{
-			HealthChecks: []core.HealthCheck{{
-				Type: "state",
-			}},
-		}
-		sut := &DoguStatusUpdater{podInterface: podClientMock, configMapInterface: cmClientMock}
+		cmClientMock.EXPECT().Get(testCtx, "ldap-health", metav1api.GetOptions{}).Return(newHealthCM(""), nil)
+		cmClientMock.EXPECT().Update(testCtx, newHealthCM("ready"), metav1api.UpdateOptions{}).Return(nil, assert.AnError)
+		sut := newTestDoguStatusUpdater(cmClientMock, podClientMock)
 
 		// when
-		err := sut.UpdateHealthConfigMap(testCtx, deployment, doguJson)
+		err := sut.UpdateHealthConfigMap(testCtx, doguResource, deployment, stateDogu)
 
 		// then
 		require.Error(t, err)
@@ -224,181 +320,197 @@ func TestDoguStatusUpdater_UpdateHealthConfigMap(t *testing.T) {
 }
 
 func TestDoguStatusUpdater_DeleteDoguOutOfHealthConfigMap(t *testing.T) {
-	type fields struct {
-		recorderFn           func(t *testing.T) record.EventRecorder
-		configMapInterfaceFn func(t *testing.T) configMapInterface
-		podInterfaceFn       func(t *testing.T) podInterface
-	}
-	tests := []struct {
-		name    string
-		fields  fields
-		dogu    *v2.Dogu
-		wantErr assert.ErrorAssertionFunc
-	}{
-		{
-			name: "should try to delete dogu out of health configmap if it is already deleted",
-			fields: fields{
-				recorderFn: func(t *testing.T) record.EventRecorder {
-					return newMockEventRecorder(t)
-				},
-				configMapInterfaceFn: func(t *testing.T) configMapInterface {
-					mck := newMockConfigMapInterface(t)
-					cm := &corev1.ConfigMap{
-						ObjectMeta: metav1api.ObjectMeta{
-							Name: healthConfigMapName,
-						},
-						Data: map[string]string{
-							"cas":  "",
-							"ldap": "",
-						},
-					}
-					mck.EXPECT().Get(testCtx, healthConfigMapName, metav1api.GetOptions{}).Return(cm, nil)
-					mck.EXPECT().Update(testCtx, cm, metav1api.UpdateOptions{}).Return(cm, nil)
-					return mck
-				},
-				podInterfaceFn: func(t *testing.T) podInterface {
-					return newMockPodInterface(t)
-				},
-			},
-			dogu:    &v2.Dogu{ObjectMeta: metav1api.ObjectMeta{Name: "test"}},
-			wantErr: assert.NoError,
-		},
-		{
-			name: "should delete dogu out of health config map",
-			fields: fields{
-				recorderFn: func(t *testing.T) record.EventRecorder {
-					return newMockEventRecorder(t)
-				},
-				configMapInterfaceFn: func(t *testing.T) configMapInterface {
-					mck := newMockConfigMapInterface(t)
-					getCm := &corev1.ConfigMap{
-						ObjectMeta: metav1api.ObjectMeta{
-							Name: healthConfigMapName,
-						},
-						Data: map[string]string{
-							"cas":  "",
-							"ldap": "",
-							"test": "",
-						},
-					}
-					updatedCm := &corev1.ConfigMap{
-						ObjectMeta: metav1api.ObjectMeta{
-							Name: healthConfigMapName,
-						},
-						Data: map[string]string{
-							"cas":  "",
-							"ldap": "",
-						},
-					}
-					mck.EXPECT().Get(testCtx, healthConfigMapName, metav1api.GetOptions{}).Return(getCm, nil)
-					mck.EXPECT().Update(testCtx, updatedCm, metav1api.UpdateOptions{}).Return(updatedCm, nil)
-					return mck
-				},
-				podInterfaceFn: func(t *testing.T) podInterface {
-					return newMockPodInterface(t)
-				},
-			},
-			dogu:    &v2.Dogu{ObjectMeta: metav1api.ObjectMeta{Name: "test"}},
-			wantErr: assert.NoError,
-		},
-		{
-			name: "should fail to update health config map",
-			fields: fields{
-				recorderFn: func(t *testing.T) record.EventRecorder {
-					return newMockEventRecorder(t)
-				},
-				configMapInterfaceFn: func(t *testing.T) configMapInterface {
-					mck := newMockConfigMapInterface(t)
-					getCm := &corev1.ConfigMap{
-						ObjectMeta: metav1api.ObjectMeta{
-							Name: healthConfigMapName,
-						},
-						Data: map[string]string{
-							"cas":  "",
-							"ldap": "",
-							"test": "",
-						},
-					}
-					updatedCm := &corev1.ConfigMap{
-						ObjectMeta: metav1api.ObjectMeta{
-							Name: healthConfigMapName,
-						},
-						Data: map[string]string{
-							"cas":  "",
-							"ldap": "",
-						},
-					}
-					mck.EXPECT().Get(testCtx, healthConfigMapName, metav1api.GetOptions{}).Return(getCm, nil)
-					mck.EXPECT().Update(testCtx, updatedCm, metav1api.UpdateOptions{}).Return(nil, assert.AnError)
-					return mck
-				},
-				podInterfaceFn: func(t *testing.T) podInterface {
-					return newMockPodInterface(t)
-				},
-			},
-			dogu:    &v2.Dogu{ObjectMeta: metav1api.ObjectMeta{Name: "test"}},
-			wantErr: assert.Error,
-		},
-		{
-			name: "should fail to create health config map if not exists",
-			fields: fields{
-				recorderFn: func(t *testing.T) record.EventRecorder {
-					return newMockEventRecorder(t)
-				},
-				configMapInterfaceFn: func(t *testing.T) configMapInterface {
-					mck := newMockConfigMapInterface(t)
-					createCm := &corev1.ConfigMap{
-						ObjectMeta: metav1api.ObjectMeta{
-							Name: healthConfigMapName,
-						},
-						Data: map[string]string{},
-					}
-					mck.EXPECT().Get(testCtx, healthConfigMapName, metav1api.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, ""))
-					mck.EXPECT().Create(testCtx, createCm, metav1api.CreateOptions{}).Return(createCm, assert.AnError)
-					return mck
-				},
-				podInterfaceFn: func(t *testing.T) podInterface {
-					return newMockPodInterface(t)
-				},
-			},
-			dogu:    &v2.Dogu{ObjectMeta: metav1api.ObjectMeta{Name: "test"}},
-			wantErr: assert.Error,
-		},
-		{
-			name: "should create health config map if not exists",
-			fields: fields{
-				recorderFn: func(t *testing.T) record.EventRecorder {
-					return newMockEventRecorder(t)
-				},
-				configMapInterfaceFn: func(t *testing.T) configMapInterface {
-					mck := newMockConfigMapInterface(t)
-					createCm := &corev1.ConfigMap{
-						ObjectMeta: metav1api.ObjectMeta{
-							Name: healthConfigMapName,
-						},
-						Data: map[string]string{},
-					}
-					mck.EXPECT().Get(testCtx, healthConfigMapName, metav1api.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, ""))
-					mck.EXPECT().Create(testCtx, createCm, metav1api.CreateOptions{}).Return(createCm, nil)
-					mck.EXPECT().Update(testCtx, createCm, metav1api.UpdateOptions{}).Return(createCm, nil)
-					return mck
-				},
-				podInterfaceFn: func(t *testing.T) podInterface {
-					return newMockPodInterface(t)
-				},
-			},
-			dogu:    &v2.Dogu{ObjectMeta: metav1api.ObjectMeta{Name: "test"}},
-			wantErr: assert.NoError,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			dsw := &DoguStatusUpdater{
-				recorder:           tt.fields.recorderFn(t),
-				configMapInterface: tt.fields.configMapInterfaceFn(t),
-				podInterface:       tt.fields.podInterfaceFn(t),
-			}
-			tt.wantErr(t, dsw.DeleteDoguOutOfHealthConfigMap(testCtx, tt.dogu), fmt.Sprintf("DeleteDoguOutOfHealthConfigMap(%v, %v)", testCtx, tt.dogu))
-		})
-	}
+	notFoundErr := errors.NewNotFound(schema.GroupResource{}, "")
+	dogu := &v2.Dogu{ObjectMeta: metav1api.ObjectMeta{Name: "test", Namespace: testNamespace}}
+
+	t.Run("should delete health config map of dogu", func(t *testing.T) {
+		// given
+		cmClientMock := newMockConfigMapInterface(t)
+		cmClientMock.EXPECT().Delete(testCtx, "test-health", metav1api.DeleteOptions{}).Return(nil)
+		sut := newTestDoguStatusUpdater(cmClientMock, newMockPodInterface(t))
+
+		// when
+		err := sut.DeleteDoguOutOfHealthConfigMap(testCtx, dogu)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should ignore missing health config map", func(t *testing.T) {
+		// given
+		cmClientMock := newMockConfigMapInterface(t)
+		cmClientMock.EXPECT().Delete(testCtx, "test-health", metav1api.DeleteOptions{}).Return(notFoundErr)
+		sut := newTestDoguStatusUpdater(cmClientMock, newMockPodInterface(t))
+
+		// when
+		err := sut.DeleteDoguOutOfHealthConfigMap(testCtx, dogu)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail to delete health config map", func(t *testing.T) {
+		// given
+		cmClientMock := newMockConfigMapInterface(t)
+		cmClientMock.EXPECT().Delete(testCtx, "test-health", metav1api.DeleteOptions{}).Return(assert.AnError)
+		sut := newTestDoguStatusUpdater(cmClientMock, newMockPodInterface(t))
+
+		// when
+		err := sut.DeleteDoguOutOfHealthConfigMap(testCtx, dogu)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to delete health configMap of dogu \"test\"")
+	})
+	t.Run("should delete dogu out of legacy health config map", func(t *testing.T) {
+		// given
+		cmClientMock := newMockConfigMapInterface(t)
+		cmClientMock.EXPECT().Delete(testCtx, "test-health", metav1api.DeleteOptions{}).Return(notFoundErr)
+		cmClientMock.EXPECT().Get(testCtx, legacyHealthConfigMapName, metav1api.GetOptions{}).
+			Return(newLegacyHealthCM(map[string]string{"cas": "", "ldap": "", "test": ""}), nil)
+		cmClientMock.EXPECT().Update(testCtx, newLegacyHealthCM(map[string]string{"cas": "", "ldap": ""}), metav1api.UpdateOptions{}).Return(nil, nil)
+		sut := newTestDoguStatusUpdater(cmClientMock, newMockPodInterface(t), "cas", "test")
+
+		// when
+		err := sut.DeleteDoguOutOfHealthConfigMap(testCtx, dogu)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, map[string]bool{"cas": true}, sut.legacy.mountingDogus[testNamespace])
+	})
+	t.Run("should fail to update legacy health config map", func(t *testing.T) {
+		// given
+		cmClientMock := newMockConfigMapInterface(t)
+		cmClientMock.EXPECT().Delete(testCtx, "test-health", metav1api.DeleteOptions{}).Return(nil)
+		cmClientMock.EXPECT().Get(testCtx, legacyHealthConfigMapName, metav1api.GetOptions{}).
+			Return(newLegacyHealthCM(map[string]string{"cas": "", "test": ""}), nil)
+		cmClientMock.EXPECT().Update(testCtx, mock.Anything, metav1api.UpdateOptions{}).Return(nil, assert.AnError)
+		sut := newTestDoguStatusUpdater(cmClientMock, newMockPodInterface(t), "cas")
+
+		// when
+		err := sut.DeleteDoguOutOfHealthConfigMap(testCtx, dogu)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestDoguStatusUpdater_MigrateLegacyHealthConfigMap(t *testing.T) {
+	notFoundErr := errors.NewNotFound(schema.GroupResource{}, "")
+	ldap := v2.Dogu{ObjectMeta: metav1api.ObjectMeta{Name: "ldap", Namespace: testNamespace}}
+	cas := v2.Dogu{ObjectMeta: metav1api.ObjectMeta{Name: "cas", Namespace: testNamespace}}
+	postfix := v2.Dogu{ObjectMeta: metav1api.ObjectMeta{Name: "postfix", Namespace: testNamespace}}
+
+	t.Run("should copy states of dogus without health config map", func(t *testing.T) {
+		// given
+		cmClientMock := newMockConfigMapInterface(t)
+		cmClientMock.EXPECT().Get(testCtx, legacyHealthConfigMapName, metav1api.GetOptions{}).
+			Return(newLegacyHealthCM(map[string]string{"ldap": "ready", "cas": "ready"}), nil)
+		cmClientMock.EXPECT().Get(testCtx, "ldap-health", metav1api.GetOptions{}).Return(nil, notFoundErr).Twice()
+		cmClientMock.EXPECT().Create(testCtx, mock.Anything, metav1api.CreateOptions{}).Run(func(ctx context.Context, cm *corev1.ConfigMap, opts metav1api.CreateOptions) {
+			assert.Equal(t, "ldap-health", cm.Name)
+			assert.Equal(t, map[string]string{"ldap": "ready"}, cm.Data)
+			require.Len(t, cm.OwnerReferences, 1)
+		}).Return(nil, nil)
+		cmClientMock.EXPECT().Get(testCtx, "cas-health", metav1api.GetOptions{}).Return(&corev1.ConfigMap{Data: map[string]string{"cas": ""}}, nil)
+		podClientMock := newMockPodInterface(t)
+		podClientMock.EXPECT().List(testCtx, metav1api.ListOptions{}).Return(&corev1.PodList{}, nil)
+		deploymentClientMock := newMockDeploymentInterface(t)
+		deploymentClientMock.EXPECT().List(testCtx, metav1api.ListOptions{}).Return(&appsv1.DeploymentList{Items: []appsv1.Deployment{
+			{ObjectMeta: metav1api.ObjectMeta{Name: "ldap"}, Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Volumes: legacyHealthVolumes}}}},
+		}}, nil)
+		sut := NewDoguStatusUpdater(nil, cmClientMock, podClientMock, deploymentClientMock, getTestScheme())
+
+		// when
+		err := sut.MigrateLegacyHealthConfigMap(testCtx, testNamespace, []v2.Dogu{ldap, cas, postfix})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, map[string]bool{"ldap": true}, sut.legacy.mountingDogus[testNamespace])
+	})
+	t.Run("should do nothing without legacy health config map", func(t *testing.T) {
+		// given
+		cmClientMock := newMockConfigMapInterface(t)
+		cmClientMock.EXPECT().Get(testCtx, legacyHealthConfigMapName, metav1api.GetOptions{}).Return(nil, notFoundErr)
+		sut := NewDoguStatusUpdater(nil, cmClientMock, newMockPodInterface(t), newMockDeploymentInterface(t), getTestScheme())
+
+		// when
+		err := sut.MigrateLegacyHealthConfigMap(testCtx, testNamespace, []v2.Dogu{ldap})
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, sut.legacy.mountingDogus[testNamespace])
+	})
+	t.Run("should fail to get legacy health config map", func(t *testing.T) {
+		// given
+		cmClientMock := newMockConfigMapInterface(t)
+		cmClientMock.EXPECT().Get(testCtx, legacyHealthConfigMapName, metav1api.GetOptions{}).Return(nil, assert.AnError)
+		sut := NewDoguStatusUpdater(nil, cmClientMock, newMockPodInterface(t), newMockDeploymentInterface(t), getTestScheme())
+
+		// when
+		err := sut.MigrateLegacyHealthConfigMap(testCtx, testNamespace, []v2.Dogu{ldap})
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.NotContains(t, sut.legacy.mountingDogus, testNamespace)
+	})
+	t.Run("should delete legacy health config map after copying the states if no dogu mounts it", func(t *testing.T) {
+		// given
+		cmClientMock := newMockConfigMapInterface(t)
+		cmClientMock.EXPECT().Get(testCtx, legacyHealthConfigMapName, metav1api.GetOptions{}).
+			Return(newLegacyHealthCM(map[string]string{"ldap": "ready"}), nil)
+		cmClientMock.EXPECT().Delete(testCtx, legacyHealthConfigMapName, metav1api.DeleteOptions{}).Return(nil)
+		cmClientMock.EXPECT().Get(testCtx, "ldap-health", metav1api.GetOptions{}).Return(&corev1.ConfigMap{Data: map[string]string{"ldap": "ready"}}, nil)
+		podClientMock := newMockPodInterface(t)
+		podClientMock.EXPECT().List(testCtx, metav1api.ListOptions{}).Return(&corev1.PodList{}, nil)
+		deploymentClientMock := newMockDeploymentInterface(t)
+		deploymentClientMock.EXPECT().List(testCtx, metav1api.ListOptions{}).Return(&appsv1.DeploymentList{}, nil)
+		sut := NewDoguStatusUpdater(nil, cmClientMock, podClientMock, deploymentClientMock, getTestScheme())
+
+		// when
+		err := sut.MigrateLegacyHealthConfigMap(testCtx, testNamespace, []v2.Dogu{ldap})
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, sut.legacy.mountingDogus[testNamespace])
+	})
+	t.Run("should fail to delete legacy health config map which no dogu mounts", func(t *testing.T) {
+		// given
+		cmClientMock := newMockConfigMapInterface(t)
+		cmClientMock.EXPECT().Get(testCtx, legacyHealthConfigMapName, metav1api.GetOptions{}).
+			Return(newLegacyHealthCM(map[string]string{"ldap": "ready"}), nil)
+		cmClientMock.EXPECT().Delete(testCtx, legacyHealthConfigMapName, metav1api.DeleteOptions{}).Return(assert.AnError)
+		podClientMock := newMockPodInterface(t)
+		podClientMock.EXPECT().List(testCtx, metav1api.ListOptions{}).Return(&corev1.PodList{}, nil)
+		deploymentClientMock := newMockDeploymentInterface(t)
+		deploymentClientMock.EXPECT().List(testCtx, metav1api.ListOptions{}).Return(&appsv1.DeploymentList{}, nil)
+		sut := NewDoguStatusUpdater(nil, cmClientMock, podClientMock, deploymentClientMock, getTestScheme())
+
+		// when
+		err := sut.MigrateLegacyHealthConfigMap(testCtx, testNamespace, []v2.Dogu{ldap})
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to delete legacy health configMap")
+	})
+	t.Run("should continue migration if the state of a dogu cannot be copied", func(t *testing.T) {
+		// given
+		cmClientMock := newMockConfigMapInterface(t)
+		cmClientMock.EXPECT().Get(testCtx, legacyHealthConfigMapName, metav1api.GetOptions{}).
+			Return(newLegacyHealthCM(map[string]string{"ldap": "ready", "cas": "ready"}), nil)
+		cmClientMock.EXPECT().Get(testCtx, "ldap-health", metav1api.GetOptions{}).Return(nil, assert.AnError)
+		cmClientMock.EXPECT().Get(testCtx, "cas-health", metav1api.GetOptions{}).Return(nil, notFoundErr).Twice()
+		cmClientMock.EXPECT().Create(testCtx, mock.Anything, metav1api.CreateOptions{}).Return(nil, nil)
+		podClientMock := newMockPodInterface(t)
+		podClientMock.EXPECT().List(testCtx, metav1api.ListOptions{}).Return(&corev1.PodList{}, nil)
+		deploymentClientMock := newMockDeploymentInterface(t)
+		deploymentClientMock.EXPECT().List(testCtx, metav1api.ListOptions{}).Return(&appsv1.DeploymentList{}, nil)
+		cmClientMock.EXPECT().Delete(testCtx, legacyHealthConfigMapName, metav1api.DeleteOptions{}).Return(nil)
+		sut := NewDoguStatusUpdater(nil, cmClientMock, podClientMock, deploymentClientMock, getTestScheme())
+
+		// when
+		err := sut.MigrateLegacyHealthConfigMap(testCtx, testNamespace, []v2.Dogu{ldap, cas})
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get health configMap of dogu \"ldap\"")
+	})
 }
@@ -12,7 +12,9 @@ import (
 	appsv1client "k8s.io/client-go/kubernetes/typed/apps/v1"
 	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 type DeploymentAvailabilityChecker interface {
@@ -21,8 +23,11 @@ type DeploymentAvailabilityChecker interface {
 }
 
 type DoguHealthStatusUpdater interface {
-	UpdateHealthConfigMap(ctx context.Context, deployment *appsv1.Deployment, doguJson *cesappcore.Dogu) error
+	UpdateHealthConfigMap(ctx context.Context, doguResource *v2.Dogu, deployment *appsv1.Deployment, doguJson *cesappcore.Dogu) error
 	DeleteDoguOutOfHealthConfigMap(ctx context.Context, dogu *v2.Dogu) error
+	// MigrateLegacyHealthConfigMap copies the health states of the dogus from the legacy health config map into their
+	// own health config maps.
+	MigrateLegacyHealthConfigMap(ctx context.Context, namespace string, dogus []v2.Dogu) error
 }
 
 type DoguHealthChecker interface {
@@ -30,6 +35,17 @@ type DoguHealthChecker interface {
 	CheckByName(ctx context.Context, doguName types.NamespacedName) error
 	// CheckDependenciesRecursive returns nil if the dogu's mandatory dependencies are up and running.
 	CheckDependenciesRecursive(ctx context.Context, fromDogu *cesappcore.Dogu, namespace string) error
+	// CheckMandatoryDependenciesRecursive returns nil if the dogu's mandatory dependencies and their mandatory
+	// dependencies are up and running. Optional dependencies are not checked.
+	CheckMandatoryDependenciesRecursive(ctx context.Context, fromDogu *cesappcore.Dogu, namespace string) error
+}
+
+// DependentDoguMapper maps a dogu to the reconcile requests of all dogus in its namespace which depend on it directly
+// or transitively via mandatory dependencies. These dogus derive their Degraded condition from the health of the dogu
+// and have to be reconciled again if it changes.
+type DependentDoguMapper interface {
+	// MapDependents returns reconcile requests for all dogus depending on the given dogu.
+	MapDependents(ctx context.Context, obj client.Object) []reconcile.Request
 }
 
 // localDoguFetcher includes functionality to search the local dogu registry for a dogu.
@@ -0,0 +1,178 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/retry-lib/retry"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1api "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// legacyHealthConfigMapName is the name of the config map which contained the health states of all dogus of a
+// namespace before every dogu got its own health config map.
+const legacyHealthConfigMapName = "k8s-dogu-operator-dogu-health"
+
+// legacyHealthConfigMap keeps the legacy health config map up to date as long as dogus still mount it. Pods of dogus
+// whose deployments were not regenerated yet read the health states of their dependencies from it.
+//
+// The dogus mounting the legacy health config map are determined once per namespace by listing all deployments and
+// pods. Afterwards, a dogu is removed from them as soon as neither its deployment nor its pods mount the legacy health
+// config map anymore. The legacy health config map is deleted when the last dogu is removed.
+type legacyHealthConfigMap struct {
+	configMapInterface  configMapInterface
+	podInterface        podInterface
+	deploymentInterface deploymentInterface
+	mutex               sync.Mutex
+	// mountingDogus contains the names of the dogus mounting the legacy health config map per namespace.
+	mountingDogus map[string]map[string]bool
+}
+
+func newLegacyHealthConfigMap(configMapInterface configMapInterface, podInterface podInterface, deploymentInterface deploymentInterface) *legacyHealthConfigMap {
+	return &legacyHealthConfigMap{
+		configMapInterface:  configMapInterface,
+		podInterface:        podInterface,
+		deploymentInterface: deploymentInterface,
+		mountingDogus:       map[string]map[string]bool{},
+	}
+}
+
+// migrate returns the health states of the legacy health config map and determines the dogus mounting it.
+// The legacy health config map is deleted right away if no dogu mounts it, e.g. if a helm upgrade recreated it after
+// the migration. It returns nil if the legacy health config map does not exist.
+func (l *legacyHealthConfigMap) migrate(ctx context.Context, namespace string) (map[string]string, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	legacyConfigMap, err := l.configMapInterface.Get(ctx, legacyHealthConfigMapName, metav1api.GetOptions{})
+	if errors.IsNotFound(err) {
+		l.mountingDogus[namespace] = map[string]bool{}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get legacy health configMap: %w", err)
+	}
+
+	mountingDogus, err := l.mountingDogusOf(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if len(mountingDogus) == 0 {
+		err = l.delete(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return legacyConfigMap.Data, nil
+}
+
+// update sets the entry of the dogu in the legacy health config map to the state; a nil state removes the entry.
+// If the dogu does not mount the legacy health config map anymore, it is not updated for this dogu again.
+func (l *legacyHealthConfigMap) update(ctx context.Context, namespace string, doguName string, state *string, stillMounted bool) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	mountingDogus, err := l.mountingDogusOf(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	if len(mountingDogus) == 0 {
+		return nil
+	}
+
+	if !stillMounted {
+		delete(mountingDogus, doguName)
+	}
+	if len(mountingDogus) == 0 {
+		return l.delete(ctx)
+	}
+
+	err = retry.OnConflict(func() error {
+		legacyConfigMap, err := l.configMapInterface.Get(ctx, legacyHealthConfigMapName, metav1api.GetOptions{})
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get legacy health configMap: %w", err)
+		}
+
+		currentState, found := legacyConfigMap.Data[doguName]
+		switch {
+		case state == nil && !found, state != nil && found && currentState == *state:
+			return nil
+		case state == nil:
+			delete(legacyConfigMap.Data, doguName)
+		default:
+			if legacyConfigMap.Data == nil {
+				legacyConfigMap.Data = map[string]string{}
+			}
+			legacyConfigMap.Data[doguName] = *state
+		}
+
+		_, err = l.configMapInterface.Update(ctx, legacyConfigMap, metav1api.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update dogu %q in legacy health configMap: %w", doguName, err)
+	}
+
+	return nil
+}
+
+func (l *legacyHealthConfigMap) delete(ctx context.Context) error {
+	err := l.configMapInterface.Delete(ctx, legacyHealthConfigMapName, metav1api.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete legacy health configMap: %w", err)
+	}
+	log.FromContext(ctx).Info("Deleted legacy health configMap because no dogu mounts it anymore", "configMap", legacyHealthConfigMapName)
+
+	return nil
+}
+
+// mountingDogusOf returns the dogus of the namespace which mount the legacy health config map. They are only listed
+// the first time. Pods are checked as well, because pods of a previous revision of a regenerated deployment may still
+// be running. The mutex has to be held by the caller.
+func (l *legacyHealthConfigMap) mountingDogusOf(ctx context.Context, namespace string) (map[string]bool, error) {
+	if mountingDogus, found := l.mountingDogus[namespace]; found {
+		return mountingDogus, nil
+	}
+
+	mountingDogus := map[string]bool{}
+	deployments, err := l.deploymentInterface.List(ctx, metav1api.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for _, deployment := range deployments.Items {
+		if mountsLegacyHealthConfigMap(deployment.Spec.Template.Spec.Volumes) {
+			mountingDogus[deployment.Name] = true
+		}
+	}
+
+	pods, err := l.podInterface.List(ctx, metav1api.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	for _, pod := range pods.Items {
+		if doguName := pod.Labels[v2.DoguLabelName]; doguName != "" && mountsLegacyHealthConfigMap(pod.Spec.Volumes) {
+			mountingDogus[doguName] = true
+		}
+	}
+
+	l.mountingDogus[namespace] = mountingDogus
+	return mountingDogus, nil
+}
+
+func mountsLegacyHealthConfigMap(volumes []v1.Volume) bool {
+	for _, volume := range volumes {
+		if volume.ConfigMap != nil && volume.ConfigMap.Name == legacyHealthConfigMapName {
+			return true
+		}
+	}
+
+	return false
+}
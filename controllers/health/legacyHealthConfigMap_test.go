@@ -0,0 +1,128 @@
+package health
+
+import (
+	"testing"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1api "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var legacyHealthVolumes = []corev1.Volume{{
+	Name: "dogu-health",
+	VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+		LocalObjectReference: corev1.LocalObjectReference{Name: legacyHealthConfigMapName},
+	}},
+}}
+
+func newLegacyHealthCM(data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1api.ObjectMeta{Name: legacyHealthConfigMapName},
+		Data:       data,
+	}
+}
+
+func Test_legacyHealthConfigMap_mountingDogusOf(t *testing.T) {
+	t.Run("should list deployments and pods mounting the legacy health config map only once", func(t *testing.T) {
+		// given
+		deploymentClientMock := newMockDeploymentInterface(t)
+		deploymentClientMock.EXPECT().List(testCtx, metav1api.ListOptions{}).Return(&appsv1.DeploymentList{Items: []appsv1.Deployment{
+			{ObjectMeta: metav1api.ObjectMeta{Name: "ldap"}, Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Volumes: legacyHealthVolumes}}}},
+			{ObjectMeta: metav1api.ObjectMeta{Name: "cas"}},
+		}}, nil).Once()
+		podClientMock := newMockPodInterface(t)
+		podClientMock.EXPECT().List(testCtx, metav1api.ListOptions{}).Return(&corev1.PodList{Items: []corev1.Pod{
+			{ObjectMeta: metav1api.ObjectMeta{Labels: map[string]string{v2.DoguLabelName: "postfix"}}, Spec: corev1.PodSpec{Volumes: legacyHealthVolumes}},
+			{ObjectMeta: metav1api.ObjectMeta{Labels: map[string]string{v2.DoguLabelName: "cas"}}},
+			{Spec: corev1.PodSpec{Volumes: legacyHealthVolumes}},
+		}}, nil).Once()
+		sut := newLegacyHealthConfigMap(newMockConfigMapInterface(t), podClientMock, deploymentClientMock)
+
+		// when
+		first, err := sut.mountingDogusOf(testCtx, testNamespace)
+		require.NoError(t, err)
+		second, err := sut.mountingDogusOf(testCtx, testNamespace)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, map[string]bool{"ldap": true, "postfix": true}, first)
+		assert.Equal(t, first, second)
+	})
+	t.Run("should fail to list deployments", func(t *testing.T) {
+		// given
+		deploymentClientMock := newMockDeploymentInterface(t)
+		deploymentClientMock.EXPECT().List(testCtx, metav1api.ListOptions{}).Return(nil, assert.AnError)
+		sut := newLegacyHealthConfigMap(newMockConfigMapInterface(t), newMockPodInterface(t), deploymentClientMock)
+
+		// when
+		_, err := sut.mountingDogusOf(testCtx, testNamespace)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to list deployments")
+		assert.NotContains(t, sut.mountingDogus, testNamespace)
+	})
+	t.Run("should fail to list pods", func(t *testing.T) {
+		// given
+		deploymentClientMock := newMockDeploymentInterface(t)
+		deploymentClientMock.EXPECT().List(testCtx, metav1api.ListOptions{}).Return(&appsv1.DeploymentList{}, nil)
+		podClientMock := newMockPodInterface(t)
+		podClientMock.EXPECT().List(testCtx, metav1api.ListOptions{}).Return(nil, assert.AnError)
+		sut := newLegacyHealthConfigMap(newMockConfigMapInterface(t), podClientMock, deploymentClientMock)
+
+		// when
+		_, err := sut.mountingDogusOf(testCtx, testNamespace)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to list pods")
+	})
+}
+
+func Test_legacyHealthConfigMap_update(t *testing.T) {
+	state := "ready"
+
+	t.Run("should not touch the legacy health config map if no dogu mounts it", func(t *testing.T) {
+		// given
+		sut := newLegacyHealthConfigMap(newMockConfigMapInterface(t), newMockPodInterface(t), newMockDeploymentInterface(t))
+		sut.mountingDogus[testNamespace] = map[string]bool{}
+
+		// when
+		err := sut.update(testCtx, testNamespace, "ldap", &state, true)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should ignore a missing legacy health config map", func(t *testing.T) {
+		// given
+		cmClientMock := newMockConfigMapInterface(t)
+		cmClientMock.EXPECT().Get(testCtx, legacyHealthConfigMapName, metav1api.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, legacyHealthConfigMapName))
+		sut := newLegacyHealthConfigMap(cmClientMock, newMockPodInterface(t), newMockDeploymentInterface(t))
+		sut.mountingDogus[testNamespace] = map[string]bool{"ldap": true}
+
+		// when
+		err := sut.update(testCtx, testNamespace, "ldap", &state, true)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail to delete the legacy health config map", func(t *testing.T) {
+		// given
+		cmClientMock := newMockConfigMapInterface(t)
+		cmClientMock.EXPECT().Delete(testCtx, legacyHealthConfigMapName, metav1api.DeleteOptions{}).Return(assert.AnError)
+		sut := newLegacyHealthConfigMap(cmClientMock, newMockPodInterface(t), newMockDeploymentInterface(t))
+		sut.mountingDogus[testNamespace] = map[string]bool{"ldap": true}
+
+		// when
+		err := sut.update(testCtx, testNamespace, "ldap", &state, false)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to delete legacy health configMap")
+	})
+}
@@ -6,6 +6,7 @@ import (
 	context "context"
 
 	core "github.com/cloudogu/cesapp-lib/core"
+
 	mock "github.com/stretchr/testify/mock"
 
 	v1 "k8s.io/api/apps/v1"
@@ -73,17 +74,65 @@ func (_c *MockDoguHealthStatusUpdater_DeleteDoguOutOfHealthConfigMap_Call) RunAn
 	return _c
 }
 
-// UpdateHealthConfigMap provides a mock function with given fields: ctx, deployment, doguJson
-func (_m *MockDoguHealthStatusUpdater) UpdateHealthConfigMap(ctx context.Context, deployment *v1.Deployment, doguJson *core.Dogu) error {
-	ret := _m.Called(ctx, deployment, doguJson)
+// MigrateLegacyHealthConfigMap provides a mock function with given fields: ctx, namespace, dogus
+func (_m *MockDoguHealthStatusUpdater) MigrateLegacyHealthConfigMap(ctx context.Context, namespace string, dogus []v2.Dogu) error {
+	ret := _m.Called(ctx, namespace, dogus)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MigrateLegacyHealthConfigMap")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []v2.Dogu) error); ok {
+		r0 = rf(ctx, namespace, dogus)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDoguHealthStatusUpdater_MigrateLegacyHealthConfigMap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MigrateLegacyHealthConfigMap'
+type MockDoguHealthStatusUpdater_MigrateLegacyHealthConfigMap_Call struct {
+	*mock.Call
+}
+
+// MigrateLegacyHealthConfigMap is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - dogus []v2.Dogu
+func (_e *MockDoguHealthStatusUpdater_Expecter) MigrateLegacyHealthConfigMap(ctx interface{}, namespace interface{}, dogus interface{}) *MockDoguHealthStatusUpdater_MigrateLegacyHealthConfigMap_Call {
+	return &MockDoguHealthStatusUpdater_MigrateLegacyHealthConfigMap_Call{Call: _e.mock.On("MigrateLegacyHealthConfigMap", ctx, namespace, dogus)}
+}
+
+func (_c *MockDoguHealthStatusUpdater_MigrateLegacyHealthConfigMap_Call) Run(run func(ctx context.Context, namespace string, dogus []v2.Dogu)) *MockDoguHealthStatusUpdater_MigrateLegacyHealthConfigMap_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]v2.Dogu))
+	})
+	return _c
+}
+
+func (_c *MockDoguHealthStatusUpdater_MigrateLegacyHealthConfigMap_Call) Return(_a0 error) *MockDoguHealthStatusUpdater_MigrateLegacyHealthConfigMap_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDoguHealthStatusUpdater_MigrateLegacyHealthConfigMap_Call) RunAndReturn(run func(context.Context, string, []v2.Dogu) error) *MockDoguHealthStatusUpdater_MigrateLegacyHealthConfigMap_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateHealthConfigMap provides a mock function with given fields: ctx, doguResource, deployment, doguJson
+func (_m *MockDoguHealthStatusUpdater) UpdateHealthConfigMap(ctx context.Context, doguResource *v2.Dogu, deployment *v1.Deployment, doguJson *core.Dogu) error {
+	ret := _m.Called(ctx, doguResource, deployment, doguJson)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateHealthConfigMap")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *v1.Deployment, *core.Dogu) error); ok {
-		r0 = rf(ctx, deployment, doguJson)
+	if rf, ok := ret.Get(0).(func(context.Context, *v2.Dogu, *v1.Deployment, *core.Dogu) error); ok {
+		r0 = rf(ctx, doguResource, deployment, doguJson)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -98,15 +147,16 @@ type MockDoguHealthStatusUpdater_UpdateHealthConfigMap_Call struct {
 
 // UpdateHealthConfigMap is a helper method to define mock.On call
 //   - ctx context.Context
+//   - doguResource *v2.Dogu
 //   - deployment *v1.Deployment
 //   - doguJson *core.Dogu
-func (_e *MockDoguHealthStatusUpdater_Expecter) UpdateHealthConfigMap(ctx interface{}, deployment interface{}, doguJson interface{}) *MockDoguHealthStatusUpdater_UpdateHealthConfigMap_Call {
-	return &MockDoguHealthStatusUpdater_UpdateHealthConfigMap_Call{Call: _e.mock.On("UpdateHealthConfigMap", ctx, deployment, doguJson)}
+func (_e *MockDoguHealthStatusUpdater_Expecter) UpdateHealthConfigMap(ctx interface{}, doguResource interface{}, deployment interface{}, doguJson interface{}) *MockDoguHealthStatusUpdater_UpdateHealthConfigMap_Call {
+	return &MockDoguHealthStatusUpdater_UpdateHealthConfigMap_Call{Call: _e.mock.On("UpdateHealthConfigMap", ctx, doguResource, deployment, doguJson)}
 }
 
-func (_c *MockDoguHealthStatusUpdater_UpdateHealthConfigMap_Call) Run(run func(ctx context.Context, deployment *v1.Deployment, doguJson *core.Dogu)) *MockDoguHealthStatusUpdater_UpdateHealthConfigMap_Call {
+func (_c *MockDoguHealthStatusUpdater_UpdateHealthConfigMap_Call) Run(run func(ctx context.Context, doguResource *v2.Dogu, deployment *v1.Deployment, doguJson *core.Dogu)) *MockDoguHealthStatusUpdater_UpdateHealthConfigMap_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*v1.Deployment), args[2].(*core.Dogu))
+		run(args[0].(context.Context), args[1].(*v2.Dogu), args[2].(*v1.Deployment), args[3].(*core.Dogu))
 	})
 	return _c
 }
@@ -116,7 +166,7 @@ func (_c *MockDoguHealthStatusUpdater_UpdateHealthConfigMap_Call) Return(_a0 err
 	return _c
 }
 
-func (_c *MockDoguHealthStatusUpdater_UpdateHealthConfigMap_Call) RunAndReturn(run func(context.Context, *v1.Deployment, *core.Dogu) error) *MockDoguHealthStatusUpdater_UpdateHealthConfigMap_Call {
+func (_c *MockDoguHealthStatusUpdater_UpdateHealthConfigMap_Call) RunAndReturn(run func(context.Context, *v2.Dogu, *v1.Deployment, *core.Dogu) error) *MockDoguHealthStatusUpdater_UpdateHealthConfigMap_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -5,9 +5,10 @@ package health
 import (
 	context "context"
 
-	dogu "github.com/cloudogu/ces-commons-lib/dogu"
 	core "github.com/cloudogu/cesapp-lib/core"
 
+	dogu "github.com/cloudogu/ces-commons-lib/dogu"
+
 	mock "github.com/stretchr/testify/mock"
 
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
@@ -83,6 +84,64 @@ func (_c *mockLocalDoguFetcher_Enabled_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// FetchAllInstalled provides a mock function with given fields: ctx
+func (_m *mockLocalDoguFetcher) FetchAllInstalled(ctx context.Context) ([]*core.Dogu, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllInstalled")
+	}
+
+	var r0 []*core.Dogu
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*core.Dogu, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*core.Dogu); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Dogu)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockLocalDoguFetcher_FetchAllInstalled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchAllInstalled'
+type mockLocalDoguFetcher_FetchAllInstalled_Call struct {
+	*mock.Call
+}
+
+// FetchAllInstalled is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *mockLocalDoguFetcher_Expecter) FetchAllInstalled(ctx interface{}) *mockLocalDoguFetcher_FetchAllInstalled_Call {
+	return &mockLocalDoguFetcher_FetchAllInstalled_Call{Call: _e.mock.On("FetchAllInstalled", ctx)}
+}
+
+func (_c *mockLocalDoguFetcher_FetchAllInstalled_Call) Run(run func(ctx context.Context)) *mockLocalDoguFetcher_FetchAllInstalled_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *mockLocalDoguFetcher_FetchAllInstalled_Call) Return(_a0 []*core.Dogu, _a1 error) *mockLocalDoguFetcher_FetchAllInstalled_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockLocalDoguFetcher_FetchAllInstalled_Call) RunAndReturn(run func(context.Context) ([]*core.Dogu, error)) *mockLocalDoguFetcher_FetchAllInstalled_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FetchForResource provides a mock function with given fields: ctx, doguResource
 func (_m *mockLocalDoguFetcher) FetchForResource(ctx context.Context, doguResource *v2.Dogu) (*core.Dogu, error) {
 	ret := _m.Called(ctx, doguResource)
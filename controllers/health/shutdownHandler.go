@@ -7,6 +7,8 @@ import (
 
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/namespaced"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -14,11 +16,12 @@ import (
 
 // ShutdownHandler is responsible for setting health states to unknown on shutdown of the operator.
 type ShutdownHandler struct {
-	doguInterface doguClient.DoguInterface
+	doguInterface   doguClient.DoguInterface
+	watchNamespaces []string
 }
 
-func NewShutdownHandler(doguInterface doguClient.DoguInterface) *ShutdownHandler {
-	return &ShutdownHandler{doguInterface: doguInterface}
+func NewShutdownHandler(doguInterface doguClient.DoguInterface, operatorConfig *config.OperatorConfig) *ShutdownHandler {
+	return &ShutdownHandler{doguInterface: doguInterface, watchNamespaces: operatorConfig.WatchNamespaces}
 }
 
 // Handle waits for the context to be cancelled and then sets health states to unknown.
@@ -26,14 +29,18 @@ func (s *ShutdownHandler) Handle(ctx context.Context) error {
 	logger := log.FromContext(ctx).WithName("health shutdown handler")
 	logger.Info("shutdown detected, handling health status")
 
-	dogus, err := s.doguInterface.List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return err
+	var dogus []v2.Dogu
+	for _, namespace := range s.watchNamespaces {
+		list, err := s.doguInterface.List(namespaced.WithNamespace(ctx, namespace), metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		dogus = append(dogus, list.Items...)
 	}
 
 	var errs []error
-	for _, dogu := range dogus.Items {
-		_, updateErr := s.doguInterface.UpdateStatusWithRetry(ctx, &dogu, func(status v2.DoguStatus) v2.DoguStatus {
+	for _, dogu := range dogus {
+		_, updateErr := s.doguInterface.UpdateStatusWithRetry(namespaced.WithNamespace(ctx, dogu.Namespace), &dogu, func(status v2.DoguStatus) v2.DoguStatus {
 			status.Health = v2.UnknownHealthStatus
 			reason := "StoppingOperator"
 			message := "The operator is shutting down"
@@ -7,6 +7,8 @@ import (
 
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	"github.com/cloudogu/k8s-dogu-lib/v2/client"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/namespaced"
 	"github.com/onsi/gomega"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -20,10 +22,11 @@ func TestNewShutdownHandler(t *testing.T) {
 		doguInterfaceMock := newMockDoguInterface(t)
 
 		// when
-		handler := NewShutdownHandler(doguInterfaceMock)
+		handler := NewShutdownHandler(doguInterfaceMock, &config.OperatorConfig{WatchNamespaces: []string{"test", "stage"}})
 
 		// then
 		assert.Equal(t, doguInterfaceMock, handler.doguInterface)
+		assert.Equal(t, []string{"test", "stage"}, handler.watchNamespaces)
 	})
 
 }
@@ -38,7 +41,7 @@ func TestShutdownHandler_Handle(t *testing.T) {
 			name: "should fail to list dogus",
 			doguInterfaceFn: func(t *testing.T) client.DoguInterface {
 				mck := newMockDoguInterface(t)
-				mck.EXPECT().List(testCtx, metav1.ListOptions{}).Return(nil, assert.AnError)
+				mck.EXPECT().List(namespaced.WithNamespace(testCtx, "ecosystem"), metav1.ListOptions{}).Return(nil, assert.AnError)
 				return mck
 			},
 			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
@@ -50,17 +53,17 @@ func TestShutdownHandler_Handle(t *testing.T) {
 			doguInterfaceFn: func(t *testing.T) client.DoguInterface {
 				mck := newMockDoguInterface(t)
 				ldapDogu := &v2.Dogu{
-					ObjectMeta: metav1.ObjectMeta{Name: "ldap"},
+					ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: "ecosystem"},
 				}
 				casDogu := &v2.Dogu{
-					ObjectMeta: metav1.ObjectMeta{Name: "cas"},
+					ObjectMeta: metav1.ObjectMeta{Name: "cas", Namespace: "ecosystem"},
 				}
-				mck.EXPECT().List(testCtx, metav1.ListOptions{}).Return(&v2.DoguList{Items: []v2.Dogu{
+				mck.EXPECT().List(namespaced.WithNamespace(testCtx, "ecosystem"), metav1.ListOptions{}).Return(&v2.DoguList{Items: []v2.Dogu{
 					*ldapDogu,
 					*casDogu,
 				}}, nil)
-				mck.EXPECT().UpdateStatusWithRetry(testCtx, ldapDogu, mock.Anything, metav1.UpdateOptions{}).Return(nil, assert.AnError)
-				mck.EXPECT().UpdateStatusWithRetry(testCtx, casDogu, mock.Anything, metav1.UpdateOptions{}).Return(nil, assert.AnError)
+				mck.EXPECT().UpdateStatusWithRetry(namespaced.WithNamespace(testCtx, "ecosystem"), ldapDogu, mock.Anything, metav1.UpdateOptions{}).Return(nil, assert.AnError)
+				mck.EXPECT().UpdateStatusWithRetry(namespaced.WithNamespace(testCtx, "ecosystem"), casDogu, mock.Anything, metav1.UpdateOptions{}).Return(nil, assert.AnError)
 				return mck
 			},
 			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
@@ -74,12 +77,12 @@ func TestShutdownHandler_Handle(t *testing.T) {
 			doguInterfaceFn: func(t *testing.T) client.DoguInterface {
 				mck := newMockDoguInterface(t)
 				ldapDogu := &v2.Dogu{
-					ObjectMeta: metav1.ObjectMeta{Name: "ldap"},
+					ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: "ecosystem"},
 				}
 				casDogu := &v2.Dogu{
-					ObjectMeta: metav1.ObjectMeta{Name: "cas"},
+					ObjectMeta: metav1.ObjectMeta{Name: "cas", Namespace: "ecosystem"},
 				}
-				mck.EXPECT().List(testCtx, metav1.ListOptions{}).Return(&v2.DoguList{Items: []v2.Dogu{
+				mck.EXPECT().List(namespaced.WithNamespace(testCtx, "ecosystem"), metav1.ListOptions{}).Return(&v2.DoguList{Items: []v2.Dogu{
 					*ldapDogu,
 					*casDogu,
 				}}, nil)
@@ -120,9 +123,9 @@ func TestShutdownHandler_Handle(t *testing.T) {
 
 					return dogu, nil
 				}
-				mck.EXPECT().UpdateStatusWithRetry(testCtx, ldapDogu, mock.Anything, metav1.UpdateOptions{}).
+				mck.EXPECT().UpdateStatusWithRetry(namespaced.WithNamespace(testCtx, "ecosystem"), ldapDogu, mock.Anything, metav1.UpdateOptions{}).
 					RunAndReturn(runAndReturnFn)
-				mck.EXPECT().UpdateStatusWithRetry(testCtx, casDogu, mock.Anything, metav1.UpdateOptions{}).
+				mck.EXPECT().UpdateStatusWithRetry(namespaced.WithNamespace(testCtx, "ecosystem"), casDogu, mock.Anything, metav1.UpdateOptions{}).
 					RunAndReturn(runAndReturnFn)
 				return mck
 			},
@@ -132,7 +135,8 @@ func TestShutdownHandler_Handle(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &ShutdownHandler{
-				doguInterface: tt.doguInterfaceFn(t),
+				doguInterface:   tt.doguInterfaceFn(t),
+				watchNamespaces: []string{"ecosystem"},
 			}
 			tt.wantErr(t, s.Handle(testCtx), fmt.Sprintf("Handle(%v)", testCtx))
 		})
@@ -5,6 +5,8 @@ import (
 
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/namespaced"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -12,16 +14,24 @@ import (
 )
 
 type StartupHandler struct {
-	doguInterface doguClient.DoguInterface
-	doguEvents    chan<- event.TypedGenericEvent[*v2.Dogu]
+	doguInterface       doguClient.DoguInterface
+	healthStatusUpdater DoguHealthStatusUpdater
+	doguEvents          chan<- event.TypedGenericEvent[*v2.Dogu]
+	watchNamespaces     []string
+	// watchesAllNamespaces is true if the dogus of all namespaces are listed at once, so that their health config
+	// maps have to be migrated per namespace of the dogus.
+	watchesAllNamespaces bool
 }
 
 // NewStartupHandler creates the StartupHandler as a manager.Runnable and adds it to the manager.Manager.
 // The doguEvents channel is used to trigger reconciles by enqueuing generic events for dogus.
-func NewStartupHandler(manager manager.Manager, doguInterface doguClient.DoguInterface, doguEvents chan<- event.TypedGenericEvent[*v2.Dogu]) (*StartupHandler, error) {
+func NewStartupHandler(manager manager.Manager, doguInterface doguClient.DoguInterface, healthStatusUpdater DoguHealthStatusUpdater, doguEvents chan<- event.TypedGenericEvent[*v2.Dogu], operatorConfig *config.OperatorConfig) (*StartupHandler, error) {
 	sh := &StartupHandler{
-		doguInterface: doguInterface,
-		doguEvents:    doguEvents,
+		doguInterface:        doguInterface,
+		healthStatusUpdater:  healthStatusUpdater,
+		doguEvents:           doguEvents,
+		watchNamespaces:      operatorConfig.WatchNamespaces,
+		watchesAllNamespaces: operatorConfig.WatchesAllNamespaces(),
 	}
 	err := manager.Add(sh)
 	if err != nil {
@@ -35,12 +45,36 @@ func (s *StartupHandler) Start(ctx context.Context) error {
 	logger := log.FromContext(ctx)
 	logger.WithName("health startup handler").Info("updating health of all dogus on startup")
 
-	list, err := s.doguInterface.List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return err
-	}
-	for _, dogu := range list.Items {
-		s.doguEvents <- event.TypedGenericEvent[*v2.Dogu]{Object: &dogu}
+	for _, namespace := range s.watchNamespaces {
+		namespaceCtx := namespaced.WithNamespace(ctx, namespace)
+		list, err := s.doguInterface.List(namespaceCtx, metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		// the health states are migrated before the dogus are reconciled, so that dependents of dogus find them
+		for doguNamespace, dogus := range s.dogusByNamespace(namespace, list.Items) {
+			err = s.healthStatusUpdater.MigrateLegacyHealthConfigMap(namespaced.WithNamespace(ctx, doguNamespace), doguNamespace, dogus)
+			if err != nil {
+				logger.Error(err, "failed to migrate legacy health configMap", "namespace", doguNamespace)
+			}
+		}
+		for _, dogu := range list.Items {
+			s.doguEvents <- event.TypedGenericEvent[*v2.Dogu]{Object: &dogu}
+		}
 	}
 	return nil
 }
+
+// dogusByNamespace groups the dogus listed in the namespace by their namespace. If all namespaces are watched, the
+// dogus of every namespace are listed at once.
+func (s *StartupHandler) dogusByNamespace(namespace string, dogus []v2.Dogu) map[string][]v2.Dogu {
+	if !s.watchesAllNamespaces {
+		return map[string][]v2.Dogu{namespace: dogus}
+	}
+
+	dogusByNamespace := map[string][]v2.Dogu{}
+	for _, dogu := range dogus {
+		dogusByNamespace[dogu.Namespace] = append(dogusByNamespace[dogu.Namespace], dogu)
+	}
+	return dogusByNamespace
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/namespaced"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -17,16 +19,32 @@ func TestNewStartupHandler(t *testing.T) {
 	t.Run("should succeed", func(t *testing.T) {
 		// given
 		doguInterfaceMock := newMockDoguInterface(t)
+		healthStatusUpdaterMock := NewMockDoguHealthStatusUpdater(t)
 		managerMock := newMockCtrlManager(t)
 		managerMock.EXPECT().Add(mock.Anything).Return(nil)
 
 		// when
-		handler, err := NewStartupHandler(managerMock, doguInterfaceMock, make(chan<- event.TypedGenericEvent[*v2.Dogu]))
+		handler, err := NewStartupHandler(managerMock, doguInterfaceMock, healthStatusUpdaterMock, make(chan<- event.TypedGenericEvent[*v2.Dogu]), &config.OperatorConfig{WatchNamespaces: []string{"ecosystem"}})
 
 		// then
 		assert.Same(t, doguInterfaceMock, handler.doguInterface)
+		assert.Same(t, healthStatusUpdaterMock, handler.healthStatusUpdater)
+		assert.Equal(t, []string{"ecosystem"}, handler.watchNamespaces)
+		assert.False(t, handler.watchesAllNamespaces)
 		assert.NoError(t, err)
 	})
+	t.Run("should watch all namespaces", func(t *testing.T) {
+		// given
+		managerMock := newMockCtrlManager(t)
+		managerMock.EXPECT().Add(mock.Anything).Return(nil)
+
+		// when
+		handler, err := NewStartupHandler(managerMock, newMockDoguInterface(t), NewMockDoguHealthStatusUpdater(t), make(chan<- event.TypedGenericEvent[*v2.Dogu]), &config.OperatorConfig{WatchNamespaces: []string{metav1.NamespaceAll}})
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, handler.watchesAllNamespaces)
+	})
 	t.Run("should fail to add handler", func(t *testing.T) {
 		// given
 		doguInterfaceMock := newMockDoguInterface(t)
@@ -34,7 +52,7 @@ func TestNewStartupHandler(t *testing.T) {
 		managerMock.EXPECT().Add(mock.Anything).Return(assert.AnError)
 
 		// when
-		_, err := NewStartupHandler(managerMock, doguInterfaceMock, make(chan<- event.TypedGenericEvent[*v2.Dogu]))
+		_, err := NewStartupHandler(managerMock, doguInterfaceMock, NewMockDoguHealthStatusUpdater(t), make(chan<- event.TypedGenericEvent[*v2.Dogu]), &config.OperatorConfig{WatchNamespaces: []string{"ecosystem"}})
 
 		// then
 		assert.ErrorIs(t, err, assert.AnError)
@@ -56,10 +74,12 @@ func TestStartupHandler_Start(t *testing.T) {
 		}
 
 		doguList := &v2.DoguList{Items: []v2.Dogu{*casDogu, *ldapDogu}}
-		doguInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{}).Return(doguList, nil)
+		doguInterfaceMock.EXPECT().List(namespaced.WithNamespace(testCtx, "ecosystem"), metav1.ListOptions{}).Return(doguList, nil)
+		healthStatusUpdaterMock := NewMockDoguHealthStatusUpdater(t)
+		healthStatusUpdaterMock.EXPECT().MigrateLegacyHealthConfigMap(namespaced.WithNamespace(testCtx, "ecosystem"), "ecosystem", doguList.Items).Return(nil)
 
 		doguEvents := make(chan event.TypedGenericEvent[*v2.Dogu])
-		sut := StartupHandler{doguInterface: doguInterfaceMock, doguEvents: doguEvents}
+		sut := StartupHandler{doguInterface: doguInterfaceMock, healthStatusUpdater: healthStatusUpdaterMock, doguEvents: doguEvents, watchNamespaces: []string{"ecosystem"}}
 
 		var wg sync.WaitGroup
 		wg.Go(func() {
@@ -85,13 +105,66 @@ func TestStartupHandler_Start(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("should enqueue dogus of all watched namespaces", func(t *testing.T) {
+		// given
+		doguInterfaceMock := newMockDoguInterface(t)
+
+		testDogu := &v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "cas", Namespace: "test"}}
+		stageDogu := &v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "cas", Namespace: "stage"}}
+		doguInterfaceMock.EXPECT().List(namespaced.WithNamespace(testCtx, "test"), metav1.ListOptions{}).Return(&v2.DoguList{Items: []v2.Dogu{*testDogu}}, nil)
+		doguInterfaceMock.EXPECT().List(namespaced.WithNamespace(testCtx, "stage"), metav1.ListOptions{}).Return(&v2.DoguList{Items: []v2.Dogu{*stageDogu}}, nil)
+
+		healthStatusUpdaterMock := NewMockDoguHealthStatusUpdater(t)
+		healthStatusUpdaterMock.EXPECT().MigrateLegacyHealthConfigMap(namespaced.WithNamespace(testCtx, "test"), "test", []v2.Dogu{*testDogu}).Return(nil)
+		// a failed migration does not prevent the reconciles of the dogus
+		healthStatusUpdaterMock.EXPECT().MigrateLegacyHealthConfigMap(namespaced.WithNamespace(testCtx, "stage"), "stage", []v2.Dogu{*stageDogu}).Return(assert.AnError)
+
+		doguEvents := make(chan event.TypedGenericEvent[*v2.Dogu], 2)
+		sut := StartupHandler{doguInterface: doguInterfaceMock, healthStatusUpdater: healthStatusUpdaterMock, doguEvents: doguEvents, watchNamespaces: []string{"test", "stage"}}
+
+		// when
+		err := sut.Start(testCtx)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, event.TypedGenericEvent[*v2.Dogu]{Object: testDogu}, <-doguEvents)
+		assert.Equal(t, event.TypedGenericEvent[*v2.Dogu]{Object: stageDogu}, <-doguEvents)
+	})
+
+	t.Run("should migrate the dogus of all namespaces per namespace", func(t *testing.T) {
+		// given
+		doguInterfaceMock := newMockDoguInterface(t)
+
+		testDogu := &v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "cas", Namespace: "test"}}
+		stageCas := &v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "cas", Namespace: "stage"}}
+		stageLdap := &v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: "stage"}}
+		doguInterfaceMock.EXPECT().List(namespaced.WithNamespace(testCtx, metav1.NamespaceAll), metav1.ListOptions{}).
+			Return(&v2.DoguList{Items: []v2.Dogu{*testDogu, *stageCas, *stageLdap}}, nil)
+
+		healthStatusUpdaterMock := NewMockDoguHealthStatusUpdater(t)
+		healthStatusUpdaterMock.EXPECT().MigrateLegacyHealthConfigMap(namespaced.WithNamespace(testCtx, "test"), "test", []v2.Dogu{*testDogu}).Return(nil)
+		healthStatusUpdaterMock.EXPECT().MigrateLegacyHealthConfigMap(namespaced.WithNamespace(testCtx, "stage"), "stage", []v2.Dogu{*stageCas, *stageLdap}).Return(nil)
+
+		doguEvents := make(chan event.TypedGenericEvent[*v2.Dogu], 3)
+		sut := StartupHandler{doguInterface: doguInterfaceMock, healthStatusUpdater: healthStatusUpdaterMock, doguEvents: doguEvents, watchNamespaces: []string{metav1.NamespaceAll}, watchesAllNamespaces: true}
+
+		// when
+		err := sut.Start(testCtx)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, event.TypedGenericEvent[*v2.Dogu]{Object: testDogu}, <-doguEvents)
+		assert.Equal(t, event.TypedGenericEvent[*v2.Dogu]{Object: stageCas}, <-doguEvents)
+		assert.Equal(t, event.TypedGenericEvent[*v2.Dogu]{Object: stageLdap}, <-doguEvents)
+	})
+
 	t.Run("should return error on dogu list error", func(t *testing.T) {
 		// given
 		doguInterfaceMock := newMockDoguInterface(t)
 
-		doguInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{}).Return(nil, assert.AnError)
+		doguInterfaceMock.EXPECT().List(namespaced.WithNamespace(testCtx, "ecosystem"), metav1.ListOptions{}).Return(nil, assert.AnError)
 
-		sut := StartupHandler{doguInterface: doguInterfaceMock, doguEvents: nil}
+		sut := StartupHandler{doguInterface: doguInterfaceMock, doguEvents: nil, watchNamespaces: []string{"ecosystem"}}
 
 		// when
 		err := sut.Start(testCtx)
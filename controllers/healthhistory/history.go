@@ -0,0 +1,145 @@
+package healthhistory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/dogustore"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+const (
+	configMapNameSuffix = "-health-history"
+	// HistoryKey is the key in the health history config map that contains the recorded transitions as JSON.
+	HistoryKey = "transitions"
+	// HistoryLabel marks config maps that contain the health history of a dogu.
+	HistoryLabel = "k8s.cloudogu.com/health-history"
+)
+
+// ConfigMapName returns the name of the config map containing the health history of the dogu.
+func ConfigMapName(doguName string) string {
+	return doguName + configMapNameSuffix
+}
+
+type configMapHistory struct {
+	store        *dogustore.ConfigMapStore
+	historyLimit int
+	threshold    int
+	window       time.Duration
+	events       chan<- event.TypedGenericEvent[*v2.Dogu]
+	mutex        sync.Mutex
+	rechecks     map[types.NamespacedName]*time.Timer
+}
+
+// NewConfigMapHistory creates a History which stores the latest health transitions of each dogu in a config map.
+// Flapping dogus are reconciled again with a generic event as soon as they stop flapping, because a dogu whose health
+// does not change anymore is not reconciled otherwise.
+func NewConfigMapHistory(configMapInterface v1.ConfigMapInterface, scheme *runtime.Scheme, operatorConfig *config.OperatorConfig, events chan<- event.TypedGenericEvent[*v2.Dogu]) History {
+	return &configMapHistory{
+		store: dogustore.NewConfigMapStore(configMapInterface, scheme, configMapNameSuffix, HistoryLabel),
+		// flapping can only be detected if the history keeps at least as many transitions as the threshold
+		historyLimit: max(operatorConfig.HealthHistoryLimit, operatorConfig.HealthFlappingThreshold),
+		threshold:    operatorConfig.HealthFlappingThreshold,
+		window:       operatorConfig.HealthFlappingWindow,
+		events:       events,
+		rechecks:     map[types.NamespacedName]*time.Timer{},
+	}
+}
+
+// Record appends the transition to the health history config map of the dogu.
+func (h *configMapHistory) Record(ctx context.Context, doguResource *v2.Dogu, transition Transition) (Evaluation, error) {
+	var history []Transition
+	err := h.store.Update(ctx, doguResource, func(data map[string]string) error {
+		// a broken history must not block recording new transitions, so it gets replaced
+		history, _ = parseTransitions(data)
+		history = appendTransition(history, transition, h.historyLimit)
+		return setTransitions(data, history)
+	})
+	if err != nil {
+		return Evaluation{}, fmt.Errorf("failed to record health transition of dogu %q: %w", doguResource.Name, err)
+	}
+
+	return h.evaluate(doguResource, history), nil
+}
+
+// Evaluate reads the health history config map of the dogu and evaluates whether the dogu is flapping.
+func (h *configMapHistory) Evaluate(ctx context.Context, doguResource *v2.Dogu) (Evaluation, error) {
+	data, err := h.store.Get(ctx, doguResource)
+	if err != nil {
+		return Evaluation{}, fmt.Errorf("failed to get health history of dogu %q: %w", doguResource.Name, err)
+	}
+
+	history, err := parseTransitions(data)
+	if err != nil {
+		return Evaluation{}, fmt.Errorf("failed to evaluate health history of dogu %q: %w", doguResource.Name, err)
+	}
+
+	return h.evaluate(doguResource, history), nil
+}
+
+func (h *configMapHistory) evaluate(doguResource *v2.Dogu, history []Transition) Evaluation {
+	evaluation := evaluateFlapping(history, time.Now(), h.threshold, h.window)
+	if evaluation.Flapping {
+		h.scheduleRecheck(doguResource.GetObjectKey(), evaluation.Until)
+	}
+
+	return evaluation
+}
+
+// scheduleRecheck reconciles the dogu again at the given time. A previously scheduled recheck of the dogu is replaced.
+func (h *configMapHistory) scheduleRecheck(dogu types.NamespacedName, at time.Time) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if timer, ok := h.rechecks[dogu]; ok {
+		timer.Stop()
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(time.Until(at), func() {
+		h.mutex.Lock()
+		if h.rechecks[dogu] == timer {
+			delete(h.rechecks, dogu)
+		}
+		h.mutex.Unlock()
+
+		h.events <- event.TypedGenericEvent[*v2.Dogu]{Object: &v2.Dogu{
+			ObjectMeta: metav1.ObjectMeta{Name: dogu.Name, Namespace: dogu.Namespace},
+		}}
+	})
+	h.rechecks[dogu] = timer
+}
+
+func setTransitions(data map[string]string, transitions []Transition) error {
+	transitionsJson, err := json.Marshal(transitions)
+	if err != nil {
+		return fmt.Errorf("failed to serialize health history: %w", err)
+	}
+	data[HistoryKey] = string(transitionsJson)
+
+	return nil
+}
+
+func parseTransitions(data map[string]string) ([]Transition, error) {
+	raw, ok := data[HistoryKey]
+	if !ok || raw == "" {
+		return nil, nil
+	}
+
+	var transitions []Transition
+	err := json.Unmarshal([]byte(raw), &transitions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse health history: %w", err)
+	}
+
+	return transitions, nil
+}
@@ -0,0 +1,207 @@
+package healthhistory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+var testCtx = context.Background()
+
+func getTestScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypeWithName(schema.GroupVersionKind{
+		Group:   "k8s.cloudogu.com",
+		Version: "v2",
+		Kind:    "Dogu",
+	}, &v2.Dogu{})
+	return scheme
+}
+
+func getTestDogu() *v2.Dogu {
+	return &v2.Dogu{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: "ecosystem", UID: "uid"},
+	}
+}
+
+func newTestHistory(cmMock configMapInterface, events chan event.TypedGenericEvent[*v2.Dogu]) *configMapHistory {
+	return NewConfigMapHistory(cmMock, getTestScheme(), &config.OperatorConfig{
+		HealthHistoryLimit:      3,
+		HealthFlappingThreshold: 2,
+		HealthFlappingWindow:    time.Hour,
+	}, events).(*configMapHistory)
+}
+
+func newHistoryConfigMap(t *testing.T, transitions ...Transition) *corev1.ConfigMap {
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "ldap-health-history", Namespace: "ecosystem"}, Data: map[string]string{}}
+	require.NoError(t, setTransitions(cm.Data, transitions))
+	return cm
+}
+
+func TestNewConfigMapHistory(t *testing.T) {
+	got := NewConfigMapHistory(newMockConfigMapInterface(t), getTestScheme(), &config.OperatorConfig{
+		HealthHistoryLimit:      2,
+		HealthFlappingThreshold: 4,
+		HealthFlappingWindow:    time.Minute,
+	}, nil)
+
+	require.NotNil(t, got)
+	assert.Equal(t, 4, got.(*configMapHistory).historyLimit)
+	assert.Equal(t, time.Minute, got.(*configMapHistory).window)
+}
+
+func Test_configMapHistory_Record(t *testing.T) {
+	notFoundErr := errors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "ldap-health-history")
+	now := time.Now().Truncate(time.Second)
+	down := NewTransition(now.Add(-2*time.Minute), v2.AvailableHealthStatus, v2.UnavailableHealthStatus, "DoguIsNotHealthy", "Not all replicas are available")
+	up := NewTransition(now.Add(-time.Minute), v2.UnavailableHealthStatus, v2.AvailableHealthStatus, "DoguIsHealthy", "")
+
+	t.Run("should create history config map with non-controlling owner reference", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-health-history", metav1.GetOptions{}).Return(nil, notFoundErr)
+		cmMock.EXPECT().Create(testCtx, mock.Anything, metav1.CreateOptions{}).
+			RunAndReturn(func(ctx context.Context, cm *corev1.ConfigMap, opts metav1.CreateOptions) (*corev1.ConfigMap, error) {
+				assert.Equal(t, "ldap-health-history", cm.Name)
+				assert.Equal(t, "ecosystem", cm.Namespace)
+				assert.Equal(t, "true", cm.Labels[HistoryLabel])
+				assert.Equal(t, "ldap", cm.Labels["dogu.name"])
+				require.Len(t, cm.OwnerReferences, 1)
+				assert.Equal(t, "Dogu", cm.OwnerReferences[0].Kind)
+				assert.Nil(t, cm.OwnerReferences[0].Controller)
+
+				transitions, err := parseTransitions(cm.Data)
+				require.NoError(t, err)
+				assert.Equal(t, []Transition{down}, transitions)
+				return cm, nil
+			})
+		sut := newTestHistory(cmMock, nil)
+
+		// when
+		evaluation, err := sut.Record(testCtx, getTestDogu(), down)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, evaluation.Flapping)
+		assert.Equal(t, 1, evaluation.Transitions)
+	})
+	t.Run("should append transition, detect flapping and recheck when flapping ends", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-health-history", metav1.GetOptions{}).Return(newHistoryConfigMap(t, down), nil)
+		cmMock.EXPECT().Update(testCtx, newHistoryConfigMap(t, down, up), metav1.UpdateOptions{}).Return(nil, nil)
+		events := make(chan event.TypedGenericEvent[*v2.Dogu], 1)
+		sut := newTestHistory(cmMock, events)
+		// the transitions are stored with a precision of seconds, so the window ends one to two seconds from now
+		sut.window = 2*time.Minute + 2*time.Second
+
+		// when
+		evaluation, err := sut.Record(testCtx, getTestDogu(), up)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, evaluation.Flapping)
+		assert.Equal(t, 2, evaluation.Transitions)
+		assert.WithinDuration(t, now.Add(2*time.Second), evaluation.Until, time.Second)
+		select {
+		case recheck := <-events:
+			assert.Equal(t, types.NamespacedName{Namespace: "ecosystem", Name: "ldap"}, recheck.Object.GetObjectKey())
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected recheck of flapping dogu")
+		}
+		assert.Empty(t, sut.rechecks)
+	})
+	t.Run("should replace broken history", func(t *testing.T) {
+		// given
+		brokenCM := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "ldap-health-history", Namespace: "ecosystem"},
+			Data:       map[string]string{HistoryKey: "{"},
+		}
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-health-history", metav1.GetOptions{}).Return(brokenCM, nil)
+		cmMock.EXPECT().Update(testCtx, newHistoryConfigMap(t, up), metav1.UpdateOptions{}).Return(nil, nil)
+		sut := newTestHistory(cmMock, nil)
+
+		// when
+		_, err := sut.Record(testCtx, getTestDogu(), up)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail to update history config map", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-health-history", metav1.GetOptions{}).Return(newHistoryConfigMap(t, down), nil)
+		cmMock.EXPECT().Update(testCtx, mock.Anything, metav1.UpdateOptions{}).Return(nil, assert.AnError)
+		sut := newTestHistory(cmMock, nil)
+
+		// when
+		_, err := sut.Record(testCtx, getTestDogu(), up)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to record health transition of dogu \"ldap\"")
+	})
+}
+
+func Test_configMapHistory_Evaluate(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	down := NewTransition(now.Add(-2*time.Hour), v2.AvailableHealthStatus, v2.UnavailableHealthStatus, "DoguIsNotHealthy", "")
+	up := NewTransition(now.Add(-time.Minute), v2.UnavailableHealthStatus, v2.AvailableHealthStatus, "DoguIsHealthy", "")
+
+	t.Run("should not be flapping after transitions left the window", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-health-history", metav1.GetOptions{}).Return(newHistoryConfigMap(t, down, up), nil)
+		sut := newTestHistory(cmMock, nil)
+
+		// when
+		evaluation, err := sut.Evaluate(testCtx, getTestDogu())
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, evaluation.Flapping)
+		assert.Equal(t, 1, evaluation.Transitions)
+		assert.Empty(t, sut.rechecks)
+	})
+	t.Run("should not be flapping without history", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-health-history", metav1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, ""))
+		sut := newTestHistory(cmMock, nil)
+
+		// when
+		evaluation, err := sut.Evaluate(testCtx, getTestDogu())
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, evaluation.Flapping)
+	})
+	t.Run("should fail to get history config map", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-health-history", metav1.GetOptions{}).Return(nil, assert.AnError)
+		sut := newTestHistory(cmMock, nil)
+
+		// when
+		_, err := sut.Evaluate(testCtx, getTestDogu())
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
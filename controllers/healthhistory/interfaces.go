@@ -0,0 +1,22 @@
+package healthhistory
+
+import (
+	"context"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// History keeps the transitions of the health status of each dogu and detects dogus whose health is flapping.
+type History interface {
+	// Record appends the transition to the health history of the dogu and evaluates whether the dogu is flapping.
+	Record(ctx context.Context, doguResource *v2.Dogu, transition Transition) (Evaluation, error)
+	// Evaluate evaluates whether the dogu is flapping without recording a transition.
+	Evaluate(ctx context.Context, doguResource *v2.Dogu) (Evaluation, error)
+}
+
+//nolint:unused
+//goland:noinspection GoUnusedType
+type configMapInterface interface {
+	v1.ConfigMapInterface
+}
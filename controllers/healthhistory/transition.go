@@ -0,0 +1,91 @@
+package healthhistory
+
+import (
+	"fmt"
+	"time"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Transition describes a change of the health status of a dogu.
+type Transition struct {
+	// Time is the time the health status changed.
+	Time metav1.Time `json:"time"`
+	// From is the previous health status. It is empty for the first health status of a dogu.
+	From v2.HealthStatus `json:"from"`
+	// To is the new health status.
+	To v2.HealthStatus `json:"to"`
+	// Reason is the reason of the condition which caused the new health status, e.g. "DoguIsNotReady".
+	Reason string `json:"reason"`
+	// Message describes the cause of the new health status.
+	Message string `json:"message,omitempty"`
+}
+
+// NewTransition creates a transition of the health status at the given time.
+func NewTransition(changedAt time.Time, from v2.HealthStatus, to v2.HealthStatus, reason string, message string) Transition {
+	return Transition{
+		Time:    metav1.NewTime(changedAt),
+		From:    from,
+		To:      to,
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+// countsForFlapping returns true if the transition changed the health of the dogu itself.
+// The first health status of a dogu is no change, and changes between available and degraded are caused by the
+// dependencies of the dogu, which are flapping themselves in that case.
+func (t Transition) countsForFlapping() bool {
+	return t.From != "" && (t.From == v2.UnavailableHealthStatus || t.To == v2.UnavailableHealthStatus)
+}
+
+// Evaluation is the result of the flapping detection of a dogu.
+type Evaluation struct {
+	// Flapping is true if at least Threshold transitions happened within the Window.
+	Flapping bool
+	// Transitions is the number of transitions within the Window.
+	Transitions int
+	// Threshold is the number of transitions within the Window which mark a dogu as flapping.
+	Threshold int
+	// Window is the time window in which the transitions are counted.
+	Window time.Duration
+	// Until is the time at which a flapping dogu is no longer flapping if its health does not change anymore.
+	Until time.Time
+}
+
+// Message describes the evaluation for the Flapping condition.
+func (e Evaluation) Message() string {
+	return fmt.Sprintf("%d health transitions within %s (threshold %d)", e.Transitions, e.Window, e.Threshold)
+}
+
+func evaluateFlapping(history []Transition, now time.Time, threshold int, window time.Duration) Evaluation {
+	var counted []Transition
+	for _, transition := range history {
+		if transition.countsForFlapping() && transition.Time.Add(window).After(now) {
+			counted = append(counted, transition)
+		}
+	}
+
+	evaluation := Evaluation{
+		Transitions: len(counted),
+		Threshold:   threshold,
+		Window:      window,
+	}
+	if len(counted) >= threshold {
+		evaluation.Flapping = true
+		// the dogu stops flapping as soon as fewer than threshold transitions are left in the window
+		evaluation.Until = counted[len(counted)-threshold].Time.Add(window)
+	}
+
+	return evaluation
+}
+
+func appendTransition(history []Transition, transition Transition, limit int) []Transition {
+	history = append(history, transition)
+	if len(history) > limit {
+		history = history[len(history)-limit:]
+	}
+
+	return history
+}
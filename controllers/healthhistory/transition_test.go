@@ -0,0 +1,72 @@
+package healthhistory
+
+import (
+	"testing"
+	"time"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var testTime = time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+func Test_evaluateFlapping(t *testing.T) {
+	down := func(minutesAgo int) Transition {
+		return NewTransition(testTime.Add(-time.Duration(minutesAgo)*time.Minute), v2.AvailableHealthStatus, v2.UnavailableHealthStatus, "DoguIsNotHealthy", "")
+	}
+	up := func(minutesAgo int) Transition {
+		return NewTransition(testTime.Add(-time.Duration(minutesAgo)*time.Minute), v2.UnavailableHealthStatus, v2.AvailableHealthStatus, "DoguIsHealthy", "")
+	}
+
+	t.Run("should detect flapping at threshold within window", func(t *testing.T) {
+		// given
+		history := []Transition{down(90), up(80), down(40), up(30), down(20), up(10)}
+
+		// when
+		evaluation := evaluateFlapping(history, testTime, 4, time.Hour)
+
+		// then
+		assert.True(t, evaluation.Flapping)
+		assert.Equal(t, 4, evaluation.Transitions)
+		// the third last transition leaves the window after the oldest one of the last four
+		assert.Equal(t, testTime.Add(20*time.Minute), evaluation.Until)
+		assert.Equal(t, "4 health transitions within 1h0m0s (threshold 4)", evaluation.Message())
+	})
+	t.Run("should not detect flapping below threshold", func(t *testing.T) {
+		// given
+		history := []Transition{down(90), up(80), down(20), up(10)}
+
+		// when
+		evaluation := evaluateFlapping(history, testTime, 4, time.Hour)
+
+		// then
+		assert.False(t, evaluation.Flapping)
+		assert.Equal(t, 2, evaluation.Transitions)
+		assert.True(t, evaluation.Until.IsZero())
+	})
+	t.Run("should ignore first health status and changes caused by dependencies", func(t *testing.T) {
+		// given
+		history := []Transition{
+			NewTransition(testTime.Add(-50*time.Minute), "", v2.AvailableHealthStatus, "DoguIsHealthy", ""),
+			NewTransition(testTime.Add(-40*time.Minute), v2.AvailableHealthStatus, "degraded", "DependencyUnhealthy", ""),
+			NewTransition(testTime.Add(-30*time.Minute), "degraded", v2.AvailableHealthStatus, "DependenciesHealthy", ""),
+			NewTransition(testTime.Add(-20*time.Minute), "degraded", v2.UnavailableHealthStatus, "DoguIsNotHealthy", ""),
+		}
+
+		// when
+		evaluation := evaluateFlapping(history, testTime, 2, time.Hour)
+
+		// then
+		assert.False(t, evaluation.Flapping)
+		assert.Equal(t, 1, evaluation.Transitions)
+	})
+}
+
+func Test_appendTransition(t *testing.T) {
+	first := NewTransition(testTime, "", v2.AvailableHealthStatus, "DoguIsHealthy", "")
+	second := NewTransition(testTime.Add(time.Minute), v2.AvailableHealthStatus, v2.UnavailableHealthStatus, "DoguIsNotHealthy", "")
+	third := NewTransition(testTime.Add(2*time.Minute), v2.UnavailableHealthStatus, v2.AvailableHealthStatus, "DoguIsHealthy", "")
+
+	assert.Equal(t, []Transition{first}, appendTransition(nil, first, 2))
+	assert.Equal(t, []Transition{second, third}, appendTransition([]Transition{first, second}, third, 2))
+}
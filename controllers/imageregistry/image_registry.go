@@ -10,11 +10,13 @@ import (
 	"time"
 
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/tracing"
 	"github.com/cloudogu/retry-lib/retry"
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/crane"
 	imagev1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"go.opentelemetry.io/otel/attribute"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -33,25 +35,18 @@ func NewCraneContainerImageRegistry() ImageRegistry {
 }
 
 // PullImageConfig pulls an image with the crane library. It uses basic auth for the registry authentication.
-func (i *craneContainerImageRegistry) PullImageConfig(ctx context.Context, image string) (*imagev1.ConfigFile, error) {
+func (i *craneContainerImageRegistry) PullImageConfig(ctx context.Context, image string) (_ *imagev1.ConfigFile, err error) {
+	ctx, span := tracing.Start(ctx, "ImageRegistry.PullImageConfig", attribute.String("image", image))
+	defer func() { tracing.End(span, err) }()
+
 	ctxOpt := crane.WithContext(ctx)
 
 	logger := log.FromContext(ctx)
 	logger.Info(fmt.Sprintf("Try to pull image manifest from image: [%s]", image))
 
-	transport := remote.DefaultTransport
-	proxyURL, found := os.LookupEnv("PROXY_URL")
-	if found && len(proxyURL) > 0 {
-		parsedURL, err := url.Parse(proxyURL)
-		if err != nil {
-			return nil, err
-		}
-
-		t, ok := transport.(*http.Transport)
-		if !ok {
-			return nil, errors.New("type assertion error: no transport")
-		}
-		t.Proxy = http.ProxyURL(parsedURL)
+	transport, err := proxyTransport()
+	if err != nil {
+		return nil, err
 	}
 
 	stage, err := config.GetStage()
@@ -82,3 +77,23 @@ func (i *craneContainerImageRegistry) PullImageConfig(ctx context.Context, image
 
 	return img.ConfigFile()
 }
+
+// proxyTransport returns the default transport of the crane library, which uses the proxy configured in PROXY_URL.
+func proxyTransport() (http.RoundTripper, error) {
+	transport := remote.DefaultTransport
+	proxyURL, found := os.LookupEnv("PROXY_URL")
+	if found && len(proxyURL) > 0 {
+		parsedURL, err := url.Parse(proxyURL)
+		if err != nil {
+			return nil, err
+		}
+
+		t, ok := transport.(*http.Transport)
+		if !ok {
+			return nil, errors.New("type assertion error: no transport")
+		}
+		t.Proxy = http.ProxyURL(parsedURL)
+	}
+
+	return transport, nil
+}
@@ -11,3 +11,11 @@ type ImageRegistry interface {
 	// PullImageConfig is used to pull the given container image.
 	PullImageConfig(ctx context.Context, image string) (*imagev1.ConfigFile, error)
 }
+
+// RegistryPinger checks whether the container registries configured for the operator are reachable.
+type RegistryPinger interface {
+	// Registries returns the hosts of the container registries for which credentials are configured.
+	Registries() ([]string, error)
+	// Ping checks whether the registry is reachable and accepts the configured credentials.
+	Ping(ctx context.Context, registry string) error
+}
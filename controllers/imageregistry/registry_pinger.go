@@ -0,0 +1,106 @@
+package imageregistry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+)
+
+const dockerConfigFileName = "config.json"
+
+// craneRegistryPinger pings container registries with the credentials of the docker config, the same way the
+// crane library does when pulling images.
+type craneRegistryPinger struct {
+	dockerConfigDir string
+}
+
+// NewCraneRegistryPinger creates a RegistryPinger for the registries of the docker config in DOCKER_CONFIG.
+func NewCraneRegistryPinger() RegistryPinger {
+	dockerConfigDir, found := os.LookupEnv("DOCKER_CONFIG")
+	if !found {
+		homeDir, _ := os.UserHomeDir()
+		dockerConfigDir = filepath.Join(homeDir, ".docker")
+	}
+
+	return &craneRegistryPinger{dockerConfigDir: dockerConfigDir}
+}
+
+type dockerConfig struct {
+	Auths map[string]json.RawMessage `json:"auths"`
+}
+
+// Registries returns the hosts of the registries in the auths section of the docker config.
+func (p *craneRegistryPinger) Registries() ([]string, error) {
+	configPath := filepath.Join(p.dockerConfigDir, dockerConfigFileName)
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read docker config %s: %w", configPath, err)
+	}
+
+	var parsedConfig dockerConfig
+	err = json.Unmarshal(content, &parsedConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse docker config %s: %w", configPath, err)
+	}
+
+	registries := make([]string, 0, len(parsedConfig.Auths))
+	for key := range parsedConfig.Auths {
+		registries = append(registries, registryHost(key))
+	}
+	slices.Sort(registries)
+
+	return slices.Compact(registries), nil
+}
+
+// registryHost strips the scheme and path of keys like "https://index.docker.io/v1/".
+func registryHost(key string) string {
+	host := key
+	if _, withoutScheme, found := strings.Cut(host, "://"); found {
+		host = withoutScheme
+	}
+	host, _, _ = strings.Cut(host, "/")
+
+	return host
+}
+
+// Ping requests the API version endpoint of the registry. If the registry requires a token, the token is requested as
+// well, so that invalid credentials are noticed.
+func (p *craneRegistryPinger) Ping(ctx context.Context, registry string) error {
+	var options []name.Option
+	stage, err := config.GetStage()
+	if err == nil && stage == config.StageDevelopment {
+		// The registry cannot be reached with the fqdn `k3ces.localdomain`. Therefore, the insecure flag is used.
+		options = append(options, name.Insecure)
+	}
+
+	reg, err := name.NewRegistry(registry, options...)
+	if err != nil {
+		return fmt.Errorf("invalid registry %q: %w", registry, err)
+	}
+
+	authenticator, err := authn.Resolve(ctx, authn.DefaultKeychain, reg)
+	if err != nil {
+		return fmt.Errorf("failed to resolve credentials for registry %s: %w", registry, err)
+	}
+
+	roundTripper, err := proxyTransport()
+	if err != nil {
+		return err
+	}
+
+	_, err = transport.NewWithContext(ctx, reg, authenticator, roundTripper, nil)
+	if err != nil {
+		return fmt.Errorf("failed to reach registry %s: %w", registry, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package imageregistry_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	craneRegistry "github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/imageregistry"
+)
+
+func TestCraneRegistryPinger_Registries(t *testing.T) {
+	t.Run("should return hosts of the docker config", func(t *testing.T) {
+		// given
+		dockerConfigDir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dockerConfigDir, "config.json"), []byte(`{"auths": {
+			"registry.cloudogu.com": {"auth": "dXNlcjpwYXNz"},
+			"https://index.docker.io/v1/": {"auth": "dXNlcjpwYXNz"},
+			"https://registry.cloudogu.com": {"auth": "dXNlcjpwYXNz"}
+		}}`), 0600)
+		require.NoError(t, err)
+		t.Setenv("DOCKER_CONFIG", dockerConfigDir)
+
+		// when
+		registries, err := imageregistry.NewCraneRegistryPinger().Registries()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"index.docker.io", "registry.cloudogu.com"}, registries)
+	})
+	t.Run("should fail if docker config does not exist", func(t *testing.T) {
+		// given
+		t.Setenv("DOCKER_CONFIG", t.TempDir())
+
+		// when
+		_, err := imageregistry.NewCraneRegistryPinger().Registries()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to read docker config")
+	})
+	t.Run("should fail if docker config is invalid", func(t *testing.T) {
+		// given
+		dockerConfigDir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dockerConfigDir, "config.json"), []byte("{"), 0600)
+		require.NoError(t, err)
+		t.Setenv("DOCKER_CONFIG", dockerConfigDir)
+
+		// when
+		_, err = imageregistry.NewCraneRegistryPinger().Registries()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to parse docker config")
+	})
+}
+
+func TestCraneRegistryPinger_Ping(t *testing.T) {
+	// other tests configure a proxy for the shared default transport
+	remote.DefaultTransport.(*http.Transport).Proxy = nil
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+
+	t.Run("should reach registry", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(craneRegistry.New())
+		defer server.Close()
+		serverURL, err := url.Parse(server.URL)
+		require.NoError(t, err)
+
+		// when
+		err = imageregistry.NewCraneRegistryPinger().Ping(context.Background(), serverURL.Host)
+
+		// then
+		assert.NoError(t, err)
+	})
+	t.Run("should fail if registry is not reachable", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(craneRegistry.New())
+		serverURL, err := url.Parse(server.URL)
+		require.NoError(t, err)
+		server.Close()
+
+		// when
+		err = imageregistry.NewCraneRegistryPinger().Ping(context.Background(), serverURL.Host)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to reach registry "+serverURL.Host)
+	})
+	t.Run("should fail for invalid registry", func(t *testing.T) {
+		// when
+		err := imageregistry.NewCraneRegistryPinger().Ping(context.Background(), "in valid")
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid registry")
+	})
+}
@@ -8,6 +8,7 @@ import (
 
 	authRegApiV1 "github.com/cloudogu/k8s-auth-registration-lib/api/v1"
 	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	operatorv1 "github.com/cloudogu/k8s-dogu-operator/v3/api/v1"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/health"
 
@@ -40,6 +41,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(doguv2.AddToScheme(scheme))
 	utilruntime.Must(authRegApiV1.AddToScheme(scheme))
+	utilruntime.Must(operatorv1.AddToScheme(scheme))
 }
 
 func NewControllerManager(
@@ -107,11 +109,9 @@ func NewManagerOptions(args Args, operatorConfig *config.OperatorConfig) (manage
 	}
 
 	return ctrl.Options{
-		Scheme:  scheme,
-		Metrics: server.Options{BindAddress: *metricsAddr},
-		Cache: cache.Options{DefaultNamespaces: map[string]cache.Config{
-			operatorConfig.Namespace: {},
-		}},
+		Scheme:                 scheme,
+		Metrics:                server.Options{BindAddress: *metricsAddr},
+		Cache:                  cache.Options{DefaultNamespaces: watchedNamespaces(operatorConfig)},
 		WebhookServer:          webhook.NewServer(webhook.Options{Port: 9443}),
 		HealthProbeBindAddress: *probeAddr,
 		LeaderElection:         *enableLeaderElection,
@@ -126,6 +126,17 @@ func NewManagerOptions(args Args, operatorConfig *config.OperatorConfig) (manage
 	}, nil
 }
 
+// watchedNamespaces restricts the cache to the namespaces in which dogus are reconciled.
+// cache.AllNamespaces is an empty string as well, so watching all namespaces needs no special treatment.
+func watchedNamespaces(operatorConfig *config.OperatorConfig) map[string]cache.Config {
+	namespaces := make(map[string]cache.Config, len(operatorConfig.WatchNamespaces))
+	for _, namespace := range operatorConfig.WatchNamespaces {
+		namespaces[namespace] = cache.Config{}
+	}
+
+	return namespaces
+}
+
 func noAggregationKey(_ *v1.Event) (string, string) {
 	uniqueEventGroup := uuid.NewString()
 	return uniqueEventGroup, uniqueEventGroup
@@ -135,16 +146,13 @@ func noSpamKey(_ *v1.Event) string {
 	return uuid.NewString()
 }
 
+// addChecks adds the liveness check of the operator. The readiness checks are added by AddReadinessChecks, because
+// they need clients which depend on the manager.
 func addChecks(mgr manager.Manager) error {
 	err := mgr.AddHealthzCheck("healthz", healthz.Ping)
 	if err != nil {
 		return fmt.Errorf("failed to add healthz check: %w", err)
 	}
 
-	err = mgr.AddReadyzCheck("readyz", healthz.Ping)
-	if err != nil {
-		return fmt.Errorf("failed to add readyz check: %w", err)
-	}
-
 	return nil
 }
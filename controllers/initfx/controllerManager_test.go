@@ -53,24 +53,10 @@ func Test_addChecks(t *testing.T) {
 		assert.Error(t, err)
 		assert.ErrorContains(t, err, "failed to add healthz check:")
 	})
-	t.Run("should fail to add ready check", func(t *testing.T) {
+	t.Run("should add health check", func(t *testing.T) {
 		// given
 		managerMock := newMockK8sManager(t)
 		managerMock.EXPECT().AddHealthzCheck("healthz", mock.AnythingOfType("healthz.Checker")).Return(nil)
-		managerMock.EXPECT().AddReadyzCheck("readyz", mock.AnythingOfType("healthz.Checker")).Return(assert.AnError)
-
-		// when
-		err := addChecks(managerMock)
-
-		// then
-		assert.Error(t, err)
-		assert.ErrorContains(t, err, "failed to add readyz check:")
-	})
-	t.Run("should fail to add ready check", func(t *testing.T) {
-		// given
-		managerMock := newMockK8sManager(t)
-		managerMock.EXPECT().AddHealthzCheck("healthz", mock.AnythingOfType("healthz.Checker")).Return(nil)
-		managerMock.EXPECT().AddReadyzCheck("readyz", mock.AnythingOfType("healthz.Checker")).Return(nil)
 
 		// when
 		err := addChecks(managerMock)
@@ -83,16 +69,16 @@ func Test_addChecks(t *testing.T) {
 func TestNewManagerOptions(t *testing.T) {
 	t.Run("should create manager options", func(t *testing.T) {
 		// given
-		operatorConfig := &config.OperatorConfig{}
+		operatorConfig := &config.OperatorConfig{Namespace: "ecosystem", WatchNamespaces: []string{"ecosystem"}}
 
 		// when
-		managerOptions, err := NewManagerOptions(Args{"1"}, &config.OperatorConfig{})
+		managerOptions, err := NewManagerOptions(Args{"1"}, operatorConfig)
 		require.NoError(t, err)
 
 		// then
 		assert.Equal(t, server.Options{BindAddress: ":8080"}, managerOptions.Metrics)
 		assert.Equal(t, cache.Options{DefaultNamespaces: map[string]cache.Config{
-			operatorConfig.Namespace: {},
+			"ecosystem": {},
 		}}, managerOptions.Cache)
 		assert.Equal(t, webhook.NewServer(webhook.Options{Port: 9443}), managerOptions.WebhookServer)
 		assert.Equal(t, ":8081", managerOptions.HealthProbeBindAddress)
@@ -101,6 +87,29 @@ func TestNewManagerOptions(t *testing.T) {
 	})
 }
 
+func Test_watchedNamespaces(t *testing.T) {
+	t.Run("should cache all configured namespaces", func(t *testing.T) {
+		// given
+		operatorConfig := &config.OperatorConfig{Namespace: "ecosystem", WatchNamespaces: []string{"test", "stage"}}
+
+		// when
+		namespaces := watchedNamespaces(operatorConfig)
+
+		// then
+		assert.Equal(t, map[string]cache.Config{"test": {}, "stage": {}}, namespaces)
+	})
+	t.Run("should cache all namespaces", func(t *testing.T) {
+		// given
+		operatorConfig := &config.OperatorConfig{Namespace: "ecosystem", WatchNamespaces: []string{""}}
+
+		// when
+		namespaces := watchedNamespaces(operatorConfig)
+
+		// then
+		assert.Equal(t, map[string]cache.Config{cache.AllNamespaces: {}}, namespaces)
+	})
+}
+
 func Test_getArgs(t *testing.T) {
 	t.Run("should return args", func(t *testing.T) {
 		// when
@@ -143,7 +152,7 @@ func TestNewControllerManager(t *testing.T) {
 					return managerOptions
 				},
 				shutdownHandlerFn: func(t *testing.T) health.HealthShutdownHandler {
-					return health.NewShutdownHandler(newMockDoguInterface(t))
+					return health.NewShutdownHandler(newMockDoguInterface(t), &config.OperatorConfig{})
 				},
 			},
 			wantManagerNotNil: false,
@@ -4,6 +4,7 @@ import (
 	authRegClientV1 "github.com/cloudogu/k8s-auth-registration-lib/client/typed/api/v1"
 	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/namespaced"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	appsv1 "k8s.io/client-go/kubernetes/typed/apps/v1"
@@ -36,40 +37,43 @@ func NewK8sClient(mgr manager.Manager) client.Client {
 	return mgr.GetClient()
 }
 
+// The namespaced clients below send their requests to the namespace of the reconciled resource
+// (see namespaced.WithNamespace) and fall back to the operator namespace otherwise.
+
 func NewDoguInterface(ecosystemClientSet doguClient.EcoSystemV2Interface, config *config.OperatorConfig) doguClient.DoguInterface {
-	return ecosystemClientSet.Dogus(config.Namespace)
+	return namespaced.NewDoguInterface(ecosystemClientSet, config.Namespace)
 }
 
 func NewDoguRestartInterface(ecosystemClientSet doguClient.EcoSystemV2Interface, config *config.OperatorConfig) doguClient.DoguRestartInterface {
-	return ecosystemClientSet.DoguRestarts(config.Namespace)
+	return namespaced.NewDoguRestartInterface(ecosystemClientSet, config.Namespace)
 }
 
 func NewAuthRegistrationInterface(authRegistrationClientSet authRegClientV1.ApiV1Interface, operatorConfig *config.OperatorConfig) authRegClientV1.AuthRegistrationInterface {
-	return authRegistrationClientSet.AuthRegistrations(operatorConfig.Namespace)
+	return namespaced.NewAuthRegistrationInterface(authRegistrationClientSet, operatorConfig.Namespace)
 }
 
 func NewConfigMapInterface(clientSet kubernetes.Interface, operatorConfig *config.OperatorConfig) v1.ConfigMapInterface {
-	return clientSet.CoreV1().ConfigMaps(operatorConfig.Namespace)
+	return namespaced.NewConfigMapInterface(clientSet, operatorConfig.Namespace)
 }
 
 func NewSecretInterface(clientSet kubernetes.Interface, operatorConfig *config.OperatorConfig) v1.SecretInterface {
-	return clientSet.CoreV1().Secrets(operatorConfig.Namespace)
+	return namespaced.NewSecretInterface(clientSet, operatorConfig.Namespace)
 }
 
 func NewDeploymentInterface(clientSet kubernetes.Interface, operatorConfig *config.OperatorConfig) appsv1.DeploymentInterface {
-	return clientSet.AppsV1().Deployments(operatorConfig.Namespace)
+	return namespaced.NewDeploymentInterface(clientSet, operatorConfig.Namespace)
 }
 
 func NewPodInterface(clientSet kubernetes.Interface, operatorConfig *config.OperatorConfig) v1.PodInterface {
-	return clientSet.CoreV1().Pods(operatorConfig.Namespace)
+	return namespaced.NewPodInterface(clientSet, operatorConfig.Namespace)
 }
 
 func NewServiceInterface(clientSet kubernetes.Interface, operatorConfig *config.OperatorConfig) v1.ServiceInterface {
-	return clientSet.CoreV1().Services(operatorConfig.Namespace)
+	return namespaced.NewServiceInterface(clientSet, operatorConfig.Namespace)
 }
 
 func NewPersistentVolumeClaimInterface(clientSet kubernetes.Interface, operatorConfig *config.OperatorConfig) v1.PersistentVolumeClaimInterface {
-	return clientSet.CoreV1().PersistentVolumeClaims(operatorConfig.Namespace)
+	return namespaced.NewPersistentVolumeClaimInterface(clientSet, operatorConfig.Namespace)
 }
 
 func NewEventRecorder(mgr manager.Manager) record.EventRecorder {
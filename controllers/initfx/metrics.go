@@ -0,0 +1,12 @@
+package initfx
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// NewMetricsRegisterer returns the registry of the controller-runtime so that the metrics of the operator
+// are served by the metrics endpoint of the manager.
+func NewMetricsRegisterer() prometheus.Registerer {
+	return ctrlmetrics.Registry
+}
@@ -0,0 +1,52 @@
+package initfx
+
+import (
+	"fmt"
+
+	"github.com/cloudogu/ces-commons-lib/dogu"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/imageregistry"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/readiness"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const globalConfigMapName = "global-config"
+
+// requiredConfigMaps are read from the namespace of the operator when dogus are installed or started.
+var requiredConfigMaps = []string{config.OperatorAdditionalImagesConfigmapName, globalConfigMapName}
+
+type namedCheck struct {
+	name    string
+	checker healthz.Checker
+}
+
+// AddReadinessChecks adds a readiness check for each dependency of the operator, so that /readyz?verbose lists the
+// dependencies which are not available. The readiness probe of the helm chart excludes the registry checks: the pod
+// of the operator also serves the admission webhooks, which would reject every change of a dogu resource while a
+// remote registry is unreachable.
+func AddReadinessChecks(
+	mgr manager.Manager,
+	remoteDoguRepository dogu.RemoteDoguDescriptorRepository,
+	registryPinger imageregistry.RegistryPinger,
+	configMapInterface corev1.ConfigMapInterface,
+) error {
+	checks := []namedCheck{
+		{name: "cache-sync", checker: readiness.NewCacheSyncCheck(mgr.GetCache())},
+		{name: "dogu-registry", checker: readiness.NewDoguRegistryCheck(remoteDoguRepository)},
+		{name: "container-registry", checker: readiness.NewContainerRegistryCheck(registryPinger)},
+	}
+	for _, configMapName := range requiredConfigMaps {
+		checks = append(checks, namedCheck{name: "configmap-" + configMapName, checker: readiness.NewConfigMapCheck(configMapInterface, configMapName)})
+	}
+
+	for _, check := range checks {
+		err := mgr.AddReadyzCheck(check.name, check.checker)
+		if err != nil {
+			return fmt.Errorf("failed to add readyz check %s: %w", check.name, err)
+		}
+	}
+
+	return nil
+}
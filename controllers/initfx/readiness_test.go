@@ -0,0 +1,40 @@
+package initfx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddReadinessChecks(t *testing.T) {
+	t.Run("should add a readiness check for each dependency", func(t *testing.T) {
+		// given
+		managerMock := newMockK8sManager(t)
+		managerMock.EXPECT().GetCache().Return(nil)
+		for _, name := range []string{"cache-sync", "dogu-registry", "container-registry", "configmap-k8s-dogu-operator-additional-images", "configmap-global-config"} {
+			managerMock.EXPECT().AddReadyzCheck(name, mock.AnythingOfType("healthz.Checker")).Return(nil).Once()
+		}
+
+		// when
+		err := AddReadinessChecks(managerMock, nil, nil, newMockConfigMapInterface(t))
+
+		// then
+		assert.NoError(t, err)
+	})
+	t.Run("should fail to add readiness check", func(t *testing.T) {
+		// given
+		managerMock := newMockK8sManager(t)
+		managerMock.EXPECT().GetCache().Return(nil)
+		managerMock.EXPECT().AddReadyzCheck("cache-sync", mock.AnythingOfType("healthz.Checker")).Return(assert.AnError)
+
+		// when
+		err := AddReadinessChecks(managerMock, nil, nil, newMockConfigMapInterface(t))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to add readyz check cache-sync")
+	})
+}
@@ -0,0 +1,13 @@
+package initfx
+
+import (
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/tracing"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/client-go/rest"
+)
+
+// DecorateRestConfigWithTracing adds a transport to the rest config which traces all requests of the K8s clients.
+func DecorateRestConfigWithTracing(restConfig *rest.Config, tracerProvider trace.TracerProvider) *rest.Config {
+	restConfig.Wrap(tracing.NewTransportWrapper(tracerProvider))
+	return restConfig
+}
@@ -0,0 +1,36 @@
+package initfx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"k8s.io/client-go/rest"
+)
+
+func TestDecorateRestConfigWithTracing(t *testing.T) {
+	// given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	// when
+	restConfig := DecorateRestConfigWithTracing(&rest.Config{Host: server.URL}, provider)
+
+	// then
+	httpClient, err := rest.HTTPClientFor(restConfig)
+	require.NoError(t, err)
+	resp, err := httpClient.Get(server.URL + "/api")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.Len(t, recorder.Ended(), 1)
+	assert.Equal(t, "HTTP GET", recorder.Ended()[0].Name())
+}
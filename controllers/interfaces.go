@@ -6,6 +6,11 @@ import (
 
 	"github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/coordination"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/ecosystemupgrade"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/health"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/metrics"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/plan"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -59,9 +64,29 @@ type RequeueHandler interface {
 	Handle(ctx context.Context, doguResource *v2.Dogu, err error, reqTime time.Duration) (result ctrl.Result, requeueErr error)
 }
 
+type requeueRecorder interface {
+	metrics.RequeueRecorder
+}
+
+type doguPlanner interface {
+	plan.Planner
+}
+
+type doguLocker interface {
+	coordination.Locker
+}
+
+type dependentDoguMapper interface {
+	health.DependentDoguMapper
+}
+
 type DoguInstallOrChangeUseCase interface {
 	DoguUsecase
 }
 type DoguDeleteUseCase interface {
 	DoguUsecase
 }
+
+type ecosystemUpgradePlanner interface {
+	ecosystemupgrade.Planner
+}
@@ -0,0 +1,20 @@
+package journal
+
+import (
+	"context"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// ExecutionJournal records the steps that have been run while reconciling a dogu.
+type ExecutionJournal interface {
+	// Record appends the run to the execution journal of the dogu.
+	Record(ctx context.Context, doguResource *v2.Dogu, run Run) error
+}
+
+//nolint:unused
+//goland:noinspection GoUnusedType
+type configMapInterface interface {
+	v1.ConfigMapInterface
+}
@@ -0,0 +1,78 @@
+package journal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/dogustore"
+	"k8s.io/apimachinery/pkg/runtime"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+const (
+	configMapNameSuffix = "-execution-journal"
+	// JournalKey is the key in the journal config map that contains the recorded runs as JSON.
+	JournalKey = "runs"
+	// JournalLabel marks config maps that contain the execution journal of a dogu.
+	JournalLabel = "k8s.cloudogu.com/execution-journal"
+)
+
+// ConfigMapName returns the name of the config map containing the execution journal of the dogu.
+func ConfigMapName(doguName string) string {
+	return doguName + configMapNameSuffix
+}
+
+type configMapJournal struct {
+	store        *dogustore.ConfigMapStore
+	historyLimit int
+}
+
+// NewConfigMapJournal creates an ExecutionJournal which stores the latest runs of each dogu in a config map.
+func NewConfigMapJournal(configMapInterface v1.ConfigMapInterface, scheme *runtime.Scheme, operatorConfig *config.OperatorConfig) ExecutionJournal {
+	return &configMapJournal{
+		store:        dogustore.NewConfigMapStore(configMapInterface, scheme, configMapNameSuffix, JournalLabel),
+		historyLimit: operatorConfig.ExecutionJournalHistoryLimit,
+	}
+}
+
+// Record appends the run to the execution journal config map of the dogu.
+func (j *configMapJournal) Record(ctx context.Context, doguResource *v2.Dogu, run Run) error {
+	err := j.store.Update(ctx, doguResource, func(data map[string]string) error {
+		// a broken journal must not block recording new runs, so it gets replaced
+		history, _ := parseRuns(data)
+		return setRuns(data, appendRun(history, run, j.historyLimit))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record run in execution journal of dogu %q: %w", doguResource.Name, err)
+	}
+
+	return nil
+}
+
+func setRuns(data map[string]string, runs []Run) error {
+	runsJson, err := json.Marshal(runs)
+	if err != nil {
+		return fmt.Errorf("failed to serialize execution journal: %w", err)
+	}
+	data[JournalKey] = string(runsJson)
+
+	return nil
+}
+
+func parseRuns(data map[string]string) ([]Run, error) {
+	raw, ok := data[JournalKey]
+	if !ok || raw == "" {
+		return nil, nil
+	}
+
+	var runs []Run
+	err := json.Unmarshal([]byte(raw), &runs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse execution journal: %w", err)
+	}
+
+	return runs, nil
+}
@@ -0,0 +1,176 @@
+package journal
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/dogustore"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var testCtx = context.Background()
+
+func getTestScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypeWithName(schema.GroupVersionKind{
+		Group:   "k8s.cloudogu.com",
+		Version: "v2",
+		Kind:    "Dogu",
+	}, &v2.Dogu{})
+	return scheme
+}
+
+func getTestDogu() *v2.Dogu {
+	return &v2.Dogu{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: "ecosystem", UID: "uid"},
+	}
+}
+
+func getTestRun() Run {
+	run := NewRun(testTime)
+	run.AddStep("validation", steps.RequeueWithError(assert.AnError), time.Millisecond)
+	return *run
+}
+
+func newTestJournal(cmMock *mockConfigMapInterface, scheme *runtime.Scheme) *configMapJournal {
+	return &configMapJournal{store: dogustore.NewConfigMapStore(cmMock, scheme, configMapNameSuffix, JournalLabel), historyLimit: 3}
+}
+
+func TestNewConfigMapJournal(t *testing.T) {
+	got := NewConfigMapJournal(newMockConfigMapInterface(t), getTestScheme(), &config.OperatorConfig{ExecutionJournalHistoryLimit: 5})
+
+	require.NotNil(t, got)
+	assert.Equal(t, 5, got.(*configMapJournal).historyLimit)
+}
+
+func Test_configMapJournal_Record(t *testing.T) {
+	notFoundErr := errors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "ldap-execution-journal")
+
+	t.Run("should create journal config map with non-controlling owner reference", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-execution-journal", metav1.GetOptions{}).Return(nil, notFoundErr)
+		cmMock.EXPECT().Create(testCtx, mock.Anything, metav1.CreateOptions{}).
+			RunAndReturn(func(ctx context.Context, cm *corev1.ConfigMap, opts metav1.CreateOptions) (*corev1.ConfigMap, error) {
+				assert.Equal(t, "ldap-execution-journal", cm.Name)
+				assert.Equal(t, "ecosystem", cm.Namespace)
+				assert.Equal(t, "true", cm.Labels[JournalLabel])
+				assert.Equal(t, "ldap", cm.Labels["dogu.name"])
+				require.Len(t, cm.OwnerReferences, 1)
+				assert.Equal(t, "Dogu", cm.OwnerReferences[0].Kind)
+				assert.Equal(t, "k8s.cloudogu.com/v2", cm.OwnerReferences[0].APIVersion)
+				assert.Nil(t, cm.OwnerReferences[0].Controller)
+
+				runs, err := parseRuns(cm.Data)
+				require.NoError(t, err)
+				require.Len(t, runs, 1)
+				assert.Equal(t, "validation", runs[0].FinalStep)
+				return cm, nil
+			})
+		sut := newTestJournal(cmMock, getTestScheme())
+
+		// when
+		err := sut.Record(testCtx, getTestDogu(), getTestRun())
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should collapse run into existing journal", func(t *testing.T) {
+		// given
+		existing := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "ldap-execution-journal"}, Data: map[string]string{}}
+		require.NoError(t, setRuns(existing.Data, []Run{getTestRun()}))
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-execution-journal", metav1.GetOptions{}).Return(existing, nil)
+		cmMock.EXPECT().Update(testCtx, mock.Anything, metav1.UpdateOptions{}).
+			RunAndReturn(func(ctx context.Context, cm *corev1.ConfigMap, opts metav1.UpdateOptions) (*corev1.ConfigMap, error) {
+				runs, err := parseRuns(cm.Data)
+				require.NoError(t, err)
+				require.Len(t, runs, 1)
+				assert.Equal(t, 2, runs[0].Count)
+				return cm, nil
+			})
+		sut := newTestJournal(cmMock, getTestScheme())
+
+		// when
+		err := sut.Record(testCtx, getTestDogu(), getTestRun())
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should replace broken journal", func(t *testing.T) {
+		// given
+		existing := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "ldap-execution-journal"},
+			Data:       map[string]string{JournalKey: "{invalid"},
+		}
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-execution-journal", metav1.GetOptions{}).Return(existing, nil)
+		cmMock.EXPECT().Update(testCtx, mock.Anything, metav1.UpdateOptions{}).
+			RunAndReturn(func(ctx context.Context, cm *corev1.ConfigMap, opts metav1.UpdateOptions) (*corev1.ConfigMap, error) {
+				var runs []Run
+				require.NoError(t, json.Unmarshal([]byte(cm.Data[JournalKey]), &runs))
+				assert.Len(t, runs, 1)
+				return cm, nil
+			})
+		sut := newTestJournal(cmMock, getTestScheme())
+
+		// when
+		err := sut.Record(testCtx, getTestDogu(), getTestRun())
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail to get journal config map", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-execution-journal", metav1.GetOptions{}).Return(nil, assert.AnError)
+		sut := newTestJournal(cmMock, getTestScheme())
+
+		// when
+		err := sut.Record(testCtx, getTestDogu(), getTestRun())
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to record run in execution journal of dogu \"ldap\"")
+	})
+	t.Run("should fail to get kind of dogu", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-execution-journal", metav1.GetOptions{}).Return(nil, notFoundErr)
+		sut := newTestJournal(cmMock, runtime.NewScheme())
+
+		// when
+		err := sut.Record(testCtx, getTestDogu(), getTestRun())
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to record run in execution journal")
+	})
+	t.Run("should fail to update journal config map", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-execution-journal", metav1.GetOptions{}).Return(&corev1.ConfigMap{}, nil)
+		cmMock.EXPECT().Update(testCtx, mock.Anything, metav1.UpdateOptions{}).Return(nil, assert.AnError)
+		sut := newTestJournal(cmMock, getTestScheme())
+
+		// when
+		err := sut.Record(testCtx, getTestDogu(), getTestRun())
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -0,0 +1,106 @@
+package journal
+
+import (
+	"slices"
+	"time"
+
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StepRecord describes the execution of a single step during a reconcile run.
+type StepRecord struct {
+	// Name is the name of the step like "validation" in the step registry.
+	Name string `json:"name"`
+	// Outcome describes whether the step continued, requeued, aborted or failed.
+	Outcome steps.Outcome `json:"outcome"`
+	// Duration is the time the step needed to run.
+	Duration metav1.Duration `json:"duration"`
+}
+
+// Run describes a reconcile run of a dogu resource.
+// Consecutive runs with the same result are collapsed into one run which counts the repetitions.
+type Run struct {
+	// FirstStartedAt is the start time of the first run of consecutive identical runs.
+	FirstStartedAt metav1.Time `json:"firstStartedAt"`
+	// LastStartedAt is the start time of the latest run of consecutive identical runs.
+	LastStartedAt metav1.Time `json:"lastStartedAt"`
+	// Count is the number of consecutive identical runs.
+	Count int `json:"count"`
+	// Duration is the time the latest run needed.
+	Duration metav1.Duration `json:"duration"`
+	// FinalStep is the name of the last step that has been run.
+	FinalStep string `json:"finalStep,omitempty"`
+	// Outcome is the outcome of the final step.
+	Outcome steps.Outcome `json:"outcome"`
+	// RequeueAfter is the requeue time the final step requested.
+	RequeueAfter metav1.Duration `json:"requeueAfter,omitempty"`
+	// Error contains the error message of the final step if it failed.
+	Error string `json:"error,omitempty"`
+	// Steps contains all steps that have been run in the latest run.
+	Steps []StepRecord `json:"steps"`
+}
+
+// NewRun starts a new run at the given time.
+func NewRun(startedAt time.Time) *Run {
+	return &Run{
+		FirstStartedAt: metav1.NewTime(startedAt),
+		LastStartedAt:  metav1.NewTime(startedAt),
+		Count:          1,
+		Outcome:        steps.OutcomeContinue,
+		Steps:          []StepRecord{},
+	}
+}
+
+// AddStep records the result of a step.
+func (r *Run) AddStep(name string, result steps.StepResult, duration time.Duration) {
+	outcome := result.Outcome()
+	r.Steps = append(r.Steps, StepRecord{
+		Name:     name,
+		Outcome:  outcome,
+		Duration: metav1.Duration{Duration: duration},
+	})
+	r.FinalStep = name
+	r.Outcome = outcome
+	r.RequeueAfter = metav1.Duration{Duration: result.RequeueAfter}
+	r.Error = ""
+	if result.Err != nil {
+		r.Error = result.Err.Error()
+	}
+}
+
+// Finish marks the run as finished at the given time.
+func (r *Run) Finish(finishedAt time.Time) {
+	r.Duration = metav1.Duration{Duration: finishedAt.Sub(r.LastStartedAt.Time)}
+}
+
+// sameResultAs returns true if both runs executed the same steps with the same outcomes and ended with the same result.
+func (r Run) sameResultAs(other Run) bool {
+	if r.FinalStep != other.FinalStep || r.Outcome != other.Outcome || r.Error != other.Error {
+		return false
+	}
+
+	return slices.EqualFunc(r.Steps, other.Steps, func(a StepRecord, b StepRecord) bool {
+		return a.Name == b.Name && a.Outcome == b.Outcome
+	})
+}
+
+// appendRun adds the run to the history, collapses it with the latest run if both have the same result and
+// drops the oldest runs exceeding the limit. The history is ordered from oldest to newest.
+func appendRun(history []Run, run Run, limit int) []Run {
+	if len(history) > 0 {
+		latest := history[len(history)-1]
+		if latest.sameResultAs(run) {
+			run.FirstStartedAt = latest.FirstStartedAt
+			run.Count = latest.Count + 1
+			history = history[:len(history)-1]
+		}
+	}
+
+	history = append(history, run)
+	if limit > 0 && len(history) > limit {
+		history = history[len(history)-limit:]
+	}
+
+	return history
+}
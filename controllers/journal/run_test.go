@@ -0,0 +1,105 @@
+package journal
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var testTime = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func TestRun_AddStep(t *testing.T) {
+	t.Run("should record steps and the result of the final step", func(t *testing.T) {
+		// given
+		run := NewRun(testTime)
+
+		// when
+		run.AddStep("install.InitializeConditionsStep", steps.Continue(), time.Millisecond)
+		run.AddStep("validation", steps.RequeueWithError(assert.AnError), 2*time.Millisecond)
+		run.Finish(testTime.Add(time.Second))
+
+		// then
+		assert.Equal(t, 1, run.Count)
+		assert.Equal(t, "validation", run.FinalStep)
+		assert.Equal(t, steps.OutcomeError, run.Outcome)
+		assert.Equal(t, assert.AnError.Error(), run.Error)
+		assert.Equal(t, time.Second, run.Duration.Duration)
+		assert.Equal(t, []StepRecord{
+			{Name: "install.InitializeConditionsStep", Outcome: steps.OutcomeContinue, Duration: metav1.Duration{Duration: time.Millisecond}},
+			{Name: "validation", Outcome: steps.OutcomeError, Duration: metav1.Duration{Duration: 2 * time.Millisecond}},
+		}, run.Steps)
+	})
+	t.Run("should record requeue time", func(t *testing.T) {
+		// given
+		run := NewRun(testTime)
+
+		// when
+		run.AddStep("upgrade.UpdateDeploymentVersionStep", steps.RequeueAfter(3*time.Second), time.Millisecond)
+
+		// then
+		assert.Equal(t, steps.OutcomeRequeue, run.Outcome)
+		assert.Equal(t, 3*time.Second, run.RequeueAfter.Duration)
+		assert.Empty(t, run.Error)
+	})
+	t.Run("should continue if no step was run", func(t *testing.T) {
+		run := NewRun(testTime)
+
+		assert.Equal(t, steps.OutcomeContinue, run.Outcome)
+		assert.Empty(t, run.FinalStep)
+	})
+}
+
+func Test_appendRun(t *testing.T) {
+	requeueRun := func(startedAt time.Time, err error) Run {
+		run := NewRun(startedAt)
+		run.AddStep("install.InitializeConditionsStep", steps.Continue(), time.Millisecond)
+		run.AddStep("validation", steps.RequeueWithError(err), time.Millisecond)
+		return *run
+	}
+	successRun := func(startedAt time.Time) Run {
+		run := NewRun(startedAt)
+		run.AddStep("install.InitializeConditionsStep", steps.Continue(), time.Millisecond)
+		return *run
+	}
+
+	t.Run("should append run to empty history", func(t *testing.T) {
+		got := appendRun(nil, successRun(testTime), 3)
+
+		assert.Len(t, got, 1)
+		assert.Equal(t, 1, got[0].Count)
+	})
+	t.Run("should collapse consecutive identical runs", func(t *testing.T) {
+		history := appendRun(nil, requeueRun(testTime, assert.AnError), 3)
+
+		got := appendRun(history, requeueRun(testTime.Add(40*time.Minute), assert.AnError), 3)
+
+		assert.Len(t, got, 1)
+		assert.Equal(t, 2, got[0].Count)
+		assert.Equal(t, testTime, got[0].FirstStartedAt.Time)
+		assert.Equal(t, testTime.Add(40*time.Minute), got[0].LastStartedAt.Time)
+	})
+	t.Run("should not collapse runs with different errors", func(t *testing.T) {
+		history := appendRun(nil, requeueRun(testTime, assert.AnError), 3)
+
+		got := appendRun(history, requeueRun(testTime.Add(time.Minute), errors.New("dependency is not healthy")), 3)
+
+		assert.Len(t, got, 2)
+		assert.Equal(t, 1, got[0].Count)
+		assert.Equal(t, 1, got[1].Count)
+		assert.Equal(t, "dependency is not healthy", got[1].Error)
+	})
+	t.Run("should drop oldest runs exceeding the limit", func(t *testing.T) {
+		var got []Run
+		got = appendRun(got, successRun(testTime), 2)
+		got = appendRun(got, requeueRun(testTime.Add(time.Minute), assert.AnError), 2)
+		got = appendRun(got, successRun(testTime.Add(2*time.Minute)), 2)
+
+		assert.Len(t, got, 2)
+		assert.Equal(t, steps.OutcomeError, got[0].Outcome)
+		assert.Equal(t, testTime.Add(2*time.Minute), got[1].FirstStartedAt.Time)
+	})
+}
@@ -11,7 +11,6 @@ import (
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/cesregistry"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/resource"
-	"github.com/cloudogu/retry-lib/retry"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -26,6 +25,7 @@ const (
 type doguAdditionalMountManager struct {
 	deploymentInterface          deploymentInterface
 	resourceGenerator            resourceGenerator
+	resourceUpserter             resourceUpserter
 	localDoguFetcher             localDoguFetcher
 	requirementsGenerator        requirementsGenerator
 	doguAdditionalMountValidator doguAdditionalMountsValidator
@@ -35,6 +35,7 @@ type doguAdditionalMountManager struct {
 func NewDoguAdditionalMountManager(
 	deploymentInterface appsv1client.DeploymentInterface,
 	resourceGenerator resource.DoguResourceGenerator,
+	upserter resource.ResourceUpserter,
 	fetcher cesregistry.LocalDoguFetcher,
 	requirementsGenerator resource.RequirementsGenerator,
 	additionalMountValidator additionalMount.Validator,
@@ -43,6 +44,7 @@ func NewDoguAdditionalMountManager(
 	return &doguAdditionalMountManager{
 		deploymentInterface:          deploymentInterface,
 		resourceGenerator:            resourceGenerator,
+		resourceUpserter:             upserter,
 		localDoguFetcher:             fetcher,
 		requirementsGenerator:        requirementsGenerator,
 		doguAdditionalMountValidator: additionalMountValidator,
@@ -133,42 +135,13 @@ func (m *doguAdditionalMountManager) UpdateAdditionalMounts(ctx context.Context,
 		return fmt.Errorf("additional mounts are not valid for dogu %s: %w", doguResource.Name, err)
 	}
 
-	container, err := m.createAdditionalMountInitContainer(ctx, doguResource)
-	if err != nil {
-		return err
-	}
-
-	volumes, err := resource.CreateVolumes(doguResource, dogu, doguResource.Spec.ExportMode)
-	if err != nil {
-		return err
-	}
-
-	err = retry.OnConflict(func() error {
-		deployment, retryErr := m.getDoguDeployment(ctx, doguResource)
-		if retryErr != nil {
-			return retryErr
-		}
-
-		var updatedInitContainers []corev1.Container
-		for _, c := range deployment.Spec.Template.Spec.InitContainers {
-			if c.Name != additionalMountsInitContainerName {
-				updatedInitContainers = append(updatedInitContainers, c)
-			}
-		}
-		updatedInitContainers = append(updatedInitContainers, *container)
-		deployment.Spec.Template.Spec.InitContainers = updatedInitContainers
-		deployment.Spec.Template.Spec.Volumes = volumes
-
-		_, retryErr = m.deploymentInterface.Update(ctx, deployment, v1.UpdateOptions{})
-
-		return retryErr
-	})
-
+	// the generated deployment contains the init container and the volumes of the additional mounts
+	_, err = m.resourceUpserter.UpsertDoguDeployment(ctx, doguResource, dogu, nil)
 	if err != nil {
 		return fmt.Errorf("failed to update deployment additional mounts for dogu %s: %w", doguResource.Name, err)
 	}
 
 	logger.Info(fmt.Sprintf("Successfully updated additional mounts for dogu resource %s", doguResource.Name))
 
-	return err
+	return nil
 }
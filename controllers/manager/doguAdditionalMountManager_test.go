@@ -16,9 +16,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 const testNamespace = "ecosystem"
@@ -424,87 +422,26 @@ func TestNewdoguAdditionalMountsManager(t *testing.T) {
 		// given
 		deploymentMock := newMockDeploymentInterface(t)
 		resourceGeneratorMock := newMockResourceGenerator(t)
+		upserterMock := newMockResourceUpserter(t)
 		localDoguFetcherMock := newMockLocalDoguFetcher(t)
 		requirementsGeneratorMock := newMockRequirementsGenerator(t)
 		mountsValidatorMock := newMockDoguAdditionalMountsValidator(t)
 		images := resource.AdditionalImages{config.AdditionalMountsInitContainerImageConfigmapNameKey: "image"}
 
 		// when
-		sut := NewDoguAdditionalMountManager(deploymentMock, resourceGeneratorMock, localDoguFetcherMock, requirementsGeneratorMock, mountsValidatorMock, images)
+		sut := NewDoguAdditionalMountManager(deploymentMock, resourceGeneratorMock, upserterMock, localDoguFetcherMock, requirementsGeneratorMock, mountsValidatorMock, images)
 
 		// then
 		require.NotNil(t, sut)
 		assert.Equal(t, deploymentMock, sut.(*doguAdditionalMountManager).deploymentInterface)
 		assert.Equal(t, resourceGeneratorMock, sut.(*doguAdditionalMountManager).resourceGenerator)
+		assert.Equal(t, upserterMock, sut.(*doguAdditionalMountManager).resourceUpserter)
 		assert.Equal(t, localDoguFetcherMock, sut.(*doguAdditionalMountManager).localDoguFetcher)
 		assert.Equal(t, "image", sut.(*doguAdditionalMountManager).image)
 	})
 }
 
 func Test_doguAdditionalMountsManager_UpdateAdditionalMounts(t *testing.T) {
-	nginxDeploymentWithOutdatedAdditionalMounts := appsv1.Deployment{
-		Spec: appsv1.DeploymentSpec{
-			Template: corev1.PodTemplateSpec{
-				Spec: corev1.PodSpec{
-					InitContainers: []corev1.Container{
-						{
-							Name: "others should be kept",
-						},
-						{
-							Name:  "dogu-additional-mounts-init",
-							Image: "",
-							VolumeMounts: []corev1.VolumeMount{
-								{
-									Name:      "customhtml",
-									MountPath: "/dogumount/customhtml",
-									SubPath:   "customhtml",
-								},
-								{
-									Name:      "oldconfigmap",
-									MountPath: "/datamount/oldconfigmap",
-									SubPath:   "oldconfigmap",
-								},
-							},
-						},
-					},
-				},
-			},
-		},
-	}
-
-	updatedInitContainer := &corev1.Container{
-		Name:  "dogu-additional-mounts-init",
-		Image: "",
-		VolumeMounts: []corev1.VolumeMount{
-			{
-				Name:      "customhtml",
-				MountPath: "/dogumount/customhtml",
-				SubPath:   "customhtml",
-			},
-			{
-				Name:      "configmap",
-				MountPath: "/datamount/configmap",
-				SubPath:   "configmap",
-			},
-		},
-	}
-
-	nginxDeploymentWithNewAdditionalMounts := &appsv1.Deployment{
-		Spec: appsv1.DeploymentSpec{
-			Template: corev1.PodTemplateSpec{
-				Spec: corev1.PodSpec{
-					InitContainers: []corev1.Container{
-						{
-							Name: "others should be kept",
-						},
-						*updatedInitContainer,
-					},
-					Volumes: CreateExpectedVolumes(),
-				},
-			},
-		},
-	}
-
 	nginxDoguResourceWithAdditionalMounts := &v2.Dogu{
 		ObjectMeta: v1.ObjectMeta{
 			Name:      "nginx",
@@ -524,10 +461,8 @@ func Test_doguAdditionalMountsManager_UpdateAdditionalMounts(t *testing.T) {
 	nginxDogu := &core.Dogu{}
 
 	type fields struct {
-		deploymentInterface       func() deploymentInterface
-		resourceGenerator         func() resourceGenerator
+		resourceUpserter          func() resourceUpserter
 		localDoguFetcher          func() localDoguFetcher
-		requirementsGenerator     func() requirementsGenerator
 		additionalMountsValidator func() doguAdditionalMountsValidator
 	}
 	type args struct {
@@ -541,12 +476,11 @@ func Test_doguAdditionalMountsManager_UpdateAdditionalMounts(t *testing.T) {
 		wantErr assert.ErrorAssertionFunc
 	}{
 		{
-			name: "should update existing init container",
+			name: "should apply generated deployment with additional mounts",
 			fields: fields{
-				deploymentInterface: func() deploymentInterface {
-					mock := newMockDeploymentInterface(t)
-					mock.EXPECT().List(testCtx, v1.ListOptions{LabelSelector: "dogu.name=nginx"}).Return(&appsv1.DeploymentList{Items: []appsv1.Deployment{nginxDeploymentWithOutdatedAdditionalMounts}}, nil)
-					mock.EXPECT().Update(testCtx, nginxDeploymentWithNewAdditionalMounts, v1.UpdateOptions{}).Return(nil, nil)
+				resourceUpserter: func() resourceUpserter {
+					mock := newMockResourceUpserter(t)
+					mock.EXPECT().UpsertDoguDeployment(testCtx, nginxDoguResourceWithAdditionalMounts, nginxDogu, (func(*appsv1.Deployment))(nil)).Return(&appsv1.Deployment{}, nil)
 					return mock
 				},
 				localDoguFetcher: func() localDoguFetcher {
@@ -554,16 +488,6 @@ func Test_doguAdditionalMountsManager_UpdateAdditionalMounts(t *testing.T) {
 					mock.EXPECT().FetchInstalled(testCtx, dogu.SimpleName(nginxDoguResourceWithAdditionalMounts.Name)).Return(nginxDogu, nil)
 					return mock
 				},
-				resourceGenerator: func() resourceGenerator {
-					mock := newMockResourceGenerator(t)
-					mock.EXPECT().BuildAdditionalMountInitContainer(testCtx, nginxDogu, nginxDoguResourceWithAdditionalMounts, "", corev1.ResourceRequirements{}).Return(updatedInitContainer, nil)
-					return mock
-				},
-				requirementsGenerator: func() requirementsGenerator {
-					mock := newMockRequirementsGenerator(t)
-					mock.EXPECT().Generate(testCtx, nginxDogu).Return(corev1.ResourceRequirements{}, nil)
-					return mock
-				},
 				additionalMountsValidator: func() doguAdditionalMountsValidator {
 					mock := newMockDoguAdditionalMountsValidator(t)
 					mock.EXPECT().ValidateAdditionalMounts(testCtx, nginxDogu, nginxDoguResourceWithAdditionalMounts).Return(nil)
@@ -577,13 +501,11 @@ func Test_doguAdditionalMountsManager_UpdateAdditionalMounts(t *testing.T) {
 			wantErr: assert.NoError,
 		},
 		{
-			name: "should should retry on conflict error",
+			name: "should return error on failing apply",
 			fields: fields{
-				deploymentInterface: func() deploymentInterface {
-					mock := newMockDeploymentInterface(t)
-					mock.EXPECT().List(testCtx, v1.ListOptions{LabelSelector: "dogu.name=nginx"}).Return(&appsv1.DeploymentList{Items: []appsv1.Deployment{nginxDeploymentWithOutdatedAdditionalMounts}}, nil).Times(2)
-					mock.EXPECT().Update(testCtx, nginxDeploymentWithNewAdditionalMounts, v1.UpdateOptions{}).Return(nil, errors.NewConflict(schema.GroupResource{}, "name", assert.AnError)).Times(1)
-					mock.EXPECT().Update(testCtx, nginxDeploymentWithNewAdditionalMounts, v1.UpdateOptions{}).Return(nil, nil).Times(1)
+				resourceUpserter: func() resourceUpserter {
+					mock := newMockResourceUpserter(t)
+					mock.EXPECT().UpsertDoguDeployment(testCtx, nginxDoguResourceWithAdditionalMounts, nginxDogu, (func(*appsv1.Deployment))(nil)).Return(nil, assert.AnError)
 					return mock
 				},
 				localDoguFetcher: func() localDoguFetcher {
@@ -591,16 +513,6 @@ func Test_doguAdditionalMountsManager_UpdateAdditionalMounts(t *testing.T) {
 					mock.EXPECT().FetchInstalled(testCtx, dogu.SimpleName(nginxDoguResourceWithAdditionalMounts.Name)).Return(nginxDogu, nil)
 					return mock
 				},
-				resourceGenerator: func() resourceGenerator {
-					mock := newMockResourceGenerator(t)
-					mock.EXPECT().BuildAdditionalMountInitContainer(testCtx, nginxDogu, nginxDoguResourceWithAdditionalMounts, "", corev1.ResourceRequirements{}).Return(updatedInitContainer, nil)
-					return mock
-				},
-				requirementsGenerator: func() requirementsGenerator {
-					mock := newMockRequirementsGenerator(t)
-					mock.EXPECT().Generate(testCtx, nginxDogu).Return(corev1.ResourceRequirements{}, nil)
-					return mock
-				},
 				additionalMountsValidator: func() doguAdditionalMountsValidator {
 					mock := newMockDoguAdditionalMountsValidator(t)
 					mock.EXPECT().ValidateAdditionalMounts(testCtx, nginxDogu, nginxDoguResourceWithAdditionalMounts).Return(nil)
@@ -611,34 +523,18 @@ func Test_doguAdditionalMountsManager_UpdateAdditionalMounts(t *testing.T) {
 				ctx:          testCtx,
 				doguResource: nginxDoguResourceWithAdditionalMounts,
 			},
-			wantErr: assert.NoError,
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.ErrorIs(t, err, assert.AnError)
+				assert.ErrorContains(t, err, "failed to update deployment additional mounts for dogu nginx")
+				return true
+			},
 		},
 		{
-			name: "should return retry error on general error",
+			name: "should return error on failing to fetch dogu descriptor",
 			fields: fields{
-				deploymentInterface: func() deploymentInterface {
-					mock := newMockDeploymentInterface(t)
-					mock.EXPECT().List(testCtx, v1.ListOptions{LabelSelector: "dogu.name=nginx"}).Return(nil, assert.AnError)
-					return mock
-				},
 				localDoguFetcher: func() localDoguFetcher {
 					mock := newMockLocalDoguFetcher(t)
-					mock.EXPECT().FetchInstalled(testCtx, dogu.SimpleName(nginxDoguResourceWithAdditionalMounts.Name)).Return(nginxDogu, nil)
-					return mock
-				},
-				resourceGenerator: func() resourceGenerator {
-					mock := newMockResourceGenerator(t)
-					mock.EXPECT().BuildAdditionalMountInitContainer(testCtx, nginxDogu, nginxDoguResourceWithAdditionalMounts, "", corev1.ResourceRequirements{}).Return(updatedInitContainer, nil)
-					return mock
-				},
-				requirementsGenerator: func() requirementsGenerator {
-					mock := newMockRequirementsGenerator(t)
-					mock.EXPECT().Generate(testCtx, nginxDogu).Return(corev1.ResourceRequirements{}, nil)
-					return mock
-				},
-				additionalMountsValidator: func() doguAdditionalMountsValidator {
-					mock := newMockDoguAdditionalMountsValidator(t)
-					mock.EXPECT().ValidateAdditionalMounts(testCtx, nginxDogu, nginxDoguResourceWithAdditionalMounts).Return(nil)
+					mock.EXPECT().FetchInstalled(testCtx, dogu.SimpleName(nginxDoguResourceWithAdditionalMounts.Name)).Return(nil, assert.AnError)
 					return mock
 				},
 			},
@@ -648,30 +544,18 @@ func Test_doguAdditionalMountsManager_UpdateAdditionalMounts(t *testing.T) {
 			},
 			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
 				assert.ErrorIs(t, err, assert.AnError)
-				assert.ErrorContains(t, err, "failed to update deployment additional mounts for dogu nginx")
+				assert.ErrorContains(t, err, "failed to get dogu descriptor for dogu nginx")
 				return true
 			},
 		},
 		{
 			name: "should return validation error on failing validation",
 			fields: fields{
-				deploymentInterface: func() deploymentInterface {
-					mock := newMockDeploymentInterface(t)
-					return mock
-				},
 				localDoguFetcher: func() localDoguFetcher {
 					mock := newMockLocalDoguFetcher(t)
 					mock.EXPECT().FetchInstalled(testCtx, dogu.SimpleName(nginxDoguResourceWithAdditionalMounts.Name)).Return(nginxDogu, nil)
 					return mock
 				},
-				resourceGenerator: func() resourceGenerator {
-					mock := newMockResourceGenerator(t)
-					return mock
-				},
-				requirementsGenerator: func() requirementsGenerator {
-					mock := newMockRequirementsGenerator(t)
-					return mock
-				},
 				additionalMountsValidator: func() doguAdditionalMountsValidator {
 					mock := newMockDoguAdditionalMountsValidator(t)
 					mock.EXPECT().ValidateAdditionalMounts(testCtx, nginxDogu, nginxDoguResourceWithAdditionalMounts).Return(assert.AnError)
@@ -692,18 +576,12 @@ func Test_doguAdditionalMountsManager_UpdateAdditionalMounts(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			m := &doguAdditionalMountManager{}
-			if tt.fields.deploymentInterface != nil {
-				m.deploymentInterface = tt.fields.deploymentInterface()
-			}
-			if tt.fields.resourceGenerator != nil {
-				m.resourceGenerator = tt.fields.resourceGenerator()
+			if tt.fields.resourceUpserter != nil {
+				m.resourceUpserter = tt.fields.resourceUpserter()
 			}
 			if tt.fields.localDoguFetcher != nil {
 				m.localDoguFetcher = tt.fields.localDoguFetcher()
 			}
-			if tt.fields.requirementsGenerator != nil {
-				m.requirementsGenerator = tt.fields.requirementsGenerator()
-			}
 			if tt.fields.additionalMountsValidator != nil {
 				m.doguAdditionalMountValidator = tt.fields.additionalMountsValidator()
 			}
@@ -711,87 +589,3 @@ func Test_doguAdditionalMountsManager_UpdateAdditionalMounts(t *testing.T) {
 		})
 	}
 }
-
-// CreateExpectedVolumes creates a set of volumes that match the expected volumes in the deployment
-func CreateExpectedVolumes() []corev1.Volume {
-	optional := true
-	return []corev1.Volume{
-		{
-			Name: "dogu-health",
-			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: "k8s-dogu-operator-dogu-health",
-					},
-				},
-			},
-		},
-		{
-			Name: "nginx-ephemeral",
-			VolumeSource: corev1.VolumeSource{
-				EmptyDir: &corev1.EmptyDirVolumeSource{},
-			},
-		},
-		{
-			Name: "global-config",
-			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: "global-config",
-					},
-				},
-			},
-		},
-		{
-			Name: "normal-config",
-			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: "nginx-config",
-					},
-				},
-			},
-		},
-		{
-			Name: "sensitive-config",
-			VolumeSource: corev1.VolumeSource{
-				Secret: &corev1.SecretVolumeSource{
-					SecretName: "nginx-config",
-				},
-			},
-		}, {
-			Name: "timezone-volume",
-			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: "k8s-dogu-operator-manager-config",
-					},
-					Items: []corev1.KeyToPath{
-						{"timezone", "timezone", new(int32(0o444))},
-					},
-				},
-			},
-		},
-		{
-			Name: "-dogu-json",
-			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: "dogu-spec-",
-					},
-					Optional: &optional,
-				},
-			},
-		},
-		{
-			Name: "configmap",
-			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: "configmap",
-					},
-				},
-			},
-		},
-	}
-}
@@ -2,11 +2,14 @@ package manager
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	appsv1 "k8s.io/client-go/kubernetes/typed/apps/v1"
 )
 
@@ -24,21 +27,35 @@ func NewDoguRestartManager(doguClient doguClient.DoguInterface, deploymentClient
 	}
 }
 
+// RestartDogu restarts the pods of the dogu by patching only the restartedAt annotation of the pod template, so that
+// the fields of the deployment applied by the dogu operator or set by other controllers are kept.
 func (drm *doguRestartManager) RestartDogu(ctx context.Context, dogu *v2.Dogu) error {
-	deployment, err := drm.deploymentInterface.Get(ctx, dogu.Name, metav1.GetOptions{})
+	patch, err := restartedAtPatch(time.Now())
 	if err != nil {
 		return err
 	}
 
-	if deployment.Spec.Template.Annotations == nil {
-		deployment.Spec.Template.Annotations = make(map[string]string)
+	_, err = drm.deploymentInterface.Patch(ctx, dogu.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to patch restartedAt annotation of deployment %s: %w", dogu.Name, err)
 	}
+	return nil
+}
 
-	deployment.Spec.Template.Annotations[restartedAtAnnotationKey] = time.Now().Format(time.RFC3339)
+func restartedAtPatch(restartedAt time.Time) ([]byte, error) {
+	patch := map[string]any{
+		"spec": map[string]any{
+			"template": map[string]any{
+				"metadata": map[string]any{
+					"annotations": map[string]string{restartedAtAnnotationKey: restartedAt.Format(time.RFC3339)},
+				},
+			},
+		},
+	}
 
-	_, err = drm.deploymentInterface.Update(ctx, deployment, metav1.UpdateOptions{})
+	data, err := json.Marshal(patch)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("failed to create restartedAt patch: %w", err)
 	}
-	return nil
+	return data, nil
 }
@@ -2,6 +2,7 @@ package manager
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
@@ -9,8 +10,10 @@ import (
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 func TestNewDoguRestartManager(t *testing.T) {
@@ -28,6 +31,18 @@ func TestNewDoguRestartManager(t *testing.T) {
 }
 
 func Test_doguRestartManager_RestartDogu(t *testing.T) {
+	assertRestartedAtPatch := func(t *testing.T) func(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) {
+		return func(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) {
+			patched := &appsv1.Deployment{}
+			require.NoError(t, json.Unmarshal(data, patched))
+			restartedAt, exists := patched.Spec.Template.Annotations[restartedAtAnnotationKey]
+			assert.True(t, exists, "restartedAt annotation should exist")
+			_, err := time.Parse(time.RFC3339, restartedAt)
+			assert.NoError(t, err, "restartedAt should be formatted correctly")
+			assert.Empty(t, patched.Spec.Template.Spec.Containers, "only the annotation should be patched")
+		}
+	}
+
 	type fields struct {
 		doguInterfaceFn       func(t *testing.T) doguInterface
 		deploymentInterfaceFn func(t *testing.T) deploymentInterface
@@ -39,36 +54,15 @@ func Test_doguRestartManager_RestartDogu(t *testing.T) {
 		wantErr assert.ErrorAssertionFunc
 	}{
 		{
-			name: "should fail to get deployment of dogu",
-			fields: fields{
-				doguInterfaceFn: func(t *testing.T) doguInterface {
-					return newMockDoguInterface(t)
-				},
-				deploymentInterfaceFn: func(t *testing.T) deploymentInterface {
-					mck := newMockDeploymentInterface(t)
-					mck.EXPECT().Get(testCtx, "test", v1.GetOptions{}).Return(nil, assert.AnError)
-					return mck
-				},
-			},
-			dogu:    &v2.Dogu{ObjectMeta: v1.ObjectMeta{Name: "test"}},
-			wantErr: assert.Error,
-		},
-		{
-			name: "should fail to update deployment of dogu",
+			name: "should fail to patch deployment of dogu",
 			fields: fields{
 				doguInterfaceFn: func(t *testing.T) doguInterface {
 					return newMockDoguInterface(t)
 				},
 				deploymentInterfaceFn: func(t *testing.T) deploymentInterface {
 					mck := newMockDeploymentInterface(t)
-					mck.EXPECT().Get(testCtx, "test", v1.GetOptions{}).Return(&appsv1.Deployment{}, nil)
-					mck.EXPECT().Update(testCtx, mock.Anything, v1.UpdateOptions{}).
-						Run(func(ctx context.Context, deployment *appsv1.Deployment, opts v1.UpdateOptions) {
-							restartedAt, exists := deployment.Spec.Template.Annotations[restartedAtAnnotationKey]
-							assert.True(t, exists, "restartedAt annotation should exist")
-							_, err := time.Parse(time.RFC3339, restartedAt)
-							assert.NoError(t, err, "restartedAt should be formatted correctly")
-						}).
+					mck.EXPECT().Patch(testCtx, "test", types.StrategicMergePatchType, mock.Anything, v1.PatchOptions{}).
+						Run(assertRestartedAtPatch(t)).
 						Return(nil, assert.AnError)
 					return mck
 				},
@@ -77,22 +71,16 @@ func Test_doguRestartManager_RestartDogu(t *testing.T) {
 			wantErr: assert.Error,
 		},
 		{
-			name: "should succeed to update deployment of dogu",
+			name: "should succeed to patch deployment of dogu",
 			fields: fields{
 				doguInterfaceFn: func(t *testing.T) doguInterface {
 					return newMockDoguInterface(t)
 				},
 				deploymentInterfaceFn: func(t *testing.T) deploymentInterface {
 					mck := newMockDeploymentInterface(t)
-					mck.EXPECT().Get(testCtx, "test", v1.GetOptions{}).Return(&appsv1.Deployment{}, nil)
-					mck.EXPECT().Update(testCtx, mock.Anything, v1.UpdateOptions{}).
-						Run(func(ctx context.Context, deployment *appsv1.Deployment, opts v1.UpdateOptions) {
-							restartedAt, exists := deployment.Spec.Template.Annotations[restartedAtAnnotationKey]
-							assert.True(t, exists, "restartedAt annotation should exist")
-							_, err := time.Parse(time.RFC3339, restartedAt)
-							assert.NoError(t, err, "restartedAt should be formatted correctly")
-						}).
-						Return(nil, nil)
+					mck.EXPECT().Patch(testCtx, "test", types.StrategicMergePatchType, mock.Anything, v1.PatchOptions{}).
+						Run(assertRestartedAtPatch(t)).
+						Return(&appsv1.Deployment{}, nil)
 					return mck
 				},
 			},
@@ -13,7 +13,6 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
-	"github.com/cloudogu/cesapp-lib/core"
 	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 )
 
@@ -22,26 +21,21 @@ const (
 )
 const SupportModeEnvVar = "SUPPORT_MODE"
 
-// podTemplateResourceGenerator is used to generate pod templates.
-type podTemplateResourceGenerator interface {
-	GetPodTemplate(ctx context.Context, doguResource *doguv2.Dogu, dogu *core.Dogu) (*corev1.PodTemplateSpec, error)
-}
-
 // doguSupportManager is used to handle the support mode for dogus.
 type doguSupportManager struct {
-	client                       client.Client
-	doguFetcher                  localDoguFetcher
-	podTemplateResourceGenerator podTemplateResourceGenerator
-	eventRecorder                eventRecorder
+	client           client.Client
+	doguFetcher      localDoguFetcher
+	resourceUpserter resourceUpserter
+	eventRecorder    eventRecorder
 }
 
 // NewDoguSupportManager creates a new instance of doguSupportManager.
-func NewDoguSupportManager(client client.Client, fetcher cesregistry.LocalDoguFetcher, generator resource.DoguResourceGenerator, eventRecorder record.EventRecorder) SupportManager {
+func NewDoguSupportManager(client client.Client, fetcher cesregistry.LocalDoguFetcher, upserter resource.ResourceUpserter, eventRecorder record.EventRecorder) SupportManager {
 	return &doguSupportManager{
-		client:                       client,
-		doguFetcher:                  fetcher,
-		podTemplateResourceGenerator: generator,
-		eventRecorder:                eventRecorder,
+		client:           client,
+		doguFetcher:      fetcher,
+		resourceUpserter: upserter,
+		eventRecorder:    eventRecorder,
 	}
 }
 
@@ -66,7 +60,7 @@ func (dsm *doguSupportManager) HandleSupportMode(ctx context.Context, doguResour
 		return false, nil
 	}
 
-	err = dsm.updateDeployment(ctx, doguResource, deployment)
+	err = dsm.updateDeployment(ctx, doguResource)
 	if err != nil {
 		return false, err
 	}
@@ -75,7 +69,9 @@ func (dsm *doguSupportManager) HandleSupportMode(ctx context.Context, doguResour
 	return true, nil
 }
 
-func (dsm *doguSupportManager) updateDeployment(ctx context.Context, doguResource *doguv2.Dogu, deployment *appsv1.Deployment) error {
+// updateDeployment applies the generated deployment of the dogu, with the pod template in support mode if the support
+// mode is enabled.
+func (dsm *doguSupportManager) updateDeployment(ctx context.Context, doguResource *doguv2.Dogu) error {
 	logger := log.FromContext(ctx)
 
 	dogu, err := dsm.doguFetcher.FetchInstalled(ctx, doguResource.GetSimpleDoguName())
@@ -83,18 +79,12 @@ func (dsm *doguSupportManager) updateDeployment(ctx context.Context, doguResourc
 		return fmt.Errorf("failed to get dogu descriptor of dogu %s: %w", doguResource.Name, err)
 	}
 
-	podTemplate, err := dsm.podTemplateResourceGenerator.GetPodTemplate(ctx, doguResource, dogu)
-	if err != nil {
-		return fmt.Errorf("failed to get pod template for dogu %s in support action: %w", doguResource.Name, err)
-	}
-
-	if doguResource.Spec.SupportMode {
-		setDoguPodTemplateInSupportMode(doguResource, podTemplate)
-	}
-
-	deployment.Spec.Template = *podTemplate
 	logger.Info(fmt.Sprintf("Update deployment for dogu %s...", doguResource.Name))
-	err = dsm.client.Update(ctx, deployment)
+	_, err = dsm.resourceUpserter.UpsertDoguDeployment(ctx, doguResource, dogu, func(deployment *appsv1.Deployment) {
+		if doguResource.Spec.SupportMode {
+			setDoguPodTemplateInSupportMode(doguResource, &deployment.Spec.Template)
+		}
+	})
 	if err != nil {
 		return fmt.Errorf("failed to update dogu deployment %s: %w", doguResource.Name, err)
 	}
@@ -1,9 +1,11 @@
 package manager
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -15,6 +17,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	cescommons "github.com/cloudogu/ces-commons-lib/dogu"
+	"github.com/cloudogu/cesapp-lib/core"
 	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 )
 
@@ -25,22 +28,22 @@ type doguSupportManagerWithMocks struct {
 	localDoguFetcherMock *mockLocalDoguFetcher
 	k8sClient            client.WithWatch
 	recorderMock         *mockEventRecorder
-	podTemplateGenerator *mockPodTemplateResourceGenerator
+	upserterMock         *mockResourceUpserter
 }
 
 func getDoguSupportManagerWithMocks(t *testing.T, scheme *runtime.Scheme) doguSupportManagerWithMocks {
 	t.Helper()
 
 	k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
-	podTemplateGenerator := newMockPodTemplateResourceGenerator(t)
+	upserterMock := newMockResourceUpserter(t)
 	localDoguFetcherMock := newMockLocalDoguFetcher(t)
 	eventRecorder := newMockEventRecorder(t)
 
 	doguSupportManager := &doguSupportManager{
-		client:                       k8sClient,
-		podTemplateResourceGenerator: podTemplateGenerator,
-		eventRecorder:                eventRecorder,
-		doguFetcher:                  localDoguFetcherMock,
+		client:           k8sClient,
+		resourceUpserter: upserterMock,
+		eventRecorder:    eventRecorder,
+		doguFetcher:      localDoguFetcherMock,
 	}
 
 	return doguSupportManagerWithMocks{
@@ -48,7 +51,7 @@ func getDoguSupportManagerWithMocks(t *testing.T, scheme *runtime.Scheme) doguSu
 		k8sClient:            k8sClient,
 		localDoguFetcherMock: localDoguFetcherMock,
 		recorderMock:         eventRecorder,
-		podTemplateGenerator: podTemplateGenerator,
+		upserterMock:         upserterMock,
 	}
 }
 
@@ -61,16 +64,16 @@ func TestNewDoguSupportManager(t *testing.T) {
 
 	k8sClient := fake.NewClientBuilder().Build()
 	fetcher := newMockLocalDoguFetcher(t)
-	generator := newMockResourceGenerator(t)
+	upserter := newMockResourceUpserter(t)
 	recorder := newMockEventRecorder(t)
 
 	// when
-	manager := NewDoguSupportManager(k8sClient, fetcher, generator, recorder)
+	manager := NewDoguSupportManager(k8sClient, fetcher, upserter, recorder)
 
 	// then
 	assert.Same(t, k8sClient, manager.(*doguSupportManager).client)
 	assert.Same(t, fetcher, manager.(*doguSupportManager).doguFetcher)
-	assert.Same(t, generator, manager.(*doguSupportManager).podTemplateResourceGenerator)
+	assert.Same(t, upserter, manager.(*doguSupportManager).resourceUpserter)
 	assert.Same(t, recorder, manager.(*doguSupportManager).eventRecorder)
 }
 
@@ -127,7 +130,26 @@ func Test_doguSupportManager_isDeploymentInSupportMode(t *testing.T) {
 }
 
 func Test_doguSupportManager_updateDeployment(t *testing.T) {
-	t.Run("successfully update deployment", func(t *testing.T) {
+	generatedDeployment := func() *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: namespace},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Hostname: "ldap",
+						Containers: []corev1.Container{{
+							Name:          "ldap",
+							Image:         "registry.cloudogu.com/official/ldap:2.4.48-4",
+							Env:           []corev1.EnvVar{},
+							LivenessProbe: &corev1.Probe{},
+						}},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("successfully apply deployment in support mode", func(t *testing.T) {
 		// given
 		sut := getDoguSupportManagerWithMocks(t, getTestScheme())
 		ldap := readDoguDescriptor(t, ldapDoguDescriptorBytes)
@@ -136,35 +158,18 @@ func Test_doguSupportManager_updateDeployment(t *testing.T) {
 		ldapCr.Spec.SupportMode = true
 		sut.localDoguFetcherMock.EXPECT().FetchInstalled(testCtx, cescommons.SimpleName("ldap")).Return(ldap, nil)
 
-		deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: namespace}}
-		err := sut.supportManager.client.Create(testCtx, deployment)
-		require.NoError(t, err)
-		resourceVersion := deployment.ResourceVersion
-
-		podSpec := corev1.PodTemplateSpec{
-			Spec: corev1.PodSpec{
-				Hostname: "ldap",
-				Containers: []corev1.Container{
-					{
-						Name:  "ldap",
-						Image: "registry.cloudogu.com/official/ldap:2.4.48-4",
-						Env:   []corev1.EnvVar{}},
-				},
-			},
-		}
-
-		sut.podTemplateGenerator.EXPECT().GetPodTemplate(testCtx, ldapCr, ldap).Return(&podSpec, nil)
+		deployment := generatedDeployment()
+		sut.upserterMock.EXPECT().UpsertDoguDeployment(testCtx, ldapCr, ldap, mock.Anything).
+			RunAndReturn(func(ctx context.Context, doguResource *doguv2.Dogu, dogu *core.Dogu, deploymentPatch func(*appsv1.Deployment)) (*appsv1.Deployment, error) {
+				deploymentPatch(deployment)
+				return deployment, nil
+			})
 
 		// when
-		err = sut.supportManager.updateDeployment(testCtx, ldapCr, deployment)
+		err := sut.supportManager.updateDeployment(testCtx, ldapCr)
 
 		// then
 		require.NoError(t, err)
-
-		deployment = &appsv1.Deployment{}
-		err = sut.k8sClient.Get(testCtx, ldapCr.GetObjectKey(), deployment)
-		require.NoError(t, err)
-		assert.Greater(t, deployment.ResourceVersion, resourceVersion)
 		expectedPodSpec := corev1.PodSpec{
 			Hostname: "ldap",
 			Containers: []corev1.Container{
@@ -183,38 +188,58 @@ func Test_doguSupportManager_updateDeployment(t *testing.T) {
 		assert.Equal(t, expectedPodSpec, deployment.Spec.Template.Spec)
 	})
 
-	t.Run("error getting dogu descriptor", func(t *testing.T) {
+	t.Run("successfully apply generated deployment without support mode", func(t *testing.T) {
 		// given
 		sut := getDoguSupportManagerWithMocks(t, getTestScheme())
+		ldap := readDoguDescriptor(t, ldapDoguDescriptorBytes)
 		ldapCr := readDoguCr(t, ldapCrBytes)
-		sut.localDoguFetcherMock.EXPECT().FetchInstalled(testCtx, cescommons.SimpleName("ldap")).Return(nil, assert.AnError)
+		ldapCr.Namespace = namespace
+		ldapCr.Spec.SupportMode = false
+		sut.localDoguFetcherMock.EXPECT().FetchInstalled(testCtx, cescommons.SimpleName("ldap")).Return(ldap, nil)
 
-		deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: namespace}}
-		err := sut.supportManager.client.Create(testCtx, deployment)
+		deployment := generatedDeployment()
+		sut.upserterMock.EXPECT().UpsertDoguDeployment(testCtx, ldapCr, ldap, mock.Anything).
+			RunAndReturn(func(ctx context.Context, doguResource *doguv2.Dogu, dogu *core.Dogu, deploymentPatch func(*appsv1.Deployment)) (*appsv1.Deployment, error) {
+				deploymentPatch(deployment)
+				return deployment, nil
+			})
+
+		// when
+		err := sut.supportManager.updateDeployment(testCtx, ldapCr)
+
+		// then
 		require.NoError(t, err)
+		assert.Equal(t, generatedDeployment().Spec.Template.Spec, deployment.Spec.Template.Spec)
+	})
+
+	t.Run("error getting dogu descriptor", func(t *testing.T) {
+		// given
+		sut := getDoguSupportManagerWithMocks(t, getTestScheme())
+		ldapCr := readDoguCr(t, ldapCrBytes)
+		sut.localDoguFetcherMock.EXPECT().FetchInstalled(testCtx, cescommons.SimpleName("ldap")).Return(nil, assert.AnError)
 
 		// when
-		err = sut.supportManager.updateDeployment(testCtx, ldapCr, nil)
+		err := sut.supportManager.updateDeployment(testCtx, ldapCr)
 
 		// then
 		require.Error(t, err)
 		assert.ErrorContains(t, err, "failed to get dogu descriptor of dogu ldap")
 	})
 
-	t.Run("error updating deployment of dogu", func(t *testing.T) {
+	t.Run("error applying deployment of dogu", func(t *testing.T) {
 		// given
 		sut := getDoguSupportManagerWithMocks(t, getTestScheme())
 		ldap := readDoguDescriptor(t, ldapDoguDescriptorBytes)
 		ldapCr := readDoguCr(t, ldapCrBytes)
 		sut.localDoguFetcherMock.EXPECT().FetchInstalled(testCtx, cescommons.SimpleName("ldap")).Return(ldap, nil)
-		podSpec := corev1.PodTemplateSpec{Spec: corev1.PodSpec{}}
-		sut.podTemplateGenerator.EXPECT().GetPodTemplate(testCtx, ldapCr, ldap).Return(&podSpec, nil)
+		sut.upserterMock.EXPECT().UpsertDoguDeployment(testCtx, ldapCr, ldap, mock.Anything).Return(nil, assert.AnError)
 
 		// when
-		err := sut.supportManager.updateDeployment(testCtx, ldapCr, &appsv1.Deployment{})
+		err := sut.supportManager.updateDeployment(testCtx, ldapCr)
 
 		// then
 		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
 		assert.ErrorContains(t, err, "failed to update dogu deployment ldap")
 	})
 }
@@ -234,7 +259,7 @@ func Test_doguSupportManager_HandleSupportMode(t *testing.T) {
 			Spec: corev1.PodSpec{
 				Containers: []corev1.Container{{Image: "official/ldap:2.4.48-4"}, {Image: "other:1.2.3"}}}}
 
-		sut.podTemplateGenerator.EXPECT().GetPodTemplate(testCtx, ldapCr, ldap).Return(&podTemplateSpec, nil)
+		sut.upserterMock.EXPECT().UpsertDoguDeployment(testCtx, ldapCr, ldap, mock.Anything).Return(&appsv1.Deployment{}, nil)
 
 		deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: namespace},
 			Spec: appsv1.DeploymentSpec{
@@ -1,101 +0,0 @@
-// Code generated by mockery v2.53.6. DO NOT EDIT.
-
-package manager
-
-import (
-	context "context"
-
-	core "github.com/cloudogu/cesapp-lib/core"
-	mock "github.com/stretchr/testify/mock"
-
-	v1 "k8s.io/api/core/v1"
-
-	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
-)
-
-// mockPodTemplateResourceGenerator is an autogenerated mock type for the podTemplateResourceGenerator type
-type mockPodTemplateResourceGenerator struct {
-	mock.Mock
-}
-
-type mockPodTemplateResourceGenerator_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *mockPodTemplateResourceGenerator) EXPECT() *mockPodTemplateResourceGenerator_Expecter {
-	return &mockPodTemplateResourceGenerator_Expecter{mock: &_m.Mock}
-}
-
-// GetPodTemplate provides a mock function with given fields: ctx, doguResource, dogu
-func (_m *mockPodTemplateResourceGenerator) GetPodTemplate(ctx context.Context, doguResource *v2.Dogu, dogu *core.Dogu) (*v1.PodTemplateSpec, error) {
-	ret := _m.Called(ctx, doguResource, dogu)
-
-	if len(ret) == 0 {
-		panic("no return value specified for GetPodTemplate")
-	}
-
-	var r0 *v1.PodTemplateSpec
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *v2.Dogu, *core.Dogu) (*v1.PodTemplateSpec, error)); ok {
-		return rf(ctx, doguResource, dogu)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, *v2.Dogu, *core.Dogu) *v1.PodTemplateSpec); ok {
-		r0 = rf(ctx, doguResource, dogu)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v1.PodTemplateSpec)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, *v2.Dogu, *core.Dogu) error); ok {
-		r1 = rf(ctx, doguResource, dogu)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// mockPodTemplateResourceGenerator_GetPodTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPodTemplate'
-type mockPodTemplateResourceGenerator_GetPodTemplate_Call struct {
-	*mock.Call
-}
-
-// GetPodTemplate is a helper method to define mock.On call
-//   - ctx context.Context
-//   - doguResource *v2.Dogu
-//   - dogu *core.Dogu
-func (_e *mockPodTemplateResourceGenerator_Expecter) GetPodTemplate(ctx interface{}, doguResource interface{}, dogu interface{}) *mockPodTemplateResourceGenerator_GetPodTemplate_Call {
-	return &mockPodTemplateResourceGenerator_GetPodTemplate_Call{Call: _e.mock.On("GetPodTemplate", ctx, doguResource, dogu)}
-}
-
-func (_c *mockPodTemplateResourceGenerator_GetPodTemplate_Call) Run(run func(ctx context.Context, doguResource *v2.Dogu, dogu *core.Dogu)) *mockPodTemplateResourceGenerator_GetPodTemplate_Call {
-	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*v2.Dogu), args[2].(*core.Dogu))
-	})
-	return _c
-}
-
-func (_c *mockPodTemplateResourceGenerator_GetPodTemplate_Call) Return(_a0 *v1.PodTemplateSpec, _a1 error) *mockPodTemplateResourceGenerator_GetPodTemplate_Call {
-	_c.Call.Return(_a0, _a1)
-	return _c
-}
-
-func (_c *mockPodTemplateResourceGenerator_GetPodTemplate_Call) RunAndReturn(run func(context.Context, *v2.Dogu, *core.Dogu) (*v1.PodTemplateSpec, error)) *mockPodTemplateResourceGenerator_GetPodTemplate_Call {
-	_c.Call.Return(run)
-	return _c
-}
-
-// newMockPodTemplateResourceGenerator creates a new instance of mockPodTemplateResourceGenerator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func newMockPodTemplateResourceGenerator(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *mockPodTemplateResourceGenerator {
-	mock := &mockPodTemplateResourceGenerator{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
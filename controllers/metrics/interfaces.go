@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// StepRecorder records metrics about the steps run while reconciling a dogu.
+type StepRecorder interface {
+	// ObserveStep records the duration and the outcome of a step run for the dogu.
+	ObserveStep(dogu types.NamespacedName, stepName string, outcome steps.Outcome, duration time.Duration)
+}
+
+// RequeueRecorder records metrics about the requeue of dogu resources.
+type RequeueRecorder interface {
+	// SetRequeueTime records the time after which the dogu will be reconciled again. Zero means no requeue.
+	SetRequeueTime(dogu types.NamespacedName, requeueAfter time.Duration)
+	// ForgetDogu removes all metrics of the dogu, e.g. after it has been deleted.
+	ForgetDogu(dogu types.NamespacedName)
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const namespace = "k8s_dogu_operator"
+
+const (
+	labelNamespace = "namespace"
+	labelDogu      = "dogu"
+	labelStep      = "step"
+	labelOutcome   = "outcome"
+)
+
+// PrometheusRecorder provides the metrics of the reconcile step pipeline in prometheus format.
+type PrometheusRecorder struct {
+	stepDuration *prometheus.HistogramVec
+	stepResults  *prometheus.CounterVec
+	requeueTime  *prometheus.GaugeVec
+}
+
+// NewPrometheusRecorder creates the metrics of the reconcile step pipeline and registers them at the registerer.
+func NewPrometheusRecorder(registerer prometheus.Registerer) (*PrometheusRecorder, error) {
+	r := &PrometheusRecorder{
+		stepDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "step_duration_seconds",
+			Help:      "Duration of the steps run while reconciling a dogu.",
+			Buckets:   []float64{0.005, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+		}, []string{labelStep, labelNamespace, labelDogu}),
+		stepResults: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "step_results_total",
+			Help:      "Number of step results by outcome (continue, requeue, abort, error).",
+		}, []string{labelStep, labelNamespace, labelDogu, labelOutcome}),
+		requeueTime: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "dogu_requeue_time_seconds",
+			Help:      "Time after which the dogu resource will be reconciled again. Zero if no requeue is pending.",
+		}, []string{labelNamespace, labelDogu}),
+	}
+
+	for _, collector := range []prometheus.Collector{r.stepDuration, r.stepResults, r.requeueTime} {
+		err := registerer.Register(collector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to register metrics: %w", err)
+		}
+	}
+
+	return r, nil
+}
+
+// ObserveStep records the duration and the outcome of a step run for the dogu.
+func (r *PrometheusRecorder) ObserveStep(dogu types.NamespacedName, stepName string, outcome steps.Outcome, duration time.Duration) {
+	r.stepDuration.WithLabelValues(stepName, dogu.Namespace, dogu.Name).Observe(duration.Seconds())
+	r.stepResults.WithLabelValues(stepName, dogu.Namespace, dogu.Name, string(outcome)).Inc()
+}
+
+// SetRequeueTime records the time after which the dogu will be reconciled again.
+func (r *PrometheusRecorder) SetRequeueTime(dogu types.NamespacedName, requeueAfter time.Duration) {
+	r.requeueTime.WithLabelValues(dogu.Namespace, dogu.Name).Set(requeueAfter.Seconds())
+}
+
+// ForgetDogu removes all metrics of the dogu so that deleted dogus do not remain in the metrics.
+func (r *PrometheusRecorder) ForgetDogu(dogu types.NamespacedName) {
+	labels := prometheus.Labels{labelNamespace: dogu.Namespace, labelDogu: dogu.Name}
+	r.stepDuration.DeletePartialMatch(labels)
+	r.stepResults.DeletePartialMatch(labels)
+	r.requeueTime.DeletePartialMatch(labels)
+}
@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var (
+	testLdap = types.NamespacedName{Namespace: "ecosystem", Name: "ldap"}
+	testCas  = types.NamespacedName{Namespace: "ecosystem", Name: "cas"}
+)
+
+func TestNewPrometheusRecorder(t *testing.T) {
+	t.Run("should register metrics", func(t *testing.T) {
+		// given
+		registry := prometheus.NewRegistry()
+
+		// when
+		recorder, err := NewPrometheusRecorder(registry)
+
+		// then
+		require.NoError(t, err)
+		require.NotNil(t, recorder)
+		recorder.ObserveStep(testLdap, "install.ValidationStep", steps.OutcomeContinue, time.Second)
+		recorder.SetRequeueTime(testLdap, time.Second)
+		families, err := registry.Gather()
+		require.NoError(t, err)
+		assert.Len(t, families, 3)
+	})
+	t.Run("should fail to register metrics twice", func(t *testing.T) {
+		// given
+		registry := prometheus.NewRegistry()
+		_, err := NewPrometheusRecorder(registry)
+		require.NoError(t, err)
+
+		// when
+		_, err = NewPrometheusRecorder(registry)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to register metrics")
+	})
+}
+
+func TestPrometheusRecorder_ObserveStep(t *testing.T) {
+	// given
+	recorder, err := NewPrometheusRecorder(prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	// when
+	recorder.ObserveStep(testLdap, "install.ValidationStep", steps.OutcomeRequeue, 2*time.Second)
+	recorder.ObserveStep(testLdap, "install.ValidationStep", steps.OutcomeRequeue, time.Second)
+	recorder.ObserveStep(testLdap, "install.ValidationStep", steps.OutcomeContinue, time.Second)
+
+	// then
+	assert.Equal(t, float64(2), testutil.ToFloat64(recorder.stepResults.WithLabelValues("install.ValidationStep", "ecosystem", "ldap", "requeue")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(recorder.stepResults.WithLabelValues("install.ValidationStep", "ecosystem", "ldap", "continue")))
+	assert.Equal(t, 1, testutil.CollectAndCount(recorder.stepDuration))
+}
+
+func TestPrometheusRecorder_SetRequeueTime(t *testing.T) {
+	// given
+	recorder, err := NewPrometheusRecorder(prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	// when
+	recorder.SetRequeueTime(testLdap, 5*time.Second)
+
+	// then
+	assert.Equal(t, float64(5), testutil.ToFloat64(recorder.requeueTime.WithLabelValues("ecosystem", "ldap")))
+}
+
+func TestPrometheusRecorder_ForgetDogu(t *testing.T) {
+	// given
+	recorder, err := NewPrometheusRecorder(prometheus.NewRegistry())
+	require.NoError(t, err)
+	recorder.ObserveStep(testLdap, "install.ValidationStep", steps.OutcomeContinue, time.Second)
+	recorder.ObserveStep(testCas, "install.ValidationStep", steps.OutcomeContinue, time.Second)
+	recorder.SetRequeueTime(testLdap, time.Second)
+	recorder.SetRequeueTime(testCas, time.Second)
+	recorder.SetRequeueTime(types.NamespacedName{Namespace: "other", Name: "ldap"}, time.Second)
+
+	// when
+	recorder.ForgetDogu(testLdap)
+
+	// then
+	assert.Equal(t, 1, testutil.CollectAndCount(recorder.stepDuration))
+	assert.Equal(t, 1, testutil.CollectAndCount(recorder.stepResults))
+	assert.Equal(t, 2, testutil.CollectAndCount(recorder.requeueTime))
+}
@@ -0,0 +1,92 @@
+package namespaced
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	applyappsv1 "k8s.io/client-go/applyconfigurations/apps/v1"
+	applyautoscalingv1 "k8s.io/client-go/applyconfigurations/autoscaling/v1"
+	"k8s.io/client-go/kubernetes"
+	typedappsv1 "k8s.io/client-go/kubernetes/typed/apps/v1"
+)
+
+// deploymentInterface sends every request to the namespace of the request context.
+// The embedded client is bound to the default namespace and serves methods without a context.
+type deploymentInterface struct {
+	typedappsv1.DeploymentInterface
+	clientSet        kubernetes.Interface
+	defaultNamespace string
+}
+
+// NewDeploymentInterface creates a deployment client which resolves the namespace from the request context.
+func NewDeploymentInterface(clientSet kubernetes.Interface, defaultNamespace string) typedappsv1.DeploymentInterface {
+	return &deploymentInterface{
+		DeploymentInterface: clientSet.AppsV1().Deployments(defaultNamespace),
+		clientSet:           clientSet,
+		defaultNamespace:    defaultNamespace,
+	}
+}
+
+func (d *deploymentInterface) forContext(ctx context.Context) typedappsv1.DeploymentInterface {
+	return d.clientSet.AppsV1().Deployments(FromContext(ctx, d.defaultNamespace))
+}
+
+func (d *deploymentInterface) Create(ctx context.Context, deployment *appsv1.Deployment, opts metav1.CreateOptions) (*appsv1.Deployment, error) {
+	return d.forContext(ctx).Create(ctx, deployment, opts)
+}
+
+func (d *deploymentInterface) Update(ctx context.Context, deployment *appsv1.Deployment, opts metav1.UpdateOptions) (*appsv1.Deployment, error) {
+	return d.forContext(ctx).Update(ctx, deployment, opts)
+}
+
+func (d *deploymentInterface) UpdateStatus(ctx context.Context, deployment *appsv1.Deployment, opts metav1.UpdateOptions) (*appsv1.Deployment, error) {
+	return d.forContext(ctx).UpdateStatus(ctx, deployment, opts)
+}
+
+func (d *deploymentInterface) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return d.forContext(ctx).Delete(ctx, name, opts)
+}
+
+func (d *deploymentInterface) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	return d.forContext(ctx).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (d *deploymentInterface) Get(ctx context.Context, name string, opts metav1.GetOptions) (*appsv1.Deployment, error) {
+	return d.forContext(ctx).Get(ctx, name, opts)
+}
+
+func (d *deploymentInterface) List(ctx context.Context, opts metav1.ListOptions) (*appsv1.DeploymentList, error) {
+	return d.forContext(ctx).List(ctx, opts)
+}
+
+func (d *deploymentInterface) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return d.forContext(ctx).Watch(ctx, opts)
+}
+
+func (d *deploymentInterface) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (*appsv1.Deployment, error) {
+	return d.forContext(ctx).Patch(ctx, name, pt, data, opts, subresources...)
+}
+
+func (d *deploymentInterface) Apply(ctx context.Context, deployment *applyappsv1.DeploymentApplyConfiguration, opts metav1.ApplyOptions) (*appsv1.Deployment, error) {
+	return d.forContext(ctx).Apply(ctx, deployment, opts)
+}
+
+func (d *deploymentInterface) ApplyStatus(ctx context.Context, deployment *applyappsv1.DeploymentApplyConfiguration, opts metav1.ApplyOptions) (*appsv1.Deployment, error) {
+	return d.forContext(ctx).ApplyStatus(ctx, deployment, opts)
+}
+
+func (d *deploymentInterface) GetScale(ctx context.Context, deploymentName string, opts metav1.GetOptions) (*autoscalingv1.Scale, error) {
+	return d.forContext(ctx).GetScale(ctx, deploymentName, opts)
+}
+
+func (d *deploymentInterface) UpdateScale(ctx context.Context, deploymentName string, scale *autoscalingv1.Scale, opts metav1.UpdateOptions) (*autoscalingv1.Scale, error) {
+	return d.forContext(ctx).UpdateScale(ctx, deploymentName, scale, opts)
+}
+
+func (d *deploymentInterface) ApplyScale(ctx context.Context, deploymentName string, scale *applyautoscalingv1.ScaleApplyConfiguration, opts metav1.ApplyOptions) (*autoscalingv1.Scale, error) {
+	return d.forContext(ctx).ApplyScale(ctx, deploymentName, scale, opts)
+}
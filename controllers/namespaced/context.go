@@ -0,0 +1,22 @@
+package namespaced
+
+import "context"
+
+type namespaceContextKey struct{}
+
+// WithNamespace returns a copy of ctx which carries the given namespace.
+// All clients created by this package send their requests to this namespace.
+// Use metav1.NamespaceAll to address all namespaces, e.g. for listing dogus.
+func WithNamespace(ctx context.Context, namespace string) context.Context {
+	return context.WithValue(ctx, namespaceContextKey{}, namespace)
+}
+
+// FromContext returns the namespace carried by ctx or defaultNamespace if ctx carries none.
+func FromContext(ctx context.Context, defaultNamespace string) string {
+	namespace, ok := ctx.Value(namespaceContextKey{}).(string)
+	if !ok {
+		return defaultNamespace
+	}
+
+	return namespace
+}
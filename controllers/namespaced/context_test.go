@@ -0,0 +1,23 @@
+package namespaced
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var testCtx = context.Background()
+
+func TestFromContext(t *testing.T) {
+	t.Run("should return default namespace if context carries none", func(t *testing.T) {
+		assert.Equal(t, "ecosystem", FromContext(testCtx, "ecosystem"))
+	})
+	t.Run("should return namespace of context", func(t *testing.T) {
+		assert.Equal(t, "stage", FromContext(WithNamespace(testCtx, "stage"), "ecosystem"))
+	})
+	t.Run("should return all namespaces if set explicitly", func(t *testing.T) {
+		assert.Equal(t, metav1.NamespaceAll, FromContext(WithNamespace(testCtx, metav1.NamespaceAll), "ecosystem"))
+	})
+}
@@ -0,0 +1,344 @@
+package namespaced
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	applycorev1 "k8s.io/client-go/applyconfigurations/core/v1"
+	"k8s.io/client-go/kubernetes"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// configMapInterface sends every request to the namespace of the request context.
+// The embedded client is bound to the default namespace and serves methods without a context.
+type configMapInterface struct {
+	v1.ConfigMapInterface
+	clientSet        kubernetes.Interface
+	defaultNamespace string
+}
+
+// NewConfigMapInterface creates a config map client which resolves the namespace from the request context.
+func NewConfigMapInterface(clientSet kubernetes.Interface, defaultNamespace string) v1.ConfigMapInterface {
+	return &configMapInterface{
+		ConfigMapInterface: clientSet.CoreV1().ConfigMaps(defaultNamespace),
+		clientSet:          clientSet,
+		defaultNamespace:   defaultNamespace,
+	}
+}
+
+func (c *configMapInterface) forContext(ctx context.Context) v1.ConfigMapInterface {
+	return c.clientSet.CoreV1().ConfigMaps(FromContext(ctx, c.defaultNamespace))
+}
+
+func (c *configMapInterface) Create(ctx context.Context, configMap *corev1.ConfigMap, opts metav1.CreateOptions) (*corev1.ConfigMap, error) {
+	return c.forContext(ctx).Create(ctx, configMap, opts)
+}
+
+func (c *configMapInterface) Update(ctx context.Context, configMap *corev1.ConfigMap, opts metav1.UpdateOptions) (*corev1.ConfigMap, error) {
+	return c.forContext(ctx).Update(ctx, configMap, opts)
+}
+
+func (c *configMapInterface) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.forContext(ctx).Delete(ctx, name, opts)
+}
+
+func (c *configMapInterface) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	return c.forContext(ctx).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (c *configMapInterface) Get(ctx context.Context, name string, opts metav1.GetOptions) (*corev1.ConfigMap, error) {
+	return c.forContext(ctx).Get(ctx, name, opts)
+}
+
+func (c *configMapInterface) List(ctx context.Context, opts metav1.ListOptions) (*corev1.ConfigMapList, error) {
+	return c.forContext(ctx).List(ctx, opts)
+}
+
+func (c *configMapInterface) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.forContext(ctx).Watch(ctx, opts)
+}
+
+func (c *configMapInterface) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (*corev1.ConfigMap, error) {
+	return c.forContext(ctx).Patch(ctx, name, pt, data, opts, subresources...)
+}
+
+func (c *configMapInterface) Apply(ctx context.Context, configMap *applycorev1.ConfigMapApplyConfiguration, opts metav1.ApplyOptions) (*corev1.ConfigMap, error) {
+	return c.forContext(ctx).Apply(ctx, configMap, opts)
+}
+
+// secretInterface sends every request to the namespace of the request context.
+// The embedded client is bound to the default namespace and serves methods without a context.
+type secretInterface struct {
+	v1.SecretInterface
+	clientSet        kubernetes.Interface
+	defaultNamespace string
+}
+
+// NewSecretInterface creates a secret client which resolves the namespace from the request context.
+func NewSecretInterface(clientSet kubernetes.Interface, defaultNamespace string) v1.SecretInterface {
+	return &secretInterface{
+		SecretInterface:  clientSet.CoreV1().Secrets(defaultNamespace),
+		clientSet:        clientSet,
+		defaultNamespace: defaultNamespace,
+	}
+}
+
+func (s *secretInterface) forContext(ctx context.Context) v1.SecretInterface {
+	return s.clientSet.CoreV1().Secrets(FromContext(ctx, s.defaultNamespace))
+}
+
+func (s *secretInterface) Create(ctx context.Context, secret *corev1.Secret, opts metav1.CreateOptions) (*corev1.Secret, error) {
+	return s.forContext(ctx).Create(ctx, secret, opts)
+}
+
+func (s *secretInterface) Update(ctx context.Context, secret *corev1.Secret, opts metav1.UpdateOptions) (*corev1.Secret, error) {
+	return s.forContext(ctx).Update(ctx, secret, opts)
+}
+
+func (s *secretInterface) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return s.forContext(ctx).Delete(ctx, name, opts)
+}
+
+func (s *secretInterface) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	return s.forContext(ctx).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (s *secretInterface) Get(ctx context.Context, name string, opts metav1.GetOptions) (*corev1.Secret, error) {
+	return s.forContext(ctx).Get(ctx, name, opts)
+}
+
+func (s *secretInterface) List(ctx context.Context, opts metav1.ListOptions) (*corev1.SecretList, error) {
+	return s.forContext(ctx).List(ctx, opts)
+}
+
+func (s *secretInterface) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return s.forContext(ctx).Watch(ctx, opts)
+}
+
+func (s *secretInterface) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (*corev1.Secret, error) {
+	return s.forContext(ctx).Patch(ctx, name, pt, data, opts, subresources...)
+}
+
+func (s *secretInterface) Apply(ctx context.Context, secret *applycorev1.SecretApplyConfiguration, opts metav1.ApplyOptions) (*corev1.Secret, error) {
+	return s.forContext(ctx).Apply(ctx, secret, opts)
+}
+
+// podInterface sends every request to the namespace of the request context.
+// The embedded client is bound to the default namespace and serves methods without a context, e.g. GetLogs.
+type podInterface struct {
+	v1.PodInterface
+	clientSet        kubernetes.Interface
+	defaultNamespace string
+}
+
+// NewPodInterface creates a pod client which resolves the namespace from the request context.
+func NewPodInterface(clientSet kubernetes.Interface, defaultNamespace string) v1.PodInterface {
+	return &podInterface{
+		PodInterface:     clientSet.CoreV1().Pods(defaultNamespace),
+		clientSet:        clientSet,
+		defaultNamespace: defaultNamespace,
+	}
+}
+
+func (p *podInterface) forContext(ctx context.Context) v1.PodInterface {
+	return p.clientSet.CoreV1().Pods(FromContext(ctx, p.defaultNamespace))
+}
+
+func (p *podInterface) Create(ctx context.Context, pod *corev1.Pod, opts metav1.CreateOptions) (*corev1.Pod, error) {
+	return p.forContext(ctx).Create(ctx, pod, opts)
+}
+
+func (p *podInterface) Update(ctx context.Context, pod *corev1.Pod, opts metav1.UpdateOptions) (*corev1.Pod, error) {
+	return p.forContext(ctx).Update(ctx, pod, opts)
+}
+
+func (p *podInterface) UpdateStatus(ctx context.Context, pod *corev1.Pod, opts metav1.UpdateOptions) (*corev1.Pod, error) {
+	return p.forContext(ctx).UpdateStatus(ctx, pod, opts)
+}
+
+func (p *podInterface) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return p.forContext(ctx).Delete(ctx, name, opts)
+}
+
+func (p *podInterface) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	return p.forContext(ctx).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (p *podInterface) Get(ctx context.Context, name string, opts metav1.GetOptions) (*corev1.Pod, error) {
+	return p.forContext(ctx).Get(ctx, name, opts)
+}
+
+func (p *podInterface) List(ctx context.Context, opts metav1.ListOptions) (*corev1.PodList, error) {
+	return p.forContext(ctx).List(ctx, opts)
+}
+
+func (p *podInterface) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return p.forContext(ctx).Watch(ctx, opts)
+}
+
+func (p *podInterface) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (*corev1.Pod, error) {
+	return p.forContext(ctx).Patch(ctx, name, pt, data, opts, subresources...)
+}
+
+func (p *podInterface) Apply(ctx context.Context, pod *applycorev1.PodApplyConfiguration, opts metav1.ApplyOptions) (*corev1.Pod, error) {
+	return p.forContext(ctx).Apply(ctx, pod, opts)
+}
+
+func (p *podInterface) ApplyStatus(ctx context.Context, pod *applycorev1.PodApplyConfiguration, opts metav1.ApplyOptions) (*corev1.Pod, error) {
+	return p.forContext(ctx).ApplyStatus(ctx, pod, opts)
+}
+
+func (p *podInterface) UpdateEphemeralContainers(ctx context.Context, podName string, pod *corev1.Pod, opts metav1.UpdateOptions) (*corev1.Pod, error) {
+	return p.forContext(ctx).UpdateEphemeralContainers(ctx, podName, pod, opts)
+}
+
+func (p *podInterface) UpdateResize(ctx context.Context, podName string, pod *corev1.Pod, opts metav1.UpdateOptions) (*corev1.Pod, error) {
+	return p.forContext(ctx).UpdateResize(ctx, podName, pod, opts)
+}
+
+func (p *podInterface) Bind(ctx context.Context, binding *corev1.Binding, opts metav1.CreateOptions) error {
+	return p.forContext(ctx).Bind(ctx, binding, opts)
+}
+
+func (p *podInterface) Evict(ctx context.Context, eviction *policyv1beta1.Eviction) error {
+	return p.forContext(ctx).Evict(ctx, eviction) //nolint:staticcheck // the deprecated method has to be delegated as well
+}
+
+func (p *podInterface) EvictV1(ctx context.Context, eviction *policyv1.Eviction) error {
+	return p.forContext(ctx).EvictV1(ctx, eviction)
+}
+
+func (p *podInterface) EvictV1beta1(ctx context.Context, eviction *policyv1beta1.Eviction) error {
+	return p.forContext(ctx).EvictV1beta1(ctx, eviction)
+}
+
+// serviceInterface sends every request to the namespace of the request context.
+// The embedded client is bound to the default namespace and serves methods without a context, e.g. ProxyGet.
+type serviceInterface struct {
+	v1.ServiceInterface
+	clientSet        kubernetes.Interface
+	defaultNamespace string
+}
+
+// NewServiceInterface creates a service client which resolves the namespace from the request context.
+func NewServiceInterface(clientSet kubernetes.Interface, defaultNamespace string) v1.ServiceInterface {
+	return &serviceInterface{
+		ServiceInterface: clientSet.CoreV1().Services(defaultNamespace),
+		clientSet:        clientSet,
+		defaultNamespace: defaultNamespace,
+	}
+}
+
+func (s *serviceInterface) forContext(ctx context.Context) v1.ServiceInterface {
+	return s.clientSet.CoreV1().Services(FromContext(ctx, s.defaultNamespace))
+}
+
+func (s *serviceInterface) Create(ctx context.Context, service *corev1.Service, opts metav1.CreateOptions) (*corev1.Service, error) {
+	return s.forContext(ctx).Create(ctx, service, opts)
+}
+
+func (s *serviceInterface) Update(ctx context.Context, service *corev1.Service, opts metav1.UpdateOptions) (*corev1.Service, error) {
+	return s.forContext(ctx).Update(ctx, service, opts)
+}
+
+func (s *serviceInterface) UpdateStatus(ctx context.Context, service *corev1.Service, opts metav1.UpdateOptions) (*corev1.Service, error) {
+	return s.forContext(ctx).UpdateStatus(ctx, service, opts)
+}
+
+func (s *serviceInterface) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return s.forContext(ctx).Delete(ctx, name, opts)
+}
+
+func (s *serviceInterface) Get(ctx context.Context, name string, opts metav1.GetOptions) (*corev1.Service, error) {
+	return s.forContext(ctx).Get(ctx, name, opts)
+}
+
+func (s *serviceInterface) List(ctx context.Context, opts metav1.ListOptions) (*corev1.ServiceList, error) {
+	return s.forContext(ctx).List(ctx, opts)
+}
+
+func (s *serviceInterface) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return s.forContext(ctx).Watch(ctx, opts)
+}
+
+func (s *serviceInterface) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (*corev1.Service, error) {
+	return s.forContext(ctx).Patch(ctx, name, pt, data, opts, subresources...)
+}
+
+func (s *serviceInterface) Apply(ctx context.Context, service *applycorev1.ServiceApplyConfiguration, opts metav1.ApplyOptions) (*corev1.Service, error) {
+	return s.forContext(ctx).Apply(ctx, service, opts)
+}
+
+func (s *serviceInterface) ApplyStatus(ctx context.Context, service *applycorev1.ServiceApplyConfiguration, opts metav1.ApplyOptions) (*corev1.Service, error) {
+	return s.forContext(ctx).ApplyStatus(ctx, service, opts)
+}
+
+// persistentVolumeClaimInterface sends every request to the namespace of the request context.
+// The embedded client is bound to the default namespace and serves methods without a context.
+type persistentVolumeClaimInterface struct {
+	v1.PersistentVolumeClaimInterface
+	clientSet        kubernetes.Interface
+	defaultNamespace string
+}
+
+// NewPersistentVolumeClaimInterface creates a pvc client which resolves the namespace from the request context.
+func NewPersistentVolumeClaimInterface(clientSet kubernetes.Interface, defaultNamespace string) v1.PersistentVolumeClaimInterface {
+	return &persistentVolumeClaimInterface{
+		PersistentVolumeClaimInterface: clientSet.CoreV1().PersistentVolumeClaims(defaultNamespace),
+		clientSet:                      clientSet,
+		defaultNamespace:               defaultNamespace,
+	}
+}
+
+func (p *persistentVolumeClaimInterface) forContext(ctx context.Context) v1.PersistentVolumeClaimInterface {
+	return p.clientSet.CoreV1().PersistentVolumeClaims(FromContext(ctx, p.defaultNamespace))
+}
+
+func (p *persistentVolumeClaimInterface) Create(ctx context.Context, pvc *corev1.PersistentVolumeClaim, opts metav1.CreateOptions) (*corev1.PersistentVolumeClaim, error) {
+	return p.forContext(ctx).Create(ctx, pvc, opts)
+}
+
+func (p *persistentVolumeClaimInterface) Update(ctx context.Context, pvc *corev1.PersistentVolumeClaim, opts metav1.UpdateOptions) (*corev1.PersistentVolumeClaim, error) {
+	return p.forContext(ctx).Update(ctx, pvc, opts)
+}
+
+func (p *persistentVolumeClaimInterface) UpdateStatus(ctx context.Context, pvc *corev1.PersistentVolumeClaim, opts metav1.UpdateOptions) (*corev1.PersistentVolumeClaim, error) {
+	return p.forContext(ctx).UpdateStatus(ctx, pvc, opts)
+}
+
+func (p *persistentVolumeClaimInterface) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return p.forContext(ctx).Delete(ctx, name, opts)
+}
+
+func (p *persistentVolumeClaimInterface) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	return p.forContext(ctx).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (p *persistentVolumeClaimInterface) Get(ctx context.Context, name string, opts metav1.GetOptions) (*corev1.PersistentVolumeClaim, error) {
+	return p.forContext(ctx).Get(ctx, name, opts)
+}
+
+func (p *persistentVolumeClaimInterface) List(ctx context.Context, opts metav1.ListOptions) (*corev1.PersistentVolumeClaimList, error) {
+	return p.forContext(ctx).List(ctx, opts)
+}
+
+func (p *persistentVolumeClaimInterface) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return p.forContext(ctx).Watch(ctx, opts)
+}
+
+func (p *persistentVolumeClaimInterface) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (*corev1.PersistentVolumeClaim, error) {
+	return p.forContext(ctx).Patch(ctx, name, pt, data, opts, subresources...)
+}
+
+func (p *persistentVolumeClaimInterface) Apply(ctx context.Context, pvc *applycorev1.PersistentVolumeClaimApplyConfiguration, opts metav1.ApplyOptions) (*corev1.PersistentVolumeClaim, error) {
+	return p.forContext(ctx).Apply(ctx, pvc, opts)
+}
+
+func (p *persistentVolumeClaimInterface) ApplyStatus(ctx context.Context, pvc *applycorev1.PersistentVolumeClaimApplyConfiguration, opts metav1.ApplyOptions) (*corev1.PersistentVolumeClaim, error) {
+	return p.forContext(ctx).ApplyStatus(ctx, pvc, opts)
+}
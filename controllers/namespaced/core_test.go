@@ -0,0 +1,93 @@
+package namespaced
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNewConfigMapInterface(t *testing.T) {
+	clientSet := fake.NewClientset(
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "global-config", Namespace: "ecosystem"}, Data: map[string]string{"fqdn": "ecosystem.local"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "global-config", Namespace: "stage"}, Data: map[string]string{"fqdn": "stage.local"}},
+	)
+	sut := NewConfigMapInterface(clientSet, "ecosystem")
+
+	t.Run("should use default namespace without namespace in context", func(t *testing.T) {
+		// when
+		configMap, err := sut.Get(testCtx, "global-config", metav1.GetOptions{})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "ecosystem.local", configMap.Data["fqdn"])
+	})
+	t.Run("should use namespace of context", func(t *testing.T) {
+		// when
+		configMap, err := sut.Get(WithNamespace(testCtx, "stage"), "global-config", metav1.GetOptions{})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "stage.local", configMap.Data["fqdn"])
+	})
+	t.Run("should create in namespace of context", func(t *testing.T) {
+		// given
+		ctx := WithNamespace(testCtx, "stage")
+
+		// when
+		_, err := sut.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "dogu-health"}}, metav1.CreateOptions{})
+
+		// then
+		require.NoError(t, err)
+		_, err = clientSet.CoreV1().ConfigMaps("stage").Get(testCtx, "dogu-health", metav1.GetOptions{})
+		assert.NoError(t, err)
+		_, err = clientSet.CoreV1().ConfigMaps("ecosystem").Get(testCtx, "dogu-health", metav1.GetOptions{})
+		assert.Error(t, err)
+	})
+}
+
+func TestNewPodInterface(t *testing.T) {
+	t.Run("should list pods of all namespaces", func(t *testing.T) {
+		// given
+		clientSet := fake.NewClientset(
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "cas", Namespace: "ecosystem"}},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "cas", Namespace: "stage"}},
+		)
+		sut := NewPodInterface(clientSet, "ecosystem")
+
+		// when
+		defaultList, err := sut.List(testCtx, metav1.ListOptions{})
+		require.NoError(t, err)
+		allList, err := sut.List(WithNamespace(testCtx, metav1.NamespaceAll), metav1.ListOptions{})
+		require.NoError(t, err)
+
+		// then
+		assert.Len(t, defaultList.Items, 1)
+		assert.Len(t, allList.Items, 2)
+	})
+}
+
+func TestNewDeploymentInterface(t *testing.T) {
+	t.Run("should delete in namespace of context", func(t *testing.T) {
+		// given
+		clientSet := fake.NewClientset(
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "cas", Namespace: "ecosystem"}},
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "cas", Namespace: "stage"}},
+		)
+		sut := NewDeploymentInterface(clientSet, "ecosystem")
+
+		// when
+		err := sut.Delete(WithNamespace(testCtx, "stage"), "cas", metav1.DeleteOptions{})
+
+		// then
+		require.NoError(t, err)
+		_, err = clientSet.AppsV1().Deployments("ecosystem").Get(testCtx, "cas", metav1.GetOptions{})
+		assert.NoError(t, err)
+		_, err = clientSet.AppsV1().Deployments("stage").Get(testCtx, "cas", metav1.GetOptions{})
+		assert.Error(t, err)
+	})
+}
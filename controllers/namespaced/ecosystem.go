@@ -0,0 +1,200 @@
+package namespaced
+
+import (
+	"context"
+
+	authRegApiV1 "github.com/cloudogu/k8s-auth-registration-lib/api/v1"
+	authRegClientV1 "github.com/cloudogu/k8s-auth-registration-lib/client/typed/api/v1"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// doguInterface sends every request to the namespace of the request context.
+// The embedded client is bound to the default namespace and serves methods without a context.
+type doguInterface struct {
+	doguClient.DoguInterface
+	ecosystemClientSet doguClient.EcoSystemV2Interface
+	defaultNamespace   string
+}
+
+// NewDoguInterface creates a dogu client which resolves the namespace from the request context.
+func NewDoguInterface(ecosystemClientSet doguClient.EcoSystemV2Interface, defaultNamespace string) doguClient.DoguInterface {
+	return &doguInterface{
+		DoguInterface:      ecosystemClientSet.Dogus(defaultNamespace),
+		ecosystemClientSet: ecosystemClientSet,
+		defaultNamespace:   defaultNamespace,
+	}
+}
+
+func (d *doguInterface) forContext(ctx context.Context) doguClient.DoguInterface {
+	return d.ecosystemClientSet.Dogus(FromContext(ctx, d.defaultNamespace))
+}
+
+func (d *doguInterface) Create(ctx context.Context, dogu *v2.Dogu, opts metav1.CreateOptions) (*v2.Dogu, error) {
+	return d.forContext(ctx).Create(ctx, dogu, opts)
+}
+
+func (d *doguInterface) Update(ctx context.Context, dogu *v2.Dogu, opts metav1.UpdateOptions) (*v2.Dogu, error) {
+	return d.forContext(ctx).Update(ctx, dogu, opts)
+}
+
+func (d *doguInterface) UpdateStatus(ctx context.Context, dogu *v2.Dogu, opts metav1.UpdateOptions) (*v2.Dogu, error) {
+	return d.forContext(ctx).UpdateStatus(ctx, dogu, opts)
+}
+
+func (d *doguInterface) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return d.forContext(ctx).Delete(ctx, name, opts)
+}
+
+func (d *doguInterface) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	return d.forContext(ctx).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (d *doguInterface) Get(ctx context.Context, name string, opts metav1.GetOptions) (*v2.Dogu, error) {
+	return d.forContext(ctx).Get(ctx, name, opts)
+}
+
+func (d *doguInterface) List(ctx context.Context, opts metav1.ListOptions) (*v2.DoguList, error) {
+	return d.forContext(ctx).List(ctx, opts)
+}
+
+func (d *doguInterface) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return d.forContext(ctx).Watch(ctx, opts)
+}
+
+func (d *doguInterface) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (*v2.Dogu, error) {
+	return d.forContext(ctx).Patch(ctx, name, pt, data, opts, subresources...)
+}
+
+func (d *doguInterface) UpdateSpecWithRetry(ctx context.Context, dogu *v2.Dogu, modifySpecFn func(v2.DoguSpec) v2.DoguSpec, opts metav1.UpdateOptions) (*v2.Dogu, error) {
+	return d.forContext(ctx).UpdateSpecWithRetry(ctx, dogu, modifySpecFn, opts)
+}
+
+func (d *doguInterface) UpdateStatusWithRetry(ctx context.Context, dogu *v2.Dogu, modifyStatusFn func(v2.DoguStatus) v2.DoguStatus, opts metav1.UpdateOptions) (*v2.Dogu, error) {
+	return d.forContext(ctx).UpdateStatusWithRetry(ctx, dogu, modifyStatusFn, opts)
+}
+
+// doguRestartInterface sends every request to the namespace of the request context.
+// The embedded client is bound to the default namespace and serves methods without a context.
+type doguRestartInterface struct {
+	doguClient.DoguRestartInterface
+	ecosystemClientSet doguClient.EcoSystemV2Interface
+	defaultNamespace   string
+}
+
+// NewDoguRestartInterface creates a dogu restart client which resolves the namespace from the request context.
+func NewDoguRestartInterface(ecosystemClientSet doguClient.EcoSystemV2Interface, defaultNamespace string) doguClient.DoguRestartInterface {
+	return &doguRestartInterface{
+		DoguRestartInterface: ecosystemClientSet.DoguRestarts(defaultNamespace),
+		ecosystemClientSet:   ecosystemClientSet,
+		defaultNamespace:     defaultNamespace,
+	}
+}
+
+func (d *doguRestartInterface) forContext(ctx context.Context) doguClient.DoguRestartInterface {
+	return d.ecosystemClientSet.DoguRestarts(FromContext(ctx, d.defaultNamespace))
+}
+
+func (d *doguRestartInterface) Create(ctx context.Context, doguRestart *v2.DoguRestart, opts metav1.CreateOptions) (*v2.DoguRestart, error) {
+	return d.forContext(ctx).Create(ctx, doguRestart, opts)
+}
+
+func (d *doguRestartInterface) Update(ctx context.Context, doguRestart *v2.DoguRestart, opts metav1.UpdateOptions) (*v2.DoguRestart, error) {
+	return d.forContext(ctx).Update(ctx, doguRestart, opts)
+}
+
+func (d *doguRestartInterface) UpdateStatus(ctx context.Context, doguRestart *v2.DoguRestart, opts metav1.UpdateOptions) (*v2.DoguRestart, error) {
+	return d.forContext(ctx).UpdateStatus(ctx, doguRestart, opts)
+}
+
+func (d *doguRestartInterface) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return d.forContext(ctx).Delete(ctx, name, opts)
+}
+
+func (d *doguRestartInterface) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	return d.forContext(ctx).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (d *doguRestartInterface) Get(ctx context.Context, name string, opts metav1.GetOptions) (*v2.DoguRestart, error) {
+	return d.forContext(ctx).Get(ctx, name, opts)
+}
+
+func (d *doguRestartInterface) List(ctx context.Context, opts metav1.ListOptions) (*v2.DoguRestartList, error) {
+	return d.forContext(ctx).List(ctx, opts)
+}
+
+func (d *doguRestartInterface) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return d.forContext(ctx).Watch(ctx, opts)
+}
+
+func (d *doguRestartInterface) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (*v2.DoguRestart, error) {
+	return d.forContext(ctx).Patch(ctx, name, pt, data, opts, subresources...)
+}
+
+func (d *doguRestartInterface) UpdateSpecWithRetry(ctx context.Context, doguRestart *v2.DoguRestart, modifySpecFn func(v2.DoguRestartSpec) v2.DoguRestartSpec, opts metav1.UpdateOptions) (*v2.DoguRestart, error) {
+	return d.forContext(ctx).UpdateSpecWithRetry(ctx, doguRestart, modifySpecFn, opts)
+}
+
+func (d *doguRestartInterface) UpdateStatusWithRetry(ctx context.Context, doguRestart *v2.DoguRestart, modifyStatusFn func(v2.DoguRestartStatus) v2.DoguRestartStatus, opts metav1.UpdateOptions) (*v2.DoguRestart, error) {
+	return d.forContext(ctx).UpdateStatusWithRetry(ctx, doguRestart, modifyStatusFn, opts)
+}
+
+// authRegistrationInterface sends every request to the namespace of the request context.
+// The embedded client is bound to the default namespace and serves methods without a context.
+type authRegistrationInterface struct {
+	authRegClientV1.AuthRegistrationInterface
+	authRegistrationClientSet authRegClientV1.ApiV1Interface
+	defaultNamespace          string
+}
+
+// NewAuthRegistrationInterface creates an auth registration client which resolves the namespace from the request context.
+func NewAuthRegistrationInterface(authRegistrationClientSet authRegClientV1.ApiV1Interface, defaultNamespace string) authRegClientV1.AuthRegistrationInterface {
+	return &authRegistrationInterface{
+		AuthRegistrationInterface: authRegistrationClientSet.AuthRegistrations(defaultNamespace),
+		authRegistrationClientSet: authRegistrationClientSet,
+		defaultNamespace:          defaultNamespace,
+	}
+}
+
+func (a *authRegistrationInterface) forContext(ctx context.Context) authRegClientV1.AuthRegistrationInterface {
+	return a.authRegistrationClientSet.AuthRegistrations(FromContext(ctx, a.defaultNamespace))
+}
+
+func (a *authRegistrationInterface) Create(ctx context.Context, authRegistration *authRegApiV1.AuthRegistration, opts metav1.CreateOptions) (*authRegApiV1.AuthRegistration, error) {
+	return a.forContext(ctx).Create(ctx, authRegistration, opts)
+}
+
+func (a *authRegistrationInterface) Update(ctx context.Context, authRegistration *authRegApiV1.AuthRegistration, opts metav1.UpdateOptions) (*authRegApiV1.AuthRegistration, error) {
+	return a.forContext(ctx).Update(ctx, authRegistration, opts)
+}
+
+func (a *authRegistrationInterface) UpdateStatus(ctx context.Context, authRegistration *authRegApiV1.AuthRegistration, opts metav1.UpdateOptions) (*authRegApiV1.AuthRegistration, error) {
+	return a.forContext(ctx).UpdateStatus(ctx, authRegistration, opts)
+}
+
+func (a *authRegistrationInterface) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return a.forContext(ctx).Delete(ctx, name, opts)
+}
+
+func (a *authRegistrationInterface) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	return a.forContext(ctx).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (a *authRegistrationInterface) Get(ctx context.Context, name string, opts metav1.GetOptions) (*authRegApiV1.AuthRegistration, error) {
+	return a.forContext(ctx).Get(ctx, name, opts)
+}
+
+func (a *authRegistrationInterface) List(ctx context.Context, opts metav1.ListOptions) (*authRegApiV1.AuthRegistrationList, error) {
+	return a.forContext(ctx).List(ctx, opts)
+}
+
+func (a *authRegistrationInterface) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return a.forContext(ctx).Watch(ctx, opts)
+}
+
+func (a *authRegistrationInterface) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (*authRegApiV1.AuthRegistration, error) {
+	return a.forContext(ctx).Patch(ctx, name, pt, data, opts, subresources...)
+}
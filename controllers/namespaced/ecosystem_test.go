@@ -0,0 +1,50 @@
+package namespaced
+
+import (
+	"testing"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewDoguInterface(t *testing.T) {
+	t.Run("should resolve dogu client for namespace of context", func(t *testing.T) {
+		// given
+		defaultDoguClientMock := newMockDoguClientInterface(t)
+		stageDoguClientMock := newMockDoguClientInterface(t)
+		ctx := WithNamespace(testCtx, "stage")
+		stageDoguClientMock.EXPECT().Get(ctx, "cas", metav1.GetOptions{}).Return(&v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "cas", Namespace: "stage"}}, nil)
+
+		ecosystemMock := newMockEcosystemClientSet(t)
+		ecosystemMock.EXPECT().Dogus("ecosystem").Return(defaultDoguClientMock)
+		ecosystemMock.EXPECT().Dogus("stage").Return(stageDoguClientMock)
+
+		sut := NewDoguInterface(ecosystemMock, "ecosystem")
+
+		// when
+		dogu, err := sut.Get(ctx, "cas", metav1.GetOptions{})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "stage", dogu.Namespace)
+	})
+	t.Run("should fall back to default namespace", func(t *testing.T) {
+		// given
+		doguClientMock := newMockDoguClientInterface(t)
+		doguClientMock.EXPECT().UpdateStatusWithRetry(testCtx, mock.Anything, mock.Anything, metav1.UpdateOptions{}).Return(nil, assert.AnError)
+
+		ecosystemMock := newMockEcosystemClientSet(t)
+		ecosystemMock.EXPECT().Dogus("ecosystem").Return(doguClientMock)
+
+		sut := NewDoguInterface(ecosystemMock, "ecosystem")
+
+		// when
+		_, err := sut.UpdateStatusWithRetry(testCtx, &v2.Dogu{}, func(status v2.DoguStatus) v2.DoguStatus { return status }, metav1.UpdateOptions{})
+
+		// then
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -0,0 +1,17 @@
+package namespaced
+
+import (
+	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
+)
+
+//nolint:unused
+//goland:noinspection GoUnusedType
+type ecosystemClientSet interface {
+	doguClient.EcoSystemV2Interface
+}
+
+//nolint:unused
+//goland:noinspection GoUnusedType
+type doguClientInterface interface {
+	doguClient.DoguInterface
+}
@@ -0,0 +1,189 @@
+// Package objectdiff compares live resources in the cluster with the resources generated by the dogu operator.
+package objectdiff
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// ignoredMetadataFields are set by the API server and not by the operator.
+var ignoredMetadataFields = []string{"creationTimestamp", "generation", "managedFields", "resourceVersion", "selfLink", "uid"}
+
+// Diff compares the live object with the planned object.
+// Fields which are only set in the live object, like defaults of the API server, are ignored.
+type Diff struct {
+	live    map[string]interface{}
+	planned map[string]interface{}
+}
+
+// New compares the live object with the planned object. The live object may be nil if it does not exist yet.
+func New(live, planned client.Object) (*Diff, error) {
+	plannedMap, err := toComparableMap(planned)
+	if err != nil {
+		return nil, err
+	}
+
+	if live == nil || reflect.ValueOf(live).IsNil() {
+		return &Diff{planned: plannedMap}, nil
+	}
+
+	liveMap, err := toComparableMap(live)
+	if err != nil {
+		return nil, err
+	}
+
+	prunedLive, _ := prune(plannedMap, liveMap).(map[string]interface{})
+	return &Diff{live: prunedLive, planned: plannedMap}, nil
+}
+
+// LiveExists returns true if the live object exists.
+func (d *Diff) LiveExists() bool {
+	return d.live != nil
+}
+
+// Changed returns true if the field with the given path differs between the live and the planned object.
+// Without a path, the whole objects are compared.
+func (d *Diff) Changed(fields ...string) bool {
+	liveField, _, _ := unstructured.NestedFieldNoCopy(d.live, fields...)
+	plannedField, _, _ := unstructured.NestedFieldNoCopy(d.planned, fields...)
+	return !reflect.DeepEqual(liveField, plannedField)
+}
+
+// ChangedFields returns the paths of all fields which differ between the existing live object and the planned object.
+// Fields which are only set in the live object, like defaults of the API server, are ignored.
+func ChangedFields(live, planned client.Object) ([]string, error) {
+	diff, err := New(live, planned)
+	if err != nil {
+		return nil, err
+	}
+
+	return changedFields("", diff.live, diff.planned), nil
+}
+
+// changedFields collects the paths of the differing fields, e.g. spec.template.spec.containers[0].image.
+// Lists with a different length are reported as a whole.
+func changedFields(path string, live, planned interface{}) []string {
+	switch plannedValue := planned.(type) {
+	case map[string]interface{}:
+		liveMap, ok := live.(map[string]interface{})
+		if !ok {
+			return []string{path}
+		}
+		keys := make([]string, 0, len(plannedValue))
+		for key := range plannedValue {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		var fields []string
+		for _, key := range keys {
+			fieldPath := key
+			if path != "" {
+				fieldPath = path + "." + key
+			}
+			fields = append(fields, changedFields(fieldPath, liveMap[key], plannedValue[key])...)
+		}
+		return fields
+	case []interface{}:
+		liveList, ok := live.([]interface{})
+		if !ok || len(liveList) != len(plannedValue) {
+			return []string{path}
+		}
+
+		var fields []string
+		for i := range plannedValue {
+			fields = append(fields, changedFields(fmt.Sprintf("%s[%d]", path, i), liveList[i], plannedValue[i])...)
+		}
+		return fields
+	default:
+		if reflect.DeepEqual(live, planned) {
+			return nil
+		}
+		return []string{path}
+	}
+}
+
+// Unified returns a unified diff of the YAML representations of the live and the planned object.
+func (d *Diff) Unified() (string, error) {
+	liveYaml, err := toYaml(d.live)
+	if err != nil {
+		return "", err
+	}
+	plannedYaml, err := toYaml(d.planned)
+	if err != nil {
+		return "", err
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(liveYaml),
+		B:        difflib.SplitLines(plannedYaml),
+		FromFile: "live",
+		ToFile:   "planned",
+		Context:  3,
+	})
+}
+
+func toYaml(object map[string]interface{}) (string, error) {
+	if object == nil {
+		return "", nil
+	}
+
+	out, err := yaml.Marshal(object)
+	if err != nil {
+		return "", fmt.Errorf("failed to render object as yaml: %w", err)
+	}
+
+	return string(out), nil
+}
+
+func toComparableMap(object client.Object) (map[string]interface{}, error) {
+	objectMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %s %q: %w", reflect.TypeOf(object).Elem().Name(), object.GetName(), err)
+	}
+
+	delete(objectMap, "status")
+	for _, field := range ignoredMetadataFields {
+		unstructured.RemoveNestedField(objectMap, "metadata", field)
+	}
+
+	return objectMap, nil
+}
+
+// prune removes all fields from the live value that are not set in the planned value.
+// Lists are only pruned element-wise if both lists have the same length.
+func prune(planned, live interface{}) interface{} {
+	switch plannedValue := planned.(type) {
+	case map[string]interface{}:
+		liveMap, ok := live.(map[string]interface{})
+		if !ok {
+			return live
+		}
+		pruned := map[string]interface{}{}
+		for key, value := range plannedValue {
+			if liveValue, found := liveMap[key]; found {
+				pruned[key] = prune(value, liveValue)
+			}
+		}
+		return pruned
+	case []interface{}:
+		liveList, ok := live.([]interface{})
+		if !ok || len(liveList) != len(plannedValue) {
+			return live
+		}
+		pruned := make([]interface{}, len(liveList))
+		for i := range liveList {
+			pruned[i] = prune(plannedValue[i], liveList[i])
+		}
+		return pruned
+	default:
+		return live
+	}
+}
@@ -0,0 +1,152 @@
+package objectdiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func getTestService(port int32) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: "ecosystem", Labels: map[string]string{"dogu.name": "ldap"}},
+		Spec: corev1.ServiceSpec{
+			Type:  corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{{Name: "ldap", Port: port, TargetPort: intstr.FromInt32(port)}},
+		},
+	}
+}
+
+func getLiveService(port int32) *corev1.Service {
+	live := getTestService(port)
+	live.UID = "uid"
+	live.ResourceVersion = "42"
+	live.Spec.ClusterIP = "10.0.0.1"
+	live.Spec.Ports[0].Protocol = corev1.ProtocolTCP
+	live.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "1.2.3.4"}}
+	return live
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should ignore fields which are only set in the live object", func(t *testing.T) {
+		// when
+		diff, err := New(getLiveService(389), getTestService(389))
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, diff.LiveExists())
+		assert.False(t, diff.Changed())
+		unified, err := diff.Unified()
+		require.NoError(t, err)
+		assert.Empty(t, unified)
+	})
+	t.Run("should render changed fields as unified diff", func(t *testing.T) {
+		// when
+		diff, err := New(getLiveService(389), getTestService(636))
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, diff.Changed())
+		assert.True(t, diff.Changed("spec", "ports"))
+		assert.False(t, diff.Changed("metadata"))
+		unified, err := diff.Unified()
+		require.NoError(t, err)
+		assert.Contains(t, unified, "--- live\n+++ planned\n")
+		assert.Contains(t, unified, "   - name: ldap\n-    port: 389\n-    targetPort: 389\n+    port: 636\n+    targetPort: 636\n")
+		assert.NotContains(t, unified, "clusterIP")
+		assert.NotContains(t, unified, "protocol")
+	})
+	t.Run("should render complete object if there is no live object", func(t *testing.T) {
+		// given
+		var live *corev1.Service
+
+		// when
+		diff, err := New(live, getTestService(389))
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, diff.Changed())
+		assert.False(t, diff.LiveExists())
+		unified, err := diff.Unified()
+		require.NoError(t, err)
+		assert.Contains(t, unified, "+  name: ldap\n")
+		assert.NotContains(t, unified, "\n-")
+	})
+}
+
+func Test_prune(t *testing.T) {
+	tests := []struct {
+		name    string
+		planned interface{}
+		live    interface{}
+		want    interface{}
+	}{
+		{
+			name:    "should remove fields missing in planned map",
+			planned: map[string]interface{}{"a": "1"},
+			live:    map[string]interface{}{"a": "2", "b": "3"},
+			want:    map[string]interface{}{"a": "2"},
+		},
+		{
+			name:    "should prune lists with same length element-wise",
+			planned: []interface{}{map[string]interface{}{"a": "1"}},
+			live:    []interface{}{map[string]interface{}{"a": "1", "b": "2"}},
+			want:    []interface{}{map[string]interface{}{"a": "1"}},
+		},
+		{
+			name:    "should keep lists with different length",
+			planned: []interface{}{"a"},
+			live:    []interface{}{"a", "b"},
+			want:    []interface{}{"a", "b"},
+		},
+		{
+			name:    "should keep live value of different type",
+			planned: map[string]interface{}{"a": "1"},
+			live:    "value",
+			want:    "value",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, prune(tt.planned, tt.live))
+		})
+	}
+}
+
+func TestChangedFields(t *testing.T) {
+	t.Run("should ignore fields which are only set in the live object", func(t *testing.T) {
+		// when
+		fields, err := ChangedFields(getLiveService(389), getTestService(389))
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, fields)
+	})
+	t.Run("should return paths of changed fields", func(t *testing.T) {
+		// given
+		live := getLiveService(389)
+		live.Labels["dogu.name"] = "cas"
+
+		// when
+		fields, err := ChangedFields(live, getTestService(636))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"metadata.labels.dogu.name", "spec.ports[0].port", "spec.ports[0].targetPort"}, fields)
+	})
+	t.Run("should return list with different length as a whole", func(t *testing.T) {
+		// given
+		live := getLiveService(389)
+		live.Spec.Ports = append(live.Spec.Ports, corev1.ServicePort{Name: "ldaps", Port: 636})
+
+		// when
+		fields, err := ChangedFields(live, getTestService(389))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"spec.ports"}, fields)
+	})
+}
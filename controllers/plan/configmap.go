@@ -0,0 +1,37 @@
+package plan
+
+import (
+	"context"
+	"fmt"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+)
+
+const (
+	configMapNameSuffix = "-plan"
+	// PlanKey is the key in the plan config map that contains the human-readable plan.
+	PlanKey = "plan"
+	// PlannedGenerationKey is the key in the plan config map that contains the generation of the planned dogu resource.
+	PlannedGenerationKey = "plannedGeneration"
+	// PlanLabel marks config maps that contain the plan of a dogu.
+	PlanLabel = "k8s.cloudogu.com/plan"
+)
+
+// ConfigMapName returns the name of the config map containing the plan of the dogu.
+func ConfigMapName(doguName string) string {
+	return doguName + configMapNameSuffix
+}
+
+// writePlan writes the plan into the plan config map of the dogu.
+func (p *doguPlanner) writePlan(ctx context.Context, doguResource *v2.Dogu, doguPlan *Plan) error {
+	err := p.store.Update(ctx, doguResource, func(data map[string]string) error {
+		data[PlanKey] = doguPlan.String()
+		data[PlannedGenerationKey] = fmt.Sprint(doguResource.Generation)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write plan of dogu %q: %w", doguResource.Name, err)
+	}
+
+	return nil
+}
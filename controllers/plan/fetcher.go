@@ -0,0 +1,30 @@
+package plan
+
+import (
+	"context"
+
+	cloudoguerrors "github.com/cloudogu/ces-commons-lib/errors"
+	"github.com/cloudogu/cesapp-lib/core"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+)
+
+// planDoguFetcher fetches the dogu descriptor of the desired version from the remote registry if it is not registered
+// in the local registry yet. Other than the FetchRemoteDoguDescriptorStep, it does not store the fetched descriptor.
+type planDoguFetcher struct {
+	localDoguFetcher
+	resourceDoguFetcher resourceDoguFetcher
+}
+
+// FetchForResource fetches the dogu descriptor for the desired version of the dogu.
+func (f *planDoguFetcher) FetchForResource(ctx context.Context, doguResource *v2.Dogu) (*core.Dogu, error) {
+	dogu, err := f.localDoguFetcher.FetchForResource(ctx, doguResource)
+	if err == nil {
+		return dogu, nil
+	}
+	if !cloudoguerrors.IsNotFoundError(err) {
+		return nil, err
+	}
+
+	dogu, _, err = f.resourceDoguFetcher.FetchWithResource(ctx, doguResource)
+	return dogu, err
+}
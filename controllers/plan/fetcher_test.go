@@ -0,0 +1,60 @@
+package plan
+
+import (
+	"context"
+	"testing"
+
+	cloudoguerrors "github.com/cloudogu/ces-commons-lib/errors"
+	"github.com/cloudogu/cesapp-lib/core"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCtx = context.Background()
+
+func Test_planDoguFetcher_FetchForResource(t *testing.T) {
+	doguResource := &v2.Dogu{}
+	dogu := &core.Dogu{Name: "official/ldap", Version: "2.6.8-1"}
+
+	t.Run("should fetch registered descriptor", func(t *testing.T) {
+		// given
+		localMock := newMockLocalDoguFetcher(t)
+		localMock.EXPECT().FetchForResource(testCtx, doguResource).Return(dogu, nil)
+		sut := &planDoguFetcher{localDoguFetcher: localMock, resourceDoguFetcher: newMockResourceDoguFetcher(t)}
+
+		// when
+		got, err := sut.FetchForResource(testCtx, doguResource)
+
+		// then
+		require.NoError(t, err)
+		assert.Same(t, dogu, got)
+	})
+	t.Run("should fetch remote descriptor if it is not registered", func(t *testing.T) {
+		// given
+		localMock := newMockLocalDoguFetcher(t)
+		localMock.EXPECT().FetchForResource(testCtx, doguResource).Return(nil, cloudoguerrors.NewNotFoundError(assert.AnError))
+		remoteMock := newMockResourceDoguFetcher(t)
+		remoteMock.EXPECT().FetchWithResource(testCtx, doguResource).Return(dogu, nil, nil)
+		sut := &planDoguFetcher{localDoguFetcher: localMock, resourceDoguFetcher: remoteMock}
+
+		// when
+		got, err := sut.FetchForResource(testCtx, doguResource)
+
+		// then
+		require.NoError(t, err)
+		assert.Same(t, dogu, got)
+	})
+	t.Run("should fail to fetch registered descriptor", func(t *testing.T) {
+		// given
+		localMock := newMockLocalDoguFetcher(t)
+		localMock.EXPECT().FetchForResource(testCtx, doguResource).Return(nil, assert.AnError)
+		sut := &planDoguFetcher{localDoguFetcher: localMock, resourceDoguFetcher: newMockResourceDoguFetcher(t)}
+
+		// when
+		_, err := sut.FetchForResource(testCtx, doguResource)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
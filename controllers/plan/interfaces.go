@@ -0,0 +1,52 @@
+package plan
+
+import (
+	"context"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/cesregistry"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/imageregistry"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/resource"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/upgrade"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Planner determines what the operator would change for a dogu resource without changing the dogu in the cluster.
+type Planner interface {
+	// Plan creates a plan of the changes for the dogu resource and writes it into the plan config map of the dogu.
+	Plan(ctx context.Context, doguResource *v2.Dogu) error
+}
+
+type k8sClient interface {
+	client.Client
+}
+
+type configMapInterface interface {
+	v1.ConfigMapInterface
+}
+
+type localDoguFetcher interface {
+	cesregistry.LocalDoguFetcher
+}
+
+type resourceDoguFetcher interface {
+	cesregistry.ResourceDoguFetcher
+}
+
+type doguResourceGenerator interface {
+	resource.DoguResourceGenerator
+}
+
+type upgradeChecker interface {
+	upgrade.Checker
+}
+
+type imageRegistry interface {
+	imageregistry.ImageRegistry
+}
+
+type step interface {
+	steps.Step
+}
@@ -0,0 +1,93 @@
+package plan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+)
+
+// Action describes what the operator would do with a resource.
+type Action string
+
+const (
+	ActionCreate    Action = "create"
+	ActionUpdate    Action = "update"
+	ActionDelete    Action = "delete"
+	ActionUnchanged Action = "unchanged"
+)
+
+// ResourceChange describes the planned change of a single resource of a dogu.
+type ResourceChange struct {
+	Kind   string
+	Name   string
+	Action Action
+	// Diff is a unified diff between the live and the planned resource. Fields which are only set in the live
+	// resource, like defaults of the API server, are omitted.
+	Diff string
+}
+
+// Plan describes what the operator would do when the dogu resource was applied.
+type Plan struct {
+	Dogu             string
+	InstalledVersion string
+	DesiredVersion   string
+	// ValidationOutcome is the outcome of the validation step.
+	ValidationOutcome steps.Outcome
+	ValidationError   string
+	Upgrade           bool
+	Restart           bool
+	VolumeExpansion   bool
+	// CurrentVolumeSize and PlannedVolumeSize are only set if the volume would be expanded.
+	CurrentVolumeSize string
+	PlannedVolumeSize string
+	Changes           []ResourceChange
+}
+
+// String renders the plan in a human-readable format.
+func (p *Plan) String() string {
+	sb := &strings.Builder{}
+	installedVersion := p.InstalledVersion
+	if installedVersion == "" {
+		installedVersion = "not installed"
+	}
+	_, _ = fmt.Fprintf(sb, "Plan for dogu %q (%s -> %s)\n\n", p.Dogu, installedVersion, p.DesiredVersion)
+
+	_, _ = fmt.Fprintf(sb, "Validation:       %s\n", p.validationSummary())
+	_, _ = fmt.Fprintf(sb, "Upgrade:          %s\n", yesNo(p.Upgrade))
+	_, _ = fmt.Fprintf(sb, "Restart:          %s\n", yesNo(p.Restart))
+	volumeExpansion := yesNo(p.VolumeExpansion)
+	if p.VolumeExpansion {
+		volumeExpansion = fmt.Sprintf("yes (%s -> %s)", p.CurrentVolumeSize, p.PlannedVolumeSize)
+	}
+	_, _ = fmt.Fprintf(sb, "Volume expansion: %s\n", volumeExpansion)
+
+	for _, change := range p.Changes {
+		_, _ = fmt.Fprintf(sb, "\n%s %q: %s\n", change.Kind, change.Name, change.Action)
+		if change.Diff != "" {
+			sb.WriteString(change.Diff)
+		}
+	}
+
+	return sb.String()
+}
+
+func (p *Plan) validationSummary() string {
+	switch p.ValidationOutcome {
+	case steps.OutcomeContinue:
+		return "passed"
+	case steps.OutcomeAbort:
+		return "aborted, e.g. because of a downgrade that is not allowed"
+	case steps.OutcomeError:
+		return fmt.Sprintf("failed: %s", p.ValidationError)
+	default:
+		return string(p.ValidationOutcome)
+	}
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,72 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlan_String(t *testing.T) {
+	t.Run("should render upgrade plan", func(t *testing.T) {
+		// given
+		sut := &Plan{
+			Dogu:              "ldap",
+			InstalledVersion:  "2.6.7-1",
+			DesiredVersion:    "2.6.8-1",
+			ValidationOutcome: steps.OutcomeContinue,
+			Upgrade:           true,
+			Restart:           true,
+			VolumeExpansion:   true,
+			CurrentVolumeSize: "2Gi",
+			PlannedVolumeSize: "5Gi",
+			Changes: []ResourceChange{
+				{Kind: "Deployment", Name: "ldap", Action: ActionUpdate, Diff: "--- live\n+++ planned\n"},
+				{Kind: "Service", Name: "ldap", Action: ActionUnchanged},
+				{Kind: "NetworkPolicy", Name: "ldap-dependency-dogu-postfix", Action: ActionDelete},
+			},
+		}
+
+		// when
+		got := sut.String()
+
+		// then
+		assert.Equal(t, `Plan for dogu "ldap" (2.6.7-1 -> 2.6.8-1)
+
+Validation:       passed
+Upgrade:          yes
+Restart:          yes
+Volume expansion: yes (2Gi -> 5Gi)
+
+Deployment "ldap": update
+--- live
++++ planned
+
+Service "ldap": unchanged
+
+NetworkPolicy "ldap-dependency-dogu-postfix": delete
+`, got)
+	})
+	t.Run("should render failed validation of new dogu", func(t *testing.T) {
+		// given
+		sut := &Plan{
+			Dogu:              "ldap",
+			DesiredVersion:    "2.6.8-1",
+			ValidationOutcome: steps.OutcomeError,
+			ValidationError:   "dependency is not healthy",
+		}
+
+		// when
+		got := sut.String()
+
+		// then
+		assert.Contains(t, got, `Plan for dogu "ldap" (not installed -> 2.6.8-1)`)
+		assert.Contains(t, got, "Validation:       failed: dependency is not healthy\n")
+		assert.Contains(t, got, "Volume expansion: no\n")
+	})
+	t.Run("should render aborted validation", func(t *testing.T) {
+		sut := &Plan{ValidationOutcome: steps.OutcomeAbort}
+
+		assert.Contains(t, sut.String(), "Validation:       aborted")
+	})
+}
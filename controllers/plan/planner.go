@@ -0,0 +1,307 @@
+package plan
+
+import (
+	"context"
+	"fmt"
+
+	cloudoguerrors "github.com/cloudogu/ces-commons-lib/errors"
+	"github.com/cloudogu/cesapp-lib/core"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/additionalMount"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/cesregistry"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/coordination"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/dependency"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/dogustore"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/health"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/imageregistry"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/objectdiff"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/resource"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/security"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps/install"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/upgrade"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DryRunAnnotation switches a dogu resource into the plan mode if set to "true". Instead of applying the dogu resource,
+// the operator writes a plan of the changes into the plan config map of the dogu.
+const DryRunAnnotation = "k8s.cloudogu.com/dry-run"
+
+// IsDryRun returns true if the object is annotated for the plan mode.
+func IsDryRun(object metav1.Object) bool {
+	return object.GetAnnotations()[DryRunAnnotation] == "true"
+}
+
+type doguPlanner struct {
+	client                 k8sClient
+	store                  *dogustore.ConfigMapStore
+	scheme                 *runtime.Scheme
+	doguFetcher            localDoguFetcher
+	resourceGenerator      doguResourceGenerator
+	upgradeChecker         upgradeChecker
+	imageRegistry          imageRegistry
+	validationStep         step
+	networkPoliciesEnabled bool
+}
+
+// NewDoguPlanner creates a Planner which only reads from the cluster and the dogu registries.
+// The only object it writes is the plan config map of the dogu.
+func NewDoguPlanner(
+	k8sClient client.Client,
+	configMapInterface v1.ConfigMapInterface,
+	scheme *runtime.Scheme,
+	localFetcher cesregistry.LocalDoguFetcher,
+	resourceFetcher cesregistry.ResourceDoguFetcher,
+	generator resource.DoguResourceGenerator,
+	checker upgrade.Checker,
+	registry imageregistry.ImageRegistry,
+	healthChecker health.DoguHealthChecker,
+	dependencyValidator dependency.Validator,
+	reverseDependencyValidator dependency.ReverseDependencyValidator,
+	securityValidator security.Validator,
+	doguAdditionalMountsValidator additionalMount.Validator,
+	operatorConfig *config.OperatorConfig,
+) Planner {
+	fetcher := &planDoguFetcher{localDoguFetcher: localFetcher, resourceDoguFetcher: resourceFetcher}
+	return &doguPlanner{
+		client:            k8sClient,
+		store:             dogustore.NewConfigMapStore(configMapInterface, scheme, configMapNameSuffix, PlanLabel),
+		scheme:            scheme,
+		doguFetcher:       fetcher,
+		resourceGenerator: generator,
+		upgradeChecker:    checker,
+		imageRegistry:     registry,
+		// the validation step gets its own fetcher because the descriptor of the desired version is not yet
+		// registered locally when planning an upgrade; it must neither emit events for the dogu nor put it on the wait
+		// list of the reconciler, so it gets a discarding recorder and a wait list of its own which is never woken
+		validationStep:         install.NewValidationStep(healthChecker, fetcher, dependencyValidator, reverseDependencyValidator, securityValidator, doguAdditionalMountsValidator, discardingRecorder{}, coordination.NewWaitList(nil)),
+		networkPoliciesEnabled: operatorConfig.NetworkPoliciesEnabled,
+	}
+}
+
+// Plan creates a plan of the changes for the dogu resource and writes it into the plan config map of the dogu.
+func (p *doguPlanner) Plan(ctx context.Context, doguResource *v2.Dogu) error {
+	doguPlan, err := p.createPlan(ctx, doguResource)
+	if err != nil {
+		return fmt.Errorf("failed to create plan for dogu %q: %w", doguResource.Name, err)
+	}
+
+	return p.writePlan(ctx, doguResource, doguPlan)
+}
+
+func (p *doguPlanner) createPlan(ctx context.Context, doguResource *v2.Dogu) (*Plan, error) {
+	doguPlan := &Plan{Dogu: doguResource.Name, DesiredVersion: doguResource.Spec.Version}
+
+	validationResult := p.validationStep.Run(ctx, doguResource)
+	doguPlan.ValidationOutcome = validationResult.Outcome()
+	if validationResult.Err != nil {
+		doguPlan.ValidationError = validationResult.Err.Error()
+	}
+
+	dogu, err := p.doguFetcher.FetchForResource(ctx, doguResource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch dogu descriptor: %w", err)
+	}
+
+	installedDogu, err := p.doguFetcher.FetchInstalled(ctx, doguResource.GetSimpleDoguName())
+	if err != nil && !cloudoguerrors.IsNotFoundError(err) {
+		return nil, err
+	}
+	if err == nil {
+		doguPlan.InstalledVersion = installedDogu.Version
+		doguPlan.Upgrade, err = p.upgradeChecker.IsUpgrade(ctx, doguResource)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = p.planDeployment(ctx, doguPlan, doguResource, dogu)
+	if err != nil {
+		return nil, err
+	}
+
+	service, err := p.planService(ctx, doguPlan, doguResource, dogu)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.planPVC(ctx, doguPlan, doguResource, dogu)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.planNetworkPolicies(ctx, doguPlan, doguResource, dogu, service)
+	if err != nil {
+		return nil, err
+	}
+
+	return doguPlan, nil
+}
+
+func (p *doguPlanner) planDeployment(ctx context.Context, doguPlan *Plan, doguResource *v2.Dogu, dogu *core.Dogu) error {
+	live := &appsv1.Deployment{}
+	err := p.client.Get(ctx, doguResource.GetObjectKey(), live)
+	if client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to get deployment: %w", err)
+	}
+
+	var planned *appsv1.Deployment
+	if apierrors.IsNotFound(err) {
+		live = nil
+		planned, err = p.resourceGenerator.CreateDoguDeployment(ctx, doguResource, dogu)
+	} else {
+		planned, err = p.resourceGenerator.UpdateDoguDeployment(ctx, live.DeepCopy(), doguResource, dogu)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to generate deployment: %w", err)
+	}
+
+	diff, err := p.addChange(doguPlan, "Deployment", live, planned)
+	if err != nil {
+		return err
+	}
+	// a changed pod template replaces all pods of the dogu
+	doguPlan.Restart = live != nil && diff.Changed("spec", "template")
+
+	return nil
+}
+
+func (p *doguPlanner) planService(ctx context.Context, doguPlan *Plan, doguResource *v2.Dogu, dogu *core.Dogu) (*corev1.Service, error) {
+	imageConfig, err := p.imageRegistry.PullImageConfig(ctx, dogu.Image+":"+dogu.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	planned, err := p.resourceGenerator.CreateDoguService(doguResource, dogu, imageConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate service: %w", err)
+	}
+
+	live := &corev1.Service{}
+	err = p.client.Get(ctx, doguResource.GetObjectKey(), live)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, fmt.Errorf("failed to get service: %w", err)
+	}
+	if apierrors.IsNotFound(err) {
+		live = nil
+	}
+
+	_, err = p.addChange(doguPlan, "Service", live, planned)
+	if err != nil {
+		return nil, err
+	}
+
+	return planned, nil
+}
+
+func (p *doguPlanner) planPVC(ctx context.Context, doguPlan *Plan, doguResource *v2.Dogu, dogu *core.Dogu) error {
+	if !resource.NeedsPVCs(dogu) {
+		return nil
+	}
+
+	planned, err := p.resourceGenerator.CreateDoguPVC(doguResource)
+	if err != nil {
+		return fmt.Errorf("failed to generate pvc: %w", err)
+	}
+
+	live := &corev1.PersistentVolumeClaim{}
+	err = p.client.Get(ctx, doguResource.GetObjectKey(), live)
+	if client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to get pvc: %w", err)
+	}
+	if apierrors.IsNotFound(err) {
+		live = nil
+	} else {
+		currentSize := live.Spec.Resources.Requests.Storage()
+		plannedSize := planned.Spec.Resources.Requests.Storage()
+		if currentSize.Cmp(*plannedSize) < 0 {
+			doguPlan.VolumeExpansion = true
+			doguPlan.CurrentVolumeSize = currentSize.String()
+			doguPlan.PlannedVolumeSize = plannedSize.String()
+		}
+	}
+
+	_, err = p.addChange(doguPlan, "PersistentVolumeClaim", live, planned)
+	return err
+}
+
+func (p *doguPlanner) planNetworkPolicies(ctx context.Context, doguPlan *Plan, doguResource *v2.Dogu, dogu *core.Dogu, service *corev1.Service) error {
+	liveNetPols := &netv1.NetworkPolicyList{}
+	err := p.client.List(ctx, liveNetPols, client.InNamespace(doguResource.Namespace), client.MatchingLabels{v2.DoguLabelName: dogu.GetSimpleName()})
+	if err != nil {
+		return fmt.Errorf("failed to list network policies: %w", err)
+	}
+
+	var plannedNetPols []*netv1.NetworkPolicy
+	if p.networkPoliciesEnabled {
+		plannedNetPols, err = resource.GenerateDoguNetworkPolicies(doguResource, dogu, service, p.scheme)
+		if err != nil {
+			return err
+		}
+	}
+
+	planned := map[string]bool{}
+	for _, plannedNetPol := range plannedNetPols {
+		planned[plannedNetPol.Name] = true
+		var live *netv1.NetworkPolicy
+		for i := range liveNetPols.Items {
+			if liveNetPols.Items[i].Name == plannedNetPol.Name {
+				live = &liveNetPols.Items[i]
+			}
+		}
+
+		_, err = p.addChange(doguPlan, "NetworkPolicy", live, plannedNetPol)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, live := range liveNetPols.Items {
+		// if network policies are enabled, only policies of removed dependencies are deleted
+		if planned[live.Name] || (p.networkPoliciesEnabled && !resource.IsDependencyNetworkPolicy(&live)) {
+			continue
+		}
+		doguPlan.Changes = append(doguPlan.Changes, ResourceChange{Kind: "NetworkPolicy", Name: live.Name, Action: ActionDelete})
+	}
+
+	return nil
+}
+
+func (p *doguPlanner) addChange(doguPlan *Plan, kind string, live, planned client.Object) (*objectdiff.Diff, error) {
+	diff, err := objectdiff.New(live, planned)
+	if err != nil {
+		return nil, err
+	}
+
+	change := ResourceChange{Kind: kind, Name: planned.GetName(), Action: ActionUnchanged}
+	if diff.Changed() {
+		change.Action = ActionUpdate
+		if !diff.LiveExists() {
+			change.Action = ActionCreate
+		}
+		change.Diff, err = diff.Unified()
+		if err != nil {
+			return nil, err
+		}
+	}
+	doguPlan.Changes = append(doguPlan.Changes, change)
+
+	return diff, nil
+}
+
+// discardingRecorder drops all events, so that planning a dogu does not show up in its events.
+type discardingRecorder struct{}
+
+func (discardingRecorder) Event(runtime.Object, string, string, string) {}
+
+func (discardingRecorder) Eventf(runtime.Object, string, string, string, ...interface{}) {}
+
+func (discardingRecorder) AnnotatedEventf(runtime.Object, map[string]string, string, string, string, ...interface{}) {
+}
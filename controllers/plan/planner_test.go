@@ -0,0 +1,300 @@
+package plan
+
+import (
+	"context"
+	"testing"
+
+	cloudoguerrors "github.com/cloudogu/ces-commons-lib/errors"
+	"github.com/cloudogu/cesapp-lib/core"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/dogustore"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	imagev1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testNamespace = "ecosystem"
+
+func getTestScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v2.AddToScheme(scheme))
+	return scheme
+}
+
+func getTestDoguResource() *v2.Dogu {
+	return &v2.Dogu{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: testNamespace, UID: "uid", Generation: 3},
+		Spec:       v2.DoguSpec{Name: "official/ldap", Version: "2.6.8-1"},
+	}
+}
+
+func getTestDogu() *core.Dogu {
+	return &core.Dogu{
+		Name:         "official/ldap",
+		Version:      "2.6.8-1",
+		Image:        "registry.cloudogu.com/official/ldap",
+		Volumes:      []core.Volume{{Name: "data", NeedsBackup: true}},
+		Dependencies: []core.Dependency{{Type: "dogu", Name: "postfix"}},
+	}
+}
+
+func getTestDeployment(image string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: testNamespace},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ldap", Image: image}}}},
+		},
+	}
+}
+
+func getTestService(port int32) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: "ecosystem", Labels: map[string]string{"dogu.name": "ldap"}},
+		Spec: corev1.ServiceSpec{
+			Type:  corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{{Name: "ldap", Port: port, TargetPort: intstr.FromInt32(port)}},
+		},
+	}
+}
+
+func getTestPVC(size string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: testNamespace},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+			},
+		},
+	}
+}
+
+func TestNewDoguPlanner(t *testing.T) {
+	got := NewDoguPlanner(nil, nil, nil, newMockLocalDoguFetcher(t), newMockResourceDoguFetcher(t), nil, nil, nil, nil, nil, nil, nil, nil, &config.OperatorConfig{NetworkPoliciesEnabled: true})
+
+	require.NotNil(t, got)
+	assert.True(t, got.(*doguPlanner).networkPoliciesEnabled)
+	assert.IsType(t, &planDoguFetcher{}, got.(*doguPlanner).doguFetcher)
+	assert.NotNil(t, got.(*doguPlanner).validationStep)
+}
+
+func Test_doguPlanner_Plan(t *testing.T) {
+	imageConfig := &imagev1.ConfigFile{}
+	notFoundErr := errors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "ldap-plan")
+
+	t.Run("should plan upgrade of installed dogu", func(t *testing.T) {
+		// given
+		doguResource := getTestDoguResource()
+		dogu := getTestDogu()
+		removedDependencyNetPol := &netv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{
+			Name:      "ldap-dependency-dogu-cas",
+			Namespace: testNamespace,
+			Labels:    map[string]string{v2.DoguLabelName: "ldap", "k8s.cloudogu.com/dependency": "cas"},
+		}}
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(getTestScheme(t)).
+			WithObjects(getTestDeployment("ldap:2.6.7-1"), getTestService(389), getTestPVC("2Gi"), removedDependencyNetPol).
+			Build()
+
+		validationMock := newMockStep(t)
+		validationMock.EXPECT().Run(testCtx, doguResource).Return(steps.Continue())
+		fetcherMock := newMockLocalDoguFetcher(t)
+		fetcherMock.EXPECT().FetchForResource(testCtx, doguResource).Return(dogu, nil)
+		fetcherMock.EXPECT().FetchInstalled(testCtx, doguResource.GetSimpleDoguName()).Return(&core.Dogu{Version: "2.6.7-1"}, nil)
+		checkerMock := newMockUpgradeChecker(t)
+		checkerMock.EXPECT().IsUpgrade(testCtx, doguResource).Return(true, nil)
+		registryMock := newMockImageRegistry(t)
+		registryMock.EXPECT().PullImageConfig(testCtx, "registry.cloudogu.com/official/ldap:2.6.8-1").Return(imageConfig, nil)
+		generatorMock := newMockDoguResourceGenerator(t)
+		generatorMock.EXPECT().UpdateDoguDeployment(testCtx, mock.Anything, doguResource, dogu).
+			RunAndReturn(func(ctx context.Context, deployment *appsv1.Deployment, doguResource *v2.Dogu, dogu *core.Dogu) (*appsv1.Deployment, error) {
+				deployment.Spec.Template.Spec.Containers[0].Image = "ldap:2.6.8-1"
+				return deployment, nil
+			})
+		generatorMock.EXPECT().CreateDoguService(doguResource, dogu, imageConfig).Return(getTestService(389), nil)
+		generatorMock.EXPECT().CreateDoguPVC(doguResource).Return(getTestPVC("5Gi"), nil)
+
+		var writtenPlan string
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-plan", metav1.GetOptions{}).Return(nil, notFoundErr)
+		cmMock.EXPECT().Create(testCtx, mock.Anything, metav1.CreateOptions{}).
+			RunAndReturn(func(ctx context.Context, cm *corev1.ConfigMap, opts metav1.CreateOptions) (*corev1.ConfigMap, error) {
+				assert.Equal(t, "ldap-plan", cm.Name)
+				assert.Equal(t, testNamespace, cm.Namespace)
+				assert.Equal(t, "true", cm.Labels[PlanLabel])
+				require.Len(t, cm.OwnerReferences, 1)
+				assert.Nil(t, cm.OwnerReferences[0].Controller)
+				assert.Equal(t, "3", cm.Data[PlannedGenerationKey])
+				writtenPlan = cm.Data[PlanKey]
+				return cm, nil
+			})
+
+		sut := &doguPlanner{
+			client:                 k8sClient,
+			store:                  dogustore.NewConfigMapStore(cmMock, getTestScheme(t), configMapNameSuffix, PlanLabel),
+			scheme:                 getTestScheme(t),
+			doguFetcher:            fetcherMock,
+			resourceGenerator:      generatorMock,
+			upgradeChecker:         checkerMock,
+			imageRegistry:          registryMock,
+			validationStep:         validationMock,
+			networkPoliciesEnabled: true,
+		}
+
+		// when
+		err := sut.Plan(testCtx, doguResource)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, writtenPlan, `Plan for dogu "ldap" (2.6.7-1 -> 2.6.8-1)`)
+		assert.Contains(t, writtenPlan, "Validation:       passed\n")
+		assert.Contains(t, writtenPlan, "Upgrade:          yes\n")
+		assert.Contains(t, writtenPlan, "Restart:          yes\n")
+		assert.Contains(t, writtenPlan, "Volume expansion: yes (2Gi -> 5Gi)\n")
+		assert.Contains(t, writtenPlan, "Deployment \"ldap\": update\n")
+		assert.Contains(t, writtenPlan, "-      - image: ldap:2.6.7-1\n+      - image: ldap:2.6.8-1\n")
+		assert.Contains(t, writtenPlan, "Service \"ldap\": unchanged\n")
+		assert.Contains(t, writtenPlan, "PersistentVolumeClaim \"ldap\": update\n")
+		assert.Contains(t, writtenPlan, "NetworkPolicy \"ldap-deny-all\": create\n")
+		assert.Contains(t, writtenPlan, "NetworkPolicy \"ldap-dependency-dogu-postfix\": create\n")
+		assert.Contains(t, writtenPlan, "NetworkPolicy \"ldap-dependency-dogu-cas\": delete\n")
+	})
+	t.Run("should plan installation of new dogu", func(t *testing.T) {
+		// given
+		doguResource := getTestDoguResource()
+		dogu := getTestDogu()
+		dogu.Volumes = nil
+		k8sClient := fake.NewClientBuilder().WithScheme(getTestScheme(t)).Build()
+
+		validationMock := newMockStep(t)
+		validationMock.EXPECT().Run(testCtx, doguResource).Return(steps.RequeueWithError(assert.AnError))
+		fetcherMock := newMockLocalDoguFetcher(t)
+		fetcherMock.EXPECT().FetchForResource(testCtx, doguResource).Return(dogu, nil)
+		fetcherMock.EXPECT().FetchInstalled(testCtx, doguResource.GetSimpleDoguName()).Return(nil, cloudoguerrors.NewNotFoundError(assert.AnError))
+		registryMock := newMockImageRegistry(t)
+		registryMock.EXPECT().PullImageConfig(testCtx, "registry.cloudogu.com/official/ldap:2.6.8-1").Return(imageConfig, nil)
+		generatorMock := newMockDoguResourceGenerator(t)
+		generatorMock.EXPECT().CreateDoguDeployment(testCtx, doguResource, dogu).Return(getTestDeployment("ldap:2.6.8-1"), nil)
+		generatorMock.EXPECT().CreateDoguService(doguResource, dogu, imageConfig).Return(getTestService(389), nil)
+
+		var writtenPlan string
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-plan", metav1.GetOptions{}).Return(&corev1.ConfigMap{}, nil)
+		cmMock.EXPECT().Update(testCtx, mock.Anything, metav1.UpdateOptions{}).
+			RunAndReturn(func(ctx context.Context, cm *corev1.ConfigMap, opts metav1.UpdateOptions) (*corev1.ConfigMap, error) {
+				writtenPlan = cm.Data[PlanKey]
+				return cm, nil
+			})
+
+		sut := &doguPlanner{
+			client:            k8sClient,
+			store:             dogustore.NewConfigMapStore(cmMock, getTestScheme(t), configMapNameSuffix, PlanLabel),
+			scheme:            getTestScheme(t),
+			doguFetcher:       fetcherMock,
+			resourceGenerator: generatorMock,
+			upgradeChecker:    newMockUpgradeChecker(t),
+			imageRegistry:     registryMock,
+			validationStep:    validationMock,
+		}
+
+		// when
+		err := sut.Plan(testCtx, doguResource)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, writtenPlan, `Plan for dogu "ldap" (not installed -> 2.6.8-1)`)
+		assert.Contains(t, writtenPlan, "Validation:       failed: "+assert.AnError.Error())
+		assert.Contains(t, writtenPlan, "Upgrade:          no\n")
+		assert.Contains(t, writtenPlan, "Restart:          no\n")
+		assert.Contains(t, writtenPlan, "Deployment \"ldap\": create\n")
+		assert.Contains(t, writtenPlan, "Service \"ldap\": create\n")
+		assert.NotContains(t, writtenPlan, "PersistentVolumeClaim")
+		assert.NotContains(t, writtenPlan, "NetworkPolicy")
+	})
+	t.Run("should fail to fetch dogu descriptor", func(t *testing.T) {
+		// given
+		doguResource := getTestDoguResource()
+		validationMock := newMockStep(t)
+		validationMock.EXPECT().Run(testCtx, doguResource).Return(steps.Continue())
+		fetcherMock := newMockLocalDoguFetcher(t)
+		fetcherMock.EXPECT().FetchForResource(testCtx, doguResource).Return(nil, assert.AnError)
+
+		sut := &doguPlanner{doguFetcher: fetcherMock, validationStep: validationMock}
+
+		// when
+		err := sut.Plan(testCtx, doguResource)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to create plan for dogu \"ldap\": failed to fetch dogu descriptor")
+	})
+	t.Run("should fail to get deployment", func(t *testing.T) {
+		// given
+		doguResource := getTestDoguResource()
+		dogu := getTestDogu()
+		validationMock := newMockStep(t)
+		validationMock.EXPECT().Run(testCtx, doguResource).Return(steps.Continue())
+		fetcherMock := newMockLocalDoguFetcher(t)
+		fetcherMock.EXPECT().FetchForResource(testCtx, doguResource).Return(dogu, nil)
+		fetcherMock.EXPECT().FetchInstalled(testCtx, doguResource.GetSimpleDoguName()).Return(nil, cloudoguerrors.NewNotFoundError(assert.AnError))
+		clientMock := newMockK8sClient(t)
+		clientMock.EXPECT().Get(testCtx, doguResource.GetObjectKey(), mock.AnythingOfType("*v1.Deployment")).Return(assert.AnError)
+
+		sut := &doguPlanner{client: clientMock, doguFetcher: fetcherMock, validationStep: validationMock}
+
+		// when
+		err := sut.Plan(testCtx, doguResource)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get deployment")
+	})
+	t.Run("should fail to write plan", func(t *testing.T) {
+		// given
+		doguResource := getTestDoguResource()
+		dogu := getTestDogu()
+		dogu.Volumes = nil
+		validationMock := newMockStep(t)
+		validationMock.EXPECT().Run(testCtx, doguResource).Return(steps.Continue())
+		fetcherMock := newMockLocalDoguFetcher(t)
+		fetcherMock.EXPECT().FetchForResource(testCtx, doguResource).Return(dogu, nil)
+		fetcherMock.EXPECT().FetchInstalled(testCtx, doguResource.GetSimpleDoguName()).Return(nil, cloudoguerrors.NewNotFoundError(assert.AnError))
+		registryMock := newMockImageRegistry(t)
+		registryMock.EXPECT().PullImageConfig(testCtx, mock.Anything).Return(imageConfig, nil)
+		generatorMock := newMockDoguResourceGenerator(t)
+		generatorMock.EXPECT().CreateDoguDeployment(testCtx, doguResource, dogu).Return(getTestDeployment("ldap:2.6.8-1"), nil)
+		generatorMock.EXPECT().CreateDoguService(doguResource, dogu, imageConfig).Return(getTestService(389), nil)
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-plan", metav1.GetOptions{}).Return(nil, assert.AnError)
+
+		sut := &doguPlanner{
+			client:            fake.NewClientBuilder().WithScheme(getTestScheme(t)).Build(),
+			store:             dogustore.NewConfigMapStore(cmMock, getTestScheme(t), configMapNameSuffix, PlanLabel),
+			doguFetcher:       fetcherMock,
+			resourceGenerator: generatorMock,
+			imageRegistry:     registryMock,
+			validationStep:    validationMock,
+		}
+
+		// when
+		err := sut.Plan(testCtx, doguResource)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to write plan of dogu \"ldap\"")
+	})
+}
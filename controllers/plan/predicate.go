@@ -0,0 +1,23 @@
+package plan
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// DryRunAnnotationChangedPredicate lets update events pass if the plan mode of the dogu resource was switched on or off.
+// Changed annotations do not increase the generation, so without this predicate the dogu resource would not be
+// applied after the plan mode was switched off.
+func DryRunAnnotationChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return false
+			}
+			return IsDryRun(e.ObjectOld) != IsDryRun(e.ObjectNew)
+		},
+	}
+}
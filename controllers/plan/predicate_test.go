@@ -0,0 +1,31 @@
+package plan
+
+import (
+	"testing"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestDryRunAnnotationChangedPredicate(t *testing.T) {
+	planned := &v2.Dogu{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{DryRunAnnotation: "true"}}}
+	applied := &v2.Dogu{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"other": "value"}}}
+
+	sut := DryRunAnnotationChangedPredicate()
+
+	assert.True(t, sut.Update(event.UpdateEvent{ObjectOld: planned, ObjectNew: applied}))
+	assert.True(t, sut.Update(event.UpdateEvent{ObjectOld: applied, ObjectNew: planned}))
+	assert.False(t, sut.Update(event.UpdateEvent{ObjectOld: planned, ObjectNew: planned}))
+	assert.False(t, sut.Update(event.UpdateEvent{ObjectOld: applied, ObjectNew: applied}))
+	assert.False(t, sut.Create(event.CreateEvent{Object: planned}))
+	assert.False(t, sut.Delete(event.DeleteEvent{Object: planned}))
+	assert.False(t, sut.Generic(event.GenericEvent{Object: planned}))
+}
+
+func TestIsDryRun(t *testing.T) {
+	assert.True(t, IsDryRun(&v2.Dogu{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{DryRunAnnotation: "true"}}}))
+	assert.False(t, IsDryRun(&v2.Dogu{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{DryRunAnnotation: "false"}}}))
+	assert.False(t, IsDryRun(&v2.Dogu{}))
+}
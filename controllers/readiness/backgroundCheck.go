@@ -0,0 +1,84 @@
+package readiness
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// checkInterval is the time after which the result of a check is refreshed.
+	checkInterval = 30 * time.Second
+	// checkTimeout limits the time a single check may take.
+	checkTimeout = 20 * time.Second
+	// cacheSyncTimeout has to be shorter than the timeout of the readiness probe.
+	cacheSyncTimeout = 500 * time.Millisecond
+)
+
+var errNotCheckedYet = errors.New("not checked yet")
+
+var log = ctrl.Log.WithName("readiness")
+
+// backgroundCheck runs a check of an external dependency outside the readiness probe and caches its result.
+// Requests to the dogu or container registry may take longer than the timeout of the probe and should not be sent
+// every few seconds, so the probe only returns the cached result and triggers a refresh once it is outdated.
+type backgroundCheck struct {
+	name     string
+	check    func(ctx context.Context) error
+	interval time.Duration
+	now      func() time.Time
+
+	mutex     sync.Mutex
+	result    error
+	checkedAt time.Time
+	running   bool
+}
+
+func newBackgroundCheck(name string, check func(ctx context.Context) error) *backgroundCheck {
+	return &backgroundCheck{
+		name:     name,
+		check:    check,
+		interval: checkInterval,
+		now:      time.Now,
+		result:   errNotCheckedYet,
+	}
+}
+
+// Check returns the latest result and starts a refresh in the background if the result is outdated.
+func (c *backgroundCheck) Check(_ *http.Request) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.running && (c.checkedAt.IsZero() || c.now().Sub(c.checkedAt) >= c.interval) {
+		c.running = true
+		go c.refresh()
+	}
+
+	return c.result
+}
+
+func (c *backgroundCheck) refresh() {
+	ctx, cancel := context.WithTimeout(context.Background(), checkTimeout)
+	defer cancel()
+
+	err := c.check(ctx)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// the readiness endpoint withholds the reason of failed checks, so it is logged whenever it changes
+	if err != nil && (c.result == nil || c.result.Error() != err.Error()) {
+		log.Error(err, "readiness check failed", "check", c.name)
+	}
+	if err == nil && c.result != nil && !errors.Is(c.result, errNotCheckedYet) {
+		log.Info("readiness check succeeded again", "check", c.name)
+	}
+
+	c.result = err
+	c.checkedAt = c.now()
+	c.running = false
+}
@@ -0,0 +1,74 @@
+package readiness
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	cescommons "github.com/cloudogu/ces-commons-lib/dogu"
+	cloudoguerrors "github.com/cloudogu/ces-commons-lib/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// probeDoguName is requested from the dogu registry to check whether it is reachable. The dogu does not exist, so its
+// descriptor is never served from the cache of the remote dogu descriptor repository.
+var probeDoguName = cescommons.QualifiedName{Namespace: "k8s", SimpleName: "k8s-dogu-operator-readiness-probe"}
+
+// NewCacheSyncCheck creates a check which fails until the informer caches of the manager are synced.
+func NewCacheSyncCheck(informers cacheSyncer) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), cacheSyncTimeout)
+		defer cancel()
+
+		if !informers.WaitForCacheSync(ctx) {
+			return errors.New("informer caches are not synced")
+		}
+
+		return nil
+	}
+}
+
+// NewDoguRegistryCheck creates a check which fails if the remote dogu registry is not reachable or rejects the
+// credentials of the operator.
+func NewDoguRegistryCheck(repository remoteDoguDescriptorRepository) healthz.Checker {
+	return newBackgroundCheck("dogu-registry", func(ctx context.Context) error {
+		_, err := repository.GetLatest(ctx, probeDoguName)
+		// the registry answered, it just does not know the probe dogu
+		if err != nil && !cloudoguerrors.IsNotFoundError(err) {
+			return fmt.Errorf("dogu registry is not reachable: %w", err)
+		}
+
+		return nil
+	}).Check
+}
+
+// NewContainerRegistryCheck creates a check which fails if one of the configured container registries is not reachable.
+func NewContainerRegistryCheck(pinger registryPinger) healthz.Checker {
+	return newBackgroundCheck("container-registry", func(ctx context.Context) error {
+		registries, err := pinger.Registries()
+		if err != nil {
+			return err
+		}
+
+		var errs []error
+		for _, registry := range registries {
+			errs = append(errs, pinger.Ping(ctx, registry))
+		}
+
+		return errors.Join(errs...)
+	}).Check
+}
+
+// NewConfigMapCheck creates a check which fails if the ConfigMap does not exist.
+func NewConfigMapCheck(configMapInterface configMapInterface, name string) healthz.Checker {
+	return newBackgroundCheck("configmap-"+name, func(ctx context.Context) error {
+		_, err := configMapInterface.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get configmap %s: %w", name, err)
+		}
+
+		return nil
+	}).Check
+}
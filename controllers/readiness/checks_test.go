@@ -0,0 +1,201 @@
+package readiness
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	cloudoguerrors "github.com/cloudogu/ces-commons-lib/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+var testRequest = &http.Request{}
+
+// eventualResult waits until the background check finished and returns its result.
+func eventualResult(t *testing.T, checker healthz.Checker) error {
+	t.Helper()
+
+	var result error
+	require.Eventually(t, func() bool {
+		result = checker(testRequest)
+		return result != errNotCheckedYet
+	}, time.Second, 10*time.Millisecond)
+
+	return result
+}
+
+func TestNewCacheSyncCheck(t *testing.T) {
+	t.Run("should succeed if caches are synced", func(t *testing.T) {
+		// given
+		syncerMock := newMockCacheSyncer(t)
+		syncerMock.EXPECT().WaitForCacheSync(mock.Anything).Return(true)
+
+		// when
+		err := NewCacheSyncCheck(syncerMock)(testRequest)
+
+		// then
+		assert.NoError(t, err)
+	})
+	t.Run("should fail if caches are not synced", func(t *testing.T) {
+		// given
+		syncerMock := newMockCacheSyncer(t)
+		syncerMock.EXPECT().WaitForCacheSync(mock.Anything).Return(false)
+
+		// when
+		err := NewCacheSyncCheck(syncerMock)(testRequest)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "informer caches are not synced")
+	})
+}
+
+func TestNewDoguRegistryCheck(t *testing.T) {
+	t.Run("should succeed if registry does not know the probe dogu", func(t *testing.T) {
+		// given
+		repoMock := newMockRemoteDoguDescriptorRepository(t)
+		repoMock.EXPECT().GetLatest(mock.Anything, probeDoguName).Return(nil, cloudoguerrors.NewNotFoundError(assert.AnError))
+
+		// when
+		err := eventualResult(t, NewDoguRegistryCheck(repoMock))
+
+		// then
+		assert.NoError(t, err)
+	})
+	t.Run("should fail if registry is not reachable", func(t *testing.T) {
+		// given
+		repoMock := newMockRemoteDoguDescriptorRepository(t)
+		repoMock.EXPECT().GetLatest(mock.Anything, probeDoguName).Return(nil, cloudoguerrors.NewConnectionError(assert.AnError))
+
+		// when
+		err := eventualResult(t, NewDoguRegistryCheck(repoMock))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "dogu registry is not reachable")
+	})
+}
+
+func TestNewContainerRegistryCheck(t *testing.T) {
+	t.Run("should succeed if all registries are reachable", func(t *testing.T) {
+		// given
+		pingerMock := newMockRegistryPinger(t)
+		pingerMock.EXPECT().Registries().Return([]string{"docker.io", "registry.cloudogu.com"}, nil)
+		pingerMock.EXPECT().Ping(mock.Anything, "docker.io").Return(nil)
+		pingerMock.EXPECT().Ping(mock.Anything, "registry.cloudogu.com").Return(nil)
+
+		// when
+		err := eventualResult(t, NewContainerRegistryCheck(pingerMock))
+
+		// then
+		assert.NoError(t, err)
+	})
+	t.Run("should fail if a registry is not reachable", func(t *testing.T) {
+		// given
+		pingerMock := newMockRegistryPinger(t)
+		pingerMock.EXPECT().Registries().Return([]string{"docker.io", "registry.cloudogu.com"}, nil)
+		pingerMock.EXPECT().Ping(mock.Anything, "docker.io").Return(nil)
+		pingerMock.EXPECT().Ping(mock.Anything, "registry.cloudogu.com").Return(assert.AnError)
+
+		// when
+		err := eventualResult(t, NewContainerRegistryCheck(pingerMock))
+
+		// then
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("should fail if registries cannot be read", func(t *testing.T) {
+		// given
+		pingerMock := newMockRegistryPinger(t)
+		pingerMock.EXPECT().Registries().Return(nil, assert.AnError)
+
+		// when
+		err := eventualResult(t, NewContainerRegistryCheck(pingerMock))
+
+		// then
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestNewConfigMapCheck(t *testing.T) {
+	t.Run("should succeed if configmap exists", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(mock.Anything, "global-config", metav1.GetOptions{}).Return(&corev1.ConfigMap{}, nil)
+
+		// when
+		err := eventualResult(t, NewConfigMapCheck(cmMock, "global-config"))
+
+		// then
+		assert.NoError(t, err)
+	})
+	t.Run("should fail if configmap does not exist", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(mock.Anything, "global-config", metav1.GetOptions{}).Return(nil, assert.AnError)
+
+		// when
+		err := eventualResult(t, NewConfigMapCheck(cmMock, "global-config"))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get configmap global-config")
+	})
+}
+
+func TestBackgroundCheck_Check(t *testing.T) {
+	t.Run("should not be ready before the first check finished", func(t *testing.T) {
+		// given
+		started := make(chan struct{})
+		finish := make(chan struct{})
+		sut := newBackgroundCheck("test", func(ctx context.Context) error {
+			close(started)
+			<-finish
+			return nil
+		})
+
+		// when
+		err := sut.Check(testRequest)
+		<-started
+		errWhileRunning := sut.Check(testRequest)
+		close(finish)
+
+		// then
+		assert.ErrorIs(t, err, errNotCheckedYet)
+		assert.ErrorIs(t, errWhileRunning, errNotCheckedYet)
+		assert.NoError(t, eventualResult(t, sut.Check))
+	})
+	t.Run("should return cached result until interval elapsed", func(t *testing.T) {
+		// given
+		now := time.Now()
+		calls := 0
+		results := []error{nil, assert.AnError}
+		sut := newBackgroundCheck("test", func(ctx context.Context) error {
+			result := results[calls]
+			calls++
+			return result
+		})
+		sut.now = func() time.Time { return now }
+		require.NoError(t, eventualResult(t, sut.Check))
+
+		// when
+		cachedErr := sut.Check(testRequest)
+		now = now.Add(checkInterval)
+		sut.Check(testRequest)
+
+		// then
+		assert.NoError(t, cachedErr)
+		require.Eventually(t, func() bool {
+			return sut.Check(testRequest) != nil
+		}, time.Second, 10*time.Millisecond)
+		assert.ErrorIs(t, sut.Check(testRequest), assert.AnError)
+		assert.Equal(t, 2, calls)
+	})
+}
@@ -0,0 +1,26 @@
+package readiness
+
+import (
+	"context"
+
+	cescommons "github.com/cloudogu/ces-commons-lib/dogu"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/imageregistry"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+type cacheSyncer interface {
+	// WaitForCacheSync waits for all the caches to sync. Returns false if it could not sync a cache.
+	WaitForCacheSync(ctx context.Context) bool
+}
+
+type remoteDoguDescriptorRepository interface {
+	cescommons.RemoteDoguDescriptorRepository
+}
+
+type registryPinger interface {
+	imageregistry.RegistryPinger
+}
+
+type configMapInterface interface {
+	v1.ConfigMapInterface
+}
@@ -0,0 +1,62 @@
+package resource
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// DesiredStateHashAnnotation contains the hash of the desired state which the dogu operator applied last to a resource.
+// It tells changes of the desired state apart from changes which were made to the resource in the cluster.
+const DesiredStateHashAnnotation = "k8s.cloudogu.com/desired-state-hash"
+
+// DesiredStateHash returns the hash which the dogu operator writes into the DesiredStateHashAnnotation when it applies
+// the given object.
+func DesiredStateHash(object client.Object, scheme *runtime.Scheme) (string, error) {
+	gvk, err := apiutil.GVKForObject(object, scheme)
+	if err != nil {
+		return "", fmt.Errorf("failed to get kind of %s: %w", object.GetName(), err)
+	}
+
+	applyObject, err := toApplyObject(object, gvk)
+	if err != nil {
+		return "", fmt.Errorf("failed to create apply configuration for %s %s: %w", gvk.Kind, object.GetName(), err)
+	}
+
+	err = setDesiredStateHash(applyObject)
+	if err != nil {
+		return "", err
+	}
+
+	return applyObject.GetAnnotations()[DesiredStateHashAnnotation], nil
+}
+
+// setDesiredStateHash writes the hash of the apply object into its DesiredStateHashAnnotation.
+// A hash which is already contained in the object is not part of the new hash.
+func setDesiredStateHash(applyObject *unstructured.Unstructured) error {
+	unstructured.RemoveNestedField(applyObject.Object, "metadata", "annotations", DesiredStateHashAnnotation)
+	if len(applyObject.GetAnnotations()) == 0 {
+		unstructured.RemoveNestedField(applyObject.Object, "metadata", "annotations")
+	}
+
+	content, err := json.Marshal(applyObject.Object)
+	if err != nil {
+		return fmt.Errorf("failed to hash desired state: %w", err)
+	}
+	hash := sha256.Sum256(content)
+
+	annotations := applyObject.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[DesiredStateHashAnnotation] = hex.EncodeToString(hash[:])
+	applyObject.SetAnnotations(annotations)
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDesiredStateHash(t *testing.T) {
+	t.Run("should fail for unknown kind", func(t *testing.T) {
+		// when
+		_, err := DesiredStateHash(&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "ldap"}}, getTestScheme())
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to get kind of ldap")
+	})
+	t.Run("should ignore fields maintained by the API server and the hash itself", func(t *testing.T) {
+		// given
+		desired := readLdapDoguExpectedDeployment(t)
+		live := readLdapDoguExpectedDeployment(t)
+		live.ResourceVersion = "42"
+		live.Status.Replicas = 1
+		live.Annotations = map[string]string{DesiredStateHashAnnotation: "outdated"}
+
+		// when
+		desiredHash, desiredErr := DesiredStateHash(desired, getTestScheme())
+		liveHash, liveErr := DesiredStateHash(live, getTestScheme())
+
+		// then
+		require.NoError(t, desiredErr)
+		require.NoError(t, liveErr)
+		assert.NotEmpty(t, desiredHash)
+		assert.Equal(t, desiredHash, liveHash)
+	})
+	t.Run("should change with the desired state", func(t *testing.T) {
+		// given
+		changed := readLdapDoguExpectedDeployment(t)
+		changed.Spec.Template.Spec.Containers[0].Image = "registry.cloudogu.com/official/ldap:2.0.0"
+
+		// when
+		hash, err := DesiredStateHash(readLdapDoguExpectedDeployment(t), getTestScheme())
+		changedHash, changedErr := DesiredStateHash(changed, getTestScheme())
+
+		// then
+		require.NoError(t, err)
+		require.NoError(t, changedErr)
+		assert.NotEqual(t, hash, changedHash)
+	})
+}
@@ -13,6 +13,7 @@ import (
 	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -101,6 +102,10 @@ type k8sClient interface {
 	client.Client
 }
 
+type eventRecorder interface {
+	record.EventRecorder
+}
+
 //nolint:unused
 //goland:noinspection GoUnusedType
 type doguClientInterface interface {
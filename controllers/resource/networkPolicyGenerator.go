@@ -5,6 +5,8 @@ import (
 
 	"github.com/cloudogu/cesapp-lib/core"
 	k8sv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/annotation"
+	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -136,6 +138,47 @@ func generateIngressNetPol(doguResource *k8sv2.Dogu, dogu *core.Dogu, scheme *ru
 	return generateNetPol(doguResource, dogu, "", netPolTypeIngress, scheme)
 }
 
+// GenerateDoguNetworkPolicies generates all network policies which the upserter applies for the given dogu: the deny
+// all policy, one policy per dogu or component dependency and, if the service exposes ces services, the ingress policy.
+func GenerateDoguNetworkPolicies(doguResource *k8sv2.Dogu, dogu *core.Dogu, service *corev1.Service, scheme *runtime.Scheme) ([]*netv1.NetworkPolicy, error) {
+	denyAllPolicy, err := generateDenyAllPolicy(doguResource, dogu, scheme)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate deny all policy: %w", err)
+	}
+	netPols := []*netv1.NetworkPolicy{denyAllPolicy}
+
+	for _, dependency := range append(dogu.Dependencies, dogu.OptionalDependencies...) {
+		var dependencyNetPol *netv1.NetworkPolicy
+		switch dependency.Type {
+		case dependencyTypeDogu:
+			dependencyNetPol, err = generateDoguDepNetPol(doguResource, dogu, dependency.Name, scheme)
+		case dependencyTypeComponent:
+			dependencyNetPol, err = generateComponentDepNetPol(doguResource, dogu, dependency.Name, scheme)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate netpol for dogu %s and dependency %s: %w", dogu.GetSimpleName(), dependency.Name, err)
+		}
+		netPols = append(netPols, dependencyNetPol)
+	}
+
+	if _, ok := service.Annotations[annotation.CesServicesAnnotation]; ok {
+		ingressNetPol, err := generateIngressNetPol(doguResource, dogu, scheme)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate ingress netpol for dogu %s: %w", dogu.GetSimpleName(), err)
+		}
+		netPols = append(netPols, ingressNetPol)
+	}
+
+	return netPols, nil
+}
+
+// IsDependencyNetworkPolicy returns true if the network policy allows the access to a dependency of a dogu.
+func IsDependencyNetworkPolicy(netPol *netv1.NetworkPolicy) bool {
+	return netPol.Labels[depenendcyLabel] != ""
+}
+
 func generateNetPolWithOwner(name string, parentDoguResource *k8sv2.Dogu, spec netv1.NetworkPolicySpec, scheme *runtime.Scheme) (*netv1.NetworkPolicy, error) {
 	netpol := &netv1.NetworkPolicy{
 		ObjectMeta: metav1.ObjectMeta{
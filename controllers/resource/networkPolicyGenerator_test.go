@@ -5,10 +5,13 @@ import (
 
 	"github.com/cloudogu/cesapp-lib/core"
 	k8sv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/annotation"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func Test_getNetPolObjectKey(t *testing.T) {
@@ -178,3 +181,51 @@ func Test_generateDenyAllPolicy(t *testing.T) {
 		assert.Len(t, result.Spec.Ingress, 0)
 	})
 }
+
+func TestGenerateDoguNetworkPolicies(t *testing.T) {
+	doguResource := &k8sv2.Dogu{
+		ObjectMeta: metav1.ObjectMeta{Name: "redmine", Namespace: "ecosystem", UID: "DoguUid-1"},
+	}
+	dogu := &core.Dogu{
+		Name: "official/redmine",
+		Dependencies: []core.Dependency{
+			{Type: "dogu", Name: "postgresql"},
+			{Type: "client", Name: "cesapp"},
+		},
+		OptionalDependencies: []core.Dependency{{Type: "component", Name: "k8s-ces-gateway"}},
+	}
+
+	t.Run("should generate deny all and dependency policies", func(t *testing.T) {
+		// when
+		result, err := GenerateDoguNetworkPolicies(doguResource, dogu, &v1.Service{}, getTestScheme())
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, result, 3)
+		assert.Equal(t, "redmine-deny-all", result[0].Name)
+		assert.Equal(t, "redmine-dependency-dogu-postgresql", result[1].Name)
+		assert.True(t, IsDependencyNetworkPolicy(result[1]))
+		assert.Equal(t, "redmine-dependency-component-k8s-ces-gateway", result[2].Name)
+		assert.False(t, IsDependencyNetworkPolicy(result[0]))
+	})
+	t.Run("should generate ingress policy for exposed ces services", func(t *testing.T) {
+		// given
+		service := &v1.Service{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{annotation.CesServicesAnnotation: "[]"}}}
+
+		// when
+		result, err := GenerateDoguNetworkPolicies(doguResource, dogu, service, getTestScheme())
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, result, 4)
+		assert.Equal(t, "redmine-ingress", result[3].Name)
+	})
+	t.Run("should fail to generate policies without dogu in scheme", func(t *testing.T) {
+		// when
+		_, err := GenerateDoguNetworkPolicies(doguResource, dogu, &v1.Service{}, runtime.NewScheme())
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to generate deny all policy")
+	})
+}
@@ -0,0 +1,86 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// replicasField is the path of the replicas of a deployment in the conflicts reported by the API server.
+const replicasField = ".spec.replicas"
+
+var conflictingManagerRegex = regexp.MustCompile(`conflict with "([^"]*)"`)
+
+type forcedOwnershipContextKey struct{}
+
+// WithForcedOwnership returns a copy of ctx which makes the upserter take over fields owned by other field managers.
+// It is meant for reverting drift, where the changes of other field managers are expected to be reverted.
+func WithForcedOwnership(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forcedOwnershipContextKey{}, true)
+}
+
+func isOwnershipForced(ctx context.Context) bool {
+	forced, ok := ctx.Value(forcedOwnershipContextKey{}).(bool)
+	return ok && forced
+}
+
+// fieldConflict is a field of an applied object which is owned by another field manager with a different value.
+type fieldConflict struct {
+	manager string
+	field   string
+}
+
+// fieldConflictsOf returns the conflicting fields of a conflict error of a server-side apply.
+func fieldConflictsOf(err error) []fieldConflict {
+	var statusErr apierrors.APIStatus
+	if !errors.As(err, &statusErr) || statusErr.Status().Details == nil {
+		return nil
+	}
+
+	var conflicts []fieldConflict
+	for _, cause := range statusErr.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		conflict := fieldConflict{field: cause.Field}
+		if match := conflictingManagerRegex.FindStringSubmatch(cause.Message); match != nil {
+			conflict.manager = match[1]
+		}
+		conflicts = append(conflicts, conflict)
+	}
+
+	return conflicts
+}
+
+// withOtherManagers returns the conflicts with field managers other than the dogu operator. Fields which were updated
+// by the dogu operator itself, e.g. by older versions of the operator or by scaling the deployment, are no conflicts.
+func withOtherManagers(conflicts []fieldConflict) []fieldConflict {
+	return slices.DeleteFunc(slices.Clone(conflicts), func(conflict fieldConflict) bool {
+		return conflict.manager == fieldManagerName
+	})
+}
+
+func containsField(conflicts []fieldConflict, field string) bool {
+	return slices.ContainsFunc(conflicts, func(conflict fieldConflict) bool {
+		return conflict.field == field
+	})
+}
+
+// conflictMessage lists the conflicting fields with their field managers or returns the message of the conflict error
+// if it does not name the fields.
+func conflictMessage(conflicts []fieldConflict, err error) string {
+	if len(conflicts) == 0 {
+		return err.Error()
+	}
+
+	formatted := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		formatted = append(formatted, conflict.field+" ("+conflict.manager+")")
+	}
+	return strings.Join(formatted, ", ")
+}
@@ -6,7 +6,6 @@ import (
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 type podSpecBuilder struct {
@@ -24,6 +23,7 @@ type podSpecBuilder struct {
 	specContainerCommand             []string
 	specContainerArgs                []string
 	specContainerLivenessProbe       *corev1.Probe
+	specContainerReadinessProbe      *corev1.Probe
 	specContainerStartupProbe        *corev1.Probe
 	specContainerImagePullPolicy     corev1.PullPolicy
 	specContainerVolumeMounts        []corev1.VolumeMount
@@ -101,23 +101,13 @@ func (p *podSpecBuilder) containerEmptyCommandAndArgs() *podSpecBuilder {
 }
 
 func (p *podSpecBuilder) containerLivenessProbe() *podSpecBuilder {
-	for _, healthCheck := range p.theDogu.HealthChecks {
-		if healthCheck.Type == "tcp" {
-			p.specContainerLivenessProbe = &corev1.Probe{
-				ProbeHandler: corev1.ProbeHandler{
-					TCPSocket: &corev1.TCPSocketAction{Port: intstr.IntOrString{IntVal: int32(healthCheck.Port)}},
-				},
-				TimeoutSeconds:   1,
-				PeriodSeconds:    10,
-				SuccessThreshold: 1,
-				// Setting this value to low makes some dogus unable to start that require a certain amount of time.
-				// The default value is set to 30 min.
-				FailureThreshold: 6 * 30,
-			}
+	p.specContainerLivenessProbe = createLivenessProbe(p.theDogu)
 
-			return p
-		}
-	}
+	return p
+}
+
+func (p *podSpecBuilder) containerReadinessProbe() *podSpecBuilder {
+	p.specContainerReadinessProbe = createReadinessProbe(p.theDogu)
 
 	return p
 }
@@ -210,6 +200,7 @@ func (p *podSpecBuilder) buildDoguContainer() corev1.Container {
 		Command:         p.specContainerCommand,
 		Args:            p.specContainerArgs,
 		LivenessProbe:   p.specContainerLivenessProbe,
+		ReadinessProbe:  p.specContainerReadinessProbe,
 		StartupProbe:    p.specContainerStartupProbe,
 		ImagePullPolicy: p.specContainerImagePullPolicy,
 		VolumeMounts:    p.specContainerVolumeMounts,
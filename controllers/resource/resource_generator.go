@@ -12,6 +12,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -27,15 +28,16 @@ import (
 const ReplicaCountStarted = 1
 const ReplicaCountStopped = 0
 
+// DefaultProgressDeadlineSeconds is the progress deadline of a dogu deployment outside of upgrades. An upgrade extends
+// it to the rollout deadline of the dogu until the post-upgrade step reverts it.
+const DefaultProgressDeadlineSeconds int32 = 600
+
 const (
 	appLabelKey      = "app"
 	appLabelValueCes = "ces"
 )
 
-const (
-	doguHealthConfigMap = "k8s-dogu-operator-dogu-health"
-	doguHealth          = "dogu-health"
-)
+const doguHealth = "dogu-health"
 
 const (
 	operatorManagerConfigMap = "k8s-dogu-operator-manager-config"
@@ -57,6 +59,14 @@ const (
 // kubernetesServiceAccountKind describes a service account on kubernetes.
 const kubernetesServiceAccountKind = "k8s"
 
+const (
+	healthCheckTypeTCP      = "tcp"
+	healthCheckTypeHTTP     = "http"
+	healthCheckTypeState    = "state"
+	defaultHealthCheckPath  = "/health"
+	defaultHealthCheckState = "ready"
+)
+
 const (
 	startupProbeTimoutEnv      = "DOGU_STARTUP_PROBE_TIMEOUT"
 	defaultStartupProbeTimeout = 1
@@ -180,6 +190,7 @@ func (r *resourceGenerator) GetPodTemplate(ctx context.Context, doguResource *k8
 		sidecarContainers(r.generateSidecarContainers(doguResource, dogu)...).
 		containerEmptyCommandAndArgs().
 		containerLivenessProbe().
+		containerReadinessProbe().
 		containerStartupProbe().
 		containerPullPolicy().
 		containerVolumeMounts(createVolumeMounts(doguResource, dogu)).
@@ -455,26 +466,18 @@ func updateDeploymentSpec(deployment *appsv1.Deployment, doguResource *k8sv2.Dog
 	}
 	deployment.Spec.Replicas = &replicas
 	deployment.Spec.Template = *podTemplate
-	deployment.Spec.ProgressDeadlineSeconds = ptr.To(int32(600))
+	deployment.Spec.ProgressDeadlineSeconds = ptr.To(DefaultProgressDeadlineSeconds)
 	deployment.Spec.RevisionHistoryLimit = ptr.To(int32(10))
 }
 
 // CreateStartupProbe returns a container start-up probe for the given dogu if it contains a state healthcheck.
 // Otherwise, it returns nil.
 func CreateStartupProbe(dogu *core.Dogu) *corev1.Probe {
-	timeoutSeconds := getStartupProbeTimeout()
-
 	for _, healthCheck := range dogu.HealthChecks {
-		if healthCheck.Type == "state" {
-			state := "ready"
-			if healthCheck.State != "" {
-				state = healthCheck.State
-			}
+		if healthCheck.Type == healthCheckTypeState {
 			return &corev1.Probe{
-				ProbeHandler: corev1.ProbeHandler{
-					Exec: &corev1.ExecAction{Command: []string{"/bin/sh", "-c", fmt.Sprintf("[ $(doguctl state) = \"%s\" ]", state)}},
-				},
-				TimeoutSeconds:   timeoutSeconds,
+				ProbeHandler:     stateProbeHandler(healthCheck),
+				TimeoutSeconds:   getStartupProbeTimeout(),
 				PeriodSeconds:    10,
 				SuccessThreshold: 1,
 				// Setting this value to low makes some dogus unable to start that require a certain amount of time.
@@ -486,6 +489,99 @@ func CreateStartupProbe(dogu *core.Dogu) *corev1.Probe {
 	return nil
 }
 
+// createLivenessProbe returns a container liveness probe for the given dogu if it contains a tcp healthcheck.
+// Only the tcp healthcheck is used, so that a slow or failing http endpoint makes the dogu unready but never restarts
+// it. Otherwise, it returns nil.
+func createLivenessProbe(dogu *core.Dogu) *corev1.Probe {
+	healthCheck, found := findHealthCheck(dogu, healthCheckTypeTCP)
+	if !found {
+		return nil
+	}
+
+	return &corev1.Probe{
+		ProbeHandler:     healthCheckProbeHandler(healthCheck),
+		TimeoutSeconds:   1,
+		PeriodSeconds:    10,
+		SuccessThreshold: 1,
+		// Setting this value to low makes some dogus unable to start that require a certain amount of time.
+		// The default value is set to 30 min.
+		FailureThreshold: 6 * 30,
+	}
+}
+
+// createReadinessProbe returns a container readiness probe for the given dogu if it contains a healthcheck, so that
+// the service of the dogu only routes traffic to the pod while the dogu is healthy.
+// As a probe can only have one handler, the most meaningful healthcheck is used: http before state before tcp.
+// Otherwise, it returns nil.
+func createReadinessProbe(dogu *core.Dogu) *corev1.Probe {
+	healthCheck, found := findHealthCheck(dogu, healthCheckTypeHTTP, healthCheckTypeState, healthCheckTypeTCP)
+	if !found {
+		return nil
+	}
+
+	timeoutSeconds := int32(1)
+	if healthCheck.Type == healthCheckTypeState {
+		timeoutSeconds = getStartupProbeTimeout()
+	}
+
+	return &corev1.Probe{
+		ProbeHandler:     healthCheckProbeHandler(healthCheck),
+		TimeoutSeconds:   timeoutSeconds,
+		PeriodSeconds:    10,
+		SuccessThreshold: 1,
+		FailureThreshold: 3,
+	}
+}
+
+// findHealthCheck returns the first healthcheck of the dogu with the first of the given types that the dogu declares.
+func findHealthCheck(dogu *core.Dogu, types ...string) (core.HealthCheck, bool) {
+	for _, healthCheckType := range types {
+		for _, healthCheck := range dogu.HealthChecks {
+			if healthCheck.Type == healthCheckType {
+				return healthCheck, true
+			}
+		}
+	}
+
+	return core.HealthCheck{}, false
+}
+
+func healthCheckProbeHandler(healthCheck core.HealthCheck) corev1.ProbeHandler {
+	switch healthCheck.Type {
+	case healthCheckTypeHTTP:
+		// The dogu descriptor has no field for the expected status code and expects 200 to 299. The kubelet cannot be
+		// restricted to these codes and also accepts redirects from 300 to 399, so a redirecting health endpoint passes.
+		path := healthCheck.Path
+		if path == "" {
+			path = defaultHealthCheckPath
+		}
+		return corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path:   path,
+				Port:   intstr.IntOrString{IntVal: int32(healthCheck.Port)},
+				Scheme: corev1.URISchemeHTTP,
+			},
+		}
+	case healthCheckTypeState:
+		return stateProbeHandler(healthCheck)
+	default:
+		return corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{Port: intstr.IntOrString{IntVal: int32(healthCheck.Port)}},
+		}
+	}
+}
+
+func stateProbeHandler(healthCheck core.HealthCheck) corev1.ProbeHandler {
+	state := defaultHealthCheckState
+	if healthCheck.State != "" {
+		state = healthCheck.State
+	}
+
+	return corev1.ProbeHandler{
+		Exec: &corev1.ExecAction{Command: []string{"/bin/sh", "-c", fmt.Sprintf("[ $(doguctl state) = \"%s\" ]", state)}},
+	}
+}
+
 func getStartupProbeTimeout() int32 {
 	timeoutSeconds := defaultStartupProbeTimeout
 	timeoutSecondsStr, found := os.LookupEnv(startupProbeTimoutEnv)
@@ -14,6 +14,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -558,6 +559,67 @@ func Test_CreateStartupProbe(t *testing.T) {
 	}
 }
 
+func Test_createLivenessProbe(t *testing.T) {
+	t.Run("should use tcp healthcheck", func(t *testing.T) {
+		dogu := &core.Dogu{HealthChecks: []core.HealthCheck{{Type: "http", Port: 8080}, {Type: "tcp", Port: 389}}}
+
+		probe := createLivenessProbe(dogu)
+
+		require.NotNil(t, probe)
+		assert.Equal(t, int32(389), probe.TCPSocket.Port.IntVal)
+		assert.Nil(t, probe.HTTPGet)
+		assert.Equal(t, int32(180), probe.FailureThreshold)
+	})
+	t.Run("should return nil without tcp healthcheck", func(t *testing.T) {
+		dogu := &core.Dogu{HealthChecks: []core.HealthCheck{{Type: "state"}, {Type: "http", Port: 8080, Path: "/nexus/status"}}}
+
+		assert.Nil(t, createLivenessProbe(dogu))
+	})
+}
+
+func Test_createReadinessProbe(t *testing.T) {
+	t.Run("should prefer http healthcheck with default path", func(t *testing.T) {
+		dogu := &core.Dogu{HealthChecks: []core.HealthCheck{{Type: "tcp", Port: 8080}, {Type: "state"}, {Type: "http", Port: 8080}}}
+
+		probe := createReadinessProbe(dogu)
+
+		require.NotNil(t, probe)
+		assert.Equal(t, &v1.HTTPGetAction{Path: "/health", Port: intstr.IntOrString{IntVal: 8080}, Scheme: v1.URISchemeHTTP}, probe.HTTPGet)
+		assert.Equal(t, int32(3), probe.FailureThreshold)
+		assert.Equal(t, int32(1), probe.TimeoutSeconds)
+	})
+	t.Run("should ignore status parameter of http healthcheck", func(t *testing.T) {
+		dogu := &core.Dogu{HealthChecks: []core.HealthCheck{{Type: "http", Port: 8080, Path: "/api/status", Parameters: map[string]string{"status": "204"}}}}
+
+		probe := createReadinessProbe(dogu)
+
+		require.NotNil(t, probe)
+		assert.Nil(t, probe.Exec)
+		assert.Equal(t, &v1.HTTPGetAction{Path: "/api/status", Port: intstr.IntOrString{IntVal: 8080}, Scheme: v1.URISchemeHTTP}, probe.HTTPGet)
+	})
+	t.Run("should prefer state healthcheck over tcp", func(t *testing.T) {
+		t.Setenv(startupProbeTimoutEnv, "5")
+		dogu := &core.Dogu{HealthChecks: []core.HealthCheck{{Type: "tcp", Port: 389}, {Type: "state", State: "custom"}}}
+
+		probe := createReadinessProbe(dogu)
+
+		require.NotNil(t, probe)
+		assert.Equal(t, []string{"/bin/sh", "-c", "[ $(doguctl state) = \"custom\" ]"}, probe.Exec.Command)
+		assert.Equal(t, int32(5), probe.TimeoutSeconds)
+	})
+	t.Run("should use tcp healthcheck", func(t *testing.T) {
+		dogu := &core.Dogu{HealthChecks: []core.HealthCheck{{Type: "tcp", Port: 389}}}
+
+		probe := createReadinessProbe(dogu)
+
+		require.NotNil(t, probe)
+		assert.Equal(t, int32(389), probe.TCPSocket.Port.IntVal)
+	})
+	t.Run("should return nil without healthchecks", func(t *testing.T) {
+		assert.Nil(t, createReadinessProbe(&core.Dogu{}))
+	})
+}
+
 func Test_BuildAdditionalMountInitContainer(t *testing.T) {
 	t.Run("success with standard volume setup\n", func(t *testing.T) {
 		// given
@@ -13,6 +13,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	eventV1 "k8s.io/api/events/v1"
+	netv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/yaml"
@@ -244,6 +245,11 @@ func getTestScheme() *runtime.Scheme {
 		Version: "v1",
 		Kind:    "PodList",
 	}, &v1.PodList{})
+	scheme.AddKnownTypeWithName(schema.GroupVersionKind{
+		Group:   "networking.k8s.io",
+		Version: "v1",
+		Kind:    "NetworkPolicy",
+	}, &netv1.NetworkPolicy{})
 
 	return scheme
 }
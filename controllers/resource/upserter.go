@@ -4,13 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"reflect"
 	"slices"
 	"strings"
 
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/annotation"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	opConfig "github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
@@ -20,6 +23,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -30,6 +34,11 @@ import (
 )
 
 const (
+	// ApplyConflictEventReason is the reason of the event which is recorded if fields of a dogu resource are owned by
+	// another field manager.
+	ApplyConflictEventReason = "ApplyConflict"
+	// fieldManagerName is the field manager which owns the fields of the resources applied for dogus.
+	fieldManagerName            = "k8s-dogu-operator"
 	errMsgFailedToGetPVC        = "failed to get pvc"
 	k8sCesGatewayComponentLabel = "k8s.cloudogu.com/component.name"
 	k8sCesGatewayComponentName  = "k8s-ces-gateway"
@@ -44,15 +53,17 @@ var (
 type upserter struct {
 	client                 k8sClient
 	scheme                 *runtime.Scheme
+	recorder               record.EventRecorder
 	generator              DoguResourceGenerator
 	networkPoliciesEnabled bool
 }
 
 // NewUpserter creates a new upserter that generates dogu resources and applies them to the cluster.
-func NewUpserter(client client.Client, scheme *runtime.Scheme, generator DoguResourceGenerator, config *opConfig.OperatorConfig) ResourceUpserter {
+func NewUpserter(client client.Client, scheme *runtime.Scheme, recorder record.EventRecorder, generator DoguResourceGenerator, config *opConfig.OperatorConfig) ResourceUpserter {
 	return &upserter{
 		client:                 client,
 		scheme:                 scheme,
+		recorder:               recorder,
 		generator:              generator,
 		networkPoliciesEnabled: config.NetworkPoliciesEnabled,
 	}
@@ -71,7 +82,7 @@ func (u *upserter) UpsertDoguDeployment(ctx context.Context, doguResource *k8sv2
 		deploymentPatch(newDeployment)
 	}
 
-	err = u.updateOrInsert(ctx, doguResource.GetObjectKey(), &appsv1.Deployment{}, newDeployment)
+	err = u.apply(ctx, doguResource, newDeployment)
 	if err != nil {
 		return nil, err
 	}
@@ -86,7 +97,7 @@ func (u *upserter) UpsertDoguService(ctx context.Context, doguResource *k8sv2.Do
 		return nil, fmt.Errorf("failed to generate service: %w", err)
 	}
 
-	err = u.updateOrInsert(ctx, doguResource.GetObjectKey(), &v1.Service{}, newService)
+	err = u.apply(ctx, doguResource, newService)
 	if err != nil {
 		return nil, err
 	}
@@ -96,13 +107,13 @@ func (u *upserter) UpsertDoguService(ctx context.Context, doguResource *k8sv2.Do
 
 // UpsertDoguPVCs generates a persistent volume claim for a given dogu and applies it to the cluster.
 func (u *upserter) UpsertDoguPVCs(ctx context.Context, doguResource *k8sv2.Dogu, dogu *core.Dogu) (*v1.PersistentVolumeClaim, error) {
-	if needsPVCs(dogu) {
+	if NeedsPVCs(dogu) {
 		newPVC, err := u.generator.CreateDoguPVC(doguResource)
 		if err != nil {
 			return nil, fmt.Errorf("failed to generate pvc: %w", err)
 		}
 
-		err = u.upsertPVC(ctx, newPVC)
+		err = u.upsertPVC(ctx, doguResource, newPVC)
 		if err != nil {
 			return nil, err
 		}
@@ -121,8 +132,13 @@ func (u *upserter) SetControllerReferenceForPVC(ctx context.Context, pvc *v1.Per
 			return wrapControllerReferenceError(err)
 		}
 
-		pvcObjectKey := types.NamespacedName{Name: pvc.Name, Namespace: pvc.Namespace}
-		err = u.updateOrInsert(ctx, pvcObjectKey, &v1.PersistentVolumeClaim{}, pvc)
+		// only the controller reference is applied, so that the dogu operator does not take over the fields of the pvc
+		ownerReferencePVC := &v1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+			Name:            pvc.Name,
+			Namespace:       pvc.Namespace,
+			OwnerReferences: []metav1.OwnerReference{*metav1.GetControllerOf(pvc)},
+		}}
+		err = u.applyPartial(ctx, doguResource, ownerReferencePVC)
 		if err != nil {
 			return fmt.Errorf("failed to update pvc with controller reference: %w", err)
 		}
@@ -131,7 +147,8 @@ func (u *upserter) SetControllerReferenceForPVC(ctx context.Context, pvc *v1.Per
 	return nil
 }
 
-func needsPVCs(dogu *core.Dogu) bool {
+// NeedsPVCs returns true if the dogu has a volume which needs a persistent volume claim.
+func NeedsPVCs(dogu *core.Dogu) bool {
 	for _, volume := range dogu.Volumes {
 		if volume.NeedsBackup {
 			return true
@@ -165,7 +182,7 @@ func (u *upserter) UpsertDoguNetworkPolicies(ctx context.Context, doguResource *
 		return fmt.Errorf("failed to generate deny all policy: %w", err)
 	}
 
-	if err = u.upsertNetworkPolicy(ctx, denyAllPolicy); err != nil {
+	if err = u.upsertNetworkPolicy(ctx, doguResource, denyAllPolicy); err != nil {
 		multiErr = errors.Join(multiErr, fmt.Errorf("failed to create or update deny all rule for dogu %s: %w", dogu.GetSimpleName(), err))
 	}
 
@@ -249,7 +266,7 @@ func (u *upserter) upsertServiceAnnotationNetworkPolicy(ctx context.Context, dog
 		return fmt.Errorf("failed to generate ingress netpol for dogu %s: %w", dogu.GetSimpleName(), err)
 	}
 
-	if err := u.upsertNetworkPolicy(ctx, dependencyNetworkPolicy); err != nil {
+	if err := u.upsertNetworkPolicy(ctx, doguResource, dependencyNetworkPolicy); err != nil {
 		return fmt.Errorf("failed to create or update network policy allow rule for ingress of dogu %s: %w", dogu.GetSimpleName(), err)
 	}
 	return nil
@@ -261,7 +278,7 @@ func (u *upserter) upsertDoguDependencyNetworkPolicy(ctx context.Context, depend
 		return fmt.Errorf("failed to generate dogu dependency netpol for dogu %s and dependency %s: %w", dogu.GetSimpleName(), dependencyName, err)
 	}
 
-	if err := u.upsertNetworkPolicy(ctx, dependencyNetworkPolicy); err != nil {
+	if err := u.upsertNetworkPolicy(ctx, doguResource, dependencyNetworkPolicy); err != nil {
 		return fmt.Errorf("failed to create or update network policy allow rule for dependency %s of dogu %s: %w", dependencyName, dogu.GetSimpleName(), err)
 	}
 
@@ -274,25 +291,25 @@ func (u *upserter) upsertComponentDependencyNetworkPolicy(ctx context.Context, d
 		return fmt.Errorf("failed to generate component dependency netpol for dogu %s and dependency %s: %w", dogu.GetSimpleName(), dependencyName, err)
 	}
 
-	if err := u.upsertNetworkPolicy(ctx, dependencyNetworkPolicy); err != nil {
+	if err := u.upsertNetworkPolicy(ctx, doguResource, dependencyNetworkPolicy); err != nil {
 		return fmt.Errorf("failed to create or update network policy allow rule for dependency %s of dogu %s: %w", dependencyName, dogu.GetSimpleName(), err)
 	}
 
 	return nil
 }
 
-func (u *upserter) upsertNetworkPolicy(ctx context.Context, netPol *netv1.NetworkPolicy) error {
-	return u.updateOrInsert(ctx, getNetPolObjectKey(netPol), &netv1.NetworkPolicy{}, netPol)
+func (u *upserter) upsertNetworkPolicy(ctx context.Context, doguResource *k8sv2.Dogu, netPol *netv1.NetworkPolicy) error {
+	return u.apply(ctx, doguResource, netPol)
 }
 
-func (u *upserter) upsertPVC(ctx context.Context, pvc *v1.PersistentVolumeClaim) error {
+func (u *upserter) upsertPVC(ctx context.Context, doguResource *k8sv2.Dogu, pvc *v1.PersistentVolumeClaim) error {
 	pvcObjectKey := types.NamespacedName{Name: pvc.Name, Namespace: pvc.Namespace}
 
 	actualPvc := &v1.PersistentVolumeClaim{}
 	err := u.client.Get(ctx, pvcObjectKey, actualPvc)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			return u.updateOrInsert(ctx, pvcObjectKey, &v1.PersistentVolumeClaim{}, pvc)
+			return u.apply(ctx, doguResource, pvc)
 		}
 
 		return fmt.Errorf("%s %s: %w", errMsgFailedToGetPVC, pvcObjectKey.Name, err)
@@ -304,7 +321,7 @@ func (u *upserter) upsertPVC(ctx context.Context, pvc *v1.PersistentVolumeClaim)
 			return fmt.Errorf("failed to wait for existing pvc %s to terminate: %w", pvc.Name, err)
 		}
 
-		return u.updateOrInsert(ctx, pvcObjectKey, &v1.PersistentVolumeClaim{}, pvc)
+		return u.apply(ctx, doguResource, pvc)
 	}
 
 	// If the pvc exists and is not terminating keep it to support init data.
@@ -333,38 +350,101 @@ func pvcRetry(err error) bool {
 	return !strings.Contains(err.Error(), errMsgFailedToGetPVC)
 }
 
-func (u *upserter) updateOrInsert(ctx context.Context, objectKey client.ObjectKey,
-	resourceType client.Object, upsertResource client.Object) error {
-	if resourceType == nil {
-		return errors.New("upsert type must be a valid pointer to an K8s resource")
+// apply applies the complete desired state of the object and marks it with the hash of the desired state.
+func (u *upserter) apply(ctx context.Context, doguResource *k8sv2.Dogu, object client.Object) error {
+	return u.applyObject(ctx, doguResource, object, true)
+}
+
+// applyPartial applies only some fields of the object. The object is not marked with a hash, because it does not
+// contain the complete desired state.
+func (u *upserter) applyPartial(ctx context.Context, doguResource *k8sv2.Dogu, object client.Object) error {
+	return u.applyObject(ctx, doguResource, object, false)
+}
+
+// applyObject applies the object with server-side apply. Only the fields set in the object are owned by the dogu
+// operator, so fields set by other controllers are kept. Fields which were updated by the dogu operator itself are
+// taken over. The replicas are left to another field manager owning them, e.g. a horizontal pod autoscaler. Other
+// fields owned by other field managers are reported with a warning event for the dogu and are not taken over, unless
+// the ownership is forced with WithForcedOwnership.
+func (u *upserter) applyObject(ctx context.Context, doguResource *k8sv2.Dogu, object client.Object, withDesiredStateHash bool) error {
+	gvk, err := apiutil.GVKForObject(object, u.scheme)
+	if err != nil {
+		return fmt.Errorf("failed to get kind of %s: %w", object.GetName(), err)
 	}
-	ok, type1, type2 := sameResourceTypes(resourceType, upsertResource)
-	if !ok {
-		return fmt.Errorf("incompatible types provided (%s != %s)", type1, type2)
+
+	applyObject, err := toApplyObject(object, gvk)
+	if err != nil {
+		return fmt.Errorf("failed to create apply configuration for %s %s: %w", gvk.Kind, object.GetName(), err)
 	}
 
-	err := u.client.Get(ctx, objectKey, resourceType)
-	if err != nil && !apierrors.IsNotFound(err) {
-		return err
+	if withDesiredStateHash {
+		err = setDesiredStateHash(applyObject)
+		if err != nil {
+			return err
+		}
 	}
 
-	if apierrors.IsNotFound(err) {
-		return u.client.Create(ctx, upsertResource)
+	err = u.client.Apply(ctx, client.ApplyConfigurationFromUnstructured(applyObject), client.FieldOwner(fieldManagerName))
+	if apierrors.IsConflict(err) && containsField(withOtherManagers(fieldConflictsOf(err)), replicasField) {
+		unstructured.RemoveNestedField(applyObject.Object, "spec", "replicas")
+		err = u.client.Apply(ctx, client.ApplyConfigurationFromUnstructured(applyObject), client.FieldOwner(fieldManagerName))
+	}
+	if apierrors.IsConflict(err) {
+		conflicts := fieldConflictsOf(err)
+		otherConflicts := withOtherManagers(conflicts)
+		if len(conflicts) == 0 || len(otherConflicts) > 0 {
+			if !isOwnershipForced(ctx) {
+				u.recorder.Eventf(doguResource, v1.EventTypeWarning, ApplyConflictEventReason,
+					"Fields of %s %s are owned by other field managers and are not applied: %s", gvk.Kind, object.GetName(), conflictMessage(otherConflicts, err))
+				return fmt.Errorf("failed to apply %s %s because of fields owned by other field managers: %w", gvk.Kind, object.GetName(), err)
+			}
+			u.recorder.Eventf(doguResource, v1.EventTypeWarning, ApplyConflictEventReason,
+				"Taking over fields of %s %s from other field managers: %s", gvk.Kind, object.GetName(), conflictMessage(otherConflicts, err))
+		}
+		err = u.client.Apply(ctx, client.ApplyConfigurationFromUnstructured(applyObject), client.FieldOwner(fieldManagerName), client.ForceOwnership)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply %s %s: %w", gvk.Kind, object.GetName(), err)
 	}
 
-	return u.client.Update(ctx, upsertResource)
+	return nil
 }
 
-func sameResourceTypes(resourceType client.Object, newResource client.Object) (bool, string, string) {
-	if reflect.TypeOf(resourceType).AssignableTo(reflect.TypeOf(newResource)) {
-		return true, "", ""
+// toApplyObject converts the object into an unstructured object which only contains the desired state.
+// Fields maintained by the API server are removed, because they would be claimed by the dogu operator otherwise.
+func toApplyObject(object client.Object, gvk schema.GroupVersionKind) (*unstructured.Unstructured, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return nil, err
 	}
 
-	return false, getTypeName(resourceType), getTypeName(newResource)
+	applyObject := &unstructured.Unstructured{Object: removeNullValues(content)}
+	applyObject.SetGroupVersionKind(gvk)
+	applyObject.SetResourceVersion("")
+	applyObject.SetUID("")
+	applyObject.SetGeneration(0)
+	applyObject.SetManagedFields(nil)
+	unstructured.RemoveNestedField(applyObject.Object, "status")
+
+	return applyObject, nil
 }
 
-func getTypeName(objectInQuestion interface{}) string {
-	// we don't check if the object is of pointer type because the method signature of updateOrInsert enforces this for us
-	t := reflect.TypeOf(objectInQuestion)
-	return "*" + t.Elem().Name()
+// removeNullValues removes fields without value like an unset creation timestamp because null deletes a field
+// in server-side apply.
+func removeNullValues(content map[string]interface{}) map[string]interface{} {
+	for key, value := range content {
+		switch typedValue := value.(type) {
+		case nil:
+			delete(content, key)
+		case map[string]interface{}:
+			removeNullValues(typedValue)
+		case []interface{}:
+			for _, item := range typedValue {
+				if itemMap, ok := item.(map[string]interface{}); ok {
+					removeNullValues(itemMap)
+				}
+			}
+		}
+	}
+	return content
 }
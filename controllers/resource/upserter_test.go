@@ -2,6 +2,7 @@ package resource
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"slices"
 	"strings"
@@ -22,79 +23,259 @@ import (
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/utils/pointer"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestNewUpserter(t *testing.T) {
 	// given
 	mockClient := newMockK8sClient(t)
+	mockRecorder := newMockEventRecorder(t)
 	mockResourceGenerator := NewMockDoguResourceGenerator(t)
 	testScheme := getTestScheme()
 
 	// when
-	resourceUpserter := NewUpserter(mockClient, testScheme, mockResourceGenerator, &opConfig.OperatorConfig{NetworkPoliciesEnabled: true})
+	resourceUpserter := NewUpserter(mockClient, testScheme, mockRecorder, mockResourceGenerator, &opConfig.OperatorConfig{NetworkPoliciesEnabled: true})
 
 	// then
 	require.NotNil(t, resourceUpserter)
 	assert.Equal(t, mockClient, resourceUpserter.(*upserter).client)
 	assert.Equal(t, resourceUpserter.(*upserter).networkPoliciesEnabled, true)
 	assert.Equal(t, testScheme, resourceUpserter.(*upserter).scheme)
+	assert.Equal(t, mockRecorder, resourceUpserter.(*upserter).recorder)
 	require.NotNil(t, resourceUpserter.(*upserter).generator)
 }
 
-func Test_upserter_updateOrInsert(t *testing.T) {
-	t.Run("fail when using different types of objects", func(t *testing.T) {
+func Test_upserter_apply(t *testing.T) {
+	t.Run("should fail for unknown kind", func(t *testing.T) {
 		// given
 		doguResource := readLdapDoguResource(t)
-		upserter := upserter{}
+		sut := upserter{scheme: getTestScheme()}
 
 		// when
-		err := upserter.updateOrInsert(context.Background(), doguResource.GetObjectKey(), nil, &appsv1.StatefulSet{})
+		err := sut.apply(testCtx, doguResource, &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "ldap"}})
 
 		// then
 		require.Error(t, err)
-		assert.ErrorContains(t, err, "upsert type must be a valid pointer to an K8s resource")
+		assert.ErrorContains(t, err, "failed to get kind of ldap")
 	})
-	t.Run("should fail on incompatible input types", func(t *testing.T) {
+	t.Run("should fail to apply", func(t *testing.T) {
 		// given
-		depl := &appsv1.Deployment{}
-		svc := &v1.Service{}
 		doguResource := readLdapDoguResource(t)
-		sut := upserter{}
+		mockClient := newMockK8sClient(t)
+		mockClient.EXPECT().Apply(testCtx, mock.Anything, client.FieldOwner(fieldManagerName)).Return(assert.AnError)
+		sut := upserter{client: mockClient, scheme: getTestScheme()}
 
 		// when
-		err := sut.updateOrInsert(context.Background(), doguResource.GetObjectKey(), depl, svc)
+		err := sut.apply(testCtx, doguResource, readLdapDoguExpectedDeployment(t))
 
 		// then
-		require.Error(t, err)
-		assert.ErrorContains(t, err, "incompatible types provided (*Deployment != *Service)")
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to apply Deployment ldap")
 	})
+	t.Run("should apply only desired state", func(t *testing.T) {
+		// given
+		doguResource := readLdapDoguResource(t)
+		deployment := readLdapDoguExpectedDeployment(t)
+		deployment.ResourceVersion = "42"
+		deployment.Status.Replicas = 1
+		mockClient := newMockK8sClient(t)
+		mockClient.EXPECT().Apply(testCtx, mock.Anything, client.FieldOwner(fieldManagerName)).Run(func(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) {
+			content, err := json.Marshal(obj)
+			require.NoError(t, err)
+			applied := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(content, &applied))
+			assert.Equal(t, "apps/v1", applied["apiVersion"])
+			assert.Equal(t, "Deployment", applied["kind"])
+			assert.NotContains(t, applied, "status")
+			assert.NotContains(t, applied["metadata"], "resourceVersion")
+			assert.NotContains(t, applied["metadata"], "creationTimestamp")
+			desiredStateHash, err := DesiredStateHash(deployment, getTestScheme())
+			require.NoError(t, err)
+			assert.Equal(t, desiredStateHash, applied["metadata"].(map[string]interface{})["annotations"].(map[string]interface{})[DesiredStateHashAnnotation])
+		}).Return(nil)
+		sut := upserter{client: mockClient, scheme: getTestScheme()}
+
+		// when
+		err := sut.apply(testCtx, doguResource, deployment)
 
-	t.Run("should update existing pcv when no controller reference is set", func(t *testing.T) {
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should keep fields of other field managers", func(t *testing.T) {
 		// given
 		doguResource := readLdapDoguResource(t)
 		existingDeployment := readLdapDoguExpectedDeployment(t)
-		// the test should override the replication count back to 1
-		existingDeployment.Spec.Replicas = pointer.Int32(10)
+		existingDeployment.Annotations = map[string]string{"sidecar.istio.io/status": "injected"}
 		testClient := fake.NewClientBuilder().WithScheme(getTestScheme()).WithObjects(doguResource, existingDeployment).Build()
-		upserter := upserter{client: testClient}
+		sut := upserter{client: testClient, scheme: getTestScheme()}
+
+		// when
+		err := sut.apply(testCtx, doguResource, readLdapDoguExpectedDeployment(t))
+
+		// then
+		require.NoError(t, err)
+		afterApply := &appsv1.Deployment{}
+		require.NoError(t, testClient.Get(testCtx, doguResource.GetObjectKey(), afterApply))
+		assert.Equal(t, "injected", afterApply.Annotations["sidecar.istio.io/status"])
+	})
+	t.Run("should apply the same configuration for unchanged resources", func(t *testing.T) {
+		// given
+		doguResource := readLdapDoguResource(t)
+		// the API server does not change the resource version if an apply does not change the resource
+		var appliedConfigurations []string
+		mockClient := newMockK8sClient(t)
+		mockClient.EXPECT().Apply(testCtx, mock.Anything, client.FieldOwner(fieldManagerName)).Run(func(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) {
+			content, err := json.Marshal(obj)
+			require.NoError(t, err)
+			appliedConfigurations = append(appliedConfigurations, string(content))
+		}).Return(nil).Twice()
+		sut := upserter{client: mockClient, scheme: getTestScheme()}
+		require.NoError(t, sut.apply(testCtx, doguResource, readLdapDoguExpectedDeployment(t)))
+
+		// when
+		err := sut.apply(testCtx, doguResource, readLdapDoguExpectedDeployment(t))
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, appliedConfigurations, 2)
+		assert.Equal(t, appliedConfigurations[0], appliedConfigurations[1])
+	})
+	t.Run("should report conflict with other field managers and not take over their fields", func(t *testing.T) {
+		// given
+		doguResource := readLdapDoguResource(t)
+		conflictErr := newApplyConflict("kubectl-edit", ".spec.template.spec.containers[name=\"ldap\"].image")
+		mockClient := newMockK8sClient(t)
+		mockClient.EXPECT().Apply(testCtx, mock.Anything, client.FieldOwner(fieldManagerName)).Return(conflictErr).Once()
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(doguResource, v1.EventTypeWarning, ApplyConflictEventReason,
+			"Fields of %s %s are owned by other field managers and are not applied: %s", "Deployment", "ldap",
+			`.spec.template.spec.containers[name="ldap"].image (kubectl-edit)`).Return()
+		sut := upserter{client: mockClient, scheme: getTestScheme(), recorder: recorderMock}
+
+		// when
+		err := sut.apply(testCtx, doguResource, readLdapDoguExpectedDeployment(t))
+
+		// then
+		require.ErrorIs(t, err, conflictErr)
+		assert.ErrorContains(t, err, "failed to apply Deployment ldap because of fields owned by other field managers")
+	})
+	t.Run("should report conflict without conflicting fields", func(t *testing.T) {
+		// given
+		doguResource := readLdapDoguResource(t)
+		conflictErr := newApplyConflict("")
+		mockClient := newMockK8sClient(t)
+		mockClient.EXPECT().Apply(testCtx, mock.Anything, client.FieldOwner(fieldManagerName)).Return(conflictErr).Once()
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(doguResource, v1.EventTypeWarning, ApplyConflictEventReason,
+			"Fields of %s %s are owned by other field managers and are not applied: %s", "Deployment", "ldap", conflictErr.Error()).Return()
+		sut := upserter{client: mockClient, scheme: getTestScheme(), recorder: recorderMock}
+
+		// when
+		err := sut.apply(testCtx, doguResource, readLdapDoguExpectedDeployment(t))
+
+		// then
+		require.ErrorIs(t, err, conflictErr)
+	})
+	t.Run("should take over fields updated by the dogu operator itself", func(t *testing.T) {
+		// given
+		doguResource := readLdapDoguResource(t)
+		conflictErr := newApplyConflict(fieldManagerName, ".spec.replicas")
+		mockClient := newMockK8sClient(t)
+		mockClient.EXPECT().Apply(testCtx, mock.Anything, client.FieldOwner(fieldManagerName)).Return(conflictErr).Once()
+		mockClient.EXPECT().Apply(testCtx, mock.Anything, client.FieldOwner(fieldManagerName), client.ForceOwnership).Return(nil).Once()
+		sut := upserter{client: mockClient, scheme: getTestScheme(), recorder: newMockEventRecorder(t)}
 
 		// when
-		upsertedDeployment := readLdapDoguExpectedDeployment(t)
-		err := upserter.updateOrInsert(context.Background(), doguResource.GetObjectKey(), &appsv1.Deployment{}, upsertedDeployment)
+		err := sut.apply(testCtx, doguResource, readLdapDoguExpectedDeployment(t))
 
 		// then
 		require.NoError(t, err)
+	})
+	t.Run("should leave replicas to other field managers", func(t *testing.T) {
+		// given
+		doguResource := readLdapDoguResource(t)
+		var appliedConfigurations []map[string]interface{}
+		mockClient := newMockK8sClient(t)
+		mockClient.EXPECT().Apply(testCtx, mock.Anything, client.FieldOwner(fieldManagerName)).Run(func(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) {
+			content, err := json.Marshal(obj)
+			require.NoError(t, err)
+			applied := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(content, &applied))
+			appliedConfigurations = append(appliedConfigurations, applied)
+		}).Return(newApplyConflict("kube-controller-manager", ".spec.replicas")).Once()
+		mockClient.EXPECT().Apply(testCtx, mock.Anything, client.FieldOwner(fieldManagerName)).Run(func(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) {
+			content, err := json.Marshal(obj)
+			require.NoError(t, err)
+			applied := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(content, &applied))
+			appliedConfigurations = append(appliedConfigurations, applied)
+		}).Return(nil).Once()
+		sut := upserter{client: mockClient, scheme: getTestScheme(), recorder: newMockEventRecorder(t)}
+
+		// when
+		err := sut.apply(testCtx, doguResource, readLdapDoguExpectedDeployment(t))
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, appliedConfigurations, 2)
+		assert.Contains(t, appliedConfigurations[0]["spec"], "replicas")
+		assert.NotContains(t, appliedConfigurations[1]["spec"], "replicas")
+	})
+	t.Run("should record event and force apply on conflict with forced ownership", func(t *testing.T) {
+		// given
+		doguResource := readLdapDoguResource(t)
+		ctx := WithForcedOwnership(testCtx)
+		conflictErr := newApplyConflict("kubectl-edit", ".spec.strategy.type")
+		mockClient := newMockK8sClient(t)
+		mockClient.EXPECT().Apply(ctx, mock.Anything, client.FieldOwner(fieldManagerName)).Return(conflictErr).Once()
+		mockClient.EXPECT().Apply(ctx, mock.Anything, client.FieldOwner(fieldManagerName), client.ForceOwnership).Return(nil).Once()
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(doguResource, v1.EventTypeWarning, ApplyConflictEventReason,
+			"Taking over fields of %s %s from other field managers: %s", "Deployment", "ldap", ".spec.strategy.type (kubectl-edit)").Return()
+		sut := upserter{client: mockClient, scheme: getTestScheme(), recorder: recorderMock}
+
+		// when
+		err := sut.apply(ctx, doguResource, readLdapDoguExpectedDeployment(t))
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail if force apply fails on conflict", func(t *testing.T) {
+		// given
+		doguResource := readLdapDoguResource(t)
+		ctx := WithForcedOwnership(testCtx)
+		mockClient := newMockK8sClient(t)
+		mockClient.EXPECT().Apply(ctx, mock.Anything, client.FieldOwner(fieldManagerName)).Return(newApplyConflict("kubectl-edit", ".spec.strategy.type")).Once()
+		mockClient.EXPECT().Apply(ctx, mock.Anything, client.FieldOwner(fieldManagerName), client.ForceOwnership).Return(assert.AnError).Once()
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(doguResource, v1.EventTypeWarning, ApplyConflictEventReason, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+		sut := upserter{client: mockClient, scheme: getTestScheme(), recorder: recorderMock}
 
-		afterUpsert := &appsv1.Deployment{}
-		err = testClient.Get(context.Background(), doguResource.GetObjectKey(), afterUpsert)
-		assert.Equal(t, pointer.Int32(1), afterUpsert.Spec.Replicas)
-		// mock assert happens during cleanup
+		// when
+		err := sut.apply(ctx, doguResource, readLdapDoguExpectedDeployment(t))
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
 	})
 }
 
+// newApplyConflict creates a conflict error like the API server returns it for a server-side apply.
+func newApplyConflict(manager string, fields ...string) *apierrors.StatusError {
+	err := apierrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, "ldap", assert.AnError)
+	for _, field := range fields {
+		err.ErrStatus.Details.Causes = append(err.ErrStatus.Details.Causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Message: fmt.Sprintf("conflict with %q using apps/v1", manager),
+			Field:   field,
+		})
+	}
+	return err
+}
+
 func Test_upserter_UpsertDoguDeployment(t *testing.T) {
 	ctx := context.Background()
 	t.Run("fail on error when generating resource", func(t *testing.T) {
@@ -107,6 +288,7 @@ func Test_upserter_UpsertDoguDeployment(t *testing.T) {
 		generator.EXPECT().CreateDoguDeployment(ctx, doguResource, dogu).Return(nil, assert.AnError)
 		upserter := upserter{
 			client:    mockClient,
+			scheme:    getTestScheme(),
 			generator: generator,
 		}
 
@@ -122,12 +304,13 @@ func Test_upserter_UpsertDoguDeployment(t *testing.T) {
 		dogu := readLdapDogu(t)
 
 		mockClient := newMockK8sClient(t)
-		mockClient.EXPECT().Get(ctx, doguResource.GetObjectKey(), &appsv1.Deployment{}).Return(assert.AnError)
+		mockClient.EXPECT().Apply(ctx, mock.Anything, client.FieldOwner(fieldManagerName)).Return(assert.AnError)
 
 		generator := NewMockDoguResourceGenerator(t)
 		generator.EXPECT().CreateDoguDeployment(ctx, doguResource, dogu).Return(readLdapDoguExpectedDeployment(t), nil)
 		upserter := upserter{
 			client:    mockClient,
+			scheme:    getTestScheme(),
 			generator: generator,
 		}
 
@@ -149,6 +332,7 @@ func Test_upserter_UpsertDoguDeployment(t *testing.T) {
 		generator.EXPECT().CreateDoguDeployment(ctx, doguResource, dogu).Return(generatedDeployment, nil)
 		upserter := upserter{
 			client:    testClient,
+			scheme:    getTestScheme(),
 			generator: generator,
 		}
 		deploymentPatch := func(deployment *appsv1.Deployment) {
@@ -161,7 +345,6 @@ func Test_upserter_UpsertDoguDeployment(t *testing.T) {
 		// then
 		require.NoError(t, err)
 		expectedDeployment := readLdapDoguExpectedDeployment(t)
-		expectedDeployment.ResourceVersion = "1"
 		expectedDeployment.Labels["test"] = "testvalue"
 		assert.Equal(t, expectedDeployment, doguDeployment)
 	})
@@ -187,6 +370,7 @@ func Test_upserter_UpsertDoguPVCs(t *testing.T) {
 		generator.EXPECT().CreateDoguPVC(doguResource).Return(readLdapDoguExpectedDoguPVC(t), nil)
 		upserter := upserter{
 			client:    mockClient,
+			scheme:    getTestScheme(),
 			generator: generator,
 		}
 		oldTries := maximumTriesWaitForExistingPVC
@@ -214,6 +398,7 @@ func Test_upserter_UpsertDoguPVCs(t *testing.T) {
 		generator.On("CreateDoguPVC", doguResource).Return(nil, assert.AnError)
 		upserter := upserter{
 			client:    mockClient,
+			scheme:    getTestScheme(),
 			generator: generator,
 		}
 
@@ -238,6 +423,7 @@ func Test_upserter_UpsertDoguPVCs(t *testing.T) {
 		generator.On("CreateDoguPVC", doguResource).Return(readLdapDoguExpectedDoguPVC(t), nil)
 		upserter := upserter{
 			client:    mockClient,
+			scheme:    getTestScheme(),
 			generator: generator,
 		}
 
@@ -259,6 +445,7 @@ func Test_upserter_UpsertDoguPVCs(t *testing.T) {
 		generator.On("CreateDoguPVC", doguResource).Return(expectedDoguPVC, nil)
 		upserter := upserter{
 			client:    testClient,
+			scheme:    getTestScheme(),
 			generator: generator,
 		}
 
@@ -293,6 +480,7 @@ func Test_upserter_UpsertDoguPVCs(t *testing.T) {
 		generator.On("CreateDoguPVC", doguResource).Return(expectedDoguPVC, nil)
 		upserter := upserter{
 			client:    testClient,
+			scheme:    getTestScheme(),
 			generator: generator,
 		}
 
@@ -318,6 +506,7 @@ func Test_upserter_UpsertDoguService(t *testing.T) {
 		generator.On("CreateDoguService", doguResource, ldapDogu, imageConfig).Return(nil, assert.AnError)
 		upserter := upserter{
 			client:    mockClient,
+			scheme:    getTestScheme(),
 			generator: generator,
 		}
 
@@ -334,13 +523,14 @@ func Test_upserter_UpsertDoguService(t *testing.T) {
 		imageConfig := readLdapDoguImageConfig(t)
 
 		mockClient := newMockK8sClient(t)
-		mockClient.On("Get", ctx, doguResource.GetObjectKey(), &v1.Service{}).Return(assert.AnError)
+		mockClient.EXPECT().Apply(ctx, mock.Anything, client.FieldOwner(fieldManagerName)).Return(assert.AnError)
 
 		generator := NewMockDoguResourceGenerator(t)
 		expectedService := readLdapDoguExpectedService(t)
 		generator.On("CreateDoguService", doguResource, ldapDogu, imageConfig).Return(expectedService, nil)
 		upserter := upserter{
 			client:    mockClient,
+			scheme:    getTestScheme(),
 			generator: generator,
 		}
 
@@ -363,6 +553,7 @@ func Test_upserter_UpsertDoguService(t *testing.T) {
 		generator.On("CreateDoguService", doguResource, ldapDogu, imageConfig).Return(expectedService, nil)
 		upserter := upserter{
 			client:    testClient,
+			scheme:    getTestScheme(),
 			generator: generator,
 		}
 
@@ -381,12 +572,13 @@ func Test_upserter_UpsertDoguService(t *testing.T) {
 		imageConfig := readLdapDoguImageConfig(t)
 
 		mockClient := newMockK8sClient(t)
-		mockClient.On("Get", context.Background(), doguResource.GetObjectKey(), &v1.Service{}).Return(assert.AnError)
+		mockClient.EXPECT().Apply(context.Background(), mock.Anything, client.FieldOwner(fieldManagerName)).Return(assert.AnError)
 
 		generator := NewMockDoguResourceGenerator(t)
 		generator.On("CreateDoguService", doguResource, ldapDogu, imageConfig).Return(readLdapDoguExpectedService(t), nil)
 		upserter := upserter{
 			client:    mockClient,
+			scheme:    getTestScheme(),
 			generator: generator,
 		}
 
@@ -449,7 +641,7 @@ func Test_upserter_UpsertDoguNetworkPolicies(t *testing.T) {
 			networkPoliciesEnabled: true,
 		},
 		{
-			name:     "fails on error with update",
+			name:     "fails on error with apply",
 			doguName: "redmine",
 			doguDependencies: []string{
 				"postgresql",
@@ -536,11 +728,7 @@ func Test_upserter_UpsertDoguNetworkPolicies(t *testing.T) {
 				Status:     k8sv2.DoguStatus{},
 			}
 
-			times := len(test.expectedNetworkPolicies)
 			mockClient := newMockK8sClient(t)
-			if times > 0 {
-				mockClient.EXPECT().Get(context.Background(), mock.Anything, mock.AnythingOfType("*v1.NetworkPolicy")).Return(nil).Times(times)
-			}
 
 			service := v1.Service{
 				ObjectMeta: metav1.ObjectMeta{
@@ -591,15 +779,19 @@ func Test_upserter_UpsertDoguNetworkPolicies(t *testing.T) {
 
 			var actualCalledPolicies []string
 			for range test.expectedNetworkPolicies {
-				mockClient.EXPECT().Update(context.Background(), mock.AnythingOfType("*v1.NetworkPolicy")).Run(func(ctx context.Context, clientObject client.Object, opts ...client.UpdateOption) {
-					policy, ok := clientObject.(*netv1.NetworkPolicy)
-					if !ok {
-						t.Error("the arg 1 passed to Update was not of type *v1.NetworkPolicy")
+				mockClient.EXPECT().Apply(context.Background(), mock.Anything, client.FieldOwner(fieldManagerName)).Run(func(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) {
+					policy, ok := obj.(interface {
+						GetKind() string
+						GetName() string
+					})
+					if !ok || policy.GetKind() != "NetworkPolicy" {
+						t.Error("the arg 1 passed to Apply was not a network policy")
+						return
 					}
-					if !slices.Contains(test.expectedNetworkPolicies, policy.Name) {
-						t.Errorf("the network policy %s was created but not expected", policy)
+					if !slices.Contains(test.expectedNetworkPolicies, policy.GetName()) {
+						t.Errorf("the network policy %s was created but not expected", policy.GetName())
 					}
-					actualCalledPolicies = append(actualCalledPolicies, policy.Name)
+					actualCalledPolicies = append(actualCalledPolicies, policy.GetName())
 				}).Return(errResult).Once()
 			}
 
@@ -664,7 +856,7 @@ func Test_upserter_SetControllerReferenceForPVC(t *testing.T) {
 			name: "should fail to update PVC",
 			clientFn: func(t *testing.T) k8sClient {
 				mck := newMockK8sClient(t)
-				mck.EXPECT().Get(testCtx, types.NamespacedName{Name: "dogupvc", Namespace: "ecosystem"}, &v1.PersistentVolumeClaim{}).Return(assert.AnError).Once()
+				mck.EXPECT().Apply(testCtx, mock.Anything, client.FieldOwner(fieldManagerName)).Return(assert.AnError).Once()
 				return mck
 			},
 			args: args{
@@ -690,20 +882,26 @@ func Test_upserter_SetControllerReferenceForPVC(t *testing.T) {
 			name: "should succeed",
 			clientFn: func(t *testing.T) k8sClient {
 				mck := newMockK8sClient(t)
-				mck.EXPECT().Get(testCtx, types.NamespacedName{Name: "dogupvc", Namespace: "ecosystem"}, &v1.PersistentVolumeClaim{}).Return(nil).Once()
-				mck.EXPECT().Update(testCtx, &v1.PersistentVolumeClaim{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "dogupvc",
-						Namespace: "ecosystem",
-						OwnerReferences: []metav1.OwnerReference{{
-							APIVersion:         "k8s.cloudogu.com/v2",
-							Kind:               "Dogu",
-							Name:               "dogu",
-							UID:                "asdf",
-							Controller:         ptr.To(true),
-							BlockOwnerDeletion: ptr.To(true),
-						}},
-					},
+				mck.EXPECT().Apply(testCtx, mock.Anything, client.FieldOwner(fieldManagerName)).Run(func(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) {
+					content, err := json.Marshal(obj)
+					require.NoError(t, err)
+					assert.JSONEq(t, `{
+						"apiVersion": "v1",
+						"kind": "PersistentVolumeClaim",
+						"metadata": {
+							"name": "dogupvc",
+							"namespace": "ecosystem",
+							"ownerReferences": [{
+								"apiVersion": "k8s.cloudogu.com/v2",
+								"kind": "Dogu",
+								"name": "dogu",
+								"uid": "asdf",
+								"controller": true,
+								"blockOwnerDeletion": true
+							}]
+						},
+						"spec": {"resources": {}}
+					}`, string(content))
 				}).Return(nil).Once()
 				return mck
 			},
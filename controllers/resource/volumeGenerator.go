@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/cloudogu/cesapp-lib/core"
 	k8sv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
@@ -67,7 +68,7 @@ type volumeConfigMapContent struct {
 }
 
 func CreateVolumes(doguResource *k8sv2.Dogu, dogu *core.Dogu, exportModeActive bool) ([]corev1.Volume, error) {
-	volumes := createStaticVolumes(doguResource)
+	volumes := createStaticVolumes(doguResource, dogu)
 	volumes = append(volumes, createDoguJsonVolumesFromDependencies(dogu)...)
 	volumes = append(volumes, getDoguJsonVolumeForDogu(dogu.GetSimpleName()))
 
@@ -166,15 +167,49 @@ func getDoguJsonVolumeForDogu(simpleDoguName string) corev1.Volume {
 	}
 }
 
-func createStaticVolumes(doguResource *k8sv2.Dogu) []corev1.Volume {
-	doguHealthVolume := corev1.Volume{
+// HealthConfigMapNameSuffix is appended to the name of a dogu to get the name of the config map containing its health
+// state.
+const HealthConfigMapNameSuffix = "-health"
+
+// HealthConfigMapName returns the name of the config map containing the health state of the dogu.
+func HealthConfigMapName(doguName string) string {
+	return doguName + HealthConfigMapNameSuffix
+}
+
+// createHealthVolume creates the volume containing the health states of the dogu and its dogu dependencies.
+// Every health config map contains the name of its dogu as the only key, so that the projected volume provides the
+// same files /etc/ces/health/<dogu> as the former health config map shared by all dogus.
+// The config maps are optional, because the health config map of a dogu is only created by its first health check.
+func createHealthVolume(doguResource *k8sv2.Dogu, dogu *core.Dogu) corev1.Volume {
+	optional := true
+	doguNames := []string{doguResource.Name}
+	for _, dependency := range append(slices.Clone(dogu.Dependencies), dogu.OptionalDependencies...) {
+		// the same file must not be projected twice
+		if dependency.Type == doguDependencyType && !slices.Contains(doguNames, dependency.Name) {
+			doguNames = append(doguNames, dependency.Name)
+		}
+	}
+
+	var sources []corev1.VolumeProjection
+	for _, doguName := range doguNames {
+		sources = append(sources, corev1.VolumeProjection{
+			ConfigMap: &corev1.ConfigMapProjection{
+				LocalObjectReference: corev1.LocalObjectReference{Name: HealthConfigMapName(doguName)},
+				Optional:             &optional,
+			},
+		})
+	}
+
+	return corev1.Volume{
 		Name: doguHealth,
 		VolumeSource: corev1.VolumeSource{
-			ConfigMap: &corev1.ConfigMapVolumeSource{
-				LocalObjectReference: corev1.LocalObjectReference{Name: doguHealthConfigMap},
-			},
+			Projected: &corev1.ProjectedVolumeSource{Sources: sources},
 		},
 	}
+}
+
+func createStaticVolumes(doguResource *k8sv2.Dogu, dogu *core.Dogu) []corev1.Volume {
+	doguHealthVolume := createHealthVolume(doguResource, dogu)
 
 	timezoneVolume := corev1.Volume{
 		Name: timeZoneMountName,
@@ -507,6 +507,37 @@ func Test_createVolumes(t *testing.T) {
 	})
 }
 
+func Test_createHealthVolume(t *testing.T) {
+	// given
+	optional := true
+	dogu := &core.Dogu{
+		Name: "official/redmine",
+		Dependencies: []core.Dependency{
+			{Type: doguDependencyType, Name: "postgresql"},
+			{Type: "client", Name: "k8s-dogu-operator"},
+		},
+		OptionalDependencies: []core.Dependency{
+			{Type: doguDependencyType, Name: "postfix"},
+			{Type: doguDependencyType, Name: "postgresql"},
+		},
+	}
+
+	// when
+	volume := createHealthVolume(&k8sv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "redmine"}}, dogu)
+
+	// then
+	assert.Equal(t, corev1.Volume{
+		Name: "dogu-health",
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{
+				{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "redmine-health"}, Optional: &optional}},
+				{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "postgresql-health"}, Optional: &optional}},
+				{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "postfix-health"}, Optional: &optional}},
+			}},
+		},
+	}, volume)
+}
+
 func Test_createDoguJsonVolumesFromDependencies(t *testing.T) {
 	optionalTrue := true
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/cloudogu/cesapp-lib/core"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/namespaced"
 	"github.com/cloudogu/k8s-registry-lib/config"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -29,7 +30,7 @@ func (c *creator) createComponentServiceAccount(ctx context.Context, dogu *core.
 
 	// get service for component of service account
 	labelSelector := fmt.Sprintf("%s=%s", saLabelProviderSvc, serviceAccount.Type)
-	servicesClient := c.clientSet.CoreV1().Services(c.namespace)
+	servicesClient := c.clientSet.CoreV1().Services(namespaced.FromContext(ctx, c.namespace))
 	service, err := getServiceForLabels(ctx, servicesClient, labelSelector)
 	if err != nil && saIsOptional {
 		logger.Info("Skipping creation of service account % because the service was not found and the service account is optional", serviceAccount.Type)
@@ -131,7 +132,7 @@ func (r *remover) removeComponentServiceAccount(ctx context.Context, dogu *core.
 
 	// get service for component of service account
 	labelSelector := fmt.Sprintf("%s=%s", saLabelProviderSvc, serviceAccount.Type)
-	servicesClient := r.clientSet.CoreV1().Services(r.namespace)
+	servicesClient := r.clientSet.CoreV1().Services(namespaced.FromContext(ctx, r.namespace))
 	service, err := getServiceForLabels(ctx, servicesClient, labelSelector)
 	if err != nil {
 		return fmt.Errorf("failed to get service: %w", err)
@@ -21,6 +21,7 @@ import (
 
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/exec"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/namespaced"
 )
 
 // doguKind describes a service account on a dogu.
@@ -185,7 +186,7 @@ func (c *creator) executeCommand(ctx context.Context, consumerDogu *core.Dogu, s
 
 	command := exec.NewShellCommand(createCommand.Command, args...)
 
-	doguResource, err := getDoguResource(ctx, saDogu.GetSimpleName(), c.namespace, c.client)
+	doguResource, err := getDoguResource(ctx, saDogu.GetSimpleName(), namespaced.FromContext(ctx, c.namespace), c.client)
 	if err != nil {
 		return nil, err
 	}
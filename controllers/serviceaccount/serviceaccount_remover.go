@@ -17,6 +17,7 @@ import (
 
 	"github.com/cloudogu/cesapp-lib/core"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/exec"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/namespaced"
 )
 
 // Remover removes a dogu's service account.
@@ -178,7 +179,7 @@ func (r *remover) executeCommand(ctx context.Context, consumerDogu *core.Dogu, s
 
 	command := exec.NewShellCommand(removeCommand.Command, args...)
 
-	doguResource, err := getDoguResource(ctx, saDogu.GetSimpleName(), r.namespace, r.client)
+	doguResource, err := getDoguResource(ctx, saDogu.GetSimpleName(), namespaced.FromContext(ctx, r.namespace), r.client)
 	if err != nil {
 		return err
 	}
@@ -9,10 +9,12 @@ import (
 	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/cesregistry"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/volumesnapshot"
 	"github.com/cloudogu/k8s-registry-lib/config"
 	"github.com/cloudogu/k8s-registry-lib/repository"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -58,8 +60,9 @@ type doguInterface interface {
 }
 
 type doguHealthStatusUpdater interface {
-	UpdateHealthConfigMap(ctx context.Context, deployment *appsv1.Deployment, doguJson *cesappcore.Dogu) error
+	UpdateHealthConfigMap(ctx context.Context, doguResource *v2.Dogu, deployment *appsv1.Deployment, doguJson *cesappcore.Dogu) error
 	DeleteDoguOutOfHealthConfigMap(ctx context.Context, dogu *v2.Dogu) error
+	MigrateLegacyHealthConfigMap(ctx context.Context, namespace string, dogus []v2.Dogu) error
 }
 
 type authRegistrationManager interface {
@@ -68,3 +71,11 @@ type authRegistrationManager interface {
 	// RemoveAuthRegistration removes the AuthRegistration belonging to the given dogu.
 	RemoveAuthRegistration(ctx context.Context, doguName cescommons.SimpleName) error
 }
+
+type volumeSnapshotter interface {
+	volumesnapshot.Snapshotter
+}
+
+type eventRecorder interface {
+	record.EventRecorder
+}
@@ -0,0 +1,88 @@
+package deletion
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/volumesnapshot"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	requeueAfterVolumeSnapshot      = 10 * time.Second
+	volumeSnapshotFailedEventReason = "VolumeSnapshotFailed"
+)
+
+// The VolumeSnapshotStep takes a volume snapshot of the data volume of dogus which opted in with the
+// volumesnapshot.Annotation before the dogu is deleted. The deletion waits until the volume snapshot is ready to use.
+// The volume snapshot is not owned by the dogu, so that it survives the deletion of the data volume.
+type VolumeSnapshotStep struct {
+	snapshotter   volumeSnapshotter
+	doguInterface doguInterface
+	recorder      eventRecorder
+}
+
+func NewVolumeSnapshotStep(snapshotter volumesnapshot.Snapshotter, doguInterface doguClient.DoguInterface, recorder record.EventRecorder) *VolumeSnapshotStep {
+	return &VolumeSnapshotStep{
+		snapshotter:   snapshotter,
+		doguInterface: doguInterface,
+		recorder:      recorder,
+	}
+}
+
+func (vs *VolumeSnapshotStep) Run(ctx context.Context, doguResource *v2.Dogu) steps.StepResult {
+	if !volumesnapshot.Enabled(doguResource) {
+		return steps.Continue()
+	}
+
+	state, err := vs.snapshotter.Take(ctx, doguResource, volumesnapshot.PurposeDeletion)
+	if err != nil {
+		return steps.RequeueWithError(fmt.Errorf("failed to take volume snapshot before deletion: %w", err))
+	}
+	if state == nil {
+		// the dogu has no data volume
+		return steps.Continue()
+	}
+
+	condition := volumesnapshot.NewCondition(state, volumesnapshot.PurposeDeletion, doguResource.Generation)
+	err = vs.setCondition(ctx, doguResource, condition)
+	if err != nil {
+		return steps.RequeueWithError(err)
+	}
+
+	if state.Error != "" {
+		vs.recorder.Event(doguResource, corev1.EventTypeWarning, volumeSnapshotFailedEventReason, condition.Message)
+		return steps.RequeueWithError(fmt.Errorf("volume snapshot %q before deletion failed: %s", state.Name, state.Error))
+	}
+	if !state.ReadyToUse {
+		return steps.RequeueAfter(requeueAfterVolumeSnapshot)
+	}
+
+	return steps.Continue()
+}
+
+func (vs *VolumeSnapshotStep) setCondition(ctx context.Context, doguResource *v2.Dogu, condition metav1.Condition) error {
+	existing := meta.FindStatusCondition(doguResource.Status.Conditions, condition.Type)
+	if existing != nil && existing.Status == condition.Status && existing.Reason == condition.Reason &&
+		existing.Message == condition.Message && existing.ObservedGeneration == condition.ObservedGeneration {
+		return nil
+	}
+
+	updatedDoguResource, err := vs.doguInterface.UpdateStatusWithRetry(ctx, doguResource, func(status v2.DoguStatus) v2.DoguStatus {
+		meta.SetStatusCondition(&status.Conditions, condition)
+		return status
+	}, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to update %s condition: %w", condition.Type, err)
+	}
+	*doguResource = *updatedDoguResource
+
+	return nil
+}
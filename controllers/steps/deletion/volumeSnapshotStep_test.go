@@ -0,0 +1,147 @@
+package deletion
+
+import (
+	"context"
+	"testing"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/volumesnapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func getVolumeSnapshotTestDogu() *v2.Dogu {
+	return &v2.Dogu{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "ldap",
+			Namespace:   "ecosystem",
+			Generation:  4,
+			Annotations: map[string]string{volumesnapshot.Annotation: "true"},
+		},
+		Status: v2.DoguStatus{InstalledVersion: "1.0.0-1"},
+	}
+}
+
+func updateStatusWithRetry(ctx context.Context, dogu *v2.Dogu, modifyStatusFn func(v2.DoguStatus) v2.DoguStatus, opts metav1.UpdateOptions) (*v2.Dogu, error) {
+	updated := dogu.DeepCopy()
+	updated.Status = modifyStatusFn(dogu.Status)
+	return updated, nil
+}
+
+func TestNewVolumeSnapshotStep(t *testing.T) {
+	step := NewVolumeSnapshotStep(newMockVolumeSnapshotter(t), newMockDoguInterface(t), newMockEventRecorder(t))
+	assert.NotNil(t, step)
+}
+
+func TestVolumeSnapshotStep_Run(t *testing.T) {
+	t.Run("should continue if volume snapshots are not enabled", func(t *testing.T) {
+		// given
+		sut := NewVolumeSnapshotStep(newMockVolumeSnapshotter(t), newMockDoguInterface(t), newMockEventRecorder(t))
+
+		// when
+		result := sut.Run(testCtx, &v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "ldap"}})
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should fail to take volume snapshot", func(t *testing.T) {
+		// given
+		doguResource := getVolumeSnapshotTestDogu()
+		snapshotterMock := newMockVolumeSnapshotter(t)
+		snapshotterMock.EXPECT().Take(testCtx, doguResource, volumesnapshot.PurposeDeletion).Return(nil, assert.AnError)
+		sut := NewVolumeSnapshotStep(snapshotterMock, newMockDoguInterface(t), newMockEventRecorder(t))
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		require.ErrorIs(t, result.Err, assert.AnError)
+		assert.ErrorContains(t, result.Err, "failed to take volume snapshot before deletion")
+	})
+	t.Run("should continue if dogu has no data volume", func(t *testing.T) {
+		// given
+		doguResource := getVolumeSnapshotTestDogu()
+		snapshotterMock := newMockVolumeSnapshotter(t)
+		snapshotterMock.EXPECT().Take(testCtx, doguResource, volumesnapshot.PurposeDeletion).Return(nil, nil)
+		sut := NewVolumeSnapshotStep(snapshotterMock, newMockDoguInterface(t), newMockEventRecorder(t))
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should wait for pending volume snapshot", func(t *testing.T) {
+		// given
+		doguResource := getVolumeSnapshotTestDogu()
+		snapshotterMock := newMockVolumeSnapshotter(t)
+		snapshotterMock.EXPECT().Take(testCtx, doguResource, volumesnapshot.PurposeDeletion).Return(&volumesnapshot.State{Name: "ldap-deletion-4"}, nil)
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().UpdateStatusWithRetry(testCtx, doguResource, mock.Anything, metav1.UpdateOptions{}).RunAndReturn(updateStatusWithRetry)
+		sut := NewVolumeSnapshotStep(snapshotterMock, doguInterfaceMock, newMockEventRecorder(t))
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.RequeueAfter(requeueAfterVolumeSnapshot), result)
+		condition := meta.FindStatusCondition(doguResource.Status.Conditions, volumesnapshot.ConditionVolumeSnapshotReady)
+		require.NotNil(t, condition)
+		assert.Equal(t, volumesnapshot.ReasonSnapshotPending, condition.Reason)
+	})
+	t.Run("should fail to update condition", func(t *testing.T) {
+		// given
+		doguResource := getVolumeSnapshotTestDogu()
+		snapshotterMock := newMockVolumeSnapshotter(t)
+		snapshotterMock.EXPECT().Take(testCtx, doguResource, volumesnapshot.PurposeDeletion).Return(&volumesnapshot.State{Name: "ldap-deletion-4"}, nil)
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().UpdateStatusWithRetry(testCtx, doguResource, mock.Anything, metav1.UpdateOptions{}).Return(nil, assert.AnError)
+		sut := NewVolumeSnapshotStep(snapshotterMock, doguInterfaceMock, newMockEventRecorder(t))
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		require.ErrorIs(t, result.Err, assert.AnError)
+		assert.ErrorContains(t, result.Err, "failed to update VolumeSnapshotReady condition")
+	})
+	t.Run("should record event and requeue failed volume snapshot", func(t *testing.T) {
+		// given
+		doguResource := getVolumeSnapshotTestDogu()
+		snapshotterMock := newMockVolumeSnapshotter(t)
+		snapshotterMock.EXPECT().Take(testCtx, doguResource, volumesnapshot.PurposeDeletion).
+			Return(&volumesnapshot.State{Name: "ldap-deletion-4", Error: "no space left"}, nil)
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().UpdateStatusWithRetry(testCtx, doguResource, mock.Anything, metav1.UpdateOptions{}).RunAndReturn(updateStatusWithRetry)
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Event(mock.Anything, corev1.EventTypeWarning, volumeSnapshotFailedEventReason,
+			`Volume snapshot "ldap-deletion-4" before the deletion failed: no space left`)
+		sut := NewVolumeSnapshotStep(snapshotterMock, doguInterfaceMock, recorderMock)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.ErrorContains(t, result.Err, `volume snapshot "ldap-deletion-4" before deletion failed: no space left`)
+	})
+	t.Run("should continue once volume snapshot is ready to use", func(t *testing.T) {
+		// given
+		doguResource := getVolumeSnapshotTestDogu()
+		state := &volumesnapshot.State{Name: "ldap-deletion-4", DoguVersion: "1.0.0-1", ReadyToUse: true}
+		doguResource.Status.Conditions = []metav1.Condition{volumesnapshot.NewCondition(state, volumesnapshot.PurposeDeletion, 4)}
+		snapshotterMock := newMockVolumeSnapshotter(t)
+		snapshotterMock.EXPECT().Take(testCtx, doguResource, volumesnapshot.PurposeDeletion).Return(state, nil)
+		sut := NewVolumeSnapshotStep(snapshotterMock, newMockDoguInterface(t), newMockEventRecorder(t))
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+}
@@ -0,0 +1,113 @@
+package steps
+
+import "errors"
+
+// ErrorClass describes whether retrying a failed reconcile can succeed.
+type ErrorClass string
+
+const (
+	// ErrorClassRetryable marks errors which may disappear by retrying, e.g. an unavailable dependency.
+	// The dogu is requeued with backoff. Errors are retryable unless they are classified otherwise.
+	ErrorClassRetryable ErrorClass = "retryable"
+	// ErrorClassTerminalUntilSpecChange marks errors which are caused by the dogu spec, e.g. an invalid security
+	// context or an unknown or unparsable dogu version. The dogu is not reconciled again until its generation changes.
+	ErrorClassTerminalUntilSpecChange ErrorClass = "terminal-until-spec-change"
+	// ErrorClassTerminal marks errors which cannot be fixed by retrying.
+	// The dogu is not requeued but reconciled again on the next event, e.g. a change of an owned resource.
+	ErrorClassTerminal ErrorClass = "terminal"
+)
+
+// requeuer is implemented by errors which decide themselves whether the dogu should be requeued.
+type requeuer interface {
+	Requeue() bool
+}
+
+type classifiedError struct {
+	class ErrorClass
+	err   error
+}
+
+func (e *classifiedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *classifiedError) Unwrap() error {
+	return e.err
+}
+
+// NewRetryableError marks the error as retryable, even if it wraps a terminal error.
+func NewRetryableError(err error) error {
+	return newClassifiedError(ErrorClassRetryable, err)
+}
+
+// NewTerminalUntilSpecChangeError marks the error as terminal until the spec of the dogu changes.
+func NewTerminalUntilSpecChangeError(err error) error {
+	return newClassifiedError(ErrorClassTerminalUntilSpecChange, err)
+}
+
+// NewTerminalError marks the error as terminal.
+func NewTerminalError(err error) error {
+	return newClassifiedError(ErrorClassTerminal, err)
+}
+
+func newClassifiedError(class ErrorClass, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &classifiedError{class: class, err: err}
+}
+
+// ClassifyError returns the class of the error. The outermost classification in the error chain wins.
+// Errors implementing Requeue() bool are retryable if they request a requeue and terminal otherwise.
+// Joined errors are retryable if any of them is retryable, because retrying may fix that part.
+func ClassifyError(err error) ErrorClass {
+	switch e := err.(type) {
+	case nil:
+		return ErrorClassRetryable
+	case *classifiedError:
+		return e.class
+	case requeuer:
+		if e.Requeue() {
+			return ErrorClassRetryable
+		}
+		return ErrorClassTerminal
+	case interface{ Unwrap() []error }:
+		return classifyJoined(e.Unwrap())
+	}
+
+	wrapped := errors.Unwrap(err)
+	if wrapped == nil {
+		return ErrorClassRetryable
+	}
+	return ClassifyError(wrapped)
+}
+
+func classifyJoined(errs []error) ErrorClass {
+	foundError := false
+	foundSpecError := false
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		foundError = true
+		switch ClassifyError(err) {
+		case ErrorClassRetryable:
+			return ErrorClassRetryable
+		case ErrorClassTerminalUntilSpecChange:
+			foundSpecError = true
+		}
+	}
+
+	if !foundError {
+		return ErrorClassRetryable
+	}
+	if foundSpecError {
+		return ErrorClassTerminalUntilSpecChange
+	}
+	return ErrorClassTerminal
+}
+
+// IsTerminal returns true if retrying cannot fix the error.
+func IsTerminal(err error) bool {
+	return err != nil && ClassifyError(err) != ErrorClassRetryable
+}
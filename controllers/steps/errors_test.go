@@ -0,0 +1,66 @@
+package steps
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testRequeueError struct {
+	requeue bool
+}
+
+func (e *testRequeueError) Error() string {
+	return "requeue error"
+}
+
+func (e *testRequeueError) Requeue() bool {
+	return e.requeue
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorClass
+	}{
+		{name: "should classify nil as retryable", err: nil, want: ErrorClassRetryable},
+		{name: "should classify unclassified error as retryable", err: assert.AnError, want: ErrorClassRetryable},
+		{name: "should classify retryable error", err: NewRetryableError(assert.AnError), want: ErrorClassRetryable},
+		{name: "should classify terminal error", err: NewTerminalError(assert.AnError), want: ErrorClassTerminal},
+		{name: "should classify terminal until spec change error", err: NewTerminalUntilSpecChangeError(assert.AnError), want: ErrorClassTerminalUntilSpecChange},
+		{name: "should classify wrapped error", err: fmt.Errorf("wrapped: %w", NewTerminalError(assert.AnError)), want: ErrorClassTerminal},
+		{name: "should prefer outermost classification", err: NewRetryableError(fmt.Errorf("wrapped: %w", NewTerminalError(assert.AnError))), want: ErrorClassRetryable},
+		{name: "should classify error requesting requeue as retryable", err: fmt.Errorf("wrapped: %w", &testRequeueError{requeue: true}), want: ErrorClassRetryable},
+		{name: "should classify error not requesting requeue as terminal", err: &testRequeueError{requeue: false}, want: ErrorClassTerminal},
+		{name: "should classify joined errors as retryable if any is retryable", err: errors.Join(NewTerminalError(assert.AnError), assert.AnError), want: ErrorClassRetryable},
+		{name: "should classify joined errors as terminal until spec change", err: errors.Join(NewTerminalError(assert.AnError), NewTerminalUntilSpecChangeError(assert.AnError)), want: ErrorClassTerminalUntilSpecChange},
+		{name: "should classify joined terminal errors as terminal", err: errors.Join(NewTerminalError(assert.AnError), &testRequeueError{requeue: false}), want: ErrorClassTerminal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClassifyError(tt.err))
+		})
+	}
+}
+
+func TestIsTerminal(t *testing.T) {
+	assert.False(t, IsTerminal(nil))
+	assert.False(t, IsTerminal(assert.AnError))
+	assert.True(t, IsTerminal(NewTerminalError(assert.AnError)))
+	assert.True(t, IsTerminal(NewTerminalUntilSpecChangeError(assert.AnError)))
+}
+
+func TestNewTerminalError(t *testing.T) {
+	t.Run("should keep message and wrapped error", func(t *testing.T) {
+		err := NewTerminalError(assert.AnError)
+
+		assert.EqualError(t, err, assert.AnError.Error())
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("should return nil for nil error", func(t *testing.T) {
+		assert.Nil(t, NewTerminalError(nil))
+	})
+}
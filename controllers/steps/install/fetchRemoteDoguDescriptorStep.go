@@ -31,7 +31,7 @@ func (f *FetchRemoteDoguDescriptorStep) Run(ctx context.Context, resource *v2.Do
 
 	version, err := resource.GetSimpleNameVersion()
 	if err != nil {
-		return steps.RequeueWithError(err)
+		return steps.TerminalUntilSpecChange(err)
 	}
 
 	doguDescriptor, err := f.localDoguDescriptorRepo.Get(ctx, version)
@@ -42,7 +42,9 @@ func (f *FetchRemoteDoguDescriptorStep) Run(ctx context.Context, resource *v2.Do
 	}
 
 	doguDescriptor, developmentDoguMap, err := f.resourceDoguFetcher.FetchWithResource(ctx, resource)
-	if err != nil {
+	if cloudoguerrors.IsNotFoundError(err) {
+		return steps.TerminalUntilSpecChange(fmt.Errorf("unknown dogu version %s of %s: %w", resource.Spec.Version, resource.Spec.Name, err))
+	} else if err != nil {
 		return steps.RequeueWithError(fmt.Errorf("failed to fetch dogu descriptor: %w", err))
 	}
 
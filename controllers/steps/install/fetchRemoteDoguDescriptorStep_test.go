@@ -49,7 +49,7 @@ func TestFetchRemoteDoguDescriptorStep_Run(t *testing.T) {
 					Version: "---",
 				},
 			},
-			want: steps.RequeueWithError(fmt.Errorf("found more than one hyphen in version ---")),
+			want: steps.TerminalUntilSpecChange(fmt.Errorf("found more than one hyphen in version ---")),
 		},
 		{
 			name: "should fail to get local dogu descriptor",
@@ -127,6 +127,38 @@ func TestFetchRemoteDoguDescriptorStep_Run(t *testing.T) {
 			},
 			want: steps.RequeueWithError(fmt.Errorf("failed to fetch dogu descriptor: %w", assert.AnError)),
 		},
+		{
+			name: "should stop requeuing for unknown dogu version",
+			fields: fields{
+				localDoguDescriptorRepoFn: func(t *testing.T) localDoguDescriptorRepository {
+					mck := newMockLocalDoguDescriptorRepository(t)
+					mck.EXPECT().Get(testCtx, dogu.SimpleNameVersion{Name: "test", Version: core.Version{Raw: "1.0.0", Major: 1}}).Return(nil, errors.NewNotFoundError(assert.AnError))
+					return mck
+				},
+				resourceDoguFetcherFn: func(t *testing.T) resourceDoguFetcher {
+					mck := newMockResourceDoguFetcher(t)
+					mck.EXPECT().FetchWithResource(testCtx, &v2.Dogu{
+						ObjectMeta: v1.ObjectMeta{Name: "test"},
+						Spec: v2.DoguSpec{
+							Name:    "official/test",
+							Version: "1.0.0",
+						},
+					}).Return(nil, nil, errors.NewNotFoundError(assert.AnError))
+					return mck
+				},
+				clientFn: func(t *testing.T) k8sClient {
+					return newMockK8sClient(t)
+				},
+			},
+			resource: &v2.Dogu{
+				ObjectMeta: v1.ObjectMeta{Name: "test"},
+				Spec: v2.DoguSpec{
+					Name:    "official/test",
+					Version: "1.0.0",
+				},
+			},
+			want: steps.TerminalUntilSpecChange(fmt.Errorf("unknown dogu version 1.0.0 of official/test: %w", errors.NewNotFoundError(assert.AnError))),
+		},
 		{
 			name: "should fail to add remote dogu descriptor to local dogu descriptor repo",
 			fields: fields{
@@ -3,43 +3,66 @@ package install
 import (
 	"context"
 	"fmt"
+	"time"
 
 	cescommons "github.com/cloudogu/ces-commons-lib/dogu"
 	cloudoguerrors "github.com/cloudogu/ces-commons-lib/errors"
+	cesappcore "github.com/cloudogu/cesapp-lib/core"
 	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/cesregistry"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/coordination"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/health"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/healthhistory"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 const (
 	ReasonDoguNotHealthy = "DoguIsNotHealthy"
+	ReasonDoguNotReady   = "DoguIsNotReady"
 	ReasonDoguHealthy    = "DoguIsHealthy"
 )
 
-// The HealthCheckStep checks the health of the dogu and updates the status of the dogu resource
+// The HealthCheckStep checks the health of the dogu and updates the status of the dogu resource.
+// A dogu is healthy if all replicas of its deployment are available and all of its pods pass their readiness probe.
+// A healthy dogu is degraded if at least one of its mandatory dependencies is not healthy.
+// Crash-looping and abnormally terminated containers of the dogu are reported with the ContainersFailing condition.
+// If the dogu becomes available, the dogus waiting for it as a dependency are reconciled again.
+// Every change of the health status is recorded in the health history of the dogu, which is used to detect flapping.
 type HealthCheckStep struct {
 	client                  k8sClient
 	availabilityChecker     deploymentAvailabilityChecker
 	doguHealthStatusUpdater doguHealthStatusUpdater
 	doguFetcher             localDoguFetcher
 	doguInterface           doguInterface
+	doguHealthChecker       doguHealthChecker
+	waitList                doguWaitList
+	healthHistory           doguHealthHistory
+	recorder                eventRecorder
 }
 
 func NewHealthCheckStep(client client.Client, availabilityChecker health.DeploymentAvailabilityChecker,
-	doguHealthStatusUpdater health.DoguHealthStatusUpdater, fetcher cesregistry.LocalDoguFetcher, doguInterface doguClient.DoguInterface) *HealthCheckStep {
+	doguHealthStatusUpdater health.DoguHealthStatusUpdater, fetcher cesregistry.LocalDoguFetcher, doguInterface doguClient.DoguInterface,
+	doguHealthChecker health.DoguHealthChecker, waitList coordination.WaitList, healthHistory healthhistory.History,
+	recorder record.EventRecorder) *HealthCheckStep {
 	return &HealthCheckStep{
 		client:                  client,
 		availabilityChecker:     availabilityChecker,
 		doguHealthStatusUpdater: doguHealthStatusUpdater,
 		doguFetcher:             fetcher,
 		doguInterface:           doguInterface,
+		doguHealthChecker:       doguHealthChecker,
+		waitList:                waitList,
+		healthHistory:           healthHistory,
+		recorder:                recorder,
 	}
 }
 
@@ -68,20 +91,32 @@ func (hcs *HealthCheckStep) updateDoguHealth(ctx context.Context, doguDeployment
 	if err != nil {
 		return fmt.Errorf("failed to get current dogu json to update health state configMap: %w", err)
 	}
-	err = hcs.doguHealthStatusUpdater.UpdateHealthConfigMap(ctx, doguDeployment, doguJson)
+	err = hcs.doguHealthStatusUpdater.UpdateHealthConfigMap(ctx, doguResource, doguDeployment, doguJson)
 	if err != nil {
 		return fmt.Errorf("failed to update health state configMap: %w", err)
 	}
 
+	pods := &corev1.PodList{}
+	err = hcs.client.List(ctx, pods, client.InNamespace(doguResource.Namespace), client.MatchingLabels(doguResource.GetDoguNameLabel()))
+	if err != nil {
+		return fmt.Errorf("failed to list dogu pods: %w", err)
+	}
+
 	status := metav1.ConditionFalse
 	reason := ReasonDoguNotHealthy
 	message := "Not all replicas are available"
 	desiredHealthStatus := doguv2.UnavailableHealthStatus
 	if doguAvailable {
-		status = metav1.ConditionTrue
-		reason = ReasonDoguHealthy
-		message = "All replicas are available"
-		desiredHealthStatus = doguv2.AvailableHealthStatus
+		notReadyMessage := findNotReadyPod(pods.Items)
+		if notReadyMessage != "" {
+			reason = ReasonDoguNotReady
+			message = notReadyMessage
+		} else {
+			status = metav1.ConditionTrue
+			reason = ReasonDoguHealthy
+			message = "All replicas are available and ready"
+			desiredHealthStatus = doguv2.AvailableHealthStatus
+		}
 	}
 
 	condition := metav1.Condition{
@@ -92,8 +127,85 @@ func (hcs *HealthCheckStep) updateDoguHealth(ctx context.Context, doguDeployment
 		ObservedGeneration: doguResource.Generation,
 	}
 
+	degradedCondition := hcs.checkDegraded(ctx, doguResource, doguJson)
+	containersCondition := containersFailingCondition(doguResource, pods.Items)
+	transition := healthhistory.NewTransition(time.Now(), doguResource.Status.Health, desiredHealthStatus, condition.Reason, condition.Message)
+	if desiredHealthStatus == doguv2.AvailableHealthStatus && degradedCondition.Status == metav1.ConditionTrue {
+		desiredHealthStatus = health.DegradedHealthStatus
+		transition = healthhistory.NewTransition(time.Now(), doguResource.Status.Health, desiredHealthStatus, degradedCondition.Reason, degradedCondition.Message)
+	}
+
+	previousHealthStatus := doguResource.Status.Health
+	previousContainersCondition := meta.FindStatusCondition(doguResource.Status.Conditions, health.ConditionContainersFailing)
 	updatedDoguResource, err := hcs.doguInterface.UpdateStatusWithRetry(ctx, doguResource, func(status doguv2.DoguStatus) doguv2.DoguStatus {
 		status.Health = desiredHealthStatus
+		meta.SetStatusCondition(&status.Conditions, condition)
+		meta.SetStatusCondition(&status.Conditions, degradedCondition)
+		meta.SetStatusCondition(&status.Conditions, containersCondition)
+		return status
+	}, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+	*doguResource = *updatedDoguResource
+
+	if containersCondition.Status == metav1.ConditionTrue && hasChangedCause(previousContainersCondition, containersCondition) {
+		hcs.recorder.Event(doguResource, corev1.EventTypeWarning, containersCondition.Reason, containersCondition.Message)
+	}
+
+	if !health.IsRunning(previousHealthStatus) && health.IsRunning(desiredHealthStatus) {
+		woken := hcs.waitList.DependencyAvailable(doguResource.GetObjectKey())
+		if len(woken) > 0 {
+			log.FromContext(ctx).Info("Dogu became available; reconciling waiting dependents", "dependents", woken)
+		}
+	}
+
+	if previousHealthStatus != desiredHealthStatus {
+		return hcs.checkFlapping(ctx, doguResource, &transition)
+	}
+	return hcs.checkFlapping(ctx, doguResource, nil)
+}
+
+// checkFlapping records the transition of the health status and updates the Flapping condition of the dogu.
+// Without a transition, the condition is only evaluated again while the dogu is flapping, so that it is reset as soon
+// as the transitions leave the flapping window.
+// Failures of the health history are only logged, because they must not prevent the health check.
+func (hcs *HealthCheckStep) checkFlapping(ctx context.Context, doguResource *doguv2.Dogu, transition *healthhistory.Transition) error {
+	logger := log.FromContext(ctx)
+	wasFlapping := meta.IsStatusConditionTrue(doguResource.Status.Conditions, health.ConditionFlapping)
+
+	var evaluation healthhistory.Evaluation
+	var err error
+	if transition != nil {
+		evaluation, err = hcs.healthHistory.Record(ctx, doguResource, *transition)
+	} else if wasFlapping {
+		evaluation, err = hcs.healthHistory.Evaluate(ctx, doguResource)
+	} else {
+		return nil
+	}
+	if err != nil {
+		logger.Error(err, "failed to check whether the health of the dogu is flapping")
+		return nil
+	}
+
+	condition := metav1.Condition{
+		Type:               health.ConditionFlapping,
+		Status:             metav1.ConditionFalse,
+		Reason:             health.ReasonHealthStable,
+		Message:            evaluation.Message(),
+		ObservedGeneration: doguResource.Generation,
+	}
+	if evaluation.Flapping {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = health.ReasonHealthFlapping
+	}
+
+	currentCondition := meta.FindStatusCondition(doguResource.Status.Conditions, health.ConditionFlapping)
+	if currentCondition != nil && currentCondition.Status == condition.Status && currentCondition.Message == condition.Message {
+		return nil
+	}
+
+	updatedDoguResource, err := hcs.doguInterface.UpdateStatusWithRetry(ctx, doguResource, func(status doguv2.DoguStatus) doguv2.DoguStatus {
 		meta.SetStatusCondition(&status.Conditions, condition)
 		return status
 	}, metav1.UpdateOptions{})
@@ -102,5 +214,87 @@ func (hcs *HealthCheckStep) updateDoguHealth(ctx context.Context, doguDeployment
 	}
 	*doguResource = *updatedDoguResource
 
+	if evaluation.Flapping && !wasFlapping {
+		hcs.recorder.Eventf(doguResource, corev1.EventTypeWarning, health.ReasonHealthFlapping, "The health of the dogu is flapping: %s", evaluation.Message())
+	}
+
+	return nil
+}
+
+// checkDegraded derives the Degraded condition from the health of the mandatory dependencies of the dogu.
+// Optional dependencies are ignored, because the dogu is expected to work without them.
+func (hcs *HealthCheckStep) checkDegraded(ctx context.Context, doguResource *doguv2.Dogu, doguJson *cesappcore.Dogu) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               health.ConditionDegraded,
+		Status:             metav1.ConditionFalse,
+		Reason:             health.ReasonDependenciesHealthy,
+		Message:            "All mandatory dependencies are healthy",
+		ObservedGeneration: doguResource.Generation,
+	}
+
+	err := hcs.doguHealthChecker.CheckMandatoryDependenciesRecursive(ctx, doguJson, doguResource.Namespace)
+	if err != nil {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = health.ReasonDependencyUnhealthy
+		condition.Message = err.Error()
+	}
+
+	return condition
+}
+
+// containersFailingCondition derives the ContainersFailing condition from the container statuses of the dogu pods.
+// Replicas of a crash-looping dogu are simply not available, so the condition explains why.
+func containersFailingCondition(doguResource *doguv2.Dogu, pods []corev1.Pod) metav1.Condition {
+	failures := health.FindContainerFailures(pods)
+	reason, message := health.DescribeContainerFailures(failures)
+	condition := metav1.Condition{
+		Type:               health.ConditionContainersFailing,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: doguResource.Generation,
+	}
+	if len(failures) > 0 {
+		condition.Status = metav1.ConditionTrue
+	}
+
+	return condition
+}
+
+// hasChangedCause returns true if the containers of the dogu started failing or fail for another reason.
+// The restart counts in the message change with every crash, so they do not trigger a new event.
+func hasChangedCause(previous *metav1.Condition, current metav1.Condition) bool {
+	return previous == nil || previous.Status != current.Status || previous.Reason != current.Reason
+}
+
+// findNotReadyPod returns a message describing the first pod of the dogu which does not pass its readiness probe.
+// The deployment reports its availability with a delay, so the pods are checked directly to reflect the current
+// result of the readiness probes. It returns an empty message if all pods are ready.
+func findNotReadyPod(pods []corev1.Pod) string {
+	for _, pod := range pods {
+		if !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		readyCondition := findPodCondition(pod, corev1.PodReady)
+		if readyCondition == nil || readyCondition.Status != corev1.ConditionTrue {
+			message := fmt.Sprintf("Pod %s is not ready", pod.Name)
+			if readyCondition != nil && readyCondition.Message != "" {
+				message = fmt.Sprintf("%s: %s", message, readyCondition.Message)
+			}
+			return message
+		}
+	}
+
+	return ""
+}
+
+func findPodCondition(pod corev1.Pod, conditionType corev1.PodConditionType) *corev1.PodCondition {
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == conditionType {
+			return &pod.Status.Conditions[i]
+		}
+	}
+
 	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
@@ -12,13 +13,19 @@ import (
 	"github.com/cloudogu/ces-commons-lib/dogu"
 	cesappcore "github.com/cloudogu/cesapp-lib/core"
 	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/health"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/healthhistory"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	v2 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestNewHealthCheckStep(t *testing.T) {
@@ -30,6 +37,10 @@ func TestNewHealthCheckStep(t *testing.T) {
 			newMockDoguHealthStatusUpdater(t),
 			newMockLocalDoguFetcher(t),
 			doguInterfaceMock,
+			newMockDoguHealthChecker(t),
+			newMockDoguWaitList(t),
+			newMockDoguHealthHistory(t),
+			newMockEventRecorder(t),
 		)
 
 		assert.NotNil(t, step)
@@ -37,12 +48,28 @@ func TestNewHealthCheckStep(t *testing.T) {
 }
 
 func TestHealthCheckStep_Run(t *testing.T) {
+	readyPod := corev1.Pod{
+		ObjectMeta: v1.ObjectMeta{Name: "test-1"},
+		Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}},
+	}
+	notReadyPod := corev1.Pod{
+		ObjectMeta: v1.ObjectMeta{Name: "test-2"},
+		Status: corev1.PodStatus{Conditions: []corev1.PodCondition{
+			{Type: corev1.PodReady, Status: corev1.ConditionFalse, Message: "containers with unready status: [test]"},
+		}},
+	}
+	terminatingPod := corev1.Pod{ObjectMeta: v1.ObjectMeta{Name: "test-0", DeletionTimestamp: &v1.Time{Time: time.Now()}}}
+
 	type fields struct {
 		clientFn                  func(t *testing.T) k8sClient
 		availabilityCheckerFn     func(t *testing.T) deploymentAvailabilityChecker
 		doguHealthStatusUpdaterFn func(t *testing.T) doguHealthStatusUpdater
 		doguFetcherFn             func(t *testing.T) localDoguFetcher
 		doguInterfaceFn           func(t *testing.T) doguInterface
+		doguHealthCheckerFn       func(t *testing.T) doguHealthChecker
+		waitListFn                func(t *testing.T) doguWaitList
+		healthHistoryFn           func(t *testing.T) doguHealthHistory
+		recorderFn                func(t *testing.T) eventRecorder
 	}
 	tests := []struct {
 		name         string
@@ -70,6 +97,12 @@ func TestHealthCheckStep_Run(t *testing.T) {
 				doguInterfaceFn: func(t *testing.T) doguInterface {
 					return newMockDoguInterface(t)
 				},
+				doguHealthCheckerFn: func(t *testing.T) doguHealthChecker {
+					return newMockDoguHealthChecker(t)
+				},
+				waitListFn: func(t *testing.T) doguWaitList {
+					return newMockDoguWaitList(t)
+				},
 			},
 			doguResource: &doguv2.Dogu{
 				ObjectMeta: v1.ObjectMeta{
@@ -99,6 +132,12 @@ func TestHealthCheckStep_Run(t *testing.T) {
 				doguInterfaceFn: func(t *testing.T) doguInterface {
 					return newMockDoguInterface(t)
 				},
+				doguHealthCheckerFn: func(t *testing.T) doguHealthChecker {
+					return newMockDoguHealthChecker(t)
+				},
+				waitListFn: func(t *testing.T) doguWaitList {
+					return newMockDoguWaitList(t)
+				},
 			},
 			doguResource: &doguv2.Dogu{
 				ObjectMeta: v1.ObjectMeta{
@@ -132,6 +171,12 @@ func TestHealthCheckStep_Run(t *testing.T) {
 				doguInterfaceFn: func(t *testing.T) doguInterface {
 					return newMockDoguInterface(t)
 				},
+				doguHealthCheckerFn: func(t *testing.T) doguHealthChecker {
+					return newMockDoguHealthChecker(t)
+				},
+				waitListFn: func(t *testing.T) doguWaitList {
+					return newMockDoguWaitList(t)
+				},
 			},
 			doguResource: &doguv2.Dogu{
 				ObjectMeta: v1.ObjectMeta{
@@ -156,7 +201,7 @@ func TestHealthCheckStep_Run(t *testing.T) {
 				},
 				doguHealthStatusUpdaterFn: func(t *testing.T) doguHealthStatusUpdater {
 					mck := newMockDoguHealthStatusUpdater(t)
-					mck.EXPECT().UpdateHealthConfigMap(testCtx, &v2.Deployment{}, &cesappcore.Dogu{}).Return(assert.AnError)
+					mck.EXPECT().UpdateHealthConfigMap(testCtx, mock.Anything, &v2.Deployment{}, &cesappcore.Dogu{}).Return(assert.AnError)
 					return mck
 				},
 				doguFetcherFn: func(t *testing.T) localDoguFetcher {
@@ -167,6 +212,12 @@ func TestHealthCheckStep_Run(t *testing.T) {
 				doguInterfaceFn: func(t *testing.T) doguInterface {
 					return newMockDoguInterface(t)
 				},
+				doguHealthCheckerFn: func(t *testing.T) doguHealthChecker {
+					return newMockDoguHealthChecker(t)
+				},
+				waitListFn: func(t *testing.T) doguWaitList {
+					return newMockDoguWaitList(t)
+				},
 			},
 			doguResource: &doguv2.Dogu{
 				ObjectMeta: v1.ObjectMeta{
@@ -182,6 +233,7 @@ func TestHealthCheckStep_Run(t *testing.T) {
 				clientFn: func(t *testing.T) k8sClient {
 					mck := newMockK8sClient(t)
 					mck.EXPECT().Get(testCtx, types.NamespacedName{Namespace: namespace, Name: "test"}, &v2.Deployment{}).Return(nil)
+					mck.EXPECT().List(testCtx, &corev1.PodList{}, client.InNamespace(namespace), client.MatchingLabels{doguv2.DoguLabelName: "test"}).Return(nil)
 					return mck
 				},
 				availabilityCheckerFn: func(t *testing.T) deploymentAvailabilityChecker {
@@ -191,7 +243,7 @@ func TestHealthCheckStep_Run(t *testing.T) {
 				},
 				doguHealthStatusUpdaterFn: func(t *testing.T) doguHealthStatusUpdater {
 					mck := newMockDoguHealthStatusUpdater(t)
-					mck.EXPECT().UpdateHealthConfigMap(testCtx, &v2.Deployment{}, &cesappcore.Dogu{}).Return(nil)
+					mck.EXPECT().UpdateHealthConfigMap(testCtx, mock.Anything, &v2.Deployment{}, &cesappcore.Dogu{}).Return(nil)
 					return mck
 				},
 				doguFetcherFn: func(t *testing.T) localDoguFetcher {
@@ -209,6 +261,14 @@ func TestHealthCheckStep_Run(t *testing.T) {
 					}, mock.Anything, v1.UpdateOptions{}).Return(nil, assert.AnError)
 					return mck
 				},
+				doguHealthCheckerFn: func(t *testing.T) doguHealthChecker {
+					mck := newMockDoguHealthChecker(t)
+					mck.EXPECT().CheckMandatoryDependenciesRecursive(testCtx, &cesappcore.Dogu{}, namespace).Return(nil)
+					return mck
+				},
+				waitListFn: func(t *testing.T) doguWaitList {
+					return newMockDoguWaitList(t)
+				},
 			},
 			doguResource: &doguv2.Dogu{
 				ObjectMeta: v1.ObjectMeta{
@@ -218,12 +278,156 @@ func TestHealthCheckStep_Run(t *testing.T) {
 			},
 			want: steps.RequeueWithError(assert.AnError),
 		},
+		{
+			name: "should fail to list dogu pods",
+			fields: fields{
+				clientFn: func(t *testing.T) k8sClient {
+					mck := newMockK8sClient(t)
+					mck.EXPECT().Get(testCtx, types.NamespacedName{Namespace: namespace, Name: "test"}, &v2.Deployment{}).Return(nil)
+					mck.EXPECT().List(testCtx, &corev1.PodList{}, client.InNamespace(namespace), client.MatchingLabels{doguv2.DoguLabelName: "test"}).Return(assert.AnError)
+					return mck
+				},
+				availabilityCheckerFn: func(t *testing.T) deploymentAvailabilityChecker {
+					mck := newMockDeploymentAvailabilityChecker(t)
+					mck.EXPECT().IsAvailable(&v2.Deployment{}).Return(true)
+					return mck
+				},
+				doguHealthStatusUpdaterFn: func(t *testing.T) doguHealthStatusUpdater {
+					mck := newMockDoguHealthStatusUpdater(t)
+					mck.EXPECT().UpdateHealthConfigMap(testCtx, mock.Anything, &v2.Deployment{}, &cesappcore.Dogu{}).Return(nil)
+					return mck
+				},
+				doguFetcherFn: func(t *testing.T) localDoguFetcher {
+					mck := newMockLocalDoguFetcher(t)
+					mck.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("")).Return(&cesappcore.Dogu{}, nil)
+					return mck
+				},
+				doguInterfaceFn: func(t *testing.T) doguInterface {
+					return newMockDoguInterface(t)
+				},
+				doguHealthCheckerFn: func(t *testing.T) doguHealthChecker {
+					return newMockDoguHealthChecker(t)
+				},
+				waitListFn: func(t *testing.T) doguWaitList {
+					return newMockDoguWaitList(t)
+				},
+			},
+			doguResource: &doguv2.Dogu{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: namespace,
+					Name:      "test",
+				},
+			},
+			want: steps.RequeueWithError(fmt.Errorf("failed to list dogu pods: %w", assert.AnError)),
+		},
+		{
+			name: "should set dogu unhealthy if pod is not ready",
+			fields: fields{
+				clientFn: func(t *testing.T) k8sClient {
+					mck := newMockK8sClient(t)
+					mck.EXPECT().Get(testCtx, types.NamespacedName{Namespace: namespace, Name: "test"}, &v2.Deployment{}).Return(nil)
+					mck.EXPECT().List(testCtx, &corev1.PodList{}, client.InNamespace(namespace), client.MatchingLabels{doguv2.DoguLabelName: "test"}).
+						Run(func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) {
+							list.(*corev1.PodList).Items = []corev1.Pod{terminatingPod, readyPod, notReadyPod}
+						}).Return(nil)
+					return mck
+				},
+				availabilityCheckerFn: func(t *testing.T) deploymentAvailabilityChecker {
+					mck := newMockDeploymentAvailabilityChecker(t)
+					mck.EXPECT().IsAvailable(&v2.Deployment{}).Return(true)
+					return mck
+				},
+				doguHealthStatusUpdaterFn: func(t *testing.T) doguHealthStatusUpdater {
+					mck := newMockDoguHealthStatusUpdater(t)
+					mck.EXPECT().UpdateHealthConfigMap(testCtx, mock.Anything, &v2.Deployment{}, &cesappcore.Dogu{}).Return(nil)
+					return mck
+				},
+				doguFetcherFn: func(t *testing.T) localDoguFetcher {
+					mck := newMockLocalDoguFetcher(t)
+					mck.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("")).Return(&cesappcore.Dogu{}, nil)
+					return mck
+				},
+				doguInterfaceFn: func(t *testing.T) doguInterface {
+					mck := newMockDoguInterface(t)
+					doguCr := &doguv2.Dogu{
+						ObjectMeta: v1.ObjectMeta{
+							Namespace: namespace,
+							Name:      "test",
+						},
+					}
+					mck.EXPECT().UpdateStatusWithRetry(testCtx, doguCr, mock.Anything, v1.UpdateOptions{}).Run(func(ctx context.Context, dogu *doguv2.Dogu, modifyStatusFn func(doguv2.DoguStatus) doguv2.DoguStatus, opts v1.UpdateOptions) {
+						status := modifyStatusFn(doguCr.Status)
+						assert.Equal(t, doguv2.UnavailableHealthStatus, status.Health)
+						gomega.NewWithT(t).Expect(status.Conditions).
+							To(conditions.MatchConditions([]v1.Condition{
+								{
+									Type:    doguv2.ConditionHealthy,
+									Status:  v1.ConditionFalse,
+									Reason:  "DoguIsNotReady",
+									Message: "Pod test-2 is not ready: containers with unready status: [test]",
+								},
+								{
+									Type:    health.ConditionDegraded,
+									Status:  v1.ConditionFalse,
+									Reason:  "DependenciesHealthy",
+									Message: "All mandatory dependencies are healthy",
+								},
+								{
+									Type:    health.ConditionContainersFailing,
+									Status:  v1.ConditionFalse,
+									Reason:  "ContainersRunning",
+									Message: "No container is crash-looping or terminated abnormally",
+								},
+							}, conditions.IgnoreLastTransitionTime(true)))
+					}).Return(doguCr, nil).Once()
+					mck.EXPECT().UpdateStatusWithRetry(testCtx, doguCr, mock.Anything, v1.UpdateOptions{}).Run(func(ctx context.Context, dogu *doguv2.Dogu, modifyStatusFn func(doguv2.DoguStatus) doguv2.DoguStatus, opts v1.UpdateOptions) {
+						status := modifyStatusFn(doguCr.Status)
+						gomega.NewWithT(t).Expect(status.Conditions).
+							To(conditions.MatchConditions([]v1.Condition{
+								{
+									Type:    health.ConditionFlapping,
+									Status:  v1.ConditionFalse,
+									Reason:  "HealthStable",
+									Message: "1 health transitions within 1h0m0s (threshold 4)",
+								},
+							}, conditions.IgnoreLastTransitionTime(true)))
+					}).Return(doguCr, nil)
+					return mck
+				},
+				doguHealthCheckerFn: func(t *testing.T) doguHealthChecker {
+					mck := newMockDoguHealthChecker(t)
+					mck.EXPECT().CheckMandatoryDependenciesRecursive(testCtx, &cesappcore.Dogu{}, namespace).Return(nil)
+					return mck
+				},
+				waitListFn: func(t *testing.T) doguWaitList {
+					return newMockDoguWaitList(t)
+				},
+				healthHistoryFn: func(t *testing.T) doguHealthHistory {
+					mck := newMockDoguHealthHistory(t)
+					mck.EXPECT().Record(testCtx, mock.Anything, mock.MatchedBy(func(transition healthhistory.Transition) bool {
+						return transition.From == "" && transition.To == doguv2.UnavailableHealthStatus && transition.Reason == "DoguIsNotReady"
+					})).Return(healthhistory.Evaluation{Flapping: false, Transitions: 1, Threshold: 4, Window: time.Hour}, nil)
+					return mck
+				},
+			},
+			doguResource: &doguv2.Dogu{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: namespace,
+					Name:      "test",
+				},
+			},
+			want: steps.Continue(),
+		},
 		{
 			name: "should succeed to update dogu resource",
 			fields: fields{
 				clientFn: func(t *testing.T) k8sClient {
 					mck := newMockK8sClient(t)
 					mck.EXPECT().Get(testCtx, types.NamespacedName{Namespace: namespace, Name: "test"}, &v2.Deployment{}).Return(nil)
+					mck.EXPECT().List(testCtx, &corev1.PodList{}, client.InNamespace(namespace), client.MatchingLabels{doguv2.DoguLabelName: "test"}).
+						Run(func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) {
+							list.(*corev1.PodList).Items = []corev1.Pod{readyPod}
+						}).Return(nil)
 					return mck
 				},
 				availabilityCheckerFn: func(t *testing.T) deploymentAvailabilityChecker {
@@ -233,7 +437,7 @@ func TestHealthCheckStep_Run(t *testing.T) {
 				},
 				doguHealthStatusUpdaterFn: func(t *testing.T) doguHealthStatusUpdater {
 					mck := newMockDoguHealthStatusUpdater(t)
-					mck.EXPECT().UpdateHealthConfigMap(testCtx, &v2.Deployment{}, &cesappcore.Dogu{}).Return(nil)
+					mck.EXPECT().UpdateHealthConfigMap(testCtx, mock.Anything, &v2.Deployment{}, &cesappcore.Dogu{}).Return(nil)
 					return mck
 				},
 				doguFetcherFn: func(t *testing.T) localDoguFetcher {
@@ -258,12 +462,158 @@ func TestHealthCheckStep_Run(t *testing.T) {
 									Type:    doguv2.ConditionHealthy,
 									Status:  v1.ConditionTrue,
 									Reason:  "DoguIsHealthy",
-									Message: "All replicas are available",
+									Message: "All replicas are available and ready",
+								},
+								{
+									Type:    health.ConditionDegraded,
+									Status:  v1.ConditionFalse,
+									Reason:  "DependenciesHealthy",
+									Message: "All mandatory dependencies are healthy",
+								},
+								{
+									Type:    health.ConditionContainersFailing,
+									Status:  v1.ConditionFalse,
+									Reason:  "ContainersRunning",
+									Message: "No container is crash-looping or terminated abnormally",
+								},
+							}, conditions.IgnoreLastTransitionTime(true)))
+					}).Return(doguCr, nil).Once()
+					mck.EXPECT().UpdateStatusWithRetry(testCtx, doguCr, mock.Anything, v1.UpdateOptions{}).Run(func(ctx context.Context, dogu *doguv2.Dogu, modifyStatusFn func(doguv2.DoguStatus) doguv2.DoguStatus, opts v1.UpdateOptions) {
+						status := modifyStatusFn(doguCr.Status)
+						gomega.NewWithT(t).Expect(status.Conditions).
+							To(conditions.MatchConditions([]v1.Condition{
+								{
+									Type:    health.ConditionFlapping,
+									Status:  v1.ConditionTrue,
+									Reason:  "HealthFlapping",
+									Message: "4 health transitions within 1h0m0s (threshold 4)",
+								},
+							}, conditions.IgnoreLastTransitionTime(true)))
+					}).Return(doguCr, nil)
+					return mck
+				},
+				doguHealthCheckerFn: func(t *testing.T) doguHealthChecker {
+					mck := newMockDoguHealthChecker(t)
+					mck.EXPECT().CheckMandatoryDependenciesRecursive(testCtx, &cesappcore.Dogu{}, namespace).Return(nil)
+					return mck
+				},
+				waitListFn: func(t *testing.T) doguWaitList {
+					mck := newMockDoguWaitList(t)
+					mck.EXPECT().DependencyAvailable(types.NamespacedName{Namespace: namespace, Name: "test"}).Return([]types.NamespacedName{{Namespace: namespace, Name: "redmine"}})
+					return mck
+				},
+				healthHistoryFn: func(t *testing.T) doguHealthHistory {
+					mck := newMockDoguHealthHistory(t)
+					mck.EXPECT().Record(testCtx, mock.Anything, mock.MatchedBy(func(transition healthhistory.Transition) bool {
+						return transition.From == "" && transition.To == doguv2.AvailableHealthStatus && transition.Reason == "DoguIsHealthy"
+					})).Return(healthhistory.Evaluation{Flapping: true, Transitions: 4, Threshold: 4, Window: time.Hour}, nil)
+					return mck
+				},
+				recorderFn: func(t *testing.T) eventRecorder {
+					mck := newMockEventRecorder(t)
+					mck.EXPECT().Eventf(mock.Anything, corev1.EventTypeWarning, "HealthFlapping", "The health of the dogu is flapping: %s", "4 health transitions within 1h0m0s (threshold 4)")
+					return mck
+				},
+			},
+			doguResource: &doguv2.Dogu{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: namespace,
+					Name:      "test",
+				},
+			},
+			want: steps.Continue(),
+		},
+		{
+			name: "should set dogu degraded if a mandatory dependency is unhealthy",
+			fields: fields{
+				clientFn: func(t *testing.T) k8sClient {
+					mck := newMockK8sClient(t)
+					mck.EXPECT().Get(testCtx, types.NamespacedName{Namespace: namespace, Name: "test"}, &v2.Deployment{}).Return(nil)
+					mck.EXPECT().List(testCtx, &corev1.PodList{}, client.InNamespace(namespace), client.MatchingLabels{doguv2.DoguLabelName: "test"}).
+						Run(func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) {
+							list.(*corev1.PodList).Items = []corev1.Pod{readyPod}
+						}).Return(nil)
+					return mck
+				},
+				availabilityCheckerFn: func(t *testing.T) deploymentAvailabilityChecker {
+					mck := newMockDeploymentAvailabilityChecker(t)
+					mck.EXPECT().IsAvailable(&v2.Deployment{}).Return(true)
+					return mck
+				},
+				doguHealthStatusUpdaterFn: func(t *testing.T) doguHealthStatusUpdater {
+					mck := newMockDoguHealthStatusUpdater(t)
+					mck.EXPECT().UpdateHealthConfigMap(testCtx, mock.Anything, &v2.Deployment{}, &cesappcore.Dogu{}).Return(nil)
+					return mck
+				},
+				doguFetcherFn: func(t *testing.T) localDoguFetcher {
+					mck := newMockLocalDoguFetcher(t)
+					mck.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("")).Return(&cesappcore.Dogu{}, nil)
+					return mck
+				},
+				doguInterfaceFn: func(t *testing.T) doguInterface {
+					mck := newMockDoguInterface(t)
+					doguCr := &doguv2.Dogu{
+						ObjectMeta: v1.ObjectMeta{
+							Namespace: namespace,
+							Name:      "test",
+						},
+					}
+					mck.EXPECT().UpdateStatusWithRetry(testCtx, doguCr, mock.Anything, v1.UpdateOptions{}).Run(func(ctx context.Context, dogu *doguv2.Dogu, modifyStatusFn func(doguv2.DoguStatus) doguv2.DoguStatus, opts v1.UpdateOptions) {
+						status := modifyStatusFn(doguCr.Status)
+						assert.Equal(t, health.DegradedHealthStatus, status.Health)
+						gomega.NewWithT(t).Expect(status.Conditions).
+							To(conditions.MatchConditions([]v1.Condition{
+								{
+									Type:    doguv2.ConditionHealthy,
+									Status:  v1.ConditionTrue,
+									Reason:  "DoguIsHealthy",
+									Message: "All replicas are available and ready",
+								},
+								{
+									Type:    health.ConditionDegraded,
+									Status:  v1.ConditionTrue,
+									Reason:  "DependencyUnhealthy",
+									Message: assert.AnError.Error(),
+								},
+								{
+									Type:    health.ConditionContainersFailing,
+									Status:  v1.ConditionFalse,
+									Reason:  "ContainersRunning",
+									Message: "No container is crash-looping or terminated abnormally",
+								},
+							}, conditions.IgnoreLastTransitionTime(true)))
+					}).Return(doguCr, nil).Once()
+					mck.EXPECT().UpdateStatusWithRetry(testCtx, doguCr, mock.Anything, v1.UpdateOptions{}).Run(func(ctx context.Context, dogu *doguv2.Dogu, modifyStatusFn func(doguv2.DoguStatus) doguv2.DoguStatus, opts v1.UpdateOptions) {
+						status := modifyStatusFn(doguCr.Status)
+						gomega.NewWithT(t).Expect(status.Conditions).
+							To(conditions.MatchConditions([]v1.Condition{
+								{
+									Type:    health.ConditionFlapping,
+									Status:  v1.ConditionFalse,
+									Reason:  "HealthStable",
+									Message: "1 health transitions within 1h0m0s (threshold 4)",
 								},
 							}, conditions.IgnoreLastTransitionTime(true)))
 					}).Return(doguCr, nil)
 					return mck
 				},
+				doguHealthCheckerFn: func(t *testing.T) doguHealthChecker {
+					mck := newMockDoguHealthChecker(t)
+					mck.EXPECT().CheckMandatoryDependenciesRecursive(testCtx, &cesappcore.Dogu{}, namespace).Return(assert.AnError)
+					return mck
+				},
+				waitListFn: func(t *testing.T) doguWaitList {
+					mck := newMockDoguWaitList(t)
+					mck.EXPECT().DependencyAvailable(types.NamespacedName{Namespace: namespace, Name: "test"}).Return(nil)
+					return mck
+				},
+				healthHistoryFn: func(t *testing.T) doguHealthHistory {
+					mck := newMockDoguHealthHistory(t)
+					mck.EXPECT().Record(testCtx, mock.Anything, mock.MatchedBy(func(transition healthhistory.Transition) bool {
+						return transition.From == "" && transition.To == health.DegradedHealthStatus && transition.Reason == "DependencyUnhealthy"
+					})).Return(healthhistory.Evaluation{Flapping: false, Transitions: 1, Threshold: 4, Window: time.Hour}, nil)
+					return mck
+				},
 			},
 			doguResource: &doguv2.Dogu{
 				ObjectMeta: v1.ObjectMeta{
@@ -282,8 +632,194 @@ func TestHealthCheckStep_Run(t *testing.T) {
 				doguHealthStatusUpdater: tt.fields.doguHealthStatusUpdaterFn(t),
 				doguFetcher:             tt.fields.doguFetcherFn(t),
 				doguInterface:           tt.fields.doguInterfaceFn(t),
+				doguHealthChecker:       tt.fields.doguHealthCheckerFn(t),
+				waitList:                tt.fields.waitListFn(t),
+				healthHistory:           newMockDoguHealthHistory(t),
+				recorder:                newMockEventRecorder(t),
+			}
+			if tt.fields.healthHistoryFn != nil {
+				hcs.healthHistory = tt.fields.healthHistoryFn(t)
+			}
+			if tt.fields.recorderFn != nil {
+				hcs.recorder = tt.fields.recorderFn(t)
 			}
 			assert.Equalf(t, tt.want, hcs.Run(testCtx, tt.doguResource), "Run(%v, %v)", testCtx, tt.doguResource)
 		})
 	}
 }
+
+func TestHealthCheckStep_checkFlapping(t *testing.T) {
+	flappingCondition := v1.Condition{
+		Type:    health.ConditionFlapping,
+		Status:  v1.ConditionTrue,
+		Reason:  health.ReasonHealthFlapping,
+		Message: "4 health transitions within 1h0m0s (threshold 4)",
+	}
+
+	t.Run("should not evaluate stable dogu without transition", func(t *testing.T) {
+		// given
+		sut := &HealthCheckStep{healthHistory: newMockDoguHealthHistory(t), doguInterface: newMockDoguInterface(t)}
+
+		// when
+		err := sut.checkFlapping(testCtx, &doguv2.Dogu{}, nil)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should reset flapping condition after transitions left the window", func(t *testing.T) {
+		// given
+		doguCr := &doguv2.Dogu{
+			ObjectMeta: v1.ObjectMeta{Namespace: namespace, Name: "test"},
+			Status:     doguv2.DoguStatus{Conditions: []v1.Condition{flappingCondition}},
+		}
+		historyMock := newMockDoguHealthHistory(t)
+		historyMock.EXPECT().Evaluate(testCtx, doguCr).Return(healthhistory.Evaluation{Transitions: 3, Threshold: 4, Window: time.Hour}, nil)
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().UpdateStatusWithRetry(testCtx, doguCr, mock.Anything, v1.UpdateOptions{}).Run(func(ctx context.Context, dogu *doguv2.Dogu, modifyStatusFn func(doguv2.DoguStatus) doguv2.DoguStatus, opts v1.UpdateOptions) {
+			status := modifyStatusFn(doguCr.Status)
+			condition := meta.FindStatusCondition(status.Conditions, health.ConditionFlapping)
+			require.NotNil(t, condition)
+			assert.Equal(t, v1.ConditionFalse, condition.Status)
+			assert.Equal(t, health.ReasonHealthStable, condition.Reason)
+			assert.Equal(t, "3 health transitions within 1h0m0s (threshold 4)", condition.Message)
+		}).Return(doguCr, nil)
+		sut := &HealthCheckStep{healthHistory: historyMock, doguInterface: doguInterfaceMock, recorder: newMockEventRecorder(t)}
+
+		// when
+		err := sut.checkFlapping(testCtx, doguCr, nil)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should not update unchanged flapping condition", func(t *testing.T) {
+		// given
+		doguCr := &doguv2.Dogu{Status: doguv2.DoguStatus{Conditions: []v1.Condition{flappingCondition}}}
+		historyMock := newMockDoguHealthHistory(t)
+		historyMock.EXPECT().Evaluate(testCtx, doguCr).Return(healthhistory.Evaluation{Flapping: true, Transitions: 4, Threshold: 4, Window: time.Hour}, nil)
+		sut := &HealthCheckStep{healthHistory: historyMock, doguInterface: newMockDoguInterface(t), recorder: newMockEventRecorder(t)}
+
+		// when
+		err := sut.checkFlapping(testCtx, doguCr, nil)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should ignore failing health history", func(t *testing.T) {
+		// given
+		transition := healthhistory.NewTransition(time.Now(), doguv2.AvailableHealthStatus, doguv2.UnavailableHealthStatus, ReasonDoguNotHealthy, "")
+		historyMock := newMockDoguHealthHistory(t)
+		historyMock.EXPECT().Record(testCtx, mock.Anything, transition).Return(healthhistory.Evaluation{}, assert.AnError)
+		sut := &HealthCheckStep{healthHistory: historyMock, doguInterface: newMockDoguInterface(t)}
+
+		// when
+		err := sut.checkFlapping(testCtx, &doguv2.Dogu{}, &transition)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail to update flapping condition", func(t *testing.T) {
+		// given
+		transition := healthhistory.NewTransition(time.Now(), doguv2.AvailableHealthStatus, doguv2.UnavailableHealthStatus, ReasonDoguNotHealthy, "")
+		historyMock := newMockDoguHealthHistory(t)
+		historyMock.EXPECT().Record(testCtx, mock.Anything, transition).Return(healthhistory.Evaluation{Transitions: 1, Threshold: 4, Window: time.Hour}, nil)
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().UpdateStatusWithRetry(testCtx, mock.Anything, mock.Anything, v1.UpdateOptions{}).Return(nil, assert.AnError)
+		sut := &HealthCheckStep{healthHistory: historyMock, doguInterface: doguInterfaceMock}
+
+		// when
+		err := sut.checkFlapping(testCtx, &doguv2.Dogu{}, &transition)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestHealthCheckStep_Run_containersFailing(t *testing.T) {
+	oomKilledPod := corev1.Pod{
+		ObjectMeta: v1.ObjectMeta{Name: "test-1"},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			Name:                 "test",
+			State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}},
+			RestartCount:         4,
+		}}},
+	}
+	expectedMessage := "container test of pod test-1: OOMKilled (exit code 137, 4 restarts). " + health.OOMKilledHint
+	newSut := func(t *testing.T, doguCr *doguv2.Dogu, recorder eventRecorder) *HealthCheckStep {
+		clientMock := newMockK8sClient(t)
+		clientMock.EXPECT().Get(testCtx, types.NamespacedName{Namespace: namespace, Name: "test"}, &v2.Deployment{}).Return(nil)
+		clientMock.EXPECT().List(testCtx, &corev1.PodList{}, client.InNamespace(namespace), client.MatchingLabels{doguv2.DoguLabelName: "test"}).
+			Run(func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) {
+				list.(*corev1.PodList).Items = []corev1.Pod{oomKilledPod}
+			}).Return(nil)
+		availabilityMock := newMockDeploymentAvailabilityChecker(t)
+		availabilityMock.EXPECT().IsAvailable(&v2.Deployment{}).Return(false)
+		statusUpdaterMock := newMockDoguHealthStatusUpdater(t)
+		statusUpdaterMock.EXPECT().UpdateHealthConfigMap(testCtx, mock.Anything, &v2.Deployment{}, &cesappcore.Dogu{}).Return(nil)
+		fetcherMock := newMockLocalDoguFetcher(t)
+		fetcherMock.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("")).Return(&cesappcore.Dogu{}, nil)
+		healthCheckerMock := newMockDoguHealthChecker(t)
+		healthCheckerMock.EXPECT().CheckMandatoryDependenciesRecursive(testCtx, &cesappcore.Dogu{}, namespace).Return(nil)
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().UpdateStatusWithRetry(testCtx, doguCr, mock.Anything, v1.UpdateOptions{}).
+			RunAndReturn(func(ctx context.Context, dogu *doguv2.Dogu, modifyStatusFn func(doguv2.DoguStatus) doguv2.DoguStatus, opts v1.UpdateOptions) (*doguv2.Dogu, error) {
+				updated := dogu.DeepCopy()
+				updated.Status = modifyStatusFn(dogu.Status)
+				return updated, nil
+			})
+
+		return &HealthCheckStep{
+			client:                  clientMock,
+			availabilityChecker:     availabilityMock,
+			doguHealthStatusUpdater: statusUpdaterMock,
+			doguFetcher:             fetcherMock,
+			doguInterface:           doguInterfaceMock,
+			doguHealthChecker:       healthCheckerMock,
+			waitList:                newMockDoguWaitList(t),
+			healthHistory:           newMockDoguHealthHistory(t),
+			recorder:                recorder,
+		}
+	}
+
+	t.Run("should report oom killed container in condition and event", func(t *testing.T) {
+		// given
+		doguCr := &doguv2.Dogu{
+			ObjectMeta: v1.ObjectMeta{Namespace: namespace, Name: "test"},
+			Status:     doguv2.DoguStatus{Health: doguv2.UnavailableHealthStatus},
+		}
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Event(mock.Anything, corev1.EventTypeWarning, health.ReasonOOMKilled, expectedMessage)
+		sut := newSut(t, doguCr, recorderMock)
+
+		// when
+		result := sut.Run(testCtx, doguCr)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+		condition := meta.FindStatusCondition(doguCr.Status.Conditions, health.ConditionContainersFailing)
+		require.NotNil(t, condition)
+		assert.Equal(t, v1.ConditionTrue, condition.Status)
+		assert.Equal(t, health.ReasonOOMKilled, condition.Reason)
+		assert.Equal(t, expectedMessage, condition.Message)
+	})
+	t.Run("should not record event again for the same cause", func(t *testing.T) {
+		// given
+		doguCr := &doguv2.Dogu{
+			ObjectMeta: v1.ObjectMeta{Namespace: namespace, Name: "test"},
+			Status: doguv2.DoguStatus{Health: doguv2.UnavailableHealthStatus, Conditions: []v1.Condition{{
+				Type:    health.ConditionContainersFailing,
+				Status:  v1.ConditionTrue,
+				Reason:  health.ReasonOOMKilled,
+				Message: "container test of pod test-1: OOMKilled (exit code 137, 3 restarts)",
+			}}},
+		}
+		sut := newSut(t, doguCr, newMockEventRecorder(t))
+
+		// when
+		result := sut.Run(testCtx, doguCr)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+		assert.Equal(t, expectedMessage, meta.FindStatusCondition(doguCr.Status.Conditions, health.ConditionContainersFailing).Message)
+	})
+}
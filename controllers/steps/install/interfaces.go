@@ -8,10 +8,13 @@ import (
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/cesregistry"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/coordination"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/dependency"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/exec"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/health"
-	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/resource"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/healthhistory"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/volumesnapshot"
 	"github.com/cloudogu/k8s-registry-lib/config"
 	"github.com/cloudogu/k8s-registry-lib/repository"
 	imagev1 "github.com/google/go-containerregistry/pkg/v1"
@@ -19,6 +22,7 @@ import (
 	coreV1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	appsv1client "k8s.io/client-go/kubernetes/typed/apps/v1"
 	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -49,6 +53,21 @@ type localDoguFetcher interface {
 	cesregistry.LocalDoguFetcher
 }
 
+// doguLocker coordinates the concurrent reconciles of a dogu and its dependencies.
+type doguLocker interface {
+	coordination.Locker
+}
+
+// doguWaitList tracks dogus which wait for their dependencies to become available.
+type doguWaitList interface {
+	coordination.WaitList
+}
+
+// doguHealthHistory keeps the health transitions of dogus and detects flapping dogus.
+type doguHealthHistory interface {
+	healthhistory.History
+}
+
 // resourceDoguFetcher includes functionality to get a dogu either from the remote dogu registry or from a local development dogu map.
 type resourceDoguFetcher interface {
 	// FetchWithResource fetches the dogu either from the remote dogu registry or from a local development dogu map and
@@ -82,8 +101,9 @@ type netPolUpserter interface {
 	UpsertDoguNetworkPolicies(ctx context.Context, doguResource *v2.Dogu, dogu *cesappcore.Dogu, doguService *coreV1.Service) error
 }
 
-type serviceGenerator interface {
-	resource.DoguResourceGenerator
+type serviceUpserter interface {
+	// UpsertDoguService generates a service for a given dogu and applies it to the cluster.
+	UpsertDoguService(ctx context.Context, doguResource *v2.Dogu, dogu *cesappcore.Dogu, image *imagev1.ConfigFile) (*coreV1.Service, error)
 }
 
 // imageRegistry abstracts the use of a container registry and includes functionality to pull container images.
@@ -150,8 +170,9 @@ type deploymentAvailabilityChecker interface {
 }
 
 type doguHealthStatusUpdater interface {
-	UpdateHealthConfigMap(ctx context.Context, deployment *apps.Deployment, doguJson *cesappcore.Dogu) error
+	UpdateHealthConfigMap(ctx context.Context, doguResource *v2.Dogu, deployment *apps.Deployment, doguJson *cesappcore.Dogu) error
 	DeleteDoguOutOfHealthConfigMap(ctx context.Context, dogu *v2.Dogu) error
+	MigrateLegacyHealthConfigMap(ctx context.Context, namespace string, dogus []v2.Dogu) error
 }
 
 type serviceAccountCreator interface {
@@ -179,6 +200,10 @@ type dependencyValidator interface {
 	ValidateDependencies(ctx context.Context, dogu *cesappcore.Dogu) error
 }
 
+type reverseDependencyValidator interface {
+	dependency.ReverseDependencyValidator
+}
+
 // doguHealthChecker includes functionality to check if the dogu described by the resource is up and running.
 type doguHealthChecker interface {
 	health.DoguHealthChecker
@@ -188,6 +213,19 @@ type persistentVolumeClaimInterface interface {
 	v1.PersistentVolumeClaimInterface
 }
 
+type deploymentInterface interface {
+	appsv1client.DeploymentInterface
+}
+
+type dataVolumeGenerator interface {
+	// CreateDoguPVC creates a persistent volume claim with a 5Gi storage for the given dogu.
+	CreateDoguPVC(doguResource *v2.Dogu) (*coreV1.PersistentVolumeClaim, error)
+}
+
+type volumeSnapshotter interface {
+	volumesnapshot.Snapshotter
+}
+
 type execPodFactory interface {
 	exec.ExecPodFactory
 }
@@ -0,0 +1,59 @@
+package install
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudogu/cesapp-lib/core"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/cesregistry"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/coordination"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const requeueAfterDependenciesLocked = 5 * time.Second
+
+// The LockDependenciesStep locks the dogu dependencies of the dogu for the rest of the reconcile, so that they are not
+// changed while the dogu e.g. creates service accounts in them. Dependencies which are being reconciled themselves
+// cause a requeue. The locks are released by the reconciler when the reconcile ends.
+type LockDependenciesStep struct {
+	localDoguFetcher localDoguFetcher
+	locker           doguLocker
+}
+
+func NewLockDependenciesStep(fetcher cesregistry.LocalDoguFetcher, locker coordination.Locker) *LockDependenciesStep {
+	return &LockDependenciesStep{
+		localDoguFetcher: fetcher,
+		locker:           locker,
+	}
+}
+
+func (lds *LockDependenciesStep) Run(ctx context.Context, doguResource *v2.Dogu) steps.StepResult {
+	dogu, err := lds.localDoguFetcher.FetchForResource(ctx, doguResource)
+	if err != nil {
+		return steps.RequeueWithError(fmt.Errorf("failed to fetch dogu descriptor for %q: %w", doguResource.Name, err))
+	}
+
+	blocked := lds.locker.TryLockDependencies(doguResource.GetObjectKey(), dependencyNames(dogu))
+	if len(blocked) > 0 {
+		log.FromContext(ctx).Info("Dependencies are being reconciled; requeueing", "dependencies", blocked, "requeueAfter", requeueAfterDependenciesLocked)
+		return steps.RequeueAfter(requeueAfterDependenciesLocked)
+	}
+
+	return steps.Continue()
+}
+
+// dependencyNames returns the names of the mandatory and optional dogu dependencies of the dogu.
+func dependencyNames(dogu *core.Dogu) []string {
+	var dependencies []string
+	for _, dependency := range dogu.GetDependenciesOfType(core.DependencyTypeDogu) {
+		dependencies = append(dependencies, dependency.Name)
+	}
+	for _, dependency := range dogu.GetOptionalDependenciesOfType(core.DependencyTypeDogu) {
+		dependencies = append(dependencies, dependency.Name)
+	}
+
+	return dependencies
+}
@@ -0,0 +1,78 @@
+package install
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudogu/cesapp-lib/core"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestNewLockDependenciesStep(t *testing.T) {
+	t.Run("Successfully created step", func(t *testing.T) {
+		step := NewLockDependenciesStep(newMockLocalDoguFetcher(t), newMockDoguLocker(t))
+
+		assert.NotNil(t, step)
+	})
+}
+
+func TestLockDependenciesStep_Run(t *testing.T) {
+	ctx := context.TODO()
+	doguResource := &v2.Dogu{ObjectMeta: v1.ObjectMeta{Name: "redmine", Namespace: "ecosystem"}}
+	redmine := types.NamespacedName{Name: "redmine", Namespace: "ecosystem"}
+	dogu := &core.Dogu{
+		Name: "official/redmine",
+		Dependencies: []core.Dependency{
+			{Type: core.DependencyTypeDogu, Name: "postgresql"},
+			{Type: core.DependencyTypeClient, Name: "k8s-dogu-operator"},
+		},
+		OptionalDependencies: []core.Dependency{
+			{Type: core.DependencyTypeDogu, Name: "cas"},
+		},
+	}
+
+	t.Run("should fail to fetch dogu descriptor", func(t *testing.T) {
+		// given
+		fetcherMock := newMockLocalDoguFetcher(t)
+		fetcherMock.EXPECT().FetchForResource(ctx, doguResource).Return(nil, assert.AnError)
+		sut := NewLockDependenciesStep(fetcherMock, newMockDoguLocker(t))
+
+		// when
+		result := sut.Run(ctx, doguResource)
+
+		// then
+		assert.ErrorIs(t, result.Err, assert.AnError)
+	})
+	t.Run("should requeue if dependencies are being reconciled", func(t *testing.T) {
+		// given
+		fetcherMock := newMockLocalDoguFetcher(t)
+		fetcherMock.EXPECT().FetchForResource(ctx, doguResource).Return(dogu, nil)
+		lockerMock := newMockDoguLocker(t)
+		lockerMock.EXPECT().TryLockDependencies(redmine, []string{"postgresql", "cas"}).Return([]string{"postgresql"})
+		sut := NewLockDependenciesStep(fetcherMock, lockerMock)
+
+		// when
+		result := sut.Run(ctx, doguResource)
+
+		// then
+		assert.Equal(t, steps.RequeueAfter(requeueAfterDependenciesLocked), result)
+	})
+	t.Run("should continue if all dependencies are locked", func(t *testing.T) {
+		// given
+		fetcherMock := newMockLocalDoguFetcher(t)
+		fetcherMock.EXPECT().FetchForResource(ctx, doguResource).Return(dogu, nil)
+		lockerMock := newMockDoguLocker(t)
+		lockerMock.EXPECT().TryLockDependencies(redmine, []string{"postgresql", "cas"}).Return(nil)
+		sut := NewLockDependenciesStep(fetcherMock, lockerMock)
+
+		// when
+		result := sut.Run(ctx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	context "context"
 
 	core "github.com/cloudogu/cesapp-lib/core"
+
 	mock "github.com/stretchr/testify/mock"
 
 	types "k8s.io/apimachinery/pkg/types"
@@ -119,6 +120,54 @@ func (_c *mockDoguHealthChecker_CheckDependenciesRecursive_Call) RunAndReturn(ru
 	return _c
 }
 
+// CheckMandatoryDependenciesRecursive provides a mock function with given fields: ctx, fromDogu, namespace
+func (_m *mockDoguHealthChecker) CheckMandatoryDependenciesRecursive(ctx context.Context, fromDogu *core.Dogu, namespace string) error {
+	ret := _m.Called(ctx, fromDogu, namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckMandatoryDependenciesRecursive")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.Dogu, string) error); ok {
+		r0 = rf(ctx, fromDogu, namespace)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockDoguHealthChecker_CheckMandatoryDependenciesRecursive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckMandatoryDependenciesRecursive'
+type mockDoguHealthChecker_CheckMandatoryDependenciesRecursive_Call struct {
+	*mock.Call
+}
+
+// CheckMandatoryDependenciesRecursive is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fromDogu *core.Dogu
+//   - namespace string
+func (_e *mockDoguHealthChecker_Expecter) CheckMandatoryDependenciesRecursive(ctx interface{}, fromDogu interface{}, namespace interface{}) *mockDoguHealthChecker_CheckMandatoryDependenciesRecursive_Call {
+	return &mockDoguHealthChecker_CheckMandatoryDependenciesRecursive_Call{Call: _e.mock.On("CheckMandatoryDependenciesRecursive", ctx, fromDogu, namespace)}
+}
+
+func (_c *mockDoguHealthChecker_CheckMandatoryDependenciesRecursive_Call) Run(run func(ctx context.Context, fromDogu *core.Dogu, namespace string)) *mockDoguHealthChecker_CheckMandatoryDependenciesRecursive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*core.Dogu), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *mockDoguHealthChecker_CheckMandatoryDependenciesRecursive_Call) Return(_a0 error) *mockDoguHealthChecker_CheckMandatoryDependenciesRecursive_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockDoguHealthChecker_CheckMandatoryDependenciesRecursive_Call) RunAndReturn(run func(context.Context, *core.Dogu, string) error) *mockDoguHealthChecker_CheckMandatoryDependenciesRecursive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // newMockDoguHealthChecker creates a new instance of mockDoguHealthChecker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func newMockDoguHealthChecker(t interface {
@@ -6,6 +6,7 @@ import (
 
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/cesregistry"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/drift"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/resource"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
 
@@ -13,7 +14,9 @@ import (
 	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
 )
 
-// The NetworkPoliciesStep creates or updates the Network Policies based on the dogu resource
+// The NetworkPoliciesStep creates or updates the Network Policies based on the dogu resource.
+// The network policies are not applied again while their drift or the drift of the service they are generated from
+// is only reported, see drift.IsReportedOnly.
 type NetworkPoliciesStep struct {
 	netPolUpserter   netPolUpserter
 	localDoguFetcher localDoguFetcher
@@ -29,6 +32,10 @@ func NewNetworkPoliciesStep(upserter resource.ResourceUpserter, fetcher cesregis
 }
 
 func (nps *NetworkPoliciesStep) Run(ctx context.Context, doguResource *v2.Dogu) steps.StepResult {
+	if drift.IsReportedOnly(doguResource, drift.KindService, drift.KindNetworkPolicy) {
+		return steps.Continue()
+	}
+
 	dogu, err := nps.localDoguFetcher.FetchInstalled(ctx, doguResource.GetSimpleDoguName())
 	if err != nil {
 		return steps.RequeueWithError(err)
@@ -33,6 +33,22 @@ func TestNetworkPoliciesStep_Run(t *testing.T) {
 		doguResource *v2.Dogu
 		want         steps.StepResult
 	}{
+		{
+			name: "should not apply network policies again while their drift is only reported",
+			fields: fields{
+				netPolUpserterFn: func(t *testing.T) netPolUpserter {
+					return newMockNetPolUpserter(t)
+				},
+				localDoguFetcherFn: func(t *testing.T) localDoguFetcher {
+					return newMockLocalDoguFetcher(t)
+				},
+				serviceInterfaceFn: func(t *testing.T) serviceInterface {
+					return newMockServiceInterface(t)
+				},
+			},
+			doguResource: newDriftReportedDogu(),
+			want:         steps.Continue(),
+		},
 		{
 			name: "should fail to fetch dogu descriptor",
 			fields: fields{
@@ -0,0 +1,257 @@
+package install
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/cesregistry"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/resource"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/volumesnapshot"
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	appsv1 "k8s.io/client-go/kubernetes/typed/apps/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	requeueAfterRestoreVolumeSnapshot = 5 * time.Second
+	volumeRestoredEventReason         = "VolumeRestored"
+	volumeRestoreFailedEventReason    = "VolumeRestoreFailed"
+)
+
+// The RestoreVolumeSnapshotStep restores the data volume of a dogu from the volume snapshot named in the
+// volumesnapshot.RestoreAnnotation. The dogu is stopped, the data volume is deleted and created again from the volume
+// snapshot. The StartStopStep starts the dogu again afterward.
+// Only volume snapshots of the installed version are restored, unless the spec version is changed to the version of the
+// volume snapshot together with the restore request, because the data of another version does not match the dogu.
+type RestoreVolumeSnapshotStep struct {
+	client              k8sClient
+	snapshotter         volumeSnapshotter
+	localDoguFetcher    localDoguFetcher
+	generator           dataVolumeGenerator
+	deploymentInterface deploymentInterface
+	recorder            eventRecorder
+}
+
+func NewRestoreVolumeSnapshotStep(
+	client client.Client,
+	snapshotter volumesnapshot.Snapshotter,
+	fetcher cesregistry.LocalDoguFetcher,
+	generator resource.DoguResourceGenerator,
+	deploymentInterface appsv1.DeploymentInterface,
+	recorder record.EventRecorder,
+) *RestoreVolumeSnapshotStep {
+	return &RestoreVolumeSnapshotStep{
+		client:              client,
+		snapshotter:         snapshotter,
+		localDoguFetcher:    fetcher,
+		generator:           generator,
+		deploymentInterface: deploymentInterface,
+		recorder:            recorder,
+	}
+}
+
+func (rs *RestoreVolumeSnapshotStep) Run(ctx context.Context, doguResource *v2.Dogu) steps.StepResult {
+	snapshotName := doguResource.GetAnnotations()[volumesnapshot.RestoreAnnotation]
+	if snapshotName == "" {
+		return steps.Continue()
+	}
+
+	dogu, err := rs.localDoguFetcher.FetchInstalled(ctx, doguResource.GetSimpleDoguName())
+	if err != nil {
+		return steps.RequeueWithError(fmt.Errorf("failed to fetch installed dogu: %w", err))
+	}
+	if !resource.NeedsPVCs(dogu) {
+		return rs.cancel(ctx, doguResource, fmt.Sprintf("Dogu %q has no data volume to restore from volume snapshot %q.", doguResource.Name, snapshotName))
+	}
+
+	state, err := rs.snapshotter.Get(ctx, doguResource, snapshotName)
+	if apierrors.IsNotFound(err) {
+		return rs.cancel(ctx, doguResource, fmt.Sprintf("Volume snapshot %q to restore does not exist.", snapshotName))
+	}
+	if err != nil {
+		return steps.RequeueWithError(err)
+	}
+	if state.Error != "" {
+		return rs.cancel(ctx, doguResource, fmt.Sprintf("Volume snapshot %q to restore failed: %s", snapshotName, state.Error))
+	}
+	if !matchesDoguVersion(doguResource, state) {
+		return rs.cancel(ctx, doguResource, fmt.Sprintf("Volume snapshot %q was taken of version %s, but version %s is installed; set the spec version to %s to restore it.",
+			snapshotName, state.DoguVersion, doguResource.Status.InstalledVersion, state.DoguVersion))
+	}
+	if !state.ReadyToUse {
+		return steps.RequeueAfter(requeueAfterRestoreVolumeSnapshot)
+	}
+
+	requestID, err := rs.startRequest(ctx, doguResource)
+	if err != nil {
+		return steps.RequeueWithError(err)
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	err = rs.client.Get(ctx, types.NamespacedName{Namespace: doguResource.Namespace, Name: doguResource.Name}, pvc)
+	if apierrors.IsNotFound(err) {
+		return rs.createDataVolume(ctx, doguResource, state, requestID)
+	}
+	if err != nil {
+		return steps.RequeueWithError(fmt.Errorf("failed to get data volume: %w", err))
+	}
+
+	if isRestoredBy(pvc, requestID, snapshotName) {
+		return rs.finish(ctx, doguResource, state)
+	}
+
+	return rs.deleteDataVolume(ctx, doguResource, pvc)
+}
+
+// startRequest returns the ID of the restore request of the dogu. A new ID is set on the dogu when the restore starts,
+// so that a data volume restored for an earlier request of the same volume snapshot is restored again.
+func (rs *RestoreVolumeSnapshotStep) startRequest(ctx context.Context, doguResource *v2.Dogu) (string, error) {
+	requestID := doguResource.GetAnnotations()[volumesnapshot.RestoreRequestAnnotation]
+	if requestID != "" {
+		return requestID, nil
+	}
+
+	requestID = uuid.NewString()
+	patch := client.MergeFrom(doguResource.DeepCopy())
+	annotations := maps.Clone(doguResource.GetAnnotations())
+	annotations[volumesnapshot.RestoreRequestAnnotation] = requestID
+	doguResource.SetAnnotations(annotations)
+
+	err := rs.client.Patch(ctx, doguResource, patch)
+	if err != nil {
+		return "", fmt.Errorf("failed to set annotation %s: %w", volumesnapshot.RestoreRequestAnnotation, err)
+	}
+
+	return requestID, nil
+}
+
+// deleteDataVolume stops the dogu and deletes its data volume once no pod uses it anymore.
+func (rs *RestoreVolumeSnapshotStep) deleteDataVolume(ctx context.Context, doguResource *v2.Dogu, pvc *corev1.PersistentVolumeClaim) steps.StepResult {
+	if pvc.DeletionTimestamp != nil {
+		return steps.RequeueAfter(requeueAfterRestoreVolumeSnapshot)
+	}
+
+	scale, err := rs.deploymentInterface.GetScale(ctx, doguResource.Name, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return steps.RequeueWithError(fmt.Errorf("failed to get scale of deployment: %w", err))
+	}
+	if err == nil && scale.Spec.Replicas != 0 {
+		scale.Spec.Replicas = 0
+		_, err = rs.deploymentInterface.UpdateScale(ctx, doguResource.Name, scale, metav1.UpdateOptions{})
+		if err != nil {
+			return steps.RequeueWithError(fmt.Errorf("failed to stop dogu before restore: %w", err))
+		}
+	}
+
+	pods := &corev1.PodList{}
+	err = rs.client.List(ctx, pods, client.InNamespace(doguResource.Namespace), client.MatchingLabels(doguResource.GetDoguNameLabel()))
+	if err != nil {
+		return steps.RequeueWithError(fmt.Errorf("failed to list pods of dogu %q: %w", doguResource.Name, err))
+	}
+	if len(pods.Items) > 0 {
+		return steps.RequeueAfter(requeueAfterRestoreVolumeSnapshot)
+	}
+
+	log.FromContext(ctx).Info("Deleting data volume to restore it from volume snapshot", "dogu", doguResource.Name)
+	err = rs.client.Delete(ctx, pvc)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return steps.RequeueWithError(fmt.Errorf("failed to delete data volume: %w", err))
+	}
+
+	return steps.RequeueAfter(requeueAfterRestoreVolumeSnapshot)
+}
+
+func (rs *RestoreVolumeSnapshotStep) createDataVolume(ctx context.Context, doguResource *v2.Dogu, state *volumesnapshot.State, requestID string) steps.StepResult {
+	pvc, err := rs.generator.CreateDoguPVC(doguResource)
+	if err != nil {
+		return steps.RequeueWithError(err)
+	}
+
+	annotations := maps.Clone(pvc.GetAnnotations())
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[volumesnapshot.RestoreRequestAnnotation] = requestID
+	pvc.SetAnnotations(annotations)
+
+	pvc.Spec.DataSource = &corev1.TypedLocalObjectReference{
+		APIGroup: ptr.To(volumesnapshot.GroupVersionKind.Group),
+		Kind:     volumesnapshot.GroupVersionKind.Kind,
+		Name:     state.Name,
+	}
+	// the restored volume must be at least as large as the volume the snapshot was taken of
+	if state.RestoreSize != nil && state.RestoreSize.Cmp(pvc.Spec.Resources.Requests[corev1.ResourceStorage]) > 0 {
+		pvc.Spec.Resources.Requests[corev1.ResourceStorage] = *state.RestoreSize
+	}
+
+	log.FromContext(ctx).Info("Restoring data volume from volume snapshot", "dogu", doguResource.Name, "volumeSnapshot", state.Name)
+	err = rs.client.Create(ctx, pvc)
+	if err != nil {
+		return steps.RequeueWithError(fmt.Errorf("failed to create data volume from volume snapshot %q: %w", state.Name, err))
+	}
+
+	return steps.RequeueAfter(requeueAfterRestoreVolumeSnapshot)
+}
+
+func (rs *RestoreVolumeSnapshotStep) finish(ctx context.Context, doguResource *v2.Dogu, state *volumesnapshot.State) steps.StepResult {
+	err := rs.removeRestoreAnnotation(ctx, doguResource)
+	if err != nil {
+		return steps.RequeueWithError(err)
+	}
+
+	rs.recorder.Eventf(doguResource, corev1.EventTypeNormal, volumeRestoredEventReason,
+		"Restored data volume from volume snapshot %q of version %s.", state.Name, state.DoguVersion)
+	return steps.Continue()
+}
+
+// cancel removes the restore request which cannot succeed, so that the dogu is not blocked by it.
+func (rs *RestoreVolumeSnapshotStep) cancel(ctx context.Context, doguResource *v2.Dogu, message string) steps.StepResult {
+	err := rs.removeRestoreAnnotation(ctx, doguResource)
+	if err != nil {
+		return steps.RequeueWithError(err)
+	}
+
+	rs.recorder.Event(doguResource, corev1.EventTypeWarning, volumeRestoreFailedEventReason, message)
+	return steps.Continue()
+}
+
+func (rs *RestoreVolumeSnapshotStep) removeRestoreAnnotation(ctx context.Context, doguResource *v2.Dogu) error {
+	patch := client.MergeFrom(doguResource.DeepCopy())
+	annotations := maps.Clone(doguResource.GetAnnotations())
+	delete(annotations, volumesnapshot.RestoreAnnotation)
+	delete(annotations, volumesnapshot.RestoreRequestAnnotation)
+	doguResource.SetAnnotations(annotations)
+
+	err := rs.client.Patch(ctx, doguResource, patch)
+	if err != nil {
+		return fmt.Errorf("failed to remove annotation %s: %w", volumesnapshot.RestoreAnnotation, err)
+	}
+
+	return nil
+}
+
+// matchesDoguVersion returns true if the volume snapshot was taken of the installed version of the dogu or of the version
+// the dogu is downgraded to.
+func matchesDoguVersion(doguResource *v2.Dogu, state *volumesnapshot.State) bool {
+	return state.DoguVersion == doguResource.Status.InstalledVersion || state.DoguVersion == doguResource.Spec.Version
+}
+
+// isRestoredBy returns true if the data volume was created from the volume snapshot for the given restore request.
+// The data source is checked as well, because the volume snapshot of a request may be changed while it is restored.
+func isRestoredBy(pvc *corev1.PersistentVolumeClaim, requestID string, snapshotName string) bool {
+	dataSource := pvc.Spec.DataSource
+	return pvc.GetAnnotations()[volumesnapshot.RestoreRequestAnnotation] == requestID &&
+		dataSource != nil && dataSource.Kind == volumesnapshot.GroupVersionKind.Kind && dataSource.Name == snapshotName &&
+		dataSource.APIGroup != nil && *dataSource.APIGroup == volumesnapshot.GroupVersionKind.Group
+}
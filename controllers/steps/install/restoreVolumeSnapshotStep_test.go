@@ -0,0 +1,479 @@
+package install
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudogu/ces-commons-lib/dogu"
+	"github.com/cloudogu/cesapp-lib/core"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/volumesnapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	testVolumeSnapshotName = "ldap-upgrade-4-0c8a2d4e"
+	testRestoreRequestID   = "6a1f3c2e-9d4b-4f0a-8c5e-2b7d9e1f0a3c"
+)
+
+var ldapPVCKey = types.NamespacedName{Namespace: "ecosystem", Name: "ldap"}
+
+type restoreVolumeSnapshotStepMocks struct {
+	client              *mockK8sClient
+	snapshotter         *mockVolumeSnapshotter
+	localDoguFetcher    *mockLocalDoguFetcher
+	generator           *mockDataVolumeGenerator
+	deploymentInterface *mockDeploymentInterface
+	recorder            *mockEventRecorder
+}
+
+func newRestoreVolumeSnapshotStepWithMocks(t *testing.T) (*RestoreVolumeSnapshotStep, restoreVolumeSnapshotStepMocks) {
+	mocks := restoreVolumeSnapshotStepMocks{
+		client:              newMockK8sClient(t),
+		snapshotter:         newMockVolumeSnapshotter(t),
+		localDoguFetcher:    newMockLocalDoguFetcher(t),
+		generator:           newMockDataVolumeGenerator(t),
+		deploymentInterface: newMockDeploymentInterface(t),
+		recorder:            newMockEventRecorder(t),
+	}
+	sut := &RestoreVolumeSnapshotStep{
+		client:              mocks.client,
+		snapshotter:         mocks.snapshotter,
+		localDoguFetcher:    mocks.localDoguFetcher,
+		generator:           mocks.generator,
+		deploymentInterface: mocks.deploymentInterface,
+		recorder:            mocks.recorder,
+	}
+	return sut, mocks
+}
+
+func getRestoreTestDogu() *v2.Dogu {
+	return &v2.Dogu{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ldap",
+			Namespace: "ecosystem",
+			Annotations: map[string]string{
+				volumesnapshot.RestoreAnnotation:        testVolumeSnapshotName,
+				volumesnapshot.RestoreRequestAnnotation: testRestoreRequestID,
+			},
+		},
+		Spec:   v2.DoguSpec{Name: "official/ldap", Version: "2.0.0-1"},
+		Status: v2.DoguStatus{InstalledVersion: "1.0.0-1"},
+	}
+}
+
+func getDoguWithDataVolume() *core.Dogu {
+	return &core.Dogu{Name: "official/ldap", Version: "2.0.0-1", Volumes: []core.Volume{{Name: "db", Path: "/var/lib/openldap", NeedsBackup: true}}}
+}
+
+func getReadyVolumeSnapshot() *volumesnapshot.State {
+	restoreSize := resource.MustParse("4Gi")
+	return &volumesnapshot.State{Name: testVolumeSnapshotName, DoguVersion: "1.0.0-1", ReadyToUse: true, RestoreSize: &restoreSize}
+}
+
+func getRestoredDataVolume(requestID string) func(ctx context.Context, key types.NamespacedName, obj client.Object, opts ...client.GetOption) error {
+	return func(ctx context.Context, key types.NamespacedName, obj client.Object, opts ...client.GetOption) error {
+		pvc := obj.(*corev1.PersistentVolumeClaim)
+		pvc.SetAnnotations(map[string]string{volumesnapshot.RestoreRequestAnnotation: requestID})
+		pvc.Spec.DataSource = &corev1.TypedLocalObjectReference{
+			APIGroup: ptr.To("snapshot.storage.k8s.io"),
+			Kind:     "VolumeSnapshot",
+			Name:     testVolumeSnapshotName,
+		}
+		return nil
+	}
+}
+
+func expectRestoreAnnotationRemoved(t *testing.T, mocks restoreVolumeSnapshotStepMocks) {
+	mocks.client.EXPECT().Patch(testCtx, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			assert.NotContains(t, obj.GetAnnotations(), volumesnapshot.RestoreAnnotation)
+			assert.NotContains(t, obj.GetAnnotations(), volumesnapshot.RestoreRequestAnnotation)
+			data, err := patch.Data(obj)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"metadata":{"annotations":null}}`, string(data))
+			return nil
+		})
+}
+
+func TestNewRestoreVolumeSnapshotStep(t *testing.T) {
+	step := NewRestoreVolumeSnapshotStep(newMockK8sClient(t), newMockVolumeSnapshotter(t), newMockLocalDoguFetcher(t),
+		nil, newMockDeploymentInterface(t), newMockEventRecorder(t))
+	assert.NotNil(t, step)
+}
+
+func TestRestoreVolumeSnapshotStep_Run(t *testing.T) {
+	notFoundErr := apierrors.NewNotFound(schema.GroupResource{Resource: "persistentvolumeclaims"}, "ldap")
+
+	t.Run("should continue if no restore is requested", func(t *testing.T) {
+		// given
+		sut, _ := newRestoreVolumeSnapshotStepWithMocks(t)
+
+		// when
+		result := sut.Run(testCtx, &v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "ldap"}})
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should fail to fetch installed dogu", func(t *testing.T) {
+		// given
+		sut, mocks := newRestoreVolumeSnapshotStepWithMocks(t)
+		mocks.localDoguFetcher.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("ldap")).Return(nil, assert.AnError)
+
+		// when
+		result := sut.Run(testCtx, getRestoreTestDogu())
+
+		// then
+		require.ErrorIs(t, result.Err, assert.AnError)
+		assert.ErrorContains(t, result.Err, "failed to fetch installed dogu")
+	})
+	t.Run("should cancel restore if dogu has no data volume", func(t *testing.T) {
+		// given
+		sut, mocks := newRestoreVolumeSnapshotStepWithMocks(t)
+		mocks.localDoguFetcher.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("ldap")).Return(&core.Dogu{Name: "official/ldap"}, nil)
+		expectRestoreAnnotationRemoved(t, mocks)
+		mocks.recorder.EXPECT().Event(mock.Anything, corev1.EventTypeWarning, volumeRestoreFailedEventReason,
+			`Dogu "ldap" has no data volume to restore from volume snapshot "ldap-upgrade-4-0c8a2d4e".`)
+
+		// when
+		result := sut.Run(testCtx, getRestoreTestDogu())
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should cancel restore if volume snapshot does not exist", func(t *testing.T) {
+		// given
+		sut, mocks := newRestoreVolumeSnapshotStepWithMocks(t)
+		doguResource := getRestoreTestDogu()
+		mocks.localDoguFetcher.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("ldap")).Return(getDoguWithDataVolume(), nil)
+		mocks.snapshotter.EXPECT().Get(testCtx, doguResource, testVolumeSnapshotName).
+			Return(nil, apierrors.NewNotFound(volumesnapshot.GroupVersionKind.GroupVersion().WithResource("volumesnapshots").GroupResource(), testVolumeSnapshotName))
+		expectRestoreAnnotationRemoved(t, mocks)
+		mocks.recorder.EXPECT().Event(mock.Anything, corev1.EventTypeWarning, volumeRestoreFailedEventReason,
+			`Volume snapshot "ldap-upgrade-4-0c8a2d4e" to restore does not exist.`)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should cancel restore if volume snapshot failed", func(t *testing.T) {
+		// given
+		sut, mocks := newRestoreVolumeSnapshotStepWithMocks(t)
+		doguResource := getRestoreTestDogu()
+		mocks.localDoguFetcher.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("ldap")).Return(getDoguWithDataVolume(), nil)
+		mocks.snapshotter.EXPECT().Get(testCtx, doguResource, testVolumeSnapshotName).
+			Return(&volumesnapshot.State{Name: testVolumeSnapshotName, Error: "no space left"}, nil)
+		expectRestoreAnnotationRemoved(t, mocks)
+		mocks.recorder.EXPECT().Event(mock.Anything, corev1.EventTypeWarning, volumeRestoreFailedEventReason,
+			`Volume snapshot "ldap-upgrade-4-0c8a2d4e" to restore failed: no space left`)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should cancel restore if volume snapshot was taken of another version", func(t *testing.T) {
+		// given
+		sut, mocks := newRestoreVolumeSnapshotStepWithMocks(t)
+		doguResource := getRestoreTestDogu()
+		doguResource.Status.InstalledVersion = "2.0.0-1"
+		mocks.localDoguFetcher.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("ldap")).Return(getDoguWithDataVolume(), nil)
+		mocks.snapshotter.EXPECT().Get(testCtx, doguResource, testVolumeSnapshotName).Return(getReadyVolumeSnapshot(), nil)
+		expectRestoreAnnotationRemoved(t, mocks)
+		mocks.recorder.EXPECT().Event(doguResource, corev1.EventTypeWarning, "VolumeRestoreFailed",
+			"Volume snapshot \"ldap-upgrade-4-0c8a2d4e\" was taken of version 1.0.0-1, but version 2.0.0-1 is installed; set the spec version to 1.0.0-1 to restore it.")
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should restore volume snapshot of the version the dogu is downgraded to", func(t *testing.T) {
+		// given
+		sut, mocks := newRestoreVolumeSnapshotStepWithMocks(t)
+		doguResource := getRestoreTestDogu()
+		doguResource.Spec.Version = "1.0.0-1"
+		doguResource.Status.InstalledVersion = "2.0.0-1"
+		mocks.localDoguFetcher.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("ldap")).Return(getDoguWithDataVolume(), nil)
+		mocks.snapshotter.EXPECT().Get(testCtx, doguResource, testVolumeSnapshotName).Return(&volumesnapshot.State{Name: testVolumeSnapshotName, DoguVersion: "1.0.0-1"}, nil)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.RequeueAfter(requeueAfterRestoreVolumeSnapshot), result)
+	})
+	t.Run("should fail to remove restore annotation", func(t *testing.T) {
+		// given
+		sut, mocks := newRestoreVolumeSnapshotStepWithMocks(t)
+		doguResource := getRestoreTestDogu()
+		mocks.localDoguFetcher.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("ldap")).Return(&core.Dogu{Name: "official/ldap"}, nil)
+		mocks.client.EXPECT().Patch(testCtx, doguResource, mock.Anything).Return(assert.AnError)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		require.ErrorIs(t, result.Err, assert.AnError)
+		assert.ErrorContains(t, result.Err, "failed to remove annotation k8s.cloudogu.com/restore-volume-snapshot")
+	})
+	t.Run("should fail to get volume snapshot", func(t *testing.T) {
+		// given
+		sut, mocks := newRestoreVolumeSnapshotStepWithMocks(t)
+		doguResource := getRestoreTestDogu()
+		mocks.localDoguFetcher.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("ldap")).Return(getDoguWithDataVolume(), nil)
+		mocks.snapshotter.EXPECT().Get(testCtx, doguResource, testVolumeSnapshotName).Return(nil, assert.AnError)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		require.ErrorIs(t, result.Err, assert.AnError)
+	})
+	t.Run("should wait until volume snapshot is ready to use", func(t *testing.T) {
+		// given
+		sut, mocks := newRestoreVolumeSnapshotStepWithMocks(t)
+		doguResource := getRestoreTestDogu()
+		mocks.localDoguFetcher.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("ldap")).Return(getDoguWithDataVolume(), nil)
+		mocks.snapshotter.EXPECT().Get(testCtx, doguResource, testVolumeSnapshotName).Return(&volumesnapshot.State{Name: testVolumeSnapshotName, DoguVersion: "1.0.0-1"}, nil)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.RequeueAfter(requeueAfterRestoreVolumeSnapshot), result)
+	})
+	t.Run("should fail to get data volume", func(t *testing.T) {
+		// given
+		sut, mocks := newRestoreVolumeSnapshotStepWithMocks(t)
+		doguResource := getRestoreTestDogu()
+		mocks.localDoguFetcher.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("ldap")).Return(getDoguWithDataVolume(), nil)
+		mocks.snapshotter.EXPECT().Get(testCtx, doguResource, testVolumeSnapshotName).Return(getReadyVolumeSnapshot(), nil)
+		mocks.client.EXPECT().Get(testCtx, ldapPVCKey, mock.Anything).Return(assert.AnError)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		require.ErrorIs(t, result.Err, assert.AnError)
+		assert.ErrorContains(t, result.Err, "failed to get data volume")
+	})
+	t.Run("should create data volume from volume snapshot", func(t *testing.T) {
+		// given
+		sut, mocks := newRestoreVolumeSnapshotStepWithMocks(t)
+		doguResource := getRestoreTestDogu()
+		mocks.localDoguFetcher.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("ldap")).Return(getDoguWithDataVolume(), nil)
+		mocks.snapshotter.EXPECT().Get(testCtx, doguResource, testVolumeSnapshotName).Return(getReadyVolumeSnapshot(), nil)
+		mocks.client.EXPECT().Get(testCtx, ldapPVCKey, mock.Anything).Return(notFoundErr)
+		mocks.generator.EXPECT().CreateDoguPVC(doguResource).Return(&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: "ecosystem"},
+			Spec: corev1.PersistentVolumeClaimSpec{Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("2Gi")},
+			}},
+		}, nil)
+		mocks.client.EXPECT().Create(testCtx, mock.Anything).
+			RunAndReturn(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				pvc := obj.(*corev1.PersistentVolumeClaim)
+				assert.Equal(t, &corev1.TypedLocalObjectReference{
+					APIGroup: ptr.To("snapshot.storage.k8s.io"),
+					Kind:     "VolumeSnapshot",
+					Name:     testVolumeSnapshotName,
+				}, pvc.Spec.DataSource)
+				assert.Equal(t, "4Gi", ptr.To(pvc.Spec.Resources.Requests[corev1.ResourceStorage]).String())
+				assert.Equal(t, testRestoreRequestID, pvc.Annotations[volumesnapshot.RestoreRequestAnnotation])
+				return nil
+			})
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.RequeueAfter(requeueAfterRestoreVolumeSnapshot), result)
+	})
+	t.Run("should fail to create data volume", func(t *testing.T) {
+		// given
+		sut, mocks := newRestoreVolumeSnapshotStepWithMocks(t)
+		doguResource := getRestoreTestDogu()
+		mocks.localDoguFetcher.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("ldap")).Return(getDoguWithDataVolume(), nil)
+		mocks.snapshotter.EXPECT().Get(testCtx, doguResource, testVolumeSnapshotName).Return(getReadyVolumeSnapshot(), nil)
+		mocks.client.EXPECT().Get(testCtx, ldapPVCKey, mock.Anything).Return(notFoundErr)
+		mocks.generator.EXPECT().CreateDoguPVC(doguResource).Return(&corev1.PersistentVolumeClaim{Spec: corev1.PersistentVolumeClaimSpec{Resources: corev1.VolumeResourceRequirements{Requests: corev1.ResourceList{}}}}, nil)
+		mocks.client.EXPECT().Create(testCtx, mock.Anything).Return(assert.AnError)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		require.ErrorIs(t, result.Err, assert.AnError)
+		assert.ErrorContains(t, result.Err, "failed to create data volume from volume snapshot")
+	})
+	t.Run("should stop dogu and wait until its pods are gone", func(t *testing.T) {
+		// given
+		sut, mocks := newRestoreVolumeSnapshotStepWithMocks(t)
+		doguResource := getRestoreTestDogu()
+		mocks.localDoguFetcher.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("ldap")).Return(getDoguWithDataVolume(), nil)
+		mocks.snapshotter.EXPECT().Get(testCtx, doguResource, testVolumeSnapshotName).Return(getReadyVolumeSnapshot(), nil)
+		mocks.client.EXPECT().Get(testCtx, ldapPVCKey, mock.Anything).Return(nil)
+		scale := &autoscalingv1.Scale{Spec: autoscalingv1.ScaleSpec{Replicas: 1}}
+		mocks.deploymentInterface.EXPECT().GetScale(testCtx, "ldap", metav1.GetOptions{}).Return(scale, nil)
+		mocks.deploymentInterface.EXPECT().UpdateScale(testCtx, "ldap", &autoscalingv1.Scale{Spec: autoscalingv1.ScaleSpec{Replicas: 0}}, metav1.UpdateOptions{}).Return(scale, nil)
+		mocks.client.EXPECT().List(testCtx, mock.Anything, client.InNamespace("ecosystem"), client.MatchingLabels{v2.DoguLabelName: "ldap"}).
+			RunAndReturn(func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+				list.(*corev1.PodList).Items = []corev1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "ldap-abc"}}}
+				return nil
+			})
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.RequeueAfter(requeueAfterRestoreVolumeSnapshot), result)
+	})
+	t.Run("should delete data volume of stopped dogu", func(t *testing.T) {
+		// given
+		sut, mocks := newRestoreVolumeSnapshotStepWithMocks(t)
+		doguResource := getRestoreTestDogu()
+		mocks.localDoguFetcher.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("ldap")).Return(getDoguWithDataVolume(), nil)
+		mocks.snapshotter.EXPECT().Get(testCtx, doguResource, testVolumeSnapshotName).Return(getReadyVolumeSnapshot(), nil)
+		mocks.client.EXPECT().Get(testCtx, ldapPVCKey, mock.Anything).Return(nil)
+		mocks.deploymentInterface.EXPECT().GetScale(testCtx, "ldap", metav1.GetOptions{}).Return(&autoscalingv1.Scale{}, nil)
+		mocks.client.EXPECT().List(testCtx, mock.Anything, client.InNamespace("ecosystem"), client.MatchingLabels{v2.DoguLabelName: "ldap"}).Return(nil)
+		mocks.client.EXPECT().Delete(testCtx, mock.Anything).Return(nil)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.RequeueAfter(requeueAfterRestoreVolumeSnapshot), result)
+	})
+	t.Run("should fail to stop dogu", func(t *testing.T) {
+		// given
+		sut, mocks := newRestoreVolumeSnapshotStepWithMocks(t)
+		doguResource := getRestoreTestDogu()
+		mocks.localDoguFetcher.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("ldap")).Return(getDoguWithDataVolume(), nil)
+		mocks.snapshotter.EXPECT().Get(testCtx, doguResource, testVolumeSnapshotName).Return(getReadyVolumeSnapshot(), nil)
+		mocks.client.EXPECT().Get(testCtx, ldapPVCKey, mock.Anything).Return(nil)
+		mocks.deploymentInterface.EXPECT().GetScale(testCtx, "ldap", metav1.GetOptions{}).Return(&autoscalingv1.Scale{Spec: autoscalingv1.ScaleSpec{Replicas: 1}}, nil)
+		mocks.deploymentInterface.EXPECT().UpdateScale(testCtx, "ldap", mock.Anything, metav1.UpdateOptions{}).Return(nil, assert.AnError)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		require.ErrorIs(t, result.Err, assert.AnError)
+		assert.ErrorContains(t, result.Err, "failed to stop dogu before restore")
+	})
+	t.Run("should wait for data volume being deleted", func(t *testing.T) {
+		// given
+		sut, mocks := newRestoreVolumeSnapshotStepWithMocks(t)
+		doguResource := getRestoreTestDogu()
+		mocks.localDoguFetcher.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("ldap")).Return(getDoguWithDataVolume(), nil)
+		mocks.snapshotter.EXPECT().Get(testCtx, doguResource, testVolumeSnapshotName).Return(getReadyVolumeSnapshot(), nil)
+		mocks.client.EXPECT().Get(testCtx, ldapPVCKey, mock.Anything).
+			RunAndReturn(func(ctx context.Context, key types.NamespacedName, obj client.Object, opts ...client.GetOption) error {
+				obj.SetDeletionTimestamp(ptr.To(metav1.Now()))
+				return nil
+			})
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.RequeueAfter(requeueAfterRestoreVolumeSnapshot), result)
+	})
+	t.Run("should finish restore once data volume is restored from volume snapshot", func(t *testing.T) {
+		// given
+		sut, mocks := newRestoreVolumeSnapshotStepWithMocks(t)
+		doguResource := getRestoreTestDogu()
+		mocks.localDoguFetcher.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("ldap")).Return(getDoguWithDataVolume(), nil)
+		mocks.snapshotter.EXPECT().Get(testCtx, doguResource, testVolumeSnapshotName).Return(getReadyVolumeSnapshot(), nil)
+		mocks.client.EXPECT().Get(testCtx, ldapPVCKey, mock.Anything).RunAndReturn(getRestoredDataVolume(testRestoreRequestID))
+		expectRestoreAnnotationRemoved(t, mocks)
+		mocks.recorder.EXPECT().Eventf(mock.Anything, corev1.EventTypeNormal, volumeRestoredEventReason,
+			"Restored data volume from volume snapshot %q of version %s.", testVolumeSnapshotName, "1.0.0-1")
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should restore data volume again which was restored for an earlier request", func(t *testing.T) {
+		// given
+		sut, mocks := newRestoreVolumeSnapshotStepWithMocks(t)
+		doguResource := getRestoreTestDogu()
+		mocks.localDoguFetcher.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("ldap")).Return(getDoguWithDataVolume(), nil)
+		mocks.snapshotter.EXPECT().Get(testCtx, doguResource, testVolumeSnapshotName).Return(getReadyVolumeSnapshot(), nil)
+		mocks.client.EXPECT().Get(testCtx, ldapPVCKey, mock.Anything).RunAndReturn(getRestoredDataVolume("earlier-request"))
+		mocks.deploymentInterface.EXPECT().GetScale(testCtx, "ldap", metav1.GetOptions{}).Return(&autoscalingv1.Scale{}, nil)
+		mocks.client.EXPECT().List(testCtx, mock.Anything, client.InNamespace("ecosystem"), client.MatchingLabels{v2.DoguLabelName: "ldap"}).Return(nil)
+		mocks.client.EXPECT().Delete(testCtx, mock.Anything).Return(nil)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.RequeueAfter(requeueAfterRestoreVolumeSnapshot), result)
+	})
+	t.Run("should mark a new restore request on the dogu", func(t *testing.T) {
+		// given
+		sut, mocks := newRestoreVolumeSnapshotStepWithMocks(t)
+		doguResource := getRestoreTestDogu()
+		delete(doguResource.Annotations, volumesnapshot.RestoreRequestAnnotation)
+		mocks.localDoguFetcher.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("ldap")).Return(getDoguWithDataVolume(), nil)
+		mocks.snapshotter.EXPECT().Get(testCtx, doguResource, testVolumeSnapshotName).Return(getReadyVolumeSnapshot(), nil)
+		var requestID string
+		mocks.client.EXPECT().Patch(testCtx, doguResource, mock.Anything).
+			RunAndReturn(func(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				requestID = obj.GetAnnotations()[volumesnapshot.RestoreRequestAnnotation]
+				assert.NotEmpty(t, requestID)
+				assert.Equal(t, testVolumeSnapshotName, obj.GetAnnotations()[volumesnapshot.RestoreAnnotation])
+				return nil
+			})
+		mocks.client.EXPECT().Get(testCtx, ldapPVCKey, mock.Anything).Return(notFoundErr)
+		mocks.generator.EXPECT().CreateDoguPVC(doguResource).Return(&corev1.PersistentVolumeClaim{Spec: corev1.PersistentVolumeClaimSpec{Resources: corev1.VolumeResourceRequirements{Requests: corev1.ResourceList{}}}}, nil)
+		mocks.client.EXPECT().Create(testCtx, mock.Anything).
+			RunAndReturn(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				assert.Equal(t, requestID, obj.GetAnnotations()[volumesnapshot.RestoreRequestAnnotation])
+				return nil
+			})
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.RequeueAfter(requeueAfterRestoreVolumeSnapshot), result)
+	})
+	t.Run("should fail to mark a new restore request on the dogu", func(t *testing.T) {
+		// given
+		sut, mocks := newRestoreVolumeSnapshotStepWithMocks(t)
+		doguResource := getRestoreTestDogu()
+		delete(doguResource.Annotations, volumesnapshot.RestoreRequestAnnotation)
+		mocks.localDoguFetcher.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("ldap")).Return(getDoguWithDataVolume(), nil)
+		mocks.snapshotter.EXPECT().Get(testCtx, doguResource, testVolumeSnapshotName).Return(getReadyVolumeSnapshot(), nil)
+		mocks.client.EXPECT().Patch(testCtx, doguResource, mock.Anything).Return(assert.AnError)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		require.ErrorIs(t, result.Err, assert.AnError)
+		assert.ErrorContains(t, result.Err, "failed to set annotation k8s.cloudogu.com/restore-volume-snapshot-request")
+	})
+}
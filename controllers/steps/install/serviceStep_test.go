@@ -5,18 +5,17 @@ import (
 
 	"github.com/cloudogu/cesapp-lib/core"
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/drift"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
 	v3 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/stretchr/testify/assert"
 	v4 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestNewServiceStep(t *testing.T) {
 	t.Run("Successfully created step", func(t *testing.T) {
-		step := NewServiceStep(newMockImageRegistry(t), newMockServiceInterface(t), newMockServiceGenerator(t), newMockLocalDoguFetcher(t))
+		step := NewServiceStep(newMockImageRegistry(t), newMockResourceUpserter(t), newMockLocalDoguFetcher(t))
 
 		assert.NotNil(t, step)
 	})
@@ -33,10 +32,9 @@ func TestServiceStep_Run(t *testing.T) {
 	testDogu := &core.Dogu{Name: "test", Image: "test", Version: "1.0.0"}
 
 	type fields struct {
-		serviceGeneratorFn func(t *testing.T) serviceGenerator
+		serviceUpserterFn  func(t *testing.T) serviceUpserter
 		localDoguFetcherFn func(t *testing.T) localDoguFetcher
 		imageRegistryFn    func(t *testing.T) imageRegistry
-		serviceInterfaceFn func(t *testing.T) serviceInterface
 	}
 	tests := []struct {
 		name         string
@@ -47,8 +45,8 @@ func TestServiceStep_Run(t *testing.T) {
 		{
 			name: "should fail to fetch dogu descriptor",
 			fields: fields{
-				serviceGeneratorFn: func(t *testing.T) serviceGenerator {
-					return newMockServiceGenerator(t)
+				serviceUpserterFn: func(t *testing.T) serviceUpserter {
+					return newMockServiceUpserter(t)
 				},
 				localDoguFetcherFn: func(t *testing.T) localDoguFetcher {
 					mck := newMockLocalDoguFetcher(t)
@@ -58,9 +56,6 @@ func TestServiceStep_Run(t *testing.T) {
 				imageRegistryFn: func(t *testing.T) imageRegistry {
 					return newMockImageRegistry(t)
 				},
-				serviceInterfaceFn: func(t *testing.T) serviceInterface {
-					return newMockServiceInterface(t)
-				},
 			},
 			doguResource: testDoguCR,
 			want:         steps.RequeueWithError(assert.AnError),
@@ -68,8 +63,8 @@ func TestServiceStep_Run(t *testing.T) {
 		{
 			name: "should fail to pull image config",
 			fields: fields{
-				serviceGeneratorFn: func(t *testing.T) serviceGenerator {
-					return newMockServiceGenerator(t)
+				serviceUpserterFn: func(t *testing.T) serviceUpserter {
+					return newMockServiceUpserter(t)
 				},
 				localDoguFetcherFn: func(t *testing.T) localDoguFetcher {
 					mck := newMockLocalDoguFetcher(t)
@@ -81,152 +76,16 @@ func TestServiceStep_Run(t *testing.T) {
 					mck.EXPECT().PullImageConfig(testCtx, "test:1.0.0").Return(nil, assert.AnError)
 					return mck
 				},
-				serviceInterfaceFn: func(t *testing.T) serviceInterface {
-					return newMockServiceInterface(t)
-				},
-			},
-			doguResource: testDoguCR,
-			want:         steps.RequeueWithError(assert.AnError),
-		},
-		{
-			name: "should fail to generate services",
-			fields: fields{
-				serviceGeneratorFn: func(t *testing.T) serviceGenerator {
-					mck := newMockServiceGenerator(t)
-					mck.EXPECT().CreateDoguService(
-						testDoguCR,
-						testDogu,
-						&v3.ConfigFile{},
-					).Return(nil, assert.AnError)
-					return mck
-				},
-				localDoguFetcherFn: func(t *testing.T) localDoguFetcher {
-					mck := newMockLocalDoguFetcher(t)
-					mck.EXPECT().FetchForResource(testCtx, testDoguCR).Return(testDogu, nil)
-					return mck
-				},
-				imageRegistryFn: func(t *testing.T) imageRegistry {
-					mck := newMockImageRegistry(t)
-					mck.EXPECT().PullImageConfig(testCtx, "test:1.0.0").Return(&v3.ConfigFile{}, nil)
-					return mck
-				},
-				serviceInterfaceFn: func(t *testing.T) serviceInterface {
-					return newMockServiceInterface(t)
-				},
-			},
-			doguResource: testDoguCR,
-			want:         steps.RequeueWithError(assert.AnError),
-		},
-		{
-			name: "should fail to get service",
-			fields: fields{
-				serviceGeneratorFn: func(t *testing.T) serviceGenerator {
-					mck := newMockServiceGenerator(t)
-					mck.EXPECT().CreateDoguService(
-						testDoguCR,
-						testDogu,
-						&v3.ConfigFile{},
-					).Return(&v4.Service{ObjectMeta: v1.ObjectMeta{Name: "test"}}, nil)
-					return mck
-				},
-				localDoguFetcherFn: func(t *testing.T) localDoguFetcher {
-					mck := newMockLocalDoguFetcher(t)
-					mck.EXPECT().FetchForResource(testCtx, testDoguCR).Return(testDogu, nil)
-					return mck
-				},
-				imageRegistryFn: func(t *testing.T) imageRegistry {
-					mck := newMockImageRegistry(t)
-					mck.EXPECT().PullImageConfig(testCtx, "test:1.0.0").Return(&v3.ConfigFile{}, nil)
-					return mck
-				},
-				serviceInterfaceFn: func(t *testing.T) serviceInterface {
-					mck := newMockServiceInterface(t)
-					mck.EXPECT().Get(testCtx, "test", v1.GetOptions{}).Return(nil, assert.AnError)
-					return mck
-				},
-			},
-			doguResource: &v2.Dogu{
-				ObjectMeta: v1.ObjectMeta{Name: "test"},
-				Spec: v2.DoguSpec{
-					Version: "1.0.0",
-				},
-			},
-			want: steps.RequeueWithError(assert.AnError),
-		},
-		{
-			name: "should fail to create service if no service for dogu is found",
-			fields: fields{
-				serviceGeneratorFn: func(t *testing.T) serviceGenerator {
-					mck := newMockServiceGenerator(t)
-					mck.EXPECT().CreateDoguService(
-						testDoguCR,
-						testDogu,
-						&v3.ConfigFile{},
-					).Return(&v4.Service{ObjectMeta: v1.ObjectMeta{Name: "test"}}, nil)
-					return mck
-				},
-				localDoguFetcherFn: func(t *testing.T) localDoguFetcher {
-					mck := newMockLocalDoguFetcher(t)
-					mck.EXPECT().FetchForResource(testCtx, testDoguCR).Return(testDogu, nil)
-					return mck
-				},
-				imageRegistryFn: func(t *testing.T) imageRegistry {
-					mck := newMockImageRegistry(t)
-					mck.EXPECT().PullImageConfig(testCtx, "test:1.0.0").Return(&v3.ConfigFile{}, nil)
-					return mck
-				},
-				serviceInterfaceFn: func(t *testing.T) serviceInterface {
-					mck := newMockServiceInterface(t)
-					mck.EXPECT().Get(testCtx, "test", v1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, ""))
-					mck.EXPECT().Create(testCtx, &v4.Service{ObjectMeta: v1.ObjectMeta{Name: "test"}}, v1.CreateOptions{}).Return(nil, assert.AnError)
-					return mck
-				},
 			},
 			doguResource: testDoguCR,
 			want:         steps.RequeueWithError(assert.AnError),
 		},
 		{
-			name: "should succeed to create service if no service for dogu is found",
-			fields: fields{
-				serviceGeneratorFn: func(t *testing.T) serviceGenerator {
-					mck := newMockServiceGenerator(t)
-					mck.EXPECT().CreateDoguService(
-						testDoguCR,
-						testDogu,
-						&v3.ConfigFile{},
-					).Return(&v4.Service{ObjectMeta: v1.ObjectMeta{Name: "test"}}, nil)
-					return mck
-				},
-				localDoguFetcherFn: func(t *testing.T) localDoguFetcher {
-					mck := newMockLocalDoguFetcher(t)
-					mck.EXPECT().FetchForResource(testCtx, testDoguCR).Return(testDogu, nil)
-					return mck
-				},
-				imageRegistryFn: func(t *testing.T) imageRegistry {
-					mck := newMockImageRegistry(t)
-					mck.EXPECT().PullImageConfig(testCtx, "test:1.0.0").Return(&v3.ConfigFile{}, nil)
-					return mck
-				},
-				serviceInterfaceFn: func(t *testing.T) serviceInterface {
-					mck := newMockServiceInterface(t)
-					mck.EXPECT().Get(testCtx, "test", v1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, ""))
-					mck.EXPECT().Create(testCtx, &v4.Service{ObjectMeta: v1.ObjectMeta{Name: "test"}}, v1.CreateOptions{}).Return(nil, nil)
-					return mck
-				},
-			},
-			doguResource: testDoguCR,
-			want:         steps.Continue(),
-		},
-		{
-			name: "should fail to update service if service for dogu is found",
+			name: "should fail to upsert service",
 			fields: fields{
-				serviceGeneratorFn: func(t *testing.T) serviceGenerator {
-					mck := newMockServiceGenerator(t)
-					mck.EXPECT().CreateDoguService(
-						testDoguCR,
-						testDogu,
-						&v3.ConfigFile{},
-					).Return(&v4.Service{ObjectMeta: v1.ObjectMeta{Name: "test"}}, nil)
+				serviceUpserterFn: func(t *testing.T) serviceUpserter {
+					mck := newMockServiceUpserter(t)
+					mck.EXPECT().UpsertDoguService(testCtx, testDoguCR, testDogu, &v3.ConfigFile{}).Return(nil, assert.AnError)
 					return mck
 				},
 				localDoguFetcherFn: func(t *testing.T) localDoguFetcher {
@@ -239,26 +98,16 @@ func TestServiceStep_Run(t *testing.T) {
 					mck.EXPECT().PullImageConfig(testCtx, "test:1.0.0").Return(&v3.ConfigFile{}, nil)
 					return mck
 				},
-				serviceInterfaceFn: func(t *testing.T) serviceInterface {
-					mck := newMockServiceInterface(t)
-					mck.EXPECT().Get(testCtx, "test", v1.GetOptions{}).Return(&v4.Service{ObjectMeta: v1.ObjectMeta{Name: "test"}}, nil)
-					mck.EXPECT().Update(testCtx, &v4.Service{ObjectMeta: v1.ObjectMeta{Name: "test"}}, v1.UpdateOptions{}).Return(nil, assert.AnError)
-					return mck
-				},
 			},
 			doguResource: testDoguCR,
 			want:         steps.RequeueWithError(assert.AnError),
 		},
 		{
-			name: "should succeed to update service if service for dogu is found",
+			name: "should succeed to upsert service",
 			fields: fields{
-				serviceGeneratorFn: func(t *testing.T) serviceGenerator {
-					mck := newMockServiceGenerator(t)
-					mck.EXPECT().CreateDoguService(
-						testDoguCR,
-						testDogu,
-						&v3.ConfigFile{},
-					).Return(&v4.Service{ObjectMeta: v1.ObjectMeta{Name: "test"}}, nil)
+				serviceUpserterFn: func(t *testing.T) serviceUpserter {
+					mck := newMockServiceUpserter(t)
+					mck.EXPECT().UpsertDoguService(testCtx, testDoguCR, testDogu, &v3.ConfigFile{}).Return(&v4.Service{ObjectMeta: v1.ObjectMeta{Name: "test"}}, nil)
 					return mck
 				},
 				localDoguFetcherFn: func(t *testing.T) localDoguFetcher {
@@ -271,12 +120,6 @@ func TestServiceStep_Run(t *testing.T) {
 					mck.EXPECT().PullImageConfig(testCtx, "test:1.0.0").Return(&v3.ConfigFile{}, nil)
 					return mck
 				},
-				serviceInterfaceFn: func(t *testing.T) serviceInterface {
-					mck := newMockServiceInterface(t)
-					mck.EXPECT().Get(testCtx, "test", v1.GetOptions{}).Return(&v4.Service{ObjectMeta: v1.ObjectMeta{Name: "test"}}, nil)
-					mck.EXPECT().Update(testCtx, &v4.Service{ObjectMeta: v1.ObjectMeta{Name: "test"}}, v1.UpdateOptions{}).Return(nil, nil)
-					return mck
-				},
 			},
 			doguResource: testDoguCR,
 			want:         steps.Continue(),
@@ -285,12 +128,43 @@ func TestServiceStep_Run(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ses := &ServiceStep{
-				serviceGenerator: tt.fields.serviceGeneratorFn(t),
+				serviceUpserter:  tt.fields.serviceUpserterFn(t),
 				localDoguFetcher: tt.fields.localDoguFetcherFn(t),
 				imageRegistry:    tt.fields.imageRegistryFn(t),
-				serviceInterface: tt.fields.serviceInterfaceFn(t),
 			}
 			assert.Equalf(t, tt.want, ses.Run(testCtx, tt.doguResource), "Run(%v, %v)", testCtx, tt.doguResource)
 		})
 	}
 }
+
+func newDriftReportedDogu() *v2.Dogu {
+	return &v2.Dogu{
+		ObjectMeta: v1.ObjectMeta{Name: "ldap", Namespace: namespace, Generation: 2},
+		Spec:       v2.DoguSpec{Name: "official/ldap", Version: "1.0.0-1"},
+		Status: v2.DoguStatus{
+			InstalledVersion: "1.0.0-1",
+			Conditions: []v1.Condition{
+				{
+					Type:               drift.ConditionDrifted,
+					Status:             v1.ConditionTrue,
+					Reason:             drift.ReasonDriftReported,
+					Message:            (&drift.Report{Drifts: []drift.ResourceDrift{{Kind: drift.KindService, Name: "ldap", Fields: []string{"spec.ports"}}}}).Message(),
+					ObservedGeneration: 2,
+				},
+			},
+		},
+	}
+}
+
+func TestServiceStep_Run_driftReported(t *testing.T) {
+	t.Run("should not apply the service again while its drift is only reported", func(t *testing.T) {
+		// given
+		sut := &ServiceStep{}
+
+		// when
+		result := sut.Run(testCtx, newDriftReportedDogu())
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+}
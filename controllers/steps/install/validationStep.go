@@ -3,50 +3,70 @@ package install
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/cloudogu/ces-commons-lib/errors"
 	"github.com/cloudogu/cesapp-lib/core"
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/additionalMount"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/cesregistry"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/coordination"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/dependency"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/health"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/security"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 )
 
+// ReverseDependencyEventReason is the reason of the event recorded if installed dogus do not accept the new version.
+const ReverseDependencyEventReason = "ReverseDependencyViolation"
+
 // The ValidationStep validates if the dogu can be installed or upgraded.
 // The step validates if
 //   - the upgrade is an unallowed downgrade
+//   - all dependencies are running, i.e. their health status is available or degraded
 //   - all dependencies are healthy
 //   - the security context is valid
 //   - the additional mounts are valid
+//
+// A dogu whose dependencies are missing or unhealthy is put on the wait list, so that it is reconciled again as soon as
+// one of its dependencies becomes available.
 type ValidationStep struct {
 	doguHealthChecker             doguHealthChecker
 	localDoguFetcher              localDoguFetcher
 	securityValidator             securityValidator
 	doguAdditionalMountsValidator doguAdditionalMountsValidator
 	dependencyValidator           dependencyValidator
+	reverseDependencyValidator    reverseDependencyValidator
 	recorder                      eventRecorder
+	waitList                      doguWaitList
+	// reportedViolations holds the last reported reverse-dependency violation per dogu, so that the warning event is
+	// only recorded again if the violation changes and not on every requeue.
+	reportedViolations map[types.NamespacedName]string
+	violationsMutex    sync.Mutex
 }
 
 func NewValidationStep(
 	healthChecker health.DoguHealthChecker,
 	fetcher cesregistry.LocalDoguFetcher,
 	dependencyValidator dependency.Validator,
+	reverseDependencyValidator dependency.ReverseDependencyValidator,
 	securityValidator security.Validator,
 	doguAdditionalMountsValidator additionalMount.Validator,
 	recorder record.EventRecorder,
+	waitList coordination.WaitList,
 ) *ValidationStep {
 	return &ValidationStep{
 		doguHealthChecker:             healthChecker,
 		localDoguFetcher:              fetcher,
 		dependencyValidator:           dependencyValidator,
+		reverseDependencyValidator:    reverseDependencyValidator,
 		securityValidator:             securityValidator,
 		doguAdditionalMountsValidator: doguAdditionalMountsValidator,
 		recorder:                      recorder,
+		waitList:                      waitList,
 	}
 }
 
@@ -57,7 +77,7 @@ func (vs *ValidationStep) Run(ctx context.Context, doguResource *v2.Dogu) steps.
 	}
 	unallowedDowngrade, err := vs.shouldAbortBecauseOfUnallowedDowngrade(fromDogu, doguResource)
 	if err != nil {
-		return steps.RequeueWithError(err)
+		return steps.TerminalUntilSpecChange(fmt.Errorf("failed to compare dogu versions: %w", err))
 	}
 	if unallowedDowngrade {
 		return steps.Abort()
@@ -70,27 +90,43 @@ func (vs *ValidationStep) Run(ctx context.Context, doguResource *v2.Dogu) steps.
 
 	if fromDogu != nil {
 		changeNamespace := doguResource.Spec.UpgradeConfig.AllowNamespaceSwitch
-		err = vs.checkDoguIdentity(fromDogu, toDogu, changeNamespace)
+		identityResult := vs.checkDoguIdentity(fromDogu, toDogu, changeNamespace)
+		if !identityResult.Continue {
+			return identityResult
+		}
+	}
+
+	if vs.shouldValidateReverseDependencies(fromDogu, doguResource) {
+		err = vs.reverseDependencyValidator.ValidateReverseDependencies(ctx, toDogu)
 		if err != nil {
+			if vs.isNewViolation(doguResource.GetObjectKey(), err.Error()) {
+				vs.recorder.Eventf(doguResource, v1.EventTypeWarning, ReverseDependencyEventReason,
+					"Installed dogus do not accept version %s of dogu %s, set the annotation %s to \"true\" to ignore: %s",
+					doguResource.Spec.Version, doguResource.Name, dependency.IgnoreReverseDependenciesAnnotation, err)
+			}
 			return steps.RequeueWithError(err)
 		}
 	}
+	vs.forgetViolation(doguResource.GetObjectKey())
 
 	if vs.shouldValidateDependencies(doguResource) {
 		err = vs.dependencyValidator.ValidateDependencies(ctx, toDogu)
 		if err != nil {
+			vs.waitList.Wait(doguResource.GetObjectKey(), dependencyNames(toDogu))
 			return steps.RequeueWithError(err)
 		}
 
 		err = vs.doguHealthChecker.CheckDependenciesRecursive(ctx, toDogu, doguResource.Namespace)
 		if err != nil {
+			vs.waitList.Wait(doguResource.GetObjectKey(), dependencyNames(toDogu))
 			return steps.RequeueWithError(err)
 		}
 	}
+	vs.waitList.Done(doguResource.GetObjectKey())
 
 	err = vs.securityValidator.ValidateSecurity(toDogu, doguResource)
 	if err != nil {
-		return steps.RequeueWithError(err)
+		return steps.TerminalUntilSpecChange(err)
 	}
 
 	err = vs.doguAdditionalMountsValidator.ValidateAdditionalMounts(ctx, toDogu, doguResource)
@@ -108,6 +144,40 @@ func (vs *ValidationStep) shouldValidateDependencies(doguResource *v2.Dogu) bool
 	return true
 }
 
+// shouldValidateReverseDependencies returns true if the version of the dogu changes and the dogu is not annotated to
+// ignore the reverse dependencies.
+func (vs *ValidationStep) shouldValidateReverseDependencies(fromDogu *core.Dogu, doguResource *v2.Dogu) bool {
+	if dependency.IgnoresReverseDependencies(doguResource) {
+		return false
+	}
+	return fromDogu == nil || fromDogu.Version != doguResource.Spec.Version
+}
+
+// isNewViolation remembers the reverse-dependency violation of the dogu and returns true if it differs from the last
+// one.
+func (vs *ValidationStep) isNewViolation(dogu types.NamespacedName, violation string) bool {
+	vs.violationsMutex.Lock()
+	defer vs.violationsMutex.Unlock()
+
+	if vs.reportedViolations == nil {
+		vs.reportedViolations = map[types.NamespacedName]string{}
+	}
+	if vs.reportedViolations[dogu] == violation {
+		return false
+	}
+	vs.reportedViolations[dogu] = violation
+
+	return true
+}
+
+// forgetViolation forgets the last reverse-dependency violation of the dogu, so that it is reported again if it
+// reappears.
+func (vs *ValidationStep) forgetViolation(dogu types.NamespacedName) {
+	vs.violationsMutex.Lock()
+	defer vs.violationsMutex.Unlock()
+	delete(vs.reportedViolations, dogu)
+}
+
 func isOlder(version1Raw, version2Raw string) (bool, error) {
 	version1, err := core.ParseVersion(version1Raw)
 	if err != nil {
@@ -122,16 +192,18 @@ func isOlder(version1Raw, version2Raw string) (bool, error) {
 	return version1.IsOlderThan(version2), nil
 }
 
-func (vs *ValidationStep) checkDoguIdentity(localDogu *core.Dogu, remoteDogu *core.Dogu, namespaceChange bool) error {
+// checkDoguIdentity checks that the new descriptor belongs to the installed dogu. A different name cannot be fixed in
+// the dogu resource, so it is terminal. A different namespace is terminal until the spec allows the namespace switch.
+func (vs *ValidationStep) checkDoguIdentity(localDogu *core.Dogu, remoteDogu *core.Dogu, namespaceChange bool) steps.StepResult {
 	if localDogu.GetSimpleName() != remoteDogu.GetSimpleName() {
-		return fmt.Errorf("dogus must have the same name (%s=%s)", localDogu.GetSimpleName(), remoteDogu.GetSimpleName())
+		return steps.Terminal(fmt.Errorf("dogus must have the same name (%s=%s)", localDogu.GetSimpleName(), remoteDogu.GetSimpleName()))
 	}
 
 	if !namespaceChange && localDogu.GetNamespace() != remoteDogu.GetNamespace() {
-		return fmt.Errorf("dogus must have the same namespace (%s=%s)", localDogu.GetNamespace(), remoteDogu.GetNamespace())
+		return steps.TerminalUntilSpecChange(fmt.Errorf("dogus must have the same namespace (%s=%s)", localDogu.GetNamespace(), remoteDogu.GetNamespace()))
 	}
 
-	return nil
+	return steps.Continue()
 }
 
 func (vs *ValidationStep) shouldAbortBecauseOfUnallowedDowngrade(fromDogu *core.Dogu, doguResource *v2.Dogu) (bool, error) {
@@ -8,9 +8,12 @@ import (
 	"github.com/cloudogu/ces-commons-lib/errors"
 	"github.com/cloudogu/cesapp-lib/core"
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/dependency"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 func TestNewValidationStep(t *testing.T) {
@@ -25,9 +28,11 @@ func TestNewValidationStep(t *testing.T) {
 			checker,
 			fetcher,
 			dependencyValidator,
+			newMockReverseDependencyValidator(t),
 			securityValidator,
 			additionalMountsValidator,
 			recorder,
+			newMockDoguWaitList(t),
 		)
 
 		assert.Same(t, checker, step.doguHealthChecker)
@@ -40,12 +45,17 @@ func TestNewValidationStep(t *testing.T) {
 }
 
 func TestValidationStep_Run(t *testing.T) {
+	doguWithDependency := &core.Dogu{Version: "1.0.1", Dependencies: []core.Dependency{{Type: core.DependencyTypeDogu, Name: "postgresql"}}}
+	_, unparsableVersionErr := core.ParseVersion("a.b.c")
+
 	type fields struct {
 		doguHealthCheckerFn             func(t *testing.T) doguHealthChecker
 		localDoguFetcherFn              func(t *testing.T) localDoguFetcher
 		securityValidatorFn             func(t *testing.T) securityValidator
 		doguAdditionalMountsValidatorFn func(t *testing.T) doguAdditionalMountsValidator
 		dependencyValidatorFn           func(t *testing.T) dependencyValidator
+		reverseDependencyValidatorFn    func(t *testing.T) reverseDependencyValidator
+		waitListFn                      func(t *testing.T) doguWaitList
 	}
 	tests := []struct {
 		name         string
@@ -53,6 +63,39 @@ func TestValidationStep_Run(t *testing.T) {
 		doguResource *v2.Dogu
 		want         steps.StepResult
 	}{
+		{
+			name: "should stop requeuing for unparsable dogu version",
+			fields: fields{
+				doguHealthCheckerFn: func(t *testing.T) doguHealthChecker {
+					return newMockDoguHealthChecker(t)
+				},
+				localDoguFetcherFn: func(t *testing.T) localDoguFetcher {
+					mck := newMockLocalDoguFetcher(t)
+					mck.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("test")).Return(&core.Dogu{Version: "1.0.0"}, nil)
+					return mck
+				},
+				securityValidatorFn: func(t *testing.T) securityValidator {
+					return newMockSecurityValidator(t)
+				},
+				doguAdditionalMountsValidatorFn: func(t *testing.T) doguAdditionalMountsValidator {
+					return newMockDoguAdditionalMountsValidator(t)
+				},
+				reverseDependencyValidatorFn: func(t *testing.T) reverseDependencyValidator {
+					return newMockReverseDependencyValidator(t)
+				},
+				dependencyValidatorFn: func(t *testing.T) dependencyValidator {
+					return newMockDependencyValidator(t)
+				},
+				waitListFn: func(t *testing.T) doguWaitList {
+					return newMockDoguWaitList(t)
+				},
+			},
+			doguResource: &v2.Dogu{
+				ObjectMeta: v1.ObjectMeta{Name: "test"},
+				Spec:       v2.DoguSpec{Version: "a.b.c"},
+			},
+			want: steps.TerminalUntilSpecChange(fmt.Errorf("failed to compare dogu versions: %w", unparsableVersionErr)),
+		},
 		{
 			name: "should fail to get dogu descriptor",
 			fields: fields{
@@ -73,9 +116,15 @@ func TestValidationStep_Run(t *testing.T) {
 				doguAdditionalMountsValidatorFn: func(t *testing.T) doguAdditionalMountsValidator {
 					return newMockDoguAdditionalMountsValidator(t)
 				},
+				reverseDependencyValidatorFn: func(t *testing.T) reverseDependencyValidator {
+					return newMockReverseDependencyValidator(t)
+				},
 				dependencyValidatorFn: func(t *testing.T) dependencyValidator {
 					return newMockDependencyValidator(t)
 				},
+				waitListFn: func(t *testing.T) doguWaitList {
+					return newMockDoguWaitList(t)
+				},
 			},
 			doguResource: &v2.Dogu{
 				ObjectMeta: v1.ObjectMeta{Name: "test"},
@@ -93,7 +142,7 @@ func TestValidationStep_Run(t *testing.T) {
 					mck.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("test")).Return(nil, errors.NewNotFoundError(assert.AnError))
 					mck.EXPECT().FetchForResource(testCtx, &v2.Dogu{
 						ObjectMeta: v1.ObjectMeta{Name: "test"},
-					}).Return(&core.Dogu{Version: "1.0.1"}, nil)
+					}).Return(doguWithDependency, nil)
 					return mck
 				},
 				securityValidatorFn: func(t *testing.T) securityValidator {
@@ -102,9 +151,19 @@ func TestValidationStep_Run(t *testing.T) {
 				doguAdditionalMountsValidatorFn: func(t *testing.T) doguAdditionalMountsValidator {
 					return newMockDoguAdditionalMountsValidator(t)
 				},
+				reverseDependencyValidatorFn: func(t *testing.T) reverseDependencyValidator {
+					mck := newMockReverseDependencyValidator(t)
+					mck.EXPECT().ValidateReverseDependencies(testCtx, mock.Anything).Return(nil)
+					return mck
+				},
 				dependencyValidatorFn: func(t *testing.T) dependencyValidator {
 					mck := newMockDependencyValidator(t)
-					mck.EXPECT().ValidateDependencies(testCtx, &core.Dogu{Version: "1.0.1"}).Return(assert.AnError)
+					mck.EXPECT().ValidateDependencies(testCtx, doguWithDependency).Return(assert.AnError)
+					return mck
+				},
+				waitListFn: func(t *testing.T) doguWaitList {
+					mck := newMockDoguWaitList(t)
+					mck.EXPECT().Wait(types.NamespacedName{Name: "test"}, []string{"postgresql"}).Return()
 					return mck
 				},
 			},
@@ -118,7 +177,7 @@ func TestValidationStep_Run(t *testing.T) {
 			fields: fields{
 				doguHealthCheckerFn: func(t *testing.T) doguHealthChecker {
 					mck := newMockDoguHealthChecker(t)
-					mck.EXPECT().CheckDependenciesRecursive(testCtx, &core.Dogu{Version: "1.0.1"}, "").Return(assert.AnError)
+					mck.EXPECT().CheckDependenciesRecursive(testCtx, doguWithDependency, "").Return(assert.AnError)
 					return mck
 				},
 				localDoguFetcherFn: func(t *testing.T) localDoguFetcher {
@@ -126,7 +185,7 @@ func TestValidationStep_Run(t *testing.T) {
 					mck.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("test")).Return(nil, errors.NewNotFoundError(assert.AnError))
 					mck.EXPECT().FetchForResource(testCtx, &v2.Dogu{
 						ObjectMeta: v1.ObjectMeta{Name: "test"},
-					}).Return(&core.Dogu{Version: "1.0.1"}, nil)
+					}).Return(doguWithDependency, nil)
 					return mck
 				},
 				securityValidatorFn: func(t *testing.T) securityValidator {
@@ -135,9 +194,19 @@ func TestValidationStep_Run(t *testing.T) {
 				doguAdditionalMountsValidatorFn: func(t *testing.T) doguAdditionalMountsValidator {
 					return newMockDoguAdditionalMountsValidator(t)
 				},
+				reverseDependencyValidatorFn: func(t *testing.T) reverseDependencyValidator {
+					mck := newMockReverseDependencyValidator(t)
+					mck.EXPECT().ValidateReverseDependencies(testCtx, mock.Anything).Return(nil)
+					return mck
+				},
 				dependencyValidatorFn: func(t *testing.T) dependencyValidator {
 					mck := newMockDependencyValidator(t)
-					mck.EXPECT().ValidateDependencies(testCtx, &core.Dogu{Version: "1.0.1"}).Return(nil)
+					mck.EXPECT().ValidateDependencies(testCtx, doguWithDependency).Return(nil)
+					return mck
+				},
+				waitListFn: func(t *testing.T) doguWaitList {
+					mck := newMockDoguWaitList(t)
+					mck.EXPECT().Wait(types.NamespacedName{Name: "test"}, []string{"postgresql"}).Return()
 					return mck
 				},
 			},
@@ -172,16 +241,26 @@ func TestValidationStep_Run(t *testing.T) {
 				doguAdditionalMountsValidatorFn: func(t *testing.T) doguAdditionalMountsValidator {
 					return newMockDoguAdditionalMountsValidator(t)
 				},
+				reverseDependencyValidatorFn: func(t *testing.T) reverseDependencyValidator {
+					mck := newMockReverseDependencyValidator(t)
+					mck.EXPECT().ValidateReverseDependencies(testCtx, mock.Anything).Return(nil)
+					return mck
+				},
 				dependencyValidatorFn: func(t *testing.T) dependencyValidator {
 					mck := newMockDependencyValidator(t)
 					mck.EXPECT().ValidateDependencies(testCtx, &core.Dogu{Version: "1.0.1"}).Return(nil)
 					return mck
 				},
+				waitListFn: func(t *testing.T) doguWaitList {
+					mck := newMockDoguWaitList(t)
+					mck.EXPECT().Done(types.NamespacedName{Name: "test"}).Return()
+					return mck
+				},
 			},
 			doguResource: &v2.Dogu{
 				ObjectMeta: v1.ObjectMeta{Name: "test"},
 			},
-			want: steps.RequeueWithError(assert.AnError),
+			want: steps.TerminalUntilSpecChange(assert.AnError),
 		},
 		{
 			name: "should fail additional mounts validation",
@@ -213,11 +292,21 @@ func TestValidationStep_Run(t *testing.T) {
 					}).Return(assert.AnError)
 					return mck
 				},
+				reverseDependencyValidatorFn: func(t *testing.T) reverseDependencyValidator {
+					mck := newMockReverseDependencyValidator(t)
+					mck.EXPECT().ValidateReverseDependencies(testCtx, mock.Anything).Return(nil)
+					return mck
+				},
 				dependencyValidatorFn: func(t *testing.T) dependencyValidator {
 					mck := newMockDependencyValidator(t)
 					mck.EXPECT().ValidateDependencies(testCtx, &core.Dogu{Version: "1.0.1"}).Return(nil)
 					return mck
 				},
+				waitListFn: func(t *testing.T) doguWaitList {
+					mck := newMockDoguWaitList(t)
+					mck.EXPECT().Done(types.NamespacedName{Name: "test"}).Return()
+					return mck
+				},
 			},
 			doguResource: &v2.Dogu{
 				ObjectMeta: v1.ObjectMeta{Name: "test"},
@@ -254,11 +343,21 @@ func TestValidationStep_Run(t *testing.T) {
 					}).Return(nil)
 					return mck
 				},
+				reverseDependencyValidatorFn: func(t *testing.T) reverseDependencyValidator {
+					mck := newMockReverseDependencyValidator(t)
+					mck.EXPECT().ValidateReverseDependencies(testCtx, mock.Anything).Return(nil)
+					return mck
+				},
 				dependencyValidatorFn: func(t *testing.T) dependencyValidator {
 					mck := newMockDependencyValidator(t)
 					mck.EXPECT().ValidateDependencies(testCtx, &core.Dogu{Version: "1.0.1"}).Return(nil)
 					return mck
 				},
+				waitListFn: func(t *testing.T) doguWaitList {
+					mck := newMockDoguWaitList(t)
+					mck.EXPECT().Done(types.NamespacedName{Name: "test"}).Return()
+					return mck
+				},
 			},
 			doguResource: &v2.Dogu{
 				ObjectMeta: v1.ObjectMeta{Name: "test"},
@@ -274,6 +373,8 @@ func TestValidationStep_Run(t *testing.T) {
 				securityValidator:             tt.fields.securityValidatorFn(t),
 				doguAdditionalMountsValidator: tt.fields.doguAdditionalMountsValidatorFn(t),
 				dependencyValidator:           tt.fields.dependencyValidatorFn(t),
+				reverseDependencyValidator:    tt.fields.reverseDependencyValidatorFn(t),
+				waitList:                      tt.fields.waitListFn(t),
 			}
 			assert.Equalf(t, tt.want, vs.Run(testCtx, tt.doguResource), "Run(%v, %v)", testCtx, tt.doguResource)
 		})
@@ -292,9 +393,11 @@ func TestShouldValidationStep(t *testing.T) {
 			checker,
 			fetcher,
 			dependencyValidator,
+			newMockReverseDependencyValidator(t),
 			securityValidator,
 			additionalMountsValidator,
 			recorder,
+			newMockDoguWaitList(t),
 		)
 		doguResource := &v2.Dogu{
 			ObjectMeta: v1.ObjectMeta{Name: "test"},
@@ -314,9 +417,11 @@ func TestShouldValidationStep(t *testing.T) {
 			checker,
 			fetcher,
 			dependencyValidator,
+			newMockReverseDependencyValidator(t),
 			securityValidator,
 			additionalMountsValidator,
 			recorder,
+			newMockDoguWaitList(t),
 		)
 		doguResource := &v2.Dogu{
 			ObjectMeta: v1.ObjectMeta{Name: "test"},
@@ -327,3 +432,204 @@ func TestShouldValidationStep(t *testing.T) {
 		assert.False(t, result)
 	})
 }
+
+func TestValidationStep_checkDoguIdentity(t *testing.T) {
+	localDogu := &core.Dogu{Name: "official/test"}
+
+	t.Run("should return terminal error for different name", func(t *testing.T) {
+		result := (&ValidationStep{}).checkDoguIdentity(localDogu, &core.Dogu{Name: "official/other"}, false)
+
+		assert.False(t, result.Continue)
+		assert.ErrorContains(t, result.Err, "dogus must have the same name (test=other)")
+		assert.Equal(t, steps.ErrorClassTerminal, steps.ClassifyError(result.Err))
+	})
+	t.Run("should return terminal until spec change error for different namespace", func(t *testing.T) {
+		result := (&ValidationStep{}).checkDoguIdentity(localDogu, &core.Dogu{Name: "premium/test"}, false)
+
+		assert.False(t, result.Continue)
+		assert.ErrorContains(t, result.Err, "dogus must have the same namespace (official=premium)")
+		assert.Equal(t, steps.ErrorClassTerminalUntilSpecChange, steps.ClassifyError(result.Err))
+	})
+	t.Run("should allow different namespace with namespace switch", func(t *testing.T) {
+		result := (&ValidationStep{}).checkDoguIdentity(localDogu, &core.Dogu{Name: "premium/test"}, true)
+
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should stop run on different namespace until the spec changes", func(t *testing.T) {
+		// given
+		doguResource := &v2.Dogu{
+			ObjectMeta: v1.ObjectMeta{Name: "test", Namespace: "ecosystem"},
+			Spec:       v2.DoguSpec{Name: "premium/test", Version: "1.0.0-1"},
+		}
+		fetcher := newMockLocalDoguFetcher(t)
+		fetcher.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("test")).Return(&core.Dogu{Name: "official/test", Version: "1.0.0-1"}, nil)
+		fetcher.EXPECT().FetchForResource(testCtx, doguResource).Return(&core.Dogu{Name: "premium/test", Version: "1.0.0-1"}, nil)
+		sut := &ValidationStep{localDoguFetcher: fetcher}
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.TerminalUntilSpecChange(fmt.Errorf("dogus must have the same namespace (official=premium)")), result)
+	})
+}
+
+func TestValidationStep_Run_reverseDependencies(t *testing.T) {
+	installedDogu := &core.Dogu{Name: "official/postgresql", Version: "12.18.1-1"}
+	targetDogu := &core.Dogu{Name: "official/postgresql", Version: "14.15.0-1"}
+	newDoguResource := func(ignoreReverseDependencies bool) *v2.Dogu {
+		doguResource := &v2.Dogu{
+			ObjectMeta: v1.ObjectMeta{Name: "postgresql", Namespace: "ecosystem"},
+			Spec: v2.DoguSpec{
+				Name:    "official/postgresql",
+				Version: "14.15.0-1",
+			},
+		}
+		if ignoreReverseDependencies {
+			doguResource.Annotations = map[string]string{dependency.IgnoreReverseDependenciesAnnotation: "true"}
+		}
+		return doguResource
+	}
+	const eventMessage = "Installed dogus do not accept version %s of dogu %s, set the annotation %s to \"true\" to ignore: %s"
+
+	t.Run("should report installed dogus which do not accept the new version", func(t *testing.T) {
+		// given
+		doguResource := newDoguResource(false)
+		fetcher := newMockLocalDoguFetcher(t)
+		fetcher.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("postgresql")).Return(installedDogu, nil)
+		fetcher.EXPECT().FetchForResource(testCtx, doguResource).Return(targetDogu, nil)
+		reverseValidator := newMockReverseDependencyValidator(t)
+		reverseValidator.EXPECT().ValidateReverseDependencies(testCtx, targetDogu).Return(assert.AnError)
+		recorder := newMockEventRecorder(t)
+		recorder.EXPECT().Eventf(doguResource, "Warning", ReverseDependencyEventReason, eventMessage,
+			"14.15.0-1", "postgresql", dependency.IgnoreReverseDependenciesAnnotation, assert.AnError).Return()
+		sut := &ValidationStep{localDoguFetcher: fetcher, reverseDependencyValidator: reverseValidator, recorder: recorder}
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.RequeueWithError(assert.AnError), result)
+	})
+	t.Run("should report the violation only again if it changed", func(t *testing.T) {
+		// given
+		doguResource := newDoguResource(false)
+		otherError := fmt.Errorf("other violation")
+		fetcher := newMockLocalDoguFetcher(t)
+		fetcher.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("postgresql")).Return(installedDogu, nil)
+		fetcher.EXPECT().FetchForResource(testCtx, doguResource).Return(targetDogu, nil)
+		reverseValidator := newMockReverseDependencyValidator(t)
+		reverseValidator.EXPECT().ValidateReverseDependencies(testCtx, targetDogu).Return(assert.AnError).Twice()
+		reverseValidator.EXPECT().ValidateReverseDependencies(testCtx, targetDogu).Return(otherError).Once()
+		recorder := newMockEventRecorder(t)
+		recorder.EXPECT().Eventf(doguResource, "Warning", ReverseDependencyEventReason, eventMessage,
+			"14.15.0-1", "postgresql", dependency.IgnoreReverseDependenciesAnnotation, assert.AnError).Return().Once()
+		recorder.EXPECT().Eventf(doguResource, "Warning", ReverseDependencyEventReason, eventMessage,
+			"14.15.0-1", "postgresql", dependency.IgnoreReverseDependenciesAnnotation, otherError).Return().Once()
+		sut := &ValidationStep{localDoguFetcher: fetcher, reverseDependencyValidator: reverseValidator, recorder: recorder}
+
+		// when
+		first := sut.Run(testCtx, doguResource)
+		second := sut.Run(testCtx, doguResource)
+		third := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.RequeueWithError(assert.AnError), first)
+		assert.Equal(t, steps.RequeueWithError(assert.AnError), second)
+		assert.Equal(t, steps.RequeueWithError(otherError), third)
+	})
+	t.Run("should report the violation again after it was resolved", func(t *testing.T) {
+		// given
+		doguResource := newDoguResource(false)
+		doguResource.Spec.Stopped = true
+		fetcher := newMockLocalDoguFetcher(t)
+		fetcher.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("postgresql")).Return(installedDogu, nil)
+		fetcher.EXPECT().FetchForResource(testCtx, doguResource).Return(targetDogu, nil)
+		reverseValidator := newMockReverseDependencyValidator(t)
+		reverseValidator.EXPECT().ValidateReverseDependencies(testCtx, targetDogu).Return(assert.AnError).Once()
+		reverseValidator.EXPECT().ValidateReverseDependencies(testCtx, targetDogu).Return(nil).Once()
+		reverseValidator.EXPECT().ValidateReverseDependencies(testCtx, targetDogu).Return(assert.AnError).Once()
+		recorder := newMockEventRecorder(t)
+		recorder.EXPECT().Eventf(doguResource, "Warning", ReverseDependencyEventReason, eventMessage,
+			"14.15.0-1", "postgresql", dependency.IgnoreReverseDependenciesAnnotation, assert.AnError).Return().Twice()
+		securityValidator := newMockSecurityValidator(t)
+		securityValidator.EXPECT().ValidateSecurity(targetDogu, doguResource).Return(nil)
+		mountsValidator := newMockDoguAdditionalMountsValidator(t)
+		mountsValidator.EXPECT().ValidateAdditionalMounts(testCtx, targetDogu, doguResource).Return(nil)
+		waitList := newMockDoguWaitList(t)
+		waitList.EXPECT().Done(doguResource.GetObjectKey()).Return()
+		sut := &ValidationStep{
+			localDoguFetcher:              fetcher,
+			reverseDependencyValidator:    reverseValidator,
+			securityValidator:             securityValidator,
+			doguAdditionalMountsValidator: mountsValidator,
+			recorder:                      recorder,
+			waitList:                      waitList,
+		}
+
+		// when
+		first := sut.Run(testCtx, doguResource)
+		second := sut.Run(testCtx, doguResource)
+		third := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.RequeueWithError(assert.AnError), first)
+		assert.Equal(t, steps.Continue(), second)
+		assert.Equal(t, steps.RequeueWithError(assert.AnError), third)
+	})
+	t.Run("should skip check if the dogu is annotated to ignore reverse dependencies", func(t *testing.T) {
+		// given
+		doguResource := newDoguResource(true)
+		doguResource.Spec.Stopped = true
+		fetcher := newMockLocalDoguFetcher(t)
+		fetcher.EXPECT().FetchInstalled(testCtx, dogu.SimpleName("postgresql")).Return(installedDogu, nil)
+		fetcher.EXPECT().FetchForResource(testCtx, doguResource).Return(targetDogu, nil)
+		waitList := newMockDoguWaitList(t)
+		waitList.EXPECT().Done(doguResource.GetObjectKey()).Return()
+		securityValidator := newMockSecurityValidator(t)
+		securityValidator.EXPECT().ValidateSecurity(targetDogu, doguResource).Return(nil)
+		mountsValidator := newMockDoguAdditionalMountsValidator(t)
+		mountsValidator.EXPECT().ValidateAdditionalMounts(testCtx, targetDogu, doguResource).Return(nil)
+		sut := &ValidationStep{
+			localDoguFetcher:              fetcher,
+			reverseDependencyValidator:    newMockReverseDependencyValidator(t),
+			waitList:                      waitList,
+			securityValidator:             securityValidator,
+			doguAdditionalMountsValidator: mountsValidator,
+		}
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+}
+
+func TestValidationStep_shouldValidateReverseDependencies(t *testing.T) {
+	installedDogu := &core.Dogu{Name: "official/postgresql", Version: "12.18.1-1"}
+	tests := []struct {
+		name         string
+		fromDogu     *core.Dogu
+		version      string
+		forceUpgrade bool
+		annotations  map[string]string
+		want         bool
+	}{
+		{name: "should validate installation", fromDogu: nil, version: "14.15.0-1", want: true},
+		{name: "should validate version change", fromDogu: installedDogu, version: "14.15.0-1", want: true},
+		{name: "should validate forced version change", fromDogu: installedDogu, version: "14.15.0-1", forceUpgrade: true, want: true},
+		{name: "should not validate unchanged version", fromDogu: installedDogu, version: "12.18.1-1", want: false},
+		{name: "should not validate ignored reverse dependencies", fromDogu: installedDogu, version: "14.15.0-1",
+			annotations: map[string]string{dependency.IgnoreReverseDependenciesAnnotation: "true"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doguResource := &v2.Dogu{
+				ObjectMeta: v1.ObjectMeta{Annotations: tt.annotations},
+				Spec:       v2.DoguSpec{Version: tt.version, UpgradeConfig: v2.UpgradeConfig{ForceUpgrade: tt.forceUpgrade}},
+			}
+			assert.Equal(t, tt.want, (&ValidationStep{}).shouldValidateReverseDependencies(tt.fromDogu, doguResource))
+		})
+	}
+}
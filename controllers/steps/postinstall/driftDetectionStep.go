@@ -0,0 +1,207 @@
+package postinstall
+
+import (
+	"context"
+	"fmt"
+
+	cesappcore "github.com/cloudogu/cesapp-lib/core"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/cesregistry"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/drift"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/imageregistry"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/resource"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	driftRevertedEventReason = "DriftReverted"
+	driftReportedEventReason = "DriftReported"
+)
+
+// The DriftDetectionStep compares the resources of an installed dogu with their desired state and sets the Drifted
+// condition. Depending on the drift policy of the dogu, drifted resources are reverted or only reported.
+// Reported drift does not stop the reconciliation of the dogu. The resources of a new generation of the dogu are
+// applied before their drift is detected again, as the changes of the spec would otherwise be reported as drift.
+type DriftDetectionStep struct {
+	detector         driftDetector
+	localDoguFetcher localDoguFetcher
+	imageRegistry    imageRegistry
+	upserter         resourceUpserter
+	doguInterface    doguInterface
+	recorder         eventRecorder
+}
+
+func NewDriftDetectionStep(
+	detector drift.Detector,
+	fetcher cesregistry.LocalDoguFetcher,
+	registry imageregistry.ImageRegistry,
+	upserter resource.ResourceUpserter,
+	doguInterface doguClient.DoguInterface,
+	recorder record.EventRecorder,
+) *DriftDetectionStep {
+	return &DriftDetectionStep{
+		detector:         detector,
+		localDoguFetcher: fetcher,
+		imageRegistry:    registry,
+		upserter:         upserter,
+		doguInterface:    doguInterface,
+		recorder:         recorder,
+	}
+}
+
+func (dds *DriftDetectionStep) Run(ctx context.Context, doguResource *v2.Dogu) steps.StepResult {
+	if doguResource.Status.InstalledVersion != doguResource.Spec.Version || isInSupportMode(doguResource) {
+		// the resources are expected to differ during an installation, an upgrade and in the support mode
+		return steps.Continue()
+	}
+
+	if drift.PolicyOf(doguResource) == drift.PolicyReport && isNewGeneration(doguResource) {
+		// the following steps apply the resources of the new generation, reported drift must not prevent this
+		message := fmt.Sprintf("Resources of generation %d are applied before their drift is detected again", doguResource.Generation)
+		_, err := dds.setDriftedCondition(ctx, doguResource, metav1.ConditionUnknown, drift.ReasonGenerationChanged, message)
+		if err != nil {
+			return steps.RequeueWithError(err)
+		}
+		return steps.Continue()
+	}
+
+	dogu, err := dds.localDoguFetcher.FetchInstalled(ctx, doguResource.GetSimpleDoguName())
+	if err != nil {
+		return steps.RequeueWithError(fmt.Errorf("failed to get local descriptor for dogu %q: %w", doguResource.Name, err))
+	}
+
+	report, err := dds.detector.Detect(ctx, doguResource, dogu)
+	if err != nil {
+		return steps.RequeueWithError(fmt.Errorf("failed to detect drift of dogu %q: %w", doguResource.Name, err))
+	}
+
+	if !report.HasDrift() {
+		_, err = dds.setDriftedCondition(ctx, doguResource, metav1.ConditionFalse, drift.ReasonInSync, "All resources of the dogu match their desired state")
+		if err != nil {
+			return steps.RequeueWithError(err)
+		}
+		return steps.Continue()
+	}
+
+	message := report.Message()
+	if drift.PolicyOf(doguResource) == drift.PolicyReport {
+		changed, err := dds.setDriftedCondition(ctx, doguResource, metav1.ConditionTrue, drift.ReasonDriftReported, message)
+		if err != nil {
+			return steps.RequeueWithError(err)
+		}
+		// the drift stays until it is resolved, so it is only recorded once and not on every reconcile
+		if changed {
+			dds.recorder.Eventf(doguResource, corev1.EventTypeWarning, driftReportedEventReason,
+				"Resources drifted from their desired state and are not reverted because of the drift policy %q: %s", drift.PolicyReport, report.Summary())
+		}
+		return steps.Continue()
+	}
+
+	revertible, notRevertible := report.Split()
+	reason := drift.ReasonDriftReverted
+	if notRevertible.HasDrift() {
+		reason = drift.ReasonDriftReported
+	}
+	changed, err := dds.setDriftedCondition(ctx, doguResource, metav1.ConditionTrue, reason, message)
+	if err != nil {
+		return steps.RequeueWithError(err)
+	}
+	if changed && notRevertible.HasDrift() {
+		dds.recorder.Eventf(doguResource, corev1.EventTypeWarning, driftReportedEventReason,
+			"Resources drifted from their desired state and cannot be reverted: %s", notRevertible.Summary())
+	}
+
+	if revertible.HasDrift() {
+		err = dds.revert(ctx, doguResource, dogu, revertible)
+		if err != nil {
+			return steps.RequeueWithError(fmt.Errorf("failed to revert drift of dogu %q: %w", doguResource.Name, err))
+		}
+		dds.recorder.Eventf(doguResource, corev1.EventTypeNormal, driftRevertedEventReason,
+			"Reverted resources which drifted from their desired state: %s", revertible.Summary())
+	}
+
+	return steps.Continue()
+}
+
+// revert applies the desired state of the drifted resources again.
+func (dds *DriftDetectionStep) revert(ctx context.Context, doguResource *v2.Dogu, dogu *cesappcore.Dogu, report *drift.Report) error {
+	// the drift was caused by someone else, so the fields of other field managers are taken over
+	applyCtx := resource.WithForcedOwnership(ctx)
+
+	if report.Contains(drift.KindDeployment) {
+		_, err := dds.upserter.UpsertDoguDeployment(applyCtx, doguResource, dogu, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	if report.Contains(drift.KindService) || report.Contains(drift.KindNetworkPolicy) {
+		imageConfig, err := dds.imageRegistry.PullImageConfig(ctx, dogu.Image+":"+dogu.Version)
+		if err != nil {
+			return err
+		}
+		// the network policies are generated from the service, so the service is applied first
+		service, err := dds.upserter.UpsertDoguService(applyCtx, doguResource, dogu, imageConfig)
+		if err != nil {
+			return err
+		}
+		if report.Contains(drift.KindNetworkPolicy) {
+			err = dds.upserter.UpsertDoguNetworkPolicies(applyCtx, doguResource, dogu, service)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if report.Contains(drift.KindPersistentVolumeClaim) {
+		_, err := dds.upserter.UpsertDoguPVCs(applyCtx, doguResource, dogu)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setDriftedCondition only updates the status of the dogu resource if the condition changed. It returns true if the
+// condition was updated.
+func (dds *DriftDetectionStep) setDriftedCondition(ctx context.Context, doguResource *v2.Dogu, status metav1.ConditionStatus, reason, message string) (bool, error) {
+	existing := meta.FindStatusCondition(doguResource.Status.Conditions, drift.ConditionDrifted)
+	if existing != nil && existing.Status == status && existing.Reason == reason && existing.Message == message &&
+		existing.ObservedGeneration == doguResource.Generation {
+		return false, nil
+	}
+
+	condition := metav1.Condition{
+		Type:               drift.ConditionDrifted,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: doguResource.Generation,
+	}
+	updatedDoguResource, err := dds.doguInterface.UpdateStatusWithRetry(ctx, doguResource, func(status v2.DoguStatus) v2.DoguStatus {
+		meta.SetStatusCondition(&status.Conditions, condition)
+		return status
+	}, metav1.UpdateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to update drift condition of dogu %q: %w", doguResource.Name, err)
+	}
+	*doguResource = *updatedDoguResource
+
+	return true, nil
+}
+
+// isNewGeneration returns true if the drift of the dogu was detected in a previous generation.
+func isNewGeneration(doguResource *v2.Dogu) bool {
+	condition := meta.FindStatusCondition(doguResource.Status.Conditions, drift.ConditionDrifted)
+	return condition != nil && condition.ObservedGeneration < doguResource.Generation
+}
+
+func isInSupportMode(doguResource *v2.Dogu) bool {
+	return doguResource.Spec.SupportMode || meta.IsStatusConditionTrue(doguResource.Status.Conditions, v2.ConditionSupportMode)
+}
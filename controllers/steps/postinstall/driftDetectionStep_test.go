@@ -0,0 +1,329 @@
+package postinstall
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	cesappcore "github.com/cloudogu/cesapp-lib/core"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/drift"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	imagev1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func getDriftTestDoguResource() *v2.Dogu {
+	return &v2.Dogu{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: "ecosystem", Generation: 2},
+		Spec:       v2.DoguSpec{Name: "official/ldap", Version: "2.6.8-1"},
+		Status:     v2.DoguStatus{InstalledVersion: "2.6.8-1"},
+	}
+}
+
+func expectDriftedCondition(t *testing.T, doguInterfaceMock *mockDoguInterface, status metav1.ConditionStatus, reason, message string) {
+	doguInterfaceMock.EXPECT().UpdateStatusWithRetry(testCtx, mock.Anything, mock.Anything, metav1.UpdateOptions{}).
+		RunAndReturn(func(ctx context.Context, doguResource *v2.Dogu, modifyStatusFn func(v2.DoguStatus) v2.DoguStatus, opts metav1.UpdateOptions) (*v2.Dogu, error) {
+			updated := doguResource.DeepCopy()
+			updated.Status = modifyStatusFn(doguResource.Status)
+			condition := meta.FindStatusCondition(updated.Status.Conditions, drift.ConditionDrifted)
+			require.NotNil(t, condition)
+			assert.Equal(t, status, condition.Status)
+			assert.Equal(t, reason, condition.Reason)
+			assert.Equal(t, message, condition.Message)
+			assert.Equal(t, int64(2), condition.ObservedGeneration)
+			return updated, nil
+		})
+}
+
+func TestNewDriftDetectionStep(t *testing.T) {
+	t.Run("Successfully created step", func(t *testing.T) {
+		// when
+		step := NewDriftDetectionStep(newMockDriftDetector(t), newMockLocalDoguFetcher(t), newMockImageRegistry(t), nil, newMockDoguInterface(t), newMockEventRecorder(t))
+
+		// then
+		assert.NotNil(t, step)
+	})
+}
+
+func TestDriftDetectionStep_Run(t *testing.T) {
+	dogu := &cesappcore.Dogu{Name: "official/ldap", Version: "2.6.8-1", Image: "registry.cloudogu.com/official/ldap"}
+	deploymentDrift := drift.ResourceDrift{Kind: drift.KindDeployment, Name: "ldap", Fields: []string{"spec.template.spec.containers[0].image"}}
+	netPolDrift := drift.ResourceDrift{Kind: drift.KindNetworkPolicy, Name: "ldap-deny-all", Missing: true}
+	pvcDrift := drift.ResourceDrift{Kind: drift.KindPersistentVolumeClaim, Name: "ldap", Fields: []string{"metadata.labels.app"}}
+
+	t.Run("should skip dogu which is not yet installed or upgraded", func(t *testing.T) {
+		// given
+		doguResource := getDriftTestDoguResource()
+		doguResource.Status.InstalledVersion = "2.6.7-1"
+		sut := &DriftDetectionStep{}
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should skip dogu in support mode", func(t *testing.T) {
+		// given
+		doguResource := getDriftTestDoguResource()
+		doguResource.Status.Conditions = []metav1.Condition{{Type: v2.ConditionSupportMode, Status: metav1.ConditionTrue}}
+		sut := &DriftDetectionStep{}
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should fail to fetch dogu descriptor", func(t *testing.T) {
+		// given
+		doguResource := getDriftTestDoguResource()
+		fetcherMock := newMockLocalDoguFetcher(t)
+		fetcherMock.EXPECT().FetchInstalled(testCtx, doguResource.GetSimpleDoguName()).Return(nil, assert.AnError)
+		sut := &DriftDetectionStep{localDoguFetcher: fetcherMock}
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.RequeueWithError(fmt.Errorf("failed to get local descriptor for dogu %q: %w", "ldap", assert.AnError)), result)
+	})
+	t.Run("should fail to detect drift", func(t *testing.T) {
+		// given
+		doguResource := getDriftTestDoguResource()
+		fetcherMock := newMockLocalDoguFetcher(t)
+		fetcherMock.EXPECT().FetchInstalled(testCtx, doguResource.GetSimpleDoguName()).Return(dogu, nil)
+		detectorMock := newMockDriftDetector(t)
+		detectorMock.EXPECT().Detect(testCtx, doguResource, dogu).Return(nil, assert.AnError)
+		sut := &DriftDetectionStep{localDoguFetcher: fetcherMock, detector: detectorMock}
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.RequeueWithError(fmt.Errorf("failed to detect drift of dogu %q: %w", "ldap", assert.AnError)), result)
+	})
+	t.Run("should set condition if resources are in sync", func(t *testing.T) {
+		// given
+		doguResource := getDriftTestDoguResource()
+		fetcherMock := newMockLocalDoguFetcher(t)
+		fetcherMock.EXPECT().FetchInstalled(testCtx, doguResource.GetSimpleDoguName()).Return(dogu, nil)
+		detectorMock := newMockDriftDetector(t)
+		detectorMock.EXPECT().Detect(testCtx, doguResource, dogu).Return(&drift.Report{}, nil)
+		doguInterfaceMock := newMockDoguInterface(t)
+		expectDriftedCondition(t, doguInterfaceMock, metav1.ConditionFalse, drift.ReasonInSync, "All resources of the dogu match their desired state")
+		sut := &DriftDetectionStep{localDoguFetcher: fetcherMock, detector: detectorMock, doguInterface: doguInterfaceMock}
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+		assert.True(t, meta.IsStatusConditionFalse(doguResource.Status.Conditions, drift.ConditionDrifted))
+	})
+	t.Run("should not update unchanged condition", func(t *testing.T) {
+		// given
+		doguResource := getDriftTestDoguResource()
+		doguResource.Status.Conditions = []metav1.Condition{{
+			Type:               drift.ConditionDrifted,
+			Status:             metav1.ConditionFalse,
+			Reason:             drift.ReasonInSync,
+			Message:            "All resources of the dogu match their desired state",
+			ObservedGeneration: 2,
+		}}
+		fetcherMock := newMockLocalDoguFetcher(t)
+		fetcherMock.EXPECT().FetchInstalled(testCtx, doguResource.GetSimpleDoguName()).Return(dogu, nil)
+		detectorMock := newMockDriftDetector(t)
+		detectorMock.EXPECT().Detect(testCtx, doguResource, dogu).Return(&drift.Report{}, nil)
+		sut := &DriftDetectionStep{localDoguFetcher: fetcherMock, detector: detectorMock, doguInterface: newMockDoguInterface(t)}
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should fail to update condition", func(t *testing.T) {
+		// given
+		doguResource := getDriftTestDoguResource()
+		fetcherMock := newMockLocalDoguFetcher(t)
+		fetcherMock.EXPECT().FetchInstalled(testCtx, doguResource.GetSimpleDoguName()).Return(dogu, nil)
+		detectorMock := newMockDriftDetector(t)
+		detectorMock.EXPECT().Detect(testCtx, doguResource, dogu).Return(&drift.Report{}, nil)
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().UpdateStatusWithRetry(testCtx, doguResource, mock.Anything, metav1.UpdateOptions{}).Return(nil, assert.AnError)
+		sut := &DriftDetectionStep{localDoguFetcher: fetcherMock, detector: detectorMock, doguInterface: doguInterfaceMock}
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.RequeueWithError(fmt.Errorf("failed to update drift condition of dogu %q: %w", "ldap", assert.AnError)), result)
+	})
+	t.Run("should only report drift and continue reconciliation with default report policy", func(t *testing.T) {
+		// given
+		doguResource := getDriftTestDoguResource()
+		report := &drift.Report{Drifts: []drift.ResourceDrift{deploymentDrift}}
+		fetcherMock := newMockLocalDoguFetcher(t)
+		fetcherMock.EXPECT().FetchInstalled(testCtx, doguResource.GetSimpleDoguName()).Return(dogu, nil)
+		detectorMock := newMockDriftDetector(t)
+		detectorMock.EXPECT().Detect(testCtx, doguResource, dogu).Return(report, nil)
+		doguInterfaceMock := newMockDoguInterface(t)
+		expectDriftedCondition(t, doguInterfaceMock, metav1.ConditionTrue, drift.ReasonDriftReported,
+			"Resources drifted from their desired state: Deployment ldap: spec.template.spec.containers[0].image")
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(mock.Anything, corev1.EventTypeWarning, driftReportedEventReason,
+			"Resources drifted from their desired state and are not reverted because of the drift policy %q: %s", drift.PolicyReport, report.Summary()).Return()
+		sut := &DriftDetectionStep{localDoguFetcher: fetcherMock, detector: detectorMock, doguInterface: doguInterfaceMock, recorder: recorderMock}
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should not detect drift before the resources of a new generation are applied", func(t *testing.T) {
+		// given
+		doguResource := getDriftTestDoguResource()
+		doguResource.Status.Conditions = []metav1.Condition{{
+			Type:               drift.ConditionDrifted,
+			Status:             metav1.ConditionTrue,
+			Reason:             drift.ReasonDriftReported,
+			Message:            (&drift.Report{Drifts: []drift.ResourceDrift{deploymentDrift}}).Message(),
+			ObservedGeneration: 1,
+		}}
+		doguInterfaceMock := newMockDoguInterface(t)
+		expectDriftedCondition(t, doguInterfaceMock, metav1.ConditionUnknown, drift.ReasonGenerationChanged,
+			"Resources of generation 2 are applied before their drift is detected again")
+		sut := &DriftDetectionStep{localDoguFetcher: newMockLocalDoguFetcher(t), detector: newMockDriftDetector(t), doguInterface: doguInterfaceMock, recorder: newMockEventRecorder(t)}
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+		assert.False(t, drift.IsReportedOnly(doguResource, drift.KindDeployment))
+	})
+	t.Run("should not report unchanged drift again", func(t *testing.T) {
+		// given
+		report := &drift.Report{Drifts: []drift.ResourceDrift{deploymentDrift}}
+		doguResource := getDriftTestDoguResource()
+		doguResource.Status.Conditions = []metav1.Condition{{
+			Type:               drift.ConditionDrifted,
+			Status:             metav1.ConditionTrue,
+			Reason:             drift.ReasonDriftReported,
+			Message:            "Resources drifted from their desired state: " + report.Summary(),
+			ObservedGeneration: 2,
+		}}
+		fetcherMock := newMockLocalDoguFetcher(t)
+		fetcherMock.EXPECT().FetchInstalled(testCtx, doguResource.GetSimpleDoguName()).Return(dogu, nil)
+		detectorMock := newMockDriftDetector(t)
+		detectorMock.EXPECT().Detect(testCtx, doguResource, dogu).Return(report, nil)
+		sut := &DriftDetectionStep{localDoguFetcher: fetcherMock, detector: detectorMock, doguInterface: newMockDoguInterface(t), recorder: newMockEventRecorder(t)}
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should revert drifted resources with repair policy", func(t *testing.T) {
+		// given
+		doguResource := getDriftTestDoguResource()
+		doguResource.Annotations = map[string]string{drift.PolicyAnnotation: "repair"}
+		report := &drift.Report{Drifts: []drift.ResourceDrift{deploymentDrift, netPolDrift}}
+		imageConfig := &imagev1.ConfigFile{}
+		service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "ldap"}}
+		fetcherMock := newMockLocalDoguFetcher(t)
+		fetcherMock.EXPECT().FetchInstalled(testCtx, doguResource.GetSimpleDoguName()).Return(dogu, nil)
+		detectorMock := newMockDriftDetector(t)
+		detectorMock.EXPECT().Detect(testCtx, doguResource, dogu).Return(report, nil)
+		doguInterfaceMock := newMockDoguInterface(t)
+		expectDriftedCondition(t, doguInterfaceMock, metav1.ConditionTrue, drift.ReasonDriftReverted, "Resources drifted from their desired state: "+report.Summary())
+		registryMock := newMockImageRegistry(t)
+		registryMock.EXPECT().PullImageConfig(testCtx, "registry.cloudogu.com/official/ldap:2.6.8-1").Return(imageConfig, nil)
+		upserterMock := newMockResourceUpserter(t)
+		upserterMock.EXPECT().UpsertDoguDeployment(mock.Anything, mock.Anything, dogu, mock.AnythingOfType("func(*v1.Deployment)")).Return(&appsv1.Deployment{}, nil)
+		upserterMock.EXPECT().UpsertDoguService(mock.Anything, mock.Anything, dogu, imageConfig).Return(service, nil)
+		upserterMock.EXPECT().UpsertDoguNetworkPolicies(mock.Anything, mock.Anything, dogu, service).Return(nil)
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(mock.Anything, corev1.EventTypeNormal, driftRevertedEventReason,
+			"Reverted resources which drifted from their desired state: %s", report.Summary()).Return()
+		sut := &DriftDetectionStep{
+			localDoguFetcher: fetcherMock,
+			detector:         detectorMock,
+			doguInterface:    doguInterfaceMock,
+			imageRegistry:    registryMock,
+			upserter:         upserterMock,
+			recorder:         recorderMock,
+		}
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should only report drift of existing volume claim with repair policy", func(t *testing.T) {
+		// given
+		doguResource := getDriftTestDoguResource()
+		doguResource.Annotations = map[string]string{drift.PolicyAnnotation: "repair"}
+		report := &drift.Report{Drifts: []drift.ResourceDrift{pvcDrift}}
+		fetcherMock := newMockLocalDoguFetcher(t)
+		fetcherMock.EXPECT().FetchInstalled(testCtx, doguResource.GetSimpleDoguName()).Return(dogu, nil)
+		detectorMock := newMockDriftDetector(t)
+		detectorMock.EXPECT().Detect(testCtx, doguResource, dogu).Return(report, nil)
+		doguInterfaceMock := newMockDoguInterface(t)
+		expectDriftedCondition(t, doguInterfaceMock, metav1.ConditionTrue, drift.ReasonDriftReported, "Resources drifted from their desired state: "+report.Summary())
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(mock.Anything, corev1.EventTypeWarning, driftReportedEventReason,
+			"Resources drifted from their desired state and cannot be reverted: %s", report.Summary()).Return()
+		sut := &DriftDetectionStep{
+			localDoguFetcher: fetcherMock,
+			detector:         detectorMock,
+			doguInterface:    doguInterfaceMock,
+			upserter:         newMockResourceUpserter(t),
+			recorder:         recorderMock,
+		}
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should fail to revert drifted resources", func(t *testing.T) {
+		// given
+		doguResource := getDriftTestDoguResource()
+		doguResource.Annotations = map[string]string{drift.PolicyAnnotation: "repair"}
+		report := &drift.Report{Drifts: []drift.ResourceDrift{{Kind: drift.KindPersistentVolumeClaim, Name: "ldap", Missing: true}}}
+		fetcherMock := newMockLocalDoguFetcher(t)
+		fetcherMock.EXPECT().FetchInstalled(testCtx, doguResource.GetSimpleDoguName()).Return(dogu, nil)
+		detectorMock := newMockDriftDetector(t)
+		detectorMock.EXPECT().Detect(testCtx, doguResource, dogu).Return(report, nil)
+		doguInterfaceMock := newMockDoguInterface(t)
+		expectDriftedCondition(t, doguInterfaceMock, metav1.ConditionTrue, drift.ReasonDriftReverted, "Resources drifted from their desired state: "+report.Summary())
+		upserterMock := newMockResourceUpserter(t)
+		upserterMock.EXPECT().UpsertDoguPVCs(mock.Anything, mock.Anything, dogu).Return(nil, assert.AnError)
+		sut := &DriftDetectionStep{
+			localDoguFetcher: fetcherMock,
+			detector:         detectorMock,
+			doguInterface:    doguInterfaceMock,
+			upserter:         upserterMock,
+			recorder:         newMockEventRecorder(t),
+		}
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.RequeueWithError(fmt.Errorf("failed to revert drift of dogu %q: %w", "ldap", assert.AnError)), result)
+	})
+}
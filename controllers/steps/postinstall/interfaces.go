@@ -7,6 +7,8 @@ import (
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/cesregistry"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/drift"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/imageregistry"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/resource"
 	imagev1 "github.com/google/go-containerregistry/pkg/v1"
 	apps "k8s.io/api/apps/v1"
@@ -88,8 +90,6 @@ type coreV1Interface interface {
 	v1.CoreV1Interface
 }
 
-//nolint:unused
-//goland:noinspection GoUnusedType
 type resourceUpserter interface {
 	// UpsertDoguDeployment generates a deployment for a given dogu and applies it to the cluster.
 	// All parameters are mandatory except deploymentPatch which may be nil.
@@ -99,7 +99,17 @@ type resourceUpserter interface {
 	UpsertDoguService(ctx context.Context, doguResource *v2.Dogu, dogu *cesappcore.Dogu, image *imagev1.ConfigFile) (*corev1.Service, error)
 	// UpsertDoguPVCs generates a persistent volume claim for a given dogu and applies it to the cluster.
 	UpsertDoguPVCs(ctx context.Context, doguResource *v2.Dogu, dogu *cesappcore.Dogu) (*corev1.PersistentVolumeClaim, error)
-	UpsertDoguNetworkPolicies(ctx context.Context, doguResource *v2.Dogu, dogu *cesappcore.Dogu) error
+	// UpsertDoguNetworkPolicies generates the network policies for a given dogu and applies them to the cluster.
+	UpsertDoguNetworkPolicies(ctx context.Context, doguResource *v2.Dogu, dogu *cesappcore.Dogu, service *corev1.Service) error
+}
+
+// driftDetector compares the resources of a dogu in the cluster with their desired state.
+type driftDetector interface {
+	drift.Detector
+}
+
+type imageRegistry interface {
+	imageregistry.ImageRegistry
 }
 
 //nolint:unused
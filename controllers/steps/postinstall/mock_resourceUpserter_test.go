@@ -6,6 +6,7 @@ import (
 	context "context"
 
 	core "github.com/cloudogu/cesapp-lib/core"
+
 	corev1 "k8s.io/api/core/v1"
 
 	mock "github.com/stretchr/testify/mock"
@@ -91,17 +92,17 @@ func (_c *mockResourceUpserter_UpsertDoguDeployment_Call) RunAndReturn(run func(
 	return _c
 }
 
-// UpsertDoguNetworkPolicies provides a mock function with given fields: ctx, doguResource, dogu
-func (_m *mockResourceUpserter) UpsertDoguNetworkPolicies(ctx context.Context, doguResource *v2.Dogu, dogu *core.Dogu) error {
-	ret := _m.Called(ctx, doguResource, dogu)
+// UpsertDoguNetworkPolicies provides a mock function with given fields: ctx, doguResource, dogu, service
+func (_m *mockResourceUpserter) UpsertDoguNetworkPolicies(ctx context.Context, doguResource *v2.Dogu, dogu *core.Dogu, service *corev1.Service) error {
+	ret := _m.Called(ctx, doguResource, dogu, service)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpsertDoguNetworkPolicies")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *v2.Dogu, *core.Dogu) error); ok {
-		r0 = rf(ctx, doguResource, dogu)
+	if rf, ok := ret.Get(0).(func(context.Context, *v2.Dogu, *core.Dogu, *corev1.Service) error); ok {
+		r0 = rf(ctx, doguResource, dogu, service)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -118,13 +119,14 @@ type mockResourceUpserter_UpsertDoguNetworkPolicies_Call struct {
 //   - ctx context.Context
 //   - doguResource *v2.Dogu
 //   - dogu *core.Dogu
-func (_e *mockResourceUpserter_Expecter) UpsertDoguNetworkPolicies(ctx interface{}, doguResource interface{}, dogu interface{}) *mockResourceUpserter_UpsertDoguNetworkPolicies_Call {
-	return &mockResourceUpserter_UpsertDoguNetworkPolicies_Call{Call: _e.mock.On("UpsertDoguNetworkPolicies", ctx, doguResource, dogu)}
+//   - service *corev1.Service
+func (_e *mockResourceUpserter_Expecter) UpsertDoguNetworkPolicies(ctx interface{}, doguResource interface{}, dogu interface{}, service interface{}) *mockResourceUpserter_UpsertDoguNetworkPolicies_Call {
+	return &mockResourceUpserter_UpsertDoguNetworkPolicies_Call{Call: _e.mock.On("UpsertDoguNetworkPolicies", ctx, doguResource, dogu, service)}
 }
 
-func (_c *mockResourceUpserter_UpsertDoguNetworkPolicies_Call) Run(run func(ctx context.Context, doguResource *v2.Dogu, dogu *core.Dogu)) *mockResourceUpserter_UpsertDoguNetworkPolicies_Call {
+func (_c *mockResourceUpserter_UpsertDoguNetworkPolicies_Call) Run(run func(ctx context.Context, doguResource *v2.Dogu, dogu *core.Dogu, service *corev1.Service)) *mockResourceUpserter_UpsertDoguNetworkPolicies_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*v2.Dogu), args[2].(*core.Dogu))
+		run(args[0].(context.Context), args[1].(*v2.Dogu), args[2].(*core.Dogu), args[3].(*corev1.Service))
 	})
 	return _c
 }
@@ -134,7 +136,7 @@ func (_c *mockResourceUpserter_UpsertDoguNetworkPolicies_Call) Return(_a0 error)
 	return _c
 }
 
-func (_c *mockResourceUpserter_UpsertDoguNetworkPolicies_Call) RunAndReturn(run func(context.Context, *v2.Dogu, *core.Dogu) error) *mockResourceUpserter_UpsertDoguNetworkPolicies_Call {
+func (_c *mockResourceUpserter_UpsertDoguNetworkPolicies_Call) RunAndReturn(run func(context.Context, *v2.Dogu, *core.Dogu, *corev1.Service) error) *mockResourceUpserter_UpsertDoguNetworkPolicies_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -2,14 +2,17 @@ package postinstall
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"github.com/cloudogu/retry-lib/retry"
 
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/cesregistry"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/resource"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	v1 "k8s.io/client-go/kubernetes/typed/apps/v1"
 )
 
@@ -27,31 +30,57 @@ func NewSecurityContextStep(fetcher cesregistry.LocalDoguFetcher, generator reso
 	}
 }
 
+// Run patches only the security contexts of the deployment, so that the other fields of the deployment, e.g. the pod
+// template of a dogu in support mode, are kept.
 func (scs *SecurityContextStep) Run(ctx context.Context, doguResource *v2.Dogu) steps.StepResult {
 	dogu, err := scs.localDoguFetcher.FetchInstalled(ctx, doguResource.GetSimpleDoguName())
 	if err != nil {
 		return steps.RequeueWithError(fmt.Errorf("failed to get local descriptor for dogu %q: %w", doguResource.Name, err))
 	}
 
-	err = retry.OnConflict(func() error {
-		var retryErr error
-		deployment, retryErr := scs.deploymentInterface.Get(ctx, doguResource.Name, metav1.GetOptions{})
-		if retryErr != nil {
-			return fmt.Errorf("failed to get deployment of dogu %q: %w", doguResource.Name, retryErr)
-		}
-
-		podSecurityContext, containerSecurityContext := scs.securityContextGenerator.Generate(ctx, dogu, doguResource)
-
-		deployment.Spec.Template.Spec.SecurityContext = podSecurityContext
-		for i := range deployment.Spec.Template.Spec.Containers {
-			deployment.Spec.Template.Spec.Containers[i].SecurityContext = containerSecurityContext
-		}
+	deployment, err := scs.deploymentInterface.Get(ctx, doguResource.Name, metav1.GetOptions{})
+	if err != nil {
+		return steps.RequeueWithError(fmt.Errorf("failed to get deployment of dogu %q: %w", doguResource.Name, err))
+	}
 
-		_, retryErr = scs.deploymentInterface.Update(ctx, deployment, metav1.UpdateOptions{})
-		return retryErr
-	})
+	podSecurityContext, containerSecurityContext := scs.securityContextGenerator.Generate(ctx, dogu, doguResource)
+	patch, err := securityContextPatch(deployment, podSecurityContext, containerSecurityContext)
 	if err != nil {
 		return steps.RequeueWithError(err)
 	}
+
+	_, err = scs.deploymentInterface.Patch(ctx, doguResource.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return steps.RequeueWithError(fmt.Errorf("failed to patch security context of deployment of dogu %q: %w", doguResource.Name, err))
+	}
 	return steps.Continue()
 }
+
+// securityContextPatch creates a strategic merge patch which sets the pod security context and the security context
+// of every container of the deployment. The containers are merged by their names.
+func securityContextPatch(deployment *appsv1.Deployment, podSecurityContext *corev1.PodSecurityContext, containerSecurityContext *corev1.SecurityContext) ([]byte, error) {
+	containers := make([]map[string]any, 0, len(deployment.Spec.Template.Spec.Containers))
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		containers = append(containers, map[string]any{
+			"name":            container.Name,
+			"securityContext": containerSecurityContext,
+		})
+	}
+
+	patch := map[string]any{
+		"spec": map[string]any{
+			"template": map[string]any{
+				"spec": map[string]any{
+					"securityContext": podSecurityContext,
+					"containers":      containers,
+				},
+			},
+		},
+	}
+
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create security context patch: %w", err)
+	}
+	return data, nil
+}
@@ -1,6 +1,8 @@
 package postinstall
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -9,9 +11,12 @@ import (
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	v4 "k8s.io/api/apps/v1"
 	v3 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
 )
 
@@ -84,7 +89,7 @@ func TestSecurityContextStep_Run(t *testing.T) {
 			want: steps.RequeueWithError(fmt.Errorf("failed to get deployment of dogu %q: %w", "test", assert.AnError)),
 		},
 		{
-			name: "should fail to update deployment",
+			name: "should fail to patch deployment",
 			fields: fields{
 				localDoguFetcherFn: func(t *testing.T) localDoguFetcher {
 					mck := newMockLocalDoguFetcher(t)
@@ -101,25 +106,17 @@ func TestSecurityContextStep_Run(t *testing.T) {
 				deploymentInterfaceFn: func(t *testing.T) deploymentInterface {
 					mck := newMockDeploymentInterface(t)
 					mck.EXPECT().Get(testCtx, "test", v1.GetOptions{}).Return(&v4.Deployment{}, nil)
-					mck.EXPECT().Update(testCtx, &v4.Deployment{
-						Spec: v4.DeploymentSpec{
-							Template: v3.PodTemplateSpec{
-								Spec: v3.PodSpec{
-									SecurityContext: &v3.PodSecurityContext{},
-								},
-							},
-						},
-					}, v1.UpdateOptions{}).Return(&v4.Deployment{}, assert.AnError)
+					mck.EXPECT().Patch(testCtx, "test", types.StrategicMergePatchType, mock.Anything, v1.PatchOptions{}).Return(nil, assert.AnError)
 					return mck
 				},
 			},
 			doguResource: &v2.Dogu{
 				ObjectMeta: v1.ObjectMeta{Name: "test", Namespace: namespace},
 			},
-			want: steps.RequeueWithError(assert.AnError),
+			want: steps.RequeueWithError(fmt.Errorf("failed to patch security context of deployment of dogu %q: %w", "test", assert.AnError)),
 		},
 		{
-			name: "should succeed to update deployment",
+			name: "should succeed to patch only the security contexts of the deployment",
 			fields: fields{
 				localDoguFetcherFn: func(t *testing.T) localDoguFetcher {
 					mck := newMockLocalDoguFetcher(t)
@@ -159,58 +156,37 @@ func TestSecurityContextStep_Run(t *testing.T) {
 									Spec: v3.PodSpec{
 										Containers: []v3.Container{
 											{
-												Name: "test-container",
+												Name:  "test-container",
+												Image: "test:1.0.0",
 											},
 										},
 									},
 								},
 							},
 						}, nil)
-					mck.EXPECT().Update(testCtx, &v4.Deployment{
-						Spec: v4.DeploymentSpec{
-							Template: v3.PodTemplateSpec{
-								Spec: v3.PodSpec{
-									SecurityContext: &v3.PodSecurityContext{
-										RunAsNonRoot: pointer.Bool(true),
-									},
-									Containers: []v3.Container{
-										{
-											Name: "test-container",
-											SecurityContext: &v3.SecurityContext{
-												Capabilities: &v3.Capabilities{
-													Add:  []v3.Capability{"test"},
-													Drop: []v3.Capability{"test2"},
-												},
-												RunAsNonRoot: pointer.Bool(true),
-											},
-										},
-									},
+					mck.EXPECT().Patch(testCtx, "test", types.StrategicMergePatchType, mock.Anything, v1.PatchOptions{}).
+						Run(func(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) {
+							patched := &v4.Deployment{}
+							require.NoError(t, json.Unmarshal(data, patched))
+							assert.Equal(t, v3.PodSpec{
+								SecurityContext: &v3.PodSecurityContext{
+									RunAsNonRoot: pointer.Bool(true),
 								},
-							},
-						},
-					}, v1.UpdateOptions{}).Return(&v4.Deployment{
-						Spec: v4.DeploymentSpec{
-							Template: v3.PodTemplateSpec{
-								Spec: v3.PodSpec{
-									SecurityContext: &v3.PodSecurityContext{
-										RunAsNonRoot: pointer.Bool(true),
-									},
-									Containers: []v3.Container{
-										{
-											Name: "test-container",
-											SecurityContext: &v3.SecurityContext{
-												Capabilities: &v3.Capabilities{
-													Add:  []v3.Capability{"test"},
-													Drop: []v3.Capability{"test2"},
-												},
-												RunAsNonRoot: pointer.Bool(true),
+								Containers: []v3.Container{
+									{
+										Name: "test-container",
+										SecurityContext: &v3.SecurityContext{
+											Capabilities: &v3.Capabilities{
+												Add:  []v3.Capability{"test"},
+												Drop: []v3.Capability{"test2"},
 											},
+											RunAsNonRoot: pointer.Bool(true),
 										},
 									},
 								},
-							},
-						},
-					}, nil)
+							}, patched.Spec.Template.Spec)
+						}).
+						Return(&v4.Deployment{}, nil)
 					return mck
 				},
 			},
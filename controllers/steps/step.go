@@ -2,6 +2,8 @@ package steps
 
 import (
 	"context"
+	"reflect"
+	"strings"
 	"time"
 
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
@@ -17,6 +19,47 @@ type StepResult struct {
 	Continue     bool
 }
 
+// Outcome describes how the execution of the steps proceeds after a step has been run.
+type Outcome string
+
+const (
+	// OutcomeContinue indicates that the next step will be run.
+	OutcomeContinue Outcome = "continue"
+	// OutcomeRequeue indicates that the reconcile will be requeued after a given time.
+	OutcomeRequeue Outcome = "requeue"
+	// OutcomeAbort indicates that the reconcile stops without requeue.
+	OutcomeAbort Outcome = "abort"
+	// OutcomeError indicates that the step failed and the reconcile will be requeued.
+	OutcomeError Outcome = "error"
+	// OutcomeTerminal indicates that the step failed with an error which cannot be fixed by retrying.
+	OutcomeTerminal Outcome = "terminal"
+)
+
+// Outcome classifies the result in the same precedence the use cases evaluate it.
+func (sr StepResult) Outcome() Outcome {
+	if IsTerminal(sr.Err) {
+		return OutcomeTerminal
+	}
+	if sr.Err != nil {
+		return OutcomeError
+	}
+	if sr.RequeueAfter != 0 {
+		return OutcomeRequeue
+	}
+	if sr.Continue {
+		return OutcomeContinue
+	}
+	return OutcomeAbort
+}
+
+// NameOf returns a human-readable name of the step like "install.ValidationStep".
+func NameOf(step Step) string {
+	if step == nil {
+		return ""
+	}
+	return strings.TrimPrefix(reflect.TypeOf(step).String(), "*")
+}
+
 func RequeueAfter(requeueAfter time.Duration) StepResult {
 	return StepResult{
 		RequeueAfter: requeueAfter,
@@ -40,3 +83,17 @@ func RequeueWithError(err error) StepResult {
 		Err: err,
 	}
 }
+
+// TerminalUntilSpecChange stops the reconciles of the dogu until its spec changes.
+func TerminalUntilSpecChange(err error) StepResult {
+	return StepResult{
+		Err: NewTerminalUntilSpecChangeError(err),
+	}
+}
+
+// Terminal stops requeuing the dogu because retrying cannot fix the error.
+func Terminal(err error) StepResult {
+	return StepResult{
+		Err: NewTerminalError(err),
+	}
+}
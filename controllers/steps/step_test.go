@@ -52,3 +52,33 @@ func TestRequeueWithError(t *testing.T) {
 		assert.ErrorContains(t, result.Err, "test error")
 	})
 }
+
+func TestStepResult_Outcome(t *testing.T) {
+	tests := []struct {
+		name   string
+		result StepResult
+		want   Outcome
+	}{
+		{name: "should return continue", result: Continue(), want: OutcomeContinue},
+		{name: "should return abort", result: Abort(), want: OutcomeAbort},
+		{name: "should return requeue", result: RequeueAfter(time.Second), want: OutcomeRequeue},
+		{name: "should return error", result: RequeueWithError(assert.AnError), want: OutcomeError},
+		{name: "should prefer error over requeue", result: StepResult{Err: assert.AnError, RequeueAfter: time.Second}, want: OutcomeError},
+		{name: "should return terminal", result: Terminal(assert.AnError), want: OutcomeTerminal},
+		{name: "should return terminal until spec change", result: TerminalUntilSpecChange(assert.AnError), want: OutcomeTerminal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.result.Outcome())
+		})
+	}
+}
+
+func TestNameOf(t *testing.T) {
+	t.Run("should return package qualified type name without pointer", func(t *testing.T) {
+		assert.Equal(t, "steps.MockStep", NameOf(NewMockStep(t)))
+	})
+	t.Run("should return empty string for nil step", func(t *testing.T) {
+		assert.Equal(t, "", NameOf(nil))
+	})
+}
@@ -9,15 +9,18 @@ import (
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/cesregistry"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/coordination"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/exec"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/resource"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/upgrade"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/volumesnapshot"
 	"github.com/cloudogu/k8s-registry-lib/config"
 	"github.com/cloudogu/k8s-registry-lib/repository"
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	appsv1client "k8s.io/client-go/kubernetes/typed/apps/v1"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -36,6 +39,11 @@ type doguRegistrator interface {
 	UnregisterDogu(ctx context.Context, dogu string) error
 }
 
+// doguLocker coordinates the concurrent reconciles of the dogus of a namespace.
+type doguLocker interface {
+	coordination.Locker
+}
+
 type deploymentInterface interface {
 	appsv1client.DeploymentInterface
 }
@@ -44,6 +52,18 @@ type upgradeChecker interface {
 	upgrade.Checker
 }
 
+type snapshotStore interface {
+	upgrade.SnapshotStore
+}
+
+type progressStore interface {
+	upgrade.ProgressStore
+}
+
+type volumeSnapshotter interface {
+	volumesnapshot.Snapshotter
+}
+
 //nolint:unused
 //goland:noinspection GoUnusedType
 type doguInterface interface {
@@ -105,6 +125,10 @@ type ecosystemInterface interface {
 	doguClient.EcoSystemV2Interface
 }
 
+type eventRecorder interface {
+	record.EventRecorder
+}
+
 type deploymentManager interface {
 	GetLastStartingTime(ctx context.Context, deploymentName string) (*time.Time, error)
 }
@@ -21,23 +21,27 @@ import (
 
 const requeueAfterRevertStartupProbe = time.Second * 3
 
-// The PostUpgradeStep runs the post-upgrade script and reverts the startup probe to the previous value.
+// The PostUpgradeStep runs the post-upgrade script and reverts the startup probe and the progress deadline of the
+// deployment, which were extended to the rollout deadline for the upgrade, to their default values.
 type PostUpgradeStep struct {
 	client              k8sClient
 	localDoguFetcher    localDoguFetcher
 	deploymentInterface deploymentInterface
+	upserter            ResourceUpserter
 	doguCommandExecutor commandExecutor
 }
 
 func NewPostUpgradeStep(
 	client client.Client,
 	deploymentInterface appsv1.DeploymentInterface,
+	upserter resource.ResourceUpserter,
 	localFetcher cesregistry.LocalDoguFetcher,
 	executor exec.CommandExecutor,
 ) *PostUpgradeStep {
 	return &PostUpgradeStep{
 		client:              client,
 		deploymentInterface: deploymentInterface,
+		upserter:            upserter,
 		localDoguFetcher:    localFetcher,
 		doguCommandExecutor: executor,
 	}
@@ -56,6 +60,11 @@ func (rsps *PostUpgradeStep) Run(ctx context.Context, doguResource *v2.Dogu) ste
 
 	originalStartupProbe := resource.CreateStartupProbe(toDogu)
 	if rsps.startupProbeHasDefaultValue(deployment, toDogu.GetSimpleName(), originalStartupProbe) {
+		// the dogu has no startup probe whose extension marks the upgrade, so only the progress deadline is reverted
+		err = rsps.revertProgressDeadlineAfterUpdate(ctx, doguResource, toDogu, deployment)
+		if err != nil {
+			return steps.RequeueWithError(err)
+		}
 		return steps.Continue()
 	}
 
@@ -114,16 +123,33 @@ func (rsps *PostUpgradeStep) executePostUpgradeScript(ctx context.Context, toDog
 	return nil
 }
 
+// revertStartupProbeAfterUpdate applies the generated deployment, which contains the default startup probe and
+// progress deadline, instead of the ones extended for the upgrade.
 func (rsps *PostUpgradeStep) revertStartupProbeAfterUpdate(ctx context.Context, toDoguResource *v2.Dogu, toDogu *core.Dogu, deployment *v1.Deployment) error {
-	originalStartupProbe := resource.CreateStartupProbe(toDogu)
-
-	for i, container := range deployment.Spec.Template.Spec.Containers {
+	for _, container := range deployment.Spec.Template.Spec.Containers {
 		if container.Name == toDoguResource.Name && container.StartupProbe != nil {
-			deployment.Spec.Template.Spec.Containers[i].StartupProbe = originalStartupProbe
-			_, err := rsps.deploymentInterface.Update(ctx, deployment, metav1.UpdateOptions{})
-			return err
+			_, err := rsps.upserter.UpsertDoguDeployment(ctx, toDoguResource, toDogu, nil)
+			if err != nil {
+				return fmt.Errorf("failed to revert startup probe of deployment: %w", err)
+			}
+			return nil
 		}
 	}
 
 	return nil
 }
+
+// revertProgressDeadlineAfterUpdate applies the generated deployment, which contains the default progress deadline,
+// if the progress deadline is still extended for the upgrade.
+func (rsps *PostUpgradeStep) revertProgressDeadlineAfterUpdate(ctx context.Context, toDoguResource *v2.Dogu, toDogu *core.Dogu, deployment *v1.Deployment) error {
+	if deployment.Spec.ProgressDeadlineSeconds == nil || *deployment.Spec.ProgressDeadlineSeconds == resource.DefaultProgressDeadlineSeconds {
+		return nil
+	}
+
+	_, err := rsps.upserter.UpsertDoguDeployment(ctx, toDoguResource, toDogu, nil)
+	if err != nil {
+		return fmt.Errorf("failed to revert progress deadline of deployment: %w", err)
+	}
+
+	return nil
+}
@@ -9,9 +9,11 @@ import (
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -20,6 +22,7 @@ func TestNewRevertStartupProbeStep(t *testing.T) {
 		step := NewPostUpgradeStep(
 			newMockK8sClient(t),
 			nil,
+			newMockResourceUpserter(t),
 			nil,
 			newMockCommandExecutor(t),
 		)
@@ -33,6 +36,7 @@ func TestRevertStartupProbeStep_Run(t *testing.T) {
 		clientFn              func(t *testing.T) k8sClient
 		localDoguFetcherFn    func(t *testing.T) localDoguFetcher
 		deploymentInterfaceFn func(t *testing.T) deploymentInterface
+		upserterFn            func(t *testing.T) ResourceUpserter
 		doguCommandExecutorFn func(t *testing.T) commandExecutor
 	}
 	tests := []struct {
@@ -121,7 +125,7 @@ func TestRevertStartupProbeStep_Run(t *testing.T) {
 			want:         steps.Continue(),
 		},
 		{
-			name: "should fail to revert startup probe",
+			name: "should revert the progress deadline if the startup probe has its default value",
 			fields: fields{
 				clientFn: func(t *testing.T) k8sClient {
 					return newMockK8sClient(t)
@@ -130,32 +134,64 @@ func TestRevertStartupProbeStep_Run(t *testing.T) {
 					mck := newMockDeploymentInterface(t)
 					mck.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(&appsv1.Deployment{
 						Spec: appsv1.DeploymentSpec{
+							ProgressDeadlineSeconds: ptr.To(int32(10800)),
 							Template: v1.PodTemplateSpec{
 								Spec: v1.PodSpec{
 									Containers: []v1.Container{
 										{
-											Name:         "test",
-											StartupProbe: &v1.Probe{},
+											Name: "test",
 										},
 									},
 								},
 							},
 						},
 					}, nil)
-					mck.EXPECT().Update(testCtx, &appsv1.Deployment{
+					return mck
+				},
+				upserterFn: func(t *testing.T) ResourceUpserter {
+					mck := newMockResourceUpserter(t)
+					mck.EXPECT().UpsertDoguDeployment(testCtx, &v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test"}}, &core.Dogu{Name: "official/test"}, mock.Anything).Return(&appsv1.Deployment{}, nil)
+					return mck
+				},
+				localDoguFetcherFn: func(t *testing.T) localDoguFetcher {
+					mck := newMockLocalDoguFetcher(t)
+					mck.EXPECT().FetchForResource(testCtx, &v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test"}}).Return(&core.Dogu{Name: "official/test"}, nil)
+					return mck
+				},
+				doguCommandExecutorFn: func(t *testing.T) commandExecutor {
+					return newMockCommandExecutor(t)
+				},
+			},
+			doguResource: &v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
+			want:         steps.Continue(),
+		},
+		{
+			name: "should fail to revert startup probe",
+			fields: fields{
+				clientFn: func(t *testing.T) k8sClient {
+					return newMockK8sClient(t)
+				},
+				deploymentInterfaceFn: func(t *testing.T) deploymentInterface {
+					mck := newMockDeploymentInterface(t)
+					mck.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(&appsv1.Deployment{
 						Spec: appsv1.DeploymentSpec{
 							Template: v1.PodTemplateSpec{
 								Spec: v1.PodSpec{
 									Containers: []v1.Container{
 										{
 											Name:         "test",
-											StartupProbe: nil,
+											StartupProbe: &v1.Probe{},
 										},
 									},
 								},
 							},
 						},
-					}, metav1.UpdateOptions{}).Return(nil, assert.AnError)
+					}, nil)
+					return mck
+				},
+				upserterFn: func(t *testing.T) ResourceUpserter {
+					mck := newMockResourceUpserter(t)
+					mck.EXPECT().UpsertDoguDeployment(testCtx, &v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test"}}, &core.Dogu{Name: "official/test"}, mock.Anything).Return(nil, assert.AnError)
 					return mck
 				},
 				localDoguFetcherFn: func(t *testing.T) localDoguFetcher {
@@ -169,7 +205,7 @@ func TestRevertStartupProbeStep_Run(t *testing.T) {
 				},
 			},
 			doguResource: &v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
-			want:         steps.RequeueWithError(assert.AnError),
+			want:         steps.RequeueWithError(fmt.Errorf("failed to revert startup probe of deployment: %w", assert.AnError)),
 		},
 		{
 			name: "should succeed to revert startup probe",
@@ -193,20 +229,11 @@ func TestRevertStartupProbeStep_Run(t *testing.T) {
 							},
 						},
 					}, nil)
-					mck.EXPECT().Update(testCtx, &appsv1.Deployment{
-						Spec: appsv1.DeploymentSpec{
-							Template: v1.PodTemplateSpec{
-								Spec: v1.PodSpec{
-									Containers: []v1.Container{
-										{
-											Name:         "test",
-											StartupProbe: nil,
-										},
-									},
-								},
-							},
-						},
-					}, metav1.UpdateOptions{}).Return(nil, nil)
+					return mck
+				},
+				upserterFn: func(t *testing.T) ResourceUpserter {
+					mck := newMockResourceUpserter(t)
+					mck.EXPECT().UpsertDoguDeployment(testCtx, &v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test"}}, &core.Dogu{Name: "official/test"}, mock.Anything).Return(&appsv1.Deployment{}, nil)
 					return mck
 				},
 				localDoguFetcherFn: func(t *testing.T) localDoguFetcher {
@@ -300,9 +327,14 @@ func TestRevertStartupProbeStep_Run(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			upserterFn := tt.fields.upserterFn
+			if upserterFn == nil {
+				upserterFn = func(t *testing.T) ResourceUpserter { return newMockResourceUpserter(t) }
+			}
 			rsps := &PostUpgradeStep{
 				client:              tt.fields.clientFn(t),
 				deploymentInterface: tt.fields.deploymentInterfaceFn(t),
+				upserter:            upserterFn(t),
 				doguCommandExecutor: tt.fields.doguCommandExecutorFn(t),
 				localDoguFetcher:    tt.fields.localDoguFetcherFn(t),
 			}
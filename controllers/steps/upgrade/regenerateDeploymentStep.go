@@ -5,28 +5,24 @@ import (
 
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/cesregistry"
-	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/resource"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/drift"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	v3 "k8s.io/client-go/kubernetes/typed/apps/v1"
 )
 
 // The RegenerateDeploymentStep updates the deployment with the current status of the dogu cr.
+// The deployment is not applied again while its drift is only reported, see drift.IsReportedOnly.
 type RegenerateDeploymentStep struct {
-	localDoguFetcher    localDoguFetcher
-	deploymentInterface deploymentInterface
-	resourceGenerator   resourceGenerator
+	localDoguFetcher localDoguFetcher
+	upserter         ResourceUpserter
 }
 
 func NewRegenerateDeploymentStep(
 	fetcher cesregistry.LocalDoguFetcher,
-	deploymentInterface v3.DeploymentInterface,
-	resourceGenerator resource.DoguResourceGenerator,
+	upserter ResourceUpserter,
 ) *RegenerateDeploymentStep {
 	return &RegenerateDeploymentStep{
-		localDoguFetcher:    fetcher,
-		deploymentInterface: deploymentInterface,
-		resourceGenerator:   resourceGenerator,
+		localDoguFetcher: fetcher,
+		upserter:         upserter,
 	}
 }
 
@@ -36,10 +32,8 @@ func (dus *RegenerateDeploymentStep) Run(ctx context.Context, doguResource *v2.D
 	if doguResource.Status.InstalledVersion != doguResource.Spec.Version {
 		return steps.Continue()
 	}
-
-	actual, err := dus.deploymentInterface.Get(ctx, doguResource.Name, v1.GetOptions{})
-	if err != nil {
-		return steps.RequeueWithError(err)
+	if drift.IsReportedOnly(doguResource, drift.KindDeployment) {
+		return steps.Continue()
 	}
 
 	dogu, err := dus.localDoguFetcher.FetchForResource(ctx, doguResource)
@@ -47,12 +41,7 @@ func (dus *RegenerateDeploymentStep) Run(ctx context.Context, doguResource *v2.D
 		return steps.RequeueWithError(err)
 	}
 
-	target, err := dus.resourceGenerator.UpdateDoguDeployment(ctx, actual, doguResource, dogu)
-	if err != nil {
-		return steps.RequeueWithError(err)
-	}
-
-	_, err = dus.deploymentInterface.Update(ctx, target, v1.UpdateOptions{})
+	_, err = dus.upserter.UpsertDoguDeployment(ctx, doguResource, dogu, nil)
 	if err != nil {
 		return steps.RequeueWithError(err)
 	}
@@ -7,26 +7,25 @@ import (
 
 	"github.com/cloudogu/cesapp-lib/core"
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/drift"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestNewDeploymentUpdaterStep(t *testing.T) {
 	t.Run("Successfully created step", func(t *testing.T) {
 		fetcher := newMockLocalDoguFetcher(t)
-		deployInterface := newMockDeploymentInterface(t)
-		resourceGen := newMockResourceGenerator(t)
+		upserter := NewMockResourceUpserter(t)
 		step := NewRegenerateDeploymentStep(
 			fetcher,
-			deployInterface,
-			resourceGen,
+			upserter,
 		)
 
 		assert.NotNil(t, step)
 		assert.Equal(t, fetcher, step.localDoguFetcher)
-		assert.Equal(t, deployInterface, step.deploymentInterface)
-		assert.Equal(t, resourceGen, step.resourceGenerator)
+		assert.Equal(t, upserter, step.upserter)
 	})
 }
 
@@ -51,20 +50,30 @@ func TestDeploymentUpdaterStep_Run(t *testing.T) {
 		},
 	}
 
-	deployment := &appsv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{Name: "test"},
-	}
-
-	deploymentToUpdate := &appsv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{Name: "test", Labels: map[string]string{"foo": "bar"}},
+	doguDriftReportedResource := &v2.Dogu{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Generation: 2},
+		Spec: v2.DoguSpec{
+			Version: "1.0.0",
+		},
+		Status: v2.DoguStatus{
+			InstalledVersion: "1.0.0",
+			Conditions: []metav1.Condition{
+				{
+					Type:               drift.ConditionDrifted,
+					Status:             metav1.ConditionTrue,
+					Reason:             drift.ReasonDriftReported,
+					Message:            (&drift.Report{Drifts: []drift.ResourceDrift{{Kind: drift.KindDeployment, Name: "test", Fields: []string{"spec.template.spec.containers[0].image"}}}}).Message(),
+					ObservedGeneration: 2,
+				},
+			},
+		},
 	}
 
 	doguDescriptor := &core.Dogu{Name: "test"}
 
 	type fields struct {
-		deploymentInterfaceFn func(t *testing.T) deploymentInterface
-		localDoguFetcherFn    func(t *testing.T) localDoguFetcher
-		resourceGeneratorFn   func(t *testing.T) resourceGenerator
+		upserterFn         func(t *testing.T) ResourceUpserter
+		localDoguFetcherFn func(t *testing.T) localDoguFetcher
 	}
 	tests := []struct {
 		name         string
@@ -75,11 +84,8 @@ func TestDeploymentUpdaterStep_Run(t *testing.T) {
 		{
 			name: "should do nothing on upgrade (deployment should already be updated earlier)",
 			fields: fields{
-				deploymentInterfaceFn: func(t *testing.T) deploymentInterface {
-					return newMockDeploymentInterface(t)
-				},
-				resourceGeneratorFn: func(t *testing.T) resourceGenerator {
-					return newMockResourceGenerator(t)
+				upserterFn: func(t *testing.T) ResourceUpserter {
+					return NewMockResourceUpserter(t)
 				},
 				localDoguFetcherFn: func(t *testing.T) localDoguFetcher {
 					return newMockLocalDoguFetcher(t)
@@ -89,34 +95,23 @@ func TestDeploymentUpdaterStep_Run(t *testing.T) {
 			want:         steps.Continue(),
 		},
 		{
-			name: "should requeue on deployment fetch error",
+			name: "should not apply deployment again while its drift is only reported",
 			fields: fields{
-				deploymentInterfaceFn: func(t *testing.T) deploymentInterface {
-					mck := newMockDeploymentInterface(t)
-					mck.EXPECT().Get(testCtx, doguNoUpgradeResource.Name, metav1.GetOptions{}).Return(nil, assert.AnError)
-					return mck
-				},
-				resourceGeneratorFn: func(t *testing.T) resourceGenerator {
-					return newMockResourceGenerator(t)
+				upserterFn: func(t *testing.T) ResourceUpserter {
+					return NewMockResourceUpserter(t)
 				},
 				localDoguFetcherFn: func(t *testing.T) localDoguFetcher {
-					mck := newMockLocalDoguFetcher(t)
-					return mck
+					return newMockLocalDoguFetcher(t)
 				},
 			},
-			doguResource: doguNoUpgradeResource,
-			want:         steps.RequeueWithError(assert.AnError),
+			doguResource: doguDriftReportedResource,
+			want:         steps.Continue(),
 		},
 		{
 			name: "should requeue on dogu fetch error",
 			fields: fields{
-				deploymentInterfaceFn: func(t *testing.T) deploymentInterface {
-					mck := newMockDeploymentInterface(t)
-					mck.EXPECT().Get(testCtx, doguNoUpgradeResource.Name, metav1.GetOptions{}).Return(deployment, nil)
-					return mck
-				},
-				resourceGeneratorFn: func(t *testing.T) resourceGenerator {
-					return newMockResourceGenerator(t)
+				upserterFn: func(t *testing.T) ResourceUpserter {
+					return NewMockResourceUpserter(t)
 				},
 				localDoguFetcherFn: func(t *testing.T) localDoguFetcher {
 					mck := newMockLocalDoguFetcher(t)
@@ -128,39 +123,11 @@ func TestDeploymentUpdaterStep_Run(t *testing.T) {
 			want:         steps.RequeueWithError(assert.AnError),
 		},
 		{
-			name: "should fail to create update deployment",
-			fields: fields{
-				deploymentInterfaceFn: func(t *testing.T) deploymentInterface {
-					mck := newMockDeploymentInterface(t)
-					mck.EXPECT().Get(testCtx, doguNoUpgradeResource.Name, metav1.GetOptions{}).Return(deployment, nil)
-					return mck
-				},
-				resourceGeneratorFn: func(t *testing.T) resourceGenerator {
-					mck := newMockResourceGenerator(t)
-					mck.EXPECT().UpdateDoguDeployment(testCtx, deployment, doguNoUpgradeResource, doguDescriptor).Return(nil, assert.AnError)
-					return mck
-				},
-				localDoguFetcherFn: func(t *testing.T) localDoguFetcher {
-					mck := newMockLocalDoguFetcher(t)
-					mck.EXPECT().FetchForResource(testCtx, doguNoUpgradeResource).Return(doguDescriptor, nil)
-					return mck
-				},
-			},
-			doguResource: doguNoUpgradeResource,
-			want:         steps.RequeueWithError(assert.AnError),
-		},
-		{
-			name: "should fail to update deployment",
+			name: "should fail to apply deployment",
 			fields: fields{
-				deploymentInterfaceFn: func(t *testing.T) deploymentInterface {
-					mck := newMockDeploymentInterface(t)
-					mck.EXPECT().Get(testCtx, doguNoUpgradeResource.Name, metav1.GetOptions{}).Return(deployment, nil)
-					mck.EXPECT().Update(testCtx, deploymentToUpdate, metav1.UpdateOptions{}).Return(nil, assert.AnError)
-					return mck
-				},
-				resourceGeneratorFn: func(t *testing.T) resourceGenerator {
-					mck := newMockResourceGenerator(t)
-					mck.EXPECT().UpdateDoguDeployment(testCtx, deployment, doguNoUpgradeResource, doguDescriptor).Return(deploymentToUpdate, nil)
+				upserterFn: func(t *testing.T) ResourceUpserter {
+					mck := NewMockResourceUpserter(t)
+					mck.EXPECT().UpsertDoguDeployment(testCtx, doguNoUpgradeResource, doguDescriptor, mock.AnythingOfType("func(*v1.Deployment)")).Return(nil, assert.AnError)
 					return mck
 				},
 				localDoguFetcherFn: func(t *testing.T) localDoguFetcher {
@@ -173,17 +140,11 @@ func TestDeploymentUpdaterStep_Run(t *testing.T) {
 			want:         steps.RequeueWithError(assert.AnError),
 		},
 		{
-			name: "should succeed to update deployment",
+			name: "should succeed to apply deployment",
 			fields: fields{
-				deploymentInterfaceFn: func(t *testing.T) deploymentInterface {
-					mck := newMockDeploymentInterface(t)
-					mck.EXPECT().Get(testCtx, doguNoUpgradeResource.Name, metav1.GetOptions{}).Return(deployment, nil)
-					mck.EXPECT().Update(testCtx, deploymentToUpdate, metav1.UpdateOptions{}).Return(nil, nil)
-					return mck
-				},
-				resourceGeneratorFn: func(t *testing.T) resourceGenerator {
-					mck := newMockResourceGenerator(t)
-					mck.EXPECT().UpdateDoguDeployment(testCtx, deployment, doguNoUpgradeResource, doguDescriptor).Return(deploymentToUpdate, nil)
+				upserterFn: func(t *testing.T) ResourceUpserter {
+					mck := NewMockResourceUpserter(t)
+					mck.EXPECT().UpsertDoguDeployment(testCtx, doguNoUpgradeResource, doguDescriptor, mock.AnythingOfType("func(*v1.Deployment)")).Return(&appsv1.Deployment{}, nil)
 					return mck
 				},
 				localDoguFetcherFn: func(t *testing.T) localDoguFetcher {
@@ -199,9 +160,8 @@ func TestDeploymentUpdaterStep_Run(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			dus := &RegenerateDeploymentStep{
-				localDoguFetcher:    tt.fields.localDoguFetcherFn(t),
-				deploymentInterface: tt.fields.deploymentInterfaceFn(t),
-				resourceGenerator:   tt.fields.resourceGeneratorFn(t),
+				localDoguFetcher: tt.fields.localDoguFetcherFn(t),
+				upserter:         tt.fields.upserterFn(t),
 			}
 			assert.Equalf(t, tt.want, dus.Run(testCtx, tt.doguResource), "Run(%v, %v)", testCtx, tt.doguResource)
 		})
@@ -4,13 +4,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	cescommons "github.com/cloudogu/ces-commons-lib/dogu"
+	cloudoguerrors "github.com/cloudogu/ces-commons-lib/errors"
+	"github.com/cloudogu/cesapp-lib/core"
 	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/cesregistry"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/coordination"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/initfx"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	"github.com/cloudogu/k8s-registry-lib/config"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 const (
@@ -18,24 +27,35 @@ const (
 	serviceAccountKindDefault = ""
 )
 
+const requeueAfterNamespaceLocked = 5 * time.Second
+
 // RetroactiveServiceAccountStep issues reconcile events for dogus that define a service account for the currently reconciled dogu.
 // The service account will then be created by install.ServiceAccountStep if it does not exist.
 // This ensures that e.g. service accounts for optional dependencies get created retroactively when that dependency gets installed.
+// The step runs on every reconcile, but only locks the namespace if an installed dogu declares a service account for
+// the reconciled dogu which does not exist yet. The reconcile events are then issued once no other dogu of the namespace
+// is being reconciled.
 type RetroactiveServiceAccountStep struct {
-	doguEvents       chan<- event.TypedGenericEvent[*doguv2.Dogu]
-	doguClient       doguInterface
-	localDoguFetcher localDoguFetcher
+	doguEvents              chan<- event.TypedGenericEvent[*doguv2.Dogu]
+	doguClient              doguInterface
+	localDoguFetcher        localDoguFetcher
+	locker                  doguLocker
+	sensitiveDoguRepository doguConfigRepository
 }
 
 func NewRetroactiveServiceAccountStep(
 	doguEvents chan<- event.TypedGenericEvent[*doguv2.Dogu],
 	doguClient doguClient.DoguInterface,
 	localDoguFetcher cesregistry.LocalDoguFetcher,
+	locker coordination.Locker,
+	sensitiveDoguConfigRepo initfx.DoguConfigRepository,
 ) *RetroactiveServiceAccountStep {
 	return &RetroactiveServiceAccountStep{
-		doguEvents:       doguEvents,
-		doguClient:       doguClient,
-		localDoguFetcher: localDoguFetcher,
+		doguEvents:              doguEvents,
+		doguClient:              doguClient,
+		localDoguFetcher:        localDoguFetcher,
+		locker:                  locker,
+		sensitiveDoguRepository: sensitiveDoguConfigRepo,
 	}
 }
 
@@ -45,21 +65,39 @@ func (r *RetroactiveServiceAccountStep) Run(ctx context.Context, resource *doguv
 		return steps.RequeueWithError(fmt.Errorf("list dogus for retroactive service accounts: %w", err))
 	}
 
+	var consumers []*doguv2.Dogu
 	var errs []error
-	for _, dogu := range doguList.Items {
-		doguDescriptor, fetchErr := r.localDoguFetcher.FetchForResource(ctx, &dogu)
+	for i := range doguList.Items {
+		dogu := &doguList.Items[i]
+		doguDescriptor, fetchErr := r.localDoguFetcher.FetchForResource(ctx, dogu)
 		if fetchErr != nil {
 			errs = append(errs, fetchErr)
 			continue
 		}
 
-		for _, serviceAccount := range doguDescriptor.ServiceAccounts {
-			if (serviceAccount.Kind == serviceAccountKindDogu ||
-				serviceAccount.Kind == serviceAccountKindDefault) &&
-				serviceAccount.Type == resource.Name {
-				r.doguEvents <- event.TypedGenericEvent[*doguv2.Dogu]{Object: &dogu}
-				break // only one reconcile necessary per dogu
-			}
+		if !declaresServiceAccountFor(doguDescriptor, resource.Name) {
+			continue
+		}
+
+		missing, missingErr := r.isServiceAccountMissing(ctx, dogu, resource.Name)
+		if missingErr != nil {
+			errs = append(errs, missingErr)
+			continue
+		}
+		if missing {
+			consumers = append(consumers, dogu)
+		}
+	}
+
+	if len(consumers) > 0 {
+		if !r.locker.TryLockNamespace(resource.GetObjectKey()) {
+			log.FromContext(ctx).Info("Other dogus of the namespace are being reconciled; requeueing retroactive service accounts", "requeueAfter", requeueAfterNamespaceLocked)
+			return steps.RequeueAfter(requeueAfterNamespaceLocked)
+		}
+
+		for _, consumer := range consumers {
+			// only one reconcile necessary per dogu
+			r.doguEvents <- event.TypedGenericEvent[*doguv2.Dogu]{Object: consumer}
 		}
 	}
 
@@ -69,3 +107,40 @@ func (r *RetroactiveServiceAccountStep) Run(ctx context.Context, resource *doguv
 
 	return steps.Continue()
 }
+
+// isServiceAccountMissing returns true if the sensitive config of the consumer contains no credentials of a service
+// account of the given dogu. The service accounts of a consumer without sensitive config are created with its
+// installation.
+func (r *RetroactiveServiceAccountStep) isServiceAccountMissing(ctx context.Context, consumer *doguv2.Dogu, doguName string) (bool, error) {
+	sensitiveConfig, err := r.sensitiveDoguRepository.Get(ctx, cescommons.SimpleName(consumer.Name))
+	if cloudoguerrors.IsNotFoundError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("get sensitive config of dogu %q: %w", consumer.Name, err)
+	}
+
+	return !hasServiceAccount(sensitiveConfig, doguName), nil
+}
+
+func hasServiceAccount(sensitiveConfig config.DoguConfig, doguName string) bool {
+	registryCredentialPath := "sa-" + doguName + "/"
+	for key := range sensitiveConfig.GetAll() {
+		if strings.HasPrefix(key.String(), registryCredentialPath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func declaresServiceAccountFor(doguDescriptor *core.Dogu, doguName string) bool {
+	for _, serviceAccount := range doguDescriptor.ServiceAccounts {
+		if (serviceAccount.Kind == serviceAccountKindDogu || serviceAccount.Kind == serviceAccountKindDefault) &&
+			serviceAccount.Type == doguName {
+			return true
+		}
+	}
+
+	return false
+}
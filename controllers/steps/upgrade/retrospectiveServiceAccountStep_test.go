@@ -5,9 +5,13 @@ import (
 	"fmt"
 	"testing"
 
+	cescommons "github.com/cloudogu/ces-commons-lib/dogu"
+	cloudoguerrors "github.com/cloudogu/ces-commons-lib/errors"
 	"github.com/cloudogu/cesapp-lib/core"
 	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/coordination"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	"github.com/cloudogu/k8s-registry-lib/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -18,18 +22,23 @@ func TestNewRetroactiveServiceAccountStep(t *testing.T) {
 	doguEvents := make(chan event.TypedGenericEvent[*doguv2.Dogu])
 	doguClient := newMockDoguInterface(t)
 	localDoguFetcher := newMockLocalDoguFetcher(t)
-	step := NewRetroactiveServiceAccountStep(doguEvents, doguClient, localDoguFetcher)
+	locker := newMockDoguLocker(t)
+	sensitiveDoguRepository := newMockDoguConfigRepository(t)
+	step := NewRetroactiveServiceAccountStep(doguEvents, doguClient, localDoguFetcher, locker, sensitiveDoguRepository)
 
 	require.NotNil(t, step)
 	assert.NotNil(t, step.doguEvents)
 	assert.Same(t, doguClient, step.doguClient)
 	assert.Same(t, localDoguFetcher, step.localDoguFetcher)
+	assert.Same(t, locker, step.locker)
+	assert.Same(t, sensitiveDoguRepository, step.sensitiveDoguRepository)
 }
 
 func TestRetroactiveServiceAccountStep_Run(t *testing.T) {
 	type fields struct {
-		doguClientFn       func(t *testing.T) doguInterface
-		localDoguFetcherFn func(t *testing.T) localDoguFetcher
+		doguClientFn              func(t *testing.T) doguInterface
+		localDoguFetcherFn        func(t *testing.T) localDoguFetcher
+		sensitiveDoguRepositoryFn func(t *testing.T) doguConfigRepository
 	}
 	tests := []struct {
 		name           string
@@ -50,6 +59,9 @@ func TestRetroactiveServiceAccountStep_Run(t *testing.T) {
 					mck := newMockLocalDoguFetcher(t)
 					return mck
 				},
+				sensitiveDoguRepositoryFn: func(t *testing.T) doguConfigRepository {
+					return newMockDoguConfigRepository(t)
+				},
 			},
 			resource:       &doguv2.Dogu{},
 			expectedEvents: nil,
@@ -75,6 +87,9 @@ func TestRetroactiveServiceAccountStep_Run(t *testing.T) {
 						Return(nil, assert.AnError)
 					return mck
 				},
+				sensitiveDoguRepositoryFn: func(t *testing.T) doguConfigRepository {
+					return newMockDoguConfigRepository(t)
+				},
 			},
 			resource:       &doguv2.Dogu{},
 			expectedEvents: nil,
@@ -88,6 +103,8 @@ func TestRetroactiveServiceAccountStep_Run(t *testing.T) {
 					doguList := &doguv2.DoguList{Items: []doguv2.Dogu{
 						{ObjectMeta: metav1.ObjectMeta{Name: "dogu1"}},
 						{ObjectMeta: metav1.ObjectMeta{Name: "dogu2"}},
+						{ObjectMeta: metav1.ObjectMeta{Name: "dogu3"}},
+						{ObjectMeta: metav1.ObjectMeta{Name: "dogu4"}},
 					}}
 					mck.EXPECT().List(testCtx, metav1.ListOptions{}).Return(doguList, nil)
 					return mck
@@ -106,6 +123,26 @@ func TestRetroactiveServiceAccountStep_Run(t *testing.T) {
 							{Kind: "", Type: "dogu0"},
 							{Kind: "dogu", Type: "dogu0"},
 						}}, nil)
+					mck.EXPECT().FetchForResource(testCtx, &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "dogu3"}}).
+						Return(&core.Dogu{ServiceAccounts: []core.ServiceAccount{
+							{Kind: "dogu", Type: "dogu0"},
+						}}, nil)
+					mck.EXPECT().FetchForResource(testCtx, &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "dogu4"}}).
+						Return(&core.Dogu{ServiceAccounts: []core.ServiceAccount{
+							{Kind: "dogu", Type: "dogu0"},
+						}}, nil)
+					return mck
+				},
+				sensitiveDoguRepositoryFn: func(t *testing.T) doguConfigRepository {
+					mck := newMockDoguConfigRepository(t)
+					mck.EXPECT().Get(testCtx, cescommons.SimpleName("dogu1")).
+						Return(config.CreateDoguConfig("dogu1", config.Entries{"sa-not-dogu0/username": "user"}), nil)
+					mck.EXPECT().Get(testCtx, cescommons.SimpleName("dogu2")).
+						Return(config.CreateDoguConfig("dogu2", config.Entries{}), nil)
+					mck.EXPECT().Get(testCtx, cescommons.SimpleName("dogu3")).
+						Return(config.CreateDoguConfig("dogu3", config.Entries{"sa-dogu0/username": "user"}), nil)
+					mck.EXPECT().Get(testCtx, cescommons.SimpleName("dogu4")).
+						Return(config.DoguConfig{}, cloudoguerrors.NewNotFoundError(assert.AnError))
 					return mck
 				},
 			},
@@ -123,9 +160,11 @@ func TestRetroactiveServiceAccountStep_Run(t *testing.T) {
 			defer close(doguEvents)
 
 			r := &RetroactiveServiceAccountStep{
-				doguEvents:       doguEvents,
-				doguClient:       tt.fields.doguClientFn(t),
-				localDoguFetcher: tt.fields.localDoguFetcherFn(t),
+				doguEvents:              doguEvents,
+				doguClient:              tt.fields.doguClientFn(t),
+				localDoguFetcher:        tt.fields.localDoguFetcherFn(t),
+				locker:                  coordination.NewLocker(),
+				sensitiveDoguRepository: tt.fields.sensitiveDoguRepositoryFn(t),
 			}
 
 			go func() {
@@ -141,3 +180,84 @@ func TestRetroactiveServiceAccountStep_Run(t *testing.T) {
 		})
 	}
 }
+
+func TestRetroactiveServiceAccountStep_Run_namespaceLocked(t *testing.T) {
+	t.Run("should requeue if a service account is missing and the namespace is locked", func(t *testing.T) {
+		// given
+		resource := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "dogu0", Namespace: "ecosystem"}}
+		consumer := doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "dogu1", Namespace: "ecosystem"}}
+		doguClient := newMockDoguInterface(t)
+		doguClient.EXPECT().List(testCtx, metav1.ListOptions{}).Return(&doguv2.DoguList{Items: []doguv2.Dogu{consumer}}, nil)
+		fetcher := newMockLocalDoguFetcher(t)
+		fetcher.EXPECT().FetchForResource(testCtx, &consumer).
+			Return(&core.Dogu{ServiceAccounts: []core.ServiceAccount{{Kind: "dogu", Type: "dogu0"}}}, nil)
+		sensitiveDoguRepository := newMockDoguConfigRepository(t)
+		sensitiveDoguRepository.EXPECT().Get(testCtx, cescommons.SimpleName("dogu1")).Return(config.CreateDoguConfig("dogu1", config.Entries{}), nil)
+		locker := newMockDoguLocker(t)
+		locker.EXPECT().TryLockNamespace(resource.GetObjectKey()).Return(false)
+		sut := NewRetroactiveServiceAccountStep(nil, doguClient, fetcher, locker, sensitiveDoguRepository)
+
+		// when
+		result := sut.Run(testCtx, resource)
+
+		// then
+		assert.Equal(t, steps.RequeueAfter(requeueAfterNamespaceLocked), result)
+	})
+	t.Run("should not lock the namespace if no dogu declares a service account", func(t *testing.T) {
+		// given
+		resource := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "dogu0", Namespace: "ecosystem"}}
+		other := doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "dogu1", Namespace: "ecosystem"}}
+		doguClient := newMockDoguInterface(t)
+		doguClient.EXPECT().List(testCtx, metav1.ListOptions{}).Return(&doguv2.DoguList{Items: []doguv2.Dogu{other}}, nil)
+		fetcher := newMockLocalDoguFetcher(t)
+		fetcher.EXPECT().FetchForResource(testCtx, &other).
+			Return(&core.Dogu{ServiceAccounts: []core.ServiceAccount{{Kind: "dogu", Type: "postgresql"}}}, nil)
+		sut := NewRetroactiveServiceAccountStep(nil, doguClient, fetcher, newMockDoguLocker(t), newMockDoguConfigRepository(t))
+
+		// when
+		result := sut.Run(testCtx, resource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should not lock the namespace if the declared service account exists", func(t *testing.T) {
+		// given
+		resource := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "dogu0", Namespace: "ecosystem"}}
+		consumer := doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "dogu1", Namespace: "ecosystem"}}
+		doguClient := newMockDoguInterface(t)
+		doguClient.EXPECT().List(testCtx, metav1.ListOptions{}).Return(&doguv2.DoguList{Items: []doguv2.Dogu{consumer}}, nil)
+		fetcher := newMockLocalDoguFetcher(t)
+		fetcher.EXPECT().FetchForResource(testCtx, &consumer).
+			Return(&core.Dogu{ServiceAccounts: []core.ServiceAccount{{Kind: "dogu", Type: "dogu0"}}}, nil)
+		sensitiveDoguRepository := newMockDoguConfigRepository(t)
+		sensitiveDoguRepository.EXPECT().Get(testCtx, cescommons.SimpleName("dogu1")).
+			Return(config.CreateDoguConfig("dogu1", config.Entries{"sa-dogu0/password": "secret"}), nil)
+		sut := NewRetroactiveServiceAccountStep(nil, doguClient, fetcher, newMockDoguLocker(t), sensitiveDoguRepository)
+
+		// when
+		result := sut.Run(testCtx, resource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should fail to get the sensitive config of a consumer", func(t *testing.T) {
+		// given
+		resource := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "dogu0", Namespace: "ecosystem"}}
+		consumer := doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "dogu1", Namespace: "ecosystem"}}
+		doguClient := newMockDoguInterface(t)
+		doguClient.EXPECT().List(testCtx, metav1.ListOptions{}).Return(&doguv2.DoguList{Items: []doguv2.Dogu{consumer}}, nil)
+		fetcher := newMockLocalDoguFetcher(t)
+		fetcher.EXPECT().FetchForResource(testCtx, &consumer).
+			Return(&core.Dogu{ServiceAccounts: []core.ServiceAccount{{Kind: "dogu", Type: "dogu0"}}}, nil)
+		sensitiveDoguRepository := newMockDoguConfigRepository(t)
+		sensitiveDoguRepository.EXPECT().Get(testCtx, cescommons.SimpleName("dogu1")).Return(config.DoguConfig{}, assert.AnError)
+		sut := NewRetroactiveServiceAccountStep(nil, doguClient, fetcher, newMockDoguLocker(t), sensitiveDoguRepository)
+
+		// when
+		result := sut.Run(testCtx, resource)
+
+		// then
+		require.ErrorIs(t, result.Err, assert.AnError)
+		assert.ErrorContains(t, result.Err, "get sensitive config of dogu \"dogu1\"")
+	})
+}
@@ -0,0 +1,414 @@
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	cesappcore "github.com/cloudogu/cesapp-lib/core"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/cesregistry"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/exec"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/resource"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/upgrade"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/volumesnapshot"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	appsv1 "k8s.io/client-go/kubernetes/typed/apps/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	rolledBackEventReason      = "UpgradeRolledBack"
+	rollbackRefusedEventReason = "UpgradeRollbackRefused"
+)
+
+// The RollbackStep rolls back failed upgrades of dogus which opted in with the upgrade.RollbackAnnotation.
+// Before the upgrade starts, the installed version and the pod template of the deployment are saved in a snapshot.
+// If the new version cannot start or a phase of the upgrade does not finish within its deadline, the previous version
+// is registered as current version again and the deployment is reset to the saved pod template.
+// Once the data volume may have been changed by the upgrade, the upgrade is only rolled back together with a restore
+// of the data volume from the volume snapshot taken before the upgrade. Without such a volume snapshot the rollback is
+// refused and the failed upgrade is only reported.
+// A rolled back upgrade is not retried until the spec version of the dogu changes. The pipeline skips the steps applying
+// the spec version in the meantime (see upgrade.IsRolledBack), all other steps keep reconciling the dogu.
+type RollbackStep struct {
+	client              k8sClient
+	upgradeChecker      upgradeChecker
+	snapshotStore       snapshotStore
+	progressStore       progressStore
+	snapshotter         volumeSnapshotter
+	localDoguFetcher    localDoguFetcher
+	doguRegistrator     doguRegistrator
+	upserter            ResourceUpserter
+	deploymentInterface deploymentInterface
+	execPodFactory      execPodFactory
+	doguInterface       doguInterface
+	recorder            eventRecorder
+	deadlines           upgrade.PhaseDeadlines
+	now                 func() time.Time
+}
+
+func NewRollbackStep(
+	client client.Client,
+	checker upgrade.Checker,
+	snapshotStore upgrade.SnapshotStore,
+	progressStore upgrade.ProgressStore,
+	snapshotter volumesnapshot.Snapshotter,
+	localFetcher cesregistry.LocalDoguFetcher,
+	registrator cesregistry.DoguRegistrator,
+	upserter resource.ResourceUpserter,
+	deploymentInterface appsv1.DeploymentInterface,
+	factory exec.ExecPodFactory,
+	doguInterface doguClient.DoguInterface,
+	recorder record.EventRecorder,
+	deadlines upgrade.PhaseDeadlines,
+) *RollbackStep {
+	return &RollbackStep{
+		client:              client,
+		upgradeChecker:      checker,
+		snapshotStore:       snapshotStore,
+		progressStore:       progressStore,
+		snapshotter:         snapshotter,
+		localDoguFetcher:    localFetcher,
+		doguRegistrator:     registrator,
+		upserter:            upserter,
+		deploymentInterface: deploymentInterface,
+		execPodFactory:      factory,
+		doguInterface:       doguInterface,
+		recorder:            recorder,
+		deadlines:           deadlines,
+		now:                 time.Now,
+	}
+}
+
+func (rs *RollbackStep) Run(ctx context.Context, doguResource *v2.Dogu) steps.StepResult {
+	if !upgrade.RollbackEnabled(doguResource) || doguResource.Status.InstalledVersion == "" {
+		return steps.Continue()
+	}
+
+	snapshot, err := rs.snapshotStore.Get(ctx, doguResource)
+	if err != nil {
+		return steps.RequeueWithError(err)
+	}
+
+	if snapshot != nil && snapshot.RolledBack {
+		if snapshot.TargetVersion == doguResource.Spec.Version {
+			// the failed upgrade is not retried until the spec version changes
+			return steps.Continue()
+		}
+
+		err = rs.finish(ctx, doguResource, upgrade.ReasonSpecVersionChanged,
+			fmt.Sprintf("The version %s of the rolled back upgrade is not desired anymore.", snapshot.TargetVersion))
+		if err != nil {
+			return steps.RequeueWithError(err)
+		}
+		snapshot = nil
+	}
+
+	if snapshot != nil && snapshot.TargetVersion == doguResource.Status.InstalledVersion {
+		err = rs.finish(ctx, doguResource, upgrade.ReasonUpgradeSucceeded,
+			fmt.Sprintf("The upgrade from %s to %s succeeded.", snapshot.PreviousVersion, snapshot.TargetVersion))
+		if err != nil {
+			return steps.RequeueWithError(err)
+		}
+		return steps.Continue()
+	}
+
+	if snapshot == nil {
+		return rs.saveSnapshot(ctx, doguResource)
+	}
+
+	if snapshot.TargetVersion != doguResource.Spec.Version {
+		// the target of a running upgrade changed, the state before the upgrade is still the one to return to
+		snapshot.TargetVersion = doguResource.Spec.Version
+		snapshot.StartedAt = metav1.NewTime(rs.now())
+		err = rs.snapshotStore.Save(ctx, doguResource, snapshot)
+		if err != nil {
+			return steps.RequeueWithError(err)
+		}
+		return steps.Continue()
+	}
+
+	if snapshot.RollbackRefused {
+		// the failed upgrade is only reported by the upgrade stall detection
+		return steps.Continue()
+	}
+
+	pods := &corev1.PodList{}
+	err = rs.client.List(ctx, pods, client.InNamespace(doguResource.Namespace), client.MatchingLabels(doguResource.GetDoguNameLabel()))
+	if err != nil {
+		return steps.RequeueWithError(fmt.Errorf("failed to list pods of dogu %q: %w", doguResource.Name, err))
+	}
+
+	progress, err := rs.progressStore.Get(ctx, doguResource)
+	if err != nil {
+		return steps.RequeueWithError(err)
+	}
+
+	failure := upgrade.CheckProgress(snapshot, progress, pods.Items, doguResource.Name, rs.deadlines.For(doguResource), rs.now())
+	if failure == nil {
+		return steps.Continue()
+	}
+
+	previousResource := doguResource.DeepCopy()
+	previousResource.Spec.Version = snapshot.PreviousVersion
+	previousDogu, err := rs.localDoguFetcher.FetchForResource(ctx, previousResource)
+	if err != nil {
+		return steps.RequeueWithError(fmt.Errorf("failed to fetch descriptor of previous version %s: %w", snapshot.PreviousVersion, err))
+	}
+
+	volumeSnapshot, refusal, err := rs.volumeSnapshotToRestore(ctx, doguResource, snapshot, previousDogu)
+	if err != nil {
+		return steps.RequeueWithError(err)
+	}
+	if refusal != "" {
+		return rs.refuse(ctx, doguResource, snapshot, failure, refusal)
+	}
+
+	err = rs.rollback(ctx, doguResource, previousResource, previousDogu, snapshot, failure, volumeSnapshot)
+	if err != nil {
+		return steps.RequeueWithError(fmt.Errorf("failed to roll back upgrade of dogu %q: %w", doguResource.Name, err))
+	}
+
+	return steps.Continue()
+}
+
+func (rs *RollbackStep) saveSnapshot(ctx context.Context, doguResource *v2.Dogu) steps.StepResult {
+	isUpgrade, err := rs.upgradeChecker.IsUpgrade(ctx, doguResource)
+	if err != nil {
+		return steps.RequeueWithError(fmt.Errorf("failed to check if dogu is upgrading: %w", err))
+	}
+	if !isUpgrade {
+		return steps.Continue()
+	}
+
+	installedDogu, err := rs.localDoguFetcher.FetchInstalled(ctx, doguResource.GetSimpleDoguName())
+	if err != nil {
+		return steps.RequeueWithError(fmt.Errorf("failed to fetch installed dogu: %w", err))
+	}
+
+	deployment, err := rs.deploymentInterface.Get(ctx, doguResource.Name, metav1.GetOptions{})
+	if err != nil {
+		return steps.RequeueWithError(fmt.Errorf("failed to fetch deployment: %w", err))
+	}
+
+	snapshot := &upgrade.Snapshot{
+		PreviousVersion: installedDogu.Version,
+		TargetVersion:   doguResource.Spec.Version,
+		Template:        deployment.Spec.Template,
+		StartedAt:       metav1.NewTime(rs.now()),
+	}
+	if volumesnapshot.Enabled(doguResource) {
+		// the VolumeSnapshotStep takes this volume snapshot before the deployment is updated
+		snapshot.VolumeSnapshot = volumesnapshot.NameFor(doguResource, volumesnapshot.PurposeUpgrade)
+	}
+
+	err = rs.snapshotStore.Save(ctx, doguResource, snapshot)
+	if err != nil {
+		return steps.RequeueWithError(err)
+	}
+
+	return steps.Continue()
+}
+
+// volumeSnapshotToRestore returns the volume snapshot from which the data volume is restored during the rollback.
+// The pre-upgrade script and the new version may migrate the data volume, and the previous version cannot be expected
+// to run on migrated data. So once the data volume may have been changed, the upgrade is only rolled back if the
+// volume snapshot taken before the upgrade is ready to use. Otherwise, the reason of the refusal is returned.
+func (rs *RollbackStep) volumeSnapshotToRestore(ctx context.Context, doguResource *v2.Dogu, snapshot *upgrade.Snapshot, previousDogu *cesappcore.Dogu) (volumeSnapshot string, refusal string, err error) {
+	if !resource.NeedsPVCs(previousDogu) {
+		return "", "", nil
+	}
+
+	changed, err := rs.mayHaveChangedData(ctx, doguResource, snapshot)
+	if err != nil || !changed {
+		return "", "", err
+	}
+
+	if snapshot.VolumeSnapshot == "" {
+		return "", "the data volume may have been changed by the upgrade and no volume snapshot was taken before the upgrade", nil
+	}
+
+	state, err := rs.snapshotter.Get(ctx, doguResource, snapshot.VolumeSnapshot)
+	if apierrors.IsNotFound(err) {
+		return "", fmt.Sprintf("the data volume may have been changed by the upgrade and the volume snapshot %q taken before the upgrade does not exist", snapshot.VolumeSnapshot), nil
+	}
+	if err != nil {
+		return "", "", err
+	}
+	if !state.ReadyToUse {
+		return "", fmt.Sprintf("the data volume may have been changed by the upgrade and the volume snapshot %q taken before the upgrade is not ready to use", snapshot.VolumeSnapshot), nil
+	}
+
+	return state.Name, "", nil
+}
+
+// mayHaveChangedData returns true if the pre-upgrade script of the target version may have run or if the deployment
+// was already updated to the target version, whose containers may migrate the data volume on startup.
+func (rs *RollbackStep) mayHaveChangedData(ctx context.Context, doguResource *v2.Dogu, snapshot *upgrade.Snapshot) (bool, error) {
+	deployment, err := rs.deploymentInterface.Get(ctx, doguResource.Name, metav1.GetOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch deployment: %w", err)
+	}
+	if isDeploymentOfVersion(deployment, snapshot.TargetVersion) {
+		return true, nil
+	}
+
+	targetDogu, err := rs.localDoguFetcher.FetchForResource(ctx, doguResource)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch descriptor of target version %s: %w", snapshot.TargetVersion, err)
+	}
+
+	return targetDogu.HasExposedCommand(cesappcore.ExposedCommandPreUpgrade), nil
+}
+
+// refuse keeps the failed upgrade and records why it is not rolled back.
+func (rs *RollbackStep) refuse(ctx context.Context, doguResource *v2.Dogu, snapshot *upgrade.Snapshot, failure *upgrade.Failure, refusal string) steps.StepResult {
+	log.FromContext(ctx).Info("Refusing to roll back failed upgrade of dogu", "dogu", doguResource.Name,
+		"from", snapshot.TargetVersion, "to", snapshot.PreviousVersion, "reason", failure.Reason, "refusal", refusal)
+
+	snapshot.RollbackRefused = true
+	err := rs.snapshotStore.Save(ctx, doguResource, snapshot)
+	if err != nil {
+		return steps.RequeueWithError(err)
+	}
+
+	rs.recorder.Eventf(doguResource, corev1.EventTypeWarning, rollbackRefusedEventReason,
+		"The upgrade from %s to %s failed because %s, but is not rolled back because %s",
+		snapshot.PreviousVersion, snapshot.TargetVersion, failure.Message, refusal)
+	return steps.Continue()
+}
+
+func (rs *RollbackStep) rollback(
+	ctx context.Context,
+	doguResource *v2.Dogu,
+	previousResource *v2.Dogu,
+	previousDogu *cesappcore.Dogu,
+	snapshot *upgrade.Snapshot,
+	failure *upgrade.Failure,
+	volumeSnapshot string,
+) error {
+	logger := log.FromContext(ctx)
+	logger.Info("Rolling back upgrade of dogu", "dogu", doguResource.Name,
+		"from", snapshot.TargetVersion, "to", snapshot.PreviousVersion, "reason", failure.Reason, "volumeSnapshot", volumeSnapshot)
+
+	err := rs.doguRegistrator.RegisterDoguVersion(ctx, previousDogu)
+	if err != nil {
+		return fmt.Errorf("failed to register previous version %s as current version: %w", snapshot.PreviousVersion, err)
+	}
+
+	_, err = rs.upserter.UpsertDoguDeployment(ctx, previousResource, previousDogu, func(deployment *apps.Deployment) {
+		deployment.Spec.Template = *snapshot.Template.DeepCopy()
+		if volumeSnapshot != "" {
+			// the previous version must not start on the changed data volume, the dogu is started after the restore
+			deployment.Spec.Replicas = ptr.To(int32(0))
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore deployment: %w", err)
+	}
+
+	err = rs.deleteTargetExecPod(ctx, doguResource)
+	if err != nil {
+		return err
+	}
+
+	if volumeSnapshot != "" {
+		err = rs.requestRestore(ctx, doguResource, volumeSnapshot)
+		if err != nil {
+			return err
+		}
+	}
+
+	snapshot.RolledBack = true
+	err = rs.snapshotStore.Save(ctx, doguResource, snapshot)
+	if err != nil {
+		return err
+	}
+
+	condition := upgrade.NewRolledBackCondition(snapshot, failure, doguResource.Generation)
+	if volumeSnapshot != "" {
+		condition.Message = fmt.Sprintf("%s; the data volume is restored from volume snapshot %q", condition.Message, volumeSnapshot)
+	}
+	updatedDoguResource, err := rs.doguInterface.UpdateStatusWithRetry(ctx, doguResource, func(status v2.DoguStatus) v2.DoguStatus {
+		status.Status = v2.DoguStatusInstalled
+		status.InstalledVersion = snapshot.PreviousVersion
+		meta.SetStatusCondition(&status.Conditions, condition)
+		return status
+	}, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to update status of rolled back dogu: %w", err)
+	}
+	*doguResource = *updatedDoguResource
+
+	rs.recorder.Event(doguResource, corev1.EventTypeWarning, rolledBackEventReason, condition.Message)
+	return nil
+}
+
+// requestRestore lets the RestoreVolumeSnapshotStep restore the data volume from the volume snapshot.
+func (rs *RollbackStep) requestRestore(ctx context.Context, doguResource *v2.Dogu, volumeSnapshot string) error {
+	patch := client.MergeFrom(doguResource.DeepCopy())
+	annotations := maps.Clone(doguResource.GetAnnotations())
+	annotations[volumesnapshot.RestoreAnnotation] = volumeSnapshot
+	doguResource.SetAnnotations(annotations)
+
+	err := rs.client.Patch(ctx, doguResource, patch)
+	if err != nil {
+		return fmt.Errorf("failed to request restore of volume snapshot %q: %w", volumeSnapshot, err)
+	}
+
+	return nil
+}
+
+func (rs *RollbackStep) deleteTargetExecPod(ctx context.Context, doguResource *v2.Dogu) error {
+	targetDogu, err := rs.localDoguFetcher.FetchForResource(ctx, doguResource)
+	if err != nil {
+		return fmt.Errorf("failed to fetch descriptor of target version %s: %w", doguResource.Spec.Version, err)
+	}
+
+	err = rs.execPodFactory.Delete(ctx, doguResource, targetDogu)
+	if err != nil {
+		return fmt.Errorf("failed to delete exec pod of target version %s: %w", doguResource.Spec.Version, err)
+	}
+
+	return nil
+}
+
+// finish deletes the snapshot of the dogu and sets the RolledBack condition to false if the dogu was rolled back before.
+func (rs *RollbackStep) finish(ctx context.Context, doguResource *v2.Dogu, reason, message string) error {
+	err := rs.snapshotStore.Delete(ctx, doguResource)
+	if err != nil {
+		return err
+	}
+
+	if !meta.IsStatusConditionTrue(doguResource.Status.Conditions, upgrade.ConditionRolledBack) {
+		return nil
+	}
+
+	updatedDoguResource, err := rs.doguInterface.UpdateStatusWithRetry(ctx, doguResource, func(status v2.DoguStatus) v2.DoguStatus {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               upgrade.ConditionRolledBack,
+			Status:             metav1.ConditionFalse,
+			Reason:             reason,
+			Message:            message,
+			ObservedGeneration: doguResource.Generation,
+		})
+		return status
+	}, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to update RolledBack condition: %w", err)
+	}
+	*doguResource = *updatedDoguResource
+
+	return nil
+}
@@ -0,0 +1,601 @@
+package upgrade
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cescommons "github.com/cloudogu/ces-commons-lib/dogu"
+	cesappcore "github.com/cloudogu/cesapp-lib/core"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/upgrade"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/volumesnapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var rollbackNow = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+type rollbackStepMocks struct {
+	client              *mockK8sClient
+	upgradeChecker      *mockUpgradeChecker
+	snapshotStore       *mockSnapshotStore
+	progressStore       *mockProgressStore
+	snapshotter         *mockVolumeSnapshotter
+	localDoguFetcher    *mockLocalDoguFetcher
+	doguRegistrator     *mockDoguRegistrator
+	upserter            *MockResourceUpserter
+	deploymentInterface *mockDeploymentInterface
+	execPodFactory      *mockExecPodFactory
+	doguInterface       *mockDoguInterface
+	recorder            *mockEventRecorder
+}
+
+func newTestRollbackStep(t *testing.T) (*RollbackStep, *rollbackStepMocks) {
+	mocks := &rollbackStepMocks{
+		client:              newMockK8sClient(t),
+		upgradeChecker:      newMockUpgradeChecker(t),
+		snapshotStore:       newMockSnapshotStore(t),
+		progressStore:       newMockProgressStore(t),
+		snapshotter:         newMockVolumeSnapshotter(t),
+		localDoguFetcher:    newMockLocalDoguFetcher(t),
+		doguRegistrator:     newMockDoguRegistrator(t),
+		upserter:            NewMockResourceUpserter(t),
+		deploymentInterface: newMockDeploymentInterface(t),
+		execPodFactory:      newMockExecPodFactory(t),
+		doguInterface:       newMockDoguInterface(t),
+		recorder:            newMockEventRecorder(t),
+	}
+	step := &RollbackStep{
+		client:              mocks.client,
+		upgradeChecker:      mocks.upgradeChecker,
+		snapshotStore:       mocks.snapshotStore,
+		progressStore:       mocks.progressStore,
+		snapshotter:         mocks.snapshotter,
+		localDoguFetcher:    mocks.localDoguFetcher,
+		doguRegistrator:     mocks.doguRegistrator,
+		upserter:            mocks.upserter,
+		deploymentInterface: mocks.deploymentInterface,
+		execPodFactory:      mocks.execPodFactory,
+		doguInterface:       mocks.doguInterface,
+		recorder:            mocks.recorder,
+		deadlines:           upgrade.PhaseDeadlines{PreUpgrade: 30 * time.Minute, Rollout: time.Hour, PostUpgrade: 30 * time.Minute},
+		now:                 func() time.Time { return rollbackNow },
+	}
+	return step, mocks
+}
+
+func newRollbackDogu() *v2.Dogu {
+	return &v2.Dogu{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "ldap",
+			Namespace:   namespace,
+			Generation:  2,
+			Annotations: map[string]string{upgrade.RollbackAnnotation: "true"},
+		},
+		Spec:   v2.DoguSpec{Name: "official/ldap", Version: "2.0.0-1"},
+		Status: v2.DoguStatus{InstalledVersion: "1.0.0-1"},
+	}
+}
+
+func newRollbackSnapshot() *upgrade.Snapshot {
+	return &upgrade.Snapshot{
+		PreviousVersion: "1.0.0-1",
+		TargetVersion:   "2.0.0-1",
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"dogu.version": "1.0.0-1"}},
+		},
+		StartedAt: metav1.NewTime(rollbackNow.Add(-time.Minute)),
+	}
+}
+
+func newExceededProgress() *upgrade.Progress {
+	return &upgrade.Progress{
+		TargetVersion:  "2.0.0-1",
+		Phase:          upgrade.PhaseRollout,
+		PhaseStartedAt: metav1.NewTime(rollbackNow.Add(-61 * time.Minute)),
+	}
+}
+
+func newDoguWithDataVolume(version string) *cesappcore.Dogu {
+	return &cesappcore.Dogu{
+		Name:    "official/ldap",
+		Version: version,
+		Volumes: []cesappcore.Volume{{Name: "data", Path: "/var/lib/ldap", NeedsBackup: true}},
+	}
+}
+
+func newDeploymentOfVersion(version string) *apps.Deployment {
+	return &apps.Deployment{Spec: apps.DeploymentSpec{Template: corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"dogu.version": version}},
+	}}}
+}
+
+func TestNewRollbackStep(t *testing.T) {
+	step := NewRollbackStep(newMockK8sClient(t), newMockUpgradeChecker(t), newMockSnapshotStore(t), newMockProgressStore(t),
+		newMockVolumeSnapshotter(t), newMockLocalDoguFetcher(t), newMockDoguRegistrator(t), nil, newMockDeploymentInterface(t),
+		newMockExecPodFactory(t), newMockDoguInterface(t), newMockEventRecorder(t),
+		upgrade.PhaseDeadlines{PreUpgrade: time.Hour, Rollout: 5 * time.Hour, PostUpgrade: time.Hour})
+
+	assert.NotNil(t, step)
+	assert.Equal(t, upgrade.PhaseDeadlines{PreUpgrade: time.Hour, Rollout: 5 * time.Hour, PostUpgrade: time.Hour}, step.deadlines)
+}
+
+func TestRollbackStep_Run(t *testing.T) {
+	t.Run("should continue if dogu did not opt in", func(t *testing.T) {
+		// given
+		sut, _ := newTestRollbackStep(t)
+		doguResource := newRollbackDogu()
+		doguResource.Annotations = nil
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should continue if dogu is not installed yet", func(t *testing.T) {
+		// given
+		sut, _ := newTestRollbackStep(t)
+		doguResource := newRollbackDogu()
+		doguResource.Status.InstalledVersion = ""
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should fail to get snapshot", func(t *testing.T) {
+		// given
+		sut, mocks := newTestRollbackStep(t)
+		doguResource := newRollbackDogu()
+		mocks.snapshotStore.EXPECT().Get(testCtx, doguResource).Return(nil, assert.AnError)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.RequeueWithError(assert.AnError), result)
+	})
+	t.Run("should continue without snapshot if dogu is not upgraded", func(t *testing.T) {
+		// given
+		sut, mocks := newTestRollbackStep(t)
+		doguResource := newRollbackDogu()
+		mocks.snapshotStore.EXPECT().Get(testCtx, doguResource).Return(nil, nil)
+		mocks.upgradeChecker.EXPECT().IsUpgrade(testCtx, doguResource).Return(false, nil)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should save snapshot before upgrade", func(t *testing.T) {
+		// given
+		sut, mocks := newTestRollbackStep(t)
+		doguResource := newRollbackDogu()
+		expected := newRollbackSnapshot()
+		expected.StartedAt = metav1.NewTime(rollbackNow)
+		mocks.snapshotStore.EXPECT().Get(testCtx, doguResource).Return(nil, nil)
+		mocks.upgradeChecker.EXPECT().IsUpgrade(testCtx, doguResource).Return(true, nil)
+		mocks.localDoguFetcher.EXPECT().FetchInstalled(testCtx, cescommons.SimpleName("ldap")).Return(&cesappcore.Dogu{Version: "1.0.0-1"}, nil)
+		mocks.deploymentInterface.EXPECT().Get(testCtx, "ldap", metav1.GetOptions{}).
+			Return(&apps.Deployment{Spec: apps.DeploymentSpec{Template: expected.Template}}, nil)
+		mocks.snapshotStore.EXPECT().Save(testCtx, doguResource, expected).Return(nil)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should save name of volume snapshot before upgrade", func(t *testing.T) {
+		// given
+		sut, mocks := newTestRollbackStep(t)
+		doguResource := newRollbackDogu()
+		doguResource.Annotations[volumesnapshot.Annotation] = "true"
+		expected := newRollbackSnapshot()
+		expected.StartedAt = metav1.NewTime(rollbackNow)
+		expected.VolumeSnapshot = "ldap-upgrade-1.0.0-1-to-2.0.0-1"
+		mocks.snapshotStore.EXPECT().Get(testCtx, doguResource).Return(nil, nil)
+		mocks.upgradeChecker.EXPECT().IsUpgrade(testCtx, doguResource).Return(true, nil)
+		mocks.localDoguFetcher.EXPECT().FetchInstalled(testCtx, cescommons.SimpleName("ldap")).Return(&cesappcore.Dogu{Version: "1.0.0-1"}, nil)
+		mocks.deploymentInterface.EXPECT().Get(testCtx, "ldap", metav1.GetOptions{}).
+			Return(&apps.Deployment{Spec: apps.DeploymentSpec{Template: expected.Template}}, nil)
+		mocks.snapshotStore.EXPECT().Save(testCtx, doguResource, expected).Return(nil)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should fail to fetch deployment for snapshot", func(t *testing.T) {
+		// given
+		sut, mocks := newTestRollbackStep(t)
+		doguResource := newRollbackDogu()
+		mocks.snapshotStore.EXPECT().Get(testCtx, doguResource).Return(nil, nil)
+		mocks.upgradeChecker.EXPECT().IsUpgrade(testCtx, doguResource).Return(true, nil)
+		mocks.localDoguFetcher.EXPECT().FetchInstalled(testCtx, cescommons.SimpleName("ldap")).Return(&cesappcore.Dogu{Version: "1.0.0-1"}, nil)
+		mocks.deploymentInterface.EXPECT().Get(testCtx, "ldap", metav1.GetOptions{}).Return(nil, assert.AnError)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		require.ErrorIs(t, result.Err, assert.AnError)
+		assert.ErrorContains(t, result.Err, "failed to fetch deployment")
+	})
+	t.Run("should continue while rolled back upgrade is still desired", func(t *testing.T) {
+		// given
+		sut, mocks := newTestRollbackStep(t)
+		doguResource := newRollbackDogu()
+		snapshot := newRollbackSnapshot()
+		snapshot.RolledBack = true
+		mocks.snapshotStore.EXPECT().Get(testCtx, doguResource).Return(snapshot, nil)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should reset condition and start new upgrade after spec version changed", func(t *testing.T) {
+		// given
+		sut, mocks := newTestRollbackStep(t)
+		doguResource := newRollbackDogu()
+		doguResource.Spec.Version = "2.0.1-1"
+		doguResource.Status.Conditions = []metav1.Condition{{Type: upgrade.ConditionRolledBack, Status: metav1.ConditionTrue}}
+		snapshot := newRollbackSnapshot()
+		snapshot.RolledBack = true
+		mocks.snapshotStore.EXPECT().Get(testCtx, doguResource).Return(snapshot, nil)
+		mocks.snapshotStore.EXPECT().Delete(testCtx, doguResource).Return(nil)
+		mocks.doguInterface.EXPECT().UpdateStatusWithRetry(testCtx, doguResource, mock.Anything, metav1.UpdateOptions{}).
+			RunAndReturn(func(ctx context.Context, dogu *v2.Dogu, modifyStatusFn func(v2.DoguStatus) v2.DoguStatus, opts metav1.UpdateOptions) (*v2.Dogu, error) {
+				status := modifyStatusFn(dogu.Status)
+				condition := meta.FindStatusCondition(status.Conditions, upgrade.ConditionRolledBack)
+				assert.Equal(t, metav1.ConditionFalse, condition.Status)
+				assert.Equal(t, upgrade.ReasonSpecVersionChanged, condition.Reason)
+				dogu.Status = status
+				return dogu, nil
+			})
+		mocks.upgradeChecker.EXPECT().IsUpgrade(testCtx, doguResource).Return(false, nil)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should delete snapshot after successful upgrade", func(t *testing.T) {
+		// given
+		sut, mocks := newTestRollbackStep(t)
+		doguResource := newRollbackDogu()
+		doguResource.Status.InstalledVersion = "2.0.0-1"
+		mocks.snapshotStore.EXPECT().Get(testCtx, doguResource).Return(newRollbackSnapshot(), nil)
+		mocks.snapshotStore.EXPECT().Delete(testCtx, doguResource).Return(nil)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should retarget snapshot if spec version changed during upgrade", func(t *testing.T) {
+		// given
+		sut, mocks := newTestRollbackStep(t)
+		doguResource := newRollbackDogu()
+		doguResource.Spec.Version = "2.0.1-1"
+		expected := newRollbackSnapshot()
+		expected.TargetVersion = "2.0.1-1"
+		expected.StartedAt = metav1.NewTime(rollbackNow)
+		mocks.snapshotStore.EXPECT().Get(testCtx, doguResource).Return(newRollbackSnapshot(), nil)
+		mocks.snapshotStore.EXPECT().Save(testCtx, doguResource, expected).Return(nil)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should continue while upgrade is in progress", func(t *testing.T) {
+		// given
+		sut, mocks := newTestRollbackStep(t)
+		doguResource := newRollbackDogu()
+		progress := newExceededProgress()
+		progress.PhaseStartedAt = metav1.NewTime(rollbackNow.Add(-59 * time.Minute))
+		mocks.snapshotStore.EXPECT().Get(testCtx, doguResource).Return(newRollbackSnapshot(), nil)
+		mocks.client.EXPECT().List(testCtx, &corev1.PodList{}, client.InNamespace(namespace), client.MatchingLabels(doguResource.GetDoguNameLabel())).Return(nil)
+		mocks.progressStore.EXPECT().Get(testCtx, doguResource).Return(progress, nil)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should continue if rollback was refused", func(t *testing.T) {
+		// given
+		sut, mocks := newTestRollbackStep(t)
+		doguResource := newRollbackDogu()
+		snapshot := newRollbackSnapshot()
+		snapshot.RollbackRefused = true
+		mocks.snapshotStore.EXPECT().Get(testCtx, doguResource).Return(snapshot, nil)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should fail to get progress", func(t *testing.T) {
+		// given
+		sut, mocks := newTestRollbackStep(t)
+		doguResource := newRollbackDogu()
+		mocks.snapshotStore.EXPECT().Get(testCtx, doguResource).Return(newRollbackSnapshot(), nil)
+		mocks.client.EXPECT().List(testCtx, &corev1.PodList{}, client.InNamespace(namespace), client.MatchingLabels(doguResource.GetDoguNameLabel())).Return(nil)
+		mocks.progressStore.EXPECT().Get(testCtx, doguResource).Return(nil, assert.AnError)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		require.ErrorIs(t, result.Err, assert.AnError)
+	})
+	t.Run("should fail to list pods", func(t *testing.T) {
+		// given
+		sut, mocks := newTestRollbackStep(t)
+		doguResource := newRollbackDogu()
+		mocks.snapshotStore.EXPECT().Get(testCtx, doguResource).Return(newRollbackSnapshot(), nil)
+		mocks.client.EXPECT().List(testCtx, &corev1.PodList{}, client.InNamespace(namespace), client.MatchingLabels(doguResource.GetDoguNameLabel())).Return(assert.AnError)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		require.ErrorIs(t, result.Err, assert.AnError)
+		assert.ErrorContains(t, result.Err, "failed to list pods of dogu \"ldap\"")
+	})
+	t.Run("should roll back upgrade after deadline", func(t *testing.T) {
+		// given
+		sut, mocks := newTestRollbackStep(t)
+		doguResource := newRollbackDogu()
+		snapshot := newRollbackSnapshot()
+		previousDogu := &cesappcore.Dogu{Name: "official/ldap", Version: "1.0.0-1"}
+		targetDogu := &cesappcore.Dogu{Name: "official/ldap", Version: "2.0.0-1"}
+		mocks.snapshotStore.EXPECT().Get(testCtx, doguResource).Return(snapshot, nil)
+		mocks.client.EXPECT().List(testCtx, &corev1.PodList{}, client.InNamespace(namespace), client.MatchingLabels(doguResource.GetDoguNameLabel())).Return(nil)
+		mocks.progressStore.EXPECT().Get(testCtx, doguResource).Return(newExceededProgress(), nil)
+		mocks.localDoguFetcher.EXPECT().FetchForResource(testCtx, mock.MatchedBy(func(resource *v2.Dogu) bool {
+			return resource.Spec.Version == "1.0.0-1"
+		})).Return(previousDogu, nil)
+		mocks.localDoguFetcher.EXPECT().FetchForResource(testCtx, doguResource).Return(targetDogu, nil)
+		mocks.doguRegistrator.EXPECT().RegisterDoguVersion(testCtx, previousDogu).Return(nil)
+		mocks.upserter.EXPECT().UpsertDoguDeployment(testCtx, mock.Anything, previousDogu, mock.Anything).
+			RunAndReturn(func(ctx context.Context, resource *v2.Dogu, dogu *cesappcore.Dogu, patch func(*apps.Deployment)) (*apps.Deployment, error) {
+				assert.Equal(t, "1.0.0-1", resource.Spec.Version)
+				deployment := &apps.Deployment{}
+				patch(deployment)
+				assert.Equal(t, snapshot.Template, deployment.Spec.Template)
+				return deployment, nil
+			})
+		mocks.execPodFactory.EXPECT().Delete(testCtx, doguResource, targetDogu).Return(nil)
+		mocks.snapshotStore.EXPECT().Save(testCtx, doguResource, mock.MatchedBy(func(saved *upgrade.Snapshot) bool {
+			return saved.RolledBack
+		})).Return(nil)
+		mocks.doguInterface.EXPECT().UpdateStatusWithRetry(testCtx, doguResource, mock.Anything, metav1.UpdateOptions{}).
+			RunAndReturn(func(ctx context.Context, dogu *v2.Dogu, modifyStatusFn func(v2.DoguStatus) v2.DoguStatus, opts metav1.UpdateOptions) (*v2.Dogu, error) {
+				status := modifyStatusFn(dogu.Status)
+				assert.Equal(t, v2.DoguStatusInstalled, status.Status)
+				assert.Equal(t, "1.0.0-1", status.InstalledVersion)
+				condition := meta.FindStatusCondition(status.Conditions, upgrade.ConditionRolledBack)
+				require.NotNil(t, condition)
+				assert.Equal(t, metav1.ConditionTrue, condition.Status)
+				assert.Equal(t, upgrade.ReasonDeadlineExceeded, condition.Reason)
+				assert.Equal(t, int64(2), condition.ObservedGeneration)
+				updated := dogu.DeepCopy()
+				updated.Status = status
+				return updated, nil
+			})
+		mocks.recorder.EXPECT().Event(mock.Anything, corev1.EventTypeWarning, "UpgradeRolledBack",
+			"The upgrade from 1.0.0-1 to 2.0.0-1 was rolled back because the rollout phase of the upgrade did not finish within 1h0m0s").Return()
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+		assert.Equal(t, "1.0.0-1", doguResource.Status.InstalledVersion)
+	})
+	t.Run("should fail to register previous version", func(t *testing.T) {
+		// given
+		sut, mocks := newTestRollbackStep(t)
+		doguResource := newRollbackDogu()
+		previousDogu := &cesappcore.Dogu{Name: "official/ldap", Version: "1.0.0-1"}
+		mocks.snapshotStore.EXPECT().Get(testCtx, doguResource).Return(newRollbackSnapshot(), nil)
+		mocks.client.EXPECT().List(testCtx, &corev1.PodList{}, client.InNamespace(namespace), client.MatchingLabels(doguResource.GetDoguNameLabel())).Return(nil)
+		mocks.progressStore.EXPECT().Get(testCtx, doguResource).Return(newExceededProgress(), nil)
+		mocks.localDoguFetcher.EXPECT().FetchForResource(testCtx, mock.Anything).Return(previousDogu, nil)
+		mocks.doguRegistrator.EXPECT().RegisterDoguVersion(testCtx, previousDogu).Return(assert.AnError)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		require.ErrorIs(t, result.Err, assert.AnError)
+		assert.ErrorContains(t, result.Err, "failed to roll back upgrade of dogu \"ldap\": failed to register previous version 1.0.0-1 as current version")
+	})
+	t.Run("should roll back and restore data volume changed by the new version", func(t *testing.T) {
+		// given
+		sut, mocks := newTestRollbackStep(t)
+		doguResource := newRollbackDogu()
+		snapshot := newRollbackSnapshot()
+		snapshot.VolumeSnapshot = "ldap-upgrade-2"
+		previousDogu := newDoguWithDataVolume("1.0.0-1")
+		targetDogu := newDoguWithDataVolume("2.0.0-1")
+		mocks.snapshotStore.EXPECT().Get(testCtx, doguResource).Return(snapshot, nil)
+		mocks.client.EXPECT().List(testCtx, &corev1.PodList{}, client.InNamespace(namespace), client.MatchingLabels(doguResource.GetDoguNameLabel())).Return(nil)
+		mocks.progressStore.EXPECT().Get(testCtx, doguResource).Return(newExceededProgress(), nil)
+		mocks.localDoguFetcher.EXPECT().FetchForResource(testCtx, mock.MatchedBy(func(resource *v2.Dogu) bool {
+			return resource.Spec.Version == "1.0.0-1"
+		})).Return(previousDogu, nil)
+		mocks.localDoguFetcher.EXPECT().FetchForResource(testCtx, doguResource).Return(targetDogu, nil)
+		mocks.deploymentInterface.EXPECT().Get(testCtx, "ldap", metav1.GetOptions{}).Return(newDeploymentOfVersion("2.0.0-1"), nil)
+		mocks.snapshotter.EXPECT().Get(testCtx, doguResource, "ldap-upgrade-2").
+			Return(&volumesnapshot.State{Name: "ldap-upgrade-2", DoguVersion: "1.0.0-1", ReadyToUse: true}, nil)
+		mocks.doguRegistrator.EXPECT().RegisterDoguVersion(testCtx, previousDogu).Return(nil)
+		mocks.upserter.EXPECT().UpsertDoguDeployment(testCtx, mock.Anything, previousDogu, mock.Anything).
+			RunAndReturn(func(ctx context.Context, resource *v2.Dogu, dogu *cesappcore.Dogu, patch func(*apps.Deployment)) (*apps.Deployment, error) {
+				deployment := &apps.Deployment{}
+				patch(deployment)
+				assert.Equal(t, snapshot.Template, deployment.Spec.Template)
+				assert.Equal(t, int32(0), *deployment.Spec.Replicas)
+				return deployment, nil
+			})
+		mocks.execPodFactory.EXPECT().Delete(testCtx, doguResource, targetDogu).Return(nil)
+		mocks.client.EXPECT().Patch(testCtx, doguResource, mock.Anything).RunAndReturn(func(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			assert.Equal(t, "ldap-upgrade-2", obj.GetAnnotations()[volumesnapshot.RestoreAnnotation])
+			return nil
+		})
+		mocks.snapshotStore.EXPECT().Save(testCtx, doguResource, mock.MatchedBy(func(saved *upgrade.Snapshot) bool {
+			return saved.RolledBack
+		})).Return(nil)
+		mocks.doguInterface.EXPECT().UpdateStatusWithRetry(testCtx, doguResource, mock.Anything, metav1.UpdateOptions{}).
+			RunAndReturn(func(ctx context.Context, dogu *v2.Dogu, modifyStatusFn func(v2.DoguStatus) v2.DoguStatus, opts metav1.UpdateOptions) (*v2.Dogu, error) {
+				updated := dogu.DeepCopy()
+				updated.Status = modifyStatusFn(dogu.Status)
+				return updated, nil
+			})
+		mocks.recorder.EXPECT().Event(mock.Anything, corev1.EventTypeWarning, "UpgradeRolledBack",
+			"The upgrade from 1.0.0-1 to 2.0.0-1 was rolled back because the rollout phase of the upgrade did not finish within 1h0m0s; the data volume is restored from volume snapshot \"ldap-upgrade-2\"").Return()
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+		assert.Equal(t, "1.0.0-1", doguResource.Status.InstalledVersion)
+	})
+	t.Run("should roll back without restore if the data volume was not changed yet", func(t *testing.T) {
+		// given
+		sut, mocks := newTestRollbackStep(t)
+		doguResource := newRollbackDogu()
+		snapshot := newRollbackSnapshot()
+		previousDogu := newDoguWithDataVolume("1.0.0-1")
+		targetDogu := newDoguWithDataVolume("2.0.0-1")
+		mocks.snapshotStore.EXPECT().Get(testCtx, doguResource).Return(snapshot, nil)
+		mocks.client.EXPECT().List(testCtx, &corev1.PodList{}, client.InNamespace(namespace), client.MatchingLabels(doguResource.GetDoguNameLabel())).Return(nil)
+		progress := newExceededProgress()
+		progress.Phase = upgrade.PhasePreUpgrade
+		mocks.progressStore.EXPECT().Get(testCtx, doguResource).Return(progress, nil)
+		mocks.localDoguFetcher.EXPECT().FetchForResource(testCtx, mock.MatchedBy(func(resource *v2.Dogu) bool {
+			return resource.Spec.Version == "1.0.0-1"
+		})).Return(previousDogu, nil)
+		mocks.localDoguFetcher.EXPECT().FetchForResource(testCtx, doguResource).Return(targetDogu, nil)
+		mocks.deploymentInterface.EXPECT().Get(testCtx, "ldap", metav1.GetOptions{}).Return(newDeploymentOfVersion("1.0.0-1"), nil)
+		mocks.doguRegistrator.EXPECT().RegisterDoguVersion(testCtx, previousDogu).Return(nil)
+		mocks.upserter.EXPECT().UpsertDoguDeployment(testCtx, mock.Anything, previousDogu, mock.Anything).
+			RunAndReturn(func(ctx context.Context, resource *v2.Dogu, dogu *cesappcore.Dogu, patch func(*apps.Deployment)) (*apps.Deployment, error) {
+				deployment := &apps.Deployment{}
+				patch(deployment)
+				assert.Nil(t, deployment.Spec.Replicas)
+				return deployment, nil
+			})
+		mocks.execPodFactory.EXPECT().Delete(testCtx, doguResource, targetDogu).Return(nil)
+		mocks.snapshotStore.EXPECT().Save(testCtx, doguResource, mock.Anything).Return(nil)
+		mocks.doguInterface.EXPECT().UpdateStatusWithRetry(testCtx, doguResource, mock.Anything, metav1.UpdateOptions{}).
+			RunAndReturn(func(ctx context.Context, dogu *v2.Dogu, modifyStatusFn func(v2.DoguStatus) v2.DoguStatus, opts metav1.UpdateOptions) (*v2.Dogu, error) {
+				updated := dogu.DeepCopy()
+				updated.Status = modifyStatusFn(dogu.Status)
+				return updated, nil
+			})
+		mocks.recorder.EXPECT().Event(mock.Anything, corev1.EventTypeWarning, "UpgradeRolledBack",
+			"The upgrade from 1.0.0-1 to 2.0.0-1 was rolled back because the pre-upgrade phase of the upgrade did not finish within 30m0s").Return()
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should refuse rollback if the pre-upgrade script may have changed the data volume without volume snapshot", func(t *testing.T) {
+		// given
+		sut, mocks := newTestRollbackStep(t)
+		doguResource := newRollbackDogu()
+		snapshot := newRollbackSnapshot()
+		targetDogu := newDoguWithDataVolume("2.0.0-1")
+		targetDogu.ExposedCommands = []cesappcore.ExposedCommand{{Name: cesappcore.ExposedCommandPreUpgrade, Command: "/pre-upgrade.sh"}}
+		mocks.snapshotStore.EXPECT().Get(testCtx, doguResource).Return(snapshot, nil)
+		mocks.client.EXPECT().List(testCtx, &corev1.PodList{}, client.InNamespace(namespace), client.MatchingLabels(doguResource.GetDoguNameLabel())).Return(nil)
+		mocks.progressStore.EXPECT().Get(testCtx, doguResource).Return(newExceededProgress(), nil)
+		mocks.localDoguFetcher.EXPECT().FetchForResource(testCtx, mock.MatchedBy(func(resource *v2.Dogu) bool {
+			return resource.Spec.Version == "1.0.0-1"
+		})).Return(newDoguWithDataVolume("1.0.0-1"), nil)
+		mocks.localDoguFetcher.EXPECT().FetchForResource(testCtx, doguResource).Return(targetDogu, nil)
+		mocks.deploymentInterface.EXPECT().Get(testCtx, "ldap", metav1.GetOptions{}).Return(newDeploymentOfVersion("1.0.0-1"), nil)
+		mocks.snapshotStore.EXPECT().Save(testCtx, doguResource, mock.MatchedBy(func(saved *upgrade.Snapshot) bool {
+			return saved.RollbackRefused && !saved.RolledBack
+		})).Return(nil)
+		mocks.recorder.EXPECT().Eventf(doguResource, corev1.EventTypeWarning, "UpgradeRollbackRefused",
+			"The upgrade from %s to %s failed because %s, but is not rolled back because %s", "1.0.0-1", "2.0.0-1",
+			"the rollout phase of the upgrade did not finish within 1h0m0s",
+			"the data volume may have been changed by the upgrade and no volume snapshot was taken before the upgrade").Return()
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should refuse rollback if the volume snapshot is not ready to use", func(t *testing.T) {
+		// given
+		sut, mocks := newTestRollbackStep(t)
+		doguResource := newRollbackDogu()
+		snapshot := newRollbackSnapshot()
+		snapshot.VolumeSnapshot = "ldap-upgrade-2"
+		mocks.snapshotStore.EXPECT().Get(testCtx, doguResource).Return(snapshot, nil)
+		mocks.client.EXPECT().List(testCtx, &corev1.PodList{}, client.InNamespace(namespace), client.MatchingLabels(doguResource.GetDoguNameLabel())).Return(nil)
+		mocks.progressStore.EXPECT().Get(testCtx, doguResource).Return(newExceededProgress(), nil)
+		mocks.localDoguFetcher.EXPECT().FetchForResource(testCtx, mock.Anything).Return(newDoguWithDataVolume("1.0.0-1"), nil)
+		mocks.deploymentInterface.EXPECT().Get(testCtx, "ldap", metav1.GetOptions{}).Return(newDeploymentOfVersion("2.0.0-1"), nil)
+		mocks.snapshotter.EXPECT().Get(testCtx, doguResource, "ldap-upgrade-2").Return(&volumesnapshot.State{Name: "ldap-upgrade-2"}, nil)
+		mocks.snapshotStore.EXPECT().Save(testCtx, doguResource, mock.Anything).Return(nil)
+		mocks.recorder.EXPECT().Eventf(doguResource, corev1.EventTypeWarning, "UpgradeRollbackRefused", mock.Anything,
+			"1.0.0-1", "2.0.0-1", mock.Anything,
+			"the data volume may have been changed by the upgrade and the volume snapshot \"ldap-upgrade-2\" taken before the upgrade is not ready to use").Return()
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should fail to get volume snapshot", func(t *testing.T) {
+		// given
+		sut, mocks := newTestRollbackStep(t)
+		doguResource := newRollbackDogu()
+		snapshot := newRollbackSnapshot()
+		snapshot.VolumeSnapshot = "ldap-upgrade-2"
+		mocks.snapshotStore.EXPECT().Get(testCtx, doguResource).Return(snapshot, nil)
+		mocks.client.EXPECT().List(testCtx, &corev1.PodList{}, client.InNamespace(namespace), client.MatchingLabels(doguResource.GetDoguNameLabel())).Return(nil)
+		mocks.progressStore.EXPECT().Get(testCtx, doguResource).Return(newExceededProgress(), nil)
+		mocks.localDoguFetcher.EXPECT().FetchForResource(testCtx, mock.Anything).Return(newDoguWithDataVolume("1.0.0-1"), nil)
+		mocks.deploymentInterface.EXPECT().Get(testCtx, "ldap", metav1.GetOptions{}).Return(newDeploymentOfVersion("2.0.0-1"), nil)
+		mocks.snapshotter.EXPECT().Get(testCtx, doguResource, "ldap-upgrade-2").Return(nil, assert.AnError)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		require.ErrorIs(t, result.Err, assert.AnError)
+	})
+
+}
@@ -10,9 +10,11 @@ import (
 	"github.com/cloudogu/cesapp-lib/core"
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/cesregistry"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/exec"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/resource"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/upgrade"
 	appsv1 "k8s.io/client-go/kubernetes/typed/apps/v1"
 
 	v1 "k8s.io/api/apps/v1"
@@ -22,12 +24,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
-const requeueAfterUpdateDeployment = time.Second * 3
 const podTemplateVersionKey = "dogu.version"
-const upgradeStartupProbeFailureThresholdRetries = int32(1080)
 const preUpgradeScriptDir = "/tmp/pre-upgrade"
 
 // The UpdateDeploymentVersionStep updates the dogu version inside the deployment and runs the pre upgrade script.
+// The startup probe and the progress deadline of the deployment are stretched to the rollout deadline of the dogu.
 type UpdateDeploymentVersionStep struct {
 	client              k8sClient
 	upserter            ResourceUpserter
@@ -35,6 +36,8 @@ type UpdateDeploymentVersionStep struct {
 	localDoguFetcher    localDoguFetcher
 	execPodFactory      execPodFactory
 	doguCommandExecutor commandExecutor
+	deadlines           upgrade.PhaseDeadlines
+	requeueAfter        time.Duration
 }
 
 func NewUpdateDeploymentVersionStep(
@@ -44,6 +47,8 @@ func NewUpdateDeploymentVersionStep(
 	localFetcher cesregistry.LocalDoguFetcher,
 	factory exec.ExecPodFactory,
 	executor exec.CommandExecutor,
+	deadlines upgrade.PhaseDeadlines,
+	operatorConfig *config.OperatorConfig,
 ) *UpdateDeploymentVersionStep {
 	return &UpdateDeploymentVersionStep{
 		client:              client,
@@ -52,6 +57,8 @@ func NewUpdateDeploymentVersionStep(
 		localDoguFetcher:    localFetcher,
 		execPodFactory:      factory,
 		doguCommandExecutor: executor,
+		deadlines:           deadlines,
+		requeueAfter:        operatorConfig.UpgradeRequeueTime,
 	}
 }
 
@@ -72,7 +79,7 @@ func (uds *UpdateDeploymentVersionStep) Run(ctx context.Context, doguResource *v
 
 	execPodExists := uds.execPodFactory.Exists(ctx, doguResource, dogu)
 	if !execPodExists {
-		return steps.RequeueAfter(requeueAfterUpdateDeployment)
+		return steps.RequeueAfter(uds.requeueAfter)
 	}
 
 	err = uds.execPodFactory.CheckReady(ctx, doguResource, dogu)
@@ -92,29 +99,36 @@ func (uds *UpdateDeploymentVersionStep) Run(ctx context.Context, doguResource *v
 	}
 
 	// update Deployment
+	rolloutDeadline := uds.deadlines.For(doguResource).Rollout
 	_, err = uds.upserter.UpsertDoguDeployment(
 		ctx,
 		doguResource,
 		dogu,
 		func(deployment *v1.Deployment) {
-			increaseStartupProbeTimeoutForUpdate(doguResource.Name, deployment)
+			increaseStartupProbeTimeoutForUpdate(doguResource.Name, deployment, rolloutDeadline)
 		},
 	)
 	if err != nil {
 		return steps.RequeueWithError(err)
 	}
 
-	return steps.RequeueAfter(requeueAfterUpdateDeployment)
+	return steps.RequeueAfter(uds.requeueAfter)
 }
 
 func (uds *UpdateDeploymentVersionStep) isDoguVersionUpdatedInDeployment(doguResource *v2.Dogu, deployment *v1.Deployment) bool {
-	return deployment.Spec.Template.Labels[podTemplateVersionKey] == doguResource.Spec.Version
+	return isDeploymentOfVersion(deployment, doguResource.Spec.Version)
 }
 
-func increaseStartupProbeTimeoutForUpdate(containerName string, deployment *v1.Deployment) {
+// increaseStartupProbeTimeoutForUpdate lets the dogu container start and the deployment progress within the rollout
+// deadline, so that long-running migrations of the new version are not killed by the startup probe.
+func increaseStartupProbeTimeoutForUpdate(containerName string, deployment *v1.Deployment, rolloutDeadline time.Duration) {
+	progressDeadlineSeconds := int32(rolloutDeadline / time.Second)
+	deployment.Spec.ProgressDeadlineSeconds = &progressDeadlineSeconds
+
 	for i, container := range deployment.Spec.Template.Spec.Containers {
-		if container.Name == containerName && deployment.Spec.Template.Spec.Containers[i].StartupProbe != nil {
-			deployment.Spec.Template.Spec.Containers[i].StartupProbe.FailureThreshold = upgradeStartupProbeFailureThresholdRetries
+		startupProbe := deployment.Spec.Template.Spec.Containers[i].StartupProbe
+		if container.Name == containerName && startupProbe != nil {
+			startupProbe.FailureThreshold = upgrade.StartupProbeFailureThreshold(rolloutDeadline, startupProbe.PeriodSeconds)
 			break
 		}
 	}
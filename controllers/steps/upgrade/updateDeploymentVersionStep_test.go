@@ -6,12 +6,15 @@ import (
 	"fmt"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/cloudogu/ces-commons-lib/dogu"
 	"github.com/cloudogu/cesapp-lib/core"
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/exec"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/upgrade"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	appsv1 "k8s.io/api/apps/v1"
@@ -20,6 +23,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const testUpgradeRequeueTime = 3 * time.Second
+
 func TestNewUpdateDeploymentStep(t *testing.T) {
 	t.Run("Successfully created step", func(t *testing.T) {
 		step := NewUpdateDeploymentVersionStep(
@@ -29,9 +34,13 @@ func TestNewUpdateDeploymentStep(t *testing.T) {
 			newMockLocalDoguFetcher(t),
 			newMockExecPodFactory(t),
 			newMockCommandExecutor(t),
+			upgrade.PhaseDeadlines{Rollout: 3 * time.Hour},
+			&config.OperatorConfig{UpgradeRequeueTime: 5 * time.Second},
 		)
 
 		assert.NotNil(t, step)
+		assert.Equal(t, 3*time.Hour, step.deadlines.Rollout)
+		assert.Equal(t, 5*time.Second, step.requeueAfter)
 	})
 }
 
@@ -186,7 +195,7 @@ func TestUpdateDeploymentStep_Run(t *testing.T) {
 				},
 			},
 			doguResource: &v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
-			want:         steps.RequeueAfter(requeueAfterUpdateDeployment),
+			want:         steps.RequeueAfter(testUpgradeRequeueTime),
 		},
 		{
 			name: "should fail if exec pod is not ready",
@@ -785,8 +794,27 @@ func TestUpdateDeploymentStep_Run(t *testing.T) {
 				localDoguFetcher:    tt.fields.localDoguFetcherFn(t),
 				execPodFactory:      tt.fields.execPodFactoryFn(t),
 				doguCommandExecutor: tt.fields.doguCommandExecutorFn(t),
+				requeueAfter:        testUpgradeRequeueTime,
 			}
 			assert.Equalf(t, tt.want, uds.Run(testCtx, tt.doguResource), "Run(%v, %v)", testCtx, tt.doguResource)
 		})
 	}
 }
+
+func Test_increaseStartupProbeTimeoutForUpdate(t *testing.T) {
+	// given
+	deployment := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Template: v1.PodTemplateSpec{Spec: v1.PodSpec{
+		Containers: []v1.Container{
+			{Name: "sidecar", StartupProbe: &v1.Probe{PeriodSeconds: 10, FailureThreshold: 3}},
+			{Name: "ldap", StartupProbe: &v1.Probe{PeriodSeconds: 10, FailureThreshold: 3}},
+		},
+	}}}}
+
+	// when
+	increaseStartupProbeTimeoutForUpdate("ldap", deployment, 5*time.Hour)
+
+	// then
+	assert.Equal(t, int32(18000), *deployment.Spec.ProgressDeadlineSeconds)
+	assert.Equal(t, int32(3), deployment.Spec.Template.Spec.Containers[0].StartupProbe.FailureThreshold)
+	assert.Equal(t, int32(1800), deployment.Spec.Template.Spec.Containers[1].StartupProbe.FailureThreshold)
+}
@@ -0,0 +1,186 @@
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/upgrade"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	appsv1 "k8s.io/client-go/kubernetes/typed/apps/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const upgradeStalledEventReason = "UpgradeStalled"
+
+// The UpgradeStallDetectionStep reports upgrades which have not progressed past a phase within the deadline of the phase.
+// An upgrade is in the pre-upgrade phase until the deployment is updated to the new version, in the rollout phase until
+// the dogu container of the new version started and in the post-upgrade phase until the new version is installed.
+// The step only reports stalled upgrades in the UpgradeStalled condition and never interrupts the pipeline.
+type UpgradeStallDetectionStep struct {
+	client              k8sClient
+	deploymentInterface deploymentInterface
+	progressStore       progressStore
+	doguInterface       doguInterface
+	recorder            eventRecorder
+	deadlines           upgrade.PhaseDeadlines
+	now                 func() time.Time
+}
+
+func NewUpgradeStallDetectionStep(
+	client client.Client,
+	deploymentInterface appsv1.DeploymentInterface,
+	progressStore upgrade.ProgressStore,
+	doguInterface doguClient.DoguInterface,
+	recorder record.EventRecorder,
+	deadlines upgrade.PhaseDeadlines,
+) *UpgradeStallDetectionStep {
+	return &UpgradeStallDetectionStep{
+		client:              client,
+		deploymentInterface: deploymentInterface,
+		progressStore:       progressStore,
+		doguInterface:       doguInterface,
+		recorder:            recorder,
+		deadlines:           deadlines,
+		now:                 time.Now,
+	}
+}
+
+func (usd *UpgradeStallDetectionStep) Run(ctx context.Context, doguResource *v2.Dogu) steps.StepResult {
+	if doguResource.Status.Status != v2.DoguStatusUpgrading {
+		err := usd.finish(ctx, doguResource)
+		if err != nil {
+			return steps.RequeueWithError(err)
+		}
+		return steps.Continue()
+	}
+
+	phase, err := usd.currentPhase(ctx, doguResource)
+	if err != nil {
+		return steps.RequeueWithError(err)
+	}
+
+	progress, err := usd.progressStore.Get(ctx, doguResource)
+	if err != nil {
+		return steps.RequeueWithError(err)
+	}
+
+	if progress == nil || progress.TargetVersion != doguResource.Spec.Version || progress.Phase != phase {
+		progress = &upgrade.Progress{
+			TargetVersion:  doguResource.Spec.Version,
+			Phase:          phase,
+			PhaseStartedAt: metav1.NewTime(usd.now()),
+		}
+		err = usd.progressStore.Save(ctx, doguResource, progress)
+		if err != nil {
+			return steps.RequeueWithError(err)
+		}
+	}
+
+	deadlines := usd.deadlines.For(doguResource)
+	deadline := deadlines.Of(phase)
+	condition := upgrade.NewProgressingCondition(progress, deadline, doguResource.Generation)
+	stalled := deadlines.Exceeded(progress, usd.now())
+	if stalled {
+		condition = upgrade.NewStalledCondition(progress, deadline, doguResource.Generation)
+	}
+
+	previous := meta.FindStatusCondition(doguResource.Status.Conditions, upgrade.ConditionUpgradeStalled)
+	if previous != nil && previous.Status == condition.Status && previous.Reason == condition.Reason &&
+		previous.Message == condition.Message && previous.ObservedGeneration == condition.ObservedGeneration {
+		return steps.Continue()
+	}
+
+	err = usd.setCondition(ctx, doguResource, condition)
+	if err != nil {
+		return steps.RequeueWithError(err)
+	}
+
+	if stalled && (previous == nil || previous.Status != metav1.ConditionTrue) {
+		usd.recorder.Event(doguResource, corev1.EventTypeWarning, upgradeStalledEventReason, condition.Message)
+	}
+
+	return steps.Continue()
+}
+
+// currentPhase determines the phase of the upgrade from the deployment and the pods of the dogu.
+func (usd *UpgradeStallDetectionStep) currentPhase(ctx context.Context, doguResource *v2.Dogu) (upgrade.Phase, error) {
+	deployment, err := usd.deploymentInterface.Get(ctx, doguResource.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch deployment of dogu %q: %w", doguResource.Name, err)
+	}
+
+	if !isDeploymentOfVersion(deployment, doguResource.Spec.Version) {
+		return upgrade.PhasePreUpgrade, nil
+	}
+
+	pods := &corev1.PodList{}
+	err = usd.client.List(ctx, pods, client.InNamespace(doguResource.Namespace), client.MatchingLabels(doguResource.GetDoguNameLabel()))
+	if err != nil {
+		return "", fmt.Errorf("failed to list pods of dogu %q: %w", doguResource.Name, err)
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Labels[v2.DoguLabelVersion] == doguResource.Spec.Version && isContainerStarted(pod, doguResource.Name) {
+			return upgrade.PhasePostUpgrade, nil
+		}
+	}
+
+	return upgrade.PhaseRollout, nil
+}
+
+func isDeploymentOfVersion(deployment *apps.Deployment, version string) bool {
+	return deployment.Spec.Template.Labels[podTemplateVersionKey] == version
+}
+
+func isContainerStarted(pod corev1.Pod, containerName string) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == containerName {
+			return status.Started != nil && *status.Started
+		}
+	}
+
+	return false
+}
+
+// finish deletes the upgrade progress of the dogu and resolves the UpgradeStalled condition once the dogu is not
+// upgrading anymore.
+func (usd *UpgradeStallDetectionStep) finish(ctx context.Context, doguResource *v2.Dogu) error {
+	previous := meta.FindStatusCondition(doguResource.Status.Conditions, upgrade.ConditionUpgradeStalled)
+	if previous == nil || previous.Reason == upgrade.ReasonUpgradeFinished {
+		return nil
+	}
+
+	err := usd.progressStore.Delete(ctx, doguResource)
+	if err != nil {
+		return err
+	}
+
+	return usd.setCondition(ctx, doguResource, metav1.Condition{
+		Type:               upgrade.ConditionUpgradeStalled,
+		Status:             metav1.ConditionFalse,
+		Reason:             upgrade.ReasonUpgradeFinished,
+		Message:            "The dogu is not upgrading.",
+		ObservedGeneration: doguResource.Generation,
+	})
+}
+
+func (usd *UpgradeStallDetectionStep) setCondition(ctx context.Context, doguResource *v2.Dogu, condition metav1.Condition) error {
+	updatedDoguResource, err := usd.doguInterface.UpdateStatusWithRetry(ctx, doguResource, func(status v2.DoguStatus) v2.DoguStatus {
+		meta.SetStatusCondition(&status.Conditions, condition)
+		return status
+	}, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to update UpgradeStalled condition: %w", err)
+	}
+	*doguResource = *updatedDoguResource
+
+	return nil
+}
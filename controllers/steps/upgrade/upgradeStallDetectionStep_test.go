@@ -0,0 +1,294 @@
+package upgrade
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/upgrade"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var stallNow = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+type upgradeStallDetectionStepMocks struct {
+	client              *mockK8sClient
+	deploymentInterface *mockDeploymentInterface
+	progressStore       *mockProgressStore
+	doguInterface       *mockDoguInterface
+	recorder            *mockEventRecorder
+}
+
+func newTestUpgradeStallDetectionStep(t *testing.T) (*UpgradeStallDetectionStep, *upgradeStallDetectionStepMocks) {
+	mocks := &upgradeStallDetectionStepMocks{
+		client:              newMockK8sClient(t),
+		deploymentInterface: newMockDeploymentInterface(t),
+		progressStore:       newMockProgressStore(t),
+		doguInterface:       newMockDoguInterface(t),
+		recorder:            newMockEventRecorder(t),
+	}
+	step := &UpgradeStallDetectionStep{
+		client:              mocks.client,
+		deploymentInterface: mocks.deploymentInterface,
+		progressStore:       mocks.progressStore,
+		doguInterface:       mocks.doguInterface,
+		recorder:            mocks.recorder,
+		deadlines:           upgrade.PhaseDeadlines{PreUpgrade: 15 * time.Minute, Rollout: 3 * time.Hour, PostUpgrade: 15 * time.Minute},
+		now:                 func() time.Time { return stallNow },
+	}
+	return step, mocks
+}
+
+func newStallDogu() *v2.Dogu {
+	return &v2.Dogu{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: namespace, Generation: 2},
+		Spec:       v2.DoguSpec{Name: "official/ldap", Version: "2.0.0-1"},
+		Status:     v2.DoguStatus{Status: v2.DoguStatusUpgrading, InstalledVersion: "1.0.0-1"},
+	}
+}
+
+func newStallDeployment(version string) *apps.Deployment {
+	return &apps.Deployment{Spec: apps.DeploymentSpec{Template: corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{podTemplateVersionKey: version}},
+	}}}
+}
+
+func expectStallPods(mocks *upgradeStallDetectionStepMocks, doguResource *v2.Dogu, pods ...corev1.Pod) {
+	mocks.client.EXPECT().List(testCtx, &corev1.PodList{}, client.InNamespace(namespace), client.MatchingLabels(doguResource.GetDoguNameLabel())).
+		RunAndReturn(func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+			list.(*corev1.PodList).Items = pods
+			return nil
+		})
+}
+
+func newStallPod(version string, started bool) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{v2.DoguLabelVersion: version}},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+			{Name: "ldap", Started: &started},
+		}},
+	}
+}
+
+func expectStalledCondition(t *testing.T, mocks *upgradeStallDetectionStepMocks, doguResource *v2.Dogu, conditionStatus metav1.ConditionStatus, reason string) {
+	mocks.doguInterface.EXPECT().UpdateStatusWithRetry(testCtx, doguResource, mock.Anything, metav1.UpdateOptions{}).
+		RunAndReturn(func(ctx context.Context, dogu *v2.Dogu, modifyStatusFn func(v2.DoguStatus) v2.DoguStatus, opts metav1.UpdateOptions) (*v2.Dogu, error) {
+			status := modifyStatusFn(dogu.Status)
+			condition := meta.FindStatusCondition(status.Conditions, upgrade.ConditionUpgradeStalled)
+			require.NotNil(t, condition)
+			assert.Equal(t, conditionStatus, condition.Status)
+			assert.Equal(t, reason, condition.Reason)
+			assert.Equal(t, int64(2), condition.ObservedGeneration)
+			updated := dogu.DeepCopy()
+			updated.Status = status
+			return updated, nil
+		})
+}
+
+func TestNewUpgradeStallDetectionStep(t *testing.T) {
+	step := NewUpgradeStallDetectionStep(newMockK8sClient(t), newMockDeploymentInterface(t), newMockProgressStore(t),
+		newMockDoguInterface(t), newMockEventRecorder(t),
+		upgrade.PhaseDeadlines{PreUpgrade: time.Minute, Rollout: time.Hour, PostUpgrade: 2 * time.Minute})
+
+	assert.NotNil(t, step)
+	assert.Equal(t, upgrade.PhaseDeadlines{PreUpgrade: time.Minute, Rollout: time.Hour, PostUpgrade: 2 * time.Minute}, step.deadlines)
+}
+
+func TestUpgradeStallDetectionStep_Run(t *testing.T) {
+	t.Run("should continue if dogu is not upgrading", func(t *testing.T) {
+		// given
+		sut, _ := newTestUpgradeStallDetectionStep(t)
+		doguResource := newStallDogu()
+		doguResource.Status.Status = v2.DoguStatusInstalled
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should delete progress and resolve condition after upgrade", func(t *testing.T) {
+		// given
+		sut, mocks := newTestUpgradeStallDetectionStep(t)
+		doguResource := newStallDogu()
+		doguResource.Status.Status = v2.DoguStatusInstalled
+		doguResource.Status.Conditions = []metav1.Condition{{Type: upgrade.ConditionUpgradeStalled, Status: metav1.ConditionTrue, Reason: "RolloutStalled"}}
+		mocks.progressStore.EXPECT().Delete(testCtx, doguResource).Return(nil)
+		expectStalledCondition(t, mocks, doguResource, metav1.ConditionFalse, upgrade.ReasonUpgradeFinished)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should fail to delete progress after upgrade", func(t *testing.T) {
+		// given
+		sut, mocks := newTestUpgradeStallDetectionStep(t)
+		doguResource := newStallDogu()
+		doguResource.Status.Status = v2.DoguStatusInstalled
+		doguResource.Status.Conditions = []metav1.Condition{{Type: upgrade.ConditionUpgradeStalled, Status: metav1.ConditionFalse, Reason: upgrade.ReasonUpgradeProgressing}}
+		mocks.progressStore.EXPECT().Delete(testCtx, doguResource).Return(assert.AnError)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		require.ErrorIs(t, result.Err, assert.AnError)
+	})
+	t.Run("should fail to fetch deployment", func(t *testing.T) {
+		// given
+		sut, mocks := newTestUpgradeStallDetectionStep(t)
+		doguResource := newStallDogu()
+		mocks.deploymentInterface.EXPECT().Get(testCtx, "ldap", metav1.GetOptions{}).Return(nil, assert.AnError)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		require.ErrorIs(t, result.Err, assert.AnError)
+		assert.ErrorContains(t, result.Err, "failed to fetch deployment of dogu \"ldap\"")
+	})
+	t.Run("should record start of pre-upgrade phase", func(t *testing.T) {
+		// given
+		sut, mocks := newTestUpgradeStallDetectionStep(t)
+		doguResource := newStallDogu()
+		mocks.deploymentInterface.EXPECT().Get(testCtx, "ldap", metav1.GetOptions{}).Return(newStallDeployment("1.0.0-1"), nil)
+		mocks.progressStore.EXPECT().Get(testCtx, doguResource).Return(nil, nil)
+		mocks.progressStore.EXPECT().Save(testCtx, doguResource, &upgrade.Progress{
+			TargetVersion:  "2.0.0-1",
+			Phase:          upgrade.PhasePreUpgrade,
+			PhaseStartedAt: metav1.NewTime(stallNow),
+		}).Return(nil)
+		expectStalledCondition(t, mocks, doguResource, metav1.ConditionFalse, upgrade.ReasonUpgradeProgressing)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should fail to save progress", func(t *testing.T) {
+		// given
+		sut, mocks := newTestUpgradeStallDetectionStep(t)
+		doguResource := newStallDogu()
+		mocks.deploymentInterface.EXPECT().Get(testCtx, "ldap", metav1.GetOptions{}).Return(newStallDeployment("1.0.0-1"), nil)
+		mocks.progressStore.EXPECT().Get(testCtx, doguResource).Return(nil, nil)
+		mocks.progressStore.EXPECT().Save(testCtx, doguResource, mock.Anything).Return(assert.AnError)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		require.ErrorIs(t, result.Err, assert.AnError)
+	})
+	t.Run("should restart deadline when rollout phase begins", func(t *testing.T) {
+		// given
+		sut, mocks := newTestUpgradeStallDetectionStep(t)
+		doguResource := newStallDogu()
+		mocks.deploymentInterface.EXPECT().Get(testCtx, "ldap", metav1.GetOptions{}).Return(newStallDeployment("2.0.0-1"), nil)
+		expectStallPods(mocks, doguResource, newStallPod("1.0.0-1", true), newStallPod("2.0.0-1", false))
+		mocks.progressStore.EXPECT().Get(testCtx, doguResource).Return(&upgrade.Progress{
+			TargetVersion:  "2.0.0-1",
+			Phase:          upgrade.PhasePreUpgrade,
+			PhaseStartedAt: metav1.NewTime(stallNow.Add(-time.Hour)),
+		}, nil)
+		mocks.progressStore.EXPECT().Save(testCtx, doguResource, &upgrade.Progress{
+			TargetVersion:  "2.0.0-1",
+			Phase:          upgrade.PhaseRollout,
+			PhaseStartedAt: metav1.NewTime(stallNow),
+		}).Return(nil)
+		expectStalledCondition(t, mocks, doguResource, metav1.ConditionFalse, upgrade.ReasonUpgradeProgressing)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should fail to list pods", func(t *testing.T) {
+		// given
+		sut, mocks := newTestUpgradeStallDetectionStep(t)
+		doguResource := newStallDogu()
+		mocks.deploymentInterface.EXPECT().Get(testCtx, "ldap", metav1.GetOptions{}).Return(newStallDeployment("2.0.0-1"), nil)
+		mocks.client.EXPECT().List(testCtx, &corev1.PodList{}, client.InNamespace(namespace), client.MatchingLabels(doguResource.GetDoguNameLabel())).Return(assert.AnError)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		require.ErrorIs(t, result.Err, assert.AnError)
+		assert.ErrorContains(t, result.Err, "failed to list pods of dogu \"ldap\"")
+	})
+	t.Run("should not update unchanged condition", func(t *testing.T) {
+		// given
+		sut, mocks := newTestUpgradeStallDetectionStep(t)
+		doguResource := newStallDogu()
+		progress := &upgrade.Progress{
+			TargetVersion:  "2.0.0-1",
+			Phase:          upgrade.PhasePostUpgrade,
+			PhaseStartedAt: metav1.NewTime(stallNow.Add(-time.Minute)),
+		}
+		doguResource.Status.Conditions = []metav1.Condition{upgrade.NewProgressingCondition(progress, 15*time.Minute, 2)}
+		mocks.deploymentInterface.EXPECT().Get(testCtx, "ldap", metav1.GetOptions{}).Return(newStallDeployment("2.0.0-1"), nil)
+		expectStallPods(mocks, doguResource, newStallPod("2.0.0-1", true))
+		mocks.progressStore.EXPECT().Get(testCtx, doguResource).Return(progress, nil)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should report stalled rollout with per dogu deadline", func(t *testing.T) {
+		// given
+		sut, mocks := newTestUpgradeStallDetectionStep(t)
+		doguResource := newStallDogu()
+		doguResource.Annotations = map[string]string{upgrade.RolloutDeadlineAnnotation: "30m"}
+		mocks.deploymentInterface.EXPECT().Get(testCtx, "ldap", metav1.GetOptions{}).Return(newStallDeployment("2.0.0-1"), nil)
+		expectStallPods(mocks, doguResource, newStallPod("2.0.0-1", false))
+		mocks.progressStore.EXPECT().Get(testCtx, doguResource).Return(&upgrade.Progress{
+			TargetVersion:  "2.0.0-1",
+			Phase:          upgrade.PhaseRollout,
+			PhaseStartedAt: metav1.NewTime(stallNow.Add(-time.Hour)),
+		}, nil)
+		expectStalledCondition(t, mocks, doguResource, metav1.ConditionTrue, "RolloutStalled")
+		mocks.recorder.EXPECT().Event(mock.Anything, corev1.EventTypeWarning, "UpgradeStalled",
+			"The upgrade to 2.0.0-1 has not progressed past the rollout phase within 30m0s, the phase started at 2026-01-02T02:04:05Z.").Return()
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+		assert.True(t, meta.IsStatusConditionTrue(doguResource.Status.Conditions, upgrade.ConditionUpgradeStalled))
+	})
+	t.Run("should fail to update condition", func(t *testing.T) {
+		// given
+		sut, mocks := newTestUpgradeStallDetectionStep(t)
+		doguResource := newStallDogu()
+		mocks.deploymentInterface.EXPECT().Get(testCtx, "ldap", metav1.GetOptions{}).Return(newStallDeployment("1.0.0-1"), nil)
+		mocks.progressStore.EXPECT().Get(testCtx, doguResource).Return(&upgrade.Progress{
+			TargetVersion:  "2.0.0-1",
+			Phase:          upgrade.PhasePreUpgrade,
+			PhaseStartedAt: metav1.NewTime(stallNow),
+		}, nil)
+		mocks.doguInterface.EXPECT().UpdateStatusWithRetry(testCtx, doguResource, mock.Anything, metav1.UpdateOptions{}).Return(nil, assert.AnError)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		require.ErrorIs(t, result.Err, assert.AnError)
+		assert.ErrorContains(t, result.Err, "failed to update UpgradeStalled condition")
+	})
+}
@@ -0,0 +1,204 @@
+package upgrade
+
+import (
+	"context"
+	"testing"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/volumesnapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type volumeSnapshotStepMocks struct {
+	upgradeChecker *mockUpgradeChecker
+	snapshotter    *mockVolumeSnapshotter
+	doguInterface  *mockDoguInterface
+	recorder       *mockEventRecorder
+}
+
+func newVolumeSnapshotStepWithMocks(t *testing.T) (*VolumeSnapshotStep, volumeSnapshotStepMocks) {
+	mocks := volumeSnapshotStepMocks{
+		upgradeChecker: newMockUpgradeChecker(t),
+		snapshotter:    newMockVolumeSnapshotter(t),
+		doguInterface:  newMockDoguInterface(t),
+		recorder:       newMockEventRecorder(t),
+	}
+	return NewVolumeSnapshotStep(mocks.upgradeChecker, mocks.snapshotter, mocks.doguInterface, mocks.recorder), mocks
+}
+
+func getVolumeSnapshotTestDogu() *v2.Dogu {
+	return &v2.Dogu{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "ldap",
+			Namespace:   "ecosystem",
+			Generation:  4,
+			Annotations: map[string]string{volumesnapshot.Annotation: "true"},
+		},
+		Spec:   v2.DoguSpec{Name: "official/ldap", Version: "2.0.0-1"},
+		Status: v2.DoguStatus{InstalledVersion: "1.0.0-1"},
+	}
+}
+
+func expectVolumeSnapshotCondition(t *testing.T, mocks volumeSnapshotStepMocks, doguResource *v2.Dogu, status metav1.ConditionStatus, reason string) {
+	mocks.doguInterface.EXPECT().UpdateStatusWithRetry(testCtx, doguResource, mock.Anything, metav1.UpdateOptions{}).
+		RunAndReturn(func(ctx context.Context, dogu *v2.Dogu, modifyStatusFn func(v2.DoguStatus) v2.DoguStatus, opts metav1.UpdateOptions) (*v2.Dogu, error) {
+			updated := dogu.DeepCopy()
+			updated.Status = modifyStatusFn(dogu.Status)
+			condition := meta.FindStatusCondition(updated.Status.Conditions, volumesnapshot.ConditionVolumeSnapshotReady)
+			require.NotNil(t, condition)
+			assert.Equal(t, status, condition.Status)
+			assert.Equal(t, reason, condition.Reason)
+			return updated, nil
+		})
+}
+
+func TestVolumeSnapshotStep_Run(t *testing.T) {
+	t.Run("should continue if volume snapshots are not enabled", func(t *testing.T) {
+		// given
+		sut, _ := newVolumeSnapshotStepWithMocks(t)
+		doguResource := getVolumeSnapshotTestDogu()
+		doguResource.Annotations = nil
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should fail to check for upgrade", func(t *testing.T) {
+		// given
+		sut, mocks := newVolumeSnapshotStepWithMocks(t)
+		doguResource := getVolumeSnapshotTestDogu()
+		mocks.upgradeChecker.EXPECT().IsUpgrade(testCtx, doguResource).Return(false, assert.AnError)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		require.ErrorIs(t, result.Err, assert.AnError)
+		assert.ErrorContains(t, result.Err, "failed to check if dogu is upgrading")
+	})
+	t.Run("should continue if dogu is not upgrading", func(t *testing.T) {
+		// given
+		sut, mocks := newVolumeSnapshotStepWithMocks(t)
+		doguResource := getVolumeSnapshotTestDogu()
+		mocks.upgradeChecker.EXPECT().IsUpgrade(testCtx, doguResource).Return(false, nil)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should fail to take volume snapshot", func(t *testing.T) {
+		// given
+		sut, mocks := newVolumeSnapshotStepWithMocks(t)
+		doguResource := getVolumeSnapshotTestDogu()
+		mocks.upgradeChecker.EXPECT().IsUpgrade(testCtx, doguResource).Return(true, nil)
+		mocks.snapshotter.EXPECT().Take(testCtx, doguResource, volumesnapshot.PurposeUpgrade).Return(nil, assert.AnError)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		require.ErrorIs(t, result.Err, assert.AnError)
+		assert.ErrorContains(t, result.Err, "failed to take volume snapshot before upgrade")
+	})
+	t.Run("should continue if dogu has no data volume", func(t *testing.T) {
+		// given
+		sut, mocks := newVolumeSnapshotStepWithMocks(t)
+		doguResource := getVolumeSnapshotTestDogu()
+		mocks.upgradeChecker.EXPECT().IsUpgrade(testCtx, doguResource).Return(true, nil)
+		mocks.snapshotter.EXPECT().Take(testCtx, doguResource, volumesnapshot.PurposeUpgrade).Return(nil, nil)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+	t.Run("should wait for pending volume snapshot", func(t *testing.T) {
+		// given
+		sut, mocks := newVolumeSnapshotStepWithMocks(t)
+		doguResource := getVolumeSnapshotTestDogu()
+		mocks.upgradeChecker.EXPECT().IsUpgrade(testCtx, doguResource).Return(true, nil)
+		mocks.snapshotter.EXPECT().Take(testCtx, doguResource, volumesnapshot.PurposeUpgrade).Return(&volumesnapshot.State{Name: "ldap-upgrade-4"}, nil)
+		expectVolumeSnapshotCondition(t, mocks, doguResource, metav1.ConditionFalse, volumesnapshot.ReasonSnapshotPending)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.RequeueAfter(requeueAfterVolumeSnapshot), result)
+		assert.True(t, meta.IsStatusConditionFalse(doguResource.Status.Conditions, volumesnapshot.ConditionVolumeSnapshotReady))
+	})
+	t.Run("should not update unchanged condition", func(t *testing.T) {
+		// given
+		sut, mocks := newVolumeSnapshotStepWithMocks(t)
+		doguResource := getVolumeSnapshotTestDogu()
+		state := &volumesnapshot.State{Name: "ldap-upgrade-4"}
+		doguResource.Status.Conditions = []metav1.Condition{volumesnapshot.NewCondition(state, volumesnapshot.PurposeUpgrade, 4)}
+		mocks.upgradeChecker.EXPECT().IsUpgrade(testCtx, doguResource).Return(true, nil)
+		mocks.snapshotter.EXPECT().Take(testCtx, doguResource, volumesnapshot.PurposeUpgrade).Return(state, nil)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.RequeueAfter(requeueAfterVolumeSnapshot), result)
+	})
+	t.Run("should fail to update condition", func(t *testing.T) {
+		// given
+		sut, mocks := newVolumeSnapshotStepWithMocks(t)
+		doguResource := getVolumeSnapshotTestDogu()
+		mocks.upgradeChecker.EXPECT().IsUpgrade(testCtx, doguResource).Return(true, nil)
+		mocks.snapshotter.EXPECT().Take(testCtx, doguResource, volumesnapshot.PurposeUpgrade).Return(&volumesnapshot.State{Name: "ldap-upgrade-4"}, nil)
+		mocks.doguInterface.EXPECT().UpdateStatusWithRetry(testCtx, doguResource, mock.Anything, metav1.UpdateOptions{}).Return(nil, assert.AnError)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		require.ErrorIs(t, result.Err, assert.AnError)
+		assert.ErrorContains(t, result.Err, "failed to update VolumeSnapshotReady condition")
+	})
+	t.Run("should record event and requeue failed volume snapshot", func(t *testing.T) {
+		// given
+		sut, mocks := newVolumeSnapshotStepWithMocks(t)
+		doguResource := getVolumeSnapshotTestDogu()
+		mocks.upgradeChecker.EXPECT().IsUpgrade(testCtx, doguResource).Return(true, nil)
+		mocks.snapshotter.EXPECT().Take(testCtx, doguResource, volumesnapshot.PurposeUpgrade).
+			Return(&volumesnapshot.State{Name: "ldap-upgrade-4", Error: "no space left"}, nil)
+		expectVolumeSnapshotCondition(t, mocks, doguResource, metav1.ConditionFalse, volumesnapshot.ReasonSnapshotFailed)
+		mocks.recorder.EXPECT().Event(mock.Anything, corev1.EventTypeWarning, volumeSnapshotFailedEventReason,
+			`Volume snapshot "ldap-upgrade-4" before the upgrade failed: no space left`)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		require.Error(t, result.Err)
+		assert.ErrorContains(t, result.Err, `volume snapshot "ldap-upgrade-4" before upgrade failed: no space left`)
+	})
+	t.Run("should continue once volume snapshot is ready to use", func(t *testing.T) {
+		// given
+		sut, mocks := newVolumeSnapshotStepWithMocks(t)
+		doguResource := getVolumeSnapshotTestDogu()
+		mocks.upgradeChecker.EXPECT().IsUpgrade(testCtx, doguResource).Return(true, nil)
+		mocks.snapshotter.EXPECT().Take(testCtx, doguResource, volumesnapshot.PurposeUpgrade).
+			Return(&volumesnapshot.State{Name: "ldap-upgrade-4", DoguVersion: "1.0.0-1", ReadyToUse: true}, nil)
+		expectVolumeSnapshotCondition(t, mocks, doguResource, metav1.ConditionTrue, volumesnapshot.ReasonSnapshotReady)
+
+		// when
+		result := sut.Run(testCtx, doguResource)
+
+		// then
+		assert.Equal(t, steps.Continue(), result)
+	})
+}
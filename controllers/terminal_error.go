@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"fmt"
+
+	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ConditionStalled is true if the last reconcile of a dogu failed with an error which cannot be fixed by retrying.
+	ConditionStalled = "Stalled"
+	// ReasonTerminalError is the reason of the Stalled condition if the dogu is not requeued anymore.
+	ReasonTerminalError = "TerminalError"
+	// ReasonTerminalUntilSpecChange is the reason of the Stalled condition if the dogu is not reconciled anymore until
+	// its spec changes.
+	ReasonTerminalUntilSpecChange = "TerminalUntilSpecChange"
+	// ReasonNotStalled is the reason of the Stalled condition if the last reconcile did not fail with a terminal error.
+	ReasonNotStalled = "NotStalled"
+)
+
+func newStalledCondition(reconcileError error, generation int64) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               ConditionStalled,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonNotStalled,
+		Message:            "The last reconcile did not fail with a terminal error",
+		ObservedGeneration: generation,
+	}
+	if reconcileError == nil {
+		return condition
+	}
+
+	switch steps.ClassifyError(reconcileError) {
+	case steps.ErrorClassTerminalUntilSpecChange:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = ReasonTerminalUntilSpecChange
+		condition.Message = fmt.Sprintf("The dogu is not reconciled until its spec changes because of: %s", reconcileError.Error())
+	case steps.ErrorClassTerminal:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = ReasonTerminalError
+		condition.Message = fmt.Sprintf("The dogu is not requeued because of: %s", reconcileError.Error())
+	}
+
+	return condition
+}
+
+// isStalledUntilSpecChange returns true if the last reconcile of the current generation of the dogu failed with an
+// error which can only be fixed by changing the spec.
+func isStalledUntilSpecChange(doguResource *doguv2.Dogu) bool {
+	condition := meta.FindStatusCondition(doguResource.Status.Conditions, ConditionStalled)
+	return condition != nil &&
+		condition.Status == metav1.ConditionTrue &&
+		condition.Reason == ReasonTerminalUntilSpecChange &&
+		condition.ObservedGeneration == doguResource.Generation
+}
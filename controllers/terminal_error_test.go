@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"testing"
+
+	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_newStalledCondition(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  v1.ConditionStatus
+		wantReason  string
+		wantMessage string
+	}{
+		{
+			name:        "should not be stalled without error",
+			err:         nil,
+			wantStatus:  v1.ConditionFalse,
+			wantReason:  ReasonNotStalled,
+			wantMessage: "The last reconcile did not fail with a terminal error",
+		},
+		{
+			name:        "should not be stalled for retryable error",
+			err:         assert.AnError,
+			wantStatus:  v1.ConditionFalse,
+			wantReason:  ReasonNotStalled,
+			wantMessage: "The last reconcile did not fail with a terminal error",
+		},
+		{
+			name:        "should be stalled until spec change",
+			err:         steps.NewTerminalUntilSpecChangeError(assert.AnError),
+			wantStatus:  v1.ConditionTrue,
+			wantReason:  ReasonTerminalUntilSpecChange,
+			wantMessage: "The dogu is not reconciled until its spec changes because of: " + assert.AnError.Error(),
+		},
+		{
+			name:        "should be stalled for terminal error",
+			err:         steps.NewTerminalError(assert.AnError),
+			wantStatus:  v1.ConditionTrue,
+			wantReason:  ReasonTerminalError,
+			wantMessage: "The dogu is not requeued because of: " + assert.AnError.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition := newStalledCondition(tt.err, 3)
+
+			assert.Equal(t, ConditionStalled, condition.Type)
+			assert.Equal(t, tt.wantStatus, condition.Status)
+			assert.Equal(t, tt.wantReason, condition.Reason)
+			assert.Equal(t, tt.wantMessage, condition.Message)
+			assert.Equal(t, int64(3), condition.ObservedGeneration)
+		})
+	}
+}
+
+func Test_isStalledUntilSpecChange(t *testing.T) {
+	newDogu := func(generation int64, condition *v1.Condition) *doguv2.Dogu {
+		doguResource := &doguv2.Dogu{ObjectMeta: v1.ObjectMeta{Name: testDoguName, Generation: generation}}
+		if condition != nil {
+			doguResource.Status.Conditions = []v1.Condition{*condition}
+		}
+		return doguResource
+	}
+	stalledCondition := newStalledCondition(steps.NewTerminalUntilSpecChangeError(assert.AnError), 2)
+	terminalCondition := newStalledCondition(steps.NewTerminalError(assert.AnError), 2)
+
+	assert.False(t, isStalledUntilSpecChange(newDogu(2, nil)))
+	assert.True(t, isStalledUntilSpecChange(newDogu(2, &stalledCondition)))
+	assert.False(t, isStalledUntilSpecChange(newDogu(3, &stalledCondition)))
+	assert.False(t, isStalledUntilSpecChange(newDogu(2, &terminalCondition)))
+}
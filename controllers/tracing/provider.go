@@ -0,0 +1,63 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/fx"
+)
+
+const serviceName = "k8s-dogu-operator"
+
+// NewTracerProvider creates the tracer provider of the operator and registers it globally.
+// If tracing is disabled, a noop provider is used. Otherwise, spans are exported via OTLP/HTTP to the endpoint
+// configured with the standard environment variables like OTEL_EXPORTER_OTLP_ENDPOINT.
+func NewTracerProvider(lc fx.Lifecycle, operatorConfig *config.OperatorConfig) (trace.TracerProvider, error) {
+	if !operatorConfig.TracingEnabled {
+		provider := noop.NewTracerProvider()
+		otel.SetTracerProvider(provider)
+		return provider, nil
+	}
+
+	version := ""
+	if operatorConfig.Version != nil {
+		version = operatorConfig.Version.Raw
+	}
+
+	provider, err := newOtlpTracerProvider(context.Background(), version)
+	if err != nil {
+		return nil, err
+	}
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	lc.Append(fx.StopHook(func(ctx context.Context) error {
+		return provider.Shutdown(ctx)
+	}))
+
+	return provider, nil
+}
+
+func newOtlpTracerProvider(ctx context.Context, version string, options ...otlptracehttp.Option) (*sdktrace.TracerProvider, error) {
+	exporter, err := otlptracehttp.New(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create otlp trace exporter: %w", err)
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(sdkresource.NewSchemaless(
+			attribute.String("service.name", serviceName),
+			attribute.String("service.version", version),
+		)),
+	), nil
+}
@@ -0,0 +1,110 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/cloudogu/cesapp-lib/core"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace/noop"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"go.uber.org/fx/fxtest"
+	"google.golang.org/protobuf/proto"
+)
+
+// collectorStandIn is an in-process stand-in for an OTLP/HTTP collector which stores the names of all received spans.
+type collectorStandIn struct {
+	mutex     sync.Mutex
+	spanNames []string
+	resources []string
+}
+
+func (c *collectorStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil || r.URL.Path != "/v1/traces" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	request := &coltracepb.ExportTraceServiceRequest{}
+	if err = proto.Unmarshal(body, request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, resourceSpans := range request.GetResourceSpans() {
+		for _, attr := range resourceSpans.GetResource().GetAttributes() {
+			c.resources = append(c.resources, attr.GetKey()+"="+attr.GetValue().GetStringValue())
+		}
+		for _, scopeSpans := range resourceSpans.GetScopeSpans() {
+			for _, span := range scopeSpans.GetSpans() {
+				c.spanNames = append(c.spanNames, span.GetName())
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.WriteHeader(http.StatusOK)
+}
+
+func restoreGlobalTracerProvider(t *testing.T) {
+	oldProvider := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(oldProvider) })
+}
+
+func TestNewTracerProvider(t *testing.T) {
+	t.Run("should use noop provider if tracing is disabled", func(t *testing.T) {
+		// given
+		restoreGlobalTracerProvider(t)
+		lc := fxtest.NewLifecycle(t)
+
+		// when
+		provider, err := NewTracerProvider(lc, &config.OperatorConfig{TracingEnabled: false})
+
+		// then
+		require.NoError(t, err)
+		assert.IsType(t, noop.TracerProvider{}, provider)
+		assert.Equal(t, provider, otel.GetTracerProvider())
+	})
+	t.Run("should export spans to collector", func(t *testing.T) {
+		// given
+		restoreGlobalTracerProvider(t)
+		collector := &collectorStandIn{}
+		server := httptest.NewServer(collector)
+		defer server.Close()
+		t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", server.URL)
+
+		version, err := core.ParseVersion("3.23.0")
+		require.NoError(t, err)
+		lc := fxtest.NewLifecycle(t)
+
+		// when
+		_, err = NewTracerProvider(lc, &config.OperatorConfig{TracingEnabled: true, Version: &version})
+		require.NoError(t, err)
+		lc.RequireStart()
+
+		ctx, reconcileSpan := Start(context.Background(), "DoguReconciler.Reconcile")
+		_, stepSpan := Start(ctx, "validation")
+		End(stepSpan, nil)
+		End(reconcileSpan, nil)
+
+		// the stop hook shuts the provider down which flushes all spans
+		lc.RequireStop()
+
+		// then
+		collector.mutex.Lock()
+		defer collector.mutex.Unlock()
+		assert.ElementsMatch(t, []string{"DoguReconciler.Reconcile", "validation"}, collector.spanNames)
+		assert.Contains(t, collector.resources, "service.name=k8s-dogu-operator")
+		assert.Contains(t, collector.resources, "service.version=3.23.0")
+	})
+}
@@ -0,0 +1,50 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const tracerName = "github.com/cloudogu/k8s-dogu-operator"
+
+const (
+	// AttributeDogu contains the name of the dogu a span belongs to.
+	AttributeDogu = attribute.Key("dogu.name")
+	// AttributeNamespace contains the namespace of the dogu a span belongs to.
+	AttributeNamespace = attribute.Key("dogu.namespace")
+	// AttributeStepOutcome contains the outcome of a reconcile step.
+	AttributeStepOutcome = attribute.Key("step.outcome")
+)
+
+const logKeyTraceID = "traceID"
+
+// Start starts a new span as child of the span in the context.
+// If tracing is disabled the global tracer provider is a noop provider and the span is not recorded.
+func Start(ctx context.Context, spanName string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, spanName, trace.WithAttributes(attributes...))
+}
+
+// End ends the span and marks it as failed if an error is given.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// WithTraceIDLogger adds the trace id of the span in the context to the logger in the context
+// so that log messages can be correlated with the trace.
+func WithTraceIDLogger(ctx context.Context) context.Context {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ctx
+	}
+
+	return log.IntoContext(ctx, log.FromContext(ctx).WithValues(logKeyTraceID, spanContext.TraceID().String()))
+}
@@ -0,0 +1,94 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var testCtx = context.Background()
+
+// useSpanRecorder registers a tracer provider which records all ended spans in memory.
+func useSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	oldProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(oldProvider) })
+	return recorder
+}
+
+func TestStartAndEnd(t *testing.T) {
+	t.Run("should record child span with attributes", func(t *testing.T) {
+		// given
+		recorder := useSpanRecorder(t)
+
+		// when
+		ctx, parent := Start(testCtx, "parent")
+		_, child := Start(ctx, "child", AttributeDogu.String("ldap"))
+		End(child, nil)
+		End(parent, nil)
+
+		// then
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+		assert.Equal(t, "child", spans[0].Name())
+		assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+		assert.Contains(t, spans[0].Attributes(), AttributeDogu.String("ldap"))
+		assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	})
+	t.Run("should mark span as failed on error", func(t *testing.T) {
+		// given
+		recorder := useSpanRecorder(t)
+
+		// when
+		_, span := Start(testCtx, "failing")
+		End(span, assert.AnError)
+
+		// then
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Equal(t, assert.AnError.Error(), spans[0].Status().Description)
+		require.Len(t, spans[0].Events(), 1)
+	})
+}
+
+func TestWithTraceIDLogger(t *testing.T) {
+	t.Run("should add trace id to logger", func(t *testing.T) {
+		// given
+		useSpanRecorder(t)
+		var logged string
+		logger := funcr.New(func(prefix, args string) { logged = args }, funcr.Options{})
+		ctx, span := Start(log.IntoContext(testCtx, logger), "reconcile")
+		defer span.End()
+
+		// when
+		ctx = WithTraceIDLogger(ctx)
+
+		// then
+		log.FromContext(ctx).Info("test")
+		assert.Contains(t, logged, span.SpanContext().TraceID().String())
+		assert.Contains(t, logged, `"traceID"`)
+	})
+	t.Run("should not change context without span", func(t *testing.T) {
+		// given
+		ctx := log.IntoContext(testCtx, logr.Discard())
+
+		// when
+		got := WithTraceIDLogger(ctx)
+
+		// then
+		assert.Equal(t, ctx, got)
+		assert.False(t, trace.SpanContextFromContext(got).IsValid())
+	})
+}
@@ -0,0 +1,60 @@
+package tracing
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// transport creates a client span for every request, e.g. the requests of the K8s clients to the API server.
+type transport struct {
+	delegate   http.RoundTripper
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// NewTransportWrapper returns a function that wraps a round tripper with a tracing round tripper.
+// It can be used as WrapTransport of a rest config.
+func NewTransportWrapper(provider trace.TracerProvider) func(http.RoundTripper) http.RoundTripper {
+	return func(delegate http.RoundTripper) http.RoundTripper {
+		return &transport{
+			delegate:   delegate,
+			tracer:     provider.Tracer(tracerName),
+			propagator: propagation.TraceContext{},
+		}
+	}
+}
+
+// RoundTrip executes the request within a client span.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := t.tracer.Start(req.Context(), fmt.Sprintf("HTTP %s", req.Method),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.path", req.URL.Path),
+			attribute.String("server.address", req.URL.Host),
+		),
+	)
+	defer span.End()
+
+	req = req.Clone(ctx)
+	t.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := t.delegate.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,77 @@
+package tracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestNewTransportWrapper(t *testing.T) {
+	t.Run("should create client span for request and propagate trace context", func(t *testing.T) {
+		// given
+		var traceparent string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			traceparent = r.Header.Get("traceparent")
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		recorder := tracetest.NewSpanRecorder()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		client := &http.Client{Transport: NewTransportWrapper(provider)(http.DefaultTransport)}
+
+		// when
+		resp, err := client.Get(server.URL + "/api/v1/namespaces/ecosystem/pods")
+
+		// then
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "HTTP GET", spans[0].Name())
+		assert.Contains(t, spans[0].Attributes(), attribute.String("url.path", "/api/v1/namespaces/ecosystem/pods"))
+		assert.Contains(t, spans[0].Attributes(), attribute.Int("http.response.status_code", http.StatusOK))
+		assert.Contains(t, traceparent, spans[0].SpanContext().TraceID().String())
+	})
+	t.Run("should mark span as failed on server error", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		recorder := tracetest.NewSpanRecorder()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		client := &http.Client{Transport: NewTransportWrapper(provider)(http.DefaultTransport)}
+
+		// when
+		resp, err := client.Get(server.URL)
+
+		// then
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		require.Len(t, recorder.Ended(), 1)
+		assert.Equal(t, codes.Error, recorder.Ended()[0].Status().Code)
+	})
+	t.Run("should mark span as failed if request fails", func(t *testing.T) {
+		// given
+		recorder := tracetest.NewSpanRecorder()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		client := &http.Client{Transport: NewTransportWrapper(provider)(http.DefaultTransport)}
+
+		// when
+		_, err := client.Get("http://127.0.0.1:0")
+
+		// then
+		require.Error(t, err)
+		require.Len(t, recorder.Ended(), 1)
+		assert.Equal(t, codes.Error, recorder.Ended()[0].Status().Code)
+	})
+}
@@ -5,6 +5,7 @@ import (
 
 	k8sv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/cesregistry"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
 )
 
 type localDoguFetcher interface {
@@ -16,3 +17,29 @@ type Checker interface {
 	// IsUpgrade returns if a dogu needs to be upgraded
 	IsUpgrade(ctx context.Context, doguResource *k8sv2.Dogu) (bool, error)
 }
+
+// SnapshotStore keeps the state of a dogu before its upgrade, so that a failed upgrade can be rolled back.
+type SnapshotStore interface {
+	// Get returns the upgrade snapshot of the dogu or nil if there is none.
+	Get(ctx context.Context, doguResource *k8sv2.Dogu) (*Snapshot, error)
+	// Save creates or replaces the upgrade snapshot of the dogu.
+	Save(ctx context.Context, doguResource *k8sv2.Dogu, snapshot *Snapshot) error
+	// Delete removes the upgrade snapshot of the dogu. It does not fail if there is none.
+	Delete(ctx context.Context, doguResource *k8sv2.Dogu) error
+}
+
+// ProgressStore keeps the current phase of a running upgrade, so that stalled upgrades can be detected.
+type ProgressStore interface {
+	// Get returns the upgrade progress of the dogu or nil if there is none.
+	Get(ctx context.Context, doguResource *k8sv2.Dogu) (*Progress, error)
+	// Save creates or replaces the upgrade progress of the dogu.
+	Save(ctx context.Context, doguResource *k8sv2.Dogu, progress *Progress) error
+	// Delete removes the upgrade progress of the dogu. It does not fail if there is none.
+	Delete(ctx context.Context, doguResource *k8sv2.Dogu) error
+}
+
+//nolint:unused
+//goland:noinspection GoUnusedType
+type configMapInterface interface {
+	v1.ConfigMapInterface
+}
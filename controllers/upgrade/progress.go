@@ -0,0 +1,94 @@
+package upgrade
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/dogustore"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+const (
+	progressConfigMapNameSuffix = "-upgrade-progress"
+	// ProgressKey is the key in the upgrade progress config map that contains the progress as JSON.
+	ProgressKey = "progress"
+	// ProgressLabel marks config maps that contain the upgrade progress of a dogu.
+	ProgressLabel = "k8s.cloudogu.com/upgrade-progress"
+)
+
+// ProgressConfigMapName returns the name of the config map containing the upgrade progress of the dogu.
+func ProgressConfigMapName(doguName string) string {
+	return doguName + progressConfigMapNameSuffix
+}
+
+// Progress records the current phase of a running upgrade.
+type Progress struct {
+	// TargetVersion is the version the dogu is upgraded to.
+	TargetVersion string `json:"targetVersion"`
+	// Phase is the current phase of the upgrade.
+	Phase Phase `json:"phase"`
+	// PhaseStartedAt is the time at which the current phase started. The deadline of the phase is counted from here.
+	PhaseStartedAt metav1.Time `json:"phaseStartedAt"`
+}
+
+type configMapProgressStore struct {
+	store *dogustore.ConfigMapStore
+}
+
+// NewConfigMapProgressStore creates a ProgressStore which keeps the upgrade progress of each dogu in a config map.
+func NewConfigMapProgressStore(configMapInterface v1.ConfigMapInterface, scheme *runtime.Scheme) ProgressStore {
+	return &configMapProgressStore{
+		store: dogustore.NewConfigMapStore(configMapInterface, scheme, progressConfigMapNameSuffix, ProgressLabel),
+	}
+}
+
+// Get reads the upgrade progress from the config map of the dogu.
+func (s *configMapProgressStore) Get(ctx context.Context, doguResource *v2.Dogu) (*Progress, error) {
+	data, err := s.store.Get(ctx, doguResource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upgrade progress of dogu %q: %w", doguResource.Name, err)
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	progress := &Progress{}
+	err = json.Unmarshal([]byte(data[ProgressKey]), progress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse upgrade progress of dogu %q: %w", doguResource.Name, err)
+	}
+
+	return progress, nil
+}
+
+// Save writes the upgrade progress into the config map of the dogu.
+func (s *configMapProgressStore) Save(ctx context.Context, doguResource *v2.Dogu, progress *Progress) error {
+	progressJson, err := json.Marshal(progress)
+	if err != nil {
+		return fmt.Errorf("failed to serialize upgrade progress of dogu %q: %w", doguResource.Name, err)
+	}
+
+	err = s.store.Update(ctx, doguResource, func(data map[string]string) error {
+		data[ProgressKey] = string(progressJson)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save upgrade progress of dogu %q: %w", doguResource.Name, err)
+	}
+
+	return nil
+}
+
+// Delete deletes the upgrade progress config map of the dogu.
+func (s *configMapProgressStore) Delete(ctx context.Context, doguResource *v2.Dogu) error {
+	err := s.store.Delete(ctx, doguResource)
+	if err != nil {
+		return fmt.Errorf("failed to delete upgrade progress of dogu %q: %w", doguResource.Name, err)
+	}
+
+	return nil
+}
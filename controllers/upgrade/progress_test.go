@@ -0,0 +1,195 @@
+package upgrade
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func getTestProgress() *Progress {
+	return &Progress{
+		TargetVersion:  "2.0.0-1",
+		Phase:          PhaseRollout,
+		PhaseStartedAt: metav1.NewTime(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)),
+	}
+}
+
+func newProgressConfigMap(t *testing.T, progress *Progress) *corev1.ConfigMap {
+	progressJson, err := json.Marshal(progress)
+	require.NoError(t, err)
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap-upgrade-progress", Namespace: "ecosystem"},
+		Data:       map[string]string{ProgressKey: string(progressJson)},
+	}
+}
+
+func TestProgressConfigMapName(t *testing.T) {
+	assert.Equal(t, "ldap-upgrade-progress", ProgressConfigMapName("ldap"))
+}
+
+func Test_configMapProgressStore_Get(t *testing.T) {
+	t.Run("should return nil if there is no progress", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-upgrade-progress", metav1.GetOptions{}).
+			Return(nil, errors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "ldap-upgrade-progress"))
+		sut := NewConfigMapProgressStore(cmMock, getTestScheme())
+
+		// when
+		progress, err := sut.Get(testCtx, getSnapshotTestDogu())
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, progress)
+	})
+	t.Run("should fail to get config map", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-upgrade-progress", metav1.GetOptions{}).Return(nil, assert.AnError)
+		sut := NewConfigMapProgressStore(cmMock, getTestScheme())
+
+		// when
+		_, err := sut.Get(testCtx, getSnapshotTestDogu())
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get upgrade progress of dogu \"ldap\"")
+	})
+	t.Run("should fail to parse progress", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cm := &corev1.ConfigMap{Data: map[string]string{ProgressKey: "{"}}
+		cmMock.EXPECT().Get(testCtx, "ldap-upgrade-progress", metav1.GetOptions{}).Return(cm, nil)
+		sut := NewConfigMapProgressStore(cmMock, getTestScheme())
+
+		// when
+		_, err := sut.Get(testCtx, getSnapshotTestDogu())
+
+		// then
+		assert.ErrorContains(t, err, "failed to parse upgrade progress of dogu \"ldap\"")
+	})
+	t.Run("should return progress", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-upgrade-progress", metav1.GetOptions{}).Return(newProgressConfigMap(t, getTestProgress()), nil)
+		sut := NewConfigMapProgressStore(cmMock, getTestScheme())
+
+		// when
+		progress, err := sut.Get(testCtx, getSnapshotTestDogu())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "2.0.0-1", progress.TargetVersion)
+		assert.Equal(t, PhaseRollout, progress.Phase)
+		assert.True(t, getTestProgress().PhaseStartedAt.Equal(&progress.PhaseStartedAt))
+	})
+}
+
+func Test_configMapProgressStore_Save(t *testing.T) {
+	notFoundErr := errors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "ldap-upgrade-progress")
+
+	t.Run("should create progress config map with non-controlling owner reference", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-upgrade-progress", metav1.GetOptions{}).Return(nil, notFoundErr)
+		cmMock.EXPECT().Create(testCtx, mock.Anything, metav1.CreateOptions{}).
+			RunAndReturn(func(ctx context.Context, cm *corev1.ConfigMap, opts metav1.CreateOptions) (*corev1.ConfigMap, error) {
+				assert.Equal(t, "ldap-upgrade-progress", cm.Name)
+				assert.Equal(t, "ecosystem", cm.Namespace)
+				assert.Equal(t, "true", cm.Labels[ProgressLabel])
+				assert.Equal(t, "ldap", cm.Labels["dogu.name"])
+				require.Len(t, cm.OwnerReferences, 1)
+				assert.Equal(t, "dogu", cm.OwnerReferences[0].Kind)
+				assert.Nil(t, cm.OwnerReferences[0].Controller)
+				assert.Equal(t, newProgressConfigMap(t, getTestProgress()).Data, cm.Data)
+				return cm, nil
+			})
+		sut := NewConfigMapProgressStore(cmMock, getTestScheme())
+
+		// when
+		err := sut.Save(testCtx, getSnapshotTestDogu(), getTestProgress())
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail to create progress config map", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-upgrade-progress", metav1.GetOptions{}).Return(nil, notFoundErr)
+		cmMock.EXPECT().Create(testCtx, mock.Anything, metav1.CreateOptions{}).Return(nil, assert.AnError)
+		sut := NewConfigMapProgressStore(cmMock, getTestScheme())
+
+		// when
+		err := sut.Save(testCtx, getSnapshotTestDogu(), getTestProgress())
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to save upgrade progress of dogu \"ldap\"")
+	})
+	t.Run("should update existing progress config map", func(t *testing.T) {
+		// given
+		postUpgrade := getTestProgress()
+		postUpgrade.Phase = PhasePostUpgrade
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-upgrade-progress", metav1.GetOptions{}).Return(newProgressConfigMap(t, getTestProgress()), nil)
+		cmMock.EXPECT().Update(testCtx, newProgressConfigMap(t, postUpgrade), metav1.UpdateOptions{}).Return(nil, nil)
+		sut := NewConfigMapProgressStore(cmMock, getTestScheme())
+
+		// when
+		err := sut.Save(testCtx, getSnapshotTestDogu(), postUpgrade)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail to update existing progress config map", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-upgrade-progress", metav1.GetOptions{}).Return(&corev1.ConfigMap{}, nil)
+		cmMock.EXPECT().Update(testCtx, mock.Anything, metav1.UpdateOptions{}).Return(nil, assert.AnError)
+		sut := NewConfigMapProgressStore(cmMock, getTestScheme())
+
+		// when
+		err := sut.Save(testCtx, getSnapshotTestDogu(), getTestProgress())
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to save upgrade progress of dogu \"ldap\"")
+	})
+}
+
+func Test_configMapProgressStore_Delete(t *testing.T) {
+	t.Run("should ignore missing progress", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Delete(testCtx, "ldap-upgrade-progress", metav1.DeleteOptions{}).
+			Return(errors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "ldap-upgrade-progress"))
+		sut := NewConfigMapProgressStore(cmMock, getTestScheme())
+
+		// when
+		err := sut.Delete(testCtx, getSnapshotTestDogu())
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail to delete progress", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Delete(testCtx, "ldap-upgrade-progress", metav1.DeleteOptions{}).Return(assert.AnError)
+		sut := NewConfigMapProgressStore(cmMock, getTestScheme())
+
+		// when
+		err := sut.Delete(testCtx, getSnapshotTestDogu())
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -0,0 +1,96 @@
+package upgrade
+
+import (
+	"fmt"
+	"time"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RollbackAnnotation opts a dogu in to the rollback of failed upgrades if set to "true".
+const RollbackAnnotation = "k8s.cloudogu.com/upgrade-rollback"
+
+const (
+	// ConditionRolledBack is true if the last upgrade of the dogu failed and has been rolled back.
+	ConditionRolledBack = "RolledBack"
+	// ReasonStartupFailed is the reason of the RolledBack condition if the new version did not start.
+	ReasonStartupFailed = "StartupFailed"
+	// ReasonDeadlineExceeded is the reason of the RolledBack condition if a phase of the upgrade did not finish within
+	// its deadline.
+	ReasonDeadlineExceeded = "UpgradeDeadlineExceeded"
+	// ReasonUpgradeSucceeded is the reason of the RolledBack condition if a later upgrade succeeded.
+	ReasonUpgradeSucceeded = "UpgradeSucceeded"
+	// ReasonSpecVersionChanged is the reason of the RolledBack condition if the version of the rolled back upgrade
+	// is not desired anymore.
+	ReasonSpecVersionChanged = "SpecVersionChanged"
+)
+
+// RollbackEnabled returns true if failed upgrades of the dogu are rolled back.
+func RollbackEnabled(doguResource *v2.Dogu) bool {
+	return doguResource.GetAnnotations()[RollbackAnnotation] == "true"
+}
+
+// IsRolledBack returns true if the upgrade to the spec version of the dogu was rolled back. The pipeline skips the
+// steps which apply the spec version until the spec version changes, so that the rolled back upgrade is not retried
+// while the other steps keep reconciling the dogu.
+func IsRolledBack(doguResource *v2.Dogu) bool {
+	return RollbackEnabled(doguResource) && doguResource.Status.InstalledVersion != doguResource.Spec.Version &&
+		meta.IsStatusConditionTrue(doguResource.Status.Conditions, ConditionRolledBack)
+}
+
+// Failure describes why an upgrade is rolled back.
+type Failure struct {
+	// Reason is either ReasonStartupFailed or ReasonDeadlineExceeded.
+	Reason string
+	// Message describes the failure.
+	Message string
+}
+
+// CheckProgress returns a failure if a container of the target version was restarted before it ever started or if
+// the current phase of the upgrade did not finish within its deadline. The dogu container is restarted when its
+// startup probe fails, so a restart before the start means that the new version cannot start. The progress is
+// recorded by the upgrade stall detection, so a stalled upgrade is rolled back after the same phase deadline after
+// which it is reported as stalled.
+func CheckProgress(snapshot *Snapshot, progress *Progress, pods []corev1.Pod, containerName string, deadlines PhaseDeadlines, now time.Time) *Failure {
+	for _, pod := range pods {
+		if pod.Labels[v2.DoguLabelVersion] != snapshot.TargetVersion {
+			continue
+		}
+
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name != containerName || status.RestartCount == 0 || (status.Started != nil && *status.Started) {
+				continue
+			}
+
+			message := fmt.Sprintf("container %s of pod %s was restarted %d times without starting", status.Name, pod.Name, status.RestartCount)
+			if terminated := status.LastTerminationState.Terminated; terminated != nil {
+				message = fmt.Sprintf("%s, last termination: %s (exit code %d)", message, terminated.Reason, terminated.ExitCode)
+			}
+			return &Failure{Reason: ReasonStartupFailed, Message: message}
+		}
+	}
+
+	if progress != nil && progress.TargetVersion == snapshot.TargetVersion && deadlines.Exceeded(progress, now) {
+		return &Failure{
+			Reason:  ReasonDeadlineExceeded,
+			Message: fmt.Sprintf("the %s phase of the upgrade did not finish within %s", progress.Phase, deadlines.Of(progress.Phase)),
+		}
+	}
+
+	return nil
+}
+
+// NewRolledBackCondition creates the RolledBack condition for a rolled back upgrade.
+func NewRolledBackCondition(snapshot *Snapshot, failure *Failure, generation int64) metav1.Condition {
+	return metav1.Condition{
+		Type:   ConditionRolledBack,
+		Status: metav1.ConditionTrue,
+		Reason: failure.Reason,
+		Message: fmt.Sprintf("The upgrade from %s to %s was rolled back because %s",
+			snapshot.PreviousVersion, snapshot.TargetVersion, failure.Message),
+		ObservedGeneration: generation,
+	}
+}
@@ -0,0 +1,132 @@
+package upgrade
+
+import (
+	"testing"
+	"time"
+
+	k8sv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRollbackEnabled(t *testing.T) {
+	t.Run("should be disabled without annotation", func(t *testing.T) {
+		assert.False(t, RollbackEnabled(&k8sv2.Dogu{}))
+	})
+	t.Run("should be disabled with other value", func(t *testing.T) {
+		doguResource := &k8sv2.Dogu{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{RollbackAnnotation: "yes"}}}
+		assert.False(t, RollbackEnabled(doguResource))
+	})
+	t.Run("should be enabled", func(t *testing.T) {
+		doguResource := &k8sv2.Dogu{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{RollbackAnnotation: "true"}}}
+		assert.True(t, RollbackEnabled(doguResource))
+	})
+}
+
+func TestIsRolledBack(t *testing.T) {
+	rolledBack := func(enabled string, installedVersion string, conditionStatus metav1.ConditionStatus) *k8sv2.Dogu {
+		return &k8sv2.Dogu{
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{RollbackAnnotation: enabled}},
+			Spec:       k8sv2.DoguSpec{Version: "2.0.0-1"},
+			Status: k8sv2.DoguStatus{
+				InstalledVersion: installedVersion,
+				Conditions:       []metav1.Condition{{Type: ConditionRolledBack, Status: conditionStatus}},
+			},
+		}
+	}
+
+	t.Run("should be rolled back", func(t *testing.T) {
+		assert.True(t, IsRolledBack(rolledBack("true", "1.0.0-1", metav1.ConditionTrue)))
+	})
+	t.Run("should not be rolled back without rollback", func(t *testing.T) {
+		assert.False(t, IsRolledBack(rolledBack("false", "1.0.0-1", metav1.ConditionTrue)))
+	})
+	t.Run("should not be rolled back if the spec version is installed", func(t *testing.T) {
+		assert.False(t, IsRolledBack(rolledBack("true", "2.0.0-1", metav1.ConditionTrue)))
+	})
+	t.Run("should not be rolled back if the condition is false", func(t *testing.T) {
+		assert.False(t, IsRolledBack(rolledBack("true", "1.0.0-1", metav1.ConditionFalse)))
+	})
+}
+
+func TestCheckProgress(t *testing.T) {
+	started := true
+	notStarted := false
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	snapshot := &Snapshot{PreviousVersion: "1.0.0-1", TargetVersion: "2.0.0-1", StartedAt: metav1.NewTime(now.Add(-10 * time.Minute))}
+	progress := &Progress{TargetVersion: "2.0.0-1", Phase: PhaseRollout, PhaseStartedAt: metav1.NewTime(now.Add(-10 * time.Minute))}
+	deadlines := PhaseDeadlines{PreUpgrade: 5 * time.Minute, Rollout: 30 * time.Minute, PostUpgrade: 5 * time.Minute}
+	newPod := func(version string, status corev1.ContainerStatus) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "ldap-abc", Labels: map[string]string{"dogu.name": "ldap", "dogu.version": version}},
+			Status:     corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{status}},
+		}
+	}
+
+	t.Run("should report nothing for upgrade in progress", func(t *testing.T) {
+		pods := []corev1.Pod{
+			newPod("2.0.0-1", corev1.ContainerStatus{Name: "ldap", Started: &notStarted}),
+			newPod("1.0.0-1", corev1.ContainerStatus{Name: "ldap", RestartCount: 3, Started: &notStarted}),
+		}
+
+		assert.Nil(t, CheckProgress(snapshot, progress, pods, "ldap", deadlines, now))
+	})
+	t.Run("should ignore restarts after the container started", func(t *testing.T) {
+		pods := []corev1.Pod{newPod("2.0.0-1", corev1.ContainerStatus{Name: "ldap", RestartCount: 1, Started: &started})}
+
+		assert.Nil(t, CheckProgress(snapshot, progress, pods, "ldap", deadlines, now))
+	})
+	t.Run("should ignore restarts of other containers", func(t *testing.T) {
+		pods := []corev1.Pod{newPod("2.0.0-1", corev1.ContainerStatus{Name: "sidecar", RestartCount: 1})}
+
+		assert.Nil(t, CheckProgress(snapshot, progress, pods, "ldap", deadlines, now))
+	})
+	t.Run("should report container of target version restarted before it started", func(t *testing.T) {
+		pods := []corev1.Pod{newPod("2.0.0-1", corev1.ContainerStatus{
+			Name:         "ldap",
+			RestartCount: 2,
+			LastTerminationState: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1},
+			},
+		})}
+
+		failure := CheckProgress(snapshot, progress, pods, "ldap", deadlines, now)
+
+		require.NotNil(t, failure)
+		assert.Equal(t, ReasonStartupFailed, failure.Reason)
+		assert.Equal(t, "container ldap of pod ldap-abc was restarted 2 times without starting, last termination: Error (exit code 1)", failure.Message)
+	})
+	t.Run("should report exceeded deadline of the current phase", func(t *testing.T) {
+		postUpgrade := &Progress{TargetVersion: "2.0.0-1", Phase: PhasePostUpgrade, PhaseStartedAt: progress.PhaseStartedAt}
+
+		failure := CheckProgress(snapshot, postUpgrade, nil, "ldap", deadlines, now)
+
+		require.NotNil(t, failure)
+		assert.Equal(t, ReasonDeadlineExceeded, failure.Reason)
+		assert.Equal(t, "the post-upgrade phase of the upgrade did not finish within 5m0s", failure.Message)
+	})
+	t.Run("should ignore progress of another target version", func(t *testing.T) {
+		otherTarget := &Progress{TargetVersion: "3.0.0-1", Phase: PhasePostUpgrade, PhaseStartedAt: progress.PhaseStartedAt}
+
+		assert.Nil(t, CheckProgress(snapshot, otherTarget, nil, "ldap", deadlines, now))
+	})
+	t.Run("should report nothing without progress", func(t *testing.T) {
+		assert.Nil(t, CheckProgress(snapshot, nil, nil, "ldap", deadlines, now))
+	})
+}
+
+func TestNewRolledBackCondition(t *testing.T) {
+	snapshot := &Snapshot{PreviousVersion: "1.0.0-1", TargetVersion: "2.0.0-1"}
+
+	condition := NewRolledBackCondition(snapshot, &Failure{Reason: ReasonDeadlineExceeded, Message: "it took too long"}, 4)
+
+	assert.Equal(t, metav1.Condition{
+		Type:               ConditionRolledBack,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonDeadlineExceeded,
+		Message:            "The upgrade from 1.0.0-1 to 2.0.0-1 was rolled back because it took too long",
+		ObservedGeneration: 4,
+	}, condition)
+}
@@ -0,0 +1,105 @@
+package upgrade
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/dogustore"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+const (
+	snapshotConfigMapNameSuffix = "-upgrade-snapshot"
+	// SnapshotKey is the key in the upgrade snapshot config map that contains the snapshot as JSON.
+	SnapshotKey = "snapshot"
+	// SnapshotLabel marks config maps that contain the upgrade snapshot of a dogu.
+	SnapshotLabel = "k8s.cloudogu.com/upgrade-snapshot"
+)
+
+// SnapshotConfigMapName returns the name of the config map containing the upgrade snapshot of the dogu.
+func SnapshotConfigMapName(doguName string) string {
+	return doguName + snapshotConfigMapNameSuffix
+}
+
+// Snapshot contains the state of a dogu before an upgrade which is needed to roll the upgrade back.
+type Snapshot struct {
+	// PreviousVersion is the version which was installed and registered as current before the upgrade.
+	PreviousVersion string `json:"previousVersion"`
+	// TargetVersion is the version the dogu is upgraded to.
+	TargetVersion string `json:"targetVersion"`
+	// Template is the pod template of the deployment before the upgrade.
+	Template corev1.PodTemplateSpec `json:"template"`
+	// StartedAt is the time at which the upgrade started.
+	StartedAt metav1.Time `json:"startedAt"`
+	// VolumeSnapshot is the name of the volume snapshot of the data volume taken before the upgrade, if the dogu opted
+	// in to volume snapshots.
+	VolumeSnapshot string `json:"volumeSnapshot,omitempty"`
+	// RolledBack is true if the upgrade to the target version has been rolled back.
+	RolledBack bool `json:"rolledBack,omitempty"`
+	// RollbackRefused is true if the upgrade to the target version failed but was not rolled back because the data
+	// volume may have been changed by the upgrade and cannot be restored.
+	RollbackRefused bool `json:"rollbackRefused,omitempty"`
+}
+
+type configMapSnapshotStore struct {
+	store *dogustore.ConfigMapStore
+}
+
+// NewConfigMapSnapshotStore creates a SnapshotStore which keeps the upgrade snapshot of each dogu in a config map.
+func NewConfigMapSnapshotStore(configMapInterface v1.ConfigMapInterface, scheme *runtime.Scheme) SnapshotStore {
+	return &configMapSnapshotStore{
+		store: dogustore.NewConfigMapStore(configMapInterface, scheme, snapshotConfigMapNameSuffix, SnapshotLabel),
+	}
+}
+
+// Get reads the upgrade snapshot from the config map of the dogu.
+func (s *configMapSnapshotStore) Get(ctx context.Context, doguResource *v2.Dogu) (*Snapshot, error) {
+	data, err := s.store.Get(ctx, doguResource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upgrade snapshot of dogu %q: %w", doguResource.Name, err)
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	snapshot := &Snapshot{}
+	err = json.Unmarshal([]byte(data[SnapshotKey]), snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse upgrade snapshot of dogu %q: %w", doguResource.Name, err)
+	}
+
+	return snapshot, nil
+}
+
+// Save writes the upgrade snapshot into the config map of the dogu.
+func (s *configMapSnapshotStore) Save(ctx context.Context, doguResource *v2.Dogu, snapshot *Snapshot) error {
+	snapshotJson, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to serialize upgrade snapshot of dogu %q: %w", doguResource.Name, err)
+	}
+
+	err = s.store.Update(ctx, doguResource, func(data map[string]string) error {
+		data[SnapshotKey] = string(snapshotJson)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save upgrade snapshot of dogu %q: %w", doguResource.Name, err)
+	}
+
+	return nil
+}
+
+// Delete deletes the upgrade snapshot config map of the dogu.
+func (s *configMapSnapshotStore) Delete(ctx context.Context, doguResource *v2.Dogu) error {
+	err := s.store.Delete(ctx, doguResource)
+	if err != nil {
+		return fmt.Errorf("failed to delete upgrade snapshot of dogu %q: %w", doguResource.Name, err)
+	}
+
+	return nil
+}
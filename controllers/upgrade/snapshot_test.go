@@ -0,0 +1,207 @@
+package upgrade
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	k8sv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func getSnapshotTestDogu() *k8sv2.Dogu {
+	return &k8sv2.Dogu{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: "ecosystem", UID: "uid"},
+		Spec:       k8sv2.DoguSpec{Version: "2.0.0-1"},
+	}
+}
+
+func getTestSnapshot() *Snapshot {
+	return &Snapshot{
+		PreviousVersion: "1.0.0-1",
+		TargetVersion:   "2.0.0-1",
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"dogu.version": "1.0.0-1"}},
+		},
+		StartedAt: metav1.NewTime(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)),
+	}
+}
+
+func newSnapshotConfigMap(t *testing.T, snapshot *Snapshot) *corev1.ConfigMap {
+	snapshotJson, err := json.Marshal(snapshot)
+	require.NoError(t, err)
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap-upgrade-snapshot", Namespace: "ecosystem"},
+		Data:       map[string]string{SnapshotKey: string(snapshotJson)},
+	}
+}
+
+func TestSnapshotConfigMapName(t *testing.T) {
+	assert.Equal(t, "ldap-upgrade-snapshot", SnapshotConfigMapName("ldap"))
+}
+
+func Test_configMapSnapshotStore_Get(t *testing.T) {
+	notFoundErr := errors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "ldap-upgrade-snapshot")
+
+	t.Run("should return nil if there is no snapshot", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-upgrade-snapshot", metav1.GetOptions{}).Return(nil, notFoundErr)
+		sut := NewConfigMapSnapshotStore(cmMock, getTestScheme())
+
+		// when
+		snapshot, err := sut.Get(testCtx, getSnapshotTestDogu())
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, snapshot)
+	})
+	t.Run("should fail to get config map", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-upgrade-snapshot", metav1.GetOptions{}).Return(nil, assert.AnError)
+		sut := NewConfigMapSnapshotStore(cmMock, getTestScheme())
+
+		// when
+		_, err := sut.Get(testCtx, getSnapshotTestDogu())
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get upgrade snapshot of dogu \"ldap\"")
+	})
+	t.Run("should fail to parse snapshot", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cm := &corev1.ConfigMap{Data: map[string]string{SnapshotKey: "{"}}
+		cmMock.EXPECT().Get(testCtx, "ldap-upgrade-snapshot", metav1.GetOptions{}).Return(cm, nil)
+		sut := NewConfigMapSnapshotStore(cmMock, getTestScheme())
+
+		// when
+		_, err := sut.Get(testCtx, getSnapshotTestDogu())
+
+		// then
+		assert.ErrorContains(t, err, "failed to parse upgrade snapshot of dogu \"ldap\"")
+	})
+	t.Run("should return snapshot", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-upgrade-snapshot", metav1.GetOptions{}).Return(newSnapshotConfigMap(t, getTestSnapshot()), nil)
+		sut := NewConfigMapSnapshotStore(cmMock, getTestScheme())
+
+		// when
+		snapshot, err := sut.Get(testCtx, getSnapshotTestDogu())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, getTestSnapshot().PreviousVersion, snapshot.PreviousVersion)
+		assert.Equal(t, getTestSnapshot().Template, snapshot.Template)
+		assert.True(t, getTestSnapshot().StartedAt.Equal(&snapshot.StartedAt))
+	})
+}
+
+func Test_configMapSnapshotStore_Save(t *testing.T) {
+	notFoundErr := errors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "ldap-upgrade-snapshot")
+
+	t.Run("should create snapshot config map with non-controlling owner reference", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-upgrade-snapshot", metav1.GetOptions{}).Return(nil, notFoundErr)
+		cmMock.EXPECT().Create(testCtx, mock.Anything, metav1.CreateOptions{}).
+			RunAndReturn(func(ctx context.Context, cm *corev1.ConfigMap, opts metav1.CreateOptions) (*corev1.ConfigMap, error) {
+				assert.Equal(t, "ldap-upgrade-snapshot", cm.Name)
+				assert.Equal(t, "ecosystem", cm.Namespace)
+				assert.Equal(t, "true", cm.Labels[SnapshotLabel])
+				assert.Equal(t, "ldap", cm.Labels["dogu.name"])
+				require.Len(t, cm.OwnerReferences, 1)
+				assert.Equal(t, "dogu", cm.OwnerReferences[0].Kind)
+				assert.Nil(t, cm.OwnerReferences[0].Controller)
+				assert.Equal(t, newSnapshotConfigMap(t, getTestSnapshot()).Data, cm.Data)
+				return cm, nil
+			})
+		sut := NewConfigMapSnapshotStore(cmMock, getTestScheme())
+
+		// when
+		err := sut.Save(testCtx, getSnapshotTestDogu(), getTestSnapshot())
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail to create snapshot config map", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-upgrade-snapshot", metav1.GetOptions{}).Return(nil, notFoundErr)
+		cmMock.EXPECT().Create(testCtx, mock.Anything, metav1.CreateOptions{}).Return(nil, assert.AnError)
+		sut := NewConfigMapSnapshotStore(cmMock, getTestScheme())
+
+		// when
+		err := sut.Save(testCtx, getSnapshotTestDogu(), getTestSnapshot())
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to save upgrade snapshot of dogu \"ldap\"")
+	})
+	t.Run("should update existing snapshot config map", func(t *testing.T) {
+		// given
+		rolledBack := getTestSnapshot()
+		rolledBack.RolledBack = true
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-upgrade-snapshot", metav1.GetOptions{}).Return(newSnapshotConfigMap(t, getTestSnapshot()), nil)
+		cmMock.EXPECT().Update(testCtx, newSnapshotConfigMap(t, rolledBack), metav1.UpdateOptions{}).Return(nil, nil)
+		sut := NewConfigMapSnapshotStore(cmMock, getTestScheme())
+
+		// when
+		err := sut.Save(testCtx, getSnapshotTestDogu(), rolledBack)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail to update existing snapshot config map", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Get(testCtx, "ldap-upgrade-snapshot", metav1.GetOptions{}).Return(&corev1.ConfigMap{}, nil)
+		cmMock.EXPECT().Update(testCtx, mock.Anything, metav1.UpdateOptions{}).Return(nil, assert.AnError)
+		sut := NewConfigMapSnapshotStore(cmMock, getTestScheme())
+
+		// when
+		err := sut.Save(testCtx, getSnapshotTestDogu(), getTestSnapshot())
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to save upgrade snapshot of dogu \"ldap\"")
+	})
+}
+
+func Test_configMapSnapshotStore_Delete(t *testing.T) {
+	t.Run("should ignore missing snapshot", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Delete(testCtx, "ldap-upgrade-snapshot", metav1.DeleteOptions{}).
+			Return(errors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "ldap-upgrade-snapshot"))
+		sut := NewConfigMapSnapshotStore(cmMock, getTestScheme())
+
+		// when
+		err := sut.Delete(testCtx, getSnapshotTestDogu())
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail to delete snapshot", func(t *testing.T) {
+		// given
+		cmMock := newMockConfigMapInterface(t)
+		cmMock.EXPECT().Delete(testCtx, "ldap-upgrade-snapshot", metav1.DeleteOptions{}).Return(assert.AnError)
+		sut := NewConfigMapSnapshotStore(cmMock, getTestScheme())
+
+		// when
+		err := sut.Delete(testCtx, getSnapshotTestDogu())
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
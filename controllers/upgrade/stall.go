@@ -0,0 +1,153 @@
+package upgrade
+
+import (
+	"fmt"
+	"time"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// PreUpgradeDeadlineAnnotation overrides the pre-upgrade deadline of the operator config for a dogu, e.g. "30m".
+	PreUpgradeDeadlineAnnotation = "k8s.cloudogu.com/upgrade-pre-upgrade-deadline"
+	// RolloutDeadlineAnnotation overrides the rollout deadline of the operator config for a dogu, e.g. "5h".
+	RolloutDeadlineAnnotation = "k8s.cloudogu.com/upgrade-rollout-deadline"
+	// PostUpgradeDeadlineAnnotation overrides the post-upgrade deadline of the operator config for a dogu, e.g. "1h".
+	PostUpgradeDeadlineAnnotation = "k8s.cloudogu.com/upgrade-post-upgrade-deadline"
+)
+
+const (
+	// ConditionUpgradeStalled is true if the running upgrade of the dogu has not progressed past a phase within the
+	// deadline of the phase.
+	ConditionUpgradeStalled = "UpgradeStalled"
+	// ReasonUpgradeProgressing is the reason of the UpgradeStalled condition while the current phase is within its
+	// deadline.
+	ReasonUpgradeProgressing = "UpgradeProgressing"
+	// ReasonUpgradeFinished is the reason of the UpgradeStalled condition once the dogu is not upgrading anymore.
+	ReasonUpgradeFinished = "UpgradeFinished"
+)
+
+// Phase is a phase of a dogu upgrade.
+type Phase string
+
+const (
+	// PhasePreUpgrade lasts from the start of the upgrade until the deployment is updated to the new version.
+	PhasePreUpgrade Phase = "PreUpgrade"
+	// PhaseRollout lasts from the update of the deployment until the dogu container of the new version started.
+	PhaseRollout Phase = "Rollout"
+	// PhasePostUpgrade lasts from the start of the new version until the new version is installed.
+	PhasePostUpgrade Phase = "PostUpgrade"
+)
+
+// StalledReason returns the reason of the UpgradeStalled condition if the upgrade stalled in the phase, e.g.
+// "RolloutStalled".
+func (p Phase) StalledReason() string {
+	return string(p) + "Stalled"
+}
+
+func (p Phase) String() string {
+	switch p {
+	case PhasePreUpgrade:
+		return "pre-upgrade"
+	case PhaseRollout:
+		return "rollout"
+	case PhasePostUpgrade:
+		return "post-upgrade"
+	default:
+		return string(p)
+	}
+}
+
+// PhaseDeadlines defines how long each phase of an upgrade may take before the upgrade is reported as stalled and
+// rolled back, if the dogu opted in to upgrade rollbacks.
+type PhaseDeadlines struct {
+	PreUpgrade  time.Duration
+	Rollout     time.Duration
+	PostUpgrade time.Duration
+}
+
+// NewPhaseDeadlines returns the phase deadlines of the operator config. They are shared by the stall detection, the
+// rollback and the update of the deployment, which stretches the startup probe to the rollout deadline.
+func NewPhaseDeadlines(operatorConfig *config.OperatorConfig) PhaseDeadlines {
+	return PhaseDeadlines{
+		PreUpgrade:  operatorConfig.PreUpgradeDeadline,
+		Rollout:     operatorConfig.RolloutDeadline,
+		PostUpgrade: operatorConfig.PostUpgradeDeadline,
+	}
+}
+
+// For returns the phase deadlines of the dogu. The annotations of the dogu override the deadlines, invalid
+// deadlines in the annotations fall back to the given deadlines.
+func (d PhaseDeadlines) For(doguResource *v2.Dogu) PhaseDeadlines {
+	return PhaseDeadlines{
+		PreUpgrade:  annotatedDeadline(doguResource, PreUpgradeDeadlineAnnotation, d.PreUpgrade),
+		Rollout:     annotatedDeadline(doguResource, RolloutDeadlineAnnotation, d.Rollout),
+		PostUpgrade: annotatedDeadline(doguResource, PostUpgradeDeadlineAnnotation, d.PostUpgrade),
+	}
+}
+
+// Of returns the deadline of the phase.
+func (d PhaseDeadlines) Of(phase Phase) time.Duration {
+	switch phase {
+	case PhasePreUpgrade:
+		return d.PreUpgrade
+	case PhaseRollout:
+		return d.Rollout
+	default:
+		return d.PostUpgrade
+	}
+}
+
+// Exceeded returns true if the current phase of the upgrade has not finished within its deadline.
+func (d PhaseDeadlines) Exceeded(progress *Progress, now time.Time) bool {
+	return now.Sub(progress.PhaseStartedAt.Time) > d.Of(progress.Phase)
+}
+
+func annotatedDeadline(doguResource *v2.Dogu, annotation string, defaultDeadline time.Duration) time.Duration {
+	deadline, err := time.ParseDuration(doguResource.GetAnnotations()[annotation])
+	if err != nil || deadline <= 0 {
+		return defaultDeadline
+	}
+
+	return deadline
+}
+
+// defaultProbePeriodSeconds is the period kubernetes uses for probes without a period.
+const defaultProbePeriodSeconds = 10
+
+// StartupProbeFailureThreshold returns the failure threshold of a startup probe with the period which lets the
+// container start within the rollout deadline.
+func StartupProbeFailureThreshold(rolloutDeadline time.Duration, periodSeconds int32) int32 {
+	if periodSeconds <= 0 {
+		periodSeconds = defaultProbePeriodSeconds
+	}
+	period := time.Duration(periodSeconds) * time.Second
+	return int32((rolloutDeadline + period - 1) / period)
+}
+
+// NewProgressingCondition creates the UpgradeStalled condition for an upgrade whose current phase is within its deadline.
+func NewProgressingCondition(progress *Progress, deadline time.Duration, generation int64) metav1.Condition {
+	return metav1.Condition{
+		Type:   ConditionUpgradeStalled,
+		Status: metav1.ConditionFalse,
+		Reason: ReasonUpgradeProgressing,
+		Message: fmt.Sprintf("The upgrade to %s is in the %s phase since %s, the deadline of the phase is %s.",
+			progress.TargetVersion, progress.Phase, progress.PhaseStartedAt.UTC().Format(time.RFC3339), deadline),
+		ObservedGeneration: generation,
+	}
+}
+
+// NewStalledCondition creates the UpgradeStalled condition for an upgrade which has not progressed past its current
+// phase within the deadline of the phase.
+func NewStalledCondition(progress *Progress, deadline time.Duration, generation int64) metav1.Condition {
+	return metav1.Condition{
+		Type:   ConditionUpgradeStalled,
+		Status: metav1.ConditionTrue,
+		Reason: progress.Phase.StalledReason(),
+		Message: fmt.Sprintf("The upgrade to %s has not progressed past the %s phase within %s, the phase started at %s.",
+			progress.TargetVersion, progress.Phase, deadline, progress.PhaseStartedAt.UTC().Format(time.RFC3339)),
+		ObservedGeneration: generation,
+	}
+}
@@ -0,0 +1,80 @@
+package upgrade
+
+import (
+	"testing"
+	"time"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPhaseDeadlines_For(t *testing.T) {
+	defaults := NewPhaseDeadlines(&config.OperatorConfig{
+		PreUpgradeDeadline:  15 * time.Minute,
+		RolloutDeadline:     3 * time.Hour,
+		PostUpgradeDeadline: 20 * time.Minute,
+	})
+
+	t.Run("should use the deadlines of the operator config without annotations", func(t *testing.T) {
+		// when
+		deadlines := defaults.For(&v2.Dogu{})
+
+		// then
+		assert.Equal(t, PhaseDeadlines{PreUpgrade: 15 * time.Minute, Rollout: 3 * time.Hour, PostUpgrade: 20 * time.Minute}, deadlines)
+	})
+	t.Run("should override deadlines with annotations and ignore invalid ones", func(t *testing.T) {
+		// given
+		doguResource := &v2.Dogu{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+			PreUpgradeDeadlineAnnotation:  "1h",
+			RolloutDeadlineAnnotation:     "soon",
+			PostUpgradeDeadlineAnnotation: "-5m",
+		}}}
+
+		// when
+		deadlines := defaults.For(doguResource)
+
+		// then
+		assert.Equal(t, PhaseDeadlines{PreUpgrade: time.Hour, Rollout: 3 * time.Hour, PostUpgrade: 20 * time.Minute}, deadlines)
+		assert.Equal(t, time.Hour, deadlines.Of(PhasePreUpgrade))
+		assert.Equal(t, 3*time.Hour, deadlines.Of(PhaseRollout))
+		assert.Equal(t, 20*time.Minute, deadlines.Of(PhasePostUpgrade))
+	})
+}
+
+func TestPhaseDeadlines_Exceeded(t *testing.T) {
+	deadlines := PhaseDeadlines{PreUpgrade: time.Minute, Rollout: time.Hour, PostUpgrade: time.Minute}
+	progress := getTestProgress()
+
+	assert.False(t, deadlines.Exceeded(progress, progress.PhaseStartedAt.Add(time.Hour)))
+	assert.True(t, deadlines.Exceeded(progress, progress.PhaseStartedAt.Add(time.Hour+time.Second)))
+}
+
+func TestStartupProbeFailureThreshold(t *testing.T) {
+	assert.Equal(t, int32(1080), StartupProbeFailureThreshold(3*time.Hour, 10))
+	assert.Equal(t, int32(2), StartupProbeFailureThreshold(11*time.Second, 10))
+	assert.Equal(t, int32(6), StartupProbeFailureThreshold(time.Minute, 0))
+}
+
+func TestNewStalledCondition(t *testing.T) {
+	// when
+	condition := NewStalledCondition(getTestProgress(), time.Hour, 3)
+
+	// then
+	assert.Equal(t, ConditionUpgradeStalled, condition.Type)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, "RolloutStalled", condition.Reason)
+	assert.Equal(t, "The upgrade to 2.0.0-1 has not progressed past the rollout phase within 1h0m0s, the phase started at 2026-01-02T03:04:05Z.", condition.Message)
+	assert.Equal(t, int64(3), condition.ObservedGeneration)
+}
+
+func TestNewProgressingCondition(t *testing.T) {
+	// when
+	condition := NewProgressingCondition(getTestProgress(), time.Hour, 3)
+
+	// then
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, ReasonUpgradeProgressing, condition.Reason)
+	assert.Equal(t, "The upgrade to 2.0.0-1 is in the rollout phase since 2026-01-02T03:04:05Z, the deadline of the phase is 1h0m0s.", condition.Message)
+}
@@ -1,6 +1,8 @@
 package usecase
 
 import (
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/journal"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/metrics"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -12,3 +14,11 @@ type Step interface {
 type K8sClient interface {
 	client.Client
 }
+
+type executionJournal interface {
+	journal.ExecutionJournal
+}
+
+type stepRecorder interface {
+	metrics.StepRecorder
+}
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
+)
+
+// HookPoint is a position in the install or change pipeline at which additional steps can be registered.
+type HookPoint string
+
+const (
+	// HookPreValidation places steps after the remote dogu descriptor was fetched and before the dogu is validated.
+	HookPreValidation HookPoint = "pre-validation"
+	// HookPostDeployment places steps after the deployment of the dogu was created and before the post-install steps.
+	HookPostDeployment HookPoint = "post-deployment"
+	// HookPostUpgrade places steps at the end of the pipeline after all upgrade steps.
+	HookPostUpgrade HookPoint = "post-upgrade"
+)
+
+// ExtensionStepGroup is the name of the fx value group in which additional steps for the install or change pipeline
+// are provided, e.g. with fx.Annotate(NewComplianceStep, fx.ResultTags(`group:"doguInstallOrChangeExtensionSteps"`)).
+const ExtensionStepGroup = "doguInstallOrChangeExtensionSteps"
+
+// NamedStep is a step with a unique name which can be registered in a StepRegistry.
+// At most one of Hook, After and Before may be set to place the step relative to the already registered steps.
+// If none of them is set, the step is appended to the end of the pipeline.
+type NamedStep struct {
+	// Name identifies the step in ordering constraints and in the list of disabled steps.
+	Name string
+	Step Step
+	// Hook places the step at the given hook point. Steps at the same hook point run in registration order.
+	Hook HookPoint
+	// After places the step directly after the step with the given name.
+	After string
+	// Before places the step directly before the step with the given name.
+	Before string
+	// SkipWhenRolledBack marks a step which applies the spec version of the dogu. It is skipped while the upgrade to
+	// the spec version is rolled back, so that the rolled back upgrade is not retried until the spec version changes.
+	SkipWhenRolledBack bool
+}
+
+type registryEntry struct {
+	name string
+	// step is nil for hook points.
+	step Step
+	// anchor is the name of the entry this entry was placed after.
+	anchor             string
+	skipWhenRolledBack bool
+}
+
+// StepRegistry collects named steps and hook points and builds the ordered step pipeline from them.
+type StepRegistry struct {
+	entries []registryEntry
+}
+
+// NewStepRegistry creates an empty StepRegistry.
+func NewStepRegistry() *StepRegistry {
+	return &StepRegistry{}
+}
+
+// AddHookPoint appends the given hook point to the pipeline.
+func (r *StepRegistry) AddHookPoint(hook HookPoint) error {
+	if r.indexOf(string(hook)) >= 0 {
+		return fmt.Errorf("hook point %q is already registered", hook)
+	}
+	r.entries = append(r.entries, registryEntry{name: string(hook)})
+	return nil
+}
+
+// Register adds the step to the pipeline at the position defined by its ordering constraint.
+func (r *StepRegistry) Register(namedStep NamedStep) error {
+	if namedStep.Name == "" {
+		return fmt.Errorf("step of type %q has no name", steps.NameOf(namedStep.Step))
+	}
+	if namedStep.Step == nil {
+		return fmt.Errorf("step %q is nil", namedStep.Name)
+	}
+	if r.indexOf(namedStep.Name) >= 0 {
+		return fmt.Errorf("step %q is already registered", namedStep.Name)
+	}
+	if countNonEmpty(string(namedStep.Hook), namedStep.After, namedStep.Before) > 1 {
+		return fmt.Errorf("step %q must not define more than one of hook, after and before", namedStep.Name)
+	}
+
+	entry := registryEntry{name: namedStep.Name, step: namedStep.Step, skipWhenRolledBack: namedStep.SkipWhenRolledBack}
+	switch {
+	case namedStep.Hook != "":
+		hookIndex := r.indexOf(string(namedStep.Hook))
+		if hookIndex < 0 || r.entries[hookIndex].step != nil {
+			return fmt.Errorf("unknown hook point %q for step %q", namedStep.Hook, namedStep.Name)
+		}
+		r.insertAfter(hookIndex, entry)
+	case namedStep.After != "":
+		afterIndex := r.indexOf(namedStep.After)
+		if afterIndex < 0 {
+			return fmt.Errorf("step %q should run after unknown step %q", namedStep.Name, namedStep.After)
+		}
+		r.insertAfter(afterIndex, entry)
+	case namedStep.Before != "":
+		beforeIndex := r.indexOf(namedStep.Before)
+		if beforeIndex < 0 {
+			return fmt.Errorf("step %q should run before unknown step %q", namedStep.Name, namedStep.Before)
+		}
+		r.entries = slices.Insert(r.entries, beforeIndex, entry)
+	default:
+		r.entries = append(r.entries, entry)
+	}
+
+	return nil
+}
+
+// insertAfter inserts the entry after the entry at the given index and after all entries which were placed there
+// before, so that steps placed after the same entry keep their registration order.
+func (r *StepRegistry) insertAfter(index int, entry registryEntry) {
+	entry.anchor = r.entries[index].name
+	position := index + 1
+	for position < len(r.entries) && r.entries[position].anchor == entry.anchor {
+		position++
+	}
+	r.entries = slices.Insert(r.entries, position, entry)
+}
+
+// Build returns the registered steps in pipeline order without hook points and without the disabled steps.
+// Disabling an unknown step results in an error so that typos in the configuration do not go unnoticed.
+func (r *StepRegistry) Build(disabledSteps []string) ([]NamedStep, error) {
+	for _, disabled := range disabledSteps {
+		index := r.indexOf(disabled)
+		if index < 0 || r.entries[index].step == nil {
+			return nil, fmt.Errorf("cannot disable unknown step %q", disabled)
+		}
+	}
+
+	var pipeline []NamedStep
+	for _, entry := range r.entries {
+		if entry.step == nil || slices.Contains(disabledSteps, entry.name) {
+			continue
+		}
+		pipeline = append(pipeline, NamedStep{Name: entry.name, Step: entry.step, SkipWhenRolledBack: entry.skipWhenRolledBack})
+	}
+
+	return pipeline, nil
+}
+
+func (r *StepRegistry) indexOf(name string) int {
+	return slices.IndexFunc(r.entries, func(entry registryEntry) bool {
+		return entry.name == name
+	})
+}
+
+func countNonEmpty(values ...string) int {
+	count := 0
+	for _, value := range values {
+		if value != "" {
+			count++
+		}
+	}
+	return count
+}
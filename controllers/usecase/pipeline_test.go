@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func namesOf(pipeline []NamedStep) []string {
+	names := make([]string, len(pipeline))
+	for i, namedStep := range pipeline {
+		names[i] = namedStep.Name
+	}
+	return names
+}
+
+func newTestRegistry(t *testing.T) *StepRegistry {
+	registry := NewStepRegistry()
+	require.NoError(t, registry.Register(NamedStep{Name: "first", Step: NewMockStep(t)}))
+	require.NoError(t, registry.AddHookPoint(HookPreValidation))
+	require.NoError(t, registry.Register(NamedStep{Name: "second", Step: NewMockStep(t)}))
+	require.NoError(t, registry.Register(NamedStep{Name: "third", Step: NewMockStep(t)}))
+	return registry
+}
+
+func TestStepRegistry_Register(t *testing.T) {
+	tests := []struct {
+		name      string
+		steps     func(t *testing.T) []NamedStep
+		wantNames []string
+	}{
+		{
+			name: "should append step without constraint",
+			steps: func(t *testing.T) []NamedStep {
+				return []NamedStep{{Name: "new", Step: NewMockStep(t)}}
+			},
+			wantNames: []string{"first", "second", "third", "new"},
+		},
+		{
+			name: "should place steps at hook point in registration order",
+			steps: func(t *testing.T) []NamedStep {
+				return []NamedStep{
+					{Name: "hooked-1", Step: NewMockStep(t), Hook: HookPreValidation},
+					{Name: "hooked-2", Step: NewMockStep(t), Hook: HookPreValidation},
+				}
+			},
+			wantNames: []string{"first", "hooked-1", "hooked-2", "second", "third"},
+		},
+		{
+			name: "should place steps after step in registration order",
+			steps: func(t *testing.T) []NamedStep {
+				return []NamedStep{
+					{Name: "after-1", Step: NewMockStep(t), After: "second"},
+					{Name: "after-2", Step: NewMockStep(t), After: "second"},
+				}
+			},
+			wantNames: []string{"first", "second", "after-1", "after-2", "third"},
+		},
+		{
+			name: "should place steps before step in registration order",
+			steps: func(t *testing.T) []NamedStep {
+				return []NamedStep{
+					{Name: "before-1", Step: NewMockStep(t), Before: "second"},
+					{Name: "before-2", Step: NewMockStep(t), Before: "second"},
+				}
+			},
+			wantNames: []string{"first", "before-1", "before-2", "second", "third"},
+		},
+		{
+			name: "should place step relative to extension step",
+			steps: func(t *testing.T) []NamedStep {
+				return []NamedStep{
+					{Name: "hooked", Step: NewMockStep(t), Hook: HookPreValidation},
+					{Name: "after-hooked", Step: NewMockStep(t), After: "hooked"},
+				}
+			},
+			wantNames: []string{"first", "hooked", "after-hooked", "second", "third"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			registry := newTestRegistry(t)
+
+			// when
+			for _, namedStep := range tt.steps(t) {
+				require.NoError(t, registry.Register(namedStep))
+			}
+
+			// then
+			pipeline, err := registry.Build(nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNames, namesOf(pipeline))
+		})
+	}
+}
+
+func TestStepRegistry_Register_errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		namedStep func(t *testing.T) NamedStep
+		wantErr   string
+	}{
+		{
+			name: "should fail without name",
+			namedStep: func(t *testing.T) NamedStep {
+				return NamedStep{Step: NewMockStep(t)}
+			},
+			wantErr: "step of type \"usecase.MockStep\" has no name",
+		},
+		{
+			name: "should fail without step",
+			namedStep: func(t *testing.T) NamedStep {
+				return NamedStep{Name: "new"}
+			},
+			wantErr: "step \"new\" is nil",
+		},
+		{
+			name: "should fail for duplicate name",
+			namedStep: func(t *testing.T) NamedStep {
+				return NamedStep{Name: "second", Step: NewMockStep(t)}
+			},
+			wantErr: "step \"second\" is already registered",
+		},
+		{
+			name: "should fail for name of hook point",
+			namedStep: func(t *testing.T) NamedStep {
+				return NamedStep{Name: string(HookPreValidation), Step: NewMockStep(t)}
+			},
+			wantErr: "step \"pre-validation\" is already registered",
+		},
+		{
+			name: "should fail for multiple constraints",
+			namedStep: func(t *testing.T) NamedStep {
+				return NamedStep{Name: "new", Step: NewMockStep(t), After: "first", Before: "third"}
+			},
+			wantErr: "step \"new\" must not define more than one of hook, after and before",
+		},
+		{
+			name: "should fail for unknown hook point",
+			namedStep: func(t *testing.T) NamedStep {
+				return NamedStep{Name: "new", Step: NewMockStep(t), Hook: HookPostUpgrade}
+			},
+			wantErr: "unknown hook point \"post-upgrade\" for step \"new\"",
+		},
+		{
+			name: "should fail for step used as hook point",
+			namedStep: func(t *testing.T) NamedStep {
+				return NamedStep{Name: "new", Step: NewMockStep(t), Hook: "second"}
+			},
+			wantErr: "unknown hook point \"second\" for step \"new\"",
+		},
+		{
+			name: "should fail for unknown after constraint",
+			namedStep: func(t *testing.T) NamedStep {
+				return NamedStep{Name: "new", Step: NewMockStep(t), After: "unknown"}
+			},
+			wantErr: "step \"new\" should run after unknown step \"unknown\"",
+		},
+		{
+			name: "should fail for unknown before constraint",
+			namedStep: func(t *testing.T) NamedStep {
+				return NamedStep{Name: "new", Step: NewMockStep(t), Before: "unknown"}
+			},
+			wantErr: "step \"new\" should run before unknown step \"unknown\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			registry := newTestRegistry(t)
+
+			// when
+			err := registry.Register(tt.namedStep(t))
+
+			// then
+			require.Error(t, err)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestStepRegistry_AddHookPoint(t *testing.T) {
+	t.Run("should fail for duplicate hook point", func(t *testing.T) {
+		// given
+		registry := newTestRegistry(t)
+
+		// when
+		err := registry.AddHookPoint(HookPreValidation)
+
+		// then
+		require.Error(t, err)
+		assert.EqualError(t, err, "hook point \"pre-validation\" is already registered")
+	})
+}
+
+func TestStepRegistry_Build(t *testing.T) {
+	t.Run("should leave out disabled steps", func(t *testing.T) {
+		// given
+		registry := newTestRegistry(t)
+		require.NoError(t, registry.Register(NamedStep{Name: "after-second", Step: NewMockStep(t), After: "second"}))
+
+		// when
+		pipeline, err := registry.Build([]string{"second"})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"first", "after-second", "third"}, namesOf(pipeline))
+	})
+	t.Run("should fail to disable unknown step", func(t *testing.T) {
+		// given
+		registry := newTestRegistry(t)
+
+		// when
+		_, err := registry.Build([]string{"unknown"})
+
+		// then
+		require.Error(t, err)
+		assert.EqualError(t, err, "cannot disable unknown step \"unknown\"")
+	})
+	t.Run("should fail to disable hook point", func(t *testing.T) {
+		// given
+		registry := newTestRegistry(t)
+
+		// when
+		_, err := registry.Build([]string{string(HookPreValidation)})
+
+		// then
+		require.Error(t, err)
+		assert.EqualError(t, err, "cannot disable unknown step \"pre-validation\"")
+	})
+}
@@ -2,21 +2,34 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/journal"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/metrics"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps/deletion"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps/install"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps/postinstall"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps/upgrade"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/tracing"
+	doguUpgrade "github.com/cloudogu/k8s-dogu-operator/v3/controllers/upgrade"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 type DoguUseCase struct {
-	steps []Step
+	steps        []NamedStep
+	journal      executionJournal
+	stepRecorder stepRecorder
 }
 
 func NewDoguDeleteUseCase(
+	executionJournal journal.ExecutionJournal,
+	stepRecorder metrics.StepRecorder,
 	statusStep *deletion.StatusStep,
+	volumeSnapshotStep *deletion.VolumeSnapshotStep,
 	authRegistrationRemoverStep *deletion.AuthRegistrationRemoverStep,
 	serviceAccountRemoverStep *deletion.ServiceAccountRemoverStep,
 	deleteOutOfHealthConfigMapStep *deletion.DeleteOutOfHealthConfigMapStep,
@@ -24,23 +37,37 @@ func NewDoguDeleteUseCase(
 	removeFinalizerStep *deletion.RemoveFinalizerStep,
 ) *DoguUseCase {
 	return &DoguUseCase{
-		steps: []Step{
-			statusStep,
-			authRegistrationRemoverStep,
-			serviceAccountRemoverStep,
-			deleteOutOfHealthConfigMapStep,
-			removeSensitiveDoguConfigStep,
-			removeFinalizerStep,
+		journal:      executionJournal,
+		stepRecorder: stepRecorder,
+		steps: []NamedStep{
+			{Name: "deletion-status", Step: statusStep},
+			{Name: "deletion-volume-snapshot", Step: volumeSnapshotStep},
+			{Name: "remove-auth-registration", Step: authRegistrationRemoverStep},
+			{Name: "remove-service-accounts", Step: serviceAccountRemoverStep},
+			{Name: "delete-health-config-map", Step: deleteOutOfHealthConfigMapStep},
+			{Name: "remove-sensitive-dogu-config", Step: removeSensitiveDoguConfigStep},
+			{Name: "remove-finalizer", Step: removeFinalizerStep},
 		}}
 }
 
+// NewDoguInstallOrChangeUseCase builds the install or change use case from a StepRegistry.
+// The default steps are registered in their fixed order together with the hook points at which the extension steps are
+// placed. Steps disabled in the operator config are left out of the pipeline.
+//
 //nolint:funlen
 func NewDoguInstallOrChangeUseCase(
+	executionJournal journal.ExecutionJournal,
+	stepRecorder metrics.StepRecorder,
+	operatorConfig *config.OperatorConfig,
+	extensionSteps []NamedStep,
 	conditionsStep *install.InitializeConditionsStep,
 	healthCheckStep *install.HealthCheckStep,
 	fetchRemoteDoguDescriptorStep *install.FetchRemoteDoguDescriptorStep,
 	validationStep *install.ValidationStep,
 	pauseReconciliationStep *install.PauseReconciliationStep,
+	lockDependenciesStep *install.LockDependenciesStep,
+	rollbackStep *upgrade.RollbackStep,
+	driftDetectionStep *postinstall.DriftDetectionStep,
 	finalizerExistsStep *install.CreateFinalizerStep,
 	createDoguConfigStep install.CreateDoguConfigStep,
 	doguConfigOwnerReferenceStep install.DoguConfigOwnerReferenceStep,
@@ -54,6 +81,7 @@ func NewDoguInstallOrChangeUseCase(
 	serviceStep *install.ServiceStep,
 	execPodCreateStep *install.CreateExecPodStep,
 	customK8sResourceStep *install.CustomK8sResourceStep,
+	restoreVolumeSnapshotStep *install.RestoreVolumeSnapshotStep,
 	volumeGeneratorStep *install.CreateVolumeStep,
 	networkPoliciesStep *install.NetworkPoliciesStep,
 	deploymentStep *install.CreateDeploymentStep,
@@ -67,6 +95,8 @@ func NewDoguInstallOrChangeUseCase(
 	additionalMountsStep *postinstall.AdditionalMountsStep,
 
 	preUpgradeStatusStep *upgrade.PreUpgradeStatusStep,
+	upgradeStallDetectionStep *upgrade.UpgradeStallDetectionStep,
+	volumeSnapshotStep *upgrade.VolumeSnapshotStep,
 	updateDeploymentStep *upgrade.UpdateDeploymentVersionStep,
 	deleteExecPodStep *upgrade.DeleteExecPodStep,
 	revertStartupProbeStep *upgrade.PostUpgradeStep,
@@ -76,56 +106,125 @@ func NewDoguInstallOrChangeUseCase(
 	updateStartedAtStep *upgrade.UpdateStartedAtStep,
 	restartDoguStep *upgrade.RestartAfterConfigChangeStep,
 	retroactiveServiceAccountStep *upgrade.RetroactiveServiceAccountStep,
-) *DoguUseCase {
-	return &DoguUseCase{
-		steps: []Step{
-			conditionsStep,
-			healthCheckStep,
-			fetchRemoteDoguDescriptorStep,
-			validationStep,
-			pauseReconciliationStep,
-			finalizerExistsStep,
-			createDoguConfigStep,
-			doguConfigOwnerReferenceStep,
-			createSensitiveDoguConfigStep,
-			sensitiveDoguConfigOwnerReferenceStep,
-			removeServiceAccountStep,
-			registerDoguVersionStep,
-			localDoguDescriptorOwnerReferenceStep,
-			authRegistrationStep,
-			serviceAccountStep,
-			serviceStep,
-			execPodCreateStep,
-			customK8sResourceStep,
-			volumeGeneratorStep,
-			networkPoliciesStep,
-
-			deploymentStep,
-			replicasStep,
-			volumeExpanderStep,
-			mismatchedStorageClassWarningStep,
-			securityContextStep,
-			exportModeStep,
-			supportModeStep,
-			additionalMountsStep,
-
-			preUpgradeStatusStep,
-			updateDeploymentStep,
-			upgradeRegisterDoguVersionStep,
-			deleteExecPodStep,
-			revertStartupProbeStep,
-			installedVersionStep,
-			deploymentUpdaterStep,
-			updateStartedAtStep,
-			restartDoguStep,
-			retroactiveServiceAccountStep,
-		},
+) (*DoguUseCase, error) {
+	registry := NewStepRegistry()
+	var registrationErrs []error
+	register := func(name string, step Step) {
+		registrationErrs = append(registrationErrs, registry.Register(NamedStep{Name: name, Step: step}))
+	}
+	// steps applying the spec version are skipped while the upgrade to it is rolled back
+	registerVersionStep := func(name string, step Step) {
+		registrationErrs = append(registrationErrs, registry.Register(NamedStep{Name: name, Step: step, SkipWhenRolledBack: true}))
+	}
+	addHookPoint := func(hook HookPoint) {
+		registrationErrs = append(registrationErrs, registry.AddHookPoint(hook))
+	}
+
+	register("initialize-conditions", conditionsStep)
+	register("health-check", healthCheckStep)
+	register("fetch-remote-dogu-descriptor", fetchRemoteDoguDescriptorStep)
+	addHookPoint(HookPreValidation)
+	register("validation", validationStep)
+	register("pause-reconciliation", pauseReconciliationStep)
+	register("lock-dependencies", lockDependenciesStep)
+	// the state before an upgrade is saved before any of the following steps changes it; while a rolled back version
+	// is still requested, only the steps registered as version steps are skipped and the pipeline continues
+	register("upgrade-rollback", rollbackStep)
+	// drift is detected before any of the following steps applies the resources of the dogu again
+	register("drift-detection", driftDetectionStep)
+	register("create-finalizer", finalizerExistsStep)
+	register("create-dogu-config", createDoguConfigStep)
+	register("dogu-config-owner-reference", doguConfigOwnerReferenceStep)
+	register("create-sensitive-dogu-config", createSensitiveDoguConfigStep)
+	register("sensitive-dogu-config-owner-reference", sensitiveDoguConfigOwnerReferenceStep)
+	register("remove-service-account", removeServiceAccountStep)
+	register("register-dogu-version", registerDoguVersionStep)
+	register("local-dogu-descriptor-owner-reference", localDoguDescriptorOwnerReferenceStep)
+	register("auth-registration", authRegistrationStep)
+	register("service-account", serviceAccountStep)
+	registerVersionStep("service", serviceStep)
+	registerVersionStep("create-exec-pod", execPodCreateStep)
+	register("custom-k8s-resource", customK8sResourceStep)
+	// the data volume is restored before it is created or expanded
+	register("restore-volume-snapshot", restoreVolumeSnapshotStep)
+	register("create-volume", volumeGeneratorStep)
+	register("network-policies", networkPoliciesStep)
+
+	register("create-deployment", deploymentStep)
+	addHookPoint(HookPostDeployment)
+	register("start-stop", replicasStep)
+	register("volume-expander", volumeExpanderStep)
+	register("mismatched-storage-class-warning", mismatchedStorageClassWarningStep)
+	register("security-context", securityContextStep)
+	register("export-mode", exportModeStep)
+	register("support-mode", supportModeStep)
+	register("additional-mounts", additionalMountsStep)
+
+	registerVersionStep("pre-upgrade-status", preUpgradeStatusStep)
+	register("upgrade-stall-detection", upgradeStallDetectionStep)
+	// the volume snapshot has to be ready before the pre-upgrade script changes the data of the dogu
+	registerVersionStep("volume-snapshot", volumeSnapshotStep)
+	registerVersionStep("update-deployment-version", updateDeploymentStep)
+	registerVersionStep("upgrade-register-dogu-version", upgradeRegisterDoguVersionStep)
+	register("delete-exec-pod", deleteExecPodStep)
+	registerVersionStep("revert-startup-probe", revertStartupProbeStep)
+	registerVersionStep("installed-version", installedVersionStep)
+	register("regenerate-deployment", deploymentUpdaterStep)
+	register("update-started-at", updateStartedAtStep)
+	register("restart-after-config-change", restartDoguStep)
+	register("retroactive-service-account", retroactiveServiceAccountStep)
+	addHookPoint(HookPostUpgrade)
+
+	err := errors.Join(registrationErrs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register default step pipeline: %w", err)
+	}
+
+	for _, extensionStep := range extensionSteps {
+		err = registry.Register(extensionStep)
+		if err != nil {
+			return nil, fmt.Errorf("failed to register extension step: %w", err)
+		}
 	}
+
+	pipeline, err := registry.Build(operatorConfig.DisabledSteps)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build step pipeline: %w", err)
+	}
+
+	stepNames := make([]string, len(pipeline))
+	for i, namedStep := range pipeline {
+		stepNames[i] = namedStep.Name
+	}
+	log.Log.WithName("usecase").Info("Effective dogu install or change pipeline", "steps", stepNames, "disabledSteps", operatorConfig.DisabledSteps)
+
+	return &DoguUseCase{
+		journal:      executionJournal,
+		stepRecorder: stepRecorder,
+		steps:        pipeline,
+	}, nil
 }
 
+// HandleUntilApplied runs the steps until one of them requeues, aborts or fails.
+// Every run is recorded in the execution journal of the dogu and every step is observed in the metrics and traced
+// under its name in the pipeline.
 func (duc *DoguUseCase) HandleUntilApplied(ctx context.Context, doguResource *v2.Dogu) (time.Duration, bool, error) {
+	run := journal.NewRun(time.Now())
+	defer duc.record(ctx, doguResource, run)
+
 	for _, s := range duc.steps {
-		result := s.Run(ctx, doguResource)
+		if s.SkipWhenRolledBack && doguUpgrade.IsRolledBack(doguResource) {
+			continue
+		}
+		stepName := s.Name
+		stepCtx, span := tracing.Start(ctx, stepName, tracing.AttributeDogu.String(doguResource.Name))
+		stepStart := time.Now()
+		result := s.Step.Run(stepCtx, doguResource)
+		stepDuration := time.Since(stepStart)
+		span.SetAttributes(tracing.AttributeStepOutcome.String(string(result.Outcome())))
+		tracing.End(span, result.Err)
+		run.AddStep(stepName, result, stepDuration)
+		duc.stepRecorder.ObserveStep(doguResource.GetObjectKey(), stepName, result.Outcome(), stepDuration)
 		if result.Err != nil || result.RequeueAfter != 0 {
 			return result.RequeueAfter, false, result.Err
 		}
@@ -135,3 +234,11 @@ func (duc *DoguUseCase) HandleUntilApplied(ctx context.Context, doguResource *v2
 	}
 	return 0, true, nil
 }
+
+func (duc *DoguUseCase) record(ctx context.Context, doguResource *v2.Dogu, run *journal.Run) {
+	run.Finish(time.Now())
+	err := duc.journal.Record(ctx, doguResource, *run)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to record execution journal")
+	}
+}
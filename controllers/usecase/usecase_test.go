@@ -9,15 +9,25 @@ import (
 	"time"
 
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/journal"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps/deletion"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps/install"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps/postinstall"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps/upgrade"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/tracing"
+	doguUpgrade "github.com/cloudogu/k8s-dogu-operator/v3/controllers/upgrade"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 var testCtx = context.Background()
@@ -25,7 +35,7 @@ var testCtx = context.Background()
 func TestDoguUseCase_HandleUntilApplied(t *testing.T) {
 	tests := []struct {
 		name             string
-		stepsFn          func(t *testing.T) []Step
+		stepsFn          func(t *testing.T) []NamedStep
 		doguResource     *v2.Dogu
 		wantRequeueAfter time.Duration
 		wantContinue     bool
@@ -33,10 +43,10 @@ func TestDoguUseCase_HandleUntilApplied(t *testing.T) {
 	}{
 		{
 			name: "should requeue run on requeueAfter time",
-			stepsFn: func(t *testing.T) []Step {
+			stepsFn: func(t *testing.T) []NamedStep {
 				step := NewMockStep(t)
-				step.EXPECT().Run(testCtx, mock.Anything).Return(steps.RequeueAfter(2))
-				return []Step{step}
+				step.EXPECT().Run(mock.Anything, mock.Anything).Return(steps.RequeueAfter(2))
+				return []NamedStep{{Name: "test-step", Step: step}}
 			},
 			doguResource: &v2.Dogu{
 				ObjectMeta: v1.ObjectMeta{Name: "test"},
@@ -47,10 +57,10 @@ func TestDoguUseCase_HandleUntilApplied(t *testing.T) {
 		},
 		{
 			name: "should requeue run on error",
-			stepsFn: func(t *testing.T) []Step {
+			stepsFn: func(t *testing.T) []NamedStep {
 				step := NewMockStep(t)
-				step.EXPECT().Run(testCtx, mock.Anything).Return(steps.RequeueWithError(assert.AnError))
-				return []Step{step}
+				step.EXPECT().Run(mock.Anything, mock.Anything).Return(steps.RequeueWithError(assert.AnError))
+				return []NamedStep{{Name: "test-step", Step: step}}
 			},
 			doguResource: &v2.Dogu{
 				ObjectMeta: v1.ObjectMeta{Name: "test"},
@@ -61,10 +71,10 @@ func TestDoguUseCase_HandleUntilApplied(t *testing.T) {
 		},
 		{
 			name: "should continue after step",
-			stepsFn: func(t *testing.T) []Step {
+			stepsFn: func(t *testing.T) []NamedStep {
 				step := NewMockStep(t)
-				step.EXPECT().Run(testCtx, mock.Anything).Return(steps.Continue())
-				return []Step{step}
+				step.EXPECT().Run(mock.Anything, mock.Anything).Return(steps.Continue())
+				return []NamedStep{{Name: "test-step", Step: step}}
 			},
 			doguResource: &v2.Dogu{
 				ObjectMeta: v1.ObjectMeta{Name: "test"},
@@ -75,10 +85,10 @@ func TestDoguUseCase_HandleUntilApplied(t *testing.T) {
 		},
 		{
 			name: "should abort after step",
-			stepsFn: func(t *testing.T) []Step {
+			stepsFn: func(t *testing.T) []NamedStep {
 				step := NewMockStep(t)
-				step.EXPECT().Run(testCtx, mock.Anything).Return(steps.Abort())
-				return []Step{step}
+				step.EXPECT().Run(mock.Anything, mock.Anything).Return(steps.Abort())
+				return []NamedStep{{Name: "test-step", Step: step}}
 			},
 			doguResource: &v2.Dogu{
 				ObjectMeta: v1.ObjectMeta{Name: "test"},
@@ -90,8 +100,14 @@ func TestDoguUseCase_HandleUntilApplied(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			journalMock := newMockExecutionJournal(t)
+			journalMock.EXPECT().Record(testCtx, tt.doguResource, mock.Anything).Return(nil)
+			recorderMock := newMockStepRecorder(t)
+			recorderMock.EXPECT().ObserveStep(types.NamespacedName{Name: "test"}, "test-step", mock.Anything, mock.Anything).Return()
 			duc := &DoguUseCase{
-				steps: tt.stepsFn(t),
+				steps:        tt.stepsFn(t),
+				journal:      journalMock,
+				stepRecorder: recorderMock,
 			}
 			got, got1, err := duc.HandleUntilApplied(testCtx, tt.doguResource)
 			if !tt.wantErr(t, err, fmt.Sprintf("HandleUntilApplied(%v, %v)", testCtx, tt.doguResource)) {
@@ -103,9 +119,139 @@ func TestDoguUseCase_HandleUntilApplied(t *testing.T) {
 	}
 }
 
+func TestDoguUseCase_HandleUntilApplied_journalAndMetrics(t *testing.T) {
+	doguResource := &v2.Dogu{ObjectMeta: v1.ObjectMeta{Name: "test"}}
+
+	t.Run("should record executed steps in journal and metrics", func(t *testing.T) {
+		// given
+		continueStep := NewMockStep(t)
+		continueStep.EXPECT().Run(mock.Anything, doguResource).Return(steps.Continue())
+		requeueStep := NewMockStep(t)
+		requeueStep.EXPECT().Run(mock.Anything, doguResource).Return(steps.RequeueWithError(assert.AnError))
+		notRunStep := NewMockStep(t)
+
+		journalMock := newMockExecutionJournal(t)
+		journalMock.EXPECT().Record(testCtx, doguResource, mock.Anything).Run(func(ctx context.Context, doguResource *v2.Dogu, run journal.Run) {
+			require.Len(t, run.Steps, 2)
+			assert.Equal(t, "continue-step", run.Steps[0].Name)
+			assert.Equal(t, steps.OutcomeContinue, run.Steps[0].Outcome)
+			assert.Equal(t, "requeue-step", run.Steps[1].Name)
+			assert.Equal(t, steps.OutcomeError, run.Steps[1].Outcome)
+			assert.Equal(t, steps.OutcomeError, run.Outcome)
+			assert.Equal(t, assert.AnError.Error(), run.Error)
+		}).Return(nil)
+
+		recorderMock := newMockStepRecorder(t)
+		recorderMock.EXPECT().ObserveStep(types.NamespacedName{Name: "test"}, "continue-step", steps.OutcomeContinue, mock.Anything).Return().Once()
+		recorderMock.EXPECT().ObserveStep(types.NamespacedName{Name: "test"}, "requeue-step", steps.OutcomeError, mock.Anything).Return().Once()
+
+		duc := &DoguUseCase{steps: []NamedStep{
+			{Name: "continue-step", Step: continueStep},
+			{Name: "requeue-step", Step: requeueStep},
+			{Name: "not-run-step", Step: notRunStep},
+		}, journal: journalMock, stepRecorder: recorderMock}
+
+		// when
+		_, _, err := duc.HandleUntilApplied(testCtx, doguResource)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("should not fail if journal cannot be recorded", func(t *testing.T) {
+		// given
+		step := NewMockStep(t)
+		step.EXPECT().Run(mock.Anything, doguResource).Return(steps.Continue())
+		journalMock := newMockExecutionJournal(t)
+		journalMock.EXPECT().Record(testCtx, doguResource, mock.Anything).Return(assert.AnError)
+
+		recorderMock := newMockStepRecorder(t)
+		recorderMock.EXPECT().ObserveStep(types.NamespacedName{Name: "test"}, "test-step", steps.OutcomeContinue, mock.Anything).Return()
+
+		duc := &DoguUseCase{steps: []NamedStep{{Name: "test-step", Step: step}}, journal: journalMock, stepRecorder: recorderMock}
+
+		// when
+		requeueAfter, cont, err := duc.HandleUntilApplied(testCtx, doguResource)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, cont)
+		assert.Zero(t, requeueAfter)
+	})
+	t.Run("should trace each executed step", func(t *testing.T) {
+		// given
+		spanRecorder := tracetest.NewSpanRecorder()
+		oldProvider := otel.GetTracerProvider()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder)))
+		defer otel.SetTracerProvider(oldProvider)
+
+		step := NewMockStep(t)
+		step.EXPECT().Run(mock.Anything, doguResource).RunAndReturn(func(ctx context.Context, _ *v2.Dogu) steps.StepResult {
+			assert.True(t, trace.SpanContextFromContext(ctx).IsValid())
+			return steps.RequeueWithError(assert.AnError)
+		})
+		journalMock := newMockExecutionJournal(t)
+		journalMock.EXPECT().Record(testCtx, doguResource, mock.Anything).Return(nil)
+		recorderMock := newMockStepRecorder(t)
+		recorderMock.EXPECT().ObserveStep(types.NamespacedName{Name: "test"}, "test-step", steps.OutcomeError, mock.Anything).Return()
+
+		duc := &DoguUseCase{steps: []NamedStep{{Name: "test-step", Step: step}}, journal: journalMock, stepRecorder: recorderMock}
+
+		// when
+		_, _, err := duc.HandleUntilApplied(testCtx, doguResource)
+
+		// then
+		require.Error(t, err)
+		spans := spanRecorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "test-step", spans[0].Name())
+		assert.Contains(t, spans[0].Attributes(), tracing.AttributeDogu.String("test"))
+		assert.Contains(t, spans[0].Attributes(), tracing.AttributeStepOutcome.String("error"))
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+	})
+}
+
+func TestDoguUseCase_HandleUntilApplied_rolledBack(t *testing.T) {
+	t.Run("should skip version steps and run all other steps while the upgrade is rolled back", func(t *testing.T) {
+		// given
+		doguResource := &v2.Dogu{
+			ObjectMeta: v1.ObjectMeta{Name: "test", Annotations: map[string]string{doguUpgrade.RollbackAnnotation: "true"}},
+			Spec:       v2.DoguSpec{Version: "2.0.0-1"},
+			Status: v2.DoguStatus{
+				InstalledVersion: "1.0.0-1",
+				Conditions:       []v1.Condition{{Type: doguUpgrade.ConditionRolledBack, Status: v1.ConditionTrue}},
+			},
+		}
+		otherStep := NewMockStep(t)
+		otherStep.EXPECT().Run(mock.Anything, doguResource).Return(steps.Continue())
+		versionStep := NewMockStep(t)
+
+		journalMock := newMockExecutionJournal(t)
+		journalMock.EXPECT().Record(testCtx, doguResource, mock.Anything).Run(func(ctx context.Context, doguResource *v2.Dogu, run journal.Run) {
+			require.Len(t, run.Steps, 1)
+			assert.Equal(t, "other-step", run.Steps[0].Name)
+		}).Return(nil)
+		recorderMock := newMockStepRecorder(t)
+		recorderMock.EXPECT().ObserveStep(types.NamespacedName{Name: "test"}, "other-step", steps.OutcomeContinue, mock.Anything).Return()
+
+		duc := &DoguUseCase{steps: []NamedStep{
+			{Name: "version-step", Step: versionStep, SkipWhenRolledBack: true},
+			{Name: "other-step", Step: otherStep},
+		}, journal: journalMock, stepRecorder: recorderMock}
+
+		// when
+		requeueAfter, cont, err := duc.HandleUntilApplied(testCtx, doguResource)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, cont)
+		assert.Zero(t, requeueAfter)
+	})
+}
+
 func TestNewDoguDeleteUseCase(t *testing.T) {
 	t.Run("should successfully create dogu delete use case with steps in correct order", func(t *testing.T) {
 		statusStep := &deletion.StatusStep{}
+		volumeSnapshotStep := &deletion.VolumeSnapshotStep{}
 		authRegistrationRemoverStep := &deletion.AuthRegistrationRemoverStep{}
 		serviceAccountRemoverStep := &deletion.ServiceAccountRemoverStep{}
 		deleteOutOfHealthConfigMapStep := &deletion.DeleteOutOfHealthConfigMapStep{}
@@ -113,7 +259,10 @@ func TestNewDoguDeleteUseCase(t *testing.T) {
 		removeFinalizerStep := &deletion.RemoveFinalizerStep{}
 
 		got := NewDoguDeleteUseCase(
+			newMockExecutionJournal(t),
+			newMockStepRecorder(t),
 			statusStep,
+			volumeSnapshotStep,
 			authRegistrationRemoverStep,
 			serviceAccountRemoverStep,
 			deleteOutOfHealthConfigMapStep,
@@ -123,6 +272,7 @@ func TestNewDoguDeleteUseCase(t *testing.T) {
 
 		wantTypes := []string{
 			"*deletion.StatusStep",
+			"*deletion.VolumeSnapshotStep",
 			"*deletion.AuthRegistrationRemoverStep",
 			"*deletion.ServiceAccountRemoverStep",
 			"*deletion.DeleteOutOfHealthConfigMapStep",
@@ -132,57 +282,16 @@ func TestNewDoguDeleteUseCase(t *testing.T) {
 
 		assert.NotNil(t, got)
 		require.True(t,
-			slices.Equal(typesOf(got.steps), wantTypes),
+			slices.Equal(stepTypesOf(got.steps), wantTypes),
 			"order mismatch: got=%v want=%v",
-			typesOf(got.steps), wantTypes,
+			stepTypesOf(got.steps), wantTypes,
 		)
 	})
 }
 
 func TestNewDoguInstallOrChangeUseCase(t *testing.T) {
 	t.Run("should successfully create dogu install or change use case with steps in correct order", func(t *testing.T) {
-		got := NewDoguInstallOrChangeUseCase(
-			&install.InitializeConditionsStep{},
-			&install.HealthCheckStep{},
-			&install.FetchRemoteDoguDescriptorStep{},
-			&install.ValidationStep{},
-			&install.PauseReconciliationStep{},
-			&install.CreateFinalizerStep{},
-			install.NewCreateConfigStep(nil),
-			install.NewOwnerReferenceStep(nil, nil),
-			install.NewCreateConfigStep(nil),
-			install.NewOwnerReferenceStep(nil, nil),
-			install.NewRemoveServiceAccountStep(nil, nil, nil),
-			&install.RegisterDoguVersionStep{},
-			install.NewOwnerReferenceStep(nil, nil),
-			&install.AuthRegistrationStep{},
-			&install.ServiceAccountStep{},
-			&install.ServiceStep{},
-			&install.CreateExecPodStep{},
-			&install.CustomK8sResourceStep{},
-			&install.CreateVolumeStep{},
-			&install.NetworkPoliciesStep{},
-			&install.CreateDeploymentStep{},
-
-			&postinstall.StartStopStep{},
-			&postinstall.VolumeExpanderStep{},
-			&postinstall.MismatchedStorageClassWarningStep{},
-			&postinstall.SecurityContextStep{},
-			&postinstall.ExportModeStep{},
-			&postinstall.SupportModeStep{},
-			&postinstall.AdditionalMountsStep{},
-
-			&upgrade.PreUpgradeStatusStep{},
-			&upgrade.UpdateDeploymentVersionStep{},
-			&upgrade.DeleteExecPodStep{},
-			&upgrade.PostUpgradeStep{},
-			&upgrade.RegenerateDeploymentStep{},
-			&upgrade.RegisterDoguVersionStep{},
-			&upgrade.InstalledVersionStep{},
-			&upgrade.UpdateStartedAtStep{},
-			&upgrade.RestartAfterConfigChangeStep{},
-			&upgrade.RetroactiveServiceAccountStep{},
-		)
+		got, err := newDoguInstallOrChangeUseCaseWithDefaultSteps(t, &config.OperatorConfig{}, nil)
 
 		wantTypes := []string{
 			"*install.InitializeConditionsStep",
@@ -190,6 +299,9 @@ func TestNewDoguInstallOrChangeUseCase(t *testing.T) {
 			"*install.FetchRemoteDoguDescriptorStep",
 			"*install.ValidationStep",
 			"*install.PauseReconciliationStep",
+			"*install.LockDependenciesStep",
+			"*upgrade.RollbackStep",
+			"*postinstall.DriftDetectionStep",
 			"*install.CreateFinalizerStep",
 			"*install.CreateConfigStep",
 			"*install.OwnerReferenceStep",
@@ -203,6 +315,7 @@ func TestNewDoguInstallOrChangeUseCase(t *testing.T) {
 			"*install.ServiceStep",
 			"*install.CreateExecPodStep",
 			"*install.CustomK8sResourceStep",
+			"*install.RestoreVolumeSnapshotStep",
 			"*install.CreateVolumeStep",
 			"*install.NetworkPoliciesStep",
 			"*install.CreateDeploymentStep",
@@ -216,6 +329,8 @@ func TestNewDoguInstallOrChangeUseCase(t *testing.T) {
 			"*postinstall.AdditionalMountsStep",
 
 			"*upgrade.PreUpgradeStatusStep",
+			"*upgrade.UpgradeStallDetectionStep",
+			"*upgrade.VolumeSnapshotStep",
 			"*upgrade.UpdateDeploymentVersionStep",
 			"*upgrade.RegisterDoguVersionStep",
 			"*upgrade.DeleteExecPodStep",
@@ -227,13 +342,142 @@ func TestNewDoguInstallOrChangeUseCase(t *testing.T) {
 			"*upgrade.RetroactiveServiceAccountStep",
 		}
 
+		require.NoError(t, err)
 		assert.NotNil(t, got)
 		require.True(t,
-			slices.Equal(typesOf(got.steps), wantTypes),
+			slices.Equal(stepTypesOf(got.steps), wantTypes),
 			"order mismatch: got=%v want=%v",
-			typesOf(got.steps), wantTypes,
+			stepTypesOf(got.steps), wantTypes,
 		)
+		var versionSteps []string
+		for _, namedStep := range got.steps {
+			if namedStep.SkipWhenRolledBack {
+				versionSteps = append(versionSteps, namedStep.Name)
+			}
+		}
+		assert.Equal(t, []string{
+			"service",
+			"create-exec-pod",
+			"pre-upgrade-status",
+			"volume-snapshot",
+			"update-deployment-version",
+			"upgrade-register-dogu-version",
+			"revert-startup-probe",
+			"installed-version",
+		}, versionSteps)
 	})
+	t.Run("should place extension steps at hook points and leave out disabled steps", func(t *testing.T) {
+		// given
+		preValidationStep := NewMockStep(t)
+		postDeploymentStep := NewMockStep(t)
+		postUpgradeStep := NewMockStep(t)
+		extensionSteps := []NamedStep{
+			{Name: "compliance-check", Step: preValidationStep, Hook: HookPreValidation},
+			{Name: "notification", Step: postUpgradeStep, Hook: HookPostUpgrade},
+			{Name: "smoke-test", Step: postDeploymentStep, After: "create-deployment"},
+		}
+		operatorConfig := &config.OperatorConfig{DisabledSteps: []string{"export-mode", "support-mode"}}
+
+		// when
+		got, err := newDoguInstallOrChangeUseCaseWithDefaultSteps(t, operatorConfig, extensionSteps)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, got.steps, 44+3-2)
+		assert.Same(t, preValidationStep, got.steps[3].Step)
+		assert.IsType(t, &install.ValidationStep{}, got.steps[4].Step)
+		assert.IsType(t, &install.CreateDeploymentStep{}, got.steps[25].Step)
+		assert.Same(t, postDeploymentStep, got.steps[26].Step)
+		assert.IsType(t, &postinstall.StartStopStep{}, got.steps[27].Step)
+		assert.Same(t, postUpgradeStep, got.steps[len(got.steps)-1].Step)
+		assert.NotContains(t, stepTypesOf(got.steps), "*postinstall.ExportModeStep")
+		assert.NotContains(t, stepTypesOf(got.steps), "*postinstall.SupportModeStep")
+	})
+	t.Run("should fail to disable unknown step", func(t *testing.T) {
+		// given
+		operatorConfig := &config.OperatorConfig{DisabledSteps: []string{"unknown"}}
+
+		// when
+		_, err := newDoguInstallOrChangeUseCaseWithDefaultSteps(t, operatorConfig, nil)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to build step pipeline: cannot disable unknown step \"unknown\"")
+	})
+	t.Run("should fail to register extension step with unknown constraint", func(t *testing.T) {
+		// given
+		extensionSteps := []NamedStep{{Name: "notification", Step: NewMockStep(t), Before: "unknown"}}
+
+		// when
+		_, err := newDoguInstallOrChangeUseCaseWithDefaultSteps(t, &config.OperatorConfig{}, extensionSteps)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to register extension step: step \"notification\" should run before unknown step \"unknown\"")
+	})
+}
+
+func newDoguInstallOrChangeUseCaseWithDefaultSteps(t *testing.T, operatorConfig *config.OperatorConfig, extensionSteps []NamedStep) (*DoguUseCase, error) {
+	return NewDoguInstallOrChangeUseCase(
+		newMockExecutionJournal(t),
+		newMockStepRecorder(t),
+		operatorConfig,
+		extensionSteps,
+		&install.InitializeConditionsStep{},
+		&install.HealthCheckStep{},
+		&install.FetchRemoteDoguDescriptorStep{},
+		&install.ValidationStep{},
+		&install.PauseReconciliationStep{},
+		&install.LockDependenciesStep{},
+		&upgrade.RollbackStep{},
+		&postinstall.DriftDetectionStep{},
+		&install.CreateFinalizerStep{},
+		install.NewCreateConfigStep(nil),
+		install.NewOwnerReferenceStep(nil, nil),
+		install.NewCreateConfigStep(nil),
+		install.NewOwnerReferenceStep(nil, nil),
+		install.NewRemoveServiceAccountStep(nil, nil, nil),
+		&install.RegisterDoguVersionStep{},
+		install.NewOwnerReferenceStep(nil, nil),
+		&install.AuthRegistrationStep{},
+		&install.ServiceAccountStep{},
+		&install.ServiceStep{},
+		&install.CreateExecPodStep{},
+		&install.CustomK8sResourceStep{},
+		&install.RestoreVolumeSnapshotStep{},
+		&install.CreateVolumeStep{},
+		&install.NetworkPoliciesStep{},
+		&install.CreateDeploymentStep{},
+
+		&postinstall.StartStopStep{},
+		&postinstall.VolumeExpanderStep{},
+		&postinstall.MismatchedStorageClassWarningStep{},
+		&postinstall.SecurityContextStep{},
+		&postinstall.ExportModeStep{},
+		&postinstall.SupportModeStep{},
+		&postinstall.AdditionalMountsStep{},
+
+		&upgrade.PreUpgradeStatusStep{},
+		&upgrade.UpgradeStallDetectionStep{},
+		&upgrade.VolumeSnapshotStep{},
+		&upgrade.UpdateDeploymentVersionStep{},
+		&upgrade.DeleteExecPodStep{},
+		&upgrade.PostUpgradeStep{},
+		&upgrade.RegenerateDeploymentStep{},
+		&upgrade.RegisterDoguVersionStep{},
+		&upgrade.InstalledVersionStep{},
+		&upgrade.UpdateStartedAtStep{},
+		&upgrade.RestartAfterConfigChangeStep{},
+		&upgrade.RetroactiveServiceAccountStep{},
+	)
+}
+
+func stepTypesOf(namedSteps []NamedStep) []string {
+	out := make([]string, len(namedSteps))
+	for i, namedStep := range namedSteps {
+		out[i] = reflect.TypeOf(namedStep.Step).String()
+	}
+	return out
 }
 
 func typesOf[T any](xs []T) []string {
@@ -0,0 +1,22 @@
+package volumesnapshot
+
+import (
+	"context"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Snapshotter takes CSI volume snapshots of the data volumes of dogus.
+type Snapshotter interface {
+	// Take creates a volume snapshot of the data volume of the dogu for the given purpose unless it already exists and
+	// returns its state. Once the snapshot is ready to use, the oldest snapshots of the dogu exceeding the retention are
+	// deleted. Nil is returned if the dogu has no data volume.
+	Take(ctx context.Context, doguResource *v2.Dogu, purpose Purpose) (*State, error)
+	// Get returns the state of the volume snapshot with the given name in the namespace of the dogu.
+	Get(ctx context.Context, doguResource *v2.Dogu, name string) (*State, error)
+}
+
+type k8sClient interface {
+	client.Client
+}
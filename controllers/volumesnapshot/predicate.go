@@ -0,0 +1,23 @@
+package volumesnapshot
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// RestoreAnnotationChangedPredicate triggers a reconcile of a dogu if a restore of its data volume is requested.
+// Annotations do not change the generation of the dogu resource.
+func RestoreAnnotationChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return false
+			}
+			restore := e.ObjectNew.GetAnnotations()[RestoreAnnotation]
+			return restore != "" && restore != e.ObjectOld.GetAnnotations()[RestoreAnnotation]
+		},
+	}
+}
@@ -0,0 +1,30 @@
+package volumesnapshot
+
+import (
+	"testing"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestRestoreAnnotationChangedPredicate(t *testing.T) {
+	withRestore := func(name string) *v2.Dogu {
+		doguResource := &v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "ldap", Annotations: map[string]string{}}}
+		if name != "" {
+			doguResource.Annotations[RestoreAnnotation] = name
+		}
+		return doguResource
+	}
+	sut := RestoreAnnotationChangedPredicate()
+
+	assert.True(t, sut.Update(event.UpdateEvent{ObjectOld: withRestore(""), ObjectNew: withRestore("ldap-upgrade-4")}))
+	assert.True(t, sut.Update(event.UpdateEvent{ObjectOld: withRestore("ldap-upgrade-3"), ObjectNew: withRestore("ldap-upgrade-4")}))
+	assert.False(t, sut.Update(event.UpdateEvent{ObjectOld: withRestore("ldap-upgrade-4"), ObjectNew: withRestore("ldap-upgrade-4")}))
+	assert.False(t, sut.Update(event.UpdateEvent{ObjectOld: withRestore("ldap-upgrade-4"), ObjectNew: withRestore("")}))
+	assert.False(t, sut.Update(event.UpdateEvent{ObjectNew: withRestore("ldap-upgrade-4")}))
+	assert.False(t, sut.Create(event.CreateEvent{Object: withRestore("ldap-upgrade-4")}))
+	assert.False(t, sut.Delete(event.DeleteEvent{Object: withRestore("ldap-upgrade-4")}))
+	assert.False(t, sut.Generic(event.GenericEvent{Object: withRestore("ldap-upgrade-4")}))
+}
@@ -0,0 +1,169 @@
+package volumesnapshot
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// Annotation opts a dogu in to volume snapshots of its data volume before upgrades and deletions if set to "true".
+	Annotation = "k8s.cloudogu.com/volume-snapshots"
+	// RestoreAnnotation requests the restore of the data volume of a dogu from the volume snapshot with the given name.
+	// The annotation is removed once the data volume has been restored.
+	RestoreAnnotation = "k8s.cloudogu.com/restore-volume-snapshot"
+	// RestoreRequestAnnotation identifies a restore request. The dogu operator sets it on the dogu when it starts the
+	// restore and on the data volume it creates from the volume snapshot, so that a finished restore is told apart from
+	// a new request of the same volume snapshot.
+	RestoreRequestAnnotation = "k8s.cloudogu.com/restore-volume-snapshot-request"
+	// DoguLabel contains the name of the dogu whose data volume the volume snapshot was taken of.
+	DoguLabel = "k8s.cloudogu.com/volume-snapshot-dogu"
+	// PurposeLabel contains the Purpose of the volume snapshot.
+	PurposeLabel = "k8s.cloudogu.com/volume-snapshot-purpose"
+	// VersionAnnotation contains the installed version of the dogu at the time of the volume snapshot.
+	VersionAnnotation = "k8s.cloudogu.com/dogu-version"
+)
+
+const (
+	// ConditionVolumeSnapshotReady is true if the last volume snapshot of the dogu is ready to use.
+	ConditionVolumeSnapshotReady = "VolumeSnapshotReady"
+	// ReasonSnapshotReady is the reason of the VolumeSnapshotReady condition if the volume snapshot is ready to use.
+	ReasonSnapshotReady = "SnapshotReady"
+	// ReasonSnapshotPending is the reason of the VolumeSnapshotReady condition while the volume snapshot is taken.
+	ReasonSnapshotPending = "SnapshotPending"
+	// ReasonSnapshotFailed is the reason of the VolumeSnapshotReady condition if the volume snapshot failed.
+	ReasonSnapshotFailed = "SnapshotFailed"
+)
+
+// Purpose describes why a volume snapshot is taken.
+type Purpose string
+
+const (
+	// PurposeUpgrade marks volume snapshots taken before the pre-upgrade script of a dogu upgrade runs.
+	PurposeUpgrade Purpose = "upgrade"
+	// PurposeDeletion marks volume snapshots taken before a dogu and its data volume are deleted.
+	PurposeDeletion Purpose = "deletion"
+)
+
+var invalidNameCharsRegex = regexp.MustCompile(`[^a-z0-9.-]`)
+
+// GroupVersionKind is the kind of the volume snapshots of the CSI snapshot controller.
+var GroupVersionKind = schema.GroupVersionKind{Group: "snapshot.storage.k8s.io", Version: "v1", Kind: "VolumeSnapshot"}
+
+// Enabled returns true if volume snapshots are taken of the data volume of the dogu.
+func Enabled(doguResource *v2.Dogu) bool {
+	return doguResource.GetAnnotations()[Annotation] == "true"
+}
+
+// NameFor returns the name of the volume snapshot of the dogu for the purpose. The name of a volume snapshot before an
+// upgrade contains the installed and the requested version, so that every upgrade gets its own volume snapshot while
+// repeated reconciles of the same upgrade find the existing one, even if other fields of the dogu resource change in
+// the meantime. The names of other volume snapshots contain the generation of the dogu resource. The prefix of the UID
+// distinguishes the volume snapshots of re-created dogus.
+func NameFor(doguResource *v2.Dogu, purpose Purpose) string {
+	name := fmt.Sprintf("%s-%s-%d", doguResource.Name, purpose, doguResource.Generation)
+	if purpose == PurposeUpgrade {
+		name = fmt.Sprintf("%s-%s-%s-to-%s", doguResource.Name, purpose,
+			nameOfVersion(doguResource.Status.InstalledVersion), nameOfVersion(doguResource.Spec.Version))
+	}
+	if uid := string(doguResource.UID); uid != "" {
+		name = fmt.Sprintf("%s-%s", name, strings.Split(uid, "-")[0])
+	}
+
+	return name
+}
+
+// nameOfVersion replaces the characters of the version which are not allowed in the names of kubernetes resources.
+func nameOfVersion(version string) string {
+	return invalidNameCharsRegex.ReplaceAllString(strings.ToLower(version), "-")
+}
+
+// State describes a volume snapshot.
+type State struct {
+	// Name is the name of the volume snapshot.
+	Name string
+	// DoguVersion is the installed version of the dogu at the time of the volume snapshot.
+	DoguVersion string
+	// ReadyToUse is true if the volume snapshot can be used to restore a volume.
+	ReadyToUse bool
+	// Error contains the message of the CSI snapshot controller if the volume snapshot failed.
+	Error string
+	// RestoreSize is the minimum size of a volume restored from the volume snapshot, if known.
+	RestoreSize *resource.Quantity
+	// CreatedAt is the creation time of the volume snapshot.
+	CreatedAt time.Time
+}
+
+// NewCondition creates the VolumeSnapshotReady condition for the volume snapshot.
+func NewCondition(state *State, purpose Purpose, generation int64) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               ConditionVolumeSnapshotReady,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonSnapshotPending,
+		Message:            fmt.Sprintf("Waiting for volume snapshot %q before the %s to become ready to use", state.Name, purpose),
+		ObservedGeneration: generation,
+	}
+
+	switch {
+	case state.Error != "":
+		condition.Reason = ReasonSnapshotFailed
+		condition.Message = fmt.Sprintf("Volume snapshot %q before the %s failed: %s", state.Name, purpose, state.Error)
+	case state.ReadyToUse:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = ReasonSnapshotReady
+		condition.Message = fmt.Sprintf("Volume snapshot %q of version %s before the %s is ready to use", state.Name, state.DoguVersion, purpose)
+	}
+
+	return condition
+}
+
+func newVolumeSnapshot(doguResource *v2.Dogu, purpose Purpose, className string) *unstructured.Unstructured {
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(GroupVersionKind)
+	snapshot.SetName(NameFor(doguResource, purpose))
+	snapshot.SetNamespace(doguResource.Namespace)
+	// the volume snapshot has no owner reference, so that it outlives the deletion of the dogu
+	snapshot.SetLabels(map[string]string{
+		"app":        "ces",
+		DoguLabel:    doguResource.Name,
+		PurposeLabel: string(purpose),
+	})
+	snapshot.SetAnnotations(map[string]string{VersionAnnotation: doguResource.Status.InstalledVersion})
+
+	spec := map[string]interface{}{
+		"source": map[string]interface{}{
+			"persistentVolumeClaimName": doguResource.Name,
+		},
+	}
+	if className != "" {
+		spec["volumeSnapshotClassName"] = className
+	}
+	snapshot.Object["spec"] = spec
+
+	return snapshot
+}
+
+func stateOf(snapshot *unstructured.Unstructured) *State {
+	state := &State{
+		Name:        snapshot.GetName(),
+		DoguVersion: snapshot.GetAnnotations()[VersionAnnotation],
+		CreatedAt:   snapshot.GetCreationTimestamp().Time,
+	}
+
+	state.ReadyToUse, _, _ = unstructured.NestedBool(snapshot.Object, "status", "readyToUse")
+	state.Error, _, _ = unstructured.NestedString(snapshot.Object, "status", "error", "message")
+
+	restoreSize, found, _ := unstructured.NestedString(snapshot.Object, "status", "restoreSize")
+	if quantity, err := resource.ParseQuantity(restoreSize); found && err == nil {
+		state.RestoreSize = &quantity
+	}
+
+	return state
+}
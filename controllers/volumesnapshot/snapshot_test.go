@@ -0,0 +1,141 @@
+package volumesnapshot
+
+import (
+	"testing"
+	"time"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func getTestDogu() *v2.Dogu {
+	return &v2.Dogu{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "ldap",
+			Namespace:   "ecosystem",
+			UID:         "0c8a2d4e-1f3b-4c5d-8e9f-a0b1c2d3e4f5",
+			Generation:  4,
+			Annotations: map[string]string{Annotation: "true"},
+		},
+		Spec:   v2.DoguSpec{Name: "official/ldap", Version: "2.6.8-1"},
+		Status: v2.DoguStatus{InstalledVersion: "2.6.7-1"},
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	assert.True(t, Enabled(getTestDogu()))
+	assert.False(t, Enabled(&v2.Dogu{}))
+	assert.False(t, Enabled(&v2.Dogu{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{Annotation: "false"}}}))
+}
+
+func TestNameFor(t *testing.T) {
+	t.Run("should contain dogu, purpose, versions of the upgrade and uid prefix", func(t *testing.T) {
+		assert.Equal(t, "ldap-upgrade-2.6.7-1-to-2.6.8-1-0c8a2d4e", NameFor(getTestDogu(), PurposeUpgrade))
+	})
+	t.Run("should keep the name of the upgrade if the generation changes", func(t *testing.T) {
+		doguResource := getTestDogu()
+		doguResource.Generation = 5
+
+		assert.Equal(t, NameFor(getTestDogu(), PurposeUpgrade), NameFor(doguResource, PurposeUpgrade))
+	})
+	t.Run("should replace invalid characters of versions", func(t *testing.T) {
+		doguResource := getTestDogu()
+		doguResource.Spec.Version = "2.6.8-1+Build"
+
+		assert.Equal(t, "ldap-upgrade-2.6.7-1-to-2.6.8-1-build-0c8a2d4e", NameFor(doguResource, PurposeUpgrade))
+	})
+	t.Run("should contain dogu, purpose, generation and uid prefix for deletions", func(t *testing.T) {
+		assert.Equal(t, "ldap-deletion-4-0c8a2d4e", NameFor(getTestDogu(), PurposeDeletion))
+	})
+	t.Run("should omit missing uid", func(t *testing.T) {
+		doguResource := getTestDogu()
+		doguResource.UID = ""
+
+		assert.Equal(t, "ldap-deletion-4", NameFor(doguResource, PurposeDeletion))
+	})
+}
+
+func TestNewCondition(t *testing.T) {
+	t.Run("should be pending", func(t *testing.T) {
+		// when
+		condition := NewCondition(&State{Name: "ldap-upgrade-4"}, PurposeUpgrade, 4)
+
+		// then
+		assert.Equal(t, ConditionVolumeSnapshotReady, condition.Type)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, ReasonSnapshotPending, condition.Reason)
+		assert.Equal(t, `Waiting for volume snapshot "ldap-upgrade-4" before the upgrade to become ready to use`, condition.Message)
+		assert.Equal(t, int64(4), condition.ObservedGeneration)
+	})
+	t.Run("should be ready", func(t *testing.T) {
+		// when
+		condition := NewCondition(&State{Name: "ldap-deletion-4", DoguVersion: "2.6.7-1", ReadyToUse: true}, PurposeDeletion, 4)
+
+		// then
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, ReasonSnapshotReady, condition.Reason)
+		assert.Equal(t, `Volume snapshot "ldap-deletion-4" of version 2.6.7-1 before the deletion is ready to use`, condition.Message)
+	})
+	t.Run("should be failed", func(t *testing.T) {
+		// when
+		condition := NewCondition(&State{Name: "ldap-upgrade-4", Error: "no space left"}, PurposeUpgrade, 4)
+
+		// then
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, ReasonSnapshotFailed, condition.Reason)
+		assert.Equal(t, `Volume snapshot "ldap-upgrade-4" before the upgrade failed: no space left`, condition.Message)
+	})
+}
+
+func Test_newVolumeSnapshot(t *testing.T) {
+	t.Run("should create volume snapshot of data volume", func(t *testing.T) {
+		// when
+		snapshot := newVolumeSnapshot(getTestDogu(), PurposeUpgrade, "csi-snapclass")
+
+		// then
+		assert.Equal(t, GroupVersionKind, snapshot.GroupVersionKind())
+		assert.Equal(t, "ldap-upgrade-2.6.7-1-to-2.6.8-1-0c8a2d4e", snapshot.GetName())
+		assert.Equal(t, "ecosystem", snapshot.GetNamespace())
+		assert.Empty(t, snapshot.GetOwnerReferences())
+		assert.Equal(t, map[string]string{"app": "ces", DoguLabel: "ldap", PurposeLabel: "upgrade"}, snapshot.GetLabels())
+		assert.Equal(t, map[string]string{VersionAnnotation: "2.6.7-1"}, snapshot.GetAnnotations())
+		pvcName, _, _ := unstructured.NestedString(snapshot.Object, "spec", "source", "persistentVolumeClaimName")
+		assert.Equal(t, "ldap", pvcName)
+		className, _, _ := unstructured.NestedString(snapshot.Object, "spec", "volumeSnapshotClassName")
+		assert.Equal(t, "csi-snapclass", className)
+	})
+	t.Run("should use default volume snapshot class", func(t *testing.T) {
+		// when
+		snapshot := newVolumeSnapshot(getTestDogu(), PurposeDeletion, "")
+
+		// then
+		_, found, _ := unstructured.NestedString(snapshot.Object, "spec", "volumeSnapshotClassName")
+		assert.False(t, found)
+	})
+}
+
+func Test_stateOf(t *testing.T) {
+	// given
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	snapshot := newVolumeSnapshot(getTestDogu(), PurposeUpgrade, "")
+	snapshot.SetCreationTimestamp(metav1.NewTime(createdAt))
+	snapshot.Object["status"] = map[string]interface{}{
+		"readyToUse":  true,
+		"restoreSize": "2Gi",
+		"error":       map[string]interface{}{"message": "retrying"},
+	}
+
+	// when
+	state := stateOf(snapshot)
+
+	// then
+	assert.Equal(t, "ldap-upgrade-2.6.7-1-to-2.6.8-1-0c8a2d4e", state.Name)
+	assert.Equal(t, "2.6.7-1", state.DoguVersion)
+	assert.True(t, state.ReadyToUse)
+	assert.Equal(t, "retrying", state.Error)
+	assert.True(t, resource.MustParse("2Gi").Equal(*state.RestoreSize))
+	assert.True(t, createdAt.Equal(state.CreatedAt))
+}
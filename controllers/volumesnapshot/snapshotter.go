@@ -0,0 +1,112 @@
+package volumesnapshot
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type snapshotter struct {
+	client    k8sClient
+	className string
+	retention int
+}
+
+// NewSnapshotter creates a Snapshotter which takes volume snapshots of the class and keeps the number of volume
+// snapshots per dogu configured in the operator config.
+func NewSnapshotter(client client.Client, operatorConfig *config.OperatorConfig) Snapshotter {
+	return &snapshotter{
+		client:    client,
+		className: operatorConfig.VolumeSnapshotClass,
+		retention: operatorConfig.VolumeSnapshotRetention,
+	}
+}
+
+// Take creates a volume snapshot of the data volume of the dogu for the given purpose unless it already exists and
+// returns its state. Once the snapshot is ready to use, the oldest snapshots of the dogu exceeding the retention are
+// deleted. Nil is returned if the dogu has no data volume.
+func (s *snapshotter) Take(ctx context.Context, doguResource *v2.Dogu, purpose Purpose) (*State, error) {
+	pvc := &corev1.PersistentVolumeClaim{}
+	err := s.client.Get(ctx, types.NamespacedName{Namespace: doguResource.Namespace, Name: doguResource.Name}, pvc)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data volume of dogu %q: %w", doguResource.Name, err)
+	}
+
+	state, err := s.Get(ctx, doguResource, NameFor(doguResource, purpose))
+	if apierrors.IsNotFound(err) {
+		snapshot := newVolumeSnapshot(doguResource, purpose, s.className)
+		log.FromContext(ctx).Info("Taking volume snapshot of data volume", "dogu", doguResource.Name, "volumeSnapshot", snapshot.GetName(), "purpose", purpose)
+		err = s.client.Create(ctx, snapshot)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create volume snapshot %q: %w", snapshot.GetName(), err)
+		}
+
+		return stateOf(snapshot), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if state.ReadyToUse {
+		err = s.prune(ctx, doguResource)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return state, nil
+}
+
+// Get returns the state of the volume snapshot with the given name in the namespace of the dogu.
+func (s *snapshotter) Get(ctx context.Context, doguResource *v2.Dogu, name string) (*State, error) {
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(GroupVersionKind)
+	err := s.client.Get(ctx, types.NamespacedName{Namespace: doguResource.Namespace, Name: name}, snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get volume snapshot %q: %w", name, err)
+	}
+
+	return stateOf(snapshot), nil
+}
+
+// prune deletes the oldest volume snapshots of the dogu exceeding the retention.
+func (s *snapshotter) prune(ctx context.Context, doguResource *v2.Dogu) error {
+	snapshots := &unstructured.UnstructuredList{}
+	snapshots.SetGroupVersionKind(GroupVersionKind.GroupVersion().WithKind(GroupVersionKind.Kind + "List"))
+	err := s.client.List(ctx, snapshots, client.InNamespace(doguResource.Namespace), client.MatchingLabels{DoguLabel: doguResource.Name})
+	if err != nil {
+		return fmt.Errorf("failed to list volume snapshots of dogu %q: %w", doguResource.Name, err)
+	}
+
+	// newest first, the name orders volume snapshots created in the same second
+	slices.SortFunc(snapshots.Items, func(a, b unstructured.Unstructured) int {
+		if c := b.GetCreationTimestamp().Compare(a.GetCreationTimestamp().Time); c != 0 {
+			return c
+		}
+		return strings.Compare(b.GetName(), a.GetName())
+	})
+
+	for i := s.retention; i < len(snapshots.Items); i++ {
+		snapshot := &snapshots.Items[i]
+		log.FromContext(ctx).Info("Deleting volume snapshot exceeding the retention", "dogu", doguResource.Name, "volumeSnapshot", snapshot.GetName())
+		err = s.client.Delete(ctx, snapshot)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete volume snapshot %q: %w", snapshot.GetName(), err)
+		}
+	}
+
+	return nil
+}
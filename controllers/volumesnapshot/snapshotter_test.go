@@ -0,0 +1,212 @@
+package volumesnapshot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var testCtx = context.Background()
+
+func getTestScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	scheme.AddKnownTypeWithName(GroupVersionKind, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(GroupVersionKind.GroupVersion().WithKind(GroupVersionKind.Kind+"List"), &unstructured.UnstructuredList{})
+	return scheme
+}
+
+func getTestPVC() *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: "ecosystem"}}
+}
+
+func newTestVolumeSnapshot(name string, createdAt time.Time, readyToUse bool) *unstructured.Unstructured {
+	snapshot := newVolumeSnapshot(getTestDogu(), PurposeUpgrade, "")
+	snapshot.SetName(name)
+	snapshot.SetCreationTimestamp(metav1.NewTime(createdAt))
+	snapshot.Object["status"] = map[string]interface{}{"readyToUse": readyToUse}
+	return snapshot
+}
+
+func listVolumeSnapshotNames(t *testing.T, k8sClient client.Client) []string {
+	snapshots := &unstructured.UnstructuredList{}
+	snapshots.SetGroupVersionKind(GroupVersionKind.GroupVersion().WithKind(GroupVersionKind.Kind + "List"))
+	require.NoError(t, k8sClient.List(testCtx, snapshots))
+
+	var names []string
+	for _, snapshot := range snapshots.Items {
+		names = append(names, snapshot.GetName())
+	}
+	return names
+}
+
+func TestNewSnapshotter(t *testing.T) {
+	// when
+	sut := NewSnapshotter(newMockK8sClient(t), &config.OperatorConfig{VolumeSnapshotClass: "csi-snapclass", VolumeSnapshotRetention: 2})
+
+	// then
+	require.NotNil(t, sut)
+	assert.Equal(t, "csi-snapclass", sut.(*snapshotter).className)
+	assert.Equal(t, 2, sut.(*snapshotter).retention)
+}
+
+func Test_snapshotter_Take(t *testing.T) {
+	t.Run("should return nil if dogu has no data volume", func(t *testing.T) {
+		// given
+		k8sClient := fake.NewClientBuilder().WithScheme(getTestScheme(t)).Build()
+		sut := &snapshotter{client: k8sClient, retention: 3}
+
+		// when
+		state, err := sut.Take(testCtx, getTestDogu(), PurposeUpgrade)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, state)
+	})
+	t.Run("should fail to get data volume", func(t *testing.T) {
+		// given
+		k8sClient := newMockK8sClient(t)
+		k8sClient.EXPECT().Get(testCtx, types.NamespacedName{Namespace: "ecosystem", Name: "ldap"}, mock.Anything).Return(assert.AnError)
+		sut := &snapshotter{client: k8sClient, retention: 3}
+
+		// when
+		_, err := sut.Take(testCtx, getTestDogu(), PurposeUpgrade)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get data volume of dogu \"ldap\"")
+	})
+	t.Run("should create volume snapshot", func(t *testing.T) {
+		// given
+		k8sClient := fake.NewClientBuilder().WithScheme(getTestScheme(t)).WithObjects(getTestPVC()).Build()
+		sut := &snapshotter{client: k8sClient, className: "csi-snapclass", retention: 3}
+
+		// when
+		state, err := sut.Take(testCtx, getTestDogu(), PurposeUpgrade)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "ldap-upgrade-2.6.7-1-to-2.6.8-1-0c8a2d4e", state.Name)
+		assert.Equal(t, "2.6.7-1", state.DoguVersion)
+		assert.False(t, state.ReadyToUse)
+		assert.Equal(t, []string{"ldap-upgrade-2.6.7-1-to-2.6.8-1-0c8a2d4e"}, listVolumeSnapshotNames(t, k8sClient))
+	})
+	t.Run("should fail to create volume snapshot", func(t *testing.T) {
+		// given
+		k8sClient := newMockK8sClient(t)
+		k8sClient.EXPECT().Get(testCtx, types.NamespacedName{Namespace: "ecosystem", Name: "ldap"}, mock.Anything).Return(nil)
+		k8sClient.EXPECT().Get(testCtx, types.NamespacedName{Namespace: "ecosystem", Name: "ldap-upgrade-2.6.7-1-to-2.6.8-1-0c8a2d4e"}, mock.Anything).
+			Return(apierrors.NewNotFound(GroupVersionKind.GroupVersion().WithResource("volumesnapshots").GroupResource(), "ldap-upgrade-2.6.7-1-to-2.6.8-1-0c8a2d4e"))
+		k8sClient.EXPECT().Create(testCtx, mock.Anything).Return(assert.AnError)
+		sut := &snapshotter{client: k8sClient, retention: 3}
+
+		// when
+		_, err := sut.Take(testCtx, getTestDogu(), PurposeUpgrade)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to create volume snapshot \"ldap-upgrade-2.6.7-1-to-2.6.8-1-0c8a2d4e\"")
+	})
+	t.Run("should return pending volume snapshot without pruning", func(t *testing.T) {
+		// given
+		now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		k8sClient := fake.NewClientBuilder().WithScheme(getTestScheme(t)).WithObjects(
+			getTestPVC(),
+			newTestVolumeSnapshot("ldap-upgrade-2.6.7-1-to-2.6.8-1-0c8a2d4e", now, false),
+			newTestVolumeSnapshot("ldap-upgrade-3-0c8a2d4e", now.Add(-time.Hour), true),
+		).Build()
+		sut := &snapshotter{client: k8sClient, retention: 1}
+
+		// when
+		state, err := sut.Take(testCtx, getTestDogu(), PurposeUpgrade)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, state.ReadyToUse)
+		assert.Len(t, listVolumeSnapshotNames(t, k8sClient), 2)
+	})
+	t.Run("should prune oldest volume snapshots of the dogu once the volume snapshot is ready", func(t *testing.T) {
+		// given
+		now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		otherDoguSnapshot := newTestVolumeSnapshot("cas-upgrade-1", now.Add(-3*time.Hour), true)
+		otherDoguSnapshot.SetLabels(map[string]string{DoguLabel: "cas"})
+		k8sClient := fake.NewClientBuilder().WithScheme(getTestScheme(t)).WithObjects(
+			getTestPVC(),
+			newTestVolumeSnapshot("ldap-upgrade-2.6.7-1-to-2.6.8-1-0c8a2d4e", now, true),
+			newTestVolumeSnapshot("ldap-upgrade-3-0c8a2d4e", now.Add(-time.Hour), true),
+			newTestVolumeSnapshot("ldap-upgrade-2-0c8a2d4e", now.Add(-2*time.Hour), true),
+			otherDoguSnapshot,
+		).Build()
+		sut := &snapshotter{client: k8sClient, retention: 2}
+
+		// when
+		state, err := sut.Take(testCtx, getTestDogu(), PurposeUpgrade)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, state.ReadyToUse)
+		assert.ElementsMatch(t, []string{"ldap-upgrade-2.6.7-1-to-2.6.8-1-0c8a2d4e", "ldap-upgrade-3-0c8a2d4e", "cas-upgrade-1"}, listVolumeSnapshotNames(t, k8sClient))
+	})
+	t.Run("should fail to list volume snapshots to prune", func(t *testing.T) {
+		// given
+		k8sClient := newMockK8sClient(t)
+		k8sClient.EXPECT().Get(testCtx, types.NamespacedName{Namespace: "ecosystem", Name: "ldap"}, mock.Anything).Return(nil)
+		k8sClient.EXPECT().Get(testCtx, types.NamespacedName{Namespace: "ecosystem", Name: "ldap-upgrade-2.6.7-1-to-2.6.8-1-0c8a2d4e"}, mock.Anything).
+			RunAndReturn(func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+				obj.(*unstructured.Unstructured).Object["status"] = map[string]interface{}{"readyToUse": true}
+				return nil
+			})
+		k8sClient.EXPECT().List(testCtx, mock.Anything, mock.Anything, mock.Anything).Return(assert.AnError)
+		sut := &snapshotter{client: k8sClient, retention: 3}
+
+		// when
+		_, err := sut.Take(testCtx, getTestDogu(), PurposeUpgrade)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to list volume snapshots of dogu \"ldap\"")
+	})
+}
+
+func Test_snapshotter_Get(t *testing.T) {
+	t.Run("should get volume snapshot", func(t *testing.T) {
+		// given
+		k8sClient := fake.NewClientBuilder().WithScheme(getTestScheme(t)).
+			WithObjects(newTestVolumeSnapshot("ldap-deletion-4-0c8a2d4e", time.Now(), true)).Build()
+		sut := &snapshotter{client: k8sClient, retention: 3}
+
+		// when
+		state, err := sut.Get(testCtx, getTestDogu(), "ldap-deletion-4-0c8a2d4e")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "ldap-deletion-4-0c8a2d4e", state.Name)
+		assert.True(t, state.ReadyToUse)
+	})
+	t.Run("should return not found error", func(t *testing.T) {
+		// given
+		k8sClient := fake.NewClientBuilder().WithScheme(getTestScheme(t)).Build()
+		sut := &snapshotter{client: k8sClient, retention: 3}
+
+		// when
+		_, err := sut.Get(testCtx, getTestDogu(), "ldap-deletion-4-0c8a2d4e")
+
+		// then
+		assert.True(t, apierrors.IsNotFound(err))
+		assert.ErrorContains(t, err, "failed to get volume snapshot \"ldap-deletion-4-0c8a2d4e\"")
+	})
+}
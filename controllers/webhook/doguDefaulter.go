@@ -0,0 +1,169 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cescommons "github.com/cloudogu/ces-commons-lib/dogu"
+	cloudoguerrors "github.com/cloudogu/ces-commons-lib/errors"
+	"github.com/cloudogu/cesapp-lib/core"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/namespaced"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/upgrade"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// remoteDescriptorTimeout limits how long the defaulting webhook waits for the remote dogu registry, so that the
+// admission is answered well within the timeout of the webhook configuration.
+const remoteDescriptorTimeout = 5 * time.Second
+
+// DoguDefaulter writes the effective defaults into the spec of new dogu resources, so that the values the operator
+// works with are visible in the resource and GitOps tools do not report a permanent diff.
+// Existing dogu resources are left untouched, because a changed default would otherwise alter running dogus.
+type DoguDefaulter struct {
+	localDoguDescriptorRepo  localDoguDescriptorRepository
+	remoteDoguDescriptorRepo remoteDoguDescriptorRepository
+	dataVolumeSize           resource.Quantity
+	storageClass             string
+	seccompProfile           string
+	upgradeDeadlines         upgrade.PhaseDeadlines
+}
+
+// NewDoguDefaulter creates the defaulting webhook for dogu resources and registers it at the webhook server of the
+// manager if webhooks are enabled.
+func NewDoguDefaulter(
+	mgr manager.Manager,
+	operatorConfig *config.OperatorConfig,
+	localDoguDescriptorRepo cescommons.LocalDoguDescriptorRepository,
+	remoteDoguDescriptorRepo cescommons.RemoteDoguDescriptorRepository,
+	upgradeDeadlines upgrade.PhaseDeadlines,
+) (*DoguDefaulter, error) {
+	d := &DoguDefaulter{
+		localDoguDescriptorRepo:  localDoguDescriptorRepo,
+		remoteDoguDescriptorRepo: remoteDoguDescriptorRepo,
+		dataVolumeSize:           operatorConfig.DefaultDataVolumeSize,
+		storageClass:             operatorConfig.DefaultStorageClass,
+		seccompProfile:           operatorConfig.DefaultSeccompProfile,
+		upgradeDeadlines:         upgradeDeadlines,
+	}
+	if !operatorConfig.WebhooksEnabled {
+		return d, nil
+	}
+
+	err := ctrl.NewWebhookManagedBy(mgr, &v2.Dogu{}).WithDefaulter(d).Complete()
+	if err != nil {
+		return nil, fmt.Errorf("failed to register defaulting webhook for dogus: %w", err)
+	}
+
+	return d, nil
+}
+
+// Default sets all unset fields of a new dogu resource which the operator would otherwise default implicitly.
+// Defaults which depend on the dogu descriptor use the local descriptor and fall back to the remote dogu registry,
+// because the descriptor of a new dogu is usually not stored locally yet. They are skipped if neither is available.
+func (d *DoguDefaulter) Default(ctx context.Context, doguResource *v2.Dogu) error {
+	req, err := admission.RequestFromContext(ctx)
+	if err == nil && req.Operation != admissionv1.Create {
+		return nil
+	}
+
+	ctx = namespaced.WithNamespace(ctx, doguResource.Namespace)
+	d.defaultResources(doguResource)
+	d.defaultSecurity(ctx, doguResource)
+	d.defaultUpgradeDeadlines(doguResource)
+
+	return nil
+}
+
+func (d *DoguDefaulter) defaultResources(doguResource *v2.Dogu) {
+	resources := &doguResource.Spec.Resources
+	if resources.DataVolumeSize == "" && resources.MinDataVolumeSize.IsZero() && !d.dataVolumeSize.IsZero() {
+		resources.MinDataVolumeSize = d.dataVolumeSize.DeepCopy()
+	}
+
+	if resources.StorageClassName == nil && d.storageClass != "" {
+		resources.StorageClassName = ptr.To(d.storageClass)
+	}
+}
+
+func (d *DoguDefaulter) defaultSecurity(ctx context.Context, doguResource *v2.Dogu) {
+	security := &doguResource.Spec.Security
+	if security.SeccompProfile == nil && d.seccompProfile != "" {
+		security.SeccompProfile = &v2.SeccompProfile{Type: v2.SeccompProfileType(d.seccompProfile)}
+	}
+
+	if security.RunAsNonRoot != nil && security.ReadOnlyRootFileSystem != nil {
+		return
+	}
+
+	doguDescriptor, err := d.fetchDescriptor(ctx, doguResource)
+	if err != nil {
+		log.FromContext(ctx).Info("Skipping security defaults of the dogu descriptor", "dogu", doguResource.Name, "reason", err.Error())
+		return
+	}
+
+	if security.RunAsNonRoot == nil {
+		security.RunAsNonRoot = ptr.To(doguDescriptor.Security.RunAsNonRoot)
+	}
+	if security.ReadOnlyRootFileSystem == nil {
+		security.ReadOnlyRootFileSystem = ptr.To(doguDescriptor.Security.ReadOnlyRootFileSystem)
+	}
+}
+
+// defaultUpgradeDeadlines writes the phase deadlines of the operator config into the deadline annotations, which make up
+// the upgrade config of the dogu next to spec.upgradeConfig. The flags of spec.upgradeConfig default to false, which
+// the API server does not store, so there is nothing to write for them.
+func (d *DoguDefaulter) defaultUpgradeDeadlines(doguResource *v2.Dogu) {
+	deadlines := map[string]time.Duration{
+		upgrade.PreUpgradeDeadlineAnnotation:  d.upgradeDeadlines.PreUpgrade,
+		upgrade.RolloutDeadlineAnnotation:     d.upgradeDeadlines.Rollout,
+		upgrade.PostUpgradeDeadlineAnnotation: d.upgradeDeadlines.PostUpgrade,
+	}
+
+	annotations := doguResource.GetAnnotations()
+	for annotation, deadline := range deadlines {
+		if _, set := annotations[annotation]; set || deadline <= 0 {
+			continue
+		}
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[annotation] = deadline.String()
+	}
+	doguResource.SetAnnotations(annotations)
+}
+
+func (d *DoguDefaulter) fetchDescriptor(ctx context.Context, doguResource *v2.Dogu) (*core.Dogu, error) {
+	version, err := doguResource.GetSimpleNameVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	doguDescriptor, err := d.localDoguDescriptorRepo.Get(ctx, version)
+	if err == nil || !cloudoguerrors.IsNotFoundError(err) {
+		return doguDescriptor, err
+	}
+
+	qualifiedName, err := cescommons.QualifiedNameFromString(doguResource.Spec.Name)
+	if err != nil {
+		return nil, err
+	}
+	qualifiedVersion := cescommons.QualifiedVersion{Name: qualifiedName, Version: version.Version}
+
+	ctx, cancel := context.WithTimeout(ctx, remoteDescriptorTimeout)
+	defer cancel()
+	doguDescriptor, err = d.remoteDoguDescriptorRepo.Get(ctx, qualifiedVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dogu descriptor from remote dogu registry: %w", err)
+	}
+
+	return doguDescriptor, nil
+}
@@ -0,0 +1,202 @@
+package webhook
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	cescommons "github.com/cloudogu/ces-commons-lib/dogu"
+	cloudoguerrors "github.com/cloudogu/ces-commons-lib/errors"
+	"github.com/cloudogu/cesapp-lib/core"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/namespaced"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/upgrade"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestNewDoguDefaulter(t *testing.T) {
+	t.Run("should not register webhook if webhooks are disabled", func(t *testing.T) {
+		// when
+		sut, err := NewDoguDefaulter(newMockCtrlManager(t), &config.OperatorConfig{}, nil, nil, upgrade.PhaseDeadlines{})
+
+		// then
+		require.NoError(t, err)
+		assert.NotNil(t, sut)
+	})
+	t.Run("should register webhook if webhooks are enabled", func(t *testing.T) {
+		// given
+		webhookServer := ctrlwebhook.NewServer(ctrlwebhook.Options{})
+		managerMock := newMockCtrlManager(t)
+		managerMock.EXPECT().GetConfig().Return(&rest.Config{})
+		managerMock.EXPECT().GetScheme().Return(getTestScheme(t))
+		managerMock.EXPECT().GetWebhookServer().Return(webhookServer)
+
+		// when
+		sut, err := NewDoguDefaulter(managerMock, &config.OperatorConfig{WebhooksEnabled: true}, nil, nil, upgrade.PhaseDeadlines{})
+
+		// then
+		require.NoError(t, err)
+		assert.NotNil(t, sut)
+		_, pattern := webhookServer.WebhookMux().Handler(&http.Request{URL: &url.URL{Path: "/mutate-k8s-cloudogu-com-v2-dogu"}})
+		assert.Equal(t, "/mutate-k8s-cloudogu-com-v2-dogu", pattern)
+	})
+}
+
+func TestDoguDefaulter_Default(t *testing.T) {
+	ctx := namespaced.WithNamespace(testCtx, "ecosystem")
+	version, _ := newTestDoguResource("2.6.8-1").GetSimpleNameVersion()
+	descriptor := &core.Dogu{Name: "official/ldap", Version: "2.6.8-1", Security: core.Security{RunAsNonRoot: true}}
+	qualifiedVersion := cescommons.QualifiedVersion{Name: cescommons.QualifiedName{Namespace: "official", SimpleName: "ldap"}, Version: version.Version}
+
+	t.Run("should write defaults of operator config and dogu descriptor", func(t *testing.T) {
+		// given
+		doguResource := newTestDoguResource("2.6.8-1")
+		repoMock := newMockLocalDoguDescriptorRepository(t)
+		repoMock.EXPECT().Get(ctx, version).Return(descriptor, nil)
+		sut := &DoguDefaulter{
+			localDoguDescriptorRepo: repoMock,
+			dataVolumeSize:          resource.MustParse("5Gi"),
+			storageClass:            "longhorn",
+			seccompProfile:          "RuntimeDefault",
+		}
+
+		// when
+		err := sut.Default(testCtx, doguResource)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, resource.MustParse("5Gi"), doguResource.Spec.Resources.MinDataVolumeSize)
+		assert.Equal(t, ptr.To("longhorn"), doguResource.Spec.Resources.StorageClassName)
+		assert.Equal(t, &v2.SeccompProfile{Type: "RuntimeDefault"}, doguResource.Spec.Security.SeccompProfile)
+		assert.Equal(t, ptr.To(true), doguResource.Spec.Security.RunAsNonRoot)
+		assert.Equal(t, ptr.To(false), doguResource.Spec.Security.ReadOnlyRootFileSystem)
+	})
+	t.Run("should keep values set by the user", func(t *testing.T) {
+		// given
+		doguResource := newTestDoguResource("2.6.8-1")
+		doguResource.Spec.Resources.DataVolumeSize = "1Gi"
+		doguResource.Spec.Resources.StorageClassName = ptr.To("standard")
+		doguResource.Spec.Security.SeccompProfile = &v2.SeccompProfile{Type: "Unconfined"}
+		doguResource.Spec.Security.RunAsNonRoot = ptr.To(false)
+		doguResource.Spec.Security.ReadOnlyRootFileSystem = ptr.To(true)
+		expectedSpec := doguResource.DeepCopy().Spec
+		sut := &DoguDefaulter{
+			dataVolumeSize: resource.MustParse("5Gi"),
+			storageClass:   "longhorn",
+			seccompProfile: "RuntimeDefault",
+		}
+
+		// when
+		err := sut.Default(testCtx, doguResource)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, expectedSpec, doguResource.Spec)
+	})
+	t.Run("should fetch descriptor from remote registry if it is not stored locally", func(t *testing.T) {
+		// given
+		doguResource := newTestDoguResource("2.6.8-1")
+		localRepoMock := newMockLocalDoguDescriptorRepository(t)
+		localRepoMock.EXPECT().Get(ctx, version).Return(nil, cloudoguerrors.NewNotFoundError(assert.AnError))
+		remoteRepoMock := newMockRemoteDoguDescriptorRepository(t)
+		remoteRepoMock.EXPECT().Get(mock.Anything, qualifiedVersion).Return(descriptor, nil)
+		sut := &DoguDefaulter{localDoguDescriptorRepo: localRepoMock, remoteDoguDescriptorRepo: remoteRepoMock}
+
+		// when
+		err := sut.Default(testCtx, doguResource)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ptr.To(true), doguResource.Spec.Security.RunAsNonRoot)
+		assert.Equal(t, ptr.To(false), doguResource.Spec.Security.ReadOnlyRootFileSystem)
+	})
+	t.Run("should skip security defaults if descriptor is neither stored locally nor available remotely", func(t *testing.T) {
+		// given
+		doguResource := newTestDoguResource("2.6.8-1")
+		localRepoMock := newMockLocalDoguDescriptorRepository(t)
+		localRepoMock.EXPECT().Get(ctx, version).Return(nil, cloudoguerrors.NewNotFoundError(assert.AnError))
+		remoteRepoMock := newMockRemoteDoguDescriptorRepository(t)
+		remoteRepoMock.EXPECT().Get(mock.Anything, qualifiedVersion).Return(nil, assert.AnError)
+		sut := &DoguDefaulter{localDoguDescriptorRepo: localRepoMock, remoteDoguDescriptorRepo: remoteRepoMock}
+
+		// when
+		err := sut.Default(testCtx, doguResource)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, doguResource.Spec.Security.RunAsNonRoot)
+		assert.Nil(t, doguResource.Spec.Security.ReadOnlyRootFileSystem)
+	})
+	t.Run("should skip security defaults if descriptor is unavailable", func(t *testing.T) {
+		// given
+		doguResource := newTestDoguResource("2.6.8-1")
+		repoMock := newMockLocalDoguDescriptorRepository(t)
+		repoMock.EXPECT().Get(ctx, version).Return(nil, assert.AnError)
+		sut := &DoguDefaulter{localDoguDescriptorRepo: repoMock, dataVolumeSize: resource.MustParse("2Gi")}
+
+		// when
+		err := sut.Default(testCtx, doguResource)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, resource.MustParse("2Gi"), doguResource.Spec.Resources.MinDataVolumeSize)
+		assert.Nil(t, doguResource.Spec.Security.RunAsNonRoot)
+		assert.Nil(t, doguResource.Spec.Security.ReadOnlyRootFileSystem)
+	})
+	t.Run("should skip security defaults if version is invalid", func(t *testing.T) {
+		// given
+		doguResource := newTestDoguResource("latest")
+		sut := &DoguDefaulter{}
+
+		// when
+		err := sut.Default(testCtx, doguResource)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, doguResource.Spec.Security.RunAsNonRoot)
+	})
+	t.Run("should write upgrade deadlines of operator config", func(t *testing.T) {
+		// given
+		doguResource := newTestDoguResource("2.6.8-1")
+		doguResource.Annotations = map[string]string{upgrade.RolloutDeadlineAnnotation: "5h"}
+		doguResource.Spec.Security.RunAsNonRoot = ptr.To(true)
+		doguResource.Spec.Security.ReadOnlyRootFileSystem = ptr.To(false)
+		sut := &DoguDefaulter{upgradeDeadlines: upgrade.PhaseDeadlines{PreUpgrade: 30 * time.Minute, Rollout: 3 * time.Hour, PostUpgrade: time.Hour}}
+
+		// when
+		err := sut.Default(testCtx, doguResource)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			upgrade.PreUpgradeDeadlineAnnotation:  "30m0s",
+			upgrade.RolloutDeadlineAnnotation:     "5h",
+			upgrade.PostUpgradeDeadlineAnnotation: "1h0m0s",
+		}, doguResource.Annotations)
+	})
+	t.Run("should not default on update", func(t *testing.T) {
+		// given
+		doguResource := newTestDoguResource("2.6.8-1")
+		updateCtx := admission.NewContextWithRequest(testCtx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Update}})
+		sut := &DoguDefaulter{dataVolumeSize: resource.MustParse("2Gi"), storageClass: "longhorn", upgradeDeadlines: upgrade.PhaseDeadlines{Rollout: 3 * time.Hour}}
+
+		// when
+		err := sut.Default(updateCtx, doguResource)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, doguResource.Spec.Resources.MinDataVolumeSize.IsZero())
+		assert.Nil(t, doguResource.Spec.Resources.StorageClassName)
+		assert.Empty(t, doguResource.Annotations)
+	})
+}
@@ -0,0 +1,194 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	cescommons "github.com/cloudogu/ces-commons-lib/dogu"
+	cloudoguerrors "github.com/cloudogu/ces-commons-lib/errors"
+	"github.com/cloudogu/cesapp-lib/core"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/additionalMount"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/cesregistry"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/namespaced"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/security"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var doguGroupKind = schema.GroupKind{Group: v2.GroupVersion.Group, Kind: "Dogu"}
+
+var (
+	versionPath          = field.NewPath("spec", "version")
+	forceUpgradePath     = field.NewPath("spec", "upgradeConfig", "forceUpgrade")
+	dataVolumeSizePath   = field.NewPath("spec", "resources", "dataVolumeSize")
+	securityPath         = field.NewPath("spec", "security")
+	additionalMountsPath = field.NewPath("spec", "additionalMounts")
+)
+
+// DoguValidator rejects dogu resources at admission time whose spec the install.ValidationStep would reject anyway,
+// so that users get the error directly from the API server instead of a dogu which is requeued again and again.
+// Checks which need the state of the cluster, like healthy dependencies or existing sources of additional mounts, are
+// left to the reconcile. The webhook only uses dogu descriptors which are stored locally, so that an admission never
+// waits for the remote dogu registry; for a version which is not stored locally yet, only the checks against the
+// descriptor are left to the reconcile.
+type DoguValidator struct {
+	localDoguFetcher        localDoguFetcher
+	localDoguDescriptorRepo localDoguDescriptorRepository
+	securityValidator       securityValidator
+}
+
+// NewDoguValidator creates the validating webhook for dogu resources and registers it at the webhook server of the
+// manager if webhooks are enabled.
+func NewDoguValidator(
+	mgr manager.Manager,
+	operatorConfig *config.OperatorConfig,
+	localDoguFetcher cesregistry.LocalDoguFetcher,
+	localDoguDescriptorRepo cescommons.LocalDoguDescriptorRepository,
+	securityValidator security.Validator,
+) (*DoguValidator, error) {
+	v := &DoguValidator{
+		localDoguFetcher:        localDoguFetcher,
+		localDoguDescriptorRepo: localDoguDescriptorRepo,
+		securityValidator:       securityValidator,
+	}
+	if !operatorConfig.WebhooksEnabled {
+		return v, nil
+	}
+
+	err := ctrl.NewWebhookManagedBy(mgr, &v2.Dogu{}).WithValidator(v).Complete()
+	if err != nil {
+		return nil, fmt.Errorf("failed to register validating webhook for dogus: %w", err)
+	}
+
+	return v, nil
+}
+
+// ValidateCreate validates a new dogu resource.
+func (v *DoguValidator) ValidateCreate(ctx context.Context, doguResource *v2.Dogu) (admission.Warnings, error) {
+	return v.validate(ctx, doguResource)
+}
+
+// ValidateUpdate validates a changed dogu resource.
+// Updates which leave the spec untouched, e.g. of finalizers or annotations, are always admitted.
+func (v *DoguValidator) ValidateUpdate(ctx context.Context, oldDoguResource, newDoguResource *v2.Dogu) (admission.Warnings, error) {
+	if !newDoguResource.GetDeletionTimestamp().IsZero() || apiequality.Semantic.DeepEqual(oldDoguResource.Spec, newDoguResource.Spec) {
+		return nil, nil
+	}
+
+	return v.validate(ctx, newDoguResource)
+}
+
+// ValidateDelete admits every deletion of a dogu resource.
+func (v *DoguValidator) ValidateDelete(_ context.Context, _ *v2.Dogu) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *DoguValidator) validate(ctx context.Context, doguResource *v2.Dogu) (admission.Warnings, error) {
+	ctx = namespaced.WithNamespace(ctx, doguResource.Namespace)
+	var warnings admission.Warnings
+	errs := validateSpec(doguResource)
+
+	version, err := doguResource.GetSimpleNameVersion()
+	if err != nil {
+		errs = append(errs, field.Invalid(versionPath, doguResource.Spec.Version, err.Error()))
+		return warnings, toInvalidError(doguResource, errs)
+	}
+
+	downgradeErrs, err := v.validateNoDowngrade(ctx, doguResource)
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("skipped downgrade check: %s", err))
+	}
+	errs = append(errs, downgradeErrs...)
+
+	doguDescriptor, err := v.localDoguDescriptorRepo.Get(ctx, version)
+	if cloudoguerrors.IsNotFoundError(err) {
+		warnings = append(warnings, fmt.Sprintf("skipped validation against the dogu descriptor: version %s is not stored locally yet and is validated by the reconcile", doguResource.Spec.Version))
+		return warnings, toInvalidError(doguResource, errs)
+	} else if err != nil {
+		warnings = append(warnings, fmt.Sprintf("skipped validation against the dogu descriptor: %s", err))
+		return warnings, toInvalidError(doguResource, errs)
+	}
+
+	return warnings, toInvalidError(doguResource, append(errs, v.validateAgainstDescriptor(doguDescriptor, doguResource)...))
+}
+
+// validateSpec runs the checks which only need the spec of the dogu resource. They run for every dogu resource,
+// whether its dogu descriptor is stored locally or not.
+func validateSpec(doguResource *v2.Dogu) field.ErrorList {
+	var errs field.ErrorList
+
+	_, err := doguResource.GetMinDataVolumeSize()
+	if err != nil {
+		errs = append(errs, field.Invalid(dataVolumeSizePath, doguResource.Spec.Resources.DataVolumeSize, err.Error()))
+	}
+
+	err = doguResource.ValidateSecurity()
+	if err != nil {
+		errs = append(errs, field.Invalid(securityPath, doguResource.Spec.Security, err.Error()))
+	}
+
+	err = additionalMount.ValidateUniqueMounts(doguResource)
+	if err != nil {
+		errs = append(errs, field.Invalid(additionalMountsPath, doguResource.Spec.AdditionalMounts, err.Error()))
+	}
+
+	return errs
+}
+
+// validateAgainstDescriptor runs the checks which need the dogu descriptor: the security fields of the descriptor
+// and the volumes of the additional mounts.
+func (v *DoguValidator) validateAgainstDescriptor(doguDescriptor *core.Dogu, doguResource *v2.Dogu) field.ErrorList {
+	var errs field.ErrorList
+
+	// the security validator checks the security fields of the dogu resource as well; they are only checked again if
+	// validateSpec found them valid, so that their errors are not reported twice
+	if doguResource.ValidateSecurity() == nil {
+		err := v.securityValidator.ValidateSecurity(doguDescriptor, doguResource)
+		if err != nil {
+			errs = append(errs, field.Invalid(securityPath, doguResource.Spec.Security, err.Error()))
+		}
+	}
+
+	err := additionalMount.ValidateVolumes(doguDescriptor, doguResource)
+	if err != nil {
+		errs = append(errs, field.Invalid(additionalMountsPath, doguResource.Spec.AdditionalMounts, err.Error()))
+	}
+
+	return errs
+}
+
+func (v *DoguValidator) validateNoDowngrade(ctx context.Context, doguResource *v2.Dogu) (field.ErrorList, error) {
+	installedDogu, err := v.localDoguFetcher.FetchInstalled(ctx, doguResource.GetSimpleDoguName())
+	if cloudoguerrors.IsNotFoundError(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	installedVersion, err := core.ParseVersion(installedDogu.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse installed version %q: %w", installedDogu.Version, err)
+	}
+	// the version has been parsed successfully before
+	newVersion, _ := core.ParseVersion(doguResource.Spec.Version)
+	if newVersion.IsOlderThan(installedVersion) && !doguResource.Spec.UpgradeConfig.ForceUpgrade {
+		return field.ErrorList{field.Forbidden(versionPath, fmt.Sprintf("downgrade from installed version %s is not allowed without %s", installedDogu.Version, forceUpgradePath))}, nil
+	}
+
+	return nil, nil
+}
+
+func toInvalidError(doguResource *v2.Dogu, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(doguGroupKind, doguResource.Name, errs)
+}
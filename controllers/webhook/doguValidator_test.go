@@ -0,0 +1,295 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	cescommons "github.com/cloudogu/ces-commons-lib/dogu"
+	cloudoguerrors "github.com/cloudogu/ces-commons-lib/errors"
+	"github.com/cloudogu/cesapp-lib/core"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/namespaced"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var testCtx = context.Background()
+
+func newTestDoguResource(version string) *v2.Dogu {
+	return &v2.Dogu{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: "ecosystem"},
+		Spec:       v2.DoguSpec{Name: "official/ldap", Version: version},
+	}
+}
+
+func getTestScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v2.AddToScheme(scheme))
+	return scheme
+}
+
+func TestNewDoguValidator(t *testing.T) {
+	t.Run("should not register webhook if webhooks are disabled", func(t *testing.T) {
+		// when
+		sut, err := NewDoguValidator(newMockCtrlManager(t), &config.OperatorConfig{}, nil, nil, nil)
+
+		// then
+		require.NoError(t, err)
+		assert.NotNil(t, sut)
+	})
+	t.Run("should register webhook if webhooks are enabled", func(t *testing.T) {
+		// given
+		webhookServer := ctrlwebhook.NewServer(ctrlwebhook.Options{})
+		managerMock := newMockCtrlManager(t)
+		managerMock.EXPECT().GetConfig().Return(&rest.Config{})
+		managerMock.EXPECT().GetScheme().Return(getTestScheme(t))
+		managerMock.EXPECT().GetWebhookServer().Return(webhookServer)
+
+		// when
+		sut, err := NewDoguValidator(managerMock, &config.OperatorConfig{WebhooksEnabled: true}, nil, nil, nil)
+
+		// then
+		require.NoError(t, err)
+		assert.NotNil(t, sut)
+		_, pattern := webhookServer.WebhookMux().Handler(&http.Request{URL: &url.URL{Path: "/validate-k8s-cloudogu-com-v2-dogu"}})
+		assert.Equal(t, "/validate-k8s-cloudogu-com-v2-dogu", pattern)
+	})
+}
+
+func TestDoguValidator_ValidateCreate(t *testing.T) {
+	ctx := namespaced.WithNamespace(testCtx, "ecosystem")
+	version, _ := newTestDoguResource("2.6.8-1").GetSimpleNameVersion()
+	descriptor := &core.Dogu{Name: "official/ldap", Version: "2.6.8-1"}
+	notFoundErr := cloudoguerrors.NewNotFoundError(assert.AnError)
+
+	t.Run("should admit valid dogu", func(t *testing.T) {
+		// given
+		doguResource := newTestDoguResource("2.6.8-1")
+		fetcherMock := newMockLocalDoguFetcher(t)
+		fetcherMock.EXPECT().FetchInstalled(ctx, cescommons.SimpleName("ldap")).Return(nil, notFoundErr)
+		repoMock := newMockLocalDoguDescriptorRepository(t)
+		repoMock.EXPECT().Get(ctx, version).Return(descriptor, nil)
+		securityMock := newMockSecurityValidator(t)
+		securityMock.EXPECT().ValidateSecurity(descriptor, doguResource).Return(nil)
+		sut := &DoguValidator{localDoguFetcher: fetcherMock, localDoguDescriptorRepo: repoMock, securityValidator: securityMock}
+
+		// when
+		warnings, err := sut.ValidateCreate(testCtx, doguResource)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+	})
+	t.Run("should reject unparsable version and malformed data volume size", func(t *testing.T) {
+		// given
+		doguResource := newTestDoguResource("latest")
+		doguResource.Spec.Resources.DataVolumeSize = "a lot"
+		sut := &DoguValidator{}
+
+		// when
+		_, err := sut.ValidateCreate(testCtx, doguResource)
+
+		// then
+		require.Error(t, err)
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.ErrorContains(t, err, "spec.version: Invalid value: \"latest\"")
+		assert.ErrorContains(t, err, "spec.resources.dataVolumeSize: Invalid value: \"a lot\"")
+	})
+	t.Run("should reject downgrade without force upgrade", func(t *testing.T) {
+		// given
+		doguResource := newTestDoguResource("2.6.8-1")
+		fetcherMock := newMockLocalDoguFetcher(t)
+		fetcherMock.EXPECT().FetchInstalled(ctx, cescommons.SimpleName("ldap")).Return(&core.Dogu{Version: "2.6.9-1"}, nil)
+		repoMock := newMockLocalDoguDescriptorRepository(t)
+		repoMock.EXPECT().Get(ctx, version).Return(descriptor, nil)
+		securityMock := newMockSecurityValidator(t)
+		securityMock.EXPECT().ValidateSecurity(descriptor, doguResource).Return(nil)
+		sut := &DoguValidator{localDoguFetcher: fetcherMock, localDoguDescriptorRepo: repoMock, securityValidator: securityMock}
+
+		// when
+		_, err := sut.ValidateCreate(testCtx, doguResource)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "spec.version: Forbidden: downgrade from installed version 2.6.9-1 is not allowed without spec.upgradeConfig.forceUpgrade")
+	})
+	t.Run("should admit downgrade with force upgrade", func(t *testing.T) {
+		// given
+		doguResource := newTestDoguResource("2.6.8-1")
+		doguResource.Spec.UpgradeConfig.ForceUpgrade = true
+		fetcherMock := newMockLocalDoguFetcher(t)
+		fetcherMock.EXPECT().FetchInstalled(ctx, cescommons.SimpleName("ldap")).Return(&core.Dogu{Version: "2.6.9-1"}, nil)
+		repoMock := newMockLocalDoguDescriptorRepository(t)
+		repoMock.EXPECT().Get(ctx, version).Return(descriptor, nil)
+		securityMock := newMockSecurityValidator(t)
+		securityMock.EXPECT().ValidateSecurity(descriptor, doguResource).Return(nil)
+		sut := &DoguValidator{localDoguFetcher: fetcherMock, localDoguDescriptorRepo: repoMock, securityValidator: securityMock}
+
+		// when
+		_, err := sut.ValidateCreate(testCtx, doguResource)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should reject invalid security fields and unknown additional mount volumes", func(t *testing.T) {
+		// given
+		doguResource := newTestDoguResource("2.6.8-1")
+		doguResource.Spec.AdditionalMounts = []v2.DataMount{{SourceType: v2.DataSourceConfigMap, Name: "html", Volume: "unknown"}}
+		fetcherMock := newMockLocalDoguFetcher(t)
+		fetcherMock.EXPECT().FetchInstalled(ctx, cescommons.SimpleName("ldap")).Return(nil, notFoundErr)
+		repoMock := newMockLocalDoguDescriptorRepository(t)
+		repoMock.EXPECT().Get(ctx, version).Return(descriptor, nil)
+		securityMock := newMockSecurityValidator(t)
+		securityMock.EXPECT().ValidateSecurity(descriptor, doguResource).Return(assert.AnError)
+		sut := &DoguValidator{localDoguFetcher: fetcherMock, localDoguDescriptorRepo: repoMock, securityValidator: securityMock}
+
+		// when
+		_, err := sut.ValidateCreate(testCtx, doguResource)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "spec.security: Invalid value")
+		assert.ErrorContains(t, err, assert.AnError.Error())
+		assert.ErrorContains(t, err, "spec.additionalMounts: Invalid value")
+		assert.ErrorContains(t, err, "volume unknown does not exists in dogu descriptor for dogu ldap")
+	})
+	t.Run("should admit with warning if descriptor is not stored locally", func(t *testing.T) {
+		// given
+		doguResource := newTestDoguResource("2.6.8-1")
+		fetcherMock := newMockLocalDoguFetcher(t)
+		fetcherMock.EXPECT().FetchInstalled(ctx, cescommons.SimpleName("ldap")).Return(nil, notFoundErr)
+		repoMock := newMockLocalDoguDescriptorRepository(t)
+		repoMock.EXPECT().Get(ctx, version).Return(nil, notFoundErr)
+		sut := &DoguValidator{localDoguFetcher: fetcherMock, localDoguDescriptorRepo: repoMock}
+
+		// when
+		warnings, err := sut.ValidateCreate(testCtx, doguResource)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, admission.Warnings{"skipped validation against the dogu descriptor: version 2.6.8-1 is not stored locally yet and is validated by the reconcile"}, warnings)
+	})
+	t.Run("should run the checks of the spec if descriptor is not stored locally", func(t *testing.T) {
+		// given
+		doguResource := newTestDoguResource("2.6.8-1")
+		doguResource.Spec.Resources.DataVolumeSize = "a lot"
+		doguResource.Spec.Security.Capabilities.Add = []core.Capability{"NOT_A_CAPABILITY"}
+		doguResource.Spec.AdditionalMounts = []v2.DataMount{
+			{SourceType: v2.DataSourceConfigMap, Name: "html", Volume: "customhtml"},
+			{SourceType: v2.DataSourceConfigMap, Name: "html", Volume: "customhtml"},
+		}
+		fetcherMock := newMockLocalDoguFetcher(t)
+		fetcherMock.EXPECT().FetchInstalled(ctx, cescommons.SimpleName("ldap")).Return(nil, notFoundErr)
+		repoMock := newMockLocalDoguDescriptorRepository(t)
+		repoMock.EXPECT().Get(ctx, version).Return(nil, notFoundErr)
+		sut := &DoguValidator{localDoguFetcher: fetcherMock, localDoguDescriptorRepo: repoMock}
+
+		// when
+		warnings, err := sut.ValidateCreate(testCtx, doguResource)
+
+		// then
+		require.Error(t, err)
+		assert.Len(t, warnings, 1)
+		assert.ErrorContains(t, err, "spec.resources.dataVolumeSize: Invalid value: \"a lot\"")
+		assert.ErrorContains(t, err, "spec.security: Invalid value")
+		assert.ErrorContains(t, err, "NOT_A_CAPABILITY")
+		assert.ErrorContains(t, err, "spec.additionalMounts: Invalid value")
+		assert.ErrorContains(t, err, "duplicate entry")
+	})
+	t.Run("should not validate invalid security fields of the resource twice", func(t *testing.T) {
+		// given
+		doguResource := newTestDoguResource("2.6.8-1")
+		doguResource.Spec.Security.Capabilities.Add = []core.Capability{"NOT_A_CAPABILITY"}
+		fetcherMock := newMockLocalDoguFetcher(t)
+		fetcherMock.EXPECT().FetchInstalled(ctx, cescommons.SimpleName("ldap")).Return(nil, notFoundErr)
+		repoMock := newMockLocalDoguDescriptorRepository(t)
+		repoMock.EXPECT().Get(ctx, version).Return(descriptor, nil)
+		sut := &DoguValidator{localDoguFetcher: fetcherMock, localDoguDescriptorRepo: repoMock, securityValidator: newMockSecurityValidator(t)}
+
+		// when
+		_, err := sut.ValidateCreate(testCtx, doguResource)
+
+		// then
+		require.Error(t, err)
+		assert.Len(t, err.(*apierrors.StatusError).ErrStatus.Details.Causes, 1)
+		assert.ErrorContains(t, err, "NOT_A_CAPABILITY")
+	})
+	t.Run("should admit with warnings if registries are unavailable", func(t *testing.T) {
+		// given
+		doguResource := newTestDoguResource("2.6.8-1")
+		fetcherMock := newMockLocalDoguFetcher(t)
+		fetcherMock.EXPECT().FetchInstalled(ctx, cescommons.SimpleName("ldap")).Return(nil, assert.AnError)
+		repoMock := newMockLocalDoguDescriptorRepository(t)
+		repoMock.EXPECT().Get(ctx, version).Return(nil, assert.AnError)
+		sut := &DoguValidator{localDoguFetcher: fetcherMock, localDoguDescriptorRepo: repoMock}
+
+		// when
+		warnings, err := sut.ValidateCreate(testCtx, doguResource)
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, warnings, 2)
+		assert.Contains(t, warnings[0], "skipped downgrade check")
+		assert.Contains(t, warnings[1], "skipped validation against the dogu descriptor")
+	})
+}
+
+func TestDoguValidator_ValidateUpdate(t *testing.T) {
+	t.Run("should admit update without spec change", func(t *testing.T) {
+		// given
+		oldDoguResource := newTestDoguResource("latest")
+		newDoguResource := newTestDoguResource("latest")
+		newDoguResource.Finalizers = []string{"dogu-finalizer"}
+		sut := &DoguValidator{}
+
+		// when
+		_, err := sut.ValidateUpdate(testCtx, oldDoguResource, newDoguResource)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should admit update of deleted dogu", func(t *testing.T) {
+		// given
+		oldDoguResource := newTestDoguResource("2.6.8-1")
+		newDoguResource := newTestDoguResource("latest")
+		now := metav1.Now()
+		newDoguResource.DeletionTimestamp = &now
+		sut := &DoguValidator{}
+
+		// when
+		_, err := sut.ValidateUpdate(testCtx, oldDoguResource, newDoguResource)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should validate changed spec", func(t *testing.T) {
+		// given
+		oldDoguResource := newTestDoguResource("2.6.8-1")
+		newDoguResource := newTestDoguResource("latest")
+		sut := &DoguValidator{}
+
+		// when
+		_, err := sut.ValidateUpdate(testCtx, oldDoguResource, newDoguResource)
+
+		// then
+		assert.ErrorContains(t, err, "spec.version")
+	})
+}
+
+func TestDoguValidator_ValidateDelete(t *testing.T) {
+	// when
+	warnings, err := (&DoguValidator{}).ValidateDelete(testCtx, newTestDoguResource("latest"))
+
+	// then
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+}
@@ -0,0 +1,33 @@
+package webhook
+
+import (
+	cescommons "github.com/cloudogu/ces-commons-lib/dogu"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/cesregistry"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/security"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// localDoguFetcher includes functionality to search the local dogu registry for a dogu.
+type localDoguFetcher interface {
+	cesregistry.LocalDoguFetcher
+}
+
+type localDoguDescriptorRepository interface {
+	cescommons.LocalDoguDescriptorRepository
+}
+
+type remoteDoguDescriptorRepository interface {
+	cescommons.RemoteDoguDescriptorRepository
+}
+
+type securityValidator interface {
+	security.Validator
+}
+
+// basically a reimplementation of manager.Manager, but the mocks generate illegal files out of that interface
+//
+//nolint:unused
+//goland:noinspection GoUnusedType
+type ctrlManager interface {
+	manager.Manager
+}
@@ -22,3 +22,33 @@ app.kubernetes.io/managed-by: {{ .Release.Service }}
 app.kubernetes.io/name: {{ include "k8s-dogu-operator.name" . }}
 app.kubernetes.io/instance: {{ .Release.Name }}
 {{- end }}
+
+{{/* RBAC kind prefix
+The operator needs cluster-wide privileges if it watches dogus in other namespaces than its own.
+*/}}
+{{- define "k8s-dogu-operator.rbacKindPrefix" -}}
+{{- if .Values.controllerManager.env.watchNamespaces }}Cluster{{ end }}
+{{- end }}
+
+{{/* Webhook namespace selector
+The webhooks only handle dogus in the namespaces watched by the operator.
+*/}}
+{{- define "k8s-dogu-operator.webhookNamespaceSelector" -}}
+{{- $watchNamespaces := .Values.controllerManager.env.watchNamespaces | default "" | trim }}
+{{- if not $watchNamespaces }}
+namespaceSelector:
+  matchLabels:
+    kubernetes.io/metadata.name: {{ .Release.Namespace }}
+{{- else if ne $watchNamespaces "*" }}
+namespaceSelector:
+  matchExpressions:
+    - key: kubernetes.io/metadata.name
+      operator: In
+      values:
+        {{- range splitList "," $watchNamespaces }}
+        {{- if trim . }}
+        - {{ trim . }}
+        {{- end }}
+        {{- end }}
+{{- end }}
+{{- end }}
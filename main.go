@@ -23,14 +23,21 @@ import (
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/authregistration"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/cesregistry"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/config"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/coordination"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/dependency"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/drift"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/ecosystemupgrade"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/exec"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/garbagecollection"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/health"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/healthhistory"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/imageregistry"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/initfx"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/journal"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/logging"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/manager"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/metrics"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/plan"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/resource"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/security"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/serviceaccount"
@@ -38,8 +45,11 @@ import (
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps/install"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps/postinstall"
 	upgradeSteps "github.com/cloudogu/k8s-dogu-operator/v3/controllers/steps/upgrade"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/tracing"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/upgrade"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/usecase"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/volumesnapshot"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/webhook"
 	"github.com/cloudogu/k8s-registry-lib/repository"
 )
 
@@ -93,6 +103,15 @@ func options() []fx.Option {
 			// our own dependencies
 			fx.Annotate(health.NewAvailabilityChecker, fx.As(new(health.DeploymentAvailabilityChecker))),
 			fx.Annotate(health.NewDoguStatusUpdater, fx.As(new(health.DoguHealthStatusUpdater))),
+			journal.NewConfigMapJournal,
+			healthhistory.NewConfigMapHistory,
+			ecosystemupgrade.NewPlanner,
+			upgrade.NewConfigMapSnapshotStore,
+			upgrade.NewConfigMapProgressStore,
+			upgrade.NewPhaseDeadlines,
+			tracing.NewTracerProvider,
+			initfx.NewMetricsRegisterer,
+			fx.Annotate(metrics.NewPrometheusRecorder, fx.As(new(metrics.StepRecorder)), fx.As(new(metrics.RequeueRecorder))),
 			fx.Annotate(initfx.NewCollectApplier, fx.As(new(initfx.CollectApplier)), fx.As(new(resource.CollectApplier))),
 			initfx.GetAdditionalImages,
 			fx.Annotate(initfx.NewCommandExecutor, fx.As(new(exec.CommandExecutor))),
@@ -137,6 +156,7 @@ func options() []fx.Option {
 			fx.Annotate(serviceaccount.NewRemover, fx.As(new(serviceaccount.ServiceAccountRemover))),
 			fx.Annotate(authregistration.NewManager, fx.As(new(authregistration.Manager))),
 			fx.Annotate(dependency.NewCompositeDependencyValidator, fx.As(new(dependency.Validator))),
+			dependency.NewReverseDependencyValidator,
 			fx.Annotate(security.NewValidator, fx.As(new(security.Validator))),
 			fx.Annotate(additionalMount.NewValidator, fx.As(new(additionalMount.Validator))),
 			fx.Annotate(initfx.NewRemoteDoguDescriptorRepository, fx.As(new(dogu.RemoteDoguDescriptorRepository))),
@@ -148,21 +168,29 @@ func options() []fx.Option {
 			fx.Annotate(resource.NewUpserter, fx.As(new(resource.ResourceUpserter)), fx.As(new(upgradeSteps.ResourceUpserter))),
 			fx.Annotate(cesregistry.NewCESDoguRegistrator, fx.As(new(cesregistry.DoguRegistrator))),
 			fx.Annotate(initfx.NewImageRegistry, fx.As(new(imageregistry.ImageRegistry))),
+			fx.Annotate(imageregistry.NewCraneRegistryPinger, fx.As(new(imageregistry.RegistryPinger))),
 			fx.Annotate(manager.NewDoguRestartManager, fx.As(new(manager.DoguRestartManager))),
 			fx.Annotate(garbagecollection.NewDoguRestartGarbageCollector, fx.As(new(controllers.DoguRestartGarbageCollector))),
 			fx.Annotate(health.NewDoguConditionUpdater, fx.As(new(install.ConditionUpdater))),
 			fx.Annotate(health.NewDoguChecker, fx.As(new(health.DoguHealthChecker))),
+			health.NewDependentDoguMapper,
 			fx.Annotate(manager.NewDoguExportManager, fx.As(new(manager.DoguExportManager))),
 			fx.Annotate(manager.NewDoguSupportManager, fx.As(new(manager.SupportManager))),
 			fx.Annotate(manager.NewDoguAdditionalMountManager, fx.As(new(manager.AdditionalMountManager))),
 			fx.Annotate(manager.NewDeploymentManager, fx.As(new(manager.DeploymentManager))),
 			fx.Annotate(upgrade.NewChecker, fx.As(new(upgrade.Checker))),
+			plan.NewDoguPlanner,
+			drift.NewDetector,
+			volumesnapshot.NewSnapshotter,
+			coordination.NewLocker,
+			coordination.NewWaitList,
 			controllers.NewDoguEvents,
 			controllers.NewDoguEventsIn,
 			controllers.NewDoguEventsOut,
 
 			// delete steps
 			deletion.NewStatusStep,
+			deletion.NewVolumeSnapshotStep,
 			deletion.NewAuthRegistrationRemoverStep,
 			deletion.NewServiceAccountRemoverStep,
 			deletion.NewDeleteOutOfHealthConfigMapStep,
@@ -175,6 +203,7 @@ func options() []fx.Option {
 			install.NewFetchRemoteDoguDescriptorStep,
 			install.NewValidationStep,
 			install.NewPauseReconciliationStep,
+			install.NewLockDependenciesStep,
 			install.NewCreateFinalizerStep,
 			// Dogu config steps
 			fx.Annotate(
@@ -211,6 +240,7 @@ func options() []fx.Option {
 			install.NewServiceStep,
 			install.NewCreateExecPodStep,
 			install.NewCustomK8sResourceStep,
+			install.NewRestoreVolumeSnapshotStep,
 			install.NewCreateVolumeStep,
 			install.NewNetworkPoliciesStep,
 			install.NewCreateDeploymentStep,
@@ -221,16 +251,20 @@ func options() []fx.Option {
 			postinstall.NewExportModeStep,
 			postinstall.NewSupportModeStep,
 			postinstall.NewAdditionalMountsStep,
+			postinstall.NewDriftDetectionStep,
 			fx.Annotate(upgradeSteps.NewRestartAfterConfigChangeStep, fx.ParamTags(`name:"normalDoguConfig"`, `name:"sensitiveDoguConfig"`, "", "", "")),
+			upgradeSteps.NewRollbackStep,
 			upgradeSteps.NewPreUpgradeStatusStep,
+			upgradeSteps.NewUpgradeStallDetectionStep,
 			upgradeSteps.NewRegisterDoguVersionStep,
+			upgradeSteps.NewVolumeSnapshotStep,
 			upgradeSteps.NewUpdateDeploymentVersionStep,
 			upgradeSteps.NewDeleteExecPodStep,
 			upgradeSteps.NewPostUpgradeStep,
 			upgradeSteps.NewInstalledVersionStep,
 			upgradeSteps.NewRegenerateDeploymentStep,
 			upgradeSteps.NewUpdateStartedAtStep,
-			upgradeSteps.NewRetroactiveServiceAccountStep,
+			fx.Annotate(upgradeSteps.NewRetroactiveServiceAccountStep, fx.ParamTags("", "", "", "", `name:"sensitiveDoguConfig"`)),
 
 			// use-cases
 			fx.Annotate(
@@ -242,28 +276,40 @@ func options() []fx.Option {
 				usecase.NewDoguDeleteUseCase,
 				fx.As(new(controllers.DoguDeleteUseCase)),
 			),
+			// additional steps can be provided in the value group usecase.ExtensionStepGroup
 			fx.Annotate(
 				usecase.NewDoguInstallOrChangeUseCase,
+				fx.ParamTags("", "", "", `group:"doguInstallOrChangeExtensionSteps"`),
 				fx.As(new(controllers.DoguInstallOrChangeUseCase)),
 				fx.ResultTags(`name:"doguInstallOrChangeUseCase"`),
 			),
 			fx.Annotate(
 				usecase.NewDoguInstallOrChangeUseCase,
+				fx.ParamTags("", "", "", `group:"doguInstallOrChangeExtensionSteps"`),
 				fx.As(new(controllers.DoguInstallOrChangeUseCase)),
 			),
 
 			// reconcilers
-			fx.Annotate(controllers.NewDoguReconciler, fx.ParamTags("", `name:"doguInstallOrChangeUseCase"`, `name:"doguDeleteUseCase"`, "", "", "", "", "", "")),
+			fx.Annotate(controllers.NewDoguReconciler, fx.ParamTags("", `name:"doguInstallOrChangeUseCase"`, `name:"doguDeleteUseCase"`, "", "", "", "", "", "", "", "", "")),
 			controllers.NewGlobalConfigReconciler,
 			controllers.NewDoguRestartReconciler,
+			controllers.NewEcosystemUpgradeReconciler,
+
+			// webhooks
+			webhook.NewDoguValidator,
+			webhook.NewDoguDefaulter,
 
 			// runners
 			health.NewStartupHandler,
 			health.NewShutdownHandler,
 		),
+		// all K8s clients are created from the rest config, so tracing its transport traces all requests to the API server
+		fx.Decorate(initfx.DecorateRestConfigWithTracing),
 		// the empty invoke functions tell fx to instantiate these structs even if nothing depends on them.
 		// reconcilers and runners are the last in the dependency chain so we have to invoke them here.
 		fx.Invoke(
+			// readiness checks have to be added before the manager is started
+			initfx.AddReadinessChecks,
 			func(*controllers.DoguReconciler) {
 				// creates a fx dependency on the DoguReconciler
 			},
@@ -273,6 +319,15 @@ func options() []fx.Option {
 			func(*controllers.GlobalConfigReconciler) {
 				// creates a fx dependency on the GlobalConfigReconciler
 			},
+			func(*controllers.EcosystemUpgradeReconciler) {
+				// creates a fx dependency on the EcosystemUpgradeReconciler
+			},
+			func(*webhook.DoguValidator) {
+				// creates a fx dependency on the DoguValidator
+			},
+			func(*webhook.DoguDefaulter) {
+				// creates a fx dependency on the DoguDefaulter
+			},
 
 			func(*health.StartupHandler) {
 				// creates a fx dependency on the StartupHandler
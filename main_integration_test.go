@@ -84,16 +84,6 @@ var _ = Describe("Dogu Upgrade Tests", func() {
 		})
 
 		It("Should install dogu in cluster", func() {
-			By("Create dogu health state config map")
-			healthCM := &corev1.ConfigMap{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "k8s-dogu-operator-dogu-health",
-					Namespace: testNamespace,
-				},
-			}
-			_, err := k8sClientSet.CoreV1().ConfigMaps(testNamespace).Create(testCtx, healthCM, metav1.CreateOptions{})
-			Expect(err).ToNot(HaveOccurred())
-
 			By("Creating dogu resource")
 			installDoguCr(testCtx, ldapCr)
 
@@ -156,7 +146,7 @@ var _ = Describe("Dogu Upgrade Tests", func() {
 
 			By("Set pvc capacity")
 			doguPvc.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("2Gi")}
-			_, err = k8sClientSet.CoreV1().PersistentVolumeClaims(testNamespace).UpdateStatus(testCtx, doguPvc, metav1.UpdateOptions{})
+			_, err := k8sClientSet.CoreV1().PersistentVolumeClaims(testNamespace).UpdateStatus(testCtx, doguPvc, metav1.UpdateOptions{})
 			Expect(err).NotTo(HaveOccurred())
 
 			By("Set pod last starting time")
@@ -97,6 +97,7 @@ var _ = ginkgo.BeforeSuite(func() {
 		CRDDirectoryPaths: []string{
 			filepath.Join("vendor", "github.com", "cloudogu", "k8s-dogu-lib", "v2", "api", "v2"),
 			filepath.Join("testdata", "crd"),
+			filepath.Join("k8s", "helm", "crds"),
 		},
 		ErrorIfCRDPathMissing: true,
 	}